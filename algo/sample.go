@@ -0,0 +1,56 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// UidSampler keeps a uniform random sample of at most size of the uids it is given, using
+// reservoir sampling, so that the uids which aren't part of the sample are never stored. The same
+// seed gives the same sample for the same uids given in the same order.
+type UidSampler struct {
+	size int
+	seen int
+	rng  *rand.Rand
+	uids []uint64
+}
+
+// NewUidSampler returns a sampler keeping at most size uids.
+func NewUidSampler(size int, seed int64) *UidSampler {
+	return &UidSampler{size: size, rng: rand.New(rand.NewSource(seed))}
+}
+
+// Add gives the next uid to the sampler.
+func (s *UidSampler) Add(uid uint64) {
+	s.seen++
+	if len(s.uids) < s.size {
+		s.uids = append(s.uids, uid)
+		return
+	}
+	// The uid replaces one of the sample with a probability of size/seen.
+	if i := s.rng.Intn(s.seen); i < s.size {
+		s.uids[i] = uid
+	}
+}
+
+// Uids returns the sample, sorted. It holds all the uids given if there were at most size of them.
+func (s *UidSampler) Uids() []uint64 {
+	sort.Slice(s.uids, func(i, j int) bool { return s.uids[i] < s.uids[j] })
+	return s.uids
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package algo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sampleOf(n, size int, seed int64) []uint64 {
+	s := NewUidSampler(size, seed)
+	for uid := uint64(1); uid <= uint64(n); uid++ {
+		s.Add(uid)
+	}
+	return s.Uids()
+}
+
+func TestUidSampler(t *testing.T) {
+	// Fewer uids than the size of the sample.
+	require.Equal(t, []uint64{1, 2, 3}, sampleOf(3, 10, 1))
+
+	sample := sampleOf(1000, 10, 1)
+	require.Len(t, sample, 10)
+	for i := 1; i < len(sample); i++ {
+		require.Less(t, sample[i-1], sample[i])
+	}
+	// The same seed gives the same sample.
+	require.Equal(t, sample, sampleOf(1000, 10, 1))
+	require.NotEqual(t, sample, sampleOf(1000, 10, 2))
+}
+
+func TestUidSamplerUniform(t *testing.T) {
+	// Each uid should be picked about size/n of the time.
+	const n, size, runs = 20, 5, 4000
+	picked := make(map[uint64]int)
+	for seed := int64(0); seed < runs; seed++ {
+		for _, uid := range sampleOf(n, size, seed) {
+			picked[uid]++
+		}
+	}
+	for uid := uint64(1); uid <= n; uid++ {
+		require.InDelta(t, runs*size/n, picked[uid], runs*size/n/5, "uid %d", uid)
+	}
+}
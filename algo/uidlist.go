@@ -38,15 +38,21 @@ func ApplyFilter(u *pb.List, f func(uint64, int) bool) {
 }
 
 // IntersectCompressedWith intersects a packed list of UIDs with another list
-// and writes the output to o.
+// and writes the output to o. Only the UIDs greater than afterUID are part of the output.
 func IntersectCompressedWith(pack *pb.UidPack, afterUID uint64, v, o *pb.List) {
 	if pack == nil {
 		return
 	}
+	// The seek below only skips the blocks before afterUID, the intersection could still go
+	// back to them. So the UIDs up to afterUID are left out of v instead.
+	uids := v.Uids
+	if afterUID > 0 {
+		uids = uids[sort.Search(len(uids), func(i int) bool { return uids[i] > afterUID }):]
+	}
 	dec := codec.Decoder{Pack: pack}
 	dec.Seek(afterUID, codec.SeekStart)
 	n := dec.ApproxLen()
-	m := len(v.Uids)
+	m := len(uids)
 
 	if n > m {
 		n, m = m, n
@@ -61,9 +67,9 @@ func IntersectCompressedWith(pack *pb.UidPack, afterUID uint64, v, o *pb.List) {
 	// Select appropriate function based on heuristics.
 	ratio := float64(m) / float64(n)
 	if ratio < 500 {
-		IntersectCompressedWithLinJump(&dec, v.Uids, &dst)
+		IntersectCompressedWithLinJump(&dec, uids, &dst)
 	} else {
-		IntersectCompressedWithBin(&dec, v.Uids, &dst)
+		IntersectCompressedWithBin(&dec, uids, &dst)
 	}
 	o.Uids = dst
 }
@@ -325,6 +331,39 @@ func IntersectSorted(lists []*pb.List) *pb.List {
 	return out
 }
 
+// IntersectHash calculates the intersection of multiple lists by looking up the uids of the
+// smallest list in hash sets of the other ones. It is faster than IntersectSorted when the
+// lists have very different sizes and the uids of the large ones are spread apart.
+func IntersectHash(lists []*pb.List) *pb.List {
+	if len(lists) == 0 {
+		return &pb.List{}
+	}
+	smallest := 0
+	for i, list := range lists {
+		if len(list.GetUids()) < len(lists[smallest].GetUids()) {
+			smallest = i
+		}
+	}
+	out := &pb.List{Uids: append([]uint64{}, lists[smallest].GetUids()...)}
+	for i, list := range lists {
+		if i == smallest || len(out.Uids) == 0 {
+			continue
+		}
+		set := make(map[uint64]struct{}, len(list.Uids))
+		for _, uid := range list.Uids {
+			set[uid] = struct{}{}
+		}
+		res := out.Uids[:0]
+		for _, uid := range out.Uids {
+			if _, ok := set[uid]; ok {
+				res = append(res, uid)
+			}
+		}
+		out.Uids = res
+	}
+	return out
+}
+
 // Difference returns the difference of two lists.
 func Difference(u, v *pb.List) *pb.List {
 	if u == nil || v == nil {
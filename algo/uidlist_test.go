@@ -157,6 +157,21 @@ func TestIntersectSorted6(t *testing.T) {
 	require.Empty(t, IntersectSorted(input).Uids)
 }
 
+func TestIntersectHash(t *testing.T) {
+	input := []*pb.List{
+		newList([]uint64{2, 3, 4, 5, 7, 9}),
+		newList([]uint64{3, 7, 9}),
+		newList([]uint64{1, 3, 9, 10}),
+	}
+	require.Equal(t, []uint64{3, 9}, IntersectHash(input).Uids)
+	require.Equal(t, IntersectSorted(input).Uids, IntersectHash(input).Uids)
+	// The input lists aren't modified.
+	require.Equal(t, []uint64{3, 7, 9}, input[1].Uids)
+
+	require.Empty(t, IntersectHash([]*pb.List{}).Uids)
+	require.Empty(t, IntersectHash(append(input, newList(nil))).Uids)
+}
+
 func TestDiffSorted1(t *testing.T) {
 	input := []*pb.List{
 		newList([]uint64{1, 2, 3}),
@@ -522,6 +537,32 @@ func TestIntersectCompressedWithBin(t *testing.T) {
 	}
 }
 
+func TestIntersectCompressedWithAfterUid(t *testing.T) {
+	enc := codec.Encoder{BlockSize: 10}
+	for i := uint64(1); i <= 100; i++ {
+		enc.Add(i)
+	}
+	pack := enc.Done()
+	defer codec.FreePack(pack)
+
+	var v, expected []uint64
+	for i := uint64(3); i <= 100; i += 3 {
+		v = append(v, i)
+		if i > 51 {
+			expected = append(expected, i)
+		}
+	}
+	for _, after := range []uint64{51, 52} {
+		out := &pb.List{}
+		IntersectCompressedWith(pack, after, newList(v), out)
+		require.Equal(t, expected, out.Uids, "after: %d", after)
+	}
+
+	out := &pb.List{}
+	IntersectCompressedWith(pack, 0, newList(v), out)
+	require.Equal(t, v, out.Uids)
+}
+
 func TestIntersectCompressedWithBinMissingSize(t *testing.T) {
 	lengths := []int{0, 1, 3, 11, 100}
 
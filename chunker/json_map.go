@@ -0,0 +1,252 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/pkg/errors"
+)
+
+// JSONMap describes how the objects of a JSON document are converted to N-Quads, for the
+// /mutate/json-map endpoint. A map is given for the objects at the top of the document, the
+// objects nested in them are converted with the map of the field they are found in.
+//
+//	{
+//	  "key": "id",
+//	  "type": "Person",
+//	  "fields": {
+//	    "name": "Person.name",
+//	    "age": {"predicate": "Person.age", "type": "int"},
+//	    "address.city": "Person.city",
+//	    "friends": {"predicate": "Person.friends", "map": {"key": "id", "type": "Person"}}
+//	  }
+//	}
+type JSONMap struct {
+	// Key is the path of the field naming the node of an object. The objects with the same key
+	// and type are the same node, which links the objects referring to each other. A new node is
+	// created for the objects without it.
+	Key string `json:"key"`
+	// Type is the dgraph.type of the nodes, if any.
+	Type string `json:"type"`
+	// Fields maps the paths of the fields, with their names separated by dots, to the
+	// predicates they are stored in. The fields which aren't in the map are ignored.
+	Fields map[string]*JSONMapField `json:"fields"`
+}
+
+// JSONMapField describes how a field is stored. It is given as a string in the map if only the
+// predicate is set.
+type JSONMapField struct {
+	Predicate string `json:"predicate"`
+	// Type is the type the values are converted to, e.g. int or datetime. It is inferred from the
+	// JSON values if unset, as for JSON mutations.
+	Type string `json:"type"`
+	// Map converts the objects of the field to nodes linked by the predicate.
+	Map *JSONMap `json:"map"`
+}
+
+// UnmarshalJSON accepts the predicate alone as well as the whole field.
+func (f *JSONMapField) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &f.Predicate)
+	}
+	type field JSONMapField
+	return json.Unmarshal(b, (*field)(f))
+}
+
+// MapJSON converts the objects of data to N-Quads with the given map. data is either an object
+// or an array of objects. The arrays found in the fields are stored as several values of the
+// predicate, which should be a list, or as several edges for the objects.
+func MapJSON(data []byte, m *JSONMap) ([]*api.NQuad, error) {
+	if m == nil {
+		return nil, errors.New("A map is required to convert the JSON data")
+	}
+	if err := m.validate(); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, errors.Wrapf(err, "while decoding the JSON data")
+	}
+
+	var nqs []*api.NQuad
+	objs, ok := v.([]interface{})
+	if !ok {
+		objs = []interface{}{v}
+	}
+	for _, obj := range objs {
+		o, ok := obj.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("Expected an object in the JSON data, got: %v", obj)
+		}
+		if _, err := m.mapObject(o, &nqs); err != nil {
+			return nil, err
+		}
+	}
+	return nqs, nil
+}
+
+func (m *JSONMap) validate() error {
+	if len(m.Fields) == 0 {
+		return errors.New("The map should have at least one field")
+	}
+	for path, f := range m.Fields {
+		if f == nil || f.Predicate == "" {
+			return errors.Errorf("Field %s of the map doesn't have a predicate", path)
+		}
+		if f.Type != "" && f.Map != nil {
+			return errors.Errorf("Field %s of the map can't have both a type and a map", path)
+		}
+		if f.Type != "" {
+			if _, ok := types.TypeForName(f.Type); !ok {
+				return errors.Errorf("Field %s of the map has an unknown type: %s", path, f.Type)
+			}
+		}
+		if f.Map != nil {
+			if err := f.Map.validate(); err != nil {
+				return errors.Wrapf(err, "in the map of field %s", path)
+			}
+		}
+	}
+	return nil
+}
+
+// mapObject adds the N-Quads of an object, and returns the blank node of the object.
+func (m *JSONMap) mapObject(o map[string]interface{}, nqs *[]*api.NQuad) (string, error) {
+	subject := getNextBlank()
+	if m.Key != "" {
+		keys := pathValues(o, m.Key)
+		switch {
+		case len(keys) > 1:
+			return "", errors.Errorf("Key %s should have a single value, got: %v", m.Key, keys)
+		case len(keys) == 1:
+			scope := m.Type
+			if scope == "" {
+				scope = m.Key
+			}
+			subject = fmt.Sprintf("_:%s.%v", scope, keys[0])
+		}
+	}
+	if m.Type != "" {
+		*nqs = append(*nqs, &api.NQuad{
+			Subject:     subject,
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: m.Type}},
+		})
+	}
+
+	// The fields are sorted, so that the N-Quads are always in the same order.
+	paths := make([]string, 0, len(m.Fields))
+	for path := range m.Fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		f := m.Fields[path]
+		for _, v := range pathValues(o, path) {
+			nq := &api.NQuad{Subject: subject, Predicate: f.Predicate}
+			if f.Map != nil {
+				child, ok := v.(map[string]interface{})
+				if !ok {
+					return "", errors.Errorf("Field %s should be an object, got: %v", path, v)
+				}
+				id, err := f.Map.mapObject(child, nqs)
+				if err != nil {
+					return "", err
+				}
+				nq.ObjectId = id
+			} else {
+				val, err := mapValue(v, f.Type)
+				if err != nil {
+					return "", errors.Wrapf(err, "while converting field %s", path)
+				}
+				nq.ObjectValue = val
+			}
+			*nqs = append(*nqs, nq)
+		}
+	}
+	return subject, nil
+}
+
+// pathValues returns the values found at the path in o. The arrays found along the path are
+// flattened, and the null values are skipped.
+func pathValues(o interface{}, path string) []interface{} {
+	vals := []interface{}{o}
+	for _, name := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, v := range vals {
+			if obj, ok := v.(map[string]interface{}); ok {
+				next = appendValues(next, obj[name])
+			}
+		}
+		vals = next
+	}
+	return vals
+}
+
+func appendValues(vals []interface{}, v interface{}) []interface{} {
+	switch v := v.(type) {
+	case nil:
+		return vals
+	case []interface{}:
+		for _, e := range v {
+			vals = appendValues(vals, e)
+		}
+		return vals
+	default:
+		return append(vals, v)
+	}
+}
+
+// mapValue converts a JSON value to the type of the field. Without a type, the type of the value
+// is inferred as for JSON mutations.
+func mapValue(v interface{}, typ string) (*api.Value, error) {
+	if _, ok := v.(map[string]interface{}); ok {
+		return nil, errors.Errorf("Expected a value, got an object: %v. A map is needed to "+
+			"convert the objects", v)
+	}
+	if typ == "" {
+		switch v := v.(type) {
+		case string:
+			// Unlike JSON mutations, the strings aren't uid functions.
+			return &api.Value{Val: &api.Value_StrVal{StrVal: v}}, nil
+		case bool:
+			return &api.Value{Val: &api.Value_BoolVal{BoolVal: v}}, nil
+		}
+		nq := &api.NQuad{}
+		if err := handleBasicType("", v, SetNquads, nq); err != nil {
+			return nil, err
+		}
+		return nq.ObjectValue, nil
+	}
+
+	tid, _ := types.TypeForName(typ)
+	src := types.Val{Tid: types.StringID, Value: []byte(fmt.Sprint(v))}
+	dst, err := types.Convert(src, tid)
+	if err != nil {
+		return nil, err
+	}
+	return types.ObjectValue(tid, dst.Value)
+}
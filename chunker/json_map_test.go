@@ -0,0 +1,118 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func parseJSONMap(t *testing.T, s string) *JSONMap {
+	var m JSONMap
+	require.NoError(t, json.Unmarshal([]byte(s), &m))
+	return &m
+}
+
+func TestMapJSON(t *testing.T) {
+	data := `[{
+		"id": "1",
+		"name": "Alice",
+		"age": "30",
+		"tags": ["a", "b"],
+		"address": {"city": "SF"},
+		"friends": [{"id": "2", "name": "Bob"}],
+		"score": 1.5,
+		"active": true,
+		"extra": "ignored"
+	}]`
+	m := parseJSONMap(t, `{
+		"key": "id",
+		"type": "Person",
+		"fields": {
+			"name": "Person.name",
+			"age": {"predicate": "Person.age", "type": "int"},
+			"tags": "Person.tags",
+			"address.city": "Person.city",
+			"score": "Person.score",
+			"active": "Person.active",
+			"friends": {
+				"predicate": "Person.friends",
+				"map": {"key": "id", "type": "Person", "fields": {"name": "Person.name"}}
+			}
+		}
+	}`)
+
+	nqs, err := MapJSON([]byte(data), m)
+	require.NoError(t, err)
+	str := func(s string) *api.Value { return &api.Value{Val: &api.Value_StrVal{StrVal: s}} }
+	require.Equal(t, []*api.NQuad{
+		makeNquad("_:Person.1", "dgraph.type", str("Person")),
+		makeNquad("_:Person.1", "Person.active",
+			&api.Value{Val: &api.Value_BoolVal{BoolVal: true}}),
+		makeNquad("_:Person.1", "Person.city", str("SF")),
+		makeNquad("_:Person.1", "Person.age", &api.Value{Val: &api.Value_IntVal{IntVal: 30}}),
+		makeNquad("_:Person.2", "dgraph.type", str("Person")),
+		makeNquad("_:Person.2", "Person.name", str("Bob")),
+		makeNquadEdge("_:Person.1", "Person.friends", "_:Person.2"),
+		makeNquad("_:Person.1", "Person.name", str("Alice")),
+		makeNquad("_:Person.1", "Person.score",
+			&api.Value{Val: &api.Value_DoubleVal{DoubleVal: 1.5}}),
+		makeNquad("_:Person.1", "Person.tags", str("a")),
+		makeNquad("_:Person.1", "Person.tags", str("b")),
+	}, nqs)
+}
+
+func TestMapJSONWithoutKey(t *testing.T) {
+	m := parseJSONMap(t, `{"fields": {"name": "name"}}`)
+	nqs, err := MapJSON([]byte(`{"name": "Alice"}`), m)
+	require.NoError(t, err)
+	require.Len(t, nqs, 1)
+	require.True(t, strings.HasPrefix(nqs[0].Subject, "_:dg."))
+	require.Equal(t, "name", nqs[0].Predicate)
+}
+
+func TestMapJSONErrors(t *testing.T) {
+	tests := []struct {
+		data string
+		m    string
+		err  string
+	}{
+		{`{"age": "abc"}`, `{"fields": {"age": {"predicate": "age", "type": "int"}}}`,
+			"while converting field age"},
+		{`{"age": 1}`, `{"fields": {"age": {"predicate": "age", "type": "number"}}}`,
+			"Field age of the map has an unknown type: number"},
+		{`{"address": {"city": "SF"}}`, `{"fields": {"address": "address"}}`,
+			"A map is needed to convert the objects"},
+		{`{"friend": "Bob"}`,
+			`{"fields": {"friend": {"predicate": "friend", "map": {"fields": {"name": "name"}}}}}`,
+			"Field friend should be an object"},
+		{`{"id": [1, 2]}`, `{"key": "id", "fields": {"id": "id"}}`,
+			"Key id should have a single value"},
+		{`["Alice"]`, `{"fields": {"name": "name"}}`, "Expected an object in the JSON data"},
+		{`{"name": "Alice"}`, `{"fields": {"name": {"type": "string"}}}`,
+			"Field name of the map doesn't have a predicate"},
+	}
+	for _, tc := range tests {
+		_, err := MapJSON([]byte(tc.data), parseJSONMap(t, tc.m))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
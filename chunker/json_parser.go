@@ -179,6 +179,14 @@ func parseScalarFacets(m map[string]interface{}, prefix string) ([]*api.Facet, e
 		}
 
 		key := fname[len(prefix):]
+		if list, ok := facetVal.([]interface{}); ok {
+			fs, err := handleListFacetsType(key, list)
+			if err != nil {
+				return nil, errors.Wrapf(err, "facet: %s", fname)
+			}
+			facetsForPred = append(facetsForPred, fs...)
+			continue
+		}
 		facet, err := handleBasicFacetsType(key, facetVal)
 		if err != nil {
 			return nil, errors.Wrapf(err, "facet: %s", fname)
@@ -189,6 +197,27 @@ func parseScalarFacets(m map[string]interface{}, prefix string) ([]*api.Facet, e
 	return facetsForPred, nil
 }
 
+// handleListFacetsType parses the values of a list facet. Each value is returned as a separate
+// facet with the given key.
+func handleListFacetsType(key string, list []interface{}) ([]*api.Facet, error) {
+	if len(list) == 0 {
+		return nil, errors.Errorf("list facets can not be empty.")
+	}
+	fs := make([]*api.Facet, 0, len(list))
+	for _, v := range list {
+		facet, err := handleBasicFacetsType(key, v)
+		if err != nil {
+			return nil, err
+		}
+		if len(fs) > 0 && fs[0].ValType != facet.ValType {
+			return nil, errors.Errorf("all the values of a list facet must be of the same type.")
+		}
+		fs = append(fs, facet)
+	}
+	facets.MarkList(fs)
+	return fs, nil
+}
+
 // This is the response for a map[string]interface{} i.e. a struct.
 type mapResponse struct {
 	uid       string       // uid retrieved or allocated for the node.
@@ -546,6 +575,13 @@ func (buf *NQuadBuffer) mapToNquads(m map[string]interface{}, op int, parentPred
 				continue
 			}
 
+			if isUUIDMarker(v) {
+				nq.ObjectId = UUIDObject
+				buf.Push(&nq)
+				buf.PushPredHint(pred, pb.Metadata_SINGLE)
+				continue
+			}
+
 			ok, err := handleGeoType(v, &nq)
 			if err != nil {
 				return mr, err
@@ -627,7 +663,13 @@ func (buf *NQuadBuffer) mapToNquads(m map[string]interface{}, op int, parentPred
 					nq.Facets = fts
 					buf.Push(&nq)
 				case map[string]interface{}:
-					// map[string]interface{} can mean geojson or a connecting entity.
+					// map[string]interface{} can mean a uuid() marker, geojson or a connecting
+					// entity.
+					if isUUIDMarker(iv) {
+						nq.ObjectId = UUIDObject
+						buf.Push(&nq)
+						continue
+					}
 					ok, err := handleGeoType(item.(map[string]interface{}), &nq)
 					if err != nil {
 						return mr, err
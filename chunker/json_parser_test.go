@@ -31,6 +31,7 @@ import (
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/stretchr/testify/require"
 )
 
@@ -712,6 +713,49 @@ func TestNquadsFromJsonFacets2(t *testing.T) {
 	checkCount(t, fastNQ, "friend", 1)
 }
 
+func TestNquadsFromJsonListFacets(t *testing.T) {
+	json := `[{"name":"Alice","friend":[{"name":"Dave","friend|tags":["work","college"]}]}]`
+
+	work, err := facets.FacetFor("tags", `"work"`)
+	require.NoError(t, err)
+	college, err := facets.FacetFor("tags", `"college"`)
+	require.NoError(t, err)
+	facets.MarkList([]*api.Facet{work, college})
+
+	for _, parse := range []func([]byte, int) ([]*api.NQuad, error){Parse, FastParse} {
+		nqs, err := parse([]byte(json), SetNquads)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(nqs))
+		for _, nq := range nqs {
+			if nq.Predicate == "friend" {
+				require.Equal(t, []*api.Facet{work, college}, nq.Facets)
+			}
+		}
+	}
+
+	// A list of a single value is kept as a list.
+	nqs, err := Parse([]byte(`[{"name":"Alice","friend":[{"name":"Dave","friend|tags":["work"]}]}]`),
+		SetNquads)
+	require.NoError(t, err)
+	for _, nq := range nqs {
+		if nq.Predicate == "friend" {
+			require.Len(t, nq.Facets, 1)
+			require.True(t, facets.IsList(nq.Facets[0]))
+		}
+	}
+
+	for _, json := range []string{
+		`[{"name":"Alice","friend":[{"name":"Dave","friend|tags":[]}]}]`,
+		`[{"name":"Alice","friend":[{"name":"Dave","friend|tags":["work",1]}]}]`,
+		`[{"name":"Alice","friend":[{"name":"Dave","friend|tags":[["work"]]}]}]`,
+	} {
+		_, err := Parse([]byte(json), SetNquads)
+		require.Error(t, err)
+		_, err = FastParse([]byte(json), SetNquads)
+		require.Error(t, err)
+	}
+}
+
 // Test valid facets json.
 func TestNquadsFromJsonFacets3(t *testing.T) {
 	json := `
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/pkg/errors"
+)
+
+const keyNodePrefix = "_:("
+
+// KeyValue is a value of a @key predicate identifying a key node.
+type KeyValue struct {
+	Predicate string
+	Value     string
+}
+
+// IsKeyNode returns true if the blank node is identified by the value of a @key predicate, like
+// _:(email="a@b.com"). A key node is the node having this value if there is one, and a new node
+// otherwise.
+func IsKeyNode(name string) bool {
+	return strings.HasPrefix(name, keyNodePrefix)
+}
+
+// ParseKeyNode returns the key of a key node and its canonical name, in which the value is quoted
+// the same way. The values of each @key predicate are unique, so a node is identified by a single
+// one of them and giving several, like _:(email="a@b.com", tenant="acme"), is an error.
+func ParseKeyNode(name string) (KeyValue, string, error) {
+	if !IsKeyNode(name) || !strings.HasSuffix(name, ")") {
+		return KeyValue{}, "", errors.Errorf("Invalid key node %s. Expected "+
+			"_:(predicate=\"value\")", name)
+	}
+	rest := strings.TrimSpace(name[len(keyNodePrefix) : len(name)-1])
+	eq := strings.IndexByte(rest, '=')
+	if eq < 0 {
+		return KeyValue{}, "", errors.Errorf("Expected predicate=\"value\" in key node %s", name)
+	}
+	pred := strings.TrimSpace(rest[:eq])
+	if strings.HasPrefix(pred, "<") && strings.HasSuffix(pred, ">") {
+		pred = pred[1 : len(pred)-1]
+	}
+	if pred == "" {
+		return KeyValue{}, "", errors.Errorf("Missing predicate in key node %s", name)
+	}
+
+	rest = strings.TrimSpace(rest[eq+1:])
+	end := quotedEnd(rest)
+	if end < 0 {
+		return KeyValue{}, "", errors.Errorf("Expected a quoted value for predicate %s in key "+
+			"node %s", pred, name)
+	}
+	val, err := strconv.Unquote(rest[:end])
+	if err != nil {
+		return KeyValue{}, "", errors.Errorf("Invalid value %s for predicate %s in key node %s",
+			rest[:end], pred, name)
+	}
+	rest = strings.TrimSpace(rest[end:])
+	switch {
+	case strings.HasPrefix(rest, ","):
+		return KeyValue{}, "", errors.Errorf("Key node %s is given several predicates, but a "+
+			"node is identified by the value of a single @key predicate", name)
+	case rest != "":
+		return KeyValue{}, "", errors.Errorf("Expected ) after the value of predicate %s in "+
+			"key node %s", pred, name)
+	}
+	return KeyValue{Predicate: pred, Value: val},
+		keyNodePrefix + pred + "=" + strconv.Quote(val) + ")", nil
+}
+
+// quotedEnd returns the index following the closing quote of the quoted string at the start of
+// s, or -1 if s doesn't start with one.
+func quotedEnd(s string) int {
+	if len(s) == 0 || s[0] != '"' {
+		return -1
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// FillKeyNodes replaces the key nodes of the N-Quads with their canonical names, so that the
+// different ways of writing a key node refer to the same node. It returns the N-Quads setting
+// the values of the keys, once for every key node, so that a key node created by a mutation can
+// be found by its key afterwards.
+func FillKeyNodes(nqs []*api.NQuad) ([]*api.NQuad, error) {
+	type nsNode struct {
+		ns   uint64
+		name string
+	}
+	var keyNQs []*api.NQuad
+	seen := make(map[nsNode]struct{})
+	err := canonicalKeyNodes(nqs, func(key KeyValue, name string, ns uint64) {
+		if _, ok := seen[nsNode{ns, name}]; ok {
+			return
+		}
+		seen[nsNode{ns, name}] = struct{}{}
+		keyNQs = append(keyNQs, &api.NQuad{
+			Subject:     name,
+			Predicate:   key.Predicate,
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: key.Value}},
+			Namespace:   ns,
+		})
+	})
+	return keyNQs, err
+}
+
+// CanonicalKeyNodes replaces the key nodes of the N-Quads with their canonical names, as
+// FillKeyNodes does, for the N-Quads which don't set the keys, e.g. those of a deletion.
+func CanonicalKeyNodes(nqs []*api.NQuad) error {
+	return canonicalKeyNodes(nqs, func(KeyValue, string, uint64) {})
+}
+
+// canonicalKeyNodes replaces the key nodes of the N-Quads with their canonical names, calling fn
+// for each of them.
+func canonicalKeyNodes(nqs []*api.NQuad, fn func(key KeyValue, name string, ns uint64)) error {
+	canonical := func(id string, ns uint64) (string, error) {
+		if !IsKeyNode(id) {
+			return id, nil
+		}
+		key, name, err := ParseKeyNode(id)
+		if err != nil {
+			return "", err
+		}
+		fn(key, name, ns)
+		return name, nil
+	}
+
+	for _, nq := range nqs {
+		var err error
+		if nq.Subject, err = canonical(nq.Subject, nq.Namespace); err != nil {
+			return err
+		}
+		if nq.ObjectId, err = canonical(nq.ObjectId, nq.Namespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
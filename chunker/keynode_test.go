@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyNode(t *testing.T) {
+	key, name, err := ParseKeyNode(`_:( <email> = "a@b.com" )`)
+	require.NoError(t, err)
+	require.Equal(t, KeyValue{"email", "a@b.com"}, key)
+	require.Equal(t, `_:(email="a@b.com")`, name)
+
+	key, name, err = ParseKeyNode(`_:(name="a \"quoted\", name")`)
+	require.NoError(t, err)
+	require.Equal(t, KeyValue{"name", `a "quoted", name`}, key)
+	require.Equal(t, `_:(name="a \"quoted\", name")`, name)
+
+	for _, id := range []string{
+		`_:(email)`,
+		`_:(="a@b.com")`,
+		`_:(email=a@b.com)`,
+		`_:(email="a@b.com" tenant="acme")`,
+		`_:(email="a@b.com", email="c@d.com")`,
+		`_:(email="a@b.com", tenant="acme")`,
+		`_:(email="a@b.com"`,
+	} {
+		_, _, err := ParseKeyNode(id)
+		require.Error(t, err, id)
+	}
+}
+
+func TestFillKeyNodes(t *testing.T) {
+	nqs, _, err := ParseRDFs([]byte(`
+		_:(email="a@b.com") <name> "a" .
+		_:( email = "a@b.com" ) <friend> _:(email="c@d.com") .
+		_:b <friend> _:(<email>="c@d.com") .`))
+	require.NoError(t, err)
+	require.Len(t, nqs, 3)
+
+	keyNQs, err := FillKeyNodes(nqs)
+	require.NoError(t, err)
+	require.Equal(t, `_:(email="a@b.com")`, nqs[0].Subject)
+	require.Equal(t, `_:(email="a@b.com")`, nqs[1].Subject)
+	require.Equal(t, `_:(email="c@d.com")`, nqs[1].ObjectId)
+	require.Equal(t, "_:b", nqs[2].Subject)
+	require.Equal(t, `_:(email="c@d.com")`, nqs[2].ObjectId)
+
+	str := func(s string) *api.Value { return &api.Value{Val: &api.Value_StrVal{StrVal: s}} }
+	require.Equal(t, []*api.NQuad{
+		{Subject: `_:(email="a@b.com")`, Predicate: "email", ObjectValue: str("a@b.com")},
+		{Subject: `_:(email="c@d.com")`, Predicate: "email", ObjectValue: str("c@d.com")},
+	}, keyNQs)
+
+	_, err = FillKeyNodes([]*api.NQuad{{Subject: `_:(email=1)`, Predicate: "name"}})
+	require.Error(t, err)
+}
+
+func TestCanonicalKeyNodes(t *testing.T) {
+	nqs := []*api.NQuad{
+		{Subject: `_:( email = "a@b.com" )`, Predicate: "name"},
+		{Subject: "_:b", Predicate: "friend", ObjectId: `_:(<email>="c@d.com")`},
+	}
+	require.NoError(t, CanonicalKeyNodes(nqs))
+	require.Equal(t, `_:(email="a@b.com")`, nqs[0].Subject)
+	require.Equal(t, "_:b", nqs[1].Subject)
+	require.Equal(t, `_:(email="c@d.com")`, nqs[1].ObjectId)
+
+	require.Error(t, CanonicalKeyNodes([]*api.NQuad{{Subject: `_:(email="a", tenant="b")`}}))
+}
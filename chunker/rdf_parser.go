@@ -72,6 +72,29 @@ func ParseRDFs(b []byte) ([]*api.NQuad, *pb.Metadata, error) {
 	return nqs, calculateTypeHints(nqs), nil
 }
 
+// ParseRDFsWithIncrements is like ParseRDFs, but it also accepts the increments of set mutations,
+// like <0x1> <views> += "1" . The increments are returned apart from the other N-Quads.
+func ParseRDFsWithIncrements(b []byte) ([]*api.NQuad, []*api.NQuad, *pb.Metadata, error) {
+	var nqs, incrs []*api.NQuad
+	var l lex.Lexer
+	for _, line := range bytes.Split(b, []byte{'\n'}) {
+		nq, incr, err := parseRDF(string(line), &l)
+		switch {
+		case err == ErrEmpty:
+			continue
+		case err != nil:
+			return nil, nil, nil, err
+		case incr:
+			incrs = append(incrs, &nq)
+		default:
+			nqs = append(nqs, &nq)
+		}
+	}
+
+	// The increments don't make a predicate a list, even if a node is incremented several times.
+	return nqs, incrs, calculateTypeHints(nqs), nil
+}
+
 func isSpaceRune(r rune) bool {
 	return r == ' '
 }
@@ -79,16 +102,27 @@ func isSpaceRune(r rune) bool {
 // ParseRDF parses a mutation string and returns the N-Quad representation for it.
 // It parses N-Quad statements based on http://www.w3.org/TR/n-quads/.
 func ParseRDF(line string, l *lex.Lexer) (api.NQuad, error) {
+	rnq, incr, err := parseRDF(line, l)
+	if err == nil && incr {
+		return rnq, errors.Errorf("Increments can only be used in set mutations. Input: [%s]", line)
+	}
+	return rnq, err
+}
+
+// parseRDF parses an N-Quad as ParseRDF does, and also accepts increments, returning true if the
+// N-Quad is one.
+func parseRDF(line string, l *lex.Lexer) (api.NQuad, bool, error) {
 	var rnq api.NQuad
+	var incr bool
 	line = strings.TrimSpace(line)
 	if len(line) == 0 {
-		return rnq, ErrEmpty
+		return rnq, incr, ErrEmpty
 	}
 
 	l.Reset(line)
 	l.Run(lexText)
 	if err := l.ValidateResult(); err != nil {
-		return rnq, err
+		return rnq, incr, err
 	}
 	it := l.NewIterator()
 	var oval string
@@ -106,13 +140,13 @@ L:
 		case itemSubjectFunc:
 			var err error
 			if rnq.Subject, err = parseFunction(it); err != nil {
-				return rnq, err
+				return rnq, incr, err
 			}
 
 		case itemObjectFunc:
 			var err error
 			if rnq.ObjectId, err = parseFunction(it); err != nil {
-				return rnq, err
+				return rnq, incr, err
 			}
 
 		case itemPredicate:
@@ -136,7 +170,7 @@ L:
 			var err error
 			oval, err = strconv.Unquote(item.Val)
 			if err != nil {
-				return rnq, errors.Wrapf(err, "while unquoting")
+				return rnq, incr, errors.Wrapf(err, "while unquoting")
 			}
 			seenOval = true
 
@@ -145,21 +179,21 @@ L:
 
 		case itemObjectType:
 			if rnq.Predicate == x.Star || rnq.Subject == x.Star {
-				return rnq, errors.Errorf("If predicate/subject is *, value should be * as well")
+				return rnq, incr, errors.Errorf("If predicate/subject is *, value should be * as well")
 			}
 
 			val := strings.TrimFunc(item.Val, isSpaceRune)
 			// TODO: Check if this condition is required.
 			if val == "*" {
-				return rnq, errors.Errorf("itemObject can't be *")
+				return rnq, incr, errors.Errorf("itemObject can't be *")
 			}
 			// Lets find out the storage type from the type map.
 			t, ok := typeMap[val]
 			if !ok {
-				return rnq, errors.Errorf("Unrecognized rdf type %s", val)
+				return rnq, incr, errors.Errorf("Unrecognized rdf type %s", val)
 			}
 			if oval == "" && t != types.StringID {
-				return rnq, errors.Errorf("Invalid ObjectValue")
+				return rnq, incr, errors.Errorf("Invalid ObjectValue")
 			}
 			src := types.ValueForType(types.StringID)
 			src.Value = []byte(oval)
@@ -167,13 +201,21 @@ L:
 			if t == types.PasswordID {
 				src.Tid = t
 			}
+			// base64Binary values are stored decoded, once enabled by --limit "binary-base64".
+			if t == types.BinaryID && x.Config.BinaryBase64 {
+				b, err := types.DecodeBinary(oval)
+				if err != nil {
+					return rnq, incr, err
+				}
+				src = types.Val{Tid: t, Value: b}
+			}
 			p, err := types.Convert(src, t)
 			if err != nil {
-				return rnq, err
+				return rnq, incr, err
 			}
 
 			if rnq.ObjectValue, err = types.ObjectValue(t, p.Value); err != nil {
-				return rnq, err
+				return rnq, incr, err
 			}
 		case itemComment:
 			isCommentLine = true
@@ -182,13 +224,13 @@ L:
 		case itemValidEnd:
 			vend = true
 			if !it.Next() {
-				return rnq, errors.Errorf("Invalid end of input. Input: [%s]", line)
+				return rnq, incr, errors.Errorf("Invalid end of input. Input: [%s]", line)
 			}
 			// RDF spec says N-Quads should be terminated with a newline. Since we break the input
 			// by newline already. We should get EOF or # after dot(.)
 			item = it.Item()
 			if !(item.Typ == lex.ItemEOF || item.Typ == itemComment) {
-				return rnq, errors.Errorf("Invalid end of input. Expected newline or # after ."+
+				return rnq, incr, errors.Errorf("Invalid end of input. Expected newline or # after ."+
 					" Input: [%s]", line)
 			}
 			break L
@@ -197,23 +239,26 @@ L:
 			s := strings.TrimFunc(item.Val, isSpaceRune)
 			namespace, err := strconv.ParseUint(s, 0, 64)
 			if err != nil {
-				return rnq, errors.Errorf("Invalid namespace ID. Input: [%s]", line)
+				return rnq, incr, errors.Errorf("Invalid namespace ID. Input: [%s]", line)
 			}
 			rnq.Namespace = namespace
 
 		case itemLeftRound:
 			it.Prev() // backup '('
 			if err := parseFacetsRDF(it, &rnq); err != nil {
-				return rnq, errors.Wrap(err, "could not parse facet")
+				return rnq, incr, errors.Wrap(err, "could not parse facet")
 			}
+
+		case itemIncrement:
+			incr = true
 		}
 	}
 
 	if !vend {
-		return rnq, errors.Errorf("Invalid end of input. Input: [%s]", line)
+		return rnq, incr, errors.Errorf("Invalid end of input. Input: [%s]", line)
 	}
 	if isCommentLine {
-		return rnq, ErrEmpty
+		return rnq, incr, ErrEmpty
 	}
 	// We only want to set default value if we have seen ObjectValue within "" and if we didn't
 	// already set it.
@@ -221,16 +266,48 @@ L:
 		rnq.ObjectValue = &api.Value{Val: &api.Value_DefaultVal{DefaultVal: oval}}
 	}
 	if len(rnq.Subject) == 0 || len(rnq.Predicate) == 0 {
-		return rnq, errors.Errorf("Empty required fields in NQuad. Input: [%s]", line)
+		return rnq, incr, errors.Errorf("Empty required fields in NQuad. Input: [%s]", line)
 	}
 	if len(rnq.ObjectId) == 0 && rnq.ObjectValue == nil {
-		return rnq, errors.Errorf("No Object in NQuad. Input: [%s]", line)
+		return rnq, incr, errors.Errorf("No Object in NQuad. Input: [%s]", line)
 	}
 	if !sane(rnq.Subject) || !sane(rnq.Predicate) || !sane(rnq.ObjectId) {
-		return rnq, errors.Errorf("NQuad failed sanity check:%+v", rnq)
+		return rnq, incr, errors.Errorf("NQuad failed sanity check:%+v", rnq)
 	}
+	if incr {
+		if err := toIncrement(&rnq); err != nil {
+			return rnq, incr, errors.Wrapf(err, "Input: [%s]", line)
+		}
+	}
+
+	return rnq, incr, nil
+}
 
-	return rnq, nil
+// toIncrement checks that the object of an increment is a number, and converts it to an int or
+// a float if it doesn't have a type, so that a new predicate is created with that type.
+func toIncrement(nq *api.NQuad) error {
+	switch {
+	case nq.ObjectId != "" || nq.ObjectValue == nil:
+		return errors.Errorf("The object of an increment must be a number")
+	case len(nq.Facets) > 0:
+		return errors.Errorf("An increment can't have facets")
+	}
+	switch v := nq.ObjectValue.Val.(type) {
+	case *api.Value_IntVal, *api.Value_DoubleVal:
+		return nil
+	case *api.Value_DefaultVal:
+		if i, err := strconv.ParseInt(v.DefaultVal, 10, 64); err == nil {
+			nq.ObjectValue = &api.Value{Val: &api.Value_IntVal{IntVal: i}}
+			return nil
+		}
+		if f, err := strconv.ParseFloat(v.DefaultVal, 64); err == nil {
+			nq.ObjectValue = &api.Value{Val: &api.Value_DoubleVal{DoubleVal: f}}
+			return nil
+		}
+		return errors.Errorf("The object of an increment must be a number, got %q", v.DefaultVal)
+	default:
+		return errors.Errorf("The object of an increment must be an int or a float")
+	}
 }
 
 // parseFunction parses uid(<var name>) and returns
@@ -245,6 +322,12 @@ func parseFunction(it *lex.ItemIterator) (string, error) {
 	}
 
 	it.Next()
+	if s == uuidFunc {
+		if item = it.Item(); item.Typ != itemRightRound {
+			return "", errors.Errorf("Expected ')', found: %s", item.Val)
+		}
+		return s + "()", nil
+	}
 	if item = it.Item(); item.Typ != itemVarName {
 		return "", errors.Errorf("Expected variable name, found: %s", item.Val)
 	}
@@ -270,6 +353,7 @@ func parseFacetsRDF(it *lex.ItemIterator, rnq *api.NQuad) error {
 		return errors.Errorf("Expected '(' but found %v at Facet.", item.Val)
 	}
 
+	seen := make(map[string]bool)
 	for it.Next() { // parse one key value pair
 		// parse key
 		item = it.Item()
@@ -280,6 +364,10 @@ func parseFacetsRDF(it *lex.ItemIterator, rnq *api.NQuad) error {
 		if len(facetKey) == 0 {
 			return errors.Errorf("Empty facetKeys not allowed.")
 		}
+		if seen[facetKey] {
+			return errors.Errorf("Repeated keys are not allowed in facets. But got %s", facetKey)
+		}
+		seen[facetKey] = true
 		// parse =
 		if !it.Next() {
 			return errors.Errorf("Unexpected end of facets.")
@@ -293,6 +381,24 @@ func parseFacetsRDF(it *lex.ItemIterator, rnq *api.NQuad) error {
 			return errors.Errorf("Unexpected end of facets.")
 		}
 		item = it.Item()
+		if item.Typ == itemLeftSquare {
+			list, err := parseFacetListRDF(it, facetKey)
+			if err != nil {
+				return err
+			}
+			rnq.Facets = append(rnq.Facets, list...)
+			if !it.Next() { // get either ')' or ','
+				return errors.Errorf("Unexpected end of facets.")
+			}
+			item = it.Item()
+			if item.Typ == itemRightRound {
+				break
+			}
+			if item.Typ == itemComma {
+				continue
+			}
+			return errors.Errorf("Expected , or ) after facet. Received %s", item.Val)
+		}
 		facetVal := ""
 		if item.Typ == itemText {
 			facetVal = item.Val
@@ -330,6 +436,43 @@ func parseFacetsRDF(it *lex.ItemIterator, rnq *api.NQuad) error {
 	return nil
 }
 
+// parseFacetListRDF parses the values of a list facet, e.g. tags=["work", "college"]. Each value
+// is returned as a separate facet with the given key.
+func parseFacetListRDF(it *lex.ItemIterator, facetKey string) ([]*api.Facet, error) {
+	var list []*api.Facet
+	expectVal := true
+	for it.Next() {
+		item := it.Item()
+		switch {
+		case item.Typ == itemRightSquare:
+			if len(list) == 0 {
+				return nil, errors.Errorf("Empty list not allowed for facet %s.", facetKey)
+			}
+			if expectVal {
+				return nil, errors.Errorf("Expected value after , in list facet %s.", facetKey)
+			}
+			facets.MarkList(list)
+			return list, nil
+		case item.Typ == itemComma && !expectVal:
+			expectVal = true
+		case item.Typ == itemText && expectVal:
+			facet, err := facets.FacetFor(facetKey, item.Val)
+			if err != nil {
+				return nil, err
+			}
+			if len(list) > 0 && list[0].ValType != facet.ValType {
+				return nil, errors.Errorf("All the values of list facet %s must be of the "+
+					"same type.", facetKey)
+			}
+			list = append(list, facet)
+			expectVal = false
+		default:
+			return nil, errors.Errorf("Unexpected %s in list facet %s.", item.Val, facetKey)
+		}
+	}
+	return nil, errors.Errorf("Unexpected end of list facet %s.", facetKey)
+}
+
 // subjectPred is a type to store the count for each <subject, pred> in the  mutations.
 type subjectPred struct {
 	subject string
@@ -363,6 +506,7 @@ var typeMap = map[string]types.TypeID{
 	"xs:boolean":         types.BoolID,
 	"xs:double":          types.FloatID,
 	"xs:float":           types.FloatID,
+	"xs:decimal":         types.DecimalID,
 	"xs:base64Binary":    types.BinaryID,
 	"geo:geojson":        types.GeoID,
 	"http://www.w3.org/2001/XMLSchema#string":          types.StringID,
@@ -374,6 +518,7 @@ var typeMap = map[string]types.TypeID{
 	"http://www.w3.org/2001/XMLSchema#boolean":         types.BoolID,
 	"http://www.w3.org/2001/XMLSchema#double":          types.FloatID,
 	"http://www.w3.org/2001/XMLSchema#float":           types.FloatID,
+	"http://www.w3.org/2001/XMLSchema#decimal":         types.DecimalID,
 	"http://www.w3.org/2001/XMLSchema#gYear":           types.DateTimeID,
 	"http://www.w3.org/2001/XMLSchema#gYearMonth":      types.DateTimeID,
 }
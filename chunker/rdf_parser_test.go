@@ -847,7 +847,81 @@ var testNQuads = []struct {
 			},
 		},
 	},
+	// List facets.
+	{
+		input: `_:alice <friend> _:bob (tags=["work", "college"],since=12) .`,
+		nq: api.NQuad{
+			Subject:   "_:alice",
+			Predicate: "friend",
+			ObjectId:  "_:bob",
+			Facets: []*api.Facet{
+				{
+					Key:     "tags",
+					Value:   []byte("work"),
+					ValType: facets.ValTypeForTypeID(facets.StringID),
+					Tokens:  []string{"\000list", "\001work"},
+				},
+				{
+					Key:     "tags",
+					Value:   []byte("college"),
+					ValType: facets.ValTypeForTypeID(facets.StringID),
+					Tokens:  []string{"\000list", "\001college"},
+				},
+				{
+					Key:     "since",
+					Value:   []byte("\014\000\000\000\000\000\000\000"),
+					ValType: facets.ValTypeForTypeID(facets.IntID),
+					Tokens:  nil,
+				},
+			},
+		},
+	},
+	// A list of a single value is kept as a list.
+	{
+		input: `_:alice <friend> _:bob (rating=[3]) .`,
+		nq: api.NQuad{
+			Subject:   "_:alice",
+			Predicate: "friend",
+			ObjectId:  "_:bob",
+			Facets: []*api.Facet{
+				{
+					Key:     "rating",
+					Value:   []byte("\003\000\000\000\000\000\000\000"),
+					ValType: facets.ValTypeForTypeID(facets.IntID),
+					Tokens:  []string{"\000list"},
+				},
+			},
+		},
+	},
 	// failing tests for facets
+	{
+		input:       `_:alice <friend> _:bob (tags=[]) .`,
+		expectedErr: true, // lists can not be empty
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags=["work", 12]) .`,
+		expectedErr: true, // all the values of a list must be of the same type
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags=["work",]) .`,
+		expectedErr: true, // comma should be followed by another value
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags=["work" "college"]) .`,
+		expectedErr: true, // values should be separated by commas
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags=["work") .`,
+		expectedErr: true, // lists should end by ']'
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags="work",tags="college") .`,
+		expectedErr: true, // keys can not be repeated
+	},
+	{
+		input:       `_:alice <friend> _:bob (tags=["work"],tags="college") .`,
+		expectedErr: true, // keys can not be repeated, even by a list
+	},
 	{
 		input:       `_:alice <knows> "stuff" (key1="val1",key2) .`,
 		expectedErr: true, // should fail because of no '=' after key2
@@ -974,6 +1048,37 @@ var testNQuads = []struct {
 		},
 		expectedErr: false,
 	},
+	{
+		input: `_:a <id> uuid() .`,
+		nq: api.NQuad{
+			Subject:   "_:a",
+			Predicate: "id",
+			ObjectId:  "uuid()",
+		},
+		expectedErr: false,
+	},
+	{
+		input: `uid(v) <id> uuid( ) .`,
+		nq: api.NQuad{
+			Subject:   "uid(v)",
+			Predicate: "id",
+			ObjectId:  "uuid()",
+		},
+		expectedErr: false,
+	},
+	{
+		input:       `uuid() <id> "a" .`,
+		expectedErr: true,
+	},
+	{
+		// Increments are only accepted in the set mutations of alpha.
+		input:       `<0x1> <views> += "1" .`,
+		expectedErr: true,
+	},
+	{
+		input:       `_:a <id> uuid(v) .`,
+		expectedErr: true,
+	},
 	{
 		input:       `uid  (  val   <lives> uid ( g )  .`,
 		expectedErr: true,
@@ -1022,3 +1127,64 @@ func TestLex(t *testing.T) {
 		}
 	}
 }
+
+func TestParseBinary(t *testing.T) {
+	defer func(enabled bool) { x.Config.BinaryBase64 = enabled }(x.Config.BinaryBase64)
+	l := &lex.Lexer{}
+	parse := func(input string) (api.NQuad, error) {
+		l.Reset(input)
+		return ParseRDF(input, l)
+	}
+	value := func(b []byte) *api.Value {
+		return &api.Value{Val: &api.Value_BytesVal{BytesVal: b}}
+	}
+
+	// Without binary-base64, the text is kept as is.
+	x.Config.BinaryBase64 = false
+	nq, err := parse(`_:alice <thumbnail> "AAH/"^^<xs:base64Binary> .`)
+	require.NoError(t, err)
+	require.Equal(t, value([]byte("AAH/")), nq.ObjectValue)
+
+	x.Config.BinaryBase64 = true
+	nq, err = parse(`_:alice <thumbnail> "AAH/"^^<xs:base64Binary> .`)
+	require.NoError(t, err)
+	require.Equal(t, value([]byte{0, 1, 0xff}), nq.ObjectValue)
+	_, err = parse(`_:alice <thumbnail> "not base64!"^^<xs:base64Binary> .`)
+	require.Error(t, err)
+}
+
+func TestParseRDFsWithIncrements(t *testing.T) {
+	nqs, incrs, md, err := ParseRDFsWithIncrements([]byte(`
+		<0x1> <name> "a" .
+		<0x1> <views> += "1" .
+		uid(v) <views> +=   "-2" .
+		<0x1> <score> += "0.5"^^<xs:float> .
+		<0x1> <views> += "1.5" .
+	`))
+	require.NoError(t, err)
+	require.Len(t, nqs, 1)
+	require.Equal(t, []*api.NQuad{
+		{Subject: "0x1", Predicate: "views",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: 1}}},
+		{Subject: "uid(v)", Predicate: "views",
+			ObjectValue: &api.Value{Val: &api.Value_IntVal{IntVal: -2}}},
+		{Subject: "0x1", Predicate: "score",
+			ObjectValue: &api.Value{Val: &api.Value_DoubleVal{DoubleVal: 0.5}}},
+		{Subject: "0x1", Predicate: "views",
+			ObjectValue: &api.Value{Val: &api.Value_DoubleVal{DoubleVal: 1.5}}},
+	}, incrs)
+	// Incrementing a node several times doesn't make the predicate a list.
+	require.Empty(t, md.PredHints)
+
+	for _, input := range []string{
+		`<0x1> <views> += "a" .`,
+		`<0x1> <views> += <0x2> .`,
+		`<0x1> <views> += "true"^^<xs:boolean> .`,
+		`<0x1> <views> += "1" (since=2021) .`,
+		`<0x1> += <views> "1" .`,
+		`<0x1> <views> +"1" .`,
+	} {
+		_, _, _, err := ParseRDFsWithIncrements([]byte(input))
+		require.Error(t, err, "Expected error for input: %q", input)
+	}
+}
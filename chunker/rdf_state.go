@@ -42,6 +42,9 @@ const (
 	itemSubjectFunc                         // uid, 20
 	itemObjectFunc                          // uid, 21
 	itemVarName                             // 22
+	itemLeftSquare                          // '[', 23
+	itemRightSquare                         // ']', 24
+	itemIncrement                           // '+=', 25
 )
 
 // These constants keep a track of the depth while parsing an rdf N-Quad.
@@ -54,19 +57,22 @@ const (
 )
 
 const (
-	lsThan     = '<'
-	underscore = '_'
-	colon      = ':'
-	dash       = '-'
-	quote      = '"'
-	hash       = '#'
-	dot        = '.'
-	at         = '@'
-	caret      = '^'
-	leftRound  = '('
-	rightRound = ')'
-	comma      = ','
-	equal      = '='
+	lsThan      = '<'
+	underscore  = '_'
+	colon       = ':'
+	dash        = '-'
+	quote       = '"'
+	hash        = '#'
+	dot         = '.'
+	at          = '@'
+	caret       = '^'
+	leftRound   = '('
+	rightRound  = ')'
+	comma       = ','
+	equal       = '='
+	leftSquare  = '['
+	rightSquare = ']'
+	plus        = '+'
 )
 
 // This function inspects the next rune and calls the appropriate stateFn.
@@ -108,6 +114,16 @@ func lexText(l *lex.Lexer) lex.StateFn {
 			l.Emit(itemText)
 			return lexObject
 
+		case r == plus:
+			// An increment, e.g. <0x1> <views> += "1" .
+			if l.Depth != atObject {
+				return l.Errorf("Invalid input: %c at lexText", r)
+			}
+			if r = l.Next(); r != equal {
+				return l.Errorf("Expected '=' after '+', found: '%c'", r)
+			}
+			l.Emit(itemIncrement)
+
 		case r == hash:
 			if l.Depth != atSubject {
 				return l.Errorf("Invalid input: %c at lexText", r)
@@ -136,7 +152,7 @@ func lexText(l *lex.Lexer) lex.StateFn {
 				l.Depth = atSubject
 			}
 
-		// This should happen when there is either UID or Val function.
+		// This should happen when there is either UID, UUID or Val function.
 		// Hence, we are just checking for u or v
 		case r == 'u' || r == 'v':
 			if l.Depth != atSubject && l.Depth != atObject {
@@ -196,6 +212,9 @@ func lexBlankNode(l *lex.Lexer, styp lex.ItemType,
 	if r == lex.EOF {
 		return l.Errorf("Unexpected end of subject")
 	}
+	if r == leftRound {
+		return lexKeyNode(l, styp, sfn)
+	}
 	if !(isPNCharsU(r) || (r >= '0' && r <= '9')) {
 		return l.Errorf("Invalid character in %v after _: , Got '%c'", styp, r)
 	}
@@ -219,6 +238,34 @@ func lexBlankNode(l *lex.Lexer, styp lex.ItemType,
 	return l.Errorf("Invalid character '%c' found for itemType: %v", r, styp)
 }
 
+// lexKeyNode lexes a key blank node, like _:(email="a@b.com"), up to the ')' following the
+// quoted values. Assumes that caller has consumed '_:('. The keys are parsed when the uids of
+// the blank nodes are assigned.
+func lexKeyNode(l *lex.Lexer, styp lex.ItemType, sfn lex.StateFn) lex.StateFn {
+	inQuote := false
+	for {
+		r := l.Next()
+		switch {
+		case r == lex.EOF:
+			return l.Errorf("Unclosed ( in key node of %v", styp)
+		case inQuote && r == '\\':
+			l.Next()
+		case r == quote:
+			inQuote = !inQuote
+		case !inQuote && r == rightRound:
+			r = l.Peek()
+			if r == lex.EOF {
+				return l.Errorf("Unexpected end of %v", styp)
+			}
+			if !isSpace(r) {
+				return l.Errorf("Invalid character '%c' found after key node of %v", r, styp)
+			}
+			l.Emit(styp)
+			return sfn
+		}
+	}
+}
+
 func lexSubject(l *lex.Lexer) lex.StateFn {
 	r := l.Next()
 	// The subject is an IRI, so we lex till we encounter '>'.
@@ -364,7 +411,8 @@ func lexLabel(l *lex.Lexer) lex.StateFn {
 
 // lexFacets parses key-value pairs of Facets. sample is :
 // ( key1 = "value1", key2=13, key3=, key4 =2.4, key5=2006-01-02T15:04:05,
-//   key6=2006-01-02 )
+//
+//	key6=2006-01-02, key7=["a", "b"] )
 func lexFacets(l *lex.Lexer) lex.StateFn {
 	r := l.Next()
 	if r != leftRound {
@@ -386,6 +434,10 @@ forLoop:
 			l.Emit(itemEqual)
 		case r == comma:
 			l.Emit(itemComma)
+		case r == leftSquare:
+			l.Emit(itemLeftSquare)
+		case r == rightSquare:
+			l.Emit(itemRightSquare)
 		case r == rightRound:
 			l.Emit(itemRightRound)
 			break forLoop
@@ -399,7 +451,8 @@ forLoop:
 			l.Emit(itemText)
 		default:
 			l.AcceptRun(func(r rune) bool {
-				return r != equal && !isSpace(r) && r != rightRound && r != comma
+				return r != equal && !isSpace(r) && r != rightRound && r != comma &&
+					r != rightSquare
 			})
 			l.Emit(itemText)
 		}
@@ -427,6 +480,8 @@ func lexVariable(l *lex.Lexer) lex.StateFn {
 	functionName := "uid"
 	if r = l.Next(); r == 'v' {
 		functionName = "val"
+	} else if l.Peek() == 'u' {
+		functionName = "uuid"
 	}
 	l.Backup()
 
@@ -450,6 +505,19 @@ func lexVariable(l *lex.Lexer) lex.StateFn {
 	l.Emit(itemLeftRound)
 	l.IgnoreRun(isSpace)
 
+	// uuid() doesn't take any arguments, and generates a value for the object.
+	if functionName == "uuid" {
+		if l.Depth != atObject {
+			return l.Errorf("uuid() can only be used as the object")
+		}
+		if r = l.Next(); r != ')' {
+			return l.Errorf("Expected ')' after uuid(, found: '%c'", r)
+		}
+		l.Emit(itemRightRound)
+		l.Depth++
+		return lexText
+	}
+
 	// TODO(Aman): we support all characters in variable names except space and
 	// right bracket. we should support only limited characters in variable names.
 	// For now, this is fine because variables names must be used once in query
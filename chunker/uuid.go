@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const uuidFunc = "uuid"
+
+// UUIDObject is the object of the N-Quads whose value is generated by the uuid() function, e.g.
+// _:a <id> uuid() . in RDF or {"id": {"dgraph.uuid": true}} in JSON.
+const UUIDObject = uuidFunc + "()"
+
+// UUIDMarker is the key of the JSON object that asks for a generated UUID. A plain string value
+// like "uuid()" is stored as is, so the marker has to be explicit. Keys starting with dgraph. are
+// reserved, so the marker doesn't clash with a nested node.
+const UUIDMarker = "dgraph.uuid"
+
+// isUUIDMarker returns true if the JSON object is {"dgraph.uuid": true}.
+func isUUIDMarker(m map[string]interface{}) bool {
+	if len(m) != 1 {
+		return false
+	}
+	v, ok := m[UUIDMarker].(bool)
+	return ok && v
+}
+
+// FillUUIDs replaces the uuid() objects in the given N-Quads with random (version 4) UUIDs. A new
+// UUID is generated for each N-Quad every time this is called, so a retried mutation stores a
+// different UUID than the failed attempt. UUIDs are random, and their uniqueness isn't checked.
+func FillUUIDs(nqs []*api.NQuad) error {
+	for _, nq := range nqs {
+		if nq.ObjectId != UUIDObject {
+			continue
+		}
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return errors.Wrapf(err, "while generating UUID for predicate %s", nq.Predicate)
+		}
+		nq.ObjectId = ""
+		nq.ObjectValue = &api.Value{Val: &api.Value_StrVal{StrVal: id.String()}}
+	}
+	return nil
+}
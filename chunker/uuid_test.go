@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chunker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillUUIDs(t *testing.T) {
+	nqs, _, err := ParseRDFs([]byte(`
+		_:a <id> uuid() .
+		_:b <id> uuid() .
+		_:b <friend> _:a .`))
+	require.NoError(t, err)
+	json, _, err := ParseJSON([]byte(`{"uid": "_:c", "id": {"dgraph.uuid": true}, "name": "uuid"}`),
+		SetNquads)
+	require.NoError(t, err)
+	nqs = append(nqs, json...)
+
+	require.NoError(t, FillUUIDs(nqs))
+	seen := make(map[string]bool)
+	for _, nq := range nqs {
+		switch nq.Predicate {
+		case "id":
+			require.Empty(t, nq.ObjectId)
+			id, err := uuid.Parse(nq.ObjectValue.GetStrVal())
+			require.NoError(t, err)
+			require.Equal(t, uuid.Version(4), id.Version())
+			require.False(t, seen[id.String()])
+			seen[id.String()] = true
+		case "friend":
+			require.Equal(t, "_:a", nq.ObjectId)
+		case "name":
+			require.Equal(t, &api.Value{Val: &api.Value_StrVal{StrVal: "uuid"}}, nq.ObjectValue)
+		}
+	}
+	require.Len(t, seen, 3)
+}
+
+func TestUUIDStringRoundTrips(t *testing.T) {
+	// Only the explicit marker generates a UUID, the string "uuid()" is a plain value.
+	nqs, _, err := ParseJSON([]byte(`{"uid": "_:a", "id": "uuid()", "ids": ["uuid()"],
+		"code": "uuid( )"}`), SetNquads)
+	require.NoError(t, err)
+	require.Len(t, nqs, 3)
+	require.NoError(t, FillUUIDs(nqs))
+	for _, nq := range nqs {
+		require.Empty(t, nq.ObjectId)
+		require.Equal(t, stripSpaces(nq.ObjectValue.GetStrVal()), UUIDObject)
+	}
+
+	nqs, _, err = ParseJSON([]byte(`{"uid": "_:a", "ids": [{"dgraph.uuid": true}],
+		"other": {"dgraph.uuid": false}}`), SetNquads)
+	require.NoError(t, err)
+	for _, nq := range nqs {
+		if nq.Predicate == "ids" {
+			require.Equal(t, UUIDObject, nq.ObjectId)
+		} else {
+			require.NotEqual(t, UUIDObject, nq.ObjectId)
+		}
+	}
+}
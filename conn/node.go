@@ -653,6 +653,20 @@ func (n *Node) WaitLinearizableRead(ctx context.Context) error {
 	span := otrace.FromContext(ctx)
 	span.Annotate(nil, "WaitLinearizableRead")
 
+	index, err := n.ReadIndex(ctx)
+	if err != nil {
+		return err
+	}
+	err = n.Applied.WaitForMark(ctx, index)
+	span.Annotatef(nil, "Error from Applied.WaitForMark: %v", err)
+	return err
+}
+
+// ReadIndex returns the commit index of the leader, as confirmed by a quorum of the group. The
+// reads are linearizable once the entries up to it have been applied.
+func (n *Node) ReadIndex(ctx context.Context) (uint64, error) {
+	span := otrace.FromContext(ctx)
+
 	if num := atomic.AddUint64(&readIndexTotal, 1); num%1000 == 0 {
 		glog.V(2).Infof("ReadIndex Total: %d\n", num)
 	}
@@ -662,23 +676,21 @@ func (n *Node) WaitLinearizableRead(ctx context.Context) error {
 		span.Annotate(nil, "Pushed to requestCh")
 	case <-ctx.Done():
 		span.Annotate(nil, "Context expired")
-		return ctx.Err()
+		return 0, ctx.Err()
 	}
 
 	select {
 	case index := <-indexCh:
 		span.Annotatef(nil, "Received index: %d", index)
 		if index == 0 {
-			return errReadIndex
+			return 0, errReadIndex
 		} else if num := atomic.AddUint64(&readIndexOk, 1); num%1000 == 0 {
 			glog.V(2).Infof("ReadIndex OK: %d\n", num)
 		}
-		err := n.Applied.WaitForMark(ctx, index)
-		span.Annotatef(nil, "Error from Applied.WaitForMark: %v", err)
-		return err
+		return index, nil
 	case <-ctx.Done():
 		span.Annotate(nil, "Context expired")
-		return ctx.Err()
+		return 0, ctx.Err()
 	}
 }
 
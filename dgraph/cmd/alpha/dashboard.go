@@ -70,8 +70,10 @@ func keywordHandler(w http.ResponseWriter, r *http.Request) {
 		"as",
 		"avg",
 		"ceil",
+		"coalesce",
 		"cond",
 		"contains",
+		"cosine",
 		"count",
 		"delete",
 		"eq",
@@ -86,6 +88,8 @@ func keywordHandler(w http.ResponseWriter, r *http.Request) {
 		"gt",
 		"index",
 		"intersects",
+		"jaro",
+		"jarowinkler",
 		"le",
 		"len",
 		"ln",
@@ -114,6 +118,7 @@ func keywordHandler(w http.ResponseWriter, r *http.Request) {
 		"tokenizer",
 		"type",
 		"uid",
+		"undirected",
 		"within",
 		"upsert",
 	}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"mime"
 	"net/http"
 	"sort"
@@ -35,10 +36,12 @@ import (
 	"github.com/dgraph-io/dgraph/graphql/admin"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/edgraph"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/query"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/golang/glog"
@@ -168,6 +171,57 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 		return
 	}
+	readTs, err := parseUint64(r, "readTs")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if readTs != 0 && startTs != 0 {
+		x.SetStatus(w, x.ErrorInvalidRequest, "Only one of startTs and readTs can be set")
+		return
+	}
+	maxStaleness, err := parseDuration(r, "maxStaleness")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if maxStaleness < 0 {
+		x.SetStatus(w, x.ErrorInvalidRequest, "maxStaleness can't be negative")
+		return
+	}
+	if maxStaleness != 0 && (readTs != 0 || startTs != 0) {
+		x.SetStatus(w, x.ErrorInvalidRequest,
+			"maxStaleness can't be set along with startTs or readTs")
+		return
+	}
+	groupTimeout, err := parseDuration(r, "groupTimeout")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if groupTimeout < 0 {
+		x.SetStatus(w, x.ErrorInvalidRequest, "groupTimeout can't be negative")
+		return
+	}
+	var renames query.FieldRenames
+	if rename := r.URL.Query().Get("rename"); rename != "" {
+		if renames, err = query.ParseFieldRenames(rename); err != nil {
+			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+			return
+		}
+	}
+	// With strict-uids, the uids of the uid function which don't have any data are listed in
+	// the extensions of the response.
+	strictUids, err := parseBool(r, "strict-uids")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	isolation, err := worker.ParseIsolation(r.URL.Query().Get("isolation"))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 
 	body := readRequest(w, r)
 	if body == nil {
@@ -206,8 +260,33 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ctx := context.WithValue(r.Context(), query.DebugKey, isDebugMode)
+	msgpack := acceptsMsgpack(r)
+	if msgpack {
+		ctx = context.WithValue(ctx, query.MsgpackKey, true)
+	}
+	if len(renames) > 0 {
+		ctx = context.WithValue(ctx, query.FieldRenamesKey, renames)
+	}
+	truncated := &query.Truncated{}
+	ctx = context.WithValue(ctx, query.TruncatedKey, truncated)
+	var missingUids *query.MissingUids
+	if strictUids {
+		missingUids = &query.MissingUids{}
+		ctx = context.WithValue(ctx, query.StrictUidsKey, missingUids)
+	}
 	ctx = x.AttachAccessJwt(ctx, r)
 	ctx = x.AttachRemoteIP(ctx, r)
+	if readTs != 0 {
+		ctx = context.WithValue(ctx, edgraph.ReadTs, readTs)
+	}
+	if maxStaleness != 0 {
+		ctx = context.WithValue(ctx, edgraph.MaxStaleness, maxStaleness)
+	}
+	var partial *worker.PartialResults
+	if groupTimeout != 0 {
+		ctx, partial = worker.WithGroupTimeout(ctx, groupTimeout)
+	}
+	ctx = context.WithValue(ctx, edgraph.Isolation, isolation)
 
 	if queryTimeout != 0 {
 		var cancel context.CancelFunc
@@ -222,7 +301,7 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		Hash:    hash,
 	}
 
-	if req.StartTs == 0 {
+	if req.StartTs == 0 && readTs == 0 {
 		// If be is set, run this as a best-effort query.
 		isBestEffort, err := parseBool(r, "be")
 		if err != nil {
@@ -240,7 +319,7 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 			x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
 			return
 		}
-		if isReadOnly {
+		if isReadOnly || maxStaleness != 0 {
 			req.ReadOnly = true
 		}
 	}
@@ -259,12 +338,33 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 		Latency: resp.Latency,
 		Metrics: resp.Metrics,
 	}
+	if missingUids != nil && missingUids.Total > 0 {
+		e.MissingUids = strings.Split(missingUids.String(), ",")
+		e.MissingUidsTotal = missingUids.Total
+	}
+	e.Truncated = truncated.Blocks
+	if partial != nil {
+		e.IncompleteGroups = partial.TimedOutGroups()
+	}
 	js, err := json.Marshal(e)
 	if err != nil {
 		x.SetStatusWithData(w, x.Error, err.Error())
 		return
 	}
 
+	if msgpack {
+		ext, err := query.JSONToMsgpack(js)
+		if err != nil {
+			x.SetStatusWithData(w, x.Error, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", msgpackContentType)
+		if _, err := x.WriteResponse(w, r, query.MsgpackResponse(resp.Json, ext)); err != nil {
+			glog.Errorln("Unable to write response: ", err)
+		}
+		return
+	}
+
 	var out bytes.Buffer
 	writeEntry := func(key string, js []byte) {
 		x.Check2(out.WriteRune('"'))
@@ -286,6 +386,34 @@ func queryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+const msgpackContentType = "application/msgpack"
+
+// acceptsMsgpack returns true if the client prefers the results to be encoded in MessagePack, i.e.
+// if application/msgpack is in the Accept header with a quality at least as high as the one of
+// application/json. Errors are still returned in JSON.
+func acceptsMsgpack(r *http.Request) bool {
+	var msgpackQ, jsonQ float64
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		switch mediaType {
+		case msgpackContentType, "application/x-msgpack":
+			msgpackQ = math.Max(msgpackQ, q)
+		case "application/json":
+			jsonQ = math.Max(jsonQ, q)
+		}
+	}
+	return msgpackQ > 0 && msgpackQ >= jsonQ
+}
+
 func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	if commonHandler(w, r) {
 		return
@@ -410,8 +538,25 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	req.StartTs = startTs
 	req.Hash = hash
 	req.CommitNow = commitNow
+	handleMutation(w, r, req, parseEnd.Sub(parseStart))
+}
 
+// handleMutation runs the mutations of req, and writes the response of a mutation request.
+func handleMutation(w http.ResponseWriter, r *http.Request, req *api.Request,
+	parsing time.Duration) {
+	duplicates, err := worker.ParseDuplicates(r.URL.Query().Get("duplicates"))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	isolation, err := worker.ParseIsolation(r.URL.Query().Get("isolation"))
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
 	ctx := x.AttachAccessJwt(context.Background(), r)
+	ctx = context.WithValue(ctx, edgraph.Duplicates, duplicates)
+	ctx = context.WithValue(ctx, edgraph.Isolation, isolation)
 	resp, err := (&edgraph.Server{}).Query(ctx, req)
 	if err != nil {
 		x.SetStatusWithData(w, x.ErrorInvalidRequest, err.Error())
@@ -420,7 +565,7 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	// Add cost to the header.
 	w.Header().Set(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
 
-	resp.Latency.ParsingNs = uint64(parseEnd.Sub(parseStart).Nanoseconds())
+	resp.Latency.ParsingNs = uint64(parsing.Nanoseconds())
 	e := query.Extensions{
 		Txn:     resp.Txn,
 		Latency: resp.Latency,
@@ -451,6 +596,62 @@ func mutationHandler(w http.ResponseWriter, r *http.Request) {
 	_, _ = x.WriteResponse(w, r, js)
 }
 
+// jsonMapHandler converts the JSON data of the request to N-Quads with the map of the request,
+// see chunker.JSONMap, and runs them as a set mutation. The body of the request is
+// {"data": <object or array of objects>, "map": <map>}.
+func jsonMapHandler(w http.ResponseWriter, r *http.Request) {
+	if commonHandler(w, r) {
+		return
+	}
+
+	commitNow, err := parseBool(r, "commitNow")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	startTs, err := parseUint64(r, "startTs")
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	body := readRequest(w, r)
+	if body == nil {
+		return
+	}
+
+	parseStart := time.Now()
+	var params struct {
+		Data json.RawMessage  `json:"data"`
+		Map  *chunker.JSONMap `json:"map"`
+	}
+	if err := json.Unmarshal(body, &params); err != nil {
+		jsonErr := convertJSONError(string(body), err)
+		x.SetStatus(w, x.ErrorInvalidRequest, jsonErr.Error())
+		return
+	}
+	if len(params.Data) == 0 {
+		x.SetStatus(w, x.ErrorInvalidRequest, "The request should have the data to convert")
+		return
+	}
+	nqs, err := chunker.MapJSON(params.Data, params.Map)
+	if err != nil {
+		x.SetStatus(w, x.ErrorInvalidRequest, err.Error())
+		return
+	}
+	if len(nqs) == 0 {
+		x.SetStatus(w, x.ErrorInvalidRequest, "The map didn't produce any N-Quad from the data")
+		return
+	}
+
+	req := &api.Request{
+		Mutations: []*api.Mutation{{Set: nqs}},
+		StartTs:   startTs,
+		Hash:      r.URL.Query().Get("hash"),
+		CommitNow: commitNow,
+	}
+	handleMutation(w, r, req, time.Since(parseStart))
+}
+
 func commitHandler(w http.ResponseWriter, r *http.Request) {
 	if commonHandler(w, r) {
 		return
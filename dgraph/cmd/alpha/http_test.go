@@ -33,6 +33,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/query"
@@ -163,11 +164,14 @@ func queryWithGz(queryText, contentType, debug, timeout string, gzReq, gzResp bo
 }
 
 type queryInp struct {
-	body  string
-	typ   string
-	debug string
-	ts    uint64
-	hash  string
+	body   string
+	typ    string
+	debug  string
+	ts     uint64
+	hash   string
+	readTs uint64
+	// maxStaleness is a duration, e.g. 500ms.
+	maxStaleness string
 }
 
 type tsInfo struct {
@@ -190,6 +194,12 @@ func queryWithTsForResp(inp queryInp) (string, *tsInfo, *http.Response, error) {
 		params = append(params, fmt.Sprintf("startTs=%v", strconv.FormatUint(inp.ts, 10)))
 		params = append(params, fmt.Sprintf("hash=%s", inp.hash))
 	}
+	if inp.readTs != 0 {
+		params = append(params, fmt.Sprintf("readTs=%d", inp.readTs))
+	}
+	if inp.maxStaleness != "" {
+		params = append(params, "maxStaleness="+inp.maxStaleness)
+	}
 	url := addr + "/query?" + strings.Join(params, "&")
 
 	_, body, resp, err := runWithRetriesForResp("POST", inp.typ, url, inp.body)
@@ -451,6 +461,120 @@ func TestTransactionBasic(t *testing.T) {
 	require.Equal(t, `{"data":{"balances":[{"name":"Bob","balance":"110"}]}}`, data)
 }
 
+func TestQueryAtReadTs(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`name: string @index(exact) .`))
+
+	q1 := `
+	{
+	  q(func: eq(name, "Alice")) {
+	    name
+	    balance
+	  }
+	}
+	`
+	m1 := `
+	{
+	  set {
+		_:alice <name> "Alice" .
+		_:alice <balance> "100" .
+	  }
+	}
+	`
+	_, err := mutationWithTs(mutationInp{body: m1, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	data, tsInfo, err := queryWithTs(queryInp{body: q1, typ: "application/dql"})
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{"q":[{"name":"Alice","balance":"100"}]}}`, data)
+	readTs := tsInfo.ts
+
+	m2 := `
+	{
+	  set {
+		_:bob <name> "Alice" .
+		_:bob <balance> "200" .
+	  }
+	}
+	`
+	_, err = mutationWithTs(mutationInp{body: m2, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	// Queries at readTs don't see the writes committed after it.
+	for i := 0; i < 2; i++ {
+		data, tsInfo, err = queryWithTs(queryInp{body: q1, typ: "application/dql", readTs: readTs})
+		require.NoError(t, err)
+		require.Equal(t, `{"data":{"q":[{"name":"Alice","balance":"100"}]}}`, data)
+		require.Equal(t, readTs, tsInfo.ts)
+	}
+
+	_, _, err = queryWithTs(queryInp{body: q1, typ: "application/dql", readTs: readTs + 1000000})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is ahead of the latest committed timestamp")
+}
+
+func TestQueryMaxStaleness(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`name: string @index(exact) .`))
+
+	q1 := `
+	{
+	  q(func: eq(name, "Alice")) {
+	    name
+	  }
+	}
+	`
+	m1 := `
+	{
+	  set {
+		_:alice <name> "Alice" .
+	  }
+	}
+	`
+	mr, err := mutationWithTs(mutationInp{body: m1, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	// The Alpha is up to date with the commits, so it can read them without asking Zero for a
+	// timestamp.
+	data, tsInfo, err := queryWithTs(queryInp{body: q1, typ: "application/dql",
+		maxStaleness: "500ms"})
+	require.NoError(t, err)
+	require.Equal(t, `{"data":{"q":[{"name":"Alice"}]}}`, data)
+	require.Greater(t, tsInfo.ts, mr.startTs)
+
+	_, _, err = queryWithTs(queryInp{body: q1, typ: "application/dql", maxStaleness: "-1s"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maxStaleness can't be negative")
+	_, _, err = queryWithTs(queryInp{body: q1, typ: "application/dql", maxStaleness: "1s",
+		readTs: tsInfo.ts})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maxStaleness can't be set along with startTs or readTs")
+}
+
+func TestQueryStrictUids(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, runMutation(`{ set { <0x1> <name> "Alice" . } }`))
+
+	q := `{ q(func: uid(0x1, 0xdead)) @filter(NOT uid(0xbeef)) { name } }`
+	_, body, err := runWithRetries("POST", "application/dql", addr+"/query?strict-uids=true", q)
+	require.NoError(t, err)
+	var r res
+	require.NoError(t, json.Unmarshal(body, &r))
+	require.JSONEq(t, `{"q": [{"name": "Alice"}]}`, string(r.Data))
+	require.Equal(t, []string{"0xbeef", "0xdead"}, r.Extensions.MissingUids)
+	require.Equal(t, 2, r.Extensions.MissingUidsTotal)
+	// The missing uids aren't counted as touched uids.
+	require.NotContains(t, r.Extensions.Metrics.NumUids, "_missing_uids")
+
+	// Without strict-uids, they aren't looked for.
+	_, body, err = runWithRetries("POST", "application/dql", addr+"/query", q)
+	require.NoError(t, err)
+	r = res{}
+	require.NoError(t, json.Unmarshal(body, &r))
+	require.Empty(t, r.Extensions.MissingUids)
+	require.Zero(t, r.Extensions.MissingUidsTotal)
+}
+
 func TestTransactionBasicNoPreds(t *testing.T) {
 	require.NoError(t, dropAll())
 	require.NoError(t, alterSchema(`name: string @index(term) .`))
@@ -954,6 +1078,68 @@ func TestContentTypeCharset(t *testing.T) {
 	require.True(t, err != nil && strings.Contains(err.Error(), "Unsupported charset"))
 }
 
+func TestQueryMsgpack(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+		name: string @lang .
+		age: int .
+		score: float .
+		dob: dateTime .
+		friend: [uid] .`))
+
+	m := `
+	{
+	  set {
+		_:alice <name> "Alice" .
+		_:alice <name> "Alicia"@es .
+		_:alice <age> "30" .
+		_:alice <score> "-1.5" .
+		_:alice <dob> "1990-01-01T00:00:00Z" .
+		_:alice <friend> _:bob (since=2006) .
+		_:bob <name> "Bob" .
+		_:bob <age> "300" .
+	  }
+	}`
+	_, err := mutationWithTs(mutationInp{body: m, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	q := `
+	{
+	  me(func: eq(name, "Alice")) {
+	    name
+	    name@es
+	    age
+	    score
+	    dob
+	    friend @facets {
+	      name
+	      age
+	    }
+	    count(friend)
+	  }
+	  none(func: eq(name, "Nobody")) {
+	    name
+	  }
+	}`
+	expected, _, err := queryWithTs(queryInp{body: q, typ: "application/dql"})
+	require.NoError(t, err)
+
+	req, err := createRequest("POST", "application/dql", addr+"/query", q)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "application/json;q=0.5, application/msgpack")
+	_, body, resp, err := runRequest(req)
+	require.NoError(t, err)
+	require.Equal(t, "application/msgpack", resp.Header.Get("Content-Type"))
+
+	var js bytes.Buffer
+	_, err = msgp.UnmarshalAsJSON(&js, body)
+	require.NoError(t, err)
+	var r res
+	require.NoError(t, json.Unmarshal(js.Bytes(), &r))
+	require.NotNil(t, r.Extensions)
+	require.JSONEq(t, expected, `{"data": `+string(r.Data)+`}`)
+}
+
 func TestQueryBackwardCompatibleWithGraphqlPlusMinusHeader(t *testing.T) {
 	require.NoError(t, dropAll())
 	require.NoError(t, alterSchema(`name: string @index(term) .`))
@@ -987,3 +1173,57 @@ func TestQueryBackwardCompatibleWithGraphqlPlusMinusHeader(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "2", resp.Header.Get(x.DgraphCostHeader))
 }
+
+func TestJSONMapMutation(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+		jm.name: string @index(exact) .
+		jm.age: int .
+		jm.tags: [string] .
+		jm.friend: [uid] .
+	`))
+
+	body := `{
+		"data": [
+			{"id": "1", "name": "Alice", "age": "30", "tags": ["a", "b"],
+				"friends": [{"id": "2"}]},
+			{"id": "2", "name": "Bob", "age": 25}
+		],
+		"map": {
+			"key": "id",
+			"fields": {
+				"name": "jm.name",
+				"age": {"predicate": "jm.age", "type": "int"},
+				"tags": "jm.tags",
+				"friends": {"predicate": "jm.friend", "map": {"key": "id", "fields": {}}}
+			}
+		}
+	}`
+	// The map of the friends needs a field.
+	_, _, err := runWithRetries("POST", "application/json",
+		addr+"/mutate/json-map?commitNow=true", body)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "The map should have at least one field")
+
+	body = strings.Replace(body, `"fields": {}`, `"fields": {"id": "jm.id"}`, 1)
+	_, _, err = runWithRetries("POST", "application/json",
+		addr+"/mutate/json-map?commitNow=true", body)
+	require.NoError(t, err)
+
+	q := `
+	{
+	  q(func: eq(jm.name, "Alice")) {
+	    jm.name
+	    jm.age
+	    jm.tags
+	    jm.friend {
+	      jm.name
+	      jm.age
+	    }
+	  }
+	}`
+	data, _, err := queryWithTs(queryInp{body: q, typ: "application/dql"})
+	require.NoError(t, err)
+	testutil.CompareJSON(t, `{"data": {"q": [{"jm.name": "Alice", "jm.age": 30,
+		"jm.tags": ["a", "b"], "jm.friend": [{"jm.name": "Bob", "jm.age": 25}]}]}}`, data)
+}
@@ -207,3 +207,66 @@ func TestMutationsStrict(t *testing.T) {
 	t.Run("allow group2 mutate group2 predicate in strict mutations mode",
 		runOn(conn2, mutateExistingAllowed2))
 }
+
+func TestPredicatesPerMutation(t *testing.T) {
+	conn, err := grpc.Dial(testutil.ContainerAddr("alpha4", 9080), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	dg := dgo.NewDgraphClient(api.NewDgraphClient(conn))
+	ctx := context.Background()
+
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`
+			_:a <ppm_one> "one" .
+			_:a <ppm_two> "two" .
+			_:a <ppm_three> "three" .
+		`),
+		CommitNow: true,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Mutation would create more than 2 new predicates")
+
+	// The predicates which already exist don't count.
+	require.NoError(t, dg.Alter(ctx, &api.Operation{Schema: `ppm_one: string .`}))
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`
+			_:a <ppm_one> "one" .
+			_:a <ppm_two> "two" .
+			_:a <ppm_three> "three" .
+		`),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+}
+
+func TestUpsertMatchLimit(t *testing.T) {
+	conn, err := grpc.Dial(testutil.ContainerAddr("alpha4", 9080), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+	dg := dgo.NewDgraphClient(api.NewDgraphClient(conn))
+	ctx := context.Background()
+
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{
+		SetNquads: []byte(`
+			_:a <um_name> "a" .
+			_:b <um_name> "b" .
+			_:c <um_name> "c" .
+		`),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+
+	upsert := func(query string) error {
+		_, err := dg.NewTxn().Do(ctx, &api.Request{
+			Query:     query,
+			Mutations: []*api.Mutation{{SetNquads: []byte(`uid(v) <um_seen> "true" .`)}},
+			CommitNow: true,
+		})
+		return err
+	}
+	err = upsert(`{ v as var(func: has(um_name)) }`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Upsert variable [v] matches 3 uids, more than the limit of 2")
+
+	require.NoError(t, upsert(`{ v as var(func: has(um_name), first: 2) }`))
+}
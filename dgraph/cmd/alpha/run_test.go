@@ -42,11 +42,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
 	"github.com/twpayne/go-geom"
 	"github.com/twpayne/go-geom/encoding/geojson"
 	"github.com/twpayne/go-geom/encoding/wkb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 )
 
 type defaultContextKey int
@@ -1283,6 +1285,355 @@ func TestDeleteValueLang(t *testing.T) {
 	require.JSONEq(t, output, `{"data": {"me":[{"name@en":"Mark", "name@es":"Marco"}]}}`)
 }
 
+func TestDerivedPredicate(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`
+		firstName: string .
+		lastName: string .
+		fullName: string @index(exact) @derived(firstName + " " + lastName) .
+	`))
+
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <firstName> "Alice" .
+		<0x1001> <lastName> "Smith" .
+		<0x1002> <firstName> "Bob" .
+	  }
+	}`))
+
+	q := `
+	{
+	  me(func: uid(0x1001, 0x1002)) {
+		fullName
+	  }
+	}`
+	output, err := runGraphqlQuery(q)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"me":[{"fullName":"Alice Smith"}]}}`, output)
+
+	// Updating a source recomputes the derived predicate and its index.
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <firstName> "Alicia" .
+		<0x1002> <lastName> "Jones" .
+	  }
+	}`))
+	output, err = runGraphqlQuery(q)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"data": {"me":[{"fullName":"Alicia Smith"}, {"fullName":"Bob Jones"}]}}`, output)
+
+	indexQuery := `
+	{
+	  me(func: eq(fullName, ["Alice Smith", "Alicia Smith"])) {
+		uid
+	  }
+	}`
+	output, err = runGraphqlQuery(indexQuery)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x1001"}]}}`, output)
+
+	// Deleting a source deletes the derived predicate.
+	require.NoError(t, runMutation(`
+	{
+	  delete {
+		<0x1002> <lastName> * .
+	  }
+	}`))
+	output, err = runGraphqlQuery(q)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"me":[{"fullName":"Alicia Smith"}]}}`, output)
+
+	err = runMutation(`
+	{
+	  set {
+		<0x1001> <fullName> "Someone Else" .
+	  }
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate fullName is derived and can't be mutated directly")
+
+	err = deletePredicate("firstName")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "used by derived predicate fullName")
+
+	err = alterSchema(`initials: string @derived(fullName + missing) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate missing used by derived predicate initials isn't "+
+		"defined in the schema")
+
+	err = alterSchema(`firstName: string @derived(fullName) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Derived predicates can't have cycles")
+
+	// Changing the derivation computes the values of the existing data.
+	require.NoError(t, alterSchemaWithRetry(
+		`fullName: string @index(exact) @derived(lastName + ", " + firstName) .`))
+	output, err = runGraphqlQuery(q)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"me":[{"fullName":"Smith, Alicia"}]}}`, output)
+}
+
+func TestExprIndexOfSeveralPredicates(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`
+		first: string .
+		last: string .
+		full: string @index(exact, expr: "lower(full)", type: hash) @derived(first + " " + last) .
+		nick: string @index(expr: "upper(nick)", type: exact) .
+	`))
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <first> "Alice" .
+		<0x1001> <last> "Smith" .
+		<0x1001> <nick> "Al" .
+	  }
+	}`))
+	query := func(fn string) string {
+		output, err := runGraphqlQuery(`{ me(func: ` + fn + `) { uid } }`)
+		require.NoError(t, err)
+		return output
+	}
+	found := `{"data": {"me":[{"uid":"0x1001"}]}}`
+	none := `{"data": {"me":[]}}`
+
+	require.JSONEq(t, found, query(`anyof(full, "LOWER( full )", "ALICE SMITH")`))
+	// eq doesn't use the expression index, the values are compared as they are.
+	require.JSONEq(t, none, query(`eq(full, "ALICE SMITH")`))
+	require.JSONEq(t, found, query(`eq(full, "Alice Smith")`))
+
+	// Changing any of the sources recomputes the index.
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <last> "Jones" .
+	  }
+	}`))
+	require.JSONEq(t, found, query(`anyof(full, "lower(full)", "alice JONES")`))
+	require.JSONEq(t, none, query(`anyof(full, "lower(full)", "alice smith")`))
+
+	require.JSONEq(t, found, query(`anyof(nick, "upper(nick)", "al")`))
+	_, err := runGraphqlQuery(`{ me(func: eq(nick, "Al")) { uid } }`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Attribute nick only has expression indexes")
+}
+
+func TestPatternPredicate(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`
+		email: string @index(exact) @pattern("^[^@]+@[^@]+$") .
+		tags: [string] @pattern("^[a-z]+$") .
+	`))
+
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <email> "alice@dgraph.io" .
+		<0x1001> <tags> "admin" .
+		<0x1001> <tags> "dev" .
+	  }
+	}`))
+
+	err := runMutation(`
+	{
+	  set {
+		<0x1002> <email> "bob" .
+	  }
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value "bob" for predicate "email" doesn't match the `+
+		`pattern "^[^@]+@[^@]+$"`)
+
+	// Every value of a list is checked.
+	err = runMutation(`
+	{
+	  set {
+		<0x1001> <tags> "ops" .
+		<0x1001> <tags> "Ops Team" .
+	  }
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value "Ops Team" for predicate "tags" doesn't match`)
+
+	output, err := runGraphqlQuery(`
+	{
+	  me(func: has(email)) {
+		email
+		tags
+	  }
+	}`)
+	require.NoError(t, err)
+	testutil.CompareJSON(t,
+		`{"data": {"me":[{"email":"alice@dgraph.io", "tags":["admin", "dev"]}]}}`, output)
+
+	output, err = runGraphqlQuery(`schema(pred: email) { pattern }`)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"data": {"schema":[{"predicate":"email", "pattern":"^[^@]+@[^@]+$"}]}}`, output)
+}
+
+func TestHistory(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`
+		status: string .
+		tags: [string] .
+	`))
+
+	for _, m := range []string{
+		`{ set { <0x1001> <status> "draft" . <0x1001> <tags> "a" . } }`,
+		`{ set { <0x1001> <status> "review" . <0x1001> <tags> "b" . } }`,
+		`{ set { <0x1002> <status> "other" . } }`,
+		`{ delete { <0x1001> <status> * . } }`,
+		`{ set { <0x1001> <status> "published" . } }`,
+	} {
+		require.NoError(t, runMutation(m))
+	}
+
+	type version struct {
+		Ts      uint64          `json:"ts"`
+		Value   json.RawMessage `json:"value"`
+		Deleted bool            `json:"deleted"`
+	}
+	history := func(pred string) []version {
+		output, err := runGraphqlQuery(`history(uid: 0x1001, pred: ` + pred + `)`)
+		require.NoError(t, err)
+		var res struct {
+			Data struct {
+				History []version `json:"history"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &res))
+		for i := 1; i < len(res.Data.History); i++ {
+			require.Greater(t, res.Data.History[i].Ts, res.Data.History[i-1].Ts)
+		}
+		return res.Data.History
+	}
+
+	// The mutation of the other node isn't part of the history.
+	versions := history("status")
+	require.Len(t, versions, 4)
+	require.JSONEq(t, `"draft"`, string(versions[0].Value))
+	require.JSONEq(t, `"review"`, string(versions[1].Value))
+	require.True(t, versions[2].Deleted)
+	require.JSONEq(t, `"published"`, string(versions[3].Value))
+
+	versions = history("tags")
+	require.Len(t, versions, 2)
+	require.JSONEq(t, `["a"]`, string(versions[0].Value))
+	require.JSONEq(t, `["a", "b"]`, string(versions[1].Value))
+
+	require.Empty(t, history("missing"))
+
+	_, err := runGraphqlQuery(`history(uid: 0x1001, pred: status) { me(func: uid(0x1)) { uid } }`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "History block is not allowed with other blocks")
+}
+
+func TestPredicateAlias(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`fullName: string @index(exact) @alias(name) .`))
+
+	// Both names can be used in mutations, the data is stored under fullName.
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <name> "Alice" .
+		<0x1002> <fullName> "Bob" .
+	  }
+	}`))
+
+	// The fields keep the name they were queried with, and the index of fullName is used by
+	// the functions on name.
+	output, err := runGraphqlQuery(`
+	{
+	  me(func: eq(name, "Alice")) {
+		name
+		fullName
+		n: name
+	  }
+	}`)
+	require.NoError(t, err)
+	testutil.CompareJSON(t,
+		`{"data": {"me":[{"name":"Alice", "fullName":"Alice", "n":"Alice"}]}}`, output)
+
+	output, err = runGraphqlQuery(`
+	{
+	  me(func: has(fullName), orderdesc: name) @filter(le(name, "Bob")) {
+		name
+	  }
+	}`)
+	require.NoError(t, err)
+	testutil.CompareJSON(t, `{"data": {"me":[{"name":"Bob"}, {"name":"Alice"}]}}`, output)
+
+	output, err = runGraphqlQuery(`schema(pred: fullName) { aliases }`)
+	require.NoError(t, err)
+	require.JSONEq(t,
+		`{"data": {"schema":[{"predicate":"fullName", "aliases":["name"]}]}}`, output)
+
+	err = alterSchema(`name: string .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate name can't be defined as it's an alias of "+
+		"predicate fullName")
+
+	err = alterSchema(`title: string @alias(fullName) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Alias fullName is already a predicate")
+
+	// The aliases are removed along with the predicate.
+	require.NoError(t, deletePredicate("fullName"))
+	require.NoError(t, alterSchemaWithRetry(`name: string .`))
+}
+
+func TestEnumPredicate(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchemaWithRetry(`status: enum("active", "inactive", "banned") .`))
+
+	require.NoError(t, runMutation(`
+	{
+	  set {
+		<0x1001> <status> "active" .
+		<0x1002> <status> "banned" .
+	  }
+	}`))
+
+	err := runMutation(`
+	{
+	  set {
+		<0x1003> <status> "deleted" .
+	  }
+	}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value "deleted" for predicate "status" isn't one of the `+
+		`values of its enum`)
+
+	// A value can be added, but not removed while some nodes use it.
+	require.NoError(t, alterSchemaWithRetry(
+		`status: enum("active", "inactive", "banned", "deleted") .`))
+	require.NoError(t, runMutation(`{ set { <0x1003> <status> "deleted" . } }`))
+	err = alterSchema(`status: enum("active", "inactive", "deleted") .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `value "banned" of predicate status isn't one of the `+
+		`values of its enum`)
+	require.NoError(t, alterSchemaWithRetry(`status: enum("active", "banned", "deleted") .`))
+
+	output, err := runGraphqlQuery(`
+	{
+	  me(func: eq(status, "banned")) {
+		uid
+	  }
+	}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x1002"}]}}`, output)
+
+	output, err = runGraphqlQuery(`schema(pred: status) { type enum_values }`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data": {"schema":[{"predicate":"status", "type":"string", `+
+		`"enum_values":["active", "banned", "deleted"]}]}}`, output)
+}
+
 func TestDropAll(t *testing.T) {
 	var m1 = `
 	{
@@ -1388,6 +1739,105 @@ func TestGrpcCompressionSupport(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGrpcStrictUids(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, runMutation(`{ set { <0x1> <name> "Alice" . } }`))
+
+	conn, err := grpc.Dial(testutil.SockAddr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	dc := api.NewDgraphClient(conn)
+	run := func(strict string) (*api.Response, metadata.MD) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			"accessJwt", token.getAccessJWTToken(), "strict-uids", strict)
+		var header metadata.MD
+		resp, err := dc.Query(ctx, &api.Request{
+			Query:    `{ q(func: uid(0x1, 0xdead)) { name } }`,
+			ReadOnly: true,
+		}, grpc.Header(&header))
+		require.NoError(t, err)
+		return resp, header
+	}
+
+	resp, header := run("true")
+	require.JSONEq(t, `{"q": [{"name": "Alice"}]}`, string(resp.Json))
+	require.Equal(t, []string{"0xdead"}, header.Get(x.DgraphMissingUidsHeader))
+	require.Equal(t, []string{"1"}, header.Get(x.DgraphMissingUidsTotalHeader))
+	require.NotContains(t, resp.Metrics.NumUids, "_missing_uids")
+
+	_, header = run("false")
+	require.Empty(t, header.Get(x.DgraphMissingUidsHeader))
+}
+
+func TestGrpcMaxStaleness(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, runMutation(`{ set { <0x1> <name> "Alice" . } }`))
+
+	conn, err := grpc.Dial(testutil.SockAddr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	dc := api.NewDgraphClient(conn)
+	run := func(staleness string, req *api.Request) (*api.Response, error) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(),
+			"accessJwt", token.getAccessJWTToken(), "max-staleness", staleness)
+		return dc.Query(ctx, req)
+	}
+	query := `{ q(func: uid(0x1)) { name } }`
+
+	// The query is made read-only by the metadata.
+	resp, err := run("500ms", &api.Request{Query: query})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"name": "Alice"}]}`, string(resp.Json))
+	require.NotZero(t, resp.Txn.StartTs)
+
+	_, err = run("-1s", &api.Request{Query: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "The metadata max-staleness can't be negative")
+	_, err = run("1x", &api.Request{Query: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "while parsing the metadata max-staleness")
+	_, err = run("1s", &api.Request{Query: query, StartTs: resp.Txn.StartTs, Hash: resp.Txn.Hash})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can only be used by a read-only query without startTs")
+}
+
+func TestGrpcBinary(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`thumbnail: binary .`))
+
+	conn, err := grpc.Dial(testutil.SockAddr, grpc.WithInsecure())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+	dc := api.NewDgraphClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"accessJwt", token.getAccessJWTToken())
+	blob := []byte{0, 1, 0xff, '"'}
+	_, err = dc.Query(ctx, &api.Request{
+		Mutations: []*api.Mutation{{Set: []*api.NQuad{{
+			Subject:     "0x1",
+			Predicate:   "thumbnail",
+			ObjectValue: &api.Value{Val: &api.Value_BytesVal{BytesVal: blob}},
+		}}}},
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+
+	// The bytes are returned raw in MessagePack.
+	ctx = metadata.AppendToOutgoingContext(ctx, "response-format", "msgpack")
+	resp, err := dc.Query(ctx, &api.Request{
+		Query:    `{ q(func: uid(0x1)) { thumbnail } }`,
+		ReadOnly: true,
+	})
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(resp.Json, msgp.AppendBytes(nil, blob)))
+}
+
 func TestTypeMutationAndQuery(t *testing.T) {
 	var m = `
 	{
@@ -28,6 +28,7 @@ import (
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/testutil"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -2126,6 +2127,155 @@ upsert {
 	require.NotContains(t, res, "count(~game_answer)")
 }
 
+func TestUpsertDeleteWhere(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+status: string @index(exact) .
+title: string @index(term) .
+replies: [uid] @reverse @count .
+type Post {
+  status
+  title
+  replies
+}`))
+
+	// The spam posts reply to a post which stays, so that the reverse edges, the index and the
+	// count index have to be cleaned up too.
+	var sb strings.Builder
+	sb.WriteString("{\n  set {\n")
+	sb.WriteString("    _:ok <status> \"ok\" .\n    _:ok <title> \"hello\" .\n")
+	sb.WriteString("    _:ok <dgraph.type> \"Post\" .\n")
+	for i := 0; i < 500; i++ {
+		sb.WriteString(fmt.Sprintf("    _:s%d <status> \"spam\" .\n", i))
+		sb.WriteString(fmt.Sprintf("    _:s%d <title> \"buy now %d\" .\n", i, i))
+		sb.WriteString(fmt.Sprintf("    _:s%d <replies> _:ok .\n", i))
+		sb.WriteString(fmt.Sprintf("    _:s%d <dgraph.type> \"Post\" .\n", i))
+	}
+	sb.WriteString("  }\n}")
+	_, err := mutationWithTs(mutationInp{body: sb.String(), typ: "application/rdf",
+		commitNow: true})
+	require.NoError(t, err)
+
+	m := `
+upsert {
+  query {
+    u as var(func: eq(status, "spam"))
+  }
+  mutation {
+    delete {
+      uid(u) * * .
+    }
+  }
+}`
+	_, err = mutationWithTs(mutationInp{body: m, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	q := `
+{
+  spam(func: eq(status, "spam")) {
+    count(uid)
+  }
+  buy(func: anyofterms(title, "buy")) {
+    count(uid)
+  }
+  replied(func: gt(count(replies), 0)) {
+    count(uid)
+  }
+  ok(func: eq(status, "ok")) {
+    title
+    count(~replies)
+  }
+}`
+	res, _, err := queryWithTs(queryInp{body: q, typ: "application/dql"})
+	require.NoError(t, err)
+	testutil.CompareJSON(t, `
+{
+  "data": {
+    "spam": [{"count": 0}],
+    "buy": [{"count": 0}],
+    "replied": [{"count": 0}],
+    "ok": [{"title": "hello", "count(~replies)": 0}]
+  }
+}`, res)
+}
+
+func TestUpsertMultipleBlocks(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+email: string @index(exact) .
+org_name: string @index(exact) .
+works_for: uid @reverse .`))
+
+	m1 := `
+{
+  set {
+    _:user <email> "user@company.io" .
+  }
+}`
+	_, err := mutationWithTs(mutationInp{body: m1, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	// The org is created by the first run, and the user is linked to it by both runs. The block
+	// finding the user is after the one using it, and l uses both of them.
+	m2 := `
+upsert {
+  query {
+    o as var(func: eq(org_name, "Company")) @filter(NOT uid(u))
+    l as var(func: uid(u)) @filter(uid_in(works_for, uid(o)))
+    u as var(func: eq(email, "user@company.io"))
+  }
+  mutation @if(eq(len(o), 0) AND eq(len(u), 1)) {
+    set {
+      _:org <org_name> "Company" .
+      uid(u) <works_for> _:org .
+    }
+  }
+  mutation @if(eq(len(o), 1) AND eq(len(u), 1) AND eq(len(l), 0)) {
+    set {
+      uid(u) <works_for> uid(o) .
+    }
+  }
+}`
+	for i := 0; i < 2; i++ {
+		_, err = mutationWithTs(mutationInp{body: m2, typ: "application/rdf", commitNow: true})
+		require.NoError(t, err)
+	}
+
+	q := `
+{
+  org(func: eq(org_name, "Company")) {
+    org_name
+    ~works_for {
+      email
+    }
+  }
+}`
+	res, _, err := queryWithTs(queryInp{body: q, typ: "application/dql"})
+	require.NoError(t, err)
+	testutil.CompareJSON(t, `
+{
+  "data": {
+    "org": [{"org_name": "Company", "~works_for": [{"email": "user@company.io"}]}]
+  }
+}`, res)
+
+	m3 := `
+upsert {
+  query {
+    o as var(func: eq(org_name, "Company")) @filter(uid_in(~works_for, uid(u)))
+    u as var(func: eq(email, "user@company.io")) @filter(uid_in(works_for, uid(o)))
+  }
+  mutation {
+    delete {
+      uid(u) <works_for> uid(o) .
+    }
+  }
+}`
+	_, err = mutationWithTs(mutationInp{body: m3, typ: "application/rdf", commitNow: true})
+	require.Contains(t, err.Error(), "Cycle detected between query blocks: the block defining o"+
+		" uses u, the block defining u uses o")
+}
+
 func TestUpsertVarOnlyUsedInQuery(t *testing.T) {
 	require.NoError(t, dropAll())
 	require.NoError(t, alterSchema(`
@@ -2910,3 +3060,84 @@ upsert {
 	_, _, err = queryWithTs(queryInp{body: q2, typ: "application/dql"})
 	require.NoError(t, err)
 }
+
+func TestUUIDFunction(t *testing.T) {
+	require.NoError(t, dropAll())
+	require.NoError(t, alterSchema(`
+		name: string @index(exact) .
+		id: string @index(exact) .`))
+
+	m1 := `
+{
+  set {
+    _:a <name> "a" .
+    _:a <id> uuid() .
+    _:b <name> "b" .
+    _:b <id> uuid( ) .
+  }
+}`
+	_, err := mutationWithTs(mutationInp{body: m1, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	m2 := `{"set": [{"name": "c", "id": {"dgraph.uuid": true}}]}`
+	_, err = mutationWithTs(mutationInp{body: m2, typ: "application/json", commitNow: true})
+	require.NoError(t, err)
+
+	// Every node matched by the upsert query gets its own UUID.
+	m3 := `
+upsert {
+  query {
+    q(func: has(name)) {
+      v as uid
+    }
+  }
+
+  mutation {
+    set {
+      uid(v) <session> uuid() .
+    }
+  }
+}`
+	_, err = mutationWithTs(mutationInp{body: m3, typ: "application/rdf", commitNow: true})
+	require.NoError(t, err)
+
+	q := `
+{
+  q(func: has(name)) {
+    id
+    session
+  }
+}`
+	res, _, err := queryWithTs(queryInp{body: q, typ: "application/dql"})
+	require.NoError(t, err)
+	var qr struct {
+		Data struct {
+			Q []struct {
+				ID      string
+				Session string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal([]byte(res), &qr))
+	require.Len(t, qr.Data.Q, 3)
+	seen := make(map[string]bool)
+	for _, node := range qr.Data.Q {
+		for _, id := range []string{node.ID, node.Session} {
+			parsed, err := uuid.Parse(id)
+			require.NoError(t, err)
+			require.Equal(t, uuid.Version(4), parsed.Version())
+			require.False(t, seen[id], "UUID %s was generated twice", id)
+			seen[id] = true
+		}
+	}
+
+	m4 := `
+{
+  delete {
+    <0x1> <id> uuid() .
+  }
+}`
+	_, err = mutationWithTs(mutationInp{body: m4, typ: "application/rdf", commitNow: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "uuid() can only be used in set mutations")
+}
@@ -0,0 +1,413 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/ristretto/z"
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// With --live-import, the posting lists written by the reducers are streamed into a running
+// cluster once the load is done. The schema is applied with an alter first, which assigns the new
+// predicates to a group. Then the posting lists of each predicate are sent as deltas to the
+// leader of the group serving it, which proposes them. The nodes of the load have been assigned
+// new uids by Zero, so their data keys don't exist in the cluster, while the index, reverse and
+// count keys are merged with the existing ones. The data is visible to the queries as it's
+// imported, so a query could see a part of it until the import is done.
+
+// clusterSchema is the result of a schema query.
+type clusterSchema struct {
+	Predicates []*clusterPredicate `json:"schema"`
+	Types      []struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+		} `json:"fields"`
+	} `json:"types"`
+}
+
+type clusterPredicate struct {
+	Predicate string   `json:"predicate"`
+	Type      string   `json:"type"`
+	Tokenizer []string `json:"tokenizer"`
+	Reverse   bool     `json:"reverse"`
+	Count     bool     `json:"count"`
+	List      bool     `json:"list"`
+	Lang      bool     `json:"lang"`
+}
+
+func (ld *loader) liveImport() {
+	fmt.Printf("Importing into the cluster at %s\n", ld.opt.AlphaAddr)
+	dg, closeFunc := x.GetDgraphClient(Bulk.Conf, false)
+	defer closeFunc()
+	ld.alterCluster(dg)
+
+	// The posting lists are written at a new timestamp, as the lists of the cluster could have
+	// been rolled up after the timestamp used by the reducers.
+	ts := getWriteTimestamp(ld.zero)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	cs, err := pb.NewZeroClient(ld.zero).Connect(ctx, &pb.Member{ClusterInfoOnly: true})
+	cancel()
+	x.Checkf(err, "Unable to get the state of the cluster from zero")
+
+	imp := &importer{
+		state:    cs.GetState(),
+		ts:       ts,
+		conns:    make(map[uint32]*grpc.ClientConn),
+		throttle: newThrottle(ld.opt.LiveImportRate << 20),
+	}
+	defer imp.close()
+	for _, db := range ld.dbs {
+		imp.importDB(db)
+	}
+	fmt.Printf("Imported %d keys into the cluster\n", imp.count)
+}
+
+// alterCluster applies the schema of the load to the cluster. The load can only be merged with
+// the data of a predicate or a type which already exists if they have the same schema, as the
+// posting lists of the indexes are merged.
+func (ld *loader) alterCluster(dg *dgo.Dgraph) {
+	ctx := context.Background()
+	txn := dg.NewReadOnlyTxn()
+	res, err := txn.Query(ctx, "schema {}")
+	x.Checkf(err, "Unable to query the schema of the cluster")
+	var cs clusterSchema
+	x.Check(json.Unmarshal(res.GetJson(), &cs))
+
+	existing := make(map[string]*clusterPredicate)
+	for _, p := range cs.Predicates {
+		existing[p.Predicate] = p
+	}
+	var sb strings.Builder
+	for attr, su := range ld.schema.schemaMap {
+		ns, pred := x.ParseNamespaceAttr(attr)
+		if ns != x.GalaxyNamespace {
+			x.Fatalf("--live-import can only load data into the default namespace, "+
+				"predicate %s is in namespace %#x", pred, ns)
+		}
+		if x.IsReservedPredicate(pred) {
+			continue
+		}
+		p, ok := existing[pred]
+		if !ok {
+			x.Check2(sb.WriteString(worker.SchemaString(su)))
+			continue
+		}
+		if loaded := toClusterPredicate(pred, su); !samePredicate(p, loaded) {
+			x.Fatalf("Predicate %s has a different schema in the cluster: %+v, the load has "+
+				"%+v", pred, *p, *loaded)
+		}
+	}
+
+	existingTypes := make(map[string][]string)
+	for _, t := range cs.Types {
+		fields := make([]string, 0, len(t.Fields))
+		for _, f := range t.Fields {
+			fields = append(fields, f.Name)
+		}
+		existingTypes[t.Name] = fields
+	}
+	for _, tu := range ld.schema.types {
+		name := x.ParseAttr(tu.TypeName)
+		if x.IsReservedType(name) {
+			continue
+		}
+		fields, ok := existingTypes[name]
+		if !ok {
+			x.Check2(sb.WriteString(worker.TypeString(tu)))
+			continue
+		}
+		loaded := make([]string, 0, len(tu.Fields))
+		for _, f := range tu.Fields {
+			loaded = append(loaded, x.ParseAttr(f.Predicate))
+		}
+		if !sameStrings(fields, loaded) {
+			x.Fatalf("Type %s has different fields in the cluster: %v, the load has %v",
+				name, fields, loaded)
+		}
+	}
+
+	if sb.Len() == 0 {
+		return
+	}
+	x.Checkf(dg.Alter(ctx, &api.Operation{Schema: sb.String()}),
+		"Unable to apply the schema to the cluster")
+}
+
+func toClusterPredicate(pred string, su *pb.SchemaUpdate) *clusterPredicate {
+	p := &clusterPredicate{
+		Predicate: pred,
+		Type:      types.TypeID(su.ValueType).Name(),
+		Reverse:   su.Directive == pb.SchemaUpdate_REVERSE,
+		Count:     su.Count,
+		List:      su.List,
+		Lang:      su.Lang,
+	}
+	if su.Directive == pb.SchemaUpdate_INDEX {
+		p.Tokenizer = su.Tokenizer
+	}
+	return p
+}
+
+func samePredicate(a, b *clusterPredicate) bool {
+	return a.Type == b.Type && a.Reverse == b.Reverse && a.Count == b.Count &&
+		a.List == b.List && a.Lang == b.Lang && sameStrings(a.Tokenizer, b.Tokenizer)
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// importer sends the posting lists of the reduce shards to the groups of the cluster.
+type importer struct {
+	state    *pb.MembershipState
+	ts       uint64
+	conns    map[uint32]*grpc.ClientConn
+	throttle *throttle
+	count    int
+}
+
+// importDB sends the posting lists of a reduce shard. The keys of a predicate are contiguous, so
+// they are sent with a single stream to the group serving it.
+func (imp *importer) importDB(db *badger.DB) {
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	var stream *predicateStream
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		pk, err := x.Parse(item.Key())
+		x.Check(err)
+		// The schema has been applied with an alter, and the parts of a split list are read
+		// along with its main key.
+		if pk.IsSchema() || pk.IsType() || pk.HasStartUid {
+			continue
+		}
+		if stream == nil || stream.attr != pk.Attr {
+			if stream != nil {
+				imp.count += stream.finish()
+			}
+			stream = imp.newStream(pk.Attr)
+		}
+		kv, err := toDelta(txn, item, imp.ts)
+		x.Check(err)
+		if kv != nil {
+			stream.add(kv)
+		}
+	}
+	if stream != nil {
+		imp.count += stream.finish()
+	}
+}
+
+func (imp *importer) newStream(attr string) *predicateStream {
+	var gid uint32
+	for id, group := range imp.state.GetGroups() {
+		if _, ok := group.GetTablets()[attr]; ok {
+			gid = id
+		}
+	}
+	if gid == 0 {
+		x.Fatalf("Predicate %s isn't served by any group of the cluster", x.ParseAttr(attr))
+	}
+	conn, ok := imp.conns[gid]
+	if !ok {
+		var addr string
+		for _, m := range imp.state.GetGroups()[gid].GetMembers() {
+			if m.GetLeader() {
+				addr = m.GetAddr()
+			}
+		}
+		if addr == "" {
+			x.Fatalf("Group %d of predicate %s doesn't have a leader", gid, x.ParseAttr(attr))
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		var err error
+		conn, err = grpc.DialContext(ctx, addr, internalDialOpts()...)
+		cancel()
+		x.Checkf(err, "Unable to connect to the leader of group %d at %s", gid, addr)
+		imp.conns[gid] = conn
+	}
+	out, err := pb.NewWorkerClient(conn).ImportKeyValues(context.Background())
+	x.Checkf(err, "While importing predicate %s", x.ParseAttr(attr))
+	return &predicateStream{
+		attr:     attr,
+		gid:      gid,
+		out:      out,
+		buf:      z.NewBuffer(64<<10, "Bulk.LiveImport"),
+		throttle: imp.throttle,
+	}
+}
+
+func (imp *importer) close() {
+	for _, conn := range imp.conns {
+		x.Check(conn.Close())
+	}
+}
+
+// predicateStream batches the posting lists of a predicate sent to its group.
+type predicateStream struct {
+	attr     string
+	gid      uint32
+	out      pb.Worker_ImportKeyValuesClient
+	buf      *z.Buffer
+	throttle *throttle
+}
+
+func (s *predicateStream) add(kv *bpb.KV) {
+	badger.KVToBuffer(kv, s.buf)
+	if s.buf.LenNoPadding() >= 4<<20 {
+		s.send()
+	}
+}
+
+func (s *predicateStream) send() {
+	if s.buf.LenNoPadding() == 0 {
+		return
+	}
+	s.throttle.wait(s.buf.LenNoPadding())
+	x.Checkf(s.out.Send(&pb.KVS{Data: s.buf.Bytes()}),
+		"While importing predicate %s", x.ParseAttr(s.attr))
+	s.buf.Reset()
+}
+
+// finish sends the remaining posting lists and returns the number of keys imported.
+func (s *predicateStream) finish() int {
+	defer s.buf.Release()
+	s.send()
+	payload, err := s.out.CloseAndRecv()
+	x.Checkf(err, "While importing predicate %s", x.ParseAttr(s.attr))
+	count, err := strconv.Atoi(string(payload.GetData()))
+	x.Check(err)
+	fmt.Printf("Imported %d keys of predicate %s into group %d\n", count,
+		x.ParseAttr(s.attr), s.gid)
+	return count
+}
+
+// toDelta reads a posting list written by the reducers, along with its parts if it's split, and
+// returns it as a delta at ts with a posting for each of its uids, so that it's merged with the
+// list stored in the cluster. It returns nil if the list is empty.
+func toDelta(txn *badger.Txn, item *badger.Item, ts uint64) (*bpb.KV, error) {
+	if item.UserMeta()&posting.BitEmptyPosting > 0 {
+		return nil, nil
+	}
+	key := item.KeyCopy(nil)
+	pl := &pb.PostingList{}
+	if err := item.Value(pl.Unmarshal); err != nil {
+		return nil, err
+	}
+	parts := []*pb.PostingList{pl}
+	for _, startUid := range pl.Splits {
+		splitKey, err := x.SplitKey(key, startUid)
+		if err != nil {
+			return nil, err
+		}
+		partItem, err := txn.Get(splitKey)
+		if err != nil {
+			return nil, err
+		}
+		part := &pb.PostingList{}
+		if err := partItem.Value(part.Unmarshal); err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	delta := &pb.PostingList{}
+	for _, part := range parts {
+		// The postings are sorted by uid, and only the uids with a value or facets have one.
+		postings := part.Postings
+		for _, uid := range codec.Decode(part.Pack, 0) {
+			p := &pb.Posting{Uid: uid}
+			if len(postings) > 0 && postings[0].Uid == uid {
+				p, postings = postings[0], postings[1:]
+			}
+			p.Op = posting.Set
+			delta.Postings = append(delta.Postings, p)
+		}
+	}
+	if len(delta.Postings) == 0 {
+		return nil, nil
+	}
+	val, err := delta.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &bpb.KV{
+		Key:      key,
+		Value:    val,
+		UserMeta: []byte{posting.BitDeltaPosting},
+		Version:  ts,
+	}, nil
+}
+
+// throttle limits the rate at which the posting lists are sent.
+type throttle struct {
+	rate  int // Bytes per second, no limit if zero.
+	start time.Time
+	sent  int
+}
+
+func newThrottle(rate int) *throttle {
+	return &throttle{rate: rate, start: time.Now()}
+}
+
+// wait blocks until n more bytes can be sent without going over the rate.
+func (t *throttle) wait(n int) {
+	t.sent += n
+	if t.rate <= 0 {
+		return
+	}
+	due := time.Duration(float64(t.sent) / float64(t.rate) * float64(time.Second))
+	if d := due - time.Since(t.start); d > 0 {
+		time.Sleep(d)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bulk
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/codec"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestToDelta(t *testing.T) {
+	db, err := badger.OpenManaged(badger.DefaultOptions("").WithInMemory(true))
+	require.NoError(t, err)
+	defer db.Close()
+
+	attr := x.GalaxyAttr("friend")
+	key, splitKey := x.DataKey(attr, 1), x.DataKey(attr, 2)
+	write := func(key []byte, pl *pb.PostingList, meta byte) {
+		val, err := pl.Marshal()
+		require.NoError(t, err)
+		txn := db.NewTransactionAt(1, true)
+		defer txn.Discard()
+		require.NoError(t, txn.SetEntry(badger.NewEntry(key, val).WithMeta(meta)))
+		require.NoError(t, txn.CommitAt(1, nil))
+	}
+	// A list with a posting for the uid having facets, and a split list.
+	write(key, &pb.PostingList{
+		Pack:     codec.Encode([]uint64{10, 11, 12}, 256),
+		Postings: []*pb.Posting{{Uid: 11, Facets: []*api.Facet{{Key: "weight"}}}},
+	}, posting.BitCompletePosting)
+	write(splitKey, &pb.PostingList{Splits: []uint64{1, 20}}, posting.BitCompletePosting)
+	for _, part := range []struct {
+		startUid uint64
+		uids     []uint64
+	}{{1, []uint64{10}}, {20, []uint64{20, 21}}} {
+		partKey, err := x.SplitKey(splitKey, part.startUid)
+		require.NoError(t, err)
+		write(partKey, &pb.PostingList{Pack: codec.Encode(part.uids, 256)},
+			posting.BitCompletePosting)
+	}
+	emptyKey := x.DataKey(attr, 3)
+	write(emptyKey, &pb.PostingList{}, posting.BitEmptyPosting)
+
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	delta := func(key []byte) *pb.PostingList {
+		item, err := txn.Get(key)
+		require.NoError(t, err)
+		kv, err := toDelta(txn, item, 5)
+		require.NoError(t, err)
+		if kv == nil {
+			return nil
+		}
+		require.Equal(t, key, kv.Key)
+		require.Equal(t, uint64(5), kv.Version)
+		require.Equal(t, []byte{posting.BitDeltaPosting}, kv.UserMeta)
+		pl := &pb.PostingList{}
+		require.NoError(t, pl.Unmarshal(kv.Value))
+		return pl
+	}
+	uids := func(pl *pb.PostingList) []uint64 {
+		var uids []uint64
+		for _, p := range pl.Postings {
+			require.Equal(t, posting.Set, p.Op)
+			uids = append(uids, p.Uid)
+		}
+		return uids
+	}
+
+	pl := delta(key)
+	require.Equal(t, []uint64{10, 11, 12}, uids(pl))
+	require.Len(t, pl.Postings[1].Facets, 1)
+	require.Equal(t, "weight", pl.Postings[1].Facets[0].Key)
+	// The parts of a split list are merged into a single delta.
+	require.Equal(t, []uint64{10, 20, 21}, uids(delta(splitKey)))
+	require.Nil(t, delta(emptyKey))
+}
+
+func TestSamePredicate(t *testing.T) {
+	var cs clusterSchema
+	require.NoError(t, json.Unmarshal([]byte(`{"schema": [
+		{"predicate": "name", "type": "string", "index": true, "tokenizer": ["term", "exact"],
+			"count": true, "list": true},
+		{"predicate": "friend", "type": "uid", "reverse": true}
+	]}`), &cs))
+
+	name := toClusterPredicate("name", &pb.SchemaUpdate{
+		ValueType: pb.Posting_STRING,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact", "term"},
+		Count:     true,
+		List:      true,
+	})
+	require.True(t, samePredicate(cs.Predicates[0], name))
+	name.Tokenizer = []string{"exact"}
+	require.False(t, samePredicate(cs.Predicates[0], name))
+
+	friend := toClusterPredicate("friend", &pb.SchemaUpdate{
+		ValueType: pb.Posting_UID,
+		Directive: pb.SchemaUpdate_REVERSE,
+	})
+	require.True(t, samePredicate(cs.Predicates[1], friend))
+	friend.Count = true
+	require.False(t, samePredicate(cs.Predicates[1], friend))
+
+	require.True(t, sameStrings([]string{"a", "b"}, []string{"b", "a"}))
+	require.False(t, sameStrings([]string{"a", "b"}, []string{"a", "a"}))
+	require.False(t, sameStrings([]string{"a"}, []string{"a", "b"}))
+}
+
+func TestThrottle(t *testing.T) {
+	// Without a rate, nothing waits.
+	start := time.Now()
+	newThrottle(0).wait(1 << 30)
+	require.Less(t, int64(time.Since(start)), int64(50*time.Millisecond))
+
+	// 10 KB at 100 KB/s take 100ms.
+	th := newThrottle(100 << 10)
+	start = time.Now()
+	th.wait(5 << 10)
+	th.wait(5 << 10)
+	elapsed := time.Since(start)
+	require.GreaterOrEqual(t, int64(elapsed), int64(90*time.Millisecond))
+	require.Less(t, int64(elapsed), int64(time.Second))
+}
@@ -68,6 +68,7 @@ type options struct {
 	ZeroAddr         string
 	HttpAddr         string
 	IgnoreErrors     bool
+	SkipPatternCheck bool
 	CustomTokenizers string
 	NewUids          bool
 	ClientDir        string
@@ -79,6 +80,11 @@ type options struct {
 
 	Namespace uint64
 
+	// LiveImport streams the output into the cluster of the alpha at AlphaAddr.
+	LiveImport     bool
+	AlphaAddr      string
+	LiveImportRate int
+
 	shardOutputDirs []string
 
 	// ........... Badger options ..........
@@ -117,17 +123,7 @@ func newLoader(opt *options) *loader {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
-	tlsConf, err := x.LoadClientTLSConfigForInternalPort(Bulk.Conf)
-	x.Check(err)
-	dialOpts := []grpc.DialOption{
-		grpc.WithBlock(),
-	}
-	if tlsConf != nil {
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)))
-	} else {
-		dialOpts = append(dialOpts, grpc.WithInsecure())
-	}
-	zero, err := grpc.DialContext(ctx, opt.ZeroAddr, dialOpts...)
+	zero, err := grpc.DialContext(ctx, opt.ZeroAddr, internalDialOpts()...)
 	x.Checkf(err, "Unable to connect to zero, Is it running at %s?", opt.ZeroAddr)
 	st := &state{
 		opt:    opt,
@@ -151,6 +147,21 @@ func newLoader(opt *options) *loader {
 	return ld
 }
 
+// internalDialOpts returns the options to connect to the internal port of Zero or an alpha.
+func internalDialOpts() []grpc.DialOption {
+	tlsConf, err := x.LoadClientTLSConfigForInternalPort(Bulk.Conf)
+	x.Check(err)
+	dialOpts := []grpc.DialOption{
+		grpc.WithBlock(),
+	}
+	if tlsConf != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConf)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+	return dialOpts
+}
+
 func getWriteTimestamp(zero *grpc.ClientConn) uint64 {
 	client := pb.NewZeroClient(zero)
 	for {
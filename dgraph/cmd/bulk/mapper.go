@@ -231,6 +231,22 @@ func (m *mapper) run(inputFormat chunker.InputFormat) {
 	}()
 
 	for nqs := range nquads.Ch() {
+		if err := chunker.FillUUIDs(nqs); err != nil {
+			atomic.AddInt64(&m.prog.errCount, 1)
+			if !m.opt.IgnoreErrors {
+				x.Check(err)
+			}
+		}
+		// The key nodes get a uid from their canonical name, as the other blank nodes. There is
+		// no data to look them up in yet.
+		keyNQs, err := chunker.FillKeyNodes(nqs)
+		if err != nil {
+			atomic.AddInt64(&m.prog.errCount, 1)
+			if !m.opt.IgnoreErrors {
+				x.Check(err)
+			}
+		}
+		nqs = append(nqs, keyNQs...)
 		for _, nq := range nqs {
 			if err := facets.SortAndValidate(nq.Facets); err != nil {
 				atomic.AddInt64(&m.prog.errCount, 1)
@@ -321,6 +337,10 @@ func (m *mapper) processNQuad(nq gql.NQuad) {
 		key = x.ReverseKey(de.Attr, oid)
 		m.addMapEntry(key, rev, shard)
 	}
+	if und := m.createUndirectedPosting(nq, de); und != nil {
+		key = x.DataKey(de.Attr, oid)
+		m.addMapEntry(key, und, shard)
+	}
 	m.addIndexMapEntries(nq, de)
 }
 
@@ -407,6 +427,20 @@ func (m *mapper) createPostings(nq gql.NQuad,
 	return p, rp
 }
 
+// createUndirectedPosting returns the posting for the edge in the other direction if the
+// predicate is undirected, or nil otherwise.
+func (m *mapper) createUndirectedPosting(nq gql.NQuad, de *pb.DirectedEdge) *pb.Posting {
+	sch := m.schema.getSchema(x.NamespaceAttr(nq.GetNamespace(), nq.GetPredicate()))
+	if !sch.GetUndirected() || nq.GetObjectValue() != nil || de.Entity == de.ValueId {
+		return nil
+	}
+	de.Entity, de.ValueId = de.ValueId, de.Entity
+	p := posting.NewPosting(de)
+	p.Facets = nq.Facets
+	de.Entity, de.ValueId = de.ValueId, de.Entity // de reused so swap back.
+	return p
+}
+
 func (m *mapper) addIndexMapEntries(nq gql.NQuad, de *pb.DirectedEdge) {
 	if nq.GetObjectValue() == nil {
 		return // Cannot index UIDs
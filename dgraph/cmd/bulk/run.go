@@ -107,6 +107,9 @@ func init() {
 	flag.String("http", "localhost:8080",
 		"Address to serve http (pprof).")
 	flag.Bool("ignore_errors", false, "ignore line parsing errors in rdf files")
+	flag.Bool("skip_pattern_check", false,
+		"Don't check the values of the string predicates against their @pattern directive. "+
+			"This speeds up loading data which is known to be valid.")
 	flag.Int("map_shards", 1,
 		"Number of map output shards. Must be greater than or equal to the number of reduce "+
 			"shards. Increasing allows more evenly sized reduce shards, at the expense of "+
@@ -121,6 +124,15 @@ func init() {
 		"Ignore UIDs in load files and assign new ones.")
 	flag.Uint64("force-namespace", math.MaxUint64,
 		"Namespace onto which to load the data. If not set, will preserve the namespace.")
+	flag.Bool("live-import", false,
+		"Stream the output into the running cluster of --alpha instead of only writing the p "+
+			"directories. The schema is applied with an alter and the posting lists are merged "+
+			"with the existing ones, so the nodes are always assigned new uids.")
+	flag.String("alpha", "localhost:9080",
+		"Comma separated list of Dgraph alpha gRPC server addresses used with --live-import.")
+	flag.Int("live-import-rate", 64,
+		"The maximum rate in MB per second at which the posting lists are sent with "+
+			"--live-import, to leave room for the live traffic of the cluster. 0 means no limit.")
 
 	flag.String("badger", BulkBadgerDefaults, z.NewSuperFlagHelp(BulkBadgerDefaults).
 		Head("Badger options (Refer to badger documentation for all possible options)").
@@ -169,12 +181,16 @@ func run() {
 		ZeroAddr:         Bulk.Conf.GetString("zero"),
 		HttpAddr:         Bulk.Conf.GetString("http"),
 		IgnoreErrors:     Bulk.Conf.GetBool("ignore_errors"),
+		SkipPatternCheck: Bulk.Conf.GetBool("skip_pattern_check"),
 		MapShards:        Bulk.Conf.GetInt("map_shards"),
 		ReduceShards:     Bulk.Conf.GetInt("reduce_shards"),
 		CustomTokenizers: Bulk.Conf.GetString("custom_tokenizers"),
 		NewUids:          Bulk.Conf.GetBool("new_uids"),
 		ClientDir:        Bulk.Conf.GetString("xidmap"),
 		Namespace:        Bulk.Conf.GetUint64("force-namespace"),
+		LiveImport:       Bulk.Conf.GetBool("live-import"),
+		AlphaAddr:        Bulk.Conf.GetString("alpha"),
+		LiveImportRate:   Bulk.Conf.GetInt("live-import-rate"),
 		Badger:           bopts,
 	}
 
@@ -249,7 +265,7 @@ func run() {
 	}
 	if opt.CustomTokenizers != "" {
 		for _, soFile := range strings.Split(opt.CustomTokenizers, ",") {
-			tok.LoadCustomTokenizer(soFile)
+			x.Check(tok.LoadCustomTokenizer(soFile))
 		}
 	}
 	if opt.MapBufSize <= 0 || opt.PartitionBufSize <= 0 {
@@ -258,6 +274,16 @@ func run() {
 		os.Exit(1)
 	}
 
+	if opt.LiveImport {
+		// The data is merged with the data of the cluster, so the uids of the files can't be used.
+		if opt.ClientDir != "" || opt.GqlSchemaFile != "" {
+			fmt.Fprint(os.Stderr,
+				"Invalid flags: --xidmap and --graphql_schema can't be used with --live-import.\n")
+			os.Exit(1)
+		}
+		opt.NewUids = true
+	}
+
 	opt.MapBufSize <<= 20       // Convert from MB to B.
 	opt.PartitionBufSize <<= 20 // Convert from MB to B.
 
@@ -353,6 +379,9 @@ func run() {
 	}
 	loader.reduceStage()
 	loader.writeSchema()
+	if opt.LiveImport {
+		loader.liveImport()
+	}
 	loader.cleanup()
 }
 
@@ -138,6 +138,14 @@ func (s *schemaStore) validateType(de *pb.DirectedEdge, objectIsUID bool) {
 	if err != nil {
 		log.Fatalf("RDF doesn't match schema: %v", err)
 	}
+	if err := wk.CheckEnum(de, sch); err != nil {
+		log.Fatalf("RDF doesn't match schema: %v", err)
+	}
+	if !s.opt.SkipPatternCheck {
+		if err := wk.CheckPattern(de, sch); err != nil {
+			log.Fatalf("RDF doesn't match schema: %v", err)
+		}
+	}
 }
 
 func (s *schemaStore) getPredicates(db *badger.DB) []string {
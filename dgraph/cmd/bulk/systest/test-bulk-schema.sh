@@ -211,6 +211,9 @@ EOF
       1 dgraph.rule.permission
       1 dgraph.rule.predicate
       1 dgraph.type
+      1 dgraph.uidset.expiry
+      1 dgraph.uidset.members
+      1 dgraph.uidset.name
       1 dgraph.user.group
       1 dgraph.xid
       1 genre
@@ -34,6 +34,7 @@ import (
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/dgo/v210"
 	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/tok"
@@ -263,6 +264,17 @@ func (l *loader) conflictKeysForNQuad(nq *api.NQuad) ([]uint64, error) {
 
 	keys := make([]uint64, 0)
 
+	// The uids of the key nodes are assigned by the alpha, so the requests using the same key node
+	// conflict on its name instead.
+	if chunker.IsKeyNode(nq.Subject) || chunker.IsKeyNode(nq.ObjectId) {
+		for _, id := range []string{nq.Subject, nq.ObjectId} {
+			if chunker.IsKeyNode(id) {
+				keys = append(keys, farm.Fingerprint64([]byte(x.NamespaceAttr(nq.Namespace, id))))
+			}
+		}
+		return keys, nil
+	}
+
 	// Calculates the conflict keys, inspired by the logic in
 	// addMutationInteration in posting/list.go.
 	sid, err := strconv.ParseUint(nq.Subject, 0, 64)
@@ -271,6 +271,11 @@ func (l *loader) processSchemaFile(ctx context.Context, file string, key x.Sensi
 }
 
 func (l *loader) uid(val string, ns uint64) string {
+	// The key nodes are looked up by the alpha, which assigns them a uid if they don't exist.
+	if chunker.IsKeyNode(val) {
+		return val
+	}
+
 	// Attempt to parse as a UID (in the same format that dgraph outputs - a
 	// hex number prefixed by "0x"). If parsing succeeds, then this is assumed
 	// to be an existing node in the graph. There is limited protection against
@@ -349,9 +354,11 @@ func (l *loader) upsertUids(nqs []*api.NQuad) {
 	for _, nq := range nqs {
 		// taking hash as the value might contain invalid symbols
 		subject := x.NamespaceAttr(nq.Namespace, nq.Subject)
-		ids[subject] = generateBlankNode(subject)
+		if !chunker.IsKeyNode(nq.Subject) {
+			ids[subject] = generateBlankNode(subject)
+		}
 
-		if len(nq.ObjectId) > 0 {
+		if len(nq.ObjectId) > 0 && !chunker.IsKeyNode(nq.ObjectId) {
 			// taking hash as the value might contain invalid symbols
 			object := x.NamespaceAttr(nq.Namespace, nq.ObjectId)
 			ids[object] = generateBlankNode(object)
@@ -18,6 +18,7 @@ package zero
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -206,6 +207,37 @@ func (st *state) moveTablet(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// rebalance returns the status of the automatic rebalancing of tablets on GET. It is paused by a
+// POST with pause=true and resumed by a POST with pause=false. Only the leader moves tablets, so
+// the pause should be sent to every Zero for it to hold when the leader changes.
+func (st *state) rebalance(w http.ResponseWriter, r *http.Request) {
+	x.AddCorsHeaders(w)
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == "OPTIONS" {
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		x.SetStatus(w, x.ErrorInvalidMethod, "Invalid method")
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		p, err := strconv.ParseBool(r.URL.Query().Get("pause"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			x.SetStatus(w, x.ErrorInvalidRequest,
+				"Query parameter 'pause' should be either true or false.")
+			return
+		}
+		st.zero.PauseRebalance(p)
+	}
+
+	if err := json.NewEncoder(w).Encode(st.zero.rebalanceStatus()); err != nil {
+		glog.Warningf("Error while writing response: %+v", err)
+	}
+}
+
 func (st *state) getState(w http.ResponseWriter, r *http.Request) {
 	x.AddCorsHeaders(w)
 	w.Header().Set("Content-Type", "application/json")
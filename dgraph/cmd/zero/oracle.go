@@ -85,12 +85,14 @@ func (o *Oracle) hasConflict(src *api.TxnContext) bool {
 		return true
 	}
 	for _, k := range src.Keys {
-		ki, err := strconv.ParseUint(k, 36, 64)
+		ki, readTs, err := x.ParseConflictKey(k)
 		if err != nil {
 			glog.Errorf("Got error while parsing conflict key %q: %v\n", k, err)
 			continue
 		}
-		if last := o.keyCommit.Get(ki); last > src.StartTs {
+		// The keys of a transaction in read-committed mode only conflict with the commits done
+		// after the data was read by its mutations.
+		if last := o.keyCommit.Get(ki); last > x.Max(src.StartTs, readTs) {
 			return true
 		}
 	}
@@ -139,9 +141,9 @@ func (o *Oracle) commit(src *api.TxnContext) error {
 	}
 	// We store src.Keys as string to ensure compatibility with all the various language clients we
 	// have. But, really they are just uint64s encoded as strings. We use base 36 during creation of
-	// these keys in FillContext in posting/mvcc.go.
+	// these keys in FillContext in posting/mvcc.go, see x.ConflictKey.
 	for _, k := range src.Keys {
-		ki, err := strconv.ParseUint(k, 36, 64)
+		ki, _, err := x.ParseConflictKey(k)
 		if err != nil {
 			glog.Errorf("Got error while parsing conflict key %q: %v\n", k, err)
 			continue
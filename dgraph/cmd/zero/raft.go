@@ -464,6 +464,9 @@ func (n *node) applyProposal(e raftpb.Entry) (uint64, error) {
 			}
 		}
 	}
+	if p.Maintenance != nil {
+		state.Maintenance = p.Maintenance
+	}
 	if p.Snapshot != nil {
 		if err := n.applySnapshot(p.Snapshot); err != nil {
 			glog.Errorf("While applying snapshot: %v\n", err)
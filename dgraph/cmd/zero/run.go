@@ -50,18 +50,19 @@ import (
 )
 
 type options struct {
-	raft              *z.SuperFlag
-	telemetry         *z.SuperFlag
-	limit             *z.SuperFlag
-	bindall           bool
-	portOffset        int
-	numReplicas       int
-	peer              string
-	w                 string
-	rebalanceInterval time.Duration
-	tlsClientConfig   *tls.Config
-	audit             *x.LoggerConf
-	limiterConfig     *x.LimiterConf
+	raft                  *z.SuperFlag
+	telemetry             *z.SuperFlag
+	limit                 *z.SuperFlag
+	bindall               bool
+	portOffset            int
+	numReplicas           int
+	peer                  string
+	w                     string
+	rebalanceInterval     time.Duration
+	rebalanceImbalancePct float64
+	tlsClientConfig       *tls.Config
+	audit                 *x.LoggerConf
+	limiterConfig         *x.LimiterConf
 }
 
 var opts options
@@ -99,6 +100,10 @@ instances to achieve high-availability.
 	flag.String("peer", "", "Address of another dgraphzero server.")
 	flag.StringP("wal", "w", "zw", "Directory storing WAL.")
 	flag.Duration("rebalance_interval", 8*time.Minute, "Interval for trying a predicate move.")
+	flag.Float64("rebalance_imbalance_pct", 10, "Minimum difference between the sizes of two "+
+		"groups, as a percentage of the size of the smaller one, for a predicate to be moved "+
+		"between them. Automatic moves can be paused and resumed with a POST to the /rebalance "+
+		"endpoint.")
 	flag.String("enterprise_license", "", "Path to the enterprise license file.")
 	flag.String("cid", "", "Cluster ID")
 
@@ -238,18 +243,19 @@ func run() {
 		RefillAfter:   limit.GetDuration("refill-interval"),
 	}
 	opts = options{
-		telemetry:         telemetry,
-		raft:              raft,
-		limit:             limit,
-		bindall:           Zero.Conf.GetBool("bindall"),
-		portOffset:        Zero.Conf.GetInt("port_offset"),
-		numReplicas:       Zero.Conf.GetInt("replicas"),
-		peer:              Zero.Conf.GetString("peer"),
-		w:                 Zero.Conf.GetString("wal"),
-		rebalanceInterval: Zero.Conf.GetDuration("rebalance_interval"),
-		tlsClientConfig:   tlsConf,
-		audit:             auditConf,
-		limiterConfig:     limitConf,
+		telemetry:             telemetry,
+		raft:                  raft,
+		limit:                 limit,
+		bindall:               Zero.Conf.GetBool("bindall"),
+		portOffset:            Zero.Conf.GetInt("port_offset"),
+		numReplicas:           Zero.Conf.GetInt("replicas"),
+		peer:                  Zero.Conf.GetString("peer"),
+		w:                     Zero.Conf.GetString("wal"),
+		rebalanceInterval:     Zero.Conf.GetDuration("rebalance_interval"),
+		rebalanceImbalancePct: Zero.Conf.GetFloat64("rebalance_imbalance_pct"),
+		tlsClientConfig:       tlsConf,
+		audit:                 auditConf,
+		limiterConfig:         limitConf,
 	}
 	glog.Infof("Setting Config to: %+v", opts)
 	x.WorkerConfig.Parse(Zero.Conf)
@@ -283,6 +289,10 @@ func run() {
 		log.Fatalf("ERROR: Rebalance interval must be greater than zero. Found: %d",
 			opts.rebalanceInterval)
 	}
+	if opts.rebalanceImbalancePct < 0 {
+		log.Fatalf("ERROR: Rebalance imbalance percentage can't be negative. Found: %v",
+			opts.rebalanceImbalancePct)
+	}
 
 	grpc.EnableTracing = false
 	otrace.ApplyConfig(otrace.Config{
@@ -328,6 +338,7 @@ func run() {
 		baseMux.HandleFunc("/state", st.getState)
 		baseMux.HandleFunc("/removeNode", st.removeNode)
 		baseMux.HandleFunc("/moveTablet", st.moveTablet)
+		baseMux.HandleFunc("/rebalance", st.rebalance)
 		baseMux.HandleFunc("/assign", st.assign)
 		baseMux.HandleFunc("/enterpriseLicense", st.applyEnterpriseLicense)
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -58,10 +59,76 @@ This would trigger G1 to get latest state. Wait for it.
 
 */
 
+// rebalanceState holds the state of the automatic rebalancing of tablets. It isn't replicated,
+// so it is lost when this Zero restarts.
+type rebalanceState struct {
+	sync.Mutex
+	paused bool
+	// pinned holds the tablets moved with /moveTablet, along with the group they were moved to.
+	// They aren't moved by the rebalancer afterwards, so that manual placement is kept until the
+	// rebalancing is resumed.
+	pinned map[string]uint32
+	// unmovable explains why the last imbalance found couldn't be reduced.
+	unmovable string
+}
+
+// rebalanceStatus is returned by the /rebalance endpoint.
+type rebalanceStatus struct {
+	Paused       bool              `json:"paused"`
+	Interval     string            `json:"interval"`
+	ImbalancePct float64           `json:"imbalance_pct"`
+	Pinned       map[string]uint32 `json:"pinned"`
+	Unmovable    string            `json:"unmovable,omitempty"`
+}
+
+// PauseRebalance pauses or resumes the automatic rebalancing of tablets. Tablets can still be
+// moved with /moveTablet while it is paused. Resuming it unpins the tablets moved manually, so
+// that the rebalancer can move them again.
+func (s *Server) PauseRebalance(pause bool) {
+	s.rebalance.Lock()
+	defer s.rebalance.Unlock()
+	if s.rebalance.paused == pause {
+		return
+	}
+	glog.Infof("Setting tablet rebalancing paused to: %v", pause)
+	s.rebalance.paused = pause
+	if !pause {
+		s.rebalance.pinned = make(map[string]uint32)
+	}
+}
+
+func (s *Server) rebalanceStatus() *rebalanceStatus {
+	s.rebalance.Lock()
+	defer s.rebalance.Unlock()
+	status := &rebalanceStatus{
+		Paused:       s.rebalance.paused,
+		Interval:     opts.rebalanceInterval.String(),
+		ImbalancePct: opts.rebalanceImbalancePct,
+		Pinned:       make(map[string]uint32, len(s.rebalance.pinned)),
+		Unmovable:    s.rebalance.unmovable,
+	}
+	for tablet, gid := range s.rebalance.pinned {
+		status.Pinned[x.FormatNsAttr(tablet)] = gid
+	}
+	return status
+}
+
+func (s *Server) pinTablet(tablet string, gid uint32) {
+	s.rebalance.Lock()
+	defer s.rebalance.Unlock()
+	s.rebalance.pinned[tablet] = gid
+}
+
 //  TODO: Have a event log for everything.
 func (s *Server) rebalanceTablets() {
 	ticker := time.NewTicker(opts.rebalanceInterval)
 	for range ticker.C {
+		s.rebalance.Lock()
+		paused := s.rebalance.paused
+		s.rebalance.Unlock()
+		if paused {
+			continue
+		}
 		predicate, srcGroup, dstGroup := s.chooseTablet()
 		if len(predicate) == 0 {
 			continue
@@ -112,6 +179,7 @@ func (s *Server) MoveTablet(ctx context.Context, req *pb.MoveTabletRequest) (*pb
 			req.Namespace, req.Tablet, srcGroup, req.DstGroup, err)
 		return &pb.Status{Code: 1, Msg: x.Error}, err
 	}
+	s.pinTablet(tablet, req.DstGroup)
 
 	return &pb.Status{Code: 0, Msg: fmt.Sprintf("namespace: %d. "+
 		"Predicate: [%s] moved from group [%d] to [%d]", req.Namespace, req.Tablet, srcGroup,
@@ -236,59 +304,103 @@ func (s *Server) chooseTablet() (predicate string, srcGroup uint32, dstGroup uin
 		return
 	}
 
+	s.rebalance.Lock()
+	defer s.rebalance.Unlock()
+	move := pickTablet(s.state.Groups, opts.rebalanceImbalancePct, s.rebalance.pinned,
+		s.hasLeader)
+	if move.unmovable != "" {
+		glog.Warningf("Unable to rebalance tablets: %s", move.unmovable)
+	}
+	s.rebalance.unmovable = move.unmovable
+	return move.predicate, move.srcGroup, move.dstGroup
+}
+
+// tabletMove is a move chosen by pickTablet.
+type tabletMove struct {
+	predicate string
+	srcGroup  uint32
+	dstGroup  uint32
+	// unmovable explains why no tablet could be moved when the groups are imbalanced.
+	unmovable string
+}
+
+// pickTablet chooses a tablet to move from one of the biggest groups to the smallest group. The
+// groups are considered imbalanced when the difference between their sizes is at least
+// imbalancePct percent of the size of the smallest group. The tablets which have been moved
+// manually aren't moved again.
+func pickTablet(groups map[uint32]*pb.Group, imbalancePct float64,
+	pinned map[string]uint32, hasLeader func(gid uint32) bool) tabletMove {
+
 	// Sort all groups by their sizes.
 	type kv struct {
 		gid  uint32
 		size int64 // in bytes
 	}
-	var groups []kv
-	for k, v := range s.state.Groups {
+	var sizes []kv
+	for k, v := range groups {
 		space := int64(0)
 		for _, tab := range v.Tablets {
 			space += tab.OnDiskBytes
 		}
-		groups = append(groups, kv{k, space})
+		sizes = append(sizes, kv{k, space})
 	}
-	sort.Slice(groups, func(i, j int) bool {
-		return groups[i].size < groups[j].size
+	sort.Slice(sizes, func(i, j int) bool {
+		return sizes[i].size < sizes[j].size
 	})
 
-	glog.Infof("\n\nGroups sorted by size: %+v\n\n", groups)
-	for lastGroup := numGroups - 1; lastGroup > 0; lastGroup-- {
-		srcGroup = groups[lastGroup].gid
-		dstGroup = groups[0].gid
-		sizeDiff := groups[lastGroup].size - groups[0].size
+	glog.Infof("\n\nGroups sorted by size: %+v\n\n", sizes)
+	var move tabletMove
+	for lastGroup := len(sizes) - 1; lastGroup > 0; lastGroup-- {
+		srcGroup := sizes[lastGroup].gid
+		dstGroup := sizes[0].gid
+		sizeDiff := sizes[lastGroup].size - sizes[0].size
 		glog.Infof("size_diff %v\n", sizeDiff)
 		// Don't move a node unless you receive atleast one update regarding tablet size.
 		// Tablet size would have come up with leader update.
-		if !s.hasLeader(dstGroup) {
-			return
+		if !hasLeader(dstGroup) {
+			return move
 		}
 		// We move the predicate only if the difference between size of both machines is
-		// atleast 10% of dst group.
-		if float64(sizeDiff) < 0.1*float64(groups[0].size) {
+		// atleast imbalancePct percent of dst group.
+		if float64(sizeDiff) < imbalancePct/100*float64(sizes[0].size) {
 			continue
 		}
 
 		// Try to find a predicate which we can move.
 		size := int64(0)
-		group := s.state.Groups[srcGroup]
-		for _, tab := range group.Tablets {
+		var tooBig *pb.Tablet
+		for _, tab := range groups[srcGroup].Tablets {
 			// Reserved predicates should always be in group 1 so do not re-balance them.
 			if x.IsReservedPredicate(tab.Predicate) {
 				continue
 			}
+			if _, ok := pinned[tab.Predicate]; ok {
+				continue
+			}
 
 			// Finds a tablet as big a possible such that on moving it dstGroup's size is
 			// less than or equal to srcGroup.
 			if tab.OnDiskBytes <= sizeDiff/2 && tab.OnDiskBytes > size {
-				predicate = tab.Predicate
+				move.predicate = tab.Predicate
 				size = tab.OnDiskBytes
 			}
+			if tab.OnDiskBytes > sizeDiff/2 &&
+				(tooBig == nil || tab.OnDiskBytes < tooBig.OnDiskBytes) {
+				tooBig = tab
+			}
+		}
+		if len(move.predicate) > 0 {
+			move.srcGroup, move.dstGroup = srcGroup, dstGroup
+			move.unmovable = ""
+			return move
 		}
-		if len(predicate) > 0 {
-			return
+		if tooBig != nil && move.unmovable == "" {
+			// Tablets can't be split, so moving this one would only make dstGroup the bigger one.
+			move.unmovable = fmt.Sprintf("group %d is %s larger than group %d, but its smallest "+
+				"movable tablet %s is %s. Tablets can't be split", srcGroup,
+				humanize.IBytes(uint64(sizeDiff)), dstGroup, x.FormatNsAttr(tooBig.Predicate),
+				humanize.IBytes(uint64(tooBig.OnDiskBytes)))
 		}
 	}
-	return
+	return move
 }
@@ -75,6 +75,7 @@ type Server struct {
 
 	moveOngoing    chan struct{}
 	blockCommitsOn *sync.Map
+	rebalance      rebalanceState
 
 	checkpointPerGroup map[uint32]uint64
 }
@@ -100,6 +101,7 @@ func (s *Server) Init() {
 	s.closer = z.NewCloser(2) // grpc and http
 	s.blockCommitsOn = new(sync.Map)
 	s.moveOngoing = make(chan struct{}, 1)
+	s.rebalance.pinned = make(map[string]uint32)
 	s.checkpointPerGroup = make(map[uint32]uint64)
 	if opts.limiterConfig.UidLeaseLimit > 0 {
 		// rate limiting is not enabled when lease limit is set to zero.
@@ -363,7 +365,8 @@ func (s *Server) createProposals(dst *pb.Group) ([]*pb.ZeroProposal, error) {
 			return res, errors.Errorf("Unknown member: %+v", dstMember)
 		}
 		if srcMember.Addr != dstMember.Addr ||
-			srcMember.Leader != dstMember.Leader {
+			srcMember.Leader != dstMember.Leader ||
+			srcMember.Region != dstMember.Region {
 
 			proposal := &pb.ZeroProposal{
 				Member: dstMember,
@@ -907,3 +910,15 @@ func (s *Server) ApplyLicense(ctx context.Context, req *pb.ApplyLicenseRequest)
 	glog.Infof("Enterprise license proposed to the cluster %+v", proposal)
 	return &pb.Status{}, nil
 }
+
+// UpdateMaintenance stores the maintenance policy of the heavy operations in the membership state,
+// so that it applies to all the alphas of the cluster.
+func (s *Server) UpdateMaintenance(ctx context.Context, req *pb.MaintenancePolicy) (*pb.Status,
+	error) {
+	proposal := &pb.ZeroProposal{Maintenance: req}
+	if err := s.Node.proposeAndWait(ctx, proposal); err != nil {
+		return nil, errors.Wrapf(err, "while proposing the maintenance policy to cluster")
+	}
+	glog.Infof("Maintenance policy proposed to the cluster %+v", req)
+	return &pb.Status{}, nil
+}
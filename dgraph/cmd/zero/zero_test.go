@@ -18,11 +18,15 @@ package zero
 
 import (
 	"context"
+	"encoding/json"
 	"math"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/testutil"
+	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,6 +42,73 @@ func TestRemoveNode(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPickTablet(t *testing.T) {
+	a, b, c := x.GalaxyAttr("a"), x.GalaxyAttr("b"), x.GalaxyAttr("c")
+	groups := map[uint32]*pb.Group{
+		1: {Tablets: map[string]*pb.Tablet{
+			a: {Predicate: a, OnDiskBytes: 1000},
+			b: {Predicate: b, OnDiskBytes: 100},
+		}},
+		2: {Tablets: map[string]*pb.Tablet{
+			c: {Predicate: c, OnDiskBytes: 100},
+		}},
+	}
+	hasLeader := func(uint32) bool { return true }
+
+	move := pickTablet(groups, 10, nil, hasLeader)
+	require.Equal(t, tabletMove{predicate: b, srcGroup: 1, dstGroup: 2}, move)
+
+	// The groups aren't imbalanced enough, group 1 is 1000% bigger than group 2.
+	move = pickTablet(groups, 2000, nil, hasLeader)
+	require.Equal(t, tabletMove{}, move)
+
+	// The destination group doesn't have a leader yet.
+	move = pickTablet(groups, 10, nil, func(uint32) bool { return false })
+	require.Equal(t, tabletMove{}, move)
+
+	// b has been moved manually, and a is too big to be moved.
+	move = pickTablet(groups, 10, map[string]uint32{b: 1}, hasLeader)
+	require.Empty(t, move.predicate)
+	require.Contains(t, move.unmovable, "smallest movable tablet 0-a is 1000 B")
+}
+
+func TestRebalanceEndpoint(t *testing.T) {
+	st := &state{zero: &Server{}}
+	st.zero.rebalance.pinned = make(map[string]uint32)
+	call := func(method, query string) (int, *rebalanceStatus) {
+		w := httptest.NewRecorder()
+		st.rebalance(w, httptest.NewRequest(method, "/rebalance"+query, nil))
+		if w.Code != http.StatusOK {
+			return w.Code, nil
+		}
+		var status rebalanceStatus
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		return w.Code, &status
+	}
+
+	// A GET doesn't change the state, even with pause.
+	code, status := call(http.MethodGet, "?pause=true")
+	require.Equal(t, http.StatusOK, code)
+	require.False(t, status.Paused)
+
+	_, status = call(http.MethodPost, "?pause=true")
+	require.True(t, status.Paused)
+	st.zero.pinTablet(x.GalaxyAttr("name"), 2)
+	_, status = call(http.MethodGet, "")
+	require.True(t, status.Paused)
+	require.Equal(t, map[string]uint32{"0-name": 2}, status.Pinned)
+
+	// Resuming unpins the tablets moved manually.
+	_, status = call(http.MethodPost, "?pause=false")
+	require.False(t, status.Paused)
+	require.Empty(t, status.Pinned)
+
+	code, _ = call(http.MethodPost, "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = call(http.MethodPut, "?pause=true")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
 func TestIdLeaseOverflow(t *testing.T) {
 	require.NoError(t, testutil.AssignUids(100))
 	err := testutil.AssignUids(math.MaxUint64 - 10)
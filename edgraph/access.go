@@ -41,6 +41,11 @@ func (s *Server) Login(ctx context.Context,
 	return &api.Response{}, x.ErrNotSupported
 }
 
+// LoadQueryAllowlist is an empty method since ACL is only supported in the enterprise version.
+func LoadQueryAllowlist(path string) error {
+	return nil
+}
+
 // ResetAcl is an empty method since ACL is only supported in the enterprise version.
 func InitializeAcl(closer *z.Closer) {
 	// do nothing
@@ -812,6 +812,7 @@ func authorizeMutation(ctx context.Context, gmu *gql.Mutation) error {
 	// Del predicates weren't included before.
 	// A bug probably since f115de2eb6a40d882a86c64da68bf5c2a33ef69a
 	preds = append(preds, parsePredsFromMutation(gmu.Del)...)
+	preds = append(preds, parsePredsFromMutation(gmu.Incr)...)
 
 	var userId string
 	var groupIds []string
@@ -831,7 +832,7 @@ func authorizeMutation(ctx context.Context, gmu *gql.Mutation) error {
 			// Members of guardians group are allowed to mutate anything
 			// (including delete) except the permission of the acl predicates.
 			switch {
-			case isAclPredMutation(gmu.Set):
+			case isAclPredMutation(gmu.Set), isAclPredMutation(gmu.Incr):
 				return errors.Errorf("the permission of ACL predicates can not be changed")
 			case isAclPredMutation(gmu.Del):
 				return errors.Errorf("ACL predicates can't be deleted")
@@ -960,9 +961,14 @@ func authorizeQuery(ctx context.Context, parsedReq *gql.Result, graphql bool) er
 
 	var userId string
 	var groupIds []string
+	var allowlist map[string]struct{}
 	predsAndvars := parsePredsFromQuery(parsedReq.Query)
 	preds := predsAndvars.preds
 	varsToPredMap := predsAndvars.vars
+	if parsedReq.History != nil {
+		// The history of a predicate needs the same permission as its current values.
+		preds = append(preds, parsedReq.History.Attr)
+	}
 
 	// Need this to efficiently identify blocked variables from the
 	// list of blocked predicates
@@ -979,6 +985,13 @@ func authorizeQuery(ctx context.Context, parsedReq *gql.Result, graphql bool) er
 
 		userId = userData.userId
 		groupIds = userData.groupIds
+		allowlist = userQueryAllowlist(userData)
+		if allowlist != nil {
+			if err := checkQueryAllowlist(allowlist, userId, allowlistPreds(preds, graphql),
+				parsedReq.Query); err != nil {
+				return nil, nil, err
+			}
+		}
 
 		if x.IsGuardian(groupIds) {
 			// Members of guardian groups are allowed to query anything.
@@ -993,6 +1006,19 @@ func authorizeQuery(ctx context.Context, parsedReq *gql.Result, graphql bool) er
 	if err != nil {
 		return err
 	}
+	if h := parsedReq.History; h != nil {
+		if _, ok := blockedPreds[h.Attr]; ok {
+			return status.Errorf(codes.PermissionDenied,
+				"unauthorized to query the history of predicate %s", h.Attr)
+		}
+	}
+	if allowlist != nil {
+		ns, err := x.ExtractNamespace(ctx)
+		if err != nil {
+			return errors.Wrapf(err, "While authorizing query")
+		}
+		allowedPreds = restrictToAllowlist(ns, allowedPreds, allowlist)
+	}
 
 	if span := otrace.FromContext(ctx); span != nil {
 		span.Annotatef(nil, (&accessEntry{
@@ -1066,13 +1092,29 @@ func authorizeSchemaQuery(ctx context.Context, er *query.ExecutionResult) error
 			return nil, status.Error(codes.Unauthenticated, err.Error())
 		}
 
+		// The predicates which aren't in the query allowlist of the user are hidden too.
+		blocked := make(map[string]struct{})
+		if allowlist := userQueryAllowlist(userData); allowlist != nil {
+			for _, pred := range preds {
+				if _, ok := allowlist[pred]; !ok {
+					blocked[pred] = struct{}{}
+				}
+			}
+		}
+
 		groupIds := userData.groupIds
 		if x.IsGuardian(groupIds) {
 			// Members of guardian groups are allowed to query anything.
-			return nil, nil
+			return blocked, nil
 		}
 		result, err := authorizePreds(ctx, userData, preds, acl.Read)
-		return result.blocked, err
+		if err != nil {
+			return nil, err
+		}
+		for pred := range blocked {
+			result.blocked[pred] = struct{}{}
+		}
+		return result.blocked, nil
 	}
 
 	// find the predicates which are blocked for the schema query
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// ActiveQuery describes a query that is currently being processed by this Alpha.
+type ActiveQuery struct {
+	Id        uint64
+	Namespace uint64
+	Query     string
+	Started   time.Time
+
+	cancel context.CancelFunc
+}
+
+type activeQueries struct {
+	sync.Mutex
+	nextId  uint64
+	queries map[uint64]*ActiveQuery
+}
+
+var runningQueries = &activeQueries{queries: make(map[uint64]*ActiveQuery)}
+
+// register assigns an id to the query and makes it cancellable through KillQuery. The returned
+// function must be called once the query is done, to deregister it.
+func (aq *activeQueries) register(ctx context.Context, ns uint64,
+	query string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	aq.Lock()
+	aq.nextId++
+	q := &ActiveQuery{
+		Id:        aq.nextId,
+		Namespace: ns,
+		Query:     query,
+		Started:   time.Now(),
+		cancel:    cancel,
+	}
+	aq.queries[q.Id] = q
+	aq.Unlock()
+
+	return ctx, func() {
+		aq.Lock()
+		delete(aq.queries, q.Id)
+		aq.Unlock()
+		cancel()
+	}
+}
+
+// ActiveQueries returns the queries running on this Alpha in the given namespace, ordered by id.
+// The guardians of the galaxy can see the queries running in all the namespaces.
+func ActiveQueries(ns uint64) []ActiveQuery {
+	aq := runningQueries
+	aq.Lock()
+	defer aq.Unlock()
+
+	res := make([]ActiveQuery, 0, len(aq.queries))
+	for _, q := range aq.queries {
+		if ns != x.GalaxyNamespace && q.Namespace != ns {
+			continue
+		}
+		res = append(res, ActiveQuery{
+			Id:        q.Id,
+			Namespace: q.Namespace,
+			Query:     q.Query,
+			Started:   q.Started,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Id < res[j].Id })
+	return res
+}
+
+// KillQuery cancels the context of the query with the given id running on this Alpha. Only the
+// guardians of the galaxy can cancel the queries running in other namespaces.
+func KillQuery(ns, id uint64) error {
+	aq := runningQueries
+	aq.Lock()
+	q, ok := aq.queries[id]
+	aq.Unlock()
+
+	// A query in another namespace is reported as missing, so that its existence isn't revealed.
+	if !ok || (ns != x.GalaxyNamespace && q.Namespace != ns) {
+		return errors.Errorf("query %d is not running, it might have already finished", id)
+	}
+	glog.Infof("Cancelling query %d in namespace %#x, running since %s", id, q.Namespace,
+		q.Started.Format(time.RFC3339))
+	q.cancel()
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// resolveAliases replaces the aliases of the predicates used by the request with the predicates
+// they stand for. It's done before the request is authorized, so that the permissions of the
+// predicate apply to its aliases. The fields of the query keep the name they were queried with
+// in the response, unless they already have an alias of their own.
+func resolveAliases(ctx context.Context, qc *queryContext) error {
+	if err := worker.LoadAliases(ctx); err != nil {
+		return err
+	}
+	if !schema.State().HasAliases() {
+		return nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	r := aliasResolver{ns: ns}
+
+	for _, gmu := range qc.gmuList {
+		for _, nq := range gmu.Set {
+			nq.Predicate, _ = r.resolve(nq.Predicate)
+		}
+		for _, nq := range gmu.Del {
+			nq.Predicate, _ = r.resolve(nq.Predicate)
+		}
+		for _, nq := range gmu.Incr {
+			nq.Predicate, _ = r.resolve(nq.Predicate)
+		}
+	}
+	for _, gq := range qc.gqlRes.Query {
+		r.resolveBlock(gq, true, false)
+	}
+	if h := qc.gqlRes.History; h != nil {
+		h.Attr, _ = r.resolve(h.Attr)
+	}
+	return nil
+}
+
+type aliasResolver struct {
+	ns uint64
+}
+
+// resolve returns the predicate the given attribute stands for, keeping the ~ of a reverse
+// predicate, and true if it's an alias.
+func (r aliasResolver) resolve(attr string) (string, bool) {
+	name := strings.TrimPrefix(attr, "~")
+	pred, ok := schema.State().ResolveAlias(x.NamespaceAttr(r.ns, name))
+	if !ok {
+		return attr, false
+	}
+	return attr[:len(attr)-len(name)] + x.ParseAttr(pred), true
+}
+
+func (r aliasResolver) resolveBlock(gq *gql.GraphQuery, root, normalize bool) {
+	// The fields without an alias aren't part of the response of a block with @normalize.
+	normalize = normalize || gq.Normalize
+	if !root && !gq.IsInternal && gq.Expand == "" {
+		if pred, ok := r.resolve(gq.Attr); ok {
+			if gq.Alias == "" && !normalize {
+				gq.Alias = aliasFieldName(gq)
+			}
+			gq.Attr = pred
+		}
+	}
+	r.resolveFunc(gq.Func)
+	r.resolveFilter(gq.Filter)
+	for _, o := range gq.Order {
+		if !gq.UsesValueVar(o.Attr) {
+			o.Attr, _ = r.resolve(o.Attr)
+		}
+	}
+	for i := range gq.GroupbyAttrs {
+		attr := &gq.GroupbyAttrs[i]
+		if pred, ok := r.resolve(attr.Attr); ok {
+			if attr.Alias == "" {
+				attr.Alias = attr.Attr
+			}
+			attr.Attr = pred
+		}
+	}
+	for _, child := range gq.Children {
+		r.resolveBlock(child, false, normalize)
+	}
+}
+
+func (r aliasResolver) resolveFunc(f *gql.Function) {
+	if f == nil || f.IsValueVar || f.IsLenVar || f.Attr == "" {
+		return
+	}
+	f.Attr, _ = r.resolve(f.Attr)
+}
+
+func (r aliasResolver) resolveFilter(ft *gql.FilterTree) {
+	if ft == nil {
+		return
+	}
+	r.resolveFunc(ft.Func)
+	for _, child := range ft.Child {
+		r.resolveFilter(child)
+	}
+}
+
+// aliasFieldName returns the name of the field in the response, as it would be without any
+// alias resolution.
+func aliasFieldName(gq *gql.GraphQuery) string {
+	switch {
+	case gq.IsCount:
+		return fmt.Sprintf("count(%s)", gq.Attr)
+	case len(gq.Langs) > 0 && gq.Langs[0] != "*":
+		return gq.Attr + "@" + strings.Join(gq.Langs, ":")
+	default:
+		return gq.Attr
+	}
+}
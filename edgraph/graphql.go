@@ -17,19 +17,109 @@
 package edgraph
 
 import (
+	"container/list"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sync"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
 )
 
+// persistedQueryCache is a LRU cache of the persisted queries, keyed by namespace and sha256Hash,
+// which saves a lookup in Dgraph for the hashes that are used often. It's cleared once the
+// persisted queries are dropped by a drop all, a drop data or the deletion of a namespace.
+type persistedQueryCache struct {
+	sync.Mutex
+	maxSize int
+	lru     *list.List
+	entries map[string]*list.Element
+	// dropEpoch returns the number of drops applied by the alpha, and epoch is the one of the
+	// entries.
+	dropEpoch func() uint64
+	epoch     uint64
+}
+
+type persistedQueryEntry struct {
+	key   string
+	query string
+}
+
+var persistedQueries = &persistedQueryCache{
+	lru:       list.New(),
+	entries:   make(map[string]*list.Element),
+	dropEpoch: worker.DropEpoch,
+}
+
+func persistedQueryKey(ns uint64, sha256Hash string) string {
+	return fmt.Sprintf("%#x-%s", ns, sha256Hash)
+}
+
+// clearIfDropped clears the cache if the data was dropped since the entries were added, and
+// returns the current epoch. It must be called with the lock held.
+func (c *persistedQueryCache) clearIfDropped() uint64 {
+	if epoch := c.dropEpoch(); epoch != c.epoch {
+		c.lru.Init()
+		c.entries = make(map[string]*list.Element)
+		c.epoch = epoch
+	}
+	return c.epoch
+}
+
+// get returns the query of key, and the epoch to pass to set once the query is read from Dgraph.
+func (c *persistedQueryCache) get(key string) (string, uint64, bool) {
+	c.Lock()
+	defer c.Unlock()
+	epoch := c.clearIfDropped()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", epoch, false
+	}
+	c.lru.MoveToFront(e)
+	return e.Value.(*persistedQueryEntry).query, epoch, true
+}
+
+// set caches the query of key, unless the data was dropped since epoch was returned by get, as
+// the query might have been read before the drop.
+func (c *persistedQueryCache) set(key, query string, epoch uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if c.maxSize <= 0 || c.clearIfDropped() != epoch {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*persistedQueryEntry).query = query
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&persistedQueryEntry{key: key, query: query})
+	for c.lru.Len() > c.maxSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*persistedQueryEntry).key)
+	}
+}
+
+// SetPersistedQueryCacheSize sets the number of persisted queries kept in memory.
+func SetPersistedQueryCacheSize(size int) {
+	persistedQueries.Lock()
+	persistedQueries.maxSize = size
+	persistedQueries.Unlock()
+}
+
+// PersistedQueryAllowlist returns whether only the persisted queries are allowed on the /graphql
+// endpoint. Only guardians can then store new persisted queries.
+func PersistedQueryAllowlist() bool {
+	return x.Config.GraphQL != nil && x.Config.GraphQL.GetBool("persisted-query-allowlist")
+}
+
 // ProcessPersistedQuery stores and retrieves persisted queries by following waterfall logic:
 // 1. If sha256Hash is not provided process queries without persisting
 // 2. If sha256Hash is provided try retrieving persisted queries
@@ -59,6 +149,20 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 		}
 	}
 
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	cacheKey := persistedQueryKey(ns, sha256Hash)
+	gotQuery, epoch, ok := persistedQueries.get(cacheKey)
+	if ok {
+		if len(query) > 0 && gotQuery != query {
+			return errors.New("query does not match persisted query")
+		}
+		gqlReq.Query = gotQuery
+		return nil
+	}
+
 	join := sha256Hash + query
 
 	queryForSHA := `query Me($join: string){
@@ -106,6 +210,15 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 		} else if !match {
 			return errors.New("provided sha does not match query")
 		}
+		if PersistedQueryAllowlist() {
+			// Only the guardians can add queries to the allowlist.
+			if err := hasPoormansAuth(ctx); err != nil {
+				return errors.Wrapf(err, "query is not in the persisted query allowlist")
+			}
+			if err := AuthorizeGuardians(ctx); err != nil {
+				return errors.Wrapf(err, "query is not in the persisted query allowlist")
+			}
+		}
 
 		req = &Request{
 			req: &api.Request{
@@ -132,8 +245,11 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 		}
 
 		ctx := context.WithValue(ctx, IsGraphql, true)
-		_, err := (&Server{}).doQuery(ctx, req)
-		return err
+		if _, err := (&Server{}).doQuery(ctx, req); err != nil {
+			return err
+		}
+		persistedQueries.set(cacheKey, query, epoch)
+		return nil
 
 	}
 
@@ -141,7 +257,7 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 		return fmt.Errorf("same sha returned %d queries", len(shaQueryRes.Me))
 	}
 
-	gotQuery := ""
+	gotQuery = ""
 	if len(shaQueryRes.Me[0].PersistedQuery) >= 64 {
 		gotQuery = shaQueryRes.Me[0].PersistedQuery[64:]
 	}
@@ -150,6 +266,7 @@ func ProcessPersistedQuery(ctx context.Context, gqlReq *schema.Request) error {
 		return errors.New("query does not match persisted query")
 	}
 
+	persistedQueries.set(cacheKey, gotQuery, epoch)
 	gqlReq.Query = gotQuery
 	return nil
 
@@ -0,0 +1,54 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+// sizer is implemented by the protobuf messages of the gRPC API.
+type sizer interface {
+	Size() int
+}
+
+// LimitMessageSize is a gRPC interceptor rejecting the requests and the responses larger than
+// --limit "grpc-max-message-mb". The gRPC server accepts messages up to x.GrpcMaxSize, as its own
+// limits can't be changed without restarting it. The errors are the same as the ones of gRPC.
+func LimitMessageSize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	max := worker.GrpcMaxMessageSize()
+	if max == 0 {
+		return handler(ctx, req)
+	}
+	if m, ok := req.(sizer); ok && int64(m.Size()) > max {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"grpc: received message larger than max (%d vs. %d)", m.Size(), max)
+	}
+	resp, err := handler(ctx, req)
+	if m, ok := resp.(sizer); ok && err == nil && int64(m.Size()) > max {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"grpc: trying to send message larger than max (%d vs. %d)", m.Size(), max)
+	}
+	return resp, err
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestLimitMessageSize(t *testing.T) {
+	defer func() { require.NoError(t, worker.UpdateGrpcMaxMessageMb(0)) }()
+
+	big := strings.Repeat("a", 2<<20)
+	query := func(req *api.Request, json string) error {
+		_, err := LimitMessageSize(context.Background(), req, &grpc.UnaryServerInfo{},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &api.Response{Json: []byte(json)}, nil
+			})
+		return err
+	}
+
+	// There is no limit by default.
+	require.NoError(t, query(&api.Request{Query: big}, big))
+
+	require.NoError(t, worker.UpdateGrpcMaxMessageMb(1))
+	require.NoError(t, query(&api.Request{Query: "{}"}, "{}"))
+	err := query(&api.Request{Query: big}, "{}")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Contains(t, err.Error(), "received message larger than max")
+	err = query(&api.Request{Query: "{}"}, big)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Contains(t, err.Error(), "trying to send message larger than max")
+
+	// The limit can be raised again.
+	require.NoError(t, worker.UpdateGrpcMaxMessageMb(4))
+	require.NoError(t, query(&api.Request{Query: big}, big))
+
+	require.Error(t, worker.UpdateGrpcMaxMessageMb(-1))
+	require.Error(t, worker.UpdateGrpcMaxMessageMb(x.GrpcMaxSize>>19))
+	require.Error(t, worker.UpdateGrpcMaxMessageMb(math.MaxInt64>>19))
+}
@@ -0,0 +1,153 @@
+//go:build !oss
+// +build !oss
+
+/*
+ * Copyright 2022 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// queryAllowlist holds the predicates which can be queried by the users listed in the file given
+// with --acl "query-allowlist=...", on top of their ACL read permissions. Unlike for the ACLs, a
+// DQL query using any other predicate is rejected instead of having the predicate dropped. The
+// reverse of an allowed predicate is allowed too. The users which aren't listed aren't restricted.
+var queryAllowlist map[allowlistKey]map[string]struct{}
+
+type allowlistKey struct {
+	namespace uint64
+	userId    string
+}
+
+// allowlistEntry is an entry of the allowlist file, which holds a JSON array like
+// [{"namespace": 0, "user": "public", "predicates": ["name", "description"]}].
+type allowlistEntry struct {
+	Namespace  uint64   `json:"namespace"`
+	User       string   `json:"user"`
+	Predicates []string `json:"predicates"`
+}
+
+// LoadQueryAllowlist reads the predicates the users can query from the given file.
+func LoadQueryAllowlist(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "while reading the query allowlist")
+	}
+	var entries []allowlistEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return errors.Wrapf(err, "while parsing the query allowlist %s", path)
+	}
+
+	allowlist := make(map[allowlistKey]map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if entry.User == "" {
+			return errors.Errorf("the query allowlist %s has an entry without user", path)
+		}
+		key := allowlistKey{namespace: entry.Namespace, userId: entry.User}
+		preds, ok := allowlist[key]
+		if !ok {
+			preds = make(map[string]struct{}, len(entry.Predicates))
+			allowlist[key] = preds
+		}
+		for _, pred := range entry.Predicates {
+			preds[strings.TrimPrefix(pred, "~")] = struct{}{}
+		}
+	}
+	queryAllowlist = allowlist
+	glog.Infof("Loaded the query allowlist of %d users", len(allowlist))
+	return nil
+}
+
+// userQueryAllowlist returns the predicates the user can query, or nil if they aren't restricted.
+func userQueryAllowlist(ud *userData) map[string]struct{} {
+	return queryAllowlist[allowlistKey{namespace: ud.namespace, userId: ud.userId}]
+}
+
+// allowlistPreds returns the predicates of a query which are checked against the allowlist. The
+// queries built by the GraphQL layer also read reserved predicates like dgraph.type, which the
+// user doesn't ask for, so those are left out for GraphQL requests.
+func allowlistPreds(preds []string, graphql bool) []string {
+	if !graphql {
+		return preds
+	}
+	checked := make([]string, 0, len(preds))
+	for _, pred := range preds {
+		// The predicates don't have a namespace yet.
+		if !x.IsReservedPredicate(x.GalaxyAttr(pred)) {
+			checked = append(checked, pred)
+		}
+	}
+	return checked
+}
+
+// checkQueryAllowlist returns an error if the query uses a predicate which isn't in the allowlist,
+// or expands the predicates of a type or of a variable. expand(_all_) is fine, as it's restricted
+// to the allowlist.
+func checkQueryAllowlist(allowlist map[string]struct{}, userId string, preds []string,
+	gqs []*gql.GraphQuery) error {
+
+	sort.Strings(preds)
+	for _, pred := range preds {
+		if _, ok := allowlist[strings.TrimPrefix(pred, "~")]; !ok {
+			return status.Errorf(codes.PermissionDenied,
+				"predicate %s isn't in the query allowlist of user %s", pred, userId)
+		}
+	}
+
+	var checkExpand func(gqs []*gql.GraphQuery) error
+	checkExpand = func(gqs []*gql.GraphQuery) error {
+		for _, gq := range gqs {
+			if gq.Expand != "" && gq.Expand != "_all_" {
+				return status.Errorf(codes.PermissionDenied,
+					"expand(%s) can't be used by user %s, which has a query allowlist. "+
+						"Use expand(_all_) instead", gq.Expand, userId)
+			}
+			if err := checkExpand(gq.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return checkExpand(gqs)
+}
+
+// restrictToAllowlist returns the predicates of allowedPreds which are in the allowlist, so that
+// expand(_all_) only returns them. allowedPreds is nil if all the predicates are allowed by the
+// ACLs. Unlike the allowlist, the predicates of allowedPreds have a namespace.
+func restrictToAllowlist(ns uint64, allowedPreds []string,
+	allowlist map[string]struct{}) []string {
+
+	restricted := make([]string, 0, len(allowlist))
+	if allowedPreds == nil {
+		for pred := range allowlist {
+			restricted = append(restricted, x.NamespaceAttr(ns, pred))
+		}
+		return restricted
+	}
+	for _, pred := range allowedPreds {
+		if _, ok := allowlist[x.ParseAttr(pred)]; ok {
+			restricted = append(restricted, pred)
+		}
+	}
+	return restricted
+}
@@ -0,0 +1,129 @@
+//go:build !oss
+// +build !oss
+
+/*
+ * Copyright 2022 Dgraph Labs, Inc. All rights reserved.
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package edgraph
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestLoadQueryAllowlist(t *testing.T) {
+	defer func() { queryAllowlist = nil }()
+
+	dir, err := ioutil.TempDir("", "allowlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "allowlist.json")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[
+		{"user": "public", "predicates": ["name", "~friend"]},
+		{"namespace": 2, "user": "public", "predicates": ["age"]}
+	]`), 0600))
+	require.NoError(t, LoadQueryAllowlist(path))
+	require.Equal(t, map[string]struct{}{"name": {}, "friend": {}},
+		userQueryAllowlist(&userData{namespace: 0, userId: "public"}))
+	require.Equal(t, map[string]struct{}{"age": {}},
+		userQueryAllowlist(&userData{namespace: 2, userId: "public"}))
+	require.Nil(t, userQueryAllowlist(&userData{namespace: 0, userId: "groot"}))
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`[{"predicates": ["name"]}]`), 0600))
+	require.Error(t, LoadQueryAllowlist(path))
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"public": ["name"]}`), 0600))
+	require.Error(t, LoadQueryAllowlist(path))
+}
+
+func TestCheckQueryAllowlist(t *testing.T) {
+	allowlist := map[string]struct{}{"name": {}, "friend": {}, "age": {}}
+	check := func(q string) error {
+		res, err := gql.Parse(gql.Request{Str: q})
+		require.NoError(t, err)
+		return checkQueryAllowlist(allowlist, "public", parsePredsFromQuery(res.Query).preds,
+			res.Query)
+	}
+
+	require.NoError(t, check(`{
+		me(func: eq(name, "a"), orderasc: age) @filter(gt(count(friend), 2)) {
+			name
+			~friend { a as age }
+			expand(_all_)
+			min(val(a))
+		}
+		ages(func: has(name)) @groupby(age) {
+			count(uid)
+		}
+	}`))
+	require.EqualError(t, check(`{ me(func: has(name)) { name email } }`),
+		"rpc error: code = PermissionDenied desc = predicate email isn't in the query "+
+			"allowlist of user public")
+	require.Error(t, check(`{ me(func: has(name)) @filter(eq(email, "a")) { name } }`))
+	require.Error(t, check(`{ me(func: has(name)) @groupby(email) { count(uid) } }`))
+	require.Error(t, check(`{ me(func: has(name)) { friend { expand(Person) } } }`))
+}
+
+func TestCheckQueryAllowlistGraphQL(t *testing.T) {
+	allowlist := map[string]struct{}{"Person.name": {}, "Person.friends": {}}
+	check := func(q string, graphql bool) error {
+		res, err := gql.Parse(gql.Request{Str: q})
+		require.NoError(t, err)
+		preds := allowlistPreds(parsePredsFromQuery(res.Query).preds, graphql)
+		return checkQueryAllowlist(allowlist, "public", preds, res.Query)
+	}
+
+	// A query like the GraphQL layer builds for queryPerson { name friends { name } }.
+	q := `query {
+		queryPerson(func: type(Person)) {
+			Person.name : Person.name
+			Person.friends : Person.friends {
+				Person.name : Person.name
+				dgraph.type
+				dgraph.uid : uid
+			}
+			dgraph.uid : uid
+		}
+	}`
+	require.NoError(t, check(q, true))
+	// The reserved predicates are only left out for GraphQL requests.
+	require.Error(t, check(q, false))
+
+	require.EqualError(t, check(`query {
+		queryPerson(func: type(Person)) @filter(eq(Person.age, 20)) {
+			Person.name : Person.name
+		}
+	}`, true), "rpc error: code = PermissionDenied desc = predicate Person.age isn't in the "+
+		"query allowlist of user public")
+	require.Error(t, check(`query {
+		queryPerson(func: type(Person)) {
+			Person.name : Person.name
+			Person.age : Person.age
+		}
+	}`, true))
+}
+
+func TestRestrictToAllowlist(t *testing.T) {
+	allowlist := map[string]struct{}{"name": {}, "age": {}}
+	preds := restrictToAllowlist(x.GalaxyNamespace, nil, allowlist)
+	sort.Strings(preds)
+	require.Equal(t, []string{x.GalaxyAttr("age"), x.GalaxyAttr("name")}, preds)
+
+	require.Equal(t, []string{x.GalaxyAttr("name")}, restrictToAllowlist(x.GalaxyNamespace,
+		[]string{x.GalaxyAttr("name"), x.GalaxyAttr("email")}, allowlist))
+}
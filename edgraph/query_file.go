@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"io"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// QueryToFile starts a task writing the results of a read-only DQL query to a file, and returns
+// the id of the task. The query is parsed and authorized with the ACLs of the user when the task
+// is created, so that a query which can't run is rejected right away. The task runs it later
+// with the credentials of the user.
+func QueryToFile(ctx context.Context, query string, vars map[string]string,
+	req *worker.QueryToFileRequest) (uint64, error) {
+
+	switch req.Format {
+	case worker.QueryFileJSON, worker.QueryFileJSONL:
+	default:
+		return 0, errors.Errorf("Invalid format %q for the results of a query, it should be "+
+			"%s or %s", req.Format, worker.QueryFileJSON, worker.QueryFileJSONL)
+	}
+	parsed, err := gql.Parse(gql.Request{Str: query, Variables: vars})
+	if err != nil {
+		return 0, err
+	}
+	ctx = x.AttachJWTNamespace(ctx)
+	authMode := getAuthMode(ctx)
+	if authMode == NeedAuthorize {
+		if err := authorizeQuery(ctx, &parsed, false); err != nil {
+			return 0, err
+		}
+	}
+
+	// The task outlives the request, so it only keeps the metadata of the request, which holds
+	// the credentials and the namespace of the user.
+	md, _ := metadata.FromIncomingContext(ctx)
+	req.Run = func(ctx context.Context, w io.Writer) error {
+		ctx = x.AttachJWTNamespace(metadata.NewIncomingContext(ctx, md.Copy()))
+		// The results are written to w as they are encoded, they aren't returned.
+		resp, err := (&Server{}).doQuery(ctx, &Request{
+			req:          &api.Request{Query: query, Vars: vars, ReadOnly: true},
+			doAuth:       authMode,
+			resultWriter: w,
+		})
+		if err != nil {
+			return err
+		}
+		// The schema and history queries are returned, as they aren't encoded from subgraphs.
+		_, err = w.Write(resp.GetJson())
+		return err
+	}
+	return worker.Tasks.Enqueue(req)
+}
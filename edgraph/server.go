@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"net"
 	"sort"
@@ -74,6 +75,17 @@ const (
 	IsGraphql GraphqlContextKey = iota
 	// Authorize is used to set if the request requires validation.
 	Authorize
+	// ReadTs is used to run a read-only query on the snapshot at the given committed timestamp.
+	ReadTs
+	// MaxStaleness is used to run a read-only query on data committed up to at most the given
+	// duration ago. The query avoids getting a timestamp from Zero if the Alpha is fresh enough.
+	MaxStaleness
+	// Duplicates is used to set how the nquads given more than once in a mutation are handled,
+	// worker.DuplicatesDedup, worker.DuplicatesError or worker.DuplicatesAppend.
+	Duplicates
+	// Isolation is used to set the isolation of the requests of a transaction,
+	// worker.IsolationSnapshot or worker.IsolationReadCommitted.
+	Isolation
 )
 
 type AuthMode int
@@ -253,6 +265,27 @@ func validateAlterOperation(ctx context.Context, op *api.Operation) error {
 	return nil
 }
 
+// checkReindexAllowed returns an error if the schema updates rebuild the index of an existing
+// predicate while the maintenance policy doesn't allow the heavy operations. The indexes of the
+// new predicates are allowed, as they don't have any data to index.
+func checkReindexAllowed(ctx context.Context, updates []*pb.SchemaUpdate) error {
+	for _, update := range updates {
+		old, ok := schema.State().Get(ctx, update.Predicate)
+		if !ok {
+			continue
+		}
+		rb := &posting.IndexRebuild{
+			Attr:          update.Predicate,
+			OldSchema:     &old,
+			CurrentSchema: update,
+		}
+		if rb.NeedIndexRebuild() {
+			return worker.CheckHeavyOp("Reindex")
+		}
+	}
+	return nil
+}
+
 // parseSchemaFromAlterOperation parses the string schema given in input operation to a Go
 // struct, and performs some checks to make sure that the schema is valid.
 func parseSchemaFromAlterOperation(ctx context.Context, op *api.Operation) (*schema.ParsedSchema,
@@ -340,6 +373,29 @@ func parseSchemaFromAlterOperation(ctx context.Context, op *api.Operation) (*sch
 				"which is reserved as the namespace for dgraph's internal types/predicates.",
 				x.ParseAttr(typ.TypeName))
 		}
+
+		// The predicate of @cascadeDelete is followed to the nodes to delete.
+		if typ.CascadeDelete != "" {
+			pred := x.NamespaceAttr(x.ParseNamespace(typ.TypeName), typ.CascadeDelete)
+			for _, update := range result.Preds {
+				if update.Predicate == pred && update.ValueType != pb.Posting_UID {
+					return nil, errors.Errorf("Predicate %s of @cascadeDelete in type %s should "+
+						"be of type uid", typ.CascadeDelete, x.ParseAttr(typ.TypeName))
+				}
+			}
+		}
+
+		// The predicate of @softDelete is compared with true in queries.
+		if typ.SoftDelete == "" {
+			continue
+		}
+		pred := x.NamespaceAttr(x.ParseNamespace(typ.TypeName), typ.SoftDelete)
+		for _, update := range result.Preds {
+			if update.Predicate == pred && update.ValueType != pb.Posting_BOOL {
+				return nil, errors.Errorf("Predicate %s of @softDelete in type %s should be of "+
+					"type bool", typ.SoftDelete, x.ParseAttr(typ.TypeName))
+			}
+		}
 	}
 
 	return result, nil
@@ -533,6 +589,15 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 	}
 
 	glog.Infof("Got schema: %+v\n", result)
+	if err := checkReindexAllowed(ctx, result.Preds); err != nil {
+		return empty, err
+	}
+	// The derived predicates which are added or changed need to be computed for the existing
+	// data once the schema is applied.
+	derived, err := worker.ChangedDerivations(ctx, result.Preds)
+	if err != nil {
+		return empty, err
+	}
 	// TODO: Maybe add some checks about the schema.
 	m.Schema = result.Preds
 	m.Types = result.Types
@@ -546,6 +611,17 @@ func (s *Server) Alter(ctx context.Context, op *api.Operation) (*api.Payload, er
 		return empty, err
 	}
 
+	if len(derived) > 0 {
+		if op.RunInBackground {
+			go func() {
+				if err := worker.ComputeDerived(context.Background(), derived); err != nil {
+					glog.Errorf("Error while computing derived predicates: %v", err)
+				}
+			}()
+		} else if err := worker.ComputeDerived(ctx, derived); err != nil {
+			return empty, err
+		}
+	}
 	return empty, nil
 }
 
@@ -553,6 +629,23 @@ func annotateNamespace(span *otrace.Span, ns uint64) {
 	span.AddAttributes(otrace.Int64Attribute("ns", int64(ns)))
 }
 
+// validateReadTs checks that a consistent snapshot can be read at the given timestamp.
+func validateReadTs(readTs uint64) error {
+	if maxTs := posting.Oracle().MaxAssigned(); readTs > maxTs {
+		return errors.Errorf("readTs %d is ahead of the latest committed timestamp %d",
+			readTs, maxTs)
+	}
+	minTs, err := worker.MinReadTs()
+	if err != nil {
+		return err
+	}
+	if readTs < minTs {
+		return errors.Errorf("readTs %d is below %d, the versions at that timestamp might have "+
+			"been garbage collected", readTs, minTs)
+	}
+	return nil
+}
+
 func annotateStartTs(span *otrace.Span, ts uint64) {
 	span.AddAttributes(otrace.Int64Attribute("startTs", int64(ts)))
 }
@@ -579,7 +672,7 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 		return err
 	}
 
-	newUids, err := query.AssignUids(ctx, qc.gmuList)
+	newUids, err := query.AssignUids(ctx, qc.gmuList, qc.req.StartTs, qc.readCommittedTs)
 	if err != nil {
 		return err
 	}
@@ -593,6 +686,12 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 	if err != nil {
 		return err
 	}
+	mode, _ := ctx.Value(Duplicates).(string)
+	if mode == worker.DuplicatesError {
+		if err := worker.CheckDuplicateEdges(edges); err != nil {
+			return err
+		}
+	}
 	ns, err := x.ExtractNamespace(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "While doing mutations:")
@@ -613,9 +712,14 @@ func (s *Server) doMutate(ctx context.Context, qc *queryContext, resp *api.Respo
 		Metadata: &pb.Metadata{
 			PredHints: predHints,
 		},
+		ReadCommittedTs:  qc.readCommittedTs,
+		AppendDuplicates: mode == worker.DuplicatesAppend,
 	}
 
 	qc.span.Annotatef(nil, "Applying mutations: %+v", m)
+	if qc.req.CommitNow {
+		ctx = worker.WithCommitNow(ctx)
+	}
 	resp.Txn, err = query.ApplyMutations(ctx, m)
 	qc.span.Annotatef(nil, "Txn Context: %+v. Err=%v", resp.Txn, err)
 
@@ -728,7 +832,8 @@ func buildUpsertQuery(qc *queryContext) string {
 }
 
 // updateMutations updates the mutation and replaces uid(var) and val(var) with
-// their values or a blank node, in case of an upsert.
+// their values or a blank node, in case of an upsert. It also replaces uuid() with
+// newly generated UUIDs.
 // We use the values stored in qc.uidRes and qc.valRes to update the mutation.
 func updateMutations(qc *queryContext) error {
 	for i, condVar := range qc.condVars {
@@ -738,6 +843,7 @@ func updateMutations(qc *queryContext) error {
 			if !(ok && len(uids) == 1) {
 				gmu.Set = nil
 				gmu.Del = nil
+				gmu.Incr = nil
 				continue
 			}
 		}
@@ -748,11 +854,26 @@ func updateMutations(qc *queryContext) error {
 		if err := updateValInMutations(gmu, qc); err != nil {
 			return err
 		}
+		// UUIDs are generated after uid(v) has been expanded, so that every node gets its own.
+		if err := chunker.FillUUIDs(gmu.Set); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// isCondVar returns true if name is one of the variables added to the query to evaluate the
+// condition of a conditional upsert.
+func isCondVar(qc *queryContext, name string) bool {
+	for _, v := range qc.condVars {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
 // findMutationVars finds all the variables used in mutation block and stores them
 // qc.uidRes and qc.valRes so that we only look for these variables in query results.
 func findMutationVars(qc *queryContext) []string {
@@ -775,6 +896,9 @@ func findMutationVars(qc *queryContext) []string {
 			updateVars(nq.Subject)
 			updateVars(nq.ObjectId)
 		}
+		for _, nq := range gmu.Incr {
+			updateVars(nq.Subject)
+		}
 	}
 
 	varsList := make([]string, 0, len(qc.uidRes)+len(qc.valRes))
@@ -942,26 +1066,34 @@ func updateUIDInMutations(gmu *gql.Mutation, qc *queryContext) error {
 
 	gmu.Del = gmuDel
 
-	// Update the values in mutation block from the query block.
-	gmuSet := make([]*api.NQuad, 0, len(gmu.Set))
-	for _, nq := range gmu.Set {
-		newSubs := getNewVals(nq.Subject)
-		newObs := getNewVals(nq.ObjectId)
-
-		qc.nquadsCount += len(newSubs) * len(newObs)
-		if qc.nquadsCount > int(x.Config.LimitQueryEdge) {
-			return errors.Errorf("NQuad count in the request: %d, is more that threshold: %d",
-				qc.nquadsCount, int(x.Config.LimitQueryEdge))
-		}
+	// Update the values in mutation block from the query block. The increments are updated as
+	// the set N-Quads, so that the increment of a new node starts from zero.
+	expandSet := func(nqs []*api.NQuad) ([]*api.NQuad, error) {
+		res := make([]*api.NQuad, 0, len(nqs))
+		for _, nq := range nqs {
+			newSubs := getNewVals(nq.Subject)
+			newObs := getNewVals(nq.ObjectId)
+
+			qc.nquadsCount += len(newSubs) * len(newObs)
+			if qc.nquadsCount > int(x.Config.LimitQueryEdge) {
+				return nil, errors.Errorf("NQuad count in the request: %d, is more that "+
+					"threshold: %d", qc.nquadsCount, int(x.Config.LimitQueryEdge))
+			}
 
-		for _, s := range newSubs {
-			for _, o := range newObs {
-				gmuSet = append(gmuSet, getNewNQuad(nq, s, o))
+			for _, s := range newSubs {
+				for _, o := range newObs {
+					res = append(res, getNewNQuad(nq, s, o))
+				}
 			}
 		}
+		return res, nil
 	}
-	gmu.Set = gmuSet
-	return nil
+	var err error
+	if gmu.Set, err = expandSet(gmu.Set); err != nil {
+		return err
+	}
+	gmu.Incr, err = expandSet(gmu.Incr)
+	return err
 }
 
 // queryContext is used to pass around all the variables needed
@@ -1002,6 +1134,11 @@ type queryContext struct {
 	// 1B) and resulting in OOM. We are limiting number of nquads which can be inserted in
 	// a single request.
 	nquadsCount int
+	// readCommittedTs is the ts the request of a transaction in read-committed mode reads the
+	// data at, instead of the start ts of the transaction.
+	readCommittedTs uint64
+	// resultWriter is written the JSON response of the query, see Request.
+	resultWriter io.Writer
 }
 
 // Request represents a query request sent to the doQuery() method on the Server.
@@ -1013,6 +1150,9 @@ type Request struct {
 	gqlField gqlSchema.Field
 	// doAuth tells whether this request needs ACL authorization or not
 	doAuth AuthMode
+	// resultWriter is written the JSON response of a DQL query as it is encoded, instead of
+	// returning it in the response.
+	resultWriter io.Writer
 }
 
 // Health handles /health and /health?all requests.
@@ -1058,6 +1198,34 @@ func (s *Server) Health(ctx context.Context, all bool) (*api.Response, error) {
 	return &api.Response{Json: jsonOut}, nil
 }
 
+// RaftHealth handles /health?raft requests. It reports the Raft role, applied index, snapshot
+// index and replication lag of this Alpha. If maxLag is positive, an error is returned when the
+// group has no leader or this Alpha lags behind the committed index by more than maxLag entries.
+func (s *Server) RaftHealth(ctx context.Context, maxLag uint64) (*api.Response, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	st, err := worker.GetRaftStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jsonOut, err := json.Marshal(st)
+	if err != nil {
+		return nil, errors.Errorf("Unable to Marshal. Err %v", err)
+	}
+	resp := &api.Response{Json: jsonOut}
+	if maxLag > 0 {
+		switch {
+		case !st.HasLeader:
+			return resp, errors.Errorf("Group %d has no leader", st.Group)
+		case st.Lag > maxLag:
+			return resp, errors.Errorf("Alpha is lagging behind by %d entries", st.Lag)
+		}
+	}
+	return resp, nil
+}
+
 // Filter out the tablets that do not belong to the requestor's namespace.
 func filterTablets(ctx context.Context, ms *pb.MembershipState) error {
 	if !x.WorkerConfig.AclEnabled {
@@ -1148,6 +1316,12 @@ func (s *Server) QueryGraphQL(ctx context.Context, req *api.Request,
 // Query handles queries or mutations
 func (s *Server) Query(ctx context.Context, req *api.Request) (*api.Response, error) {
 	ctx = x.AttachJWTNamespace(ctx)
+	if readTs, _ := ctx.Value(ReadTs).(uint64); readTs != 0 {
+		if req.GetStartTs() != 0 || len(req.GetMutations()) > 0 {
+			return nil, errors.Errorf("readTs can only be used by a read-only query without startTs")
+		}
+		req.ReadOnly = true
+	}
 	if x.WorkerConfig.AclEnabled && req.GetStartTs() != 0 {
 		// A fresh StartTs is assigned if it is 0.
 		ns, err := x.ExtractNamespace(ctx)
@@ -1168,9 +1342,103 @@ func (s *Server) Query(ctx context.Context, req *api.Request) (*api.Response, er
 			defer cancel()
 		}
 	}
+	if wantsMsgpackOverGrpc(ctx) {
+		ctx = context.WithValue(ctx, query.MsgpackKey, true)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// The fields of the response are renamed with the metadata rename, as with the rename
+		// parameter of /query.
+		if rename := md.Get("rename"); len(rename) > 0 {
+			renames, err := query.ParseFieldRenames(rename[0])
+			if err != nil {
+				return nil, err
+			}
+			ctx = context.WithValue(ctx, query.FieldRenamesKey, renames)
+		}
+		// The isolation of the requests of a transaction is set with the metadata isolation, as
+		// with the isolation parameter of /query and /mutate.
+		if isolation := md.Get("isolation"); len(isolation) > 0 {
+			mode, err := worker.ParseIsolation(isolation[0])
+			if err != nil {
+				return nil, err
+			}
+			ctx = context.WithValue(ctx, Isolation, mode)
+		}
+		// The duplicate nquads of the mutations are handled as set by the metadata duplicates,
+		// as with the duplicates parameter of /mutate.
+		if duplicates := md.Get("duplicates"); len(duplicates) > 0 {
+			mode, err := worker.ParseDuplicates(duplicates[0])
+			if err != nil {
+				return nil, err
+			}
+			ctx = context.WithValue(ctx, Duplicates, mode)
+		}
+		// With the metadata group-timeout, the query returns partial results if a group doesn't
+		// reply within the duration, as with the groupTimeout parameter of /query. The groups
+		// which timed out are sent back in the header dgraph-incomplete-groups.
+		if timeout := md.Get("group-timeout"); len(timeout) > 0 {
+			d, err := time.ParseDuration(timeout[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "while parsing the metadata group-timeout")
+			}
+			if d < 0 {
+				return nil, errors.Errorf("The metadata group-timeout can't be negative")
+			}
+			if d > 0 {
+				ctx, _ = worker.WithGroupTimeout(ctx, d)
+			}
+		}
+		// The query reads data committed at most the duration of the metadata max-staleness ago,
+		// as with the maxStaleness parameter of /query.
+		if staleness := md.Get("max-staleness"); len(staleness) > 0 {
+			d, err := time.ParseDuration(staleness[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "while parsing the metadata max-staleness")
+			}
+			if d < 0 {
+				return nil, errors.Errorf("The metadata max-staleness can't be negative")
+			}
+			if d > 0 {
+				if req.GetStartTs() != 0 || ctx.Value(ReadTs) != nil || len(req.GetMutations()) > 0 {
+					return nil, errors.Errorf("The metadata max-staleness can only be used by a " +
+						"read-only query without startTs or readTs")
+				}
+				req.ReadOnly = true
+				ctx = context.WithValue(ctx, MaxStaleness, d)
+			}
+		}
+		// With the metadata strict-uids: true, the uids of the uid function which don't have any
+		// data are sent back in the header dgraph-missinguids, and their number in the header
+		// dgraph-missinguids-total.
+		if strict := md.Get("strict-uids"); len(strict) > 0 {
+			ok, err := strconv.ParseBool(strict[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "while parsing the metadata strict-uids")
+			}
+			if ok {
+				ctx = context.WithValue(ctx, query.StrictUidsKey, &query.MissingUids{})
+			}
+		}
+	}
+	if ctx.Value(query.TruncatedKey) == nil {
+		// The HTTP requests pass their own, which is reported in the extensions.
+		ctx = context.WithValue(ctx, query.TruncatedKey, &query.Truncated{})
+	}
 	return s.doQuery(ctx, &Request{req: req, doAuth: getAuthMode(ctx)})
 }
 
+// wantsMsgpackOverGrpc returns true if a gRPC client asks for the results in MessagePack with the
+// metadata response-format: msgpack, as HTTP clients do with the Accept header. The values of
+// binary predicates are then raw bytes instead of base64 strings.
+func wantsMsgpackOverGrpc(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	format := md.Get("response-format")
+	return len(format) > 0 && format[0] == "msgpack"
+}
+
 var pendingQueries int64
 var maxPendingQueries int64
 var serverOverloadErr = errors.New("429 Too Many Requests. Please throttle your requests")
@@ -1248,6 +1516,16 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 	if isMutation {
 		ostats.Record(ctx, x.NumMutations.M(1))
 	}
+	if isQuery {
+		if rerr = worker.CheckNamespaceQueryRate(ctx); rerr != nil {
+			return
+		}
+	}
+	if isMutation {
+		if rerr = worker.CheckNamespaceMutationRate(ctx); rerr != nil {
+			return
+		}
+	}
 
 	if req.doAuth == NeedAuthorize && x.IsGalaxyOperation(ctx) {
 		// Only the guardian of the galaxy can do a galaxy wide query/mutation. This operation is
@@ -1260,15 +1538,19 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 	}
 
 	qc := &queryContext{
-		req:      req.req,
-		latency:  l,
-		span:     span,
-		graphql:  isGraphQL,
-		gqlField: req.gqlField,
+		req:          req.req,
+		latency:      l,
+		span:         span,
+		graphql:      isGraphQL,
+		gqlField:     req.gqlField,
+		resultWriter: req.resultWriter,
 	}
 	if rerr = parseRequest(qc); rerr != nil {
 		return
 	}
+	if rerr = resolveAliases(ctx, qc); rerr != nil {
+		return
+	}
 
 	if req.doAuth == NeedAuthorize {
 		if rerr = authorizeRequest(ctx, qc); rerr != nil {
@@ -1276,6 +1558,23 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 		}
 	}
 
+	if isQuery {
+		// Register the query so that it can be listed and cancelled through the admin API.
+		ns, _ := x.ExtractNamespace(ctx)
+		var deregister func()
+		ctx, deregister = runningQueries.register(ctx, ns, req.req.Query)
+		defer deregister()
+	}
+
+	// A request of a transaction in read-committed mode reads the data committed when it runs. The
+	// ts is a new one, not a read-only one, so that the data committed at it is always known.
+	if mode, _ := ctx.Value(Isolation).(string); mode == worker.IsolationReadCommitted &&
+		req.req.StartTs != 0 && !req.req.ReadOnly && !x.WorkerConfig.LudicrousEnabled {
+		start := time.Now()
+		qc.readCommittedTs = worker.State.GetTimestamp(false)
+		qc.latency.AssignTimestamp += time.Since(start)
+	}
+
 	// We use defer here because for queries, startTs will be
 	// assigned in the processQuery function called below.
 	defer annotateStartTs(qc.span, qc.req.StartTs)
@@ -1335,6 +1634,22 @@ func (s *Server) doQuery(ctx context.Context, req *Request) (resp *api.Response,
 		TotalNs:           uint64((time.Since(l.Start)).Nanoseconds()),
 	}
 	md := metadata.Pairs(x.DgraphCostHeader, fmt.Sprint(resp.Metrics.NumUids["_total"]))
+	if mu, ok := ctx.Value(query.StrictUidsKey).(*query.MissingUids); ok && mu.Total > 0 {
+		md.Append(x.DgraphMissingUidsHeader, mu.String())
+		md.Append(x.DgraphMissingUidsTotalHeader, strconv.Itoa(mu.Total))
+	}
+	if tr, ok := ctx.Value(query.TruncatedKey).(*query.Truncated); ok && len(tr.Blocks) > 0 {
+		md.Append(x.DgraphTruncatedHeader, strings.Join(tr.Blocks, ","))
+	}
+	if p := worker.PartialResultsOf(ctx); p != nil {
+		if gids := p.TimedOutGroups(); len(gids) > 0 {
+			strs := make([]string, 0, len(gids))
+			for _, gid := range gids {
+				strs = append(strs, strconv.FormatUint(uint64(gid), 10))
+			}
+			md.Append(x.DgraphIncompleteGroupsHeader, strings.Join(strs, ","))
+		}
+	}
 	grpc.SendHeader(ctx, md)
 	return resp, gqlErrs
 }
@@ -1354,9 +1669,17 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 	if x.WorkerConfig.LudicrousEnabled {
 		qc.req.StartTs = posting.Oracle().MaxAssigned()
 	}
+	// Keep track of the memory used by the query, so that it can be rejected under memory
+	// pressure instead of the Alpha running out of memory.
+	ctx, releaseMemory, err := query.WithMemoryAccount(ctx)
+	if err != nil {
+		return resp, err
+	}
+	defer releaseMemory()
 	qr := query.Request{
 		Latency:  qc.latency,
 		GqlQuery: &qc.gqlRes,
+		Upsert:   len(qc.gmuList) > 0,
 	}
 
 	// Here we try our best effort to not contact Zero for a timestamp. If we succeed,
@@ -1371,6 +1694,16 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		qc.span.Annotate([]otrace.Attribute{otrace.BoolAttribute("no", true)}, "")
 	}
 
+	if maxStaleness, _ := ctx.Value(MaxStaleness).(time.Duration); maxStaleness > 0 &&
+		qc.req.StartTs == 0 && !qc.req.BestEffort {
+		if !qc.req.ReadOnly {
+			return resp, errors.Errorf("A query with a max staleness must be read-only.")
+		}
+		qc.req.StartTs = worker.BoundedStalenessTs(ctx, maxStaleness)
+		// As for the best effort queries, the timestamp isn't always from Zero.
+		qr.Cache = worker.NoCache
+	}
+
 	if qc.req.BestEffort {
 		// Sanity: check that request is read-only too.
 		if !qc.req.ReadOnly {
@@ -1382,6 +1715,16 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		qr.Cache = worker.NoCache
 	}
 
+	if readTs, _ := ctx.Value(ReadTs).(uint64); readTs != 0 {
+		if err := validateReadTs(readTs); err != nil {
+			return resp, err
+		}
+		qc.req.StartTs = readTs
+		// The transaction cache could hold the uncommitted writes of a transaction which has
+		// readTs as its start ts, so the query must only read committed data.
+		qr.Cache = worker.NoCache
+	}
+
 	if qc.req.StartTs == 0 {
 		assignTimestampStart := time.Now()
 		qc.req.StartTs = worker.State.GetTimestamp(qc.req.ReadOnly)
@@ -1389,8 +1732,18 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 	}
 
 	qr.ReadTs = qc.req.StartTs
+	if qc.readCommittedTs != 0 {
+		qr.ReadTs, qr.TxnStartTs = qc.readCommittedTs, qc.req.StartTs
+	}
 	resp.Txn = &api.TxnContext{StartTs: qc.req.StartTs}
 
+	// The query has a group timeout if the context was given one with worker.WithGroupTimeout.
+	partial := worker.PartialResultsOf(ctx)
+	if partial != nil && !qc.req.ReadOnly {
+		// The results of an upsert query are used by its mutations, they can't be partial.
+		return resp, errors.Errorf("A query with a group timeout must be read-only.")
+	}
+
 	// Core processing happens here.
 	er, err := qr.Process(ctx)
 
@@ -1425,8 +1778,24 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 			respMap["types"] = formatTypes(er.Types)
 		}
 		resp.Json, err = json.Marshal(respMap)
+		if err == nil && query.WantsMsgpack(ctx) {
+			resp.Json, err = query.JSONToMsgpack(resp.Json)
+		}
+	} else if er.History != nil {
+		var history []byte
+		if history, err = query.HistoryToJson(er.History); err == nil {
+			resp.Json, err = json.Marshal(map[string]json.RawMessage{"history": history})
+		}
+		if err == nil && query.WantsMsgpack(ctx) {
+			resp.Json, err = query.JSONToMsgpack(resp.Json)
+		}
+	} else if qc.resultWriter != nil && qc.gqlField == nil {
+		err = query.WriteJson(ctx, qc.latency, er.Subgraphs, qc.resultWriter)
 	} else if qc.req.RespFormat == api.Request_RDF {
 		resp.Rdf, err = query.ToRDF(qc.latency, er.Subgraphs)
+	} else if query.WantsMsgpack(ctx) && qc.gqlField == nil {
+		// The HTTP endpoint returns the whole response in MessagePack, Json holds the data.
+		resp.Json, err = query.ToMsgpack(ctx, qc.latency, er.Subgraphs)
 	} else {
 		resp.Json, err = query.ToJson(ctx, qc.latency, er.Subgraphs, qc.gqlField)
 	}
@@ -1464,6 +1833,12 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		if len(uidList) > 1e6 {
 			return resp, errors.Errorf("var [%v] has over million UIDs", name)
 		}
+		if limit := x.Config.LimitUpsertMatch; limit > 0 && len(uidList) > limit &&
+			!isCondVar(qc, name) {
+			return resp, errors.Errorf("Upsert variable [%v] matches %d uids, more than the "+
+				"limit of %d set by --limit upsert-match. Split the upsert into smaller ones, "+
+				"e.g. by paginating its query with first", name, len(uidList), limit)
+		}
 
 		uids := make([]string, len(uidList))
 		for i, u := range uidList {
@@ -1487,6 +1862,13 @@ func processQuery(ctx context.Context, qc *queryContext) (*api.Response, error)
 		total += num
 	}
 	resp.Metrics.NumUids["_total"] = total
+	if partial != nil {
+		// The groups are reported in the extensions of HTTP and the headers of gRPC.
+		if gids := partial.TimedOutGroups(); len(gids) > 0 {
+			glog.Warningf("Returning partial results, groups %v didn't reply within %s",
+				gids, partial.Timeout())
+		}
+	}
 
 	return resp, err
 }
@@ -1695,11 +2077,12 @@ func parseMutationObject(mu *api.Mutation, qc *queryContext) (*gql.Mutation, err
 		res.Del = append(res.Del, nqs...)
 	}
 	if len(mu.SetNquads) > 0 {
-		nqs, md, err := chunker.ParseRDFs(mu.SetNquads)
+		nqs, incrs, md, err := chunker.ParseRDFsWithIncrements(mu.SetNquads)
 		if err != nil {
 			return nil, err
 		}
 		res.Set = append(res.Set, nqs...)
+		res.Incr = incrs
 		res.Metadata = md
 	}
 	if len(mu.DelNquads) > 0 {
@@ -1722,6 +2105,10 @@ func parseMutationObject(mu *api.Mutation, qc *queryContext) (*gql.Mutation, err
 	if err := validateNQuads(res.Set, res.Del, qc); err != nil {
 		return nil, err
 	}
+	// The increments are validated as the set N-Quads.
+	if err := validateNQuads(res.Incr, nil, qc); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
@@ -1786,6 +2173,9 @@ func validateNQuads(set, del []*api.NQuad, qc *queryContext) error {
 		if nq.Subject == x.Star || (nq.Predicate == x.Star && !ostar) {
 			return errors.Errorf("Only valid wildcard delete patterns are 'S * *' and 'S P *': %v", nq)
 		}
+		if nq.ObjectId == chunker.UUIDObject {
+			return errors.Errorf("uuid() can only be used in set mutations: %v", nq)
+		}
 		if err := validateForGraphql(nq, qc.graphql); err != nil {
 			return err
 		}
@@ -1862,6 +2252,15 @@ func formatTypes(typeList []*pb.TypeUpdate) []map[string]interface{} {
 			fields[i] = m
 		}
 		typeMap["fields"] = fields
+		if typ.SoftDelete != "" {
+			typeMap["softDelete"] = typ.SoftDelete
+		}
+		if typ.CascadeDelete != "" {
+			typeMap["cascadeDelete"] = typ.CascadeDelete
+			if typ.CascadeDeleteForce {
+				typeMap["cascadeDeleteForce"] = true
+			}
+		}
 
 		res = append(res, typeMap)
 	}
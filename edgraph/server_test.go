@@ -17,6 +17,7 @@
 package edgraph
 
 import (
+	"container/list"
 	"context"
 	"github.com/dgraph-io/dgraph/schema"
 	"google.golang.org/grpc/metadata"
@@ -205,3 +206,72 @@ func TestParseSchemaFromAlterOperation(t *testing.T) {
 	}
 
 }
+
+func TestKillQuery(t *testing.T) {
+	ctx1, done1 := runningQueries.register(context.Background(), x.GalaxyNamespace, "{q1}")
+	defer done1()
+	ctx2, done2 := runningQueries.register(context.Background(), 2, "{q2}")
+
+	queries := ActiveQueries(2)
+	require.Len(t, queries, 1)
+	require.Equal(t, "{q2}", queries[0].Query)
+	require.Len(t, ActiveQueries(x.GalaxyNamespace), 2)
+
+	// A namespace can't cancel the queries of other namespaces.
+	id1 := ActiveQueries(x.GalaxyNamespace)[0].Id
+	require.Error(t, KillQuery(2, id1))
+	require.NoError(t, ctx1.Err())
+
+	require.NoError(t, KillQuery(x.GalaxyNamespace, queries[0].Id))
+	require.Equal(t, context.Canceled, ctx2.Err())
+
+	// The query is no longer listed once it's done.
+	done2()
+	require.Len(t, ActiveQueries(2), 0)
+	require.Error(t, KillQuery(x.GalaxyNamespace, queries[0].Id))
+}
+
+func TestPersistedQueryCache(t *testing.T) {
+	var epoch uint64
+	newCache := func(size int) *persistedQueryCache {
+		return &persistedQueryCache{maxSize: size, lru: list.New(),
+			entries: make(map[string]*list.Element), dropEpoch: func() uint64 { return epoch }}
+	}
+	c := newCache(2)
+	c.set("a", "query a", 0)
+	c.set("b", "query b", 0)
+
+	q, _, ok := c.get("a")
+	require.True(t, ok)
+	require.Equal(t, "query a", q)
+
+	// b is the least recently used, so it's evicted.
+	c.set("c", "query c", 0)
+	_, _, ok = c.get("b")
+	require.False(t, ok)
+	_, _, ok = c.get("a")
+	require.True(t, ok)
+	_, _, ok = c.get("c")
+	require.True(t, ok)
+
+	// The queries are dropped along with the data.
+	epoch++
+	_, got, ok := c.get("a")
+	require.False(t, ok)
+	require.Equal(t, epoch, got)
+	_, _, ok = c.get("c")
+	require.False(t, ok)
+	// A query read before the drop isn't cached.
+	c.set("a", "query a", epoch-1)
+	_, _, ok = c.get("a")
+	require.False(t, ok)
+	c.set("a", "query a", epoch)
+	_, _, ok = c.get("a")
+	require.True(t, ok)
+
+	// Nothing is cached without a size.
+	c = newCache(0)
+	c.set("a", "query a", epoch)
+	_, _, ok = c.get("a")
+	require.False(t, ok)
+}
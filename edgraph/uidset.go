@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package edgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// queryUidSet finds the node of a uid set by its name.
+const queryUidSet = `
+	query set($name: string) {
+		set(func: eq(dgraph.uidset.name, $name)) @filter(type(dgraph.uidset)) {
+			s as uid
+		}
+	}`
+
+// SetUidSet stores the uid set with the given name in the namespace of the context, replacing
+// the set with the same name if there is one. The set is replaced in a single transaction, so a
+// query reads either the old members or the new ones. The set expires at expiresAt, unless it is
+// zero.
+func SetUidSet(ctx context.Context, name string, uids []uint64, expiresAt time.Time) error {
+	if name == "" {
+		return errors.New("The name of a uid set can't be empty")
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return errors.Errorf("The expiry of uid set %q is in the past", name)
+	}
+
+	// The node of the previous set is deleted and a new one is created, so that none of the
+	// previous members are left.
+	set := []*api.NQuad{
+		{
+			Subject:     "_:set",
+			Predicate:   "dgraph.uidset.name",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: name}},
+		},
+		{
+			Subject:     "_:set",
+			Predicate:   "dgraph.type",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{StrVal: "dgraph.uidset"}},
+		},
+	}
+	if !expiresAt.IsZero() {
+		set = append(set, &api.NQuad{
+			Subject:   "_:set",
+			Predicate: "dgraph.uidset.expiry",
+			ObjectValue: &api.Value{Val: &api.Value_StrVal{
+				StrVal: expiresAt.UTC().Format(time.RFC3339Nano)}},
+		})
+	}
+	for _, uid := range uids {
+		set = append(set, &api.NQuad{
+			Subject:   "_:set",
+			Predicate: "dgraph.uidset.members",
+			ObjectId:  fmt.Sprintf("%#x", uid),
+		})
+	}
+
+	_, err := updateUidSet(ctx, name, set)
+	return errors.Wrapf(err, "while setting uid set %q", name)
+}
+
+// DeleteUidSet deletes the uid set with the given name in the namespace of the context.
+func DeleteUidSet(ctx context.Context, name string) error {
+	found, err := updateUidSet(ctx, name, nil)
+	if err != nil {
+		return errors.Wrapf(err, "while deleting uid set %q", name)
+	}
+	if !found {
+		return errors.Errorf("Uid set %q not found", name)
+	}
+	return nil
+}
+
+// updateUidSet deletes the node of the uid set with the given name and sets the given nquads, in
+// a single upsert. It returns whether the set existed.
+func updateUidSet(ctx context.Context, name string, set []*api.NQuad) (bool, error) {
+	del := []*api.NQuad{{
+		Subject:     "uid(s)",
+		Predicate:   x.Star,
+		ObjectValue: &api.Value{Val: &api.Value_DefaultVal{DefaultVal: x.Star}},
+	}}
+	req := &Request{
+		req: &api.Request{
+			Query:     queryUidSet,
+			Vars:      map[string]string{"$name": name},
+			Mutations: []*api.Mutation{{Set: set, Del: del}},
+			CommitNow: true,
+		},
+		doAuth: NoAuthorize,
+	}
+	resp, err := (&Server{}).doQuery(context.WithValue(ctx, IsGraphql, true), req)
+	if err != nil {
+		return false, err
+	}
+
+	var res struct {
+		Set []struct {
+			Uid string `json:"uid"`
+		} `json:"set"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return false, err
+	}
+	return len(res.Set) > 0, nil
+}
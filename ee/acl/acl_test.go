@@ -2419,6 +2419,24 @@ func TestSchemaQueryWithACL(t *testing.T) {
       ],
       "list": true
     },
+    {
+      "predicate": "dgraph.uidset.expiry",
+      "type": "datetime"
+    },
+    {
+      "predicate": "dgraph.uidset.members",
+      "type": "uid",
+      "list": true
+    },
+    {
+      "predicate": "dgraph.uidset.name",
+      "type": "string",
+      "index": true,
+      "tokenizer": [
+        "exact"
+      ],
+      "upsert": true
+    },
     {
       "predicate": "dgraph.user.group",
       "type": "uid",
@@ -2490,6 +2508,20 @@ func TestSchemaQueryWithACL(t *testing.T) {
         }
       ],
       "name": "dgraph.type.User"
+    },
+    {
+      "fields": [
+        {
+          "name": "dgraph.uidset.name"
+        },
+        {
+          "name": "dgraph.uidset.members"
+        },
+        {
+          "name": "dgraph.uidset.expiry"
+        }
+      ],
+      "name": "dgraph.uidset"
     }
   ]
 }`
@@ -2524,6 +2556,10 @@ func TestSchemaQueryWithACL(t *testing.T) {
     {
       "fields": [],
       "name": "dgraph.type.User"
+    },
+    {
+      "fields": [],
+      "name": "dgraph.uidset"
     }
   ]
 }`
@@ -31,7 +31,9 @@ type Keys struct {
 	AclKey        x.SensitiveByteSlice
 	AclAccessTtl  time.Duration
 	AclRefreshTtl time.Duration
-	EncKey        x.SensitiveByteSlice
+	// AclQueryAllowlist is the path of the file holding the predicates some users can query.
+	AclQueryAllowlist string
+	EncKey            x.SensitiveByteSlice
 }
 
 const (
@@ -39,6 +41,7 @@ const (
 	flagAclAccessTtl  = "access-ttl"
 	flagAclRefreshTtl = "refresh-ttl"
 	flagAclSecretFile = "secret-file"
+	flagAclAllowlist  = "query-allowlist"
 
 	flagEnc        = "encryption"
 	flagEncKeyFile = "key-file"
@@ -66,10 +69,11 @@ func RegisterEncFlag(flag *pflag.FlagSet) {
 }
 
 var (
-	AclDefaults = fmt.Sprintf("%s=%s; %s=%s; %s=%s",
+	AclDefaults = fmt.Sprintf("%s=%s; %s=%s; %s=%s; %s=%s",
 		flagAclAccessTtl, "6h",
 		flagAclRefreshTtl, "30d",
-		flagAclSecretFile, "")
+		flagAclSecretFile, "",
+		flagAclAllowlist, "")
 	EncDefaults = fmt.Sprintf("%s=%s", flagEncKeyFile, "")
 )
 
@@ -131,6 +135,11 @@ func registerAclFlag(flag *pflag.FlagSet) {
 			"The TTL for the access JWT.").
 		Flag("refresh-ttl",
 			"The TTL for the refresh JWT.").
+		Flag("query-allowlist",
+			"A JSON file restricting the predicates some users can query on top of their ACL "+
+				`permissions, e.g. [{"namespace": 0, "user": "public", "predicates": ["name"]}]. `+
+				"DQL queries using other predicates, or expanding the predicates of a type, are "+
+				"rejected, and expand(_all_) only returns the allowed predicates.").
 		String()
 	flag.String(flagAcl, AclDefaults, helpText)
 }
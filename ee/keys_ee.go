@@ -62,6 +62,7 @@ func GetKeys(config *viper.Viper) (*Keys, error) {
 	// Get remaining keys
 	keys.AclAccessTtl = aclSuperFlag.GetDuration(flagAclAccessTtl)
 	keys.AclRefreshTtl = aclSuperFlag.GetDuration(flagAclRefreshTtl)
+	keys.AclQueryAllowlist = aclSuperFlag.GetPath(flagAclAllowlist)
 
 	return keys, nil
 }
@@ -65,7 +65,7 @@ type MathTree struct {
 
 func isUnary(f string) bool {
 	return f == "exp" || f == "ln" || f == "u-" || f == "sqrt" ||
-		f == "floor" || f == "ceil" || f == "since"
+		f == "floor" || f == "ceil" || f == "since" || isCoercionFunc(f)
 }
 
 func isBinaryMath(f string) bool {
@@ -163,7 +163,8 @@ func isMathFunc(f string) bool {
 		f == "==" || f == "!=" ||
 		f == "min" || f == "max" || f == "sqrt" ||
 		f == "pow" || f == "logbase" || f == "floor" || f == "ceil" ||
-		f == "since"
+		f == "since" || f == "coalesce" || isCoercionFunc(f) ||
+		f == "jaro" || f == "jarowinkler" || f == "cosine"
 }
 
 func parseMathFunc(it *lex.ItemIterator, again bool) (*MathTree, bool, error) {
@@ -250,8 +251,18 @@ loop:
 				}
 				continue
 			}
-			// We will try to parse the constant as an Int first, if that fails we move to float
 			child := &MathTree{}
+			// Quoted values are string constants, used by the string similarity functions.
+			if strings.HasPrefix(item.Val, `"`) {
+				s, err := unquoteIfQuoted(item.Val)
+				if err != nil {
+					return nil, false, err
+				}
+				child.Const = types.Val{Tid: types.StringID, Value: s}
+				valueStack.push(child)
+				continue
+			}
+			// We will try to parse the constant as an Int first, if that fails we move to float
 			i, err := strconv.ParseInt(item.Val, 10, 64)
 			if err != nil {
 				v, err := strconv.ParseFloat(item.Val, 64)
@@ -373,6 +384,8 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 				t.Const.Value.(float64), 'E', -1, 64))
 		case types.IntID:
 			leafStr, err = buf.WriteString(strconv.FormatInt(t.Const.Value.(int64), 10))
+		case types.StringID:
+			leafStr, err = buf.WriteString(strconv.Quote(t.Const.Value.(string)))
 		}
 		x.Check2(leafStr, err)
 		return
@@ -382,7 +395,7 @@ func (t *MathTree) stringHelper(buf *bytes.Buffer) {
 	switch t.Fn {
 	case "+", "-", "/", "*", "%", "exp", "ln", "cond", "min",
 		"sqrt", "max", "<", ">", "<=", ">=", "==", "!=", "u-",
-		"logbase", "pow":
+		"logbase", "pow", "coalesce", "jaro", "jarowinkler", "cosine":
 		x.Check2(buf.WriteString(t.Fn))
 	default:
 		x.Fatalf("Unknown operator: %q", t.Fn)
@@ -32,9 +32,11 @@ var (
 
 // Mutation stores the strings corresponding to set and delete operations.
 type Mutation struct {
-	Cond         string
-	Set          []*api.NQuad
-	Del          []*api.NQuad
+	Cond string
+	Set  []*api.NQuad
+	Del  []*api.NQuad
+	// Incr holds the increments, whose values are added to the current ones of the predicates.
+	Incr         []*api.NQuad
 	AllowedPreds []string
 
 	Metadata *pb.Metadata
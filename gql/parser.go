@@ -37,8 +37,20 @@ const (
 	lenFunc   = "len"
 	countFunc = "count"
 	uidInFunc = "uid_in"
+	inSetFunc = "in_set"
+
+	toIntFunc    = "toint"
+	toFloatFunc  = "tofloat"
+	toStringFunc = "tostring"
+	splitFunc    = "split"
 )
 
+// isCoercionFunc returns true if f converts the values of a value variable to another type, as
+// in gt(toint(val(x)), 5).
+func isCoercionFunc(f string) bool {
+	return f == toIntFunc || f == toFloatFunc || f == toStringFunc
+}
+
 var (
 	errExpandType = "expand is only compatible with type filters"
 )
@@ -70,11 +82,16 @@ type GraphQuery struct {
 	ShortestPathArgs ShortestPathArgs
 	Cascade          []string
 	IgnoreReflex     bool
+	TotalCount       bool
 	Facets           *pb.FacetParams
 	FacetsFilter     *FilterTree
 	GroupbyAttrs     []GroupByAttr
+	GroupbyPage      *GroupByPage
 	FacetVar         map[string]string
 	FacetsOrder      []*FacetOrder
+	Include          *IncludeArgs
+	Dedup            *DedupArgs
+	Intersect        *IntersectArgs
 
 	// Used for ACL enabled queries to curtail results to only accessible params
 	AllowedPreds []string
@@ -106,11 +123,51 @@ type ShortestPathArgs struct {
 	To   *Function
 }
 
+// IncludeArgs stores the arguments needed to process the @include and @skip directives.
+type IncludeArgs struct {
+	// Var is the boolean value variable used as the condition.
+	Var string
+	// Skip is true for @skip, the block is then left out for the uids where the condition holds.
+	Skip bool
+	// UidVar is true if Var is a uid variable, the condition then holds for the uids in it.
+	UidVar bool
+}
+
+// DedupArgs stores the arguments needed to process the @dedup directive.
+type DedupArgs struct {
+	// Var is the value variable holding the keys the results are deduplicated by. The uids are
+	// the keys if it's empty.
+	Var string
+}
+
+// IntersectArgs stores the arguments passed to the @intersect directive, a hint on how the results
+// of the filters joined by AND are intersected.
+type IntersectArgs struct {
+	// Order lists the predicates of the filters which are run first, in this order. Each of them
+	// is then only run on the uids matched by the previous ones.
+	Order []string
+	// Method is the algorithm used to intersect the results of the filters, merge or hash.
+	Method string
+}
+
 // GroupByAttr stores the arguments needed to process the @groupby directive.
 type GroupByAttr struct {
 	Attr  string
 	Alias string
 	Langs []string
+	// Split is the separator given to split(attr, sep), the values are then grouped by token.
+	Split string
+}
+
+// GroupByPage stores the order and the pagination of the groups of the @groupby directive, given
+// as in @groupby(genre, orderdesc: count, first: 20, offset: 20).
+type GroupByPage struct {
+	// Order is the name of a group key or of an aggregate the groups are ordered by.
+	Order string
+	Desc  bool
+	// First is the number of groups returned, 0 for all of them.
+	First  int
+	Offset int
 }
 
 // FacetOrder stores ordering for single facet key.
@@ -185,6 +242,9 @@ type Function struct {
 	IsCount    bool         // gt(count(friends),0)
 	IsValueVar bool         // eq(val(s), 5)
 	IsLenVar   bool         // eq(len(s), 5)
+	Coerce     string       // gt(toint(val(s)), 5)
+	Split      string       // eq(split(val(s), ","), "a")
+	Limit      int          // has(name)@limit(1000), only for the function at root
 }
 
 // filterOpPrecedence is a map from filterOp (a string) to its precedence.
@@ -194,18 +254,26 @@ var filterOpPrecedence = map[string]int{
 	"or":  1,
 }
 var mathOpPrecedence = map[string]int{
-	"u-":      500,
-	"floor":   105,
-	"ceil":    104,
-	"since":   103,
-	"exp":     100,
-	"ln":      99,
-	"sqrt":    98,
-	"cond":    90,
-	"pow":     89,
-	"logbase": 88,
-	"max":     85,
-	"min":     84,
+	"u-":       500,
+	"toint":    108,
+	"tofloat":  107,
+	"tostring": 106,
+	"floor":    105,
+	"ceil":     104,
+	"since":    103,
+	"exp":      100,
+	"ln":       99,
+	"sqrt":     98,
+	"cond":     90,
+	"pow":      89,
+	"logbase":  88,
+	"coalesce": 86,
+	"max":      85,
+	"min":      84,
+
+	"jaro":        83,
+	"jarowinkler": 82,
+	"cosine":      81,
 
 	"/": 50,
 	"*": 49,
@@ -231,6 +299,16 @@ func (f *Function) IsPasswordVerifier() bool {
 	return f.Name == "checkpwd"
 }
 
+// IsGeoDistance returns true if the function name is "distance".
+func (f *Function) IsGeoDistance() bool {
+	return f.Name == "distance"
+}
+
+// IsRelevance returns true if the function name is "relevance".
+func (f *Function) IsRelevance() bool {
+	return f.Name == "relevance"
+}
+
 // DebugPrint is useful for debugging.
 func (gq *GraphQuery) DebugPrint(prefix string) {
 	glog.Infof("%s[%x %q %q]\n", prefix, gq.UID, gq.Attr, gq.Alias)
@@ -527,6 +605,7 @@ type Result struct {
 	Query     []*GraphQuery
 	QueryVars []*Vars
 	Schema    *pb.SchemaRequest
+	History   *pb.HistoryRequest
 }
 
 // Parse initializes and runs the lexer. It also constructs the GraphQuery subgraph
@@ -539,20 +618,21 @@ func Parse(r Request) (Result, error) {
 //
 // The needVars parameter is passed in the case of upsert block.
 // For example, when parsing the query block inside -
-// upsert {
-//   query {
-//     me(func: eq(email, "someone@gmail.com"), first: 1) {
-//       v as uid
-//     }
-//   }
 //
-//   mutation {
-//     set {
-//       uid(v) <name> "Some One" .
-//       uid(v) <email> "someone@gmail.com" .
-//     }
-//   }
-// }
+//	upsert {
+//	  query {
+//	    me(func: eq(email, "someone@gmail.com"), first: 1) {
+//	      v as uid
+//	    }
+//	  }
+//
+//	  mutation {
+//	    set {
+//	      uid(v) <name> "Some One" .
+//	      uid(v) <email> "someone@gmail.com" .
+//	    }
+//	  }
+//	}
 //
 // The variable name v needs to be passed through the needVars parameter. Otherwise, an error
 // is reported complaining that the variable v is defined but not used in the query block.
@@ -587,6 +667,13 @@ func ParseWithNeedVars(r Request, needVars []string) (res Result, rerr error) {
 				if res.Schema, rerr = getSchema(it); rerr != nil {
 					return res, rerr
 				}
+			case "history":
+				if res.History != nil {
+					return res, item.Errorf("Only one history block allowed")
+				}
+				if res.History, rerr = getHistory(it); rerr != nil {
+					return res, rerr
+				}
 			case "fragment":
 				// TODO(jchiu0): This is to be done in ParseSchema once it is ready.
 				fnode, rerr := getFragment(it)
@@ -617,6 +704,10 @@ func ParseWithNeedVars(r Request, needVars []string) (res Result, rerr error) {
 		}
 	}
 
+	if res.History != nil && (len(res.Query) != 0 || res.Schema != nil) {
+		return res, errors.Errorf("History block is not allowed with other blocks")
+	}
+
 	if len(res.Query) != 0 {
 		res.QueryVars = make([]*Vars, 0, len(res.Query))
 		for i := 0; i < len(res.Query); i++ {
@@ -646,6 +737,9 @@ func ParseWithNeedVars(r Request, needVars []string) (res Result, rerr error) {
 		if err := checkDependency(allVars); err != nil {
 			return res, err
 		}
+		if err := checkBlockCycles(res.QueryVars); err != nil {
+			return res, err
+		}
 	}
 
 	if err := validateResult(&res); err != nil {
@@ -706,6 +800,85 @@ func checkDependency(vl []*Vars) error {
 	return nil
 }
 
+// checkBlockCycles returns an error if some query blocks can't be executed because they use the
+// variables of each other, e.g. when the block defining u uses o, and the block defining o uses u.
+// A block can use the variables it defines.
+func checkBlockCycles(vl []*Vars) error {
+	definedBy := make(map[string]int)
+	for i, v := range vl {
+		for _, name := range v.Defines {
+			definedBy[name] = i
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(vl))
+	// The blocks being visited, and the variables through which each of them uses the next one.
+	var blocks []int
+	var uses []string
+	var visit func(i int) error
+	visit = func(i int) error {
+		state[i] = visiting
+		blocks = append(blocks, i)
+		for _, name := range vl[i].Needs {
+			j, ok := definedBy[name]
+			if !ok || j == i {
+				continue
+			}
+			uses = append(uses, name)
+			switch state[j] {
+			case visiting:
+				start := 0
+				for k, b := range blocks {
+					if b == j {
+						start = k
+					}
+				}
+				// A block is named after the variable through which the previous one uses it.
+				cycle := uses[start:]
+				parts := make([]string, 0, len(cycle))
+				for k, used := range cycle {
+					defined := cycle[(k+len(cycle)-1)%len(cycle)]
+					parts = append(parts, fmt.Sprintf("the block defining %s uses %s",
+						defined, used))
+				}
+				return errors.Errorf("Cycle detected between query blocks: %s",
+					strings.Join(parts, ", "))
+			case unvisited:
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+			uses = uses[:len(uses)-1]
+		}
+		blocks = blocks[:len(blocks)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range vl {
+		if state[i] == unvisited {
+			if err := visit(i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UsesValueVar returns true if name is a value variable used by the block.
+func (gq *GraphQuery) UsesValueVar(name string) bool {
+	for _, v := range gq.NeedsVar {
+		if v.Name == name && v.Typ == ValueVar {
+			return true
+		}
+	}
+	return false
+}
+
 func (gq *GraphQuery) collectVars(v *Vars) {
 	if gq.Var != "" {
 		v.Defines = append(v.Defines, gq.Var)
@@ -775,6 +948,44 @@ func (f *FilterTree) hasVars() bool {
 	return false
 }
 
+// ParseVariableTypes returns the types of the variables declared by a named query, like
+// {"$name": "string!"} for query q($name: string!) { ... }. The rest of the query isn't parsed.
+func ParseVariableTypes(query string) (map[string]string, error) {
+	var lexer lex.Lexer
+	lexer.Reset(query)
+	lexer.Run(lexTopLevel)
+	if err := lexer.ValidateResult(); err != nil {
+		return nil, err
+	}
+
+	vmap := make(varMap)
+	it := lexer.NewIterator()
+L:
+	for it.Next() {
+		item := it.Item()
+		if item.Typ != itemOpType || item.Val != "query" {
+			continue
+		}
+		for it.Next() {
+			switch item = it.Item(); item.Typ {
+			case itemLeftRound:
+				if err := parseGqlVariables(it, vmap); err != nil {
+					return nil, err
+				}
+				break L
+			case itemLeftCurl:
+				break L
+			}
+		}
+	}
+
+	types := make(map[string]string, len(vmap))
+	for name, v := range vmap {
+		types[name] = v.Type
+	}
+	return types, nil
+}
+
 // getVariablesAndQuery checks if the query has a variable list and stores it in
 // vmap. For variable list to be present, the query should have a name which is
 // also checked for. It also calls getQuery to create the GraphQuery object tree.
@@ -969,11 +1180,21 @@ L:
 				}
 			case "ignorereflex":
 				gq.IgnoreReflex = true
+			case "dedup":
+				if err := parseDedup(it, gq); err != nil {
+					return nil, err
+				}
+			case "totalcount":
+				gq.TotalCount = true
 			case "recurse":
 				gq.Recurse = true
 				if err := parseRecurseArgs(it, gq); err != nil {
 					return nil, err
 				}
+			case "intersect":
+				if err := parseIntersect(it, gq); err != nil {
+					return nil, err
+				}
 			default:
 				return nil, item.Errorf("Unknown directive [%s]", item.Val)
 			}
@@ -1150,6 +1371,65 @@ func getSchema(it *lex.ItemIterator) (*pb.SchemaRequest, error) {
 	return nil, it.Errorf("Invalid schema block.")
 }
 
+// getHistory parses history(uid: 0x1, pred: status). The predicate can also be given as a
+// string or an IRI.
+func getHistory(it *lex.ItemIterator) (*pb.HistoryRequest, error) {
+	var h pb.HistoryRequest
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return nil, it.Item().Errorf("Expected ( after history")
+	}
+	for it.Next() {
+		item := it.Item()
+		switch item.Typ {
+		case itemRightRound:
+			switch {
+			case h.Uid == 0:
+				return nil, item.Errorf("Argument uid is required in history block")
+			case h.Attr == "":
+				return nil, item.Errorf("Argument pred is required in history block")
+			}
+			return &h, nil
+		case itemComma:
+			continue
+		case itemName:
+		default:
+			return nil, item.Errorf("Invalid history block")
+		}
+
+		arg := item.Val
+		if !it.Next() || it.Item().Typ != itemColon {
+			return nil, it.Item().Errorf("Expected : after %s in history block", arg)
+		}
+		if !it.Next() || it.Item().Typ != itemName {
+			return nil, it.Item().Errorf("Expected a value for %s in history block", arg)
+		}
+		val := it.Item()
+		switch arg {
+		case "uid":
+			uid, err := strconv.ParseUint(val.Val, 0, 64)
+			if err != nil || uid == 0 {
+				return nil, val.Errorf("Invalid uid %s in history block", val.Val)
+			}
+			h.Uid = uid
+		case "pred":
+			pred := val.Val
+			if strings.HasPrefix(pred, `"`) {
+				var err error
+				if pred, err = strconv.Unquote(pred); err != nil {
+					return nil, val.Errorf("Invalid predicate %s in history block", val.Val)
+				}
+			}
+			if pred == "" || strings.HasPrefix(pred, "~") {
+				return nil, val.Errorf("Invalid predicate %s in history block", val.Val)
+			}
+			h.Attr = pred
+		default:
+			return nil, item.Errorf("Invalid argument %s in history block", arg)
+		}
+	}
+	return nil, it.Errorf("Invalid history block")
+}
+
 // parseGqlVariables parses the the graphQL variable declaration.
 func parseGqlVariables(it *lex.ItemIterator, vmap varMap) error {
 	expectArg := true
@@ -1619,7 +1899,8 @@ func validFuncName(name string) bool {
 
 	switch name {
 	case "regexp", "anyofterms", "allofterms", "alloftext", "anyoftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", "percentile_above",
+		"reachable", "in_set":
 		return true
 	}
 	return false
@@ -1725,6 +2006,36 @@ L:
 				case countFunc:
 					function.Attr = nestedFunc.Attr
 					function.IsCount = true
+				case toIntFunc, toFloatFunc, toStringFunc:
+					if !nestedFunc.IsValueVar {
+						return nil, itemInFunc.Errorf("%s only accepts a value variable, e.g. "+
+							"%s(val(x))", nestedFunc.Name, nestedFunc.Name)
+					}
+					if !IsInequalityFn(function.Name) || len(function.Attr) != 0 {
+						return nil, itemInFunc.Errorf("%s is only allowed as the first argument "+
+							"of an inequality function", nestedFunc.Name)
+					}
+					function.Attr = nestedFunc.Attr
+					function.IsValueVar = true
+					function.Coerce = nestedFunc.Name
+					function.NeedsVar = append(function.NeedsVar, nestedFunc.NeedsVar...)
+				case splitFunc:
+					if !nestedFunc.IsValueVar || len(nestedFunc.Args) != 1 ||
+						nestedFunc.Args[0].IsGraphQLVar {
+						return nil, itemInFunc.Errorf("split expects a value variable and a " +
+							"separator, e.g. split(val(x), \",\")")
+					}
+					if nestedFunc.Args[0].Value == "" {
+						return nil, itemInFunc.Errorf("split expects a non-empty separator")
+					}
+					if !IsInequalityFn(function.Name) || len(function.Attr) != 0 {
+						return nil, itemInFunc.Errorf("split is only allowed as the first " +
+							"argument of an inequality function")
+					}
+					function.Attr = nestedFunc.Attr
+					function.IsValueVar = true
+					function.Split = nestedFunc.Args[0].Value
+					function.NeedsVar = append(function.NeedsVar, nestedFunc.NeedsVar...)
 				case uidFunc:
 					// TODO (Anurag): See if is is possible to support uid(1,2,3) when
 					// uid is nested inside a function like @filter(uid_in(predicate, uid()))
@@ -1740,8 +2051,8 @@ L:
 					function.NeedsVar[0].Typ = UidVar
 					function.Args = append(function.Args, Arg{Value: nestedFunc.NeedsVar[0].Name})
 				default:
-					return nil, itemInFunc.Errorf("Only val/count/len/uid allowed as function "+
-						"within another. Got: %s", nestedFunc.Name)
+					return nil, itemInFunc.Errorf("Only val/count/len/uid/toint/tofloat/tostring/"+
+						"split allowed as function within another. Got: %s", nestedFunc.Name)
 				}
 				expectArg = false
 				continue
@@ -1774,7 +2085,7 @@ L:
 			case itemLeftSquare:
 				var err error
 				switch {
-				case isGeoFunc(function.Name):
+				case isGeoFunc(function.Name) || function.Name == "distance":
 					err = parseGeoArgs(it, function)
 
 				case IsInequalityFn(function.Name):
@@ -1783,6 +2094,17 @@ L:
 				case function.Name == "uid_in":
 					err = parseFuncArgs(it, function)
 
+				case function.Name == "anyof" || function.Name == "allof":
+					// The list of values is the set to match, e.g. allof(tags, ["a", "b"]). The
+					// empty tokenizer tells it apart from anyof(pred, "tokenizer", "value").
+					if len(function.Args) > 0 {
+						err = itemInFunc.Errorf("Function %s expects either a tokenizer and a "+
+							"value or a list of values", function.Name)
+						break
+					}
+					function.Args = append(function.Args, Arg{})
+					err = parseFuncArgs(it, function)
+
 				default:
 					err = itemInFunc.Errorf("Unexpected character [ while parsing request.")
 				}
@@ -1848,7 +2170,7 @@ L:
 			// Unlike other functions, uid function has no attribute, everything is args.
 			switch {
 			case len(function.Attr) == 0 && function.Name != uidFunc &&
-				function.Name != typFunc:
+				function.Name != typFunc && function.Name != inSetFunc:
 
 				if strings.ContainsRune(itemInFunc.Val, '"') {
 					return nil, itemInFunc.Errorf("Attribute in function"+
@@ -1918,7 +2240,8 @@ L:
 		}
 	}
 
-	if function.Name != uidFunc && function.Name != typFunc && len(function.Attr) == 0 {
+	if function.Name != uidFunc && function.Name != typFunc && function.Name != inSetFunc &&
+		len(function.Attr) == 0 {
 		return nil, it.Errorf("Got empty attr for function: [%s]", function.Name)
 	}
 
@@ -1926,6 +2249,11 @@ L:
 		return nil, it.Errorf("type function only supports one argument. Got: %v", function.Args)
 	}
 
+	if function.Name == inSetFunc && len(function.Args) != 1 {
+		return nil, it.Errorf("in_set function expects the name of a uid set. Got: %v",
+			function.Args)
+	}
+
 	return function, nil
 }
 
@@ -2113,7 +2441,7 @@ func tryParseFacetList(it *lex.ItemIterator) (res facetRes, parseOk bool, err er
 
 // parseCascade parses the cascade directive.
 // Two formats:
-// 	1. @cascade
+//  1. @cascade
 //  2. @cascade(pred1, pred2, ...)
 func parseCascade(it *lex.ItemIterator, gq *GraphQuery) error {
 	item := it.Item()
@@ -2206,6 +2534,13 @@ loop:
 			if err != nil {
 				return err
 			}
+			if peekIt[0].Typ == itemColon && isGroupbyPageKey(val) && alias == "" {
+				if err := parseGroupbyPage(it, gq, val); err != nil {
+					return err
+				}
+				expectArg = false
+				continue
+			}
 			if peekIt[0].Typ == itemColon {
 				if alias != "" {
 					return item.Errorf("Expected predicate after %s:", alias)
@@ -2217,6 +2552,18 @@ loop:
 				it.Next() // Consume the itemColon
 				continue
 			}
+			if val == splitFunc && peekIt[0].Typ == itemLeftRound {
+				attr, err := parseGroupbySplit(it)
+				if err != nil {
+					return err
+				}
+				attr.Alias = alias
+				alias = ""
+				gq.GroupbyAttrs = append(gq.GroupbyAttrs, attr)
+				count++
+				expectArg = false
+				continue
+			}
 
 			var langs []string
 			items, err := it.Peek(1)
@@ -2249,6 +2596,269 @@ loop:
 	return nil
 }
 
+func isGroupbyPageKey(key string) bool {
+	switch key {
+	case "orderasc", "orderdesc", "first", "offset":
+		return true
+	}
+	return false
+}
+
+// parseGroupbyPage parses an option of the order or the pagination of the groups inside
+// @groupby, e.g. first: 20, the iterator being at the key.
+func parseGroupbyPage(it *lex.ItemIterator, gq *GraphQuery, key string) error {
+	it.Next() // Consume the colon.
+	if !it.Next() || it.Item().Typ != itemName {
+		return it.Item().Errorf("Expected a value for %s in groupby", key)
+	}
+	item := it.Item()
+	if gq.GroupbyPage == nil {
+		gq.GroupbyPage = &GroupByPage{}
+	}
+	page := gq.GroupbyPage
+	switch key {
+	case "orderasc", "orderdesc":
+		if page.Order != "" {
+			return item.Errorf("Only one order is allowed in groupby")
+		}
+		page.Order = collectName(it, item.Val)
+		page.Desc = key == "orderdesc"
+	default:
+		n, err := strconv.Atoi(item.Val)
+		if err != nil || n < 0 {
+			return item.Errorf("Expected a non-negative number for %s in groupby, got: %v",
+				key, item.Val)
+		}
+		if key == "first" {
+			page.First = n
+		} else {
+			page.Offset = n
+		}
+	}
+	return nil
+}
+
+// parseGroupbySplit parses split(attr, "sep") inside @groupby, the iterator being at split.
+func parseGroupbySplit(it *lex.ItemIterator) (GroupByAttr, error) {
+	var attr GroupByAttr
+	it.Next() // Consume the left round.
+	if !it.Next() || it.Item().Typ != itemName {
+		return attr, it.Item().Errorf("Expected a predicate in split()")
+	}
+	attr.Attr = collectName(it, it.Item().Val)
+	if items, err := it.Peek(1); err == nil && items[0].Typ == itemAt {
+		it.Next() // consume '@'
+		it.Next() // move forward
+		if attr.Langs, err = parseLanguageList(it); err != nil {
+			return attr, err
+		}
+	}
+	if !it.Next() || it.Item().Typ != itemComma {
+		return attr, it.Item().Errorf("Expected a separator after %s in split()", attr.Attr)
+	}
+	if !it.Next() || it.Item().Typ != itemName {
+		return attr, it.Item().Errorf("Expected a separator after %s in split()", attr.Attr)
+	}
+	sep, err := unquoteIfQuoted(it.Item().Val)
+	if err != nil {
+		return attr, err
+	}
+	if sep == "" {
+		return attr, it.Item().Errorf("split expects a non-empty separator")
+	}
+	attr.Split = sep
+	if !it.Next() || it.Item().Typ != itemRightRound {
+		return attr, it.Item().Errorf("Expected ) after the separator of split()")
+	}
+	return attr, nil
+}
+
+// parseInclude parses the @include(if: val(x)) and @skip(if: val(x)) directives, and their
+// @include(if: uid(x)) and @skip(if: uid(x)) forms for uid variables.
+func parseInclude(it *lex.ItemIterator, gq *GraphQuery, skip bool) error {
+	name := it.Item().Val
+	expect := func(typ lex.ItemType, val string) error {
+		if !it.Next() {
+			return it.Errorf("Invalid use of @%s directive", name)
+		}
+		item := it.Item()
+		if item.Typ != typ || (val != "" && strings.ToLower(item.Val) != val) {
+			return item.Errorf("Expected @%s(if: val(<var>)) or @%s(if: uid(<var>)) but got: %s",
+				name, name, item.Val)
+		}
+		return nil
+	}
+
+	if err := expect(itemLeftRound, ""); err != nil {
+		return err
+	}
+	if err := expect(itemName, "if"); err != nil {
+		return err
+	}
+	if err := expect(itemColon, ""); err != nil {
+		return err
+	}
+	if err := expect(itemName, ""); err != nil {
+		return err
+	}
+	fn := strings.ToLower(it.Item().Val)
+	if fn != "val" && fn != "uid" {
+		return it.Item().Errorf("Expected @%s(if: val(<var>)) or @%s(if: uid(<var>)) but got: %s",
+			name, name, it.Item().Val)
+	}
+	if err := expect(itemLeftRound, ""); err != nil {
+		return err
+	}
+	if err := expect(itemName, ""); err != nil {
+		return err
+	}
+	varName := it.Item().Val
+	if err := expect(itemRightRound, ""); err != nil {
+		return err
+	}
+	if err := expect(itemRightRound, ""); err != nil {
+		return err
+	}
+
+	gq.Include = &IncludeArgs{Var: varName, Skip: skip, UidVar: fn == "uid"}
+	typ := ValueVar
+	if fn == "uid" {
+		typ = UidVar
+	}
+	gq.NeedsVar = append(gq.NeedsVar, VarContext{Name: varName, Typ: typ})
+	return nil
+}
+
+// parseDedup parses the @dedup(by: val(x)) and @dedup(by: uid) directives.
+func parseDedup(it *lex.ItemIterator, gq *GraphQuery) error {
+	if gq.Dedup != nil {
+		return it.Item().Errorf("Only one @dedup directive allowed.")
+	}
+	expect := func(typ lex.ItemType, val string) error {
+		if !it.Next() {
+			return it.Errorf("Invalid use of @dedup directive")
+		}
+		item := it.Item()
+		if item.Typ != typ || (val != "" && strings.ToLower(item.Val) != val) {
+			return item.Errorf("Expected @dedup(by: val(<var>)) or @dedup(by: uid) but got: %s",
+				item.Val)
+		}
+		return nil
+	}
+
+	if err := expect(itemLeftRound, ""); err != nil {
+		return err
+	}
+	if err := expect(itemName, "by"); err != nil {
+		return err
+	}
+	if err := expect(itemColon, ""); err != nil {
+		return err
+	}
+	if err := expect(itemName, ""); err != nil {
+		return err
+	}
+	switch strings.ToLower(it.Item().Val) {
+	case "uid":
+		gq.Dedup = &DedupArgs{}
+	case "val":
+		if err := expect(itemLeftRound, ""); err != nil {
+			return err
+		}
+		if err := expect(itemName, ""); err != nil {
+			return err
+		}
+		varName := it.Item().Val
+		if err := expect(itemRightRound, ""); err != nil {
+			return err
+		}
+		gq.Dedup = &DedupArgs{Var: varName}
+		gq.NeedsVar = append(gq.NeedsVar, VarContext{Name: varName, Typ: ValueVar})
+	default:
+		return it.Item().Errorf("Expected @dedup(by: val(<var>)) or @dedup(by: uid) but got: %s",
+			it.Item().Val)
+	}
+	return expect(itemRightRound, "")
+}
+
+// parseIntersect parses the @intersect(order: [pred1, pred2], method: hash) directive. Both
+// arguments are optional. The hint is checked against the filters when the query is run.
+func parseIntersect(it *lex.ItemIterator, gq *GraphQuery) error {
+	if gq.Intersect != nil {
+		return it.Item().Errorf("Only one @intersect directive allowed.")
+	}
+	if ok := trySkipItemTyp(it, itemLeftRound); !ok {
+		return it.Errorf("Expected ( after @intersect")
+	}
+
+	args := &IntersectArgs{}
+	for it.Next() {
+		item := it.Item()
+		if item.Typ != itemName {
+			return item.Errorf("Expected key inside @intersect()")
+		}
+		key := strings.ToLower(item.Val)
+		if ok := trySkipItemTyp(it, itemColon); !ok {
+			return it.Errorf("Expected colon(:) after %s", key)
+		}
+
+		switch key {
+		case "order":
+			if args.Order != nil {
+				return item.Errorf("Repeated key order inside @intersect")
+			}
+			order, err := parseIntersectOrder(it)
+			if err != nil {
+				return err
+			}
+			args.Order = order
+		case "method":
+			if ok := trySkipItemTyp(it, itemName); !ok {
+				return it.Errorf("Expected a value for method inside @intersect")
+			}
+			args.Method = strings.ToLower(it.Item().Val)
+		default:
+			return item.Errorf("Unexpected key: [%s] inside @intersect", key)
+		}
+
+		if ok := trySkipItemTyp(it, itemRightRound); ok {
+			gq.Intersect = args
+			return nil
+		}
+		if ok := trySkipItemTyp(it, itemComma); !ok {
+			return it.Errorf("Expected comma after the value of %s inside @intersect", key)
+		}
+	}
+	return it.Errorf("Invalid use of @intersect directive")
+}
+
+// parseIntersectOrder parses the list of predicates of @intersect(order: [pred1, pred2]).
+func parseIntersectOrder(it *lex.ItemIterator) ([]string, error) {
+	if ok := trySkipItemTyp(it, itemLeftSquare); !ok {
+		return nil, it.Errorf("Expected a list of predicates for order inside @intersect")
+	}
+	order := []string{}
+	expectArg := true
+	for it.Next() {
+		item := it.Item()
+		switch {
+		case item.Typ == itemRightSquare && !expectArg:
+			return order, nil
+		case item.Typ == itemRightSquare && len(order) == 0:
+			return nil, item.Errorf("At least one predicate required in order inside @intersect")
+		case item.Typ == itemComma && !expectArg:
+			expectArg = true
+		case item.Typ == itemName && expectArg:
+			order = append(order, collectName(it, item.Val))
+			expectArg = false
+		default:
+			return nil, item.Errorf("Unexpected item inside the order of @intersect: %v",
+				item.Val)
+		}
+	}
+	return nil, it.Errorf("Expected ] after the order of @intersect")
+}
+
 // parseFilter parses the filter directive to produce a QueryFilter / parse tree.
 func parseFilter(it *lex.ItemIterator) (*FilterTree, error) {
 	it.Next()
@@ -2518,6 +3128,10 @@ func parseDirective(it *lex.ItemIterator, curp *GraphQuery) error {
 		}
 	case item.Val == "normalize":
 		curp.Normalize = true
+	case item.Val == "totalcount":
+		// The count of the nested blocks is given by count(predicate).
+		return item.Errorf("@totalcount is only supported at the root of a query block, "+
+			"use count(%s) for the nested blocks", curp.Attr)
 	case peek[0].Typ == itemLeftRound:
 		// this is directive
 		switch item.Val {
@@ -2542,6 +3156,21 @@ func parseDirective(it *lex.ItemIterator, curp *GraphQuery) error {
 			if err := parseGroupby(it, curp); err != nil {
 				return err
 			}
+		case "include", "skip":
+			if curp.Include != nil {
+				return item.Errorf("Only one @include or @skip directive allowed.")
+			}
+			if err := parseInclude(it, curp, item.Val == "skip"); err != nil {
+				return err
+			}
+		case "dedup":
+			if err := parseDedup(it, curp); err != nil {
+				return err
+			}
+		case "intersect":
+			if err := parseIntersect(it, curp); err != nil {
+				return err
+			}
 		default:
 			return item.Errorf("Unknown directive [%s]", item.Val)
 		}
@@ -2600,7 +3229,12 @@ func validKeyAtRoot(k string) bool {
 	case "from", "to", "numpaths", "minweight", "maxweight":
 		// Specific to shortest path
 		return true
-	case "depth":
+	case "depth", "projectType", "nestFacets", "includeDeleted":
+		return true
+	case "datetimeFormat", "timezone", "floatPrecision":
+		// Formatting of the values in the response
+		return true
+	case "sample", "seed":
 		return true
 	}
 	return false
@@ -2609,7 +3243,7 @@ func validKeyAtRoot(k string) bool {
 // Check for validity of key at non-root nodes.
 func validKey(k string) bool {
 	switch k {
-	case "orderasc", "orderdesc", "first", "offset", "after":
+	case "orderasc", "orderdesc", "first", "offset", "after", "projectType":
 		return true
 	}
 	return false
@@ -2719,6 +3353,12 @@ loop:
 			if !validFuncName(gen.Name) {
 				return nil, item.Errorf("Function name: %s is not valid.", gen.Name)
 			}
+			if gen.Limit, err = parseFuncLimit(it); err != nil {
+				return nil, err
+			}
+			if gen.Limit > 0 && gen.Name == uidFunc {
+				return nil, item.Errorf("@limit can't be used with the uid function")
+			}
 			gq.Func = gen
 			gq.NeedsVar = append(gq.NeedsVar, gen.NeedsVar...)
 		case "from", "to":
@@ -2808,6 +3448,23 @@ loop:
 				}
 				// Modify the NeedsVar context here.
 				gq.NeedsVar[len(gq.NeedsVar)-1].Typ = ValueVar
+			} else if isSortkey(key) && val == "" && item.Val == "count" && peekIsLeftRound(it) {
+				// Ordering by the number of edges, e.g. orderdesc: count(~references).
+				attr, err := parseOrderByCount(it)
+				if err != nil {
+					return nil, err
+				}
+				if len(gq.Order) > 0 {
+					return nil, it.Errorf("Sorting by count(%s) can't be combined with other "+
+						"orders", attr)
+				}
+				gq.Order = append(gq.Order, &pb.Order{
+					Attr:    strings.TrimPrefix(attr, "~"),
+					Desc:    key == "orderdesc",
+					Count:   true,
+					Reverse: strings.HasPrefix(attr, "~"),
+				})
+				continue
 			} else {
 				val = collectName(it, val+item.Val)
 				// Get language list, if present
@@ -2843,6 +3500,10 @@ loop:
 				}
 			}
 			if isSortkey(key) {
+				if len(gq.Order) > 0 && gq.Order[0].Count {
+					return nil, it.Errorf("Sorting by count(%s) can't be combined with other "+
+						"orders", gq.Order[0].Attr)
+				}
 				if order[val] {
 					return nil, it.Errorf("Sorting by an attribute: [%s] can only be done once", val)
 				}
@@ -2868,6 +3529,26 @@ loop:
 	return gq, nil
 }
 
+func peekIsLeftRound(it *lex.ItemIterator) bool {
+	items, err := it.Peek(1)
+	return err == nil && items[0].Typ == itemLeftRound
+}
+
+// parseOrderByCount parses the count(predicate) of an order at root, the iterator being on count.
+func parseOrderByCount(it *lex.ItemIterator) (string, error) {
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return "", it.Errorf("Expected ( after count in order")
+	}
+	if !it.Next() || it.Item().Typ != itemName {
+		return "", it.Errorf("Expected a predicate in count() of order")
+	}
+	attr := it.Item().Val
+	if !it.Next() || it.Item().Typ != itemRightRound {
+		return "", it.Errorf("Expected ) after count(%s in order", attr)
+	}
+	return attr, nil
+}
+
 func isSortkey(k string) bool {
 	return k == "orderasc" || k == "orderdesc"
 }
@@ -2978,7 +3659,9 @@ func godeep(it *lex.ItemIterator, gq *GraphQuery) error {
 			}
 
 			switch {
-			case valLower == "checkpwd":
+			case valLower == "checkpwd" ||
+				((valLower == "distance" || valLower == "relevance") &&
+					peekIt[0].Typ == itemLeftRound):
 				child := &GraphQuery{
 					Args:  make(map[string]string),
 					Var:   varName,
@@ -3037,6 +3720,28 @@ func godeep(it *lex.ItemIterator, gq *GraphQuery) error {
 							" aggregator but got %v", count)
 					}
 					child.NeedsVar[len(child.NeedsVar)-1].Typ = ValueVar
+					// The values of avg can be weighted, e.g. avg(val(x), val(w)).
+					if items, err := it.Peek(1); err == nil && items[0].Typ == itemComma {
+						if valLower != "avg" {
+							return it.Errorf("Only avg can be weighted, got weights for %v",
+								valLower)
+						}
+						it.Next() // consume ','
+						it.Next()
+						if it.Item().Val != valueFunc {
+							return it.Errorf("Only variables allowed as the weights of avg."+
+								" Got: %v", it.Item().Val)
+						}
+						count, err := parseVarList(it, child)
+						if err != nil {
+							return err
+						}
+						if count != 1 {
+							return it.Errorf("Expected one variable inside val() of the"+
+								" weights of avg but got %v", count)
+						}
+						child.NeedsVar[len(child.NeedsVar)-1].Typ = ValueVar
+					}
 				}
 				child.Func = &Function{
 					Name:     valLower,
@@ -3339,6 +4044,43 @@ func collectName(it *lex.ItemIterator, val string) string {
 	return val
 }
 
+// parseFuncLimit parses the @limit(n) which can follow the function at root, e.g.
+// func: has(name)@limit(1000), and returns n, or 0 if there is no limit. The function then finds
+// at most n uids, the lowest ones, before the filters and the pagination are applied. This bounds
+// the memory used by functions matching many nodes, but the results are those of the truncated
+// set: e.g. with a filter, fewer nodes than exist may be returned.
+func parseFuncLimit(it *lex.ItemIterator) (int, error) {
+	// Any other directive is left to the caller, which reports it.
+	items, err := it.Peek(2)
+	if err != nil || items[0].Typ != itemAt || items[1].Typ != itemName ||
+		items[1].Val != "limit" {
+		return 0, nil
+	}
+	it.Next()
+	it.Next()
+	item, ok := tryParseItemType(it, itemLeftRound)
+	if !ok {
+		return 0, item.Errorf("Expected ( after @limit")
+	}
+	// A negative number is lexed as a dash followed by its digits.
+	var val string
+	if trySkipItemVal(it, "-") {
+		val = "-"
+	}
+	if item, ok = tryParseItemType(it, itemName); !ok {
+		return 0, item.Errorf("Expected a number in @limit, got: %v", item.Val)
+	}
+	val += item.Val
+	limit, err := strconv.ParseInt(val, 10, 32)
+	if err != nil || limit <= 0 {
+		return 0, item.Errorf("@limit requires a positive integer, got: %v", val)
+	}
+	if item, ok = tryParseItemType(it, itemRightRound); !ok {
+		return 0, item.Errorf("Expected ) after the value of @limit")
+	}
+	return int(limit), nil
+}
+
 // Steps the parser.
 func tryParseItemType(it *lex.ItemIterator, typ lex.ItemType) (lex.Item, bool) {
 	item, ok := it.PeekOne()
@@ -25,6 +25,7 @@ import (
 	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/lex"
+	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -329,6 +330,35 @@ func TestParseQueryAggChild(t *testing.T) {
 	require.Contains(t, err.Error(), "Only variables allowed in aggregate functions")
 }
 
+func TestParseQueryWeightedAvg(t *testing.T) {
+	query := `
+	{
+		var(func: uid(0x0a)) {
+			friends @facets(s as score, w as weight)
+			avg(val(s), val(w))
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	agg := res.Query[0].Children[1]
+	require.Equal(t, "avg", agg.Func.Name)
+	require.Equal(t, []VarContext{{Name: "s", Typ: ValueVar}, {Name: "w", Typ: ValueVar}},
+		agg.NeedsVar)
+
+	query = `
+	{
+		var(func: uid(0x0a)) {
+			friends @facets(s as score, w as weight)
+			sum(val(s), val(w))
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only avg can be weighted, got weights for sum")
+}
+
 func TestParseQueryWithXIDError(t *testing.T) {
 	query := `
 {
@@ -482,6 +512,25 @@ func TestParseQueryWithVarValAggNested(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestParseQueryWithVarValSimilarity(t *testing.T) {
+	query := `
+	{
+		me(func: uid(s), orderdesc: val(s)) {
+			name
+		}
+
+		var(func: match(name, "john", 8)) {
+			n as name
+			s as math(jarowinkler(n, "Jöhn \"J\"") + cosine(n, "john"))
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.EqualValues(t, `(+ (jarowinkler n "Jöhn \"J\"") (cosine n "john"))`,
+		res.Query[1].Children[1].MathExp.debugString())
+}
+
 func TestParseQueryWithVarValAggNested2(t *testing.T) {
 	query := `
 	{
@@ -587,6 +636,214 @@ func TestParseQueryWithVarValAggNestedConditional(t *testing.T) {
 		res.Query[1].Children[0].Children[5].MathExp.debugString())
 }
 
+func TestParseQueryWithVarValAggCoalesce(t *testing.T) {
+	query := `
+	{
+		me(func: uid(L), orderasc: val(d) ) {
+			name
+			val(d)
+		}
+
+		var(func: uid(0x0a)) {
+			L as friends {
+				a as age
+				b as count(friends)
+				d as math(coalesce(a, 0) + b)
+			}
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.EqualValues(t, "(+ (coalesce a 0) b)",
+		res.Query[1].Children[0].Children[2].MathExp.debugString())
+}
+
+func TestParseIncludeDirective(t *testing.T) {
+	query := `
+	{
+		adults as var(func: ge(age, 18))
+		me(func: uid(0x0a)) {
+			a as age
+			show as math(a > 18)
+			friends @include(if: val(show)) {
+				name
+			}
+			relatives @skip(if: val(show)) {
+				name
+			}
+			email @include(if: uid(adults))
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, &IncludeArgs{Var: "show"}, res.Query[1].Children[2].Include)
+	require.Equal(t, &IncludeArgs{Var: "show", Skip: true}, res.Query[1].Children[3].Include)
+	require.Contains(t, res.Query[1].Children[2].NeedsVar, VarContext{Name: "show", Typ: ValueVar})
+	require.Equal(t, &IncludeArgs{Var: "adults", UidVar: true}, res.Query[1].Children[4].Include)
+	require.Contains(t, res.Query[1].Children[4].NeedsVar, VarContext{Name: "adults", Typ: UidVar})
+}
+
+func TestParseIncludeDirectiveInvalid(t *testing.T) {
+	query := `
+	{
+		me(func: uid(0x0a)) {
+			a as age
+			show as math(a > 18)
+			friends @include(show) {
+				name
+			}
+		}
+	}
+`
+	_, err := Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected @include(if: val(<var>))")
+
+	query = `
+	{
+		me(func: uid(0x0a)) {
+			a as age
+			show as math(a > 18)
+			friends @include(if: val(show)) @skip(if: val(show)) {
+				name
+			}
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only one @include or @skip directive allowed")
+}
+
+func TestParseDedupDirective(t *testing.T) {
+	query := `
+	{
+		me(func: uid(0x0a)) @dedup(by: val(e)) {
+			e as email
+			friends @dedup(by: uid) {
+				name
+			}
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, &DedupArgs{Var: "e"}, res.Query[0].Dedup)
+	require.Contains(t, res.Query[0].NeedsVar, VarContext{Name: "e", Typ: ValueVar})
+	require.Equal(t, &DedupArgs{}, res.Query[0].Children[1].Dedup)
+}
+
+func TestParseDedupDirectiveInvalid(t *testing.T) {
+	query := `
+	{
+		me(func: uid(0x0a)) @dedup(by: email) {
+			email
+		}
+	}
+`
+	_, err := Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected @dedup(by: val(<var>)) or @dedup(by: uid)")
+
+	query = `
+	{
+		me(func: uid(0x0a)) {
+			friends @dedup(by: uid) @dedup(by: uid) {
+				name
+			}
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only one @dedup directive allowed")
+}
+
+func TestParseIntersectDirective(t *testing.T) {
+	query := `
+	{
+		me(func: has(name)) @filter(eq(age, 20) AND anyofterms(name, "a"))
+			@intersect(order: [name, dgraph.type], method: hash) {
+			friends @filter(eq(age, 20) AND has(email)) @intersect(order: [email]) {
+				name
+			}
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, &IntersectArgs{Order: []string{"name", "dgraph.type"}, Method: "hash"},
+		res.Query[0].Intersect)
+	require.Equal(t, &IntersectArgs{Order: []string{"email"}}, res.Query[0].Children[0].Intersect)
+}
+
+func TestParseIntersectDirectiveInvalid(t *testing.T) {
+	tests := []struct {
+		directive string
+		err       string
+	}{
+		{`@intersect(order: name)`, "Expected a list of predicates for order"},
+		{`@intersect(order: [])`, "At least one predicate required in order"},
+		{`@intersect(order: [name,, age])`, "Consecutive commas not allowed"},
+		{`@intersect(order: [name age])`, "Unexpected item inside the order of @intersect"},
+		{`@intersect(index: name)`, "Unexpected key: [index] inside @intersect"},
+		{`@intersect(method: hash) @intersect(method: merge)`, "Only one @intersect directive"},
+	}
+	for _, tc := range tests {
+		query := `{ me(func: has(name)) @filter(has(age) AND has(name)) ` + tc.directive +
+			` { name } }`
+		_, err := Parse(Request{Str: query})
+		require.Error(t, err, tc.directive)
+		require.Contains(t, err.Error(), tc.err, tc.directive)
+	}
+}
+
+func TestParseBlockCycles(t *testing.T) {
+	// The blocks can use the variables of each other as long as they don't form a cycle.
+	query := `
+	{
+		o as var(func: eq(name, "Dgraph")) @filter(NOT uid(u))
+		u as var(func: eq(email, "a@dgraph.io"))
+		me(func: uid(u)) @filter(uid_in(org, uid(o))) {
+			uid
+		}
+	}
+`
+	_, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+
+	query = `
+	{
+		o as var(func: eq(name, "Dgraph")) @filter(NOT uid(u))
+		u as var(func: eq(email, "a@dgraph.io")) @filter(uid_in(org, uid(o)))
+		me(func: uid(u)) {
+			uid
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.EqualError(t, err, "Cycle detected between query blocks: the block defining o "+
+		"uses u, the block defining u uses o")
+
+	query = `
+	{
+		a as var(func: uid(c))
+		b as var(func: uid(a))
+		var(func: uid(b)) {
+			c as friend
+		}
+		me(func: uid(a, b, c)) {
+			uid
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.EqualError(t, err, "Cycle detected between query blocks: the block defining a "+
+		"uses c, the block defining c uses b, the block defining b uses a")
+}
+
 func TestParseQueryWithVarValAggNested3(t *testing.T) {
 	query := `
 	{
@@ -1842,6 +2099,43 @@ func TestParseSchemaError(t *testing.T) {
 	require.Contains(t, err.Error(), "Invalid schema block")
 }
 
+func TestParseHistory(t *testing.T) {
+	for _, query := range []string{
+		`history(uid: 0x1a, pred: status)`,
+		`history(pred: "status", uid: 26)`,
+		`# The history of a predicate.
+		history(uid: 0x1a, pred: <status>)`,
+	} {
+		res, err := Parse(Request{Str: query})
+		require.NoError(t, err, query)
+		require.Nil(t, res.Query)
+		require.Equal(t, &pb.HistoryRequest{Uid: 0x1a, Attr: "status"}, res.History, query)
+	}
+
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`history uid: 0x1)`, "Expected ( after history"},
+		{`history(uid: 0x1)`, "Argument pred is required in history block"},
+		{`history(pred: status)`, "Argument uid is required in history block"},
+		{`history(uid: abc, pred: status)`, "Invalid uid abc in history block"},
+		{`history(uid: 0x1, pred: "~friend")`, `Invalid predicate "~friend" in history`},
+		{`history(uid: 0x1, lang: en)`, "Invalid argument lang in history block"},
+		{`history(uid 0x1, pred: status)`, "Expected : after uid in history block"},
+		{`history(uid: 0x1, pred: status`, "Unclosed history block"},
+		{"history(uid: 0x1, pred: status)\nhistory(uid: 0x2, pred: status)",
+			"Only one history block allowed"},
+		{"history(uid: 0x1, pred: status)\n{ me(func: uid(0x1)) { uid } }",
+			"History block is not allowed with other blocks"},
+	}
+	for _, test := range tests {
+		_, err := Parse(Request{Str: test.query})
+		require.Error(t, err, test.query)
+		require.Contains(t, err.Error(), test.err, test.query)
+	}
+}
+
 func TestParseSchemaErrorMulti(t *testing.T) {
 	query := `
 		schema {
@@ -2162,6 +2456,47 @@ func TestParseFilter_root(t *testing.T) {
 	require.Equal(t, `(namefilter name "a")`, res.Query[0].Children[0].Children[0].Filter.debugString())
 }
 
+func TestParseOrderByCount(t *testing.T) {
+	query := `
+	{
+		me(func: uid(1, 2), orderdesc: count(~references), first: 10) {
+			name
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, []*pb.Order{{Attr: "references", Desc: true, Count: true, Reverse: true}},
+		res.Query[0].Order)
+
+	_, err = Parse(Request{Str: `{me(func: uid(1), orderasc: count(friend), orderasc: name) {
+		name
+	}}`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't be combined with other orders")
+}
+
+func TestParseSetMembershipFunc(t *testing.T) {
+	query := `
+	{
+		me(func: allof(tags, ["a", "b"])) @filter(anyof(tags, [])) {
+			name
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	fn := res.Query[0].Func
+	require.Equal(t, "allof", fn.Name)
+	require.Equal(t, "tags", fn.Attr)
+	require.Equal(t, []Arg{{}, {Value: "a"}, {Value: "b"}}, fn.Args)
+	require.Equal(t, []Arg{{}}, res.Query[0].Filter.Func.Args)
+
+	_, err = Parse(Request{Str: `{me(func: anyof(tags, "exact", ["a"])) {name}}`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "either a tokenizer and a value or a list of values")
+}
+
 func TestParseFuncNested(t *testing.T) {
 	query := `
 	query {
@@ -2182,6 +2517,33 @@ func TestParseFuncNested(t *testing.T) {
 	require.Equal(t, res.Query[0].Func.IsCount, true)
 }
 
+func TestParseFuncLimit(t *testing.T) {
+	query := `{
+		me(func: has(name@en)@limit(100), first: 10) {
+			name
+		}
+	}`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, "has", res.Query[0].Func.Name)
+	require.Equal(t, "name", res.Query[0].Func.Attr)
+	require.Equal(t, "en", res.Query[0].Func.Lang)
+	require.Equal(t, 100, res.Query[0].Func.Limit)
+	require.Equal(t, "10", res.Query[0].Args["first"])
+
+	for query, msg := range map[string]string{
+		`{me(func: has(name)@limit(0)) {name}}`:    "@limit requires a positive integer",
+		`{me(func: has(name)@limit(-5)) {name}}`:   "@limit requires a positive integer",
+		`{me(func: has(name)@first(5)) {name}}`:    `"@"`,
+		`{me(func: uid(0x1)@limit(5)) {name}}`:     "@limit can't be used with the uid function",
+		`{me(func: has(name)@limit(1, 2)) {name}}`: "Expected ) after the value of @limit",
+	} {
+		_, err := Parse(Request{Str: query})
+		require.Error(t, err, query)
+		require.Contains(t, err.Error(), msg, query)
+	}
+}
+
 func TestParseFuncNested2(t *testing.T) {
 	query := `
 	query {
@@ -2718,6 +3080,47 @@ func TestParseCheckPwd(t *testing.T) {
 	require.Equal(t, "password", gq.Query[0].Children[0].Attr)
 }
 
+func TestParseDistance(t *testing.T) {
+	query := `{
+		me(func: uid(1)) {
+			d as distance(loc, [-122.4, 37.7])
+			distance
+			val(d)
+		}
+	}`
+	gq, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	child := gq.Query[0].Children[0]
+	require.Equal(t, "distance", child.Func.Name)
+	require.Equal(t, "loc", child.Attr)
+	require.Equal(t, "d", child.Var)
+	require.Equal(t, "[-122.4,37.7]", child.Func.Args[0].Value)
+	// distance is still a valid predicate name.
+	require.Equal(t, "distance", gq.Query[0].Children[1].Attr)
+	require.Nil(t, gq.Query[0].Children[1].Func)
+}
+
+func TestParseRelevance(t *testing.T) {
+	query := `{
+		me(func: anyoftext(description, "graph database")) {
+			s as relevance(description@en, "graph database")
+			relevance
+			val(s)
+		}
+	}`
+	gq, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	child := gq.Query[0].Children[0]
+	require.Equal(t, "relevance", child.Func.Name)
+	require.Equal(t, "description", child.Attr)
+	require.Equal(t, "en", child.Func.Lang)
+	require.Equal(t, "s", child.Var)
+	require.Equal(t, "graph database", child.Func.Args[0].Value)
+	// relevance is still a valid predicate name.
+	require.Equal(t, "relevance", gq.Query[0].Children[1].Attr)
+	require.Nil(t, gq.Query[0].Children[1].Func)
+}
+
 func TestParseComments(t *testing.T) {
 	query := `
 	# Something
@@ -3123,6 +3526,130 @@ func TestParseNormalize(t *testing.T) {
 	require.True(t, res.Query[0].Normalize)
 }
 
+func TestParseTotalCount(t *testing.T) {
+	query := `
+	query {
+		me(func: has(name), first: 10) @filter(eq(alive, true)) @totalcount {
+			name
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.NotNil(t, res.Query[0])
+	require.True(t, res.Query[0].TotalCount)
+	require.NotNil(t, res.Query[0].Filter)
+
+	// It isn't taken for a language list of the nested blocks.
+	query = `
+	query {
+		me(func: has(name), first: 10) {
+			friend (first: 2) @totalcount {
+				name
+			}
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "@totalcount is only supported at the root of a query block, "+
+		"use count(friend) for the nested blocks")
+}
+
+func TestParseCoercionFunc(t *testing.T) {
+	query := `
+	{
+		var(func: has(score)) {
+			s as score
+		}
+		me(func: uid(s)) @filter(gt(toInt(val(s)), 5)) {
+			total: math(tofloat(s) * 2)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	fn := res.Query[1].Filter.Func
+	require.Equal(t, "gt", fn.Name)
+	require.Equal(t, "s", fn.Attr)
+	require.Equal(t, "toint", fn.Coerce)
+	require.True(t, fn.IsValueVar)
+	require.Equal(t, []VarContext{{Name: "s", Typ: ValueVar}}, fn.NeedsVar)
+	require.Equal(t, "tofloat", res.Query[1].Children[0].MathExp.Child[0].Fn)
+
+	query = `
+	{
+		me(func: gt(toint(score), 5)) {
+			name
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "toint only accepts a value variable")
+
+	query = `
+	{
+		var(func: has(score)) {
+			s as score
+		}
+		me(func: uid(s)) @filter(anyofterms(name, toint(val(s)))) {
+			name
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only allowed as the first argument of an inequality")
+}
+
+func TestParseSplitFunc(t *testing.T) {
+	query := `
+	{
+		var(func: has(tags)) {
+			t as tags
+		}
+		me(func: uid(t)) @filter(eq(split(val(t), ","), "red")) {
+			name
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	fn := res.Query[1].Filter.Func
+	require.Equal(t, "eq", fn.Name)
+	require.Equal(t, "t", fn.Attr)
+	require.Equal(t, ",", fn.Split)
+	require.Equal(t, []Arg{{Value: "red"}}, fn.Args)
+	require.True(t, fn.IsValueVar)
+	require.Equal(t, []VarContext{{Name: "t", Typ: ValueVar}}, fn.NeedsVar)
+
+	query = `
+	{
+		me(func: eq(split(tags, ","), "red")) {
+			name
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "split expects a value variable and a separator")
+
+	query = `
+	{
+		var(func: has(tags)) {
+			t as tags
+		}
+		me(func: eq(split(val(t), ""), "red")) {
+			name
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "split expects a non-empty separator")
+}
+
 func TestParseGroupbyRoot(t *testing.T) {
 	query := `
 	query {
@@ -3231,6 +3758,34 @@ func TestParseGroupbyWithAlias(t *testing.T) {
 	require.Equal(t, "GroupCount", res.Query[0].Children[0].Children[0].Alias)
 }
 
+func TestParseGroupbySplit(t *testing.T) {
+	query := `
+	query {
+		me(func: has(tags)) @groupby(split(tags@en, ", "), Tag: split(labels, ";"), age) {
+			count(uid)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, []GroupByAttr{
+		{Attr: "tags", Langs: []string{"en"}, Split: ", "},
+		{Attr: "labels", Alias: "Tag", Split: ";"},
+		{Attr: "age"},
+	}, res.Query[0].GroupbyAttrs)
+
+	query = `
+	query {
+		me(func: has(tags)) @groupby(split(tags)) {
+			count(uid)
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected a separator after tags in split()")
+}
+
 func TestParseGroupbyWithAliasForKey(t *testing.T) {
 	query := `
 	query {
@@ -3254,7 +3809,7 @@ func TestParseGroupbyWithAliasForError(t *testing.T) {
 	query := `
 	query {
 		me(func: uid(0x1)) {
-			friends @groupby(first: 10, SchooL: school) {
+			friends @groupby(after: 10, SchooL: school) {
 				count(uid)
 			}
 			hometown
@@ -3263,7 +3818,44 @@ func TestParseGroupbyWithAliasForError(t *testing.T) {
 	}
 `
 	_, err := Parse(Request{Str: query})
-	require.Contains(t, err.Error(), "Can't use keyword first as alias in groupby")
+	require.Contains(t, err.Error(), "Can't use keyword after as alias in groupby")
+}
+
+func TestParseGroupbyPage(t *testing.T) {
+	query := `
+	query {
+		me(func: has(genre)) @groupby(genre, orderdesc: count, first: 20, offset: 40) {
+			count(uid)
+		}
+	}
+`
+	res, err := Parse(Request{Str: query})
+	require.NoError(t, err)
+	require.Equal(t, []GroupByAttr{{Attr: "genre"}}, res.Query[0].GroupbyAttrs)
+	require.Equal(t, &GroupByPage{Order: "count", Desc: true, First: 20, Offset: 40},
+		res.Query[0].GroupbyPage)
+
+	query = `
+	query {
+		me(func: has(genre)) @groupby(genre, orderasc: genre, orderdesc: count) {
+			count(uid)
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Only one order is allowed in groupby")
+
+	query = `
+	query {
+		me(func: has(genre)) @groupby(genre, first: many) {
+			count(uid)
+		}
+	}
+`
+	_, err = Parse(Request{Str: query})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected a non-negative number for first in groupby")
 }
 
 func TestParseGroupbyError(t *testing.T) {
@@ -5116,6 +5708,28 @@ func TestTypeFilterInPredicate(t *testing.T) {
 	require.Equal(t, "name", gq.Query[0].Children[0].Children[0].Attr)
 }
 
+func TestInSetFunction(t *testing.T) {
+	q := `
+	query {
+		me(func: in_set("activeUsers")) @filter(in_set(cohort42)) {
+			name
+		}
+	}`
+	gq, err := Parse(Request{Str: q})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(gq.Query))
+	require.Equal(t, "in_set", gq.Query[0].Func.Name)
+	require.Equal(t, "", gq.Query[0].Func.Attr)
+	require.Equal(t, 1, len(gq.Query[0].Func.Args))
+	require.Equal(t, "activeUsers", gq.Query[0].Func.Args[0].Value)
+	require.Equal(t, "in_set", gq.Query[0].Filter.Func.Name)
+	require.Equal(t, "cohort42", gq.Query[0].Filter.Func.Args[0].Value)
+
+	_, err = Parse(Request{Str: `{ me(func: in_set(a, b)) { name } }`})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "in_set function expects the name of a uid set")
+}
+
 func TestParseExpandType(t *testing.T) {
 	query := `
 	{
@@ -5453,3 +6067,18 @@ func TestEmptyId(t *testing.T) {
 	_, err := Parse(r)
 	require.Error(t, err, "ID cannot be empty")
 }
+
+func TestParseVariableTypes(t *testing.T) {
+	types, err := ParseVariableTypes(`query q($name: string!, $age: int = 10) {
+		q(func: eq(name, $name)) @filter(gt(age, $age)) { name }
+	}`)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"$name": "string!", "$age": "int"}, types)
+
+	types, err = ParseVariableTypes(`{ q(func: uid(0x1)) { name } }`)
+	require.NoError(t, err)
+	require.Empty(t, types)
+
+	_, err = ParseVariableTypes(`query q($name) { q(func: uid(0x1)) { name } }`)
+	require.Contains(t, err.Error(), "Expecting a colon")
+}
@@ -271,6 +271,41 @@ func lexInsideSchema(l *lex.Lexer) lex.StateFn {
 	}
 }
 
+// lexInsideHistory lexes the arguments of a history block, e.g. history(uid: 0x1, pred: status).
+func lexInsideHistory(l *lex.Lexer) lex.StateFn {
+	l.Mode = lexInsideHistory
+	for {
+		switch r := l.Next(); {
+		case r == leftRound:
+			l.Emit(itemLeftRound)
+		case r == rightRound:
+			l.Emit(itemRightRound)
+			return lexTopLevel
+		case isSpace(r) || lex.IsEndOfLine(r):
+			l.Ignore()
+		case r == lsThan:
+			return lexIRIRef
+		case isNameBegin(r) || isNumber(r):
+			return lexArgName
+		case r == quote:
+			if err := l.LexQuotedString(); err != nil {
+				return l.Errorf(err.Error())
+			}
+			l.Emit(itemName)
+		case r == '#':
+			return lexComment
+		case r == colon:
+			l.Emit(itemColon)
+		case r == comma:
+			l.Emit(itemComma)
+		case r == lex.EOF:
+			return l.Errorf("Unclosed history block")
+		default:
+			return l.Errorf("Unrecognized character inside history: %#U", r)
+		}
+	}
+}
+
 func lexFuncOrArg(l *lex.Lexer) lex.StateFn {
 	l.Mode = lexFuncOrArg
 	var empty bool
@@ -353,6 +388,8 @@ func lexFuncOrArg(l *lex.Lexer) lex.StateFn {
 		case r == leftSquare:
 			l.Emit(itemLeftSquare)
 		case r == rightSquare:
+			// A list is an argument, even when it's empty.
+			empty = false
 			l.Emit(itemRightSquare)
 		case r == '#':
 			return lexComment
@@ -603,6 +640,9 @@ func lexOperationType(l *lex.Lexer) lex.StateFn {
 	case "schema":
 		l.Emit(itemOpType)
 		return lexInsideSchema
+	case "history":
+		l.Emit(itemOpType)
+		return lexInsideHistory
 	default:
 		return l.Errorf("Invalid operation type: %s", word)
 	}
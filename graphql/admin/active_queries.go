@@ -0,0 +1,79 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func resolveActiveQueries(ctx context.Context, q schema.Query) *resolve.Resolved {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	queries := edgraph.ActiveQueries(ns)
+	res := make([]interface{}, 0, len(queries))
+	for _, aq := range queries {
+		res = append(res, map[string]interface{}{
+			"id":        json.Number(strconv.FormatUint(aq.Id, 10)),
+			"namespace": json.Number(strconv.FormatUint(aq.Namespace, 10)),
+			"query":     aq.Query,
+			"startedAt": aq.Started.Format(time.RFC3339),
+		})
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): res},
+		nil,
+	)
+}
+
+func resolveKillQuery(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got killQuery request through GraphQL admin API")
+
+	id, err := parseAsUint64(m.ArgValue("id"))
+	if err != nil {
+		return resolve.EmptyResult(m, inputArgError(schema.GQLWrapf(err,
+			"can't convert id to uint64"))), false
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	if err := edgraph.KillQuery(ns, id); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			fmt.Sprintf("Cancelled query %d.", id))},
+		nil,
+	), true
+}
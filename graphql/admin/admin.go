@@ -246,6 +246,32 @@ const (
 		Set to true to allow backing up to S3 or Minio bucket that requires no credentials.
 		"""
 		anonymous: Boolean
+
+		"""
+		Set to true to write the schema and data of a single namespace to one archive, which can
+		be restored using the import mutation. Bundles can only be written to a local directory.
+		"""
+		bundle: Boolean
+
+		"""
+		Set to true to include the ACL users, groups and rules in the bundle (default: false).
+		"""
+		includeAcl: Boolean
+	}
+
+	input ImportInput {
+		"""
+		Path of the bundle to import on this Alpha, e.g. dgraph.r10.bundle.tar as returned by
+		the export. It must be in the export directory, and relative paths are relative to it.
+		"""
+		file: String!
+
+		"""
+		Set to true to replace the ACL users, groups and rules with the ones in the bundle
+		(default: false). The user running the import keeps its password, groups and their rules,
+		and the users and groups of the bundle with the same names aren't imported.
+		"""
+		includeAcl: Boolean
 	}
 
 	input TaskInput {
@@ -262,6 +288,10 @@ const (
 		response: Response
 	}
 
+	type ImportPayload {
+		response: Response
+	}
+
 	type DrainingPayload {
 		response: Response
 	}
@@ -270,6 +300,128 @@ const (
 		response: Response
 	}
 
+	type WarmupPayload {
+		response: Response
+
+		"""
+		Predicates served by this node whose posting lists were loaded into the cache.
+		"""
+		warmed: [String]
+
+		"""
+		Predicates not served by this node. They should be warmed up on the nodes serving them.
+		"""
+		skipped: [String]
+	}
+
+	type ActiveQuery {
+		"""
+		Id of the query, used to cancel it.
+		"""
+		id: UInt64
+
+		"""
+		Namespace the query is running in.
+		"""
+		namespace: UInt64
+
+		query: String
+		startedAt: DateTime
+	}
+
+	type XidMapping {
+		uid: UInt64
+		xid: String
+	}
+
+	"""
+	A predicate, or a key of a predicate if uid is set, with the estimated number of times it
+	was read or written.
+	"""
+	type HotKey {
+		namespace: UInt64
+		predicate: String
+		uid: UInt64
+		count: UInt64
+	}
+
+	type HotKeysPayload {
+		predicates: [HotKey]
+		keys: [HotKey]
+	}
+
+	"""
+	Estimates of the disk space used by the posting store of a node, and of the space a value
+	log GC or a compaction would reclaim. The fields from discardTs on are only set if the
+	versions have been scanned.
+	"""
+	type StorageStats {
+		lsmSize: Int64
+		vlogSize: Int64
+		tables: Int
+
+		"""
+		Size of the data of the tables which is already known to be stale.
+		"""
+		staleBytes: UInt64
+
+		"""
+		Estimate of the space reclaimed by a value log GC and a compaction of every level. It
+		only includes the stale data of the tables if the versions haven't been scanned.
+		"""
+		reclaimableBytes: UInt64
+		scanned: Boolean
+
+		"""
+		Versions below this timestamp can be discarded.
+		"""
+		discardTs: UInt64
+		keys: UInt64
+		versions: UInt64
+
+		"""
+		Versions shadowed by a newer complete or deleted version below the discard ts.
+		"""
+		discardableVersions: UInt64
+		discardableBytes: UInt64
+
+		"""
+		Size of the values stored in the value log which are still used.
+		"""
+		liveVlogBytes: UInt64
+
+		"""
+		Fraction of the value log which is still used.
+		"""
+		vlogUtilization: Float
+	}
+
+	type ValidateQueryPayload {
+		"""
+		True if no problem was found in the query.
+		"""
+		valid: Boolean
+		errors: [String]
+	}
+
+	type KillQueryPayload {
+		response: Response
+	}
+
+	type SetUidSetPayload {
+		response: Response
+	}
+
+	type DeleteUidSetPayload {
+		response: Response
+	}
+
+	type QueryToFilePayload {
+		response: Response
+		taskId: String
+		location: String
+	}
+
 	type TaskPayload {
 		kind: TaskKind
 		status: TaskStatus
@@ -287,6 +439,7 @@ const (
 	enum TaskKind {
 		Backup
 		Export
+		QueryToFile
 		Unknown
 	}
 
@@ -303,6 +456,26 @@ const (
 		False value of logDQLRequest disables above.
 		"""
 		logDQLRequest: Boolean
+
+		"""
+		The maximum size in MB of the requests and the responses of the gRPC API. 0 means no
+		limit other than gRPC's 2GB. Clients need a matching limit to receive large responses.
+		"""
+		grpcMaxMessageMb: Int
+
+		"""
+		The window of the day in UTC, e.g. "22:00-06:00", during which the heavy operations
+		(exports, backups and schema changes rebuilding an index) can be started. They are
+		rejected outside of it. An empty string allows them at any time. The window applies to
+		all the alphas of the cluster.
+		"""
+		heavyOpsWindow: String
+
+		"""
+		True value of heavyOpsOverride allows the heavy operations outside of heavyOpsWindow,
+		for emergencies. False value of heavyOpsOverride restores the window.
+		"""
+		heavyOpsOverride: Boolean
 	}
 
 	type ConfigPayload {
@@ -311,6 +484,9 @@ const (
 
 	type Config {
 		cacheMb: Float
+		grpcMaxMessageMb: Int
+		heavyOpsWindow: String
+		heavyOpsOverride: Boolean
 	}
 
 	input RemoveNodeInput {
@@ -397,6 +573,37 @@ const (
 		state: MembershipState
 		config: Config
 		task(input: TaskInput!): TaskPayload
+
+		"""
+		List the queries currently running on this node.
+		"""
+		activeQueries: [ActiveQuery]
+
+		"""
+		Look up the xids stored in dgraph.xid for the given uids. Uids without an xid are left out.
+		"""
+		xids(uids: [UInt64!]!, namespace: UInt64): [XidMapping]
+
+		"""
+		Check a DQL query against the schema without running it. The problems found, like
+		predicates which aren't in the schema or functions used on predicates without the index
+		they need, are returned in errors. The variables are given as a JSON object, as for /query.
+		"""
+		validateQuery(query: String!, variables: String): ValidateQueryPayload
+
+		"""
+		Get the storage stats of the posting store of this node. With scan set, every version
+		of every key is read to count the discardable versions and the used part of the value
+		log, which is more accurate but can take a while on a large store.
+		"""
+		storageStats(scan: Boolean = false): StorageStats
+
+		"""
+		List the predicates and the keys of predicates accessed the most by the queries and the
+		mutations on this node over the given window, e.g. "5m". The accesses are sampled, so the
+		counts are estimates. The window can be at most an hour.
+		"""
+		hotKeys(window: String = "5m", limit: Int = 10): HotKeysPayload
 		` + adminQueries + `
 	}
 
@@ -415,6 +622,12 @@ const (
 		"""
 		export(input: ExportInput!): ExportPayload
 
+		"""
+		Restores a bundle written by export into the namespace of the user running the import.
+		The namespace must not have any data or schema yet.
+		"""
+		import(input: ImportInput!): ImportPayload
+
 		"""
 		Set (or unset) the cluster draining mode.  In draining mode no further requests are served.
 		"""
@@ -425,6 +638,40 @@ const (
 		"""
 		shutdown: ShutdownPayload
 
+		"""
+		Load the posting lists of the given predicates served by this node into the cache, to
+		reduce the latency of the first queries after a restart.
+		"""
+		warmup(predicates: [String!]!, namespace: UInt64): WarmupPayload
+
+		"""
+		Cancel a query running on this node. The id of the query can be found using activeQueries.
+		"""
+		killQuery(id: UInt64!): KillQueryPayload
+
+		"""
+		Store a named set of uids in the namespace, which queries can filter by with
+		in_set("name"). A set with the same name is replaced. The uids are given in hex, e.g.
+		"0x1". The set can't be used anymore once expiresAt is reached, if it is given.
+		"""
+		setUidSet(name: String!, uids: [String!]!, expiresAt: DateTime): SetUidSetPayload
+
+		"""
+		Delete a named set of uids of the namespace.
+		"""
+		deleteUidSet(name: String!): DeleteUidSetPayload
+
+		"""
+		Run a read-only DQL query in a task and write its results to a gzipped file, for the
+		results which are too large to be sent back in a response. The format is "json", the
+		response of the query, or "jsonl", a line per node of a root block. The location is a
+		directory of the Alpha, or a Minio or S3 bucket, and defaults to the export path. The
+		status of the task can be polled with the task query, and the file is removed if it fails.
+		"""
+		queryToFile(query: String!, variables: String, format: String = "jsonl",
+			location: String, accessKey: String, secretKey: String, sessionToken: String,
+			anonymous: Boolean): QueryToFilePayload
+
 		"""
 		Alter the node's config.
 		"""
@@ -500,11 +747,16 @@ var (
 		resolve.LoggingMWMutation,
 	}
 	adminQueryMWConfig = map[string]resolve.QueryMiddlewares{
-		"health":       minimalAdminQryMWs, // dgraph checks Guardian auth for health
-		"state":        minimalAdminQryMWs, // dgraph checks Guardian auth for state
-		"config":       gogQryMWs,
-		"listBackups":  gogQryMWs,
-		"getGQLSchema": stdAdminQryMWs,
+		"health":        minimalAdminQryMWs, // dgraph checks Guardian auth for health
+		"state":         minimalAdminQryMWs, // dgraph checks Guardian auth for state
+		"config":        gogQryMWs,
+		"listBackups":   gogQryMWs,
+		"getGQLSchema":  stdAdminQryMWs,
+		"activeQueries": stdAdminQryMWs, // namespace guardians can only see their own queries
+		"xids":          stdAdminQryMWs,
+		"validateQuery": stdAdminQryMWs,
+		"storageStats":  gogQryMWs,
+		"hotKeys":       stdAdminQryMWs,
 		// for queries and mutations related to User/Group, dgraph handles Guardian auth,
 		// so no need to apply GuardianAuth Middleware
 		"queryUser":      minimalAdminQryMWs,
@@ -518,9 +770,15 @@ var (
 		"config":            gogMutMWs,
 		"draining":          gogMutMWs,
 		"export":            stdAdminMutMWs, // dgraph handles the export for other namespaces by guardian of galaxy
+		"import":            stdAdminMutMWs,
 		"login":             minimalAdminMutMWs,
 		"restore":           gogMutMWs,
 		"shutdown":          gogMutMWs,
+		"warmup":            gogMutMWs,
+		"killQuery":         stdAdminMutMWs, // namespace guardians can only kill their own queries
+		"setUidSet":         stdAdminMutMWs,
+		"deleteUidSet":      stdAdminMutMWs,
+		"queryToFile":       stdAdminMutMWs, // the query is run with the ACLs of the user
 		"removeNode":        gogMutMWs,
 		"moveTablet":        gogMutMWs,
 		"assign":            gogMutMWs,
@@ -620,7 +878,7 @@ func NewServers(withIntrospection bool, globalEpoch map[uint64]*uint64,
 
 	resolvers := resolve.New(gqlSchema, resolverFactoryWithErrorMsg(errNoGraphQLSchema))
 	e := globalEpoch[x.GalaxyNamespace]
-	mainServer := NewServer()
+	mainServer := newServer(edgraph.PersistedQueryAllowlist())
 	mainServer.Set(x.GalaxyNamespace, e, resolvers)
 
 	fns := &resolve.ResolverFns{
@@ -761,10 +1019,16 @@ func newAdminResolverFactory() resolve.ResolverFactory {
 		"deleteNamespace":   resolveDeleteNamespace,
 		"draining":          resolveDraining,
 		"export":            resolveExport,
+		"import":            resolveImport,
 		"login":             resolveLogin,
 		"resetPassword":     resolveResetPassword,
 		"restore":           resolveRestore,
 		"shutdown":          resolveShutdown,
+		"warmup":            resolveWarmup,
+		"killQuery":         resolveKillQuery,
+		"setUidSet":         resolveSetUidSet,
+		"deleteUidSet":      resolveDeleteUidSet,
+		"queryToFile":       resolveQueryToFile,
 		"removeNode":        resolveRemoveNode,
 		"moveTablet":        resolveMoveTablet,
 		"assign":            resolveAssign,
@@ -789,6 +1053,21 @@ func newAdminResolverFactory() resolve.ResolverFactory {
 		WithQueryResolver("task", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(resolveTask)
 		}).
+		WithQueryResolver("activeQueries", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveActiveQueries)
+		}).
+		WithQueryResolver("xids", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveXids)
+		}).
+		WithQueryResolver("validateQuery", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveValidateQuery)
+		}).
+		WithQueryResolver("storageStats", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveStorageStats)
+		}).
+		WithQueryResolver("hotKeys", func(q schema.Query) resolve.QueryResolver {
+			return resolve.QueryResolverFunc(resolveHotKeys)
+		}).
 		WithQueryResolver("getGQLSchema", func(q schema.Query) resolve.QueryResolver {
 			return resolve.QueryResolverFunc(
 				func(ctx context.Context, query schema.Query) *resolve.Resolved {
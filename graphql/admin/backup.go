@@ -49,6 +49,9 @@ func resolveBackup(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 		err := fmt.Errorf("you must specify a 'destination' value")
 		return resolve.EmptyResult(m, err), false
 	}
+	if err := worker.CheckHeavyOp("Backup"); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
 
 	req := &pb.BackupRequest{
 		Destination:  input.Destination,
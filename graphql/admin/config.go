@@ -33,6 +33,11 @@ type configInput struct {
 	// logging of all requests coming to alphas. LogDQLRequest type has been kept as *bool instead of
 	// bool to avoid updating WorkerOptions.LogDQLRequest when it has default value of false.
 	LogDQLRequest *bool
+	// GrpcMaxMessageMb is used to update the maximum size of the messages of the gRPC API.
+	GrpcMaxMessageMb *int64
+	// HeavyOpsWindow and HeavyOpsOverride are used to update the maintenance policy.
+	HeavyOpsWindow   *string
+	HeavyOpsOverride *bool
 }
 
 func resolveUpdateConfig(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
@@ -55,6 +60,20 @@ func resolveUpdateConfig(ctx context.Context, m schema.Mutation) (*resolve.Resol
 		worker.UpdateLogDQLRequest(*input.LogDQLRequest)
 	}
 
+	if input.GrpcMaxMessageMb != nil {
+		if err = worker.UpdateGrpcMaxMessageMb(*input.GrpcMaxMessageMb); err != nil {
+			return resolve.EmptyResult(m, err), false
+		}
+	}
+
+	// The maintenance policy is stored in the cluster, it applies to all the alphas.
+	if input.HeavyOpsWindow != nil || input.HeavyOpsOverride != nil {
+		if err = worker.UpdateHeavyOpsPolicy(ctx, input.HeavyOpsWindow,
+			input.HeavyOpsOverride); err != nil {
+			return resolve.EmptyResult(m, err), false
+		}
+	}
+
 	return resolve.DataResult(
 		m,
 		map[string]interface{}{m.Name(): response("Success", "Config updated successfully")},
@@ -65,10 +84,15 @@ func resolveUpdateConfig(ctx context.Context, m schema.Mutation) (*resolve.Resol
 func resolveGetConfig(ctx context.Context, q schema.Query) *resolve.Resolved {
 	glog.Info("Got config query through GraphQL admin API")
 
+	window, override := worker.HeavyOpsPolicy()
 	return resolve.DataResult(
 		q,
 		map[string]interface{}{q.Name(): map[string]interface{}{
 			"cacheMb": json.Number(strconv.FormatInt(worker.Config.CacheMb, 10)),
+			"grpcMaxMessageMb": json.Number(strconv.FormatInt(
+				worker.GrpcMaxMessageSize()>>20, 10)),
+			"heavyOpsWindow":   window,
+			"heavyOpsOverride": override,
 		}},
 		nil,
 	)
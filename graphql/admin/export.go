@@ -33,8 +33,10 @@ import (
 const notSet = math.MaxInt64
 
 type exportInput struct {
-	Format    string
-	Namespace int64
+	Format     string
+	Namespace  int64
+	Bundle     bool
+	IncludeAcl bool
 	DestinationFields
 }
 
@@ -45,6 +47,9 @@ func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 	if err != nil {
 		return resolve.EmptyResult(m, err), false
 	}
+	if err := worker.CheckHeavyOp("Export"); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
 
 	format := worker.DefaultExportFormat
 	if input.Format != "" {
@@ -90,6 +95,8 @@ func resolveExport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, b
 		SecretKey:    input.SecretKey,
 		SessionToken: input.SessionToken,
 		Anonymous:    input.Anonymous,
+		Bundle:       input.Bundle,
+		IncludeAcl:   input.IncludeAcl,
 	}
 
 	files, err := worker.ExportOverNetwork(context.Background(), req)
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func resolveHotKeys(ctx context.Context, q schema.Query) *resolve.Resolved {
+	window := 5 * time.Minute
+	if arg, ok := q.ArgValue("window").(string); ok {
+		var err error
+		if window, err = time.ParseDuration(arg); err != nil {
+			return resolve.EmptyResult(q, inputArgError(schema.GQLWrapf(err,
+				"can't parse window as a duration")))
+		}
+	}
+	if window <= 0 || window > worker.MaxHotKeysWindow {
+		return resolve.EmptyResult(q, inputArgError(errors.Errorf(
+			"window must be positive and at most %s", worker.MaxHotKeysWindow)))
+	}
+	limit := 10
+	if arg, ok := q.ArgValue("limit").(int64); ok {
+		if arg <= 0 {
+			return resolve.EmptyResult(q, inputArgError(errors.New("limit must be positive")))
+		}
+		limit = int(arg)
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	preds, keys := worker.HotKeys(ns, window, limit)
+	toList := func(hks []worker.HotKey) []interface{} {
+		res := make([]interface{}, 0, len(hks))
+		for _, hk := range hks {
+			hkNs, attr := x.ParseNamespaceAttr(hk.Attr)
+			m := map[string]interface{}{
+				"namespace": json.Number(strconv.FormatUint(hkNs, 10)),
+				"predicate": attr,
+				"count":     json.Number(strconv.FormatUint(hk.Count, 10)),
+			}
+			if hk.Uid != 0 {
+				m["uid"] = json.Number(strconv.FormatUint(hk.Uid, 10))
+			}
+			res = append(res, m)
+		}
+		return res
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): map[string]interface{}{
+			"predicates": toList(preds),
+			"keys":       toList(keys),
+		}},
+		nil,
+	)
+}
@@ -64,13 +64,20 @@ type graphqlHandler struct {
 	poller      map[uint64]*subscription.Poller
 	resolverMux sync.RWMutex // protects resolver from RW races
 	pollerMux   sync.RWMutex // protects poller from RW races
+	// persistedOnly is set if only persisted queries are served.
+	persistedOnly bool
 }
 
 // NewServer returns a new IServeGraphQL that can serve the given resolvers
 func NewServer() IServeGraphQL {
+	return newServer(false)
+}
+
+func newServer(persistedOnly bool) IServeGraphQL {
 	gh := &graphqlHandler{
-		resolver: make(map[uint64]*resolve.RequestResolver),
-		poller:   make(map[uint64]*subscription.Poller),
+		resolver:      make(map[uint64]*resolve.RequestResolver),
+		poller:        make(map[uint64]*subscription.Poller),
+		persistedOnly: persistedOnly,
 	}
 	gh.handler = recoveryHandler(commonHeaders(gh.Handler()))
 	return gh
@@ -250,6 +257,11 @@ func (gh *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if gh.persistedOnly && gqlReq.Extensions.PersistedQuery.Sha256Hash == "" {
+		WriteErrorResponse(w, r, errors.New("only persisted queries are allowed, "+
+			"sha256Hash is missing"))
+		return
+	}
 	if err = edgraph.ProcessPersistedQuery(ctx, gqlReq); err != nil {
 		WriteErrorResponse(w, r, err)
 		return
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	dgoapi "github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+type importInput struct {
+	File       string
+	IncludeAcl bool
+}
+
+// aclNodesQuery finds the nodes storing the ACL users, groups and rules of the namespace.
+const aclNodesQuery = `{
+	users(func: type(dgraph.type.User)) { uid }
+	groups(func: type(dgraph.type.Group)) { uid }
+	rules(func: type(dgraph.type.Rule)) { uid }
+}`
+
+// importerAclQuery finds the ACL user running the import, along with its groups and their rules.
+const importerAclQuery = `query q($user: string) {
+	user(func: eq(dgraph.xid, $user)) @filter(type(dgraph.type.User)) {
+		uid
+		dgraph.xid
+		dgraph.user.group {
+			uid
+			dgraph.xid
+			dgraph.acl.rule { uid }
+		}
+	}
+}`
+
+func resolveImport(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got import request through GraphQL admin API")
+
+	input, err := getImportInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	if err := importBundle(ctx, input); err != nil {
+		glog.Errorf("Import of bundle %s failed: %v", input.File, err)
+		return resolve.EmptyResult(m, err), false
+	}
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success", "Import completed.")},
+		nil,
+	), true
+}
+
+func importBundle(ctx context.Context, input *importInput) error {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	path, err := worker.BundlePath(input.File)
+	if err != nil {
+		return err
+	}
+	bundle, err := worker.OpenBundle(path)
+	if err != nil {
+		return err
+	}
+	defer bundle.Close()
+	if input.IncludeAcl && !bundle.Manifest.IncludesAcl {
+		return errors.Errorf("Bundle %s was exported without ACL data", input.File)
+	}
+	glog.Infof("Importing bundle %s exported from namespace %#x at ts %d into namespace %#x",
+		input.File, bundle.Manifest.Namespace, bundle.Manifest.ReadTs, ns)
+
+	if err := checkEmptyNamespace(ctx, ns); err != nil {
+		return err
+	}
+
+	// The namespace in the bundle schema is replaced by the namespace of the user.
+	sch, err := bundle.Schema()
+	if err != nil {
+		return err
+	}
+	if _, err := (&edgraph.Server{}).Alter(ctx, &dgoapi.Operation{Schema: sch}); err != nil {
+		return errors.Wrapf(err, "while applying the bundle schema")
+	}
+
+	gqlSchema, err := bundle.GraphQLSchema()
+	if err != nil {
+		return err
+	}
+	if gqlSchema != "" {
+		schHandler, err := schema.NewHandler(gqlSchema, false)
+		if err != nil {
+			return errors.Wrapf(err, "while validating the bundle GraphQL schema")
+		}
+		if _, err := edgraph.UpdateGQLSchema(ctx, gqlSchema, schHandler.DGSchema()); err != nil {
+			return errors.Wrapf(err, "while applying the bundle GraphQL schema")
+		}
+	}
+
+	// Find the current ACL nodes before loading the data, so that they can be replaced by the
+	// ones in the bundle once it has been loaded. The user running the import keeps its groups
+	// and rules, so that it doesn't lose access to the namespace.
+	var aclNodes []string
+	var keep map[worker.AclNode]uint64
+	if input.IncludeAcl {
+		var kept map[string]struct{}
+		if keep, kept, err = findImporterAcl(ctx); err != nil {
+			return err
+		}
+		if aclNodes, err = findAclNodes(ctx, kept); err != nil {
+			return err
+		}
+	}
+
+	err = bundle.LoadData(ctx, input.IncludeAcl, keep, func(nqs []*dgoapi.NQuad) error {
+		_, err := (&edgraph.Server{}).Query(ctx, &dgoapi.Request{
+			Mutations: []*dgoapi.Mutation{{Set: nqs}},
+			CommitNow: true,
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(aclNodes) > 0 {
+		var del []*dgoapi.NQuad
+		for _, uid := range aclNodes {
+			del = append(del, &dgoapi.NQuad{
+				Subject:     uid,
+				Predicate:   x.Star,
+				ObjectValue: &dgoapi.Value{Val: &dgoapi.Value_DefaultVal{DefaultVal: x.Star}},
+			})
+		}
+		if _, err := (&edgraph.Server{}).Query(ctx, &dgoapi.Request{
+			Mutations: []*dgoapi.Mutation{{Del: del}},
+			CommitNow: true,
+		}); err != nil {
+			return errors.Wrapf(err, "while removing the previous ACL data")
+		}
+	}
+	return nil
+}
+
+// checkEmptyNamespace returns an error if any predicate other than the reserved ones has already
+// been defined in the namespace.
+func checkEmptyNamespace(ctx context.Context, ns uint64) error {
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		pns, pred := x.ParseNamespaceAttr(node.Predicate)
+		if pns != ns || x.IsReservedPredicate(pred) {
+			continue
+		}
+		return errors.Errorf("Bundles can only be imported into an empty namespace. Found "+
+			"predicate %s in namespace %#x", pred, ns)
+	}
+	return nil
+}
+
+// findImporterAcl returns the ACL user running the import and its groups, which are kept by the
+// import, along with the uids of their nodes and of the rules of the groups. There is no such user
+// if ACL isn't enabled.
+func findImporterAcl(ctx context.Context) (map[worker.AclNode]uint64,
+	map[string]struct{}, error) {
+
+	jwt, err := x.ExtractJwt(ctx)
+	if err != nil || jwt == "" {
+		return nil, nil, nil
+	}
+	userId, err := x.ExtractUserName(jwt)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "while reading the user running the import")
+	}
+	resp, err := (&edgraph.Server{}).Query(ctx, &dgoapi.Request{
+		Query: importerAclQuery,
+		Vars:  map[string]string{"$user": userId},
+	})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "while querying the ACL data of user %s", userId)
+	}
+	type node struct {
+		Uid   string `json:"uid"`
+		Xid   string `json:"dgraph.xid"`
+		Rules []struct {
+			Uid string `json:"uid"`
+		} `json:"dgraph.acl.rule"`
+	}
+	var res struct {
+		User []struct {
+			node
+			Groups []node `json:"dgraph.user.group"`
+		} `json:"user"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return nil, nil, err
+	}
+
+	keep := make(map[worker.AclNode]uint64)
+	kept := make(map[string]struct{})
+	add := func(typ string, n node) error {
+		uid, err := strconv.ParseUint(n.Uid, 0, 64)
+		if err != nil {
+			return err
+		}
+		keep[worker.AclNode{Type: typ, Xid: n.Xid}] = uid
+		kept[n.Uid] = struct{}{}
+		for _, rule := range n.Rules {
+			kept[rule.Uid] = struct{}{}
+		}
+		return nil
+	}
+	for _, user := range res.User {
+		if err := add("dgraph.type.User", user.node); err != nil {
+			return nil, nil, err
+		}
+		for _, group := range user.Groups {
+			if err := add("dgraph.type.Group", group); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return keep, kept, nil
+}
+
+// findAclNodes returns the uids of the ACL nodes of the namespace, except the kept ones.
+func findAclNodes(ctx context.Context, kept map[string]struct{}) ([]string, error) {
+	resp, err := (&edgraph.Server{}).Query(ctx, &dgoapi.Request{Query: aclNodesQuery})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while querying the ACL data")
+	}
+	var res map[string][]struct {
+		Uid string `json:"uid"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return nil, err
+	}
+	var uids []string
+	for _, nodes := range res {
+		for _, n := range nodes {
+			if _, ok := kept[n.Uid]; !ok {
+				uids = append(uids, n.Uid)
+			}
+		}
+	}
+	return uids, nil
+}
+
+func getImportInput(m schema.Mutation) (*importInput, error) {
+	inputArg := m.ArgValue(schema.InputArgName)
+	inputByts, err := json.Marshal(inputArg)
+	if err != nil {
+		return nil, schema.GQLWrapf(err, "couldn't get input argument")
+	}
+
+	var input importInput
+	if err := json.Unmarshal(inputByts, &input); err != nil {
+		return nil, schema.GQLWrapf(err, "couldn't get input argument")
+	}
+	if input.File == "" {
+		return nil, errors.Errorf("file must be set for import")
+	}
+	return &input, nil
+}
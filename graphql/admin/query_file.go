@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+func resolveQueryToFile(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	query, ok := m.ArgValue("query").(string)
+	if !ok || query == "" {
+		return resolve.EmptyResult(m, inputArgError(errors.Errorf("the query can't be empty"))),
+			false
+	}
+	var vars map[string]string
+	if v, ok := m.ArgValue("variables").(string); ok && v != "" {
+		if err := json.Unmarshal([]byte(v), &vars); err != nil {
+			return resolve.EmptyResult(m, inputArgError(schema.GQLWrapf(err,
+				"can't parse variables as a JSON object of strings"))), false
+		}
+	}
+	req := &worker.QueryToFileRequest{Format: worker.QueryFileJSONL}
+	if format, ok := m.ArgValue("format").(string); ok && format != "" {
+		req.Format = format
+	}
+	req.Destination, _ = m.ArgValue("location").(string)
+	req.AccessKey, _ = m.ArgValue("accessKey").(string)
+	req.SecretKey, _ = m.ArgValue("secretKey").(string)
+	req.SessionToken, _ = m.ArgValue("sessionToken").(string)
+	req.Anonymous, _ = m.ArgValue("anonymous").(bool)
+
+	taskId, err := edgraph.QueryToFile(ctx, query, vars, req)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	msg := fmt.Sprintf("Query queued with ID %#x", taskId)
+	data := response("Success", msg)
+	data["taskId"] = fmt.Sprintf("%#x", taskId)
+	data["location"] = worker.QueryFileLocation(req.Destination, taskId, req.Format)
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): data},
+		nil,
+	), true
+}
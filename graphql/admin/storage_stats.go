@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+)
+
+func resolveStorageStats(ctx context.Context, q schema.Query) *resolve.Resolved {
+	scan, _ := q.ArgValue("scan").(bool)
+	stats, err := worker.GetStorageStats(ctx, scan)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+
+	uint64Val := func(v uint64) json.Number { return json.Number(strconv.FormatUint(v, 10)) }
+	res := map[string]interface{}{
+		"lsmSize":          json.Number(strconv.FormatInt(stats.LsmSize, 10)),
+		"vlogSize":         json.Number(strconv.FormatInt(stats.VlogSize, 10)),
+		"tables":           stats.Tables,
+		"staleBytes":       uint64Val(stats.StaleBytes),
+		"reclaimableBytes": uint64Val(stats.ReclaimableBytes()),
+		"scanned":          stats.Scanned,
+	}
+	if stats.Scanned {
+		res["discardTs"] = uint64Val(stats.DiscardTs)
+		res["keys"] = uint64Val(stats.Keys)
+		res["versions"] = uint64Val(stats.Versions)
+		res["discardableVersions"] = uint64Val(stats.DiscardableVersions)
+		res["discardableBytes"] = uint64Val(stats.DiscardableBytes)
+		res["liveVlogBytes"] = uint64Val(stats.LiveVlogBytes)
+		res["vlogUtilization"] = stats.VlogUtilization()
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): res},
+		nil,
+	)
+}
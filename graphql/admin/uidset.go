@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/edgraph"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+)
+
+type uidSetInput struct {
+	Name      string
+	Uids      []uint64
+	ExpiresAt time.Time
+}
+
+func resolveSetUidSet(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got setUidSet request through GraphQL admin API")
+
+	input, err := getUidSetInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+	if err := edgraph.SetUidSet(ctx, input.Name, input.Uids, input.ExpiresAt); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			fmt.Sprintf("Stored uid set %q with %d uids.", input.Name, len(input.Uids)))},
+		nil,
+	), true
+}
+
+func resolveDeleteUidSet(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got deleteUidSet request through GraphQL admin API")
+
+	name, _ := m.ArgValue("name").(string)
+	if err := edgraph.DeleteUidSet(ctx, name); err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): response("Success",
+			fmt.Sprintf("Deleted uid set %q.", name))},
+		nil,
+	), true
+}
+
+func getUidSetInput(m schema.Mutation) (*uidSetInput, error) {
+	input := &uidSetInput{}
+	input.Name, _ = m.ArgValue("name").(string)
+
+	uids, ok := m.ArgValue("uids").([]interface{})
+	if !ok {
+		return nil, inputArgError(errors.Errorf("can't convert uids to list"))
+	}
+	for _, u := range uids {
+		s, ok := u.(string)
+		if !ok {
+			return nil, inputArgError(errors.Errorf("can't convert uid to string"))
+		}
+		uid, err := strconv.ParseUint(s, 0, 64)
+		if err != nil || uid == 0 {
+			return nil, inputArgError(errors.Errorf("invalid uid %q", s))
+		}
+		input.Uids = append(input.Uids, uid)
+	}
+
+	if v, ok := m.ArgValue("expiresAt").(string); ok && v != "" {
+		var err error
+		if input.ExpiresAt, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, inputArgError(schema.GQLWrapf(err, "can't parse expiresAt"))
+		}
+	}
+	return input, nil
+}
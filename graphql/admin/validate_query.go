@@ -0,0 +1,60 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/query"
+)
+
+func resolveValidateQuery(ctx context.Context, q schema.Query) *resolve.Resolved {
+	str, ok := q.ArgValue("query").(string)
+	if !ok {
+		return resolve.EmptyResult(q, inputArgError(errors.Errorf("can't convert query to string")))
+	}
+	req := gql.Request{Str: str}
+	if vars, ok := q.ArgValue("variables").(string); ok && vars != "" {
+		if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+			return resolve.EmptyResult(q, inputArgError(schema.GQLWrapf(err,
+				"can't parse variables as a JSON object of strings")))
+		}
+	}
+
+	problems, err := query.ValidateQuery(ctx, req)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	errs := make([]interface{}, 0, len(problems))
+	for _, p := range problems {
+		errs = append(errs, p)
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): map[string]interface{}{
+			"valid":  len(problems) == 0,
+			"errors": errs,
+		}},
+		nil,
+	)
+}
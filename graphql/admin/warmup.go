@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+type warmupInput struct {
+	Namespace  uint64
+	Predicates []string
+}
+
+func resolveWarmup(ctx context.Context, m schema.Mutation) (*resolve.Resolved, bool) {
+	glog.Info("Got warmup request through GraphQL admin API")
+
+	input, err := getWarmupInput(m)
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	res, err := worker.WarmupPredicates(ctx, x.NamespaceAttrList(input.Namespace, input.Predicates))
+	if err != nil {
+		return resolve.EmptyResult(m, err), false
+	}
+
+	msg := fmt.Sprintf("Warmed up %d predicates, read %d bytes.", len(res.Warmed), res.Bytes)
+	if res.Truncated {
+		msg += " Stopped early as the block cache is full."
+	}
+	data := response("Success", msg)
+	data["warmed"] = toInterfaceSlice(x.ParseAttrList(res.Warmed))
+	data["skipped"] = toInterfaceSlice(x.ParseAttrList(res.Skipped))
+	return resolve.DataResult(
+		m,
+		map[string]interface{}{m.Name(): data},
+		nil,
+	), true
+}
+
+func getWarmupInput(m schema.Mutation) (*warmupInput, error) {
+	input := &warmupInput{Namespace: x.GalaxyNamespace}
+	if ns := m.ArgValue("namespace"); ns != nil {
+		var err error
+		if input.Namespace, err = parseAsUint64(ns); err != nil {
+			return nil, inputArgError(schema.GQLWrapf(err, "can't convert namespace to uint64"))
+		}
+	}
+
+	preds, ok := m.ArgValue("predicates").([]interface{})
+	if !ok {
+		return nil, inputArgError(errors.Errorf("can't convert predicates to list"))
+	}
+	for _, p := range preds {
+		pred, ok := p.(string)
+		if !ok {
+			return nil, inputArgError(errors.Errorf("can't convert predicate to string"))
+		}
+		input.Predicates = append(input.Predicates, pred)
+	}
+	if len(input.Predicates) == 0 {
+		return nil, inputArgError(errors.Errorf("at least one predicate is required"))
+	}
+	return input, nil
+}
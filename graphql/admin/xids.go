@@ -0,0 +1,88 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/graphql/resolve"
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func resolveXids(ctx context.Context, q schema.Query) *resolve.Resolved {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	if arg := q.ArgValue("namespace"); arg != nil {
+		argNs, err := parseAsUint64(arg)
+		if err != nil {
+			return resolve.EmptyResult(q, inputArgError(schema.GQLWrapf(err,
+				"can't convert namespace to uint64")))
+		}
+		// Only the guardians of the galaxy can look up the xids of other namespaces.
+		if ns != x.GalaxyNamespace && argNs != ns {
+			return resolve.EmptyResult(q, errors.Errorf("not allowed to look up xids of "+
+				"namespace %#x", argNs))
+		}
+		ns = argNs
+	}
+
+	list, ok := q.ArgValue("uids").([]interface{})
+	if !ok {
+		return resolve.EmptyResult(q, inputArgError(errors.Errorf("can't convert uids to list")))
+	}
+	uids := make([]uint64, 0, len(list))
+	for _, v := range list {
+		uid, err := parseAsUint64(v)
+		if err != nil {
+			return resolve.EmptyResult(q, inputArgError(schema.GQLWrapf(err,
+				"can't convert uid to uint64")))
+		}
+		uids = append(uids, uid)
+	}
+
+	xids, err := worker.LookupXids(ctx, ns, uids)
+	if err != nil {
+		return resolve.EmptyResult(q, err)
+	}
+	sorted := make([]uint64, 0, len(xids))
+	for uid := range xids {
+		sorted = append(sorted, uid)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	res := make([]interface{}, 0, len(sorted))
+	for _, uid := range sorted {
+		res = append(res, map[string]interface{}{
+			"uid": json.Number(strconv.FormatUint(uid, 10)),
+			"xid": xids[uid],
+		})
+	}
+	return resolve.DataResult(
+		q,
+		map[string]interface{}{q.Name(): res},
+		nil,
+	)
+}
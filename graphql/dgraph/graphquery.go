@@ -93,6 +93,17 @@ func writeQuery(b *strings.Builder, query *gql.GraphQuery, prefix string) {
 		}
 	}
 
+	if inc := query.Include; inc != nil {
+		directive, fn := "include", "val"
+		if inc.Skip {
+			directive = "skip"
+		}
+		if inc.UidVar {
+			fn = "uid"
+		}
+		x.Check2(b.WriteString(fmt.Sprintf(" @%s(if: %s(%s))", directive, fn, inc.Var)))
+	}
+
 	switch {
 	case len(query.Children) > 0:
 		prefixAdd := ""
@@ -212,11 +223,12 @@ func writeFilter(b *strings.Builder, ft *gql.FilterTree) {
 func hasOrderOrPage(q *gql.GraphQuery) bool {
 	_, hasFirst := q.Args["first"]
 	_, hasOffset := q.Args["offset"]
-	return len(q.Order) > 0 || hasFirst || hasOffset
+	_, hasAfter := q.Args["after"]
+	return len(q.Order) > 0 || hasFirst || hasOffset || hasAfter
 }
 
 func writeOrderAndPage(b *strings.Builder, query *gql.GraphQuery, root bool) {
-	var wroteOrder, wroteFirst bool
+	var wroteOrder, wroteFirst, wroteOffset bool
 
 	for _, ord := range query.Order {
 		if root || wroteOrder {
@@ -246,5 +258,14 @@ func writeOrderAndPage(b *strings.Builder, query *gql.GraphQuery, root bool) {
 		}
 		x.Check2(b.WriteString("offset: "))
 		x.Check2(b.WriteString(offset))
+		wroteOffset = true
+	}
+
+	if after, ok := query.Args["after"]; ok {
+		if root || wroteOrder || wroteFirst || wroteOffset {
+			x.Check2(b.WriteString(", "))
+		}
+		x.Check2(b.WriteString("after: "))
+		x.Check2(b.WriteString(after))
 	}
 }
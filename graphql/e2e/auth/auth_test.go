@@ -1201,6 +1201,118 @@ func TestDeepFilter(t *testing.T) {
 	}
 }
 
+// columnConnection is the result of a connection field of columns.
+type columnConnection struct {
+	Typename string `json:"__typename"`
+	Edges    []struct {
+		Node   *Column `json:"node"`
+		Cursor string  `json:"cursor"`
+	} `json:"edges"`
+	PageInfo struct {
+		HasNextPage     bool   `json:"hasNextPage"`
+		HasPreviousPage bool   `json:"hasPreviousPage"`
+		StartCursor     string `json:"startCursor"`
+		EndCursor       string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+// queryColumnsConnection returns the connections of the columns of the projects seen by the user,
+// by the name of the project.
+func queryColumnsConnection(t *testing.T, user, role string,
+	vars map[string]interface{}) map[string]columnConnection {
+	params := &common.GraphQLParams{
+		Headers: common.GetJWT(t, user, role, metaInfo),
+		Query: `
+		query($first: Int, $after: String) {
+			queryProject(order: {asc: name}) {
+				name
+				columnsConnection(first: $first, after: $after) {
+					__typename
+					edges {
+						node {
+							name
+						}
+						cursor
+					}
+					pageInfo {
+						hasNextPage
+						hasPreviousPage
+						startCursor
+						endCursor
+					}
+				}
+			}
+		}`,
+		Variables: vars,
+	}
+	gqlResponse := params.ExecuteAsPost(t, common.GraphqlURL)
+	common.RequireNoGQLErrors(t, gqlResponse)
+
+	var result struct {
+		QueryProject []struct {
+			Name              string
+			ColumnsConnection columnConnection
+		}
+	}
+	require.NoError(t, json.Unmarshal(gqlResponse.Data, &result))
+	connections := make(map[string]columnConnection)
+	for _, p := range result.QueryProject {
+		connections[p.Name] = p.ColumnsConnection
+	}
+	return connections
+}
+
+func columnNames(t *testing.T, c columnConnection) []string {
+	var names []string
+	for _, e := range c.Edges {
+		require.NotNil(t, e.Node)
+		names = append(names, e.Node.Name)
+	}
+	return names
+}
+
+func TestConnectionField(t *testing.T) {
+	connections := queryColumnsConnection(t, "user2", "USER", nil)
+	require.Len(t, connections, 2)
+	project2 := connections["Project2"]
+	require.Equal(t, "ColumnConnection", project2.Typename)
+	require.ElementsMatch(t, []string{"Column2", "Column3"}, columnNames(t, project2))
+	require.False(t, project2.PageInfo.HasNextPage)
+	require.False(t, project2.PageInfo.HasPreviousPage)
+	require.Equal(t, project2.Edges[0].Cursor, project2.PageInfo.StartCursor)
+	require.Equal(t, project2.Edges[1].Cursor, project2.PageInfo.EndCursor)
+	require.Equal(t, []string{"Column1"}, columnNames(t, connections["Project1"]))
+
+	// The pages follow each other from the cursor of the last node.
+	first := queryColumnsConnection(t, "user2", "USER",
+		map[string]interface{}{"first": 1})["Project2"]
+	require.Equal(t, columnNames(t, project2)[:1], columnNames(t, first))
+	require.True(t, first.PageInfo.HasNextPage)
+	require.Equal(t, project2.Edges[0].Cursor, first.PageInfo.EndCursor)
+
+	next := queryColumnsConnection(t, "user2", "USER",
+		map[string]interface{}{"first": 1, "after": first.PageInfo.EndCursor})["Project2"]
+	require.Equal(t, columnNames(t, project2)[1:], columnNames(t, next))
+	require.False(t, next.PageInfo.HasNextPage)
+}
+
+func TestConnectionFieldAuth(t *testing.T) {
+	// An admin sees the projects, but only the columns of the projects it can view.
+	connections := queryColumnsConnection(t, "user5", "ADMIN", nil)
+	require.Len(t, connections, 2)
+	for name, c := range connections {
+		require.Empty(t, c.Edges, name)
+		require.False(t, c.PageInfo.HasNextPage, name)
+		require.Empty(t, c.PageInfo.StartCursor, name)
+		require.Empty(t, c.PageInfo.EndCursor, name)
+	}
+
+	// user4 can only view the columns of Project2, and sees Project2 only.
+	connections = queryColumnsConnection(t, "user4", "USER", nil)
+	require.Len(t, connections, 1)
+	require.ElementsMatch(t, []string{"Column2", "Column3"}, columnNames(t, connections["Project2"]))
+}
+
 func TestRootFilter(t *testing.T) {
 	testCases := []TestCase{{
 		user:   "user1",
@@ -887,3 +887,128 @@ func read(t *testing.T, file string) []byte {
 	require.NoError(t, err, "Unable to read test file")
 	return b
 }
+
+// customDQLExecutor returns the given responses in turn, and records the queries it runs.
+type customDQLExecutor struct {
+	resps   []string
+	queries []string
+}
+
+func (ex *customDQLExecutor) Execute(ctx context.Context, req *dgoapi.Request,
+	field schema.Field) (*dgoapi.Response, error) {
+	ex.queries = append(ex.queries, req.Query)
+	resp := ex.resps[0]
+	ex.resps = ex.resps[1:]
+	return &dgoapi.Response{Json: []byte(resp)}, nil
+}
+
+func (ex *customDQLExecutor) CommitOrAbort(ctx context.Context,
+	tc *dgoapi.TxnContext) (*dgoapi.TxnContext, error) {
+	return &dgoapi.TxnContext{}, nil
+}
+
+func customDQLAuthQuery(t *testing.T, query string) (context.Context, schema.Query) {
+	sch := `
+	type Todo @auth(
+		query: { rule: """
+			query($USER: String!) {
+				queryTodo(filter: { owner: { eq: $USER } }) { id }
+			}"""
+		}
+	) {
+		id: ID!
+		text: String
+		owner: String @search(by: [hash])
+		parent: Todo
+		subtasks: [Todo]
+	}
+
+	type Query {
+		todosByText(text: String!): [Todo] @custom(dql: """
+			query q($text: string) {
+				todosByText(func: eq(Todo.text, $text)) {
+					id: uid
+					text: Todo.text
+				}
+			}""")
+	}
+	`
+	authSchema, err := testutil.AppendAuthInfo([]byte(sch), jwt.SigningMethodHS256.Name, "", false)
+	require.NoError(t, err)
+	strSchema := string(authSchema)
+	authMeta, err := authorization.Parse(strSchema)
+	require.NoError(t, err)
+	metaInfo := &testutil.AuthMeta{
+		PublicKey: authMeta.VerificationKey,
+		Namespace: authMeta.Namespace,
+		Algo:      authMeta.Algo,
+		AuthVars:  map[string]interface{}{"USER": "alice"},
+	}
+	ctx, err := metaInfo.AddClaimsToContext(context.Background())
+	require.NoError(t, err)
+
+	gqlSchema := test.LoadSchemaFromString(t, strSchema)
+	op, err := gqlSchema.Operation(&schema.Request{Query: query})
+	require.NoError(t, err)
+	return ctx, test.GetQuery(t, op)
+}
+
+func TestCustomDQLQueryAuth(t *testing.T) {
+	ctx, gqlQuery := customDQLAuthQuery(t, `query { todosByText(text: "a") { id text } }`)
+	ex := &customDQLExecutor{resps: []string{
+		`{"todosByText": [{"id": "0x1", "text": "a"}, {"id": "0x2", "text": "a"}]}`,
+		`{"authorized": [{"uid": "0x2"}]}`,
+	}}
+	resolved := NewCustomDQLQueryResolver(ex).Resolve(ctx, gqlQuery)
+	require.Nil(t, resolved.Err)
+	require.JSONEq(t, `{"todosByText": [{"id": "0x2", "text": "a"}]}`, string(resolved.Data))
+
+	// Only the nodes returned by the DQL query are checked.
+	require.Len(t, ex.queries, 2)
+	require.Contains(t, ex.queries[1], "uid(0x1, 0x2)")
+	require.Contains(t, ex.queries[1], `eq(Todo.owner, "alice")`)
+}
+
+func TestCustomDQLQueryAuthNested(t *testing.T) {
+	ctx, gqlQuery := customDQLAuthQuery(t, `query {
+		todosByText(text: "a") {
+			id
+			parent { id text }
+			subtasks { id text }
+		}
+	}`)
+	ex := &customDQLExecutor{resps: []string{
+		`{"todosByText": [
+			{"id": "0x1", "parent": {"id": "0x3", "text": "b"},
+				"subtasks": [{"id": "0x4", "text": "c"}, {"id": "0x5", "text": "d"}]},
+			{"id": "0x2", "parent": {"id": "0x6", "text": "e"}}
+		]}`,
+		`{"authorized": [{"uid": "0x1"}, {"uid": "0x3"}, {"uid": "0x5"}]}`,
+	}}
+	resolved := NewCustomDQLQueryResolver(ex).Resolve(ctx, gqlQuery)
+	require.Nil(t, resolved.Err)
+	require.JSONEq(t, `{"todosByText": [{"id": "0x1", "parent": {"id": "0x3", "text": "b"},
+		"subtasks": [{"id": "0x5", "text": "d"}]}]}`, string(resolved.Data))
+
+	// The root and nested nodes of a type are checked by a single query, the nodes nested in
+	// 0x2 included as they are found before it's removed.
+	require.Len(t, ex.queries, 2)
+	require.Contains(t, ex.queries[1], "uid(0x1, 0x3, 0x4, 0x5, 0x2, 0x6)")
+}
+
+func TestCustomDQLQueryAuthMissingID(t *testing.T) {
+	ctx, gqlQuery := customDQLAuthQuery(t, `query {
+		todosByText(text: "a") { text subtasks { text } }
+	}`)
+	ex := &customDQLExecutor{resps: []string{
+		`{"todosByText": [{"id": "0x1", "text": "a", "subtasks": [{"text": "b"}]}]}`,
+	}}
+	resolved := NewCustomDQLQueryResolver(ex).Resolve(ctx, gqlQuery)
+	require.NotNil(t, resolved.Err)
+	require.Contains(t, resolved.Err.Error(),
+		"a node of type Todo was returned without a valid id")
+	require.JSONEq(t, `{"todosByText": []}`, string(resolved.Data))
+
+	// No auth query is run, and no node is returned unchecked.
+	require.Len(t, ex.queries, 1)
+}
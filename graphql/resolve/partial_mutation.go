@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/graphql/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// inputItem is an add mutation whose input is a single item of the input of another one.
+type inputItem struct {
+	schema.Mutation
+	item interface{}
+}
+
+func (m inputItem) ArgValue(name string) interface{} {
+	if name == schema.InputArgName {
+		return []interface{}{m.item}
+	}
+	return m.Mutation.ArgValue(name)
+}
+
+// resolveInputItems resolves each item of the input of the add mutation m in its own transaction,
+// so that the items which fail don't keep the others from being added. The failures are reported
+// with the index of their item, and the results of the items which were added are returned as if
+// they were added by a single mutation.
+func resolveInputItems(ctx context.Context, mr MutationResolver, m schema.Mutation,
+	items []interface{}) (*Resolved, bool) {
+
+	var errs error
+	var added [][]byte
+	numUids := 0
+	success := true
+	someAdded := false
+	ext := &schema.Extensions{}
+	for i, item := range items {
+		res, ok := mr.Resolve(ctx, inputItem{Mutation: m, item: item})
+		success = success && ok
+		ext.Merge(res.Extensions)
+		errs = schema.AppendGQLErrs(errs, schema.GQLWrapf(res.Err, "input item %d", i))
+
+		var data map[string]map[string]json.RawMessage
+		if len(res.Data) == 0 || json.Unmarshal(res.Data, &data) != nil ||
+			data[m.ResponseName()] == nil {
+			continue
+		}
+		someAdded = true
+		for _, f := range m.SelectionSet() {
+			val := data[m.ResponseName()][f.ResponseName()]
+			switch f.Name() {
+			case schema.Typename, schema.Msg:
+			case schema.NumUid:
+				n, _ := strconv.Atoi(string(val))
+				numUids += n
+			default:
+				// The list of the query field, without its brackets.
+				if val = bytes.TrimSpace(val); len(val) > 2 && val[0] == '[' {
+					added = append(added, val[1:len(val)-1])
+				}
+			}
+		}
+	}
+
+	res := &Resolved{Field: m, Err: errs, Extensions: ext}
+	if !someAdded {
+		res.Data = m.NullResponse()
+		return res, success
+	}
+	var qryResult []byte
+	if qf := m.QueryField(); qf != nil && len(added) > 0 {
+		var buf bytes.Buffer
+		x.Check2(buf.WriteString(`{"` + qf.ResponseName() + `":[`))
+		x.Check2(buf.Write(bytes.Join(added, []byte{','})))
+		x.Check2(buf.WriteString(`]}`))
+		qryResult = buf.Bytes()
+	}
+	res.Data = completeMutationResult(m, qryResult, numUids)
+	return res, success
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/golang/glog"
@@ -27,6 +28,7 @@ import (
 
 	dgoapi "github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/graphql/authorization"
 	"github.com/dgraph-io/dgraph/graphql/dgraph"
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/x"
@@ -226,12 +228,192 @@ func (qr *customDQLQueryResolver) rewriteAndExecute(ctx context.Context,
 	if err = schema.Unmarshal(resp.Json, &respJson); err != nil {
 		return emptyResult(schema.GQLWrapf(err, "couldn't unmarshal Dgraph result"))
 	}
+	if err = qr.applyAuthRules(ctx, query, respJson); err != nil {
+		return emptyResult(schema.GQLWrapf(err, "couldn't apply the @auth rules of %s",
+			query.Type().Name()))
+	}
 
 	resolved := DataResult(query, respJson, nil)
 	resolved.Extensions = ext
 	return resolved
 }
 
+// applyAuthRules removes from the results of a @custom(dql: ...) query the nodes which don't
+// satisfy the query rule of the @auth directive of their type, at the root and in every nested
+// field of the selection set. The nodes are found from their ID field, so when a type has such a
+// rule the DQL query needs to return it, e.g. as id: uid, and it's an error if a node doesn't.
+func (qr *customDQLQueryResolver) applyAuthRules(ctx context.Context, query schema.Query,
+	respJson map[string]interface{}) error {
+	// The uids of the nodes of each type with a query rule, e.g. {"Todo": [0x1, 0x2]}.
+	uids := make(map[string][]uint64)
+	types := make(map[string]schema.Type)
+	err := walkCustomDQLNodes(query, respJson, func(typ schema.Type, obj map[string]interface{},
+		set func(allowed bool)) error {
+		uid, err := customDQLNodeUid(typ, obj)
+		if err != nil {
+			return err
+		}
+		uids[typ.Name()] = append(uids[typ.Name()], uid)
+		types[typ.Name()] = typ
+		return nil
+	})
+	if err != nil || len(types) == 0 {
+		return err
+	}
+
+	customClaims, err := query.GetAuthMeta().ExtractCustomClaims(ctx)
+	if err != nil {
+		return err
+	}
+	// The uids allowed for each type, nil if the rule is satisfied for all of them.
+	allowed := make(map[string]map[uint64]bool, len(types))
+	for name, typ := range types {
+		if allowed[name], err = qr.authorizedUids(ctx, customClaims, typ, uids[name]); err != nil {
+			return err
+		}
+	}
+
+	return walkCustomDQLNodes(query, respJson, func(typ schema.Type, obj map[string]interface{},
+		set func(allowed bool)) error {
+		if ok := allowed[typ.Name()]; ok != nil {
+			uid, _ := customDQLNodeUid(typ, obj)
+			set(ok[uid])
+		}
+		return nil
+	})
+}
+
+// authorizedUids returns which of the given nodes of typ satisfy the query rule of its @auth
+// directive, or nil if they all do.
+func (qr *customDQLQueryResolver) authorizedUids(ctx context.Context,
+	customClaims *authorization.CustomClaims, typ schema.Type,
+	uids []uint64) (map[uint64]bool, error) {
+	authRw := &authRewriter{
+		authVariables: customClaims.AuthVariables,
+		varGen:        NewVariableGenerator(),
+		selector:      queryAuthSelector,
+		parentVarName: typ.Name() + "Root",
+		hasAuthRules:  true,
+	}
+	rbac := authRw.evaluateStaticRules(typ)
+	allowed := make(map[uint64]bool, len(uids))
+	switch rbac {
+	case schema.Positive:
+		return nil, nil
+	case schema.Negative:
+		return allowed, nil
+	}
+
+	// authorized(func: uid(AuthorRoot)) { uid }
+	// AuthorRoot as var(func: uid(Author1)) @filter(uid(AuthorAuth2))
+	// Author1 as var(func: uid(0x1, 0x2))
+	// AuthorAuth2 as var(func: uid(Author1)) @filter(...)
+	dgQuery := authRw.addAuthQueries(typ, []*gql.GraphQuery{{
+		Attr:     "authorized",
+		Func:     &gql.Function{Name: "uid", UID: uids},
+		Children: []*gql.GraphQuery{{Attr: "uid"}},
+	}}, rbac)
+	resp, err := qr.executor.Execute(ctx, &dgoapi.Request{Query: dgraph.AsString(dgQuery),
+		ReadOnly: true}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Authorized []struct {
+			Uid string `json:"uid"`
+		} `json:"authorized"`
+	}
+	if err := json.Unmarshal(resp.GetJson(), &res); err != nil {
+		return nil, err
+	}
+	for _, n := range res.Authorized {
+		if uid, err := strconv.ParseUint(n.Uid, 0, 64); err == nil {
+			allowed[uid] = true
+		}
+	}
+	return allowed, nil
+}
+
+// customDQLNodeUid returns the uid of a node of typ from the value of its ID field.
+func customDQLNodeUid(typ schema.Type, obj map[string]interface{}) (uint64, error) {
+	idField := typ.IDField()
+	if idField == nil {
+		return 0, fmt.Errorf("type %s has an @auth query rule but no ID field", typ.Name())
+	}
+	id, _ := obj[idField.Name()].(string)
+	uid, err := strconv.ParseUint(id, 0, 64)
+	if err != nil || uid == 0 {
+		return 0, fmt.Errorf("a node of type %s was returned without a valid %s, which is "+
+			"needed to check its @auth rules", typ.Name(), idField.Name())
+	}
+	return uid, nil
+}
+
+// walkCustomDQLNodes calls fn for each node of f, and of the fields nested in it, whose type has
+// an @auth query rule. set(false) removes the node from the result, in which case the fields
+// nested in it aren't walked.
+func walkCustomDQLNodes(f schema.Field, parent map[string]interface{},
+	fn func(typ schema.Type, obj map[string]interface{}, set func(allowed bool)) error) error {
+	key := f.RemoteResponseName()
+	typ := f.Type()
+	if typ.IsInbuiltOrEnumType() {
+		return nil
+	}
+	hasRules := queryAuthSelector(typ) != nil
+	if typ.IsInterface() {
+		for _, t := range typ.ImplementingTypes() {
+			hasRules = hasRules || queryAuthSelector(t) != nil
+		}
+	}
+
+	visit := func(obj map[string]interface{}) (bool, error) {
+		keep := true
+		if hasRules {
+			if err := fn(typ, obj, func(allowed bool) { keep = allowed }); err != nil {
+				return false, err
+			}
+		}
+		if !keep {
+			return false, nil
+		}
+		for _, child := range f.SelectionSet() {
+			if err := walkCustomDQLNodes(child, obj, fn); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	switch val := parent[key].(type) {
+	case []interface{}:
+		kept := val[:0]
+		for _, node := range val {
+			obj, ok := node.(map[string]interface{})
+			if !ok {
+				kept = append(kept, node)
+				continue
+			}
+			keep, err := visit(obj)
+			if err != nil {
+				return err
+			}
+			if keep {
+				kept = append(kept, node)
+			}
+		}
+		parent[key] = kept
+	case map[string]interface{}:
+		keep, err := visit(val)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			parent[key] = nil
+		}
+	}
+	return nil
+}
+
 func resolveIntrospection(ctx context.Context, q schema.Query) *Resolved {
 	data, err := schema.Introspect(q)
 	return &Resolved{
@@ -80,6 +80,12 @@ func hasAuthRules(field schema.Field, authRw *authRewriter) bool {
 	}
 
 	for _, childField := range field.SelectionSet() {
+		if childField.IsConnectionField() {
+			childField = newConnectionField(childField)
+		}
+		if hasFieldAuthRules(field.Type(), childField.Name()) {
+			return true
+		}
 		if authRules := hasAuthRules(childField, authRw); authRules {
 			return true
 		}
@@ -87,6 +93,33 @@ func hasAuthRules(field schema.Field, authRw *authRewriter) bool {
 	return false
 }
 
+// fieldAuthTypes returns the types whose field auth rules apply to the fields of typ. The rules
+// on the fields of an interface are merged into its implementing types.
+func fieldAuthTypes(typ schema.Type) []schema.Type {
+	if typ.IsInterface() {
+		return typ.ImplementingTypes()
+	}
+	return []schema.Type{typ}
+}
+
+// fieldQueryRule returns the query rule of the @auth directive on the field fld of typ, if any.
+func fieldQueryRule(typ schema.Type, fld string) *schema.RuleNode {
+	auth := typ.AuthRules()
+	if auth == nil || auth.Fields[fld] == nil {
+		return nil
+	}
+	return auth.Fields[fld].Query
+}
+
+func hasFieldAuthRules(typ schema.Type, fld string) bool {
+	for _, t := range fieldAuthTypes(typ) {
+		if fieldQueryRule(t, fld) != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func hasCascadeDirective(field schema.Field) bool {
 	if c := field.Cascade(); c != nil {
 		return true
@@ -127,6 +160,13 @@ func (qr *queryRewriter) Rewrite(
 	authRw.hasAuthRules = hasAuthRules(gqlQuery, authRw)
 	authRw.hasCascade = hasCascadeDirective(gqlQuery)
 
+	if err := checkCursors(gqlQuery); err != nil {
+		return nil, err
+	}
+	if err := authRw.checkFieldAuth(gqlQuery); err != nil {
+		return nil, err
+	}
+
 	switch gqlQuery.QueryType() {
 	case schema.GetQuery:
 
@@ -297,6 +337,11 @@ func aggregateQuery(query schema.Query, authRw *authRewriter) []*gql.GraphQuery
 				// constructedForField contains the field for which aggregate function has been queried.
 				// As all aggregate functions have length 3, removing last 3 characters from fldName.
 				constructedForField := fldName[:len(fldName)-3]
+				// The aggregate of a field with an @auth rule is only returned if the rule is
+				// satisfied for all the nodes, as it can't be computed per node.
+				if !authRw.fieldAuthSatisfied(mainType, constructedForField) {
+					break
+				}
 				// isAggregateVarAdded ensures that a field is added to Var query at maximum once.
 				// If a field has already been added to the var query, don't add it again.
 				// Eg. Even if scoreMax and scoreMin are queried, the query will contain only one expression
@@ -943,6 +988,112 @@ func (authRw *authRewriter) rewriteAuthQueries(typ schema.Type) ([]*gql.GraphQue
 	}).rewriteRuleNode(typ, authRw.selector(typ))
 }
 
+// fieldAuthSatisfied returns true if the query rules on the field fld of typ are statically
+// satisfied, so that the field can be returned for all the nodes.
+func (authRw *authRewriter) fieldAuthSatisfied(typ schema.Type, fld string) bool {
+	if authRw == nil || authRw.isWritingAuth {
+		return true
+	}
+	for _, t := range fieldAuthTypes(typ) {
+		rn := fieldQueryRule(t, fld)
+		if rn != nil && rn.EvaluateStatic(authRw.authVariables) != schema.Positive {
+			return false
+		}
+	}
+	return true
+}
+
+// rewriteFieldAuth builds the queries for the query rule on the field f of typ. The rule is
+// evaluated for the nodes of the current level, which are in parentVarName, and the field is only
+// returned for the nodes which satisfy it, while the nodes themselves are still returned. It
+// returns the queries and the variable holding the nodes which satisfy the rule, which is empty if
+// the field is returned for all the nodes. The boolean is false if the field isn't returned for
+// any node.
+func (authRw *authRewriter) rewriteFieldAuth(
+	typ schema.Type,
+	f schema.Field) ([]*gql.GraphQuery, string, bool) {
+
+	if authRw == nil || authRw.isWritingAuth || !hasFieldAuthRules(typ, f.Name()) {
+		return nil, "", true
+	}
+
+	var qrys []*gql.GraphQuery
+	var filts []*gql.FilterTree
+	restricted := false
+	for _, t := range fieldAuthTypes(typ) {
+		rn := fieldQueryRule(t, f.Name())
+		eval := schema.Positive
+		if rn != nil {
+			eval = rn.EvaluateStatic(authRw.authVariables)
+		}
+
+		var filter *gql.FilterTree
+		switch eval {
+		case schema.Negative:
+			restricted = true
+			continue
+		case schema.Uncertain:
+			// The auth queries start from the nodes of the current level, like
+			// Post_Auth3 as var(func: uid(PostRoot)) @filter(...) @cascade { ... }
+			var ruleQrys []*gql.GraphQuery
+			ruleQrys, filter = (&authRewriter{
+				authVariables: authRw.authVariables,
+				varGen:        authRw.varGen,
+				isWritingAuth: true,
+				varName:       authRw.parentVarName,
+				selector:      authRw.selector,
+				parentVarName: authRw.parentVarName,
+				hasAuthRules:  authRw.hasAuthRules,
+			}).rewriteRuleNode(t, rn)
+			restricted = true
+			if filter == nil {
+				continue
+			}
+			qrys = append(qrys, ruleQrys...)
+		}
+
+		// For an interface, the rule of an implementing type only applies to the nodes of
+		// that type.
+		if typ.IsInterface() {
+			typeFilter := &gql.FilterTree{Func: buildTypeFunc(t.DgraphName())}
+			if filter == nil {
+				filter = typeFilter
+			} else {
+				filter = &gql.FilterTree{Op: "and", Child: []*gql.FilterTree{typeFilter, filter}}
+			}
+		}
+		if filter != nil {
+			filts = append(filts, filter)
+		}
+	}
+
+	if !restricted {
+		return nil, "", true
+	}
+	if len(filts) == 0 {
+		return nil, "", false
+	}
+
+	// build a query like
+	//   Post_4 as var(func: uid(PostRoot)) @filter(uid(Post_Auth3))
+	// which holds the nodes for which the field is returned.
+	filter := filts[0]
+	if len(filts) > 1 {
+		filter = &gql.FilterTree{Op: "or", Child: filts}
+	}
+	varName := authRw.varGen.Next(typ, "", "", false)
+	qrys = append(qrys, &gql.GraphQuery{
+		Var:  varName,
+		Attr: "var",
+		Func: &gql.Function{
+			Name: "uid",
+			Args: []gql.Arg{{Value: authRw.parentVarName}},
+		},
+		Filter: filter,
+	})
+	return qrys, varName, true
+}
+
 func (authRw *authRewriter) evaluateStaticRules(typ schema.Type) schema.RuleResult {
 	if authRw == nil || authRw.isWritingAuth {
 		return schema.Uncertain
@@ -1210,6 +1361,9 @@ func buildAggregateFields(
 				// has been queried. Eg. name for nameMax. Removing last 3 characters as all
 				// aggregation functions have length 3
 				constructedForField := aggregateFldName[:len(aggregateFldName)-3]
+				if !auth.fieldAuthSatisfied(constructedForType, constructedForField) {
+					break
+				}
 				// constructedForDgraphPredicate stores the Dgraph predicate for which aggregate function
 				// has been queried. Eg. Post.name for nameMin
 				constructedForDgraphPredicateField := aggregateField.DgraphPredicateForAggregateField()
@@ -1380,6 +1534,12 @@ func addSelectionSetFrom(
 			continue
 		}
 
+		// A connection field is queried as the list field it is generated for, with the nodes
+		// selected in edges { node { ... } }.
+		if f.IsConnectionField() {
+			f = newConnectionField(f)
+		}
+
 		child := &gql.GraphQuery{
 			Alias: f.DgraphAlias(),
 		}
@@ -1398,6 +1558,17 @@ func addSelectionSetFrom(
 			continue
 		}
 
+		// A field with an @auth directive is only part of the results of the nodes which
+		// satisfy its query rule.
+		fieldRuleQueries, includeVar, allowed := auth.rewriteFieldAuth(field.Type(), f)
+		if !allowed {
+			fieldAdded[f.DgraphAlias()] = true
+			continue
+		}
+		if includeVar != "" {
+			child.Include = &gql.IncludeArgs{Var: includeVar, UidVar: true}
+		}
+
 		// Add type filter in case the Dgraph predicate is a reverse edge
 		if strings.HasPrefix(f.DgraphPredicate(), "~") {
 			addTypeFilter(child, f.Type())
@@ -1422,6 +1593,9 @@ func addSelectionSetFrom(
 		if !f.Type().IsGeo() {
 			selectionAuth = addSelectionSetFrom(child, f, auth)
 		}
+		if _, ok := f.(*connectionField); ok {
+			addConnectionCursor(child)
+		}
 
 		restoreAuthState := func() {
 			if len(f.SelectionSet()) > 0 && !auth.isWritingAuth && auth.hasAuthRules {
@@ -1485,6 +1659,7 @@ func addSelectionSetFrom(
 			}
 			authQueries = append(authQueries, commonAuthQueryVars.parentQry, commonAuthQueryVars.selectionQry)
 		}
+		authQueries = append(authQueries, fieldRuleQueries...)
 		authQueries = append(authQueries, selectionAuth...)
 		authQueries = append(authQueries, fieldAuth...)
 		restoreAuthState()
@@ -1551,6 +1726,181 @@ func addPagination(q *gql.GraphQuery, field schema.Field) {
 	if offset != nil {
 		q.Args["offset"] = fmt.Sprintf("%v", offset)
 	}
+
+	after := field.ArgValue("after")
+	if after != nil {
+		q.Args["after"] = fmt.Sprintf("%v", after)
+	}
+}
+
+// connectionField is the list field a connection field is generated for, as seen by the query
+// rewriting. Its selection set is the one of the nodes of the edges of the connection, and one
+// more node is queried than asked for with first, to know whether there is a next page.
+type connectionField struct {
+	schema.Field
+	node schema.Field
+}
+
+func newConnectionField(f schema.Field) *connectionField {
+	cf := &connectionField{Field: f}
+	for _, edges := range f.SelectionSet() {
+		if edges.Name() != "edges" || edges.Skip() || !edges.Include() {
+			continue
+		}
+		for _, node := range edges.SelectionSet() {
+			if node.Name() == "node" && !node.Skip() && node.Include() {
+				cf.node = node
+				return cf
+			}
+		}
+	}
+	return cf
+}
+
+func (cf *connectionField) Name() string {
+	return strings.TrimSuffix(cf.Field.Name(), "Connection")
+}
+
+func (cf *connectionField) Type() schema.Type {
+	return cf.ConstructedFor()
+}
+
+func (cf *connectionField) DgraphPredicate() string {
+	return cf.ConstructedForDgraphPredicate()
+}
+
+func (cf *connectionField) SelectionSet() []schema.Field {
+	if cf.node == nil {
+		return nil
+	}
+	return cf.node.SelectionSet()
+}
+
+func (cf *connectionField) AbstractType() bool {
+	return cf.node != nil && cf.node.AbstractType()
+}
+
+func (cf *connectionField) ArgValue(name string) interface{} {
+	val := cf.Field.ArgValue(name)
+	switch name {
+	case "first":
+		if first, ok := val.(int64); ok {
+			return first + 1
+		}
+	case "after":
+		if after, ok := val.(string); ok {
+			// The cursor has already been checked by checkCursors.
+			uid, _ := schema.ParseConnectionCursor(after)
+			return fmt.Sprintf("%#x", uid)
+		}
+	}
+	return val
+}
+
+// addConnectionCursor adds the uid of the nodes of a connection, which their cursors are made
+// from, unless it's already queried.
+func addConnectionCursor(q *gql.GraphQuery) {
+	for _, c := range q.Children {
+		if c.Alias == "dgraph.uid" {
+			return
+		}
+	}
+	q.Children = append(q.Children, &gql.GraphQuery{Attr: "uid", Alias: "dgraph.uid"})
+}
+
+// checkCursors returns an error if the after argument of a connection field selected by f isn't
+// a cursor returned by Dgraph.
+func checkCursors(f schema.Field) error {
+	for _, sel := range f.SelectionSet() {
+		if sel.IsConnectionField() {
+			if after, ok := sel.ArgValue("after").(string); ok {
+				if _, err := schema.ParseConnectionCursor(after); err != nil {
+					return errors.Wrapf(err, "while reading the after argument of %s", sel.Name())
+				}
+			}
+		}
+		if err := checkCursors(sel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFieldAuth checks that the fields with an @auth query rule, which are used in the filter
+// and order arguments of f and its selections, have their rule satisfied for all the nodes.
+// Unlike the selection set, a filter or an order on such a field would otherwise still be
+// evaluated with the values of the nodes which don't satisfy the rule.
+func (authRw *authRewriter) checkFieldAuth(f schema.Field) error {
+	typ := f.ConstructedFor()
+	check := func(fld, arg string) error {
+		if !authRw.fieldAuthSatisfied(typ, fld) {
+			return errors.Errorf("field %s of type %s has an @auth rule which isn't satisfied, "+
+				"so it can't be used in the %s argument of %s", fld, typ.Name(), arg, f.Name())
+		}
+		return nil
+	}
+
+	filter, _ := f.ArgValue("filter").(map[string]interface{})
+	for _, fld := range filterFields(filter) {
+		if err := check(fld, "filter"); err != nil {
+			return err
+		}
+	}
+	order, ok := f.ArgValue("order").(map[string]interface{})
+	for ok {
+		for _, dir := range []string{"asc", "desc"} {
+			if fld, isFld := order[dir].(string); isFld {
+				if err := check(fld, "order"); err != nil {
+					return err
+				}
+			}
+		}
+		order, ok = order["then"].(map[string]interface{})
+	}
+
+	for _, sel := range f.SelectionSet() {
+		if err := authRw.checkFieldAuth(sel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterFields returns the names of the fields used in a GraphQL filter argument, including the
+// fields of the has filter.
+func filterFields(filter map[string]interface{}) []string {
+	var flds []string
+	for key, val := range filter {
+		switch key {
+		case "and", "or", "not":
+			switch v := val.(type) {
+			case map[string]interface{}:
+				flds = append(flds, filterFields(v)...)
+			case []interface{}:
+				for _, obj := range v {
+					if m, ok := obj.(map[string]interface{}); ok {
+						flds = append(flds, filterFields(m)...)
+					}
+				}
+			}
+		case "has":
+			switch v := val.(type) {
+			case string:
+				flds = append(flds, v)
+			case []interface{}:
+				for _, fld := range v {
+					if s, ok := fld.(string); ok {
+						flds = append(flds, s)
+					}
+				}
+			}
+		default:
+			flds = append(flds, key)
+		}
+	}
+	// Get a stable ordering so we report the same field each time.
+	sort.Strings(flds)
+	return flds
 }
 
 func addCascadeDirective(q *gql.GraphQuery, field schema.Field) {
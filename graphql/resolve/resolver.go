@@ -508,6 +508,10 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) (
 		resp.Errors = schema.AsGQLErrors(err)
 		return
 	}
+	if err := validateQueryLimits(op); err != nil {
+		resp.Errors = schema.AsGQLErrors(err)
+		return
+	}
 
 	if glog.V(3) {
 		// don't log the introspection queries they are sent too frequently
@@ -583,11 +587,16 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) (
 		// https://github.com/graphql/graphql-spec/pull/438
 		//
 		// A reasonable interpretation of that is to stop a list of mutations after the first error -
-		// which seems like the natural semantics and is what we enforce here.
+		// which seems like the natural semantics and is what we enforce here, unless
+		// partial-mutations is set in the --graphql superflag or in the extensions of the request.
+		// In that case, every mutation is executed and each failure is reported with the path of
+		// its mutation, and so is every item of the input of the add mutations. Either way, each
+		// mutation is committed in its own transaction.
 		allSuccessful := true
+		partial := partialMutations(gqlReq)
 
 		for _, m := range op.Mutations() {
-			if !allSuccessful {
+			if !allSuccessful && !partial {
 				resp.WithError(x.GqlErrorf(
 					"Mutation %s was not executed because of a previous error.",
 					m.ResponseName()).
@@ -598,7 +607,14 @@ func (r *RequestResolver) Resolve(ctx context.Context, gqlReq *schema.Request) (
 			}
 
 			var res *Resolved
-			res, allSuccessful = r.resolvers.mutationResolverFor(m).Resolve(ctx, m)
+			var success bool
+			items, _ := m.ArgValue(schema.InputArgName).([]interface{})
+			if partial && m.MutationType() == schema.AddMutation && len(items) > 1 {
+				res, success = resolveInputItems(ctx, r.resolvers.mutationResolverFor(m), m, items)
+			} else {
+				res, success = r.resolvers.mutationResolverFor(m).Resolve(ctx, m)
+			}
+			allSuccessful = allSuccessful && success
 			addResult(resp, res)
 		}
 	case op.IsSubscription():
@@ -618,6 +634,9 @@ func (r *RequestResolver) ValidateSubscription(req *schema.Request) error {
 	if !op.IsSubscription() {
 		return errors.New("given GraphQL operation is not a subscription")
 	}
+	if err := validateQueryLimits(op); err != nil {
+		return err
+	}
 
 	for _, q := range op.Queries() {
 		for _, field := range q.SelectionSet() {
@@ -633,6 +652,70 @@ func (r *RequestResolver) Schema() schema.Schema {
 	return r.schema
 }
 
+// partialMutations returns whether all the mutations of an operation are executed even if some of
+// them fail, as set by partialMutations in the extensions of the request, or else by
+// partial-mutations in the --graphql superflag.
+func partialMutations(gqlReq *schema.Request) bool {
+	if p := gqlReq.Extensions.PartialMutations; p != nil {
+		return *p
+	}
+	return x.Config.GraphQL != nil && x.Config.GraphQL.GetBool("partial-mutations")
+}
+
+// validateQueryLimits rejects the operation if it is nested deeper than the max-depth or selects
+// more fields than the max-complexity set in the --graphql superflag. A limit of 0 disables it.
+func validateQueryLimits(op schema.Operation) error {
+	if x.Config.GraphQL == nil {
+		return nil
+	}
+	return checkQueryLimits(op, x.Config.GraphQL.GetInt64("max-depth"),
+		x.Config.GraphQL.GetInt64("max-complexity"))
+}
+
+func checkQueryLimits(op schema.Operation, maxDepth, maxComplexity int64) error {
+	if maxDepth <= 0 && maxComplexity <= 0 {
+		return nil
+	}
+
+	fields := make([]schema.Field, 0, len(op.Queries())+len(op.Mutations()))
+	for _, q := range op.Queries() {
+		fields = append(fields, q)
+	}
+	for _, m := range op.Mutations() {
+		fields = append(fields, m)
+	}
+
+	// Fragments have already been expanded into the selection sets, so the fields selected
+	// through them count towards the depth and complexity like any other field. Every aliased
+	// field is a separate field in the selection set, and counts on its own.
+	var complexity int64
+	var walk func(f schema.Field, depth int64) error
+	walk = func(f schema.Field, depth int64) error {
+		if maxDepth > 0 && depth > maxDepth {
+			return x.GqlErrorf("Query is nested %d levels deep at field `%s`, which is more "+
+				"than the maximum allowed depth of %d.", depth, f.Name(), maxDepth).
+				WithLocations(f.Location())
+		}
+		complexity++
+		if maxComplexity > 0 && complexity > maxComplexity {
+			return x.GqlErrorf("Query selects more than the maximum allowed %d fields.",
+				maxComplexity).WithLocations(f.Location())
+		}
+		for _, child := range f.SelectionSet() {
+			if err := walk(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, f := range fields {
+		if err := walk(f, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateCustomFieldsRecursively will return err if the given field is custom or any of its
 // children is type of a custom field.
 func validateCustomFieldsRecursively(field schema.Field) error {
@@ -698,7 +781,7 @@ func (hr *httpResolver) Resolve(ctx context.Context, field schema.Field) *Resolv
 }
 
 func (hr *httpResolver) rewriteAndExecute(ctx context.Context, field schema.Field) *Resolved {
-	hrc, err := field.CustomHTTPConfig()
+	hrc, err := field.CustomHTTPConfig(ctx)
 	if err != nil {
 		return EmptyResult(field, err)
 	}
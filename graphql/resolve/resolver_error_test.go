@@ -27,6 +27,7 @@ import (
 	"github.com/dgraph-io/dgraph/graphql/schema"
 	"github.com/dgraph-io/dgraph/graphql/test"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto/z"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -448,6 +449,100 @@ func TestManyMutationsWithError(t *testing.T) {
 	}
 }
 
+// TestManyMutationsWithErrorPartial : with partial-mutations set in the --graphql superflag, the
+// mutations following an error are still run, and only the failed mutation is reported.
+func TestManyMutationsWithErrorPartial(t *testing.T) {
+	defer func(gql *z.SuperFlag) { x.Config.GraphQL = gql }(x.Config.GraphQL)
+	x.Config.GraphQL = z.NewSuperFlag("partial-mutations=true;")
+
+	multiMutation := `mutation multipleMutations($id: ID!) {
+			add1: addPost(input: [{title: "A Post", text: "Some text", author: {id: "0x1"}}]) {
+				post { title }
+			}
+
+			add2: addPost(input: [{title: "A Post", text: "Some text", author: {id: $id}}]) {
+				post { title }
+			}
+
+			add3: addPost(input: [{title: "A Post", text: "Some text", author: {id: "0x1"}}]) {
+				post { title }
+			}
+		}`
+
+	gqlSchema := test.LoadSchemaFromString(t, testGQLSchema)
+	resp := resolveWithClient(
+		gqlSchema,
+		multiMutation,
+		map[string]interface{}{"id": "hi"},
+		&executor{
+			existenceQueriesResp: `{ "Author_1": [{"uid":"0x1", "dgraph.type":["Author"]}]}`,
+			resp:                 `{"post": [{ "title": "A Post" } ] }`,
+			assigned:             map[string]string{"Post_2": "0x2"},
+		})
+
+	errs := x.GqlErrorList{
+		&x.GqlError{Message: `couldn't rewrite mutation addPost because ` +
+			`failed to rewrite mutation payload because ` +
+			`ID argument (hi) was not able to be parsed`,
+			Path: []interface{}{"add2"}}}
+	if diff := cmp.Diff(errs, resp.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	require.JSONEq(t, `{
+		"add1": { "post": [{ "title": "A Post" }] },
+		"add2" : null,
+		"add3": { "post": [{ "title": "A Post" }] }
+	}`, resp.Data.String())
+}
+
+// TestAddItemsWithErrorPartial : with partialMutations set in the extensions of the request, each
+// item of the input of an add mutation is added on its own, and only the failed item is reported.
+func TestAddItemsWithErrorPartial(t *testing.T) {
+	addPosts := `mutation addPosts($id: ID!) {
+			addPost(input: [
+				{title: "A Post", text: "Some text", author: {id: "0x1"}},
+				{title: "A Post", text: "Some text", author: {id: $id}},
+				{title: "A Post", text: "Some text", author: {id: "0x1"}}
+			]) {
+				post { title }
+				numUids
+			}
+		}`
+	gqlSchema := test.LoadSchemaFromString(t, testGQLSchema)
+	resolve := func(partial bool) *schema.Response {
+		req := &schema.Request{Query: addPosts, Variables: map[string]interface{}{"id": "hi"}}
+		req.Extensions.PartialMutations = &partial
+		return resolveRequestWithClient(gqlSchema, req, &executor{
+			existenceQueriesResp: `{ "Author_1": [{"uid":"0x1", "dgraph.type":["Author"]}]}`,
+			resp:                 `{"post": [{ "title": "A Post" } ] }`,
+			assigned:             map[string]string{"Post_2": "0x2"},
+		})
+	}
+	rewriteErr := `couldn't rewrite mutation addPost because ` +
+		`failed to rewrite mutation payload because ` +
+		`ID argument (hi) was not able to be parsed`
+
+	resp := resolve(true)
+	errs := x.GqlErrorList{
+		&x.GqlError{Message: "input item 1 because " + rewriteErr, Path: []interface{}{"addPost"}}}
+	if diff := cmp.Diff(errs, resp.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	require.JSONEq(t, `{
+		"addPost": { "post": [{ "title": "A Post" }, { "title": "A Post" }], "numUids": 2 }
+	}`, resp.Data.String())
+
+	// The request overrides the superflag, and none of the items is added without it.
+	defer func(gql *z.SuperFlag) { x.Config.GraphQL = gql }(x.Config.GraphQL)
+	x.Config.GraphQL = z.NewSuperFlag("partial-mutations=true;")
+	resp = resolve(false)
+	errs = x.GqlErrorList{&x.GqlError{Message: rewriteErr, Path: []interface{}{"addPost"}}}
+	if diff := cmp.Diff(errs, resp.Errors); diff != "" {
+		t.Errorf("errors mismatch (-want +got):\n%s", diff)
+	}
+	require.JSONEq(t, `{"addPost": null}`, resp.Data.String())
+}
+
 func TestSubscriptionErrorWhenNoneDefined(t *testing.T) {
 	gqlSchema := test.LoadSchemaFromString(t, testGQLSchema)
 	resp := resolveWithClient(gqlSchema, `subscription { foo }`, nil, nil)
@@ -464,6 +559,13 @@ func resolveWithClient(
 	gqlQuery string,
 	vars map[string]interface{},
 	ex DgraphExecutor) *schema.Response {
+	return resolveRequestWithClient(gqlSchema, &schema.Request{Query: gqlQuery, Variables: vars}, ex)
+}
+
+func resolveRequestWithClient(
+	gqlSchema schema.Schema,
+	req *schema.Request,
+	ex DgraphExecutor) *schema.Response {
 	resolver := New(
 		gqlSchema,
 		NewResolverFactory(nil, nil).WithConventionResolvers(gqlSchema, &ResolverFns{
@@ -473,5 +575,5 @@ func resolveWithClient(
 			Ex:  ex,
 		}))
 
-	return resolver.Resolve(context.Background(), &schema.Request{Query: gqlQuery, Variables: vars})
+	return resolver.Resolve(context.Background(), req)
 }
@@ -489,3 +489,51 @@ func TestResponseOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryLimits(t *testing.T) {
+	gqlSchema := test.LoadSchemaFromFile(t, "schema.graphql")
+
+	tests := []struct {
+		name          string
+		query         string
+		maxDepth      int64
+		maxComplexity int64
+		err           string
+	}{
+		{name: "no limits",
+			query: `query { getAuthor(id: "0x1") { posts { author { posts { title } } } } }`},
+		{name: "within the depth limit",
+			query:    `query { getAuthor(id: "0x1") { posts { title } } }`,
+			maxDepth: 3},
+		{name: "too deep",
+			query:    `query { getAuthor(id: "0x1") { posts { author { name } } } }`,
+			maxDepth: 3,
+			err:      "Query is nested 4 levels deep at field `name`"},
+		{name: "fragments count towards the depth",
+			query: `query { getAuthor(id: "0x1") { ...authorPosts } }
+				fragment authorPosts on Author { posts { author { name } } }`,
+			maxDepth: 3,
+			err:      "Query is nested 4 levels deep at field `name`"},
+		{name: "within the complexity limit",
+			query:         `query { getAuthor(id: "0x1") { name dob } }`,
+			maxComplexity: 3},
+		{name: "aliases count towards the complexity",
+			query:         `query { getAuthor(id: "0x1") { name n1: name n2: name } }`,
+			maxComplexity: 3,
+			err:           "Query selects more than the maximum allowed 3 fields."},
+	}
+
+	for _, tcase := range tests {
+		t.Run(tcase.name, func(t *testing.T) {
+			op, err := gqlSchema.Operation(&schema.Request{Query: tcase.query})
+			require.NoError(t, err)
+			err = checkQueryLimits(op, tcase.maxDepth, tcase.maxComplexity)
+			if tcase.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tcase.err)
+		})
+	}
+}
@@ -238,7 +238,7 @@ func authRules(sch *schema) (map[string]*TypeAuth, error) {
 		}
 	}
 
-	// Merge the Auth rules on interfaces into the implementing types
+	// Merge the Auth rules on interfaces and on their fields into the implementing types
 	for _, typ := range s.Types {
 		name := typeName(typ)
 		if typ.Kind == ast.Object {
@@ -251,6 +251,16 @@ func authRules(sch *schema) (map[string]*TypeAuth, error) {
 						mergeAuthNodeWithAnd,
 					)
 				}
+				if authRules[interfaceName] == nil {
+					continue
+				}
+				for fld, rules := range authRules[interfaceName].Fields {
+					authRules[name].Fields[fld] = mergeAuthRules(
+						authRules[name].Fields[fld],
+						rules,
+						mergeAuthNodeWithAnd,
+					)
+				}
 			}
 		}
 	}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/gqlparser/v2/ast"
+	"github.com/dgraph-io/gqlparser/v2/gqlerror"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A list field with the @connection directive also gets a Relay style connection field, for
+// cursor based pagination. For example, posts: [Post] @connection adds
+//
+//	postsConnection(filter: PostFilter, first: Int, after: String): PostConnection!
+//
+// along with the types:
+//
+//	type PostConnection {
+//		edges: [PostEdge!]!
+//		pageInfo: PageInfo!
+//	}
+//	type PostEdge {
+//		node: Post
+//		cursor: String!
+//	}
+//
+// The edges are in the order of the uids of the nodes, so that a cursor still points to the same
+// position when nodes are added or removed. That's why a connection can't be ordered.
+
+const (
+	connectionSuffix = "Connection"
+	pageInfoType     = "PageInfo"
+)
+
+// connectionValidation checks that @connection is on a list of an object or interface type, and
+// that the generated field and types don't clash with the ones of the schema.
+func connectionValidation(sch *ast.Schema,
+	typ *ast.Definition,
+	field *ast.FieldDefinition,
+	dir *ast.Directive,
+	secrets map[string]x.SensitiveByteSlice) gqlerror.List {
+
+	fldType := sch.Types[field.Type.Name()]
+	if !isTypeList(field) || fldType == nil ||
+		(fldType.Kind != ast.Object && fldType.Kind != ast.Interface) {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: @connection directive can only be applied to a list of an "+
+				"object or an interface type.", typ.Name, field.Name)}
+	}
+	if hasCustomOrLambda(field) {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: @connection directive can't be applied to a field with "+
+				"@custom or @lambda.", typ.Name, field.Name)}
+	}
+	if typ.Fields.ForName(field.Name+connectionSuffix) != nil {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: @connection directive generates the field %s, which is "+
+				"already defined.", typ.Name, field.Name, field.Name+connectionSuffix)}
+	}
+	for _, name := range []string{fldType.Name + connectionSuffix, fldType.Name + "Edge",
+		pageInfoType} {
+		if def := sch.Types[name]; def != nil && !def.BuiltIn {
+			return []*gqlerror.Error{gqlerror.ErrorPosf(dir.Position,
+				"Type %s; Field %s: @connection directive generates the type %s, which is "+
+					"already defined.", typ.Name, field.Name, name)}
+		}
+	}
+	return nil
+}
+
+// addConnectionFields adds the connection fields of the list fields of defn which have the
+// @connection directive, and the types they return.
+func addConnectionFields(sch *ast.Schema, defn *ast.Definition) {
+	for _, fld := range defn.Fields {
+		if fld.Directives.ForName(connectionDirective) == nil {
+			continue
+		}
+		nodeType := fld.Type.Name()
+		addConnectionTypes(sch, nodeType)
+
+		connField := &ast.FieldDefinition{
+			Name: fld.Name + connectionSuffix,
+			Type: &ast.Type{NamedType: nodeType + connectionSuffix, NonNull: true},
+		}
+		addFilterArgumentForField(sch, connField, nodeType)
+		connField.Arguments = append(connField.Arguments,
+			&ast.ArgumentDefinition{Name: "first", Type: &ast.Type{NamedType: "Int"}},
+			&ast.ArgumentDefinition{Name: "after", Type: &ast.Type{NamedType: "String"}})
+		defn.Fields = append(defn.Fields, connField)
+	}
+}
+
+func addConnectionTypes(sch *ast.Schema, nodeType string) {
+	if _, ok := sch.Types[pageInfoType]; !ok {
+		sch.Types[pageInfoType] = &ast.Definition{
+			Kind: ast.Object,
+			Name: pageInfoType,
+			Fields: []*ast.FieldDefinition{
+				{Name: "hasNextPage", Type: &ast.Type{NamedType: "Boolean", NonNull: true}},
+				{Name: "hasPreviousPage", Type: &ast.Type{NamedType: "Boolean", NonNull: true}},
+				{Name: "startCursor", Type: &ast.Type{NamedType: "String"}},
+				{Name: "endCursor", Type: &ast.Type{NamedType: "String"}},
+			},
+		}
+	}
+	if _, ok := sch.Types[nodeType+connectionSuffix]; ok {
+		return
+	}
+	sch.Types[nodeType+"Edge"] = &ast.Definition{
+		Kind: ast.Object,
+		Name: nodeType + "Edge",
+		Fields: []*ast.FieldDefinition{
+			{Name: "node", Type: &ast.Type{NamedType: nodeType}},
+			{Name: "cursor", Type: &ast.Type{NamedType: "String", NonNull: true}},
+		},
+	}
+	sch.Types[nodeType+connectionSuffix] = &ast.Definition{
+		Kind: ast.Object,
+		Name: nodeType + connectionSuffix,
+		Fields: []*ast.FieldDefinition{
+			{Name: "edges", Type: &ast.Type{
+				Elem:    &ast.Type{NamedType: nodeType + "Edge", NonNull: true},
+				NonNull: true,
+			}},
+			{Name: "pageInfo", Type: &ast.Type{NamedType: pageInfoType, NonNull: true}},
+		},
+	}
+}
+
+// ConnectionCursor returns the cursor of the node of a connection with the given uid. The cursors
+// are opaque to the clients.
+func ConnectionCursor(uid string) string {
+	return base64.StdEncoding.EncodeToString([]byte(uid))
+}
+
+// ParseConnectionCursor returns the uid of the node a cursor was returned for.
+func ParseConnectionCursor(cursor string) (uint64, error) {
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err == nil {
+		var uid uint64
+		if uid, err = strconv.ParseUint(string(b), 0, 64); err == nil {
+			return uid, nil
+		}
+	}
+	return 0, errors.Errorf("%q isn't a valid cursor", cursor)
+}
+
+// isConnectionField returns true if the field named name of defn is the connection field of a
+// list field with @connection.
+func isConnectionField(defn *ast.Definition, name string) bool {
+	if defn == nil || !strings.HasSuffix(name, connectionSuffix) {
+		return false
+	}
+	fld := defn.Fields.ForName(strings.TrimSuffix(name, connectionSuffix))
+	return fld != nil && fld.Directives.ForName(connectionDirective) != nil
+}
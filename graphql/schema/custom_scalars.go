@@ -0,0 +1,172 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"strings"
+
+	"github.com/dgraph-io/gqlparser/v2/ast"
+	"github.com/dgraph-io/gqlparser/v2/gqlerror"
+)
+
+// A custom scalar is declared with the DQL type it's stored as, and optionally the indexes of the
+// fields of that scalar:
+//
+//	scalar Email @dgraphType(type: "string", index: ["hash"])
+//
+// The fields of a custom scalar are handled as fields of the GraphQL scalar matching the DQL
+// type, with the @search directive for the given indexes if they don't have one. So the values
+// are validated like the values of that scalar, and the fields get the same filters.
+
+// dgraphTypeToScalar maps the DQL types custom scalars can be declared with to the GraphQL
+// scalar they are handled as.
+var dgraphTypeToScalar = map[string]string{
+	"string":   "String",
+	"int":      "Int64",
+	"float":    "Float",
+	"bool":     "Boolean",
+	"datetime": "DateTime",
+}
+
+// expandCustomScalars removes the custom scalars declared with @dgraphType from the document,
+// and replaces them in the type of the fields and the arguments using them. The scalars declared
+// without @dgraphType are left for dataTypeCheck to reject.
+func expandCustomScalars(doc *ast.SchemaDocument) gqlerror.List {
+	var errs gqlerror.List
+	scalars := make(map[string]*ast.Directive)
+	defns := doc.Definitions[:0]
+	for _, defn := range doc.Definitions {
+		if dir := defn.Directives.ForName(dgraphTypeDirective); dir != nil && !defn.BuiltIn &&
+			defn.Kind == ast.Scalar {
+			if err := validateCustomScalar(defn, dir); err != nil {
+				errs = append(errs, err)
+			}
+			scalars[defn.Name] = dir
+			continue
+		}
+		defns = append(defns, defn)
+	}
+	doc.Definitions = defns
+	if len(errs) > 0 || len(scalars) == 0 {
+		return errs
+	}
+
+	for _, defn := range doc.Definitions {
+		if defn.BuiltIn {
+			continue
+		}
+		for _, fld := range defn.Fields {
+			for _, arg := range fld.Arguments {
+				replaceCustomScalar(arg.Type, scalars)
+			}
+			dir, ok := scalars[fld.Type.Name()]
+			if !ok {
+				continue
+			}
+			replaceCustomScalar(fld.Type, scalars)
+			indexes := customScalarIndexes(dir)
+			if len(indexes) == 0 || defn.Kind == ast.InputObject ||
+				fld.Directives.ForName(searchDirective) != nil {
+				continue
+			}
+			by := &ast.Value{Kind: ast.ListValue, Position: dir.Position}
+			for _, idx := range indexes {
+				by.Children = append(by.Children, &ast.ChildValue{
+					Value:    &ast.Value{Kind: ast.EnumValue, Raw: idx, Position: dir.Position},
+					Position: dir.Position,
+				})
+			}
+			fld.Directives = append(fld.Directives, &ast.Directive{
+				Name:      searchDirective,
+				Arguments: ast.ArgumentList{{Name: searchArgs, Value: by, Position: dir.Position}},
+				Position:  dir.Position,
+			})
+		}
+	}
+	return nil
+}
+
+func validateCustomScalar(defn *ast.Definition, dir *ast.Directive) *gqlerror.Error {
+	if isScalar(defn.Name) {
+		return gqlerror.ErrorPosf(defn.Position,
+			"Scalar %s; %s is a built-in scalar, it can't be declared with @%s.",
+			defn.Name, defn.Name, dgraphTypeDirective)
+	}
+	typ := dir.Arguments.ForName(dgraphTypeArg)
+	if typ == nil || typ.Value.Kind != ast.StringValue {
+		return gqlerror.ErrorPosf(dir.Position,
+			"Scalar %s; @%s requires the type argument, like @%s(type: \"string\").",
+			defn.Name, dgraphTypeDirective, dgraphTypeDirective)
+	}
+	scalar, ok := dgraphTypeToScalar[strings.ToLower(typ.Value.Raw)]
+	if !ok {
+		return gqlerror.ErrorPosf(typ.Position,
+			"Scalar %s; type %s isn't supported, the type should be one of string, int, float, "+
+				"bool or datetime.", defn.Name, typ.Value.Raw)
+	}
+	if idx := dir.Arguments.ForName(dgraphTypeIndexArg); idx != nil &&
+		idx.Value.Kind != ast.StringValue && idx.Value.Kind != ast.ListValue {
+		return gqlerror.ErrorPosf(idx.Position,
+			"Scalar %s; the index argument should be a string or a list of strings.", defn.Name)
+	}
+	for _, idx := range customScalarIndexes(dir) {
+		if search, ok := supportedSearches[idx]; !ok || search.gqlType != scalar {
+			return gqlerror.ErrorPosf(dir.Position,
+				"Scalar %s; index %s can't be used for a scalar of type %s.",
+				defn.Name, idx, typ.Value.Raw)
+		}
+	}
+	return nil
+}
+
+// customScalarIndexes returns the arguments of the @search directive for the indexes of a custom
+// scalar.
+func customScalarIndexes(dir *ast.Directive) []string {
+	arg := dir.Arguments.ForName(dgraphTypeIndexArg)
+	if arg == nil {
+		return nil
+	}
+	values := []*ast.Value{arg.Value}
+	if arg.Value.Kind == ast.ListValue {
+		values = values[:0]
+		for _, child := range arg.Value.Children {
+			values = append(values, child.Value)
+		}
+	}
+	scalar := dgraphTypeToScalar[strings.ToLower(dir.Arguments.ForName(dgraphTypeArg).Value.Raw)]
+	indexes := make([]string, 0, len(values))
+	for _, v := range values {
+		idx := v.Raw
+		if idx == "int" && scalar == "Int64" {
+			// The int fields are stored as Int64, whose search argument is int64.
+			idx = "int64"
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes
+}
+
+// replaceCustomScalar replaces the custom scalar in typ, which can be a list, by the GraphQL
+// scalar it's handled as.
+func replaceCustomScalar(typ *ast.Type, scalars map[string]*ast.Directive) {
+	for t := typ; t != nil; t = t.Elem {
+		if dir, ok := scalars[t.NamedType]; ok {
+			t.NamedType = dgraphTypeToScalar[strings.ToLower(
+				dir.Arguments.ForName(dgraphTypeArg).Value.Raw)]
+		}
+	}
+}
@@ -38,6 +38,9 @@ const (
 	dgraphTypeArg   = "type"
 	dgraphPredArg   = "pred"
 
+	dgraphTypeDirective = "dgraphType"
+	dgraphTypeIndexArg  = "index"
+
 	idDirective             = "id"
 	subscriptionDirective   = "withSubscription"
 	secretDirective         = "secret"
@@ -47,6 +50,7 @@ const (
 	remoteResponseDirective = "remoteResponse"
 	lambdaDirective         = "lambda"
 	lambdaOnMutateDirective = "lambdaOnMutate"
+	connectionDirective     = "connection"
 
 	generateDirective       = "generate"
 	generateQueryArg        = "query"
@@ -284,12 +288,13 @@ directive @auth(
 	query: AuthRule,
 	add: AuthRule,
 	update: AuthRule,
-	delete: AuthRule) on OBJECT | INTERFACE
+	delete: AuthRule) on OBJECT | INTERFACE | FIELD_DEFINITION
 directive @custom(http: CustomHTTP, dql: String) on FIELD_DEFINITION
 directive @remote on OBJECT | INTERFACE | UNION | INPUT_OBJECT | ENUM
 directive @remoteResponse(name: String) on FIELD_DEFINITION
 directive @cascade(fields: [String]) on FIELD
 directive @lambda on FIELD_DEFINITION
+directive @connection on FIELD_DEFINITION
 directive @lambdaOnMutate(add: Boolean, update: Boolean, delete: Boolean) on OBJECT | INTERFACE
 directive @cacheControl(maxAge: Int!) on QUERY
 directive @generate(
@@ -313,6 +318,7 @@ directive @secret(field: String!, pred: String) on OBJECT | INTERFACE
 directive @remote on OBJECT | INTERFACE | UNION | INPUT_OBJECT | ENUM
 directive @remoteResponse(name: String) on FIELD_DEFINITION
 directive @lambda on FIELD_DEFINITION
+directive @connection on FIELD_DEFINITION
 directive @lambdaOnMutate(add: Boolean, update: Boolean, delete: Boolean) on OBJECT | INTERFACE
 `
 	filterInputs = `
@@ -570,6 +576,7 @@ var directiveValidators = map[string]directiveValidator{
 	deprecatedDirective:     ValidatorNoOp,
 	lambdaDirective:         lambdaDirectiveValidation,
 	lambdaOnMutateDirective: ValidatorNoOp,
+	connectionDirective:     connectionValidation,
 	generateDirective:       ValidatorNoOp,
 	apolloKeyDirective:      ValidatorNoOp,
 	apolloExtendsDirective:  ValidatorNoOp,
@@ -594,6 +601,7 @@ var directiveLocationMap = map[string]map[ast.DefinitionKind]bool{
 		ast.InputObject: true, ast.Enum: true},
 	lambdaDirective:         nil,
 	lambdaOnMutateDirective: {ast.Object: true, ast.Interface: true},
+	connectionDirective:     nil,
 	generateDirective:       {ast.Object: true, ast.Interface: true},
 	apolloKeyDirective:      {ast.Object: true, ast.Interface: true},
 	apolloExtendsDirective:  {ast.Object: true, ast.Interface: true},
@@ -1041,6 +1049,7 @@ func completeSchema(
 		// We need to call this at last as aggregateFields
 		// should not be part of HasFilter or UpdatePayloadType etc.
 		addAggregateFields(sch, defn, apolloServiceQuery)
+		addConnectionFields(sch, defn)
 	}
 }
 
@@ -39,6 +39,8 @@ type Request struct {
 // RequestExtensions represents extensions recieved in requests
 type RequestExtensions struct {
 	PersistedQuery PersistedQuery
+	// PartialMutations overrides partial-mutations of the --graphql superflag for the request.
+	PartialMutations *bool
 }
 
 // PersistedQuery represents the query struct received from clients like Apollo
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/gqlparser/v2/ast"
 	"github.com/dgraph-io/gqlparser/v2/gqlerror"
@@ -47,7 +48,7 @@ func init() {
 		remoteTypeValidation, generateDirectiveValidation, apolloKeyValidation,
 		apolloExtendsValidation, lambdaOnMutateValidation)
 	fieldValidations = append(fieldValidations, listValidityCheck, fieldArgumentCheck,
-		fieldNameCheck, isValidFieldForList, hasAuthDirective, fieldDirectiveCheck)
+		fieldNameCheck, isValidFieldForList, fieldAuthValidation, fieldDirectiveCheck)
 
 	validator.AddRuleWithOrder("Check variable type is correct", baseRules, variableTypeCheck)
 	validator.AddRuleWithOrder("Check arguments of cascade directive", baseRules, directiveArgumentsCheck)
@@ -433,7 +434,7 @@ func customMutationNameValidation(schema *ast.SchemaDocument) gqlerror.List {
 func dataTypeCheck(schema *ast.Schema, defn *ast.Definition) gqlerror.List {
 	if defn.Kind == ast.Scalar {
 		return []*gqlerror.Error{gqlerror.ErrorPosf(
-			defn.Position, "You can't add scalar definitions. "+
+			defn.Position, "You can't add scalar definitions without the @dgraphType directive. "+
 				"Only type, interface, union, input and enums are allowed in initial schema.")}
 	}
 	return nil
@@ -723,16 +724,33 @@ func idCountCheck(schema *ast.Schema, typ *ast.Definition) gqlerror.List {
 	return errs
 }
 
-func hasAuthDirective(typ *ast.Definition, field *ast.FieldDefinition) gqlerror.List {
-	for _, directive := range field.Directives {
-		if directive.Name != authDirective {
-			continue
+// fieldAuthValidation checks the @auth directives on fields. A field with @auth is left out of
+// the results for the users who don't satisfy its query rule, so it has to be nullable, and the
+// other rules aren't supported on fields.
+func fieldAuthValidation(typ *ast.Definition, field *ast.FieldDefinition) gqlerror.List {
+	dir := field.Directives.ForName(authDirective)
+	if dir == nil {
+		return nil
+	}
+	var errs []*gqlerror.Error
+	for _, arg := range dir.Arguments {
+		if arg.Name != "query" {
+			errs = append(errs, gqlerror.ErrorPosf(arg.Position,
+				"Type %s; Field %s: @%s directive on a field can only have a query rule, got %s.",
+				typ.Name, field.Name, authDirective, arg.Name))
 		}
-		return []*gqlerror.Error{gqlerror.ErrorPosf(field.Position,
-			"Type %s; Field %s: @%s directive is not allowed on fields",
-			typ.Name, field.Name, authDirective)}
 	}
-	return nil
+	switch {
+	case field.Type.Name() == IDType:
+		errs = append(errs, gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: @%s directive can't be used on a field of type ID.",
+			typ.Name, field.Name, authDirective))
+	case field.Type.NonNull:
+		errs = append(errs, gqlerror.ErrorPosf(dir.Position,
+			"Type %s; Field %s: has the @%s directive, so it should be nullable, as it isn't "+
+				"returned to unauthorized users.", typ.Name, field.Name, authDirective))
+	}
+	return errs
 }
 
 func isValidFieldForList(typ *ast.Definition, field *ast.FieldDefinition) gqlerror.List {
@@ -1495,6 +1513,9 @@ func customDirectiveValidation(sch *ast.Schema,
 					typ.Name, field.Name, arg.Name))
 			}
 		}
+		if len(errs) == 0 {
+			errs = append(errs, dqlVariablesValidation(typ, field, dqlArg)...)
+		}
 
 		// if there was dql, always return no matter we found errors or not,
 		// as rest of the validation is for http arg, and http won't be present together with dql
@@ -2362,3 +2383,72 @@ func isQueryOrMutationType(typ *ast.Definition) bool {
 func isQueryOrMutation(name string) bool {
 	return name == "Query" || name == "Mutation"
 }
+
+// dqlVariableTypes are the types of the DQL variables the value of an argument of each scalar
+// type can be bound to. The enums are bound as strings.
+var dqlVariableTypes = map[string][]string{
+	"Int":      {"int", "float", "string"},
+	"Int64":    {"int", "float", "string"},
+	"Float":    {"float", "string"},
+	"Boolean":  {"bool", "string"},
+	"String":   {"string"},
+	"ID":       {"string"},
+	"DateTime": {"string"},
+}
+
+// dqlVariablesValidation checks that the arguments of a field with @custom(dql: ...) are bound to
+// variables of the DQL query which have a matching type, and that the variables the DQL query
+// requires are given by a non-nullable argument.
+func dqlVariablesValidation(typ *ast.Definition, field *ast.FieldDefinition,
+	dqlArg *ast.Argument) gqlerror.List {
+	vars, err := gql.ParseVariableTypes(dqlArg.Value.Raw)
+	if err != nil {
+		return []*gqlerror.Error{gqlerror.ErrorPosf(dqlArg.Position,
+			"Type %s; Field %s: dql argument for @custom directive isn't a valid DQL query: %s",
+			typ.Name, field.Name, err)}
+	}
+
+	var errs []*gqlerror.Error
+	for _, arg := range field.Arguments {
+		varType, ok := vars["$"+arg.Name]
+		if !ok {
+			errs = append(errs, gqlerror.ErrorPosf(dqlArg.Position,
+				"Type %s; Field %s: Argument %s: must be declared as the variable $%s of the "+
+					"DQL query.", typ.Name, field.Name, arg.Name, arg.Name))
+			continue
+		}
+		required := strings.HasSuffix(varType, "!")
+		varType = strings.TrimSuffix(varType, "!")
+		allowed, ok := dqlVariableTypes[arg.Type.Name()]
+		if !ok {
+			allowed = dqlVariableTypes["String"]
+		}
+		if !x.HasString(allowed, varType) {
+			errs = append(errs, gqlerror.ErrorPosf(dqlArg.Position,
+				"Type %s; Field %s: Argument %s: a value of type %s can't be bound to the "+
+					"variable $%s of type %s of the DQL query.",
+				typ.Name, field.Name, arg.Name, arg.Type.Name(), arg.Name, varType))
+		}
+		if required && !arg.Type.NonNull {
+			errs = append(errs, gqlerror.ErrorPosf(dqlArg.Position,
+				"Type %s; Field %s: Argument %s: must be non-nullable, as the variable $%s of "+
+					"the DQL query is required.", typ.Name, field.Name, arg.Name, arg.Name))
+		}
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasSuffix(vars[name], "!") &&
+			field.Arguments.ForName(strings.TrimPrefix(name, "$")) == nil {
+			errs = append(errs, gqlerror.ErrorPosf(dqlArg.Position,
+				"Type %s; Field %s: the variable %s of the DQL query is required, but the field "+
+					"doesn't have an argument %s.",
+				typ.Name, field.Name, name, strings.TrimPrefix(name, "$")))
+		}
+	}
+	return errs
+}
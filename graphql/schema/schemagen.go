@@ -337,7 +337,12 @@ func NewHandler(input string, apolloServiceQuery bool) (Handler, error) {
 	doc.Definitions = append(doc.Definitions, doc.Extensions...)
 	doc.Extensions = nil
 
-	gqlErrList := preGQLValidation(doc)
+	gqlErrList := expandCustomScalars(doc)
+	if gqlErrList != nil {
+		return nil, gqlErrList
+	}
+
+	gqlErrList = preGQLValidation(doc)
 	if gqlErrList != nil {
 		return nil, gqlErrList
 	}
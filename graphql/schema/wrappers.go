@@ -18,10 +18,12 @@ package schema
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -71,6 +73,10 @@ type FieldHTTPConfig struct {
 	// the GraphqlBatchModeArgument would be sinput, we use it to know the GraphQL variable that
 	// we should send the data in.
 	GraphqlBatchModeArgument string
+
+	// jwtVars has the values of the JWT claims used in the body template, keyed by the name
+	// they are referred with in the template, like `JWT.tenant`.
+	jwtVars map[string]interface{}
 }
 
 // EntityRepresentations is the parsed form of the `representations` argument in `_entities` query
@@ -172,12 +178,15 @@ type Field interface {
 	TypeName(dgraphTypes []string) string
 	GetObjectName() string
 	IsAuthQuery() bool
-	CustomHTTPConfig() (*FieldHTTPConfig, error)
+	CustomHTTPConfig(ctx context.Context) (*FieldHTTPConfig, error)
 	EnumValues() []string
 	ConstructedFor() Type
 	ConstructedForDgraphPredicate() string
 	DgraphPredicateForAggregateField() string
 	IsAggregateField() bool
+	// IsConnectionField returns true if f is the connection field generated for a list field
+	// with the @connection directive.
+	IsConnectionField() bool
 	GqlErrorf(path []interface{}, message string, args ...interface{}) *x.GqlError
 	// MaxPathLength finds the max length (including list indexes) of any path in the 'query' f.
 	MaxPathLength() int
@@ -1057,6 +1066,10 @@ func (f *field) IsAggregateField() bool {
 	return strings.HasSuffix(f.Name(), "Aggregate") && f.Type().IsAggregateResult()
 }
 
+func (f *field) IsConnectionField() bool {
+	return isConnectionField(f.field.ObjectDefinition, f.Name())
+}
+
 func (f *field) GqlErrorf(path []interface{}, message string, args ...interface{}) *x.GqlError {
 	pathCopy := make([]interface{}, len(path))
 	copy(pathCopy, path)
@@ -1473,7 +1486,8 @@ func (t *astType) IsInbuiltOrEnumType() bool {
 	return ok || (t.inSchema.schema.Types[t.Name()].Kind == ast.Enum)
 }
 
-func getCustomHTTPConfig(f *field, isQueryOrMutation bool) (*FieldHTTPConfig, error) {
+func getCustomHTTPConfig(ctx context.Context, f *field,
+	isQueryOrMutation bool) (*FieldHTTPConfig, error) {
 	custom := f.op.inSchema.customDirectives[f.GetObjectName()][f.Name()]
 	httpArg := custom.Arguments.ForName(httpArg)
 	fconf := &FieldHTTPConfig{
@@ -1503,6 +1517,18 @@ func getCustomHTTPConfig(f *field, isQueryOrMutation bool) (*FieldHTTPConfig, er
 			return nil, err
 		}
 		fconf.Template = bt
+		if claims := jwtVarsInBody(bt); len(claims) > 0 {
+			customClaims, err := f.GetAuthMeta().ExtractCustomClaims(ctx)
+			if err != nil {
+				return nil, errors.Wrapf(err, "while reading JWT claims for body template")
+			}
+			fconf.jwtVars = make(map[string]interface{}, len(claims))
+			for _, claim := range claims {
+				if val, ok := customClaims.AuthVariables[claim]; ok {
+					fconf.jwtVars[jwtVarPrefix+claim] = val
+				}
+			}
+		}
 	}
 
 	fconf.ForwardHeaders = http.Header{}
@@ -1568,13 +1594,13 @@ func getCustomHTTPConfig(f *field, isQueryOrMutation bool) (*FieldHTTPConfig, er
 			bodyVars["query"] = fconf.RemoteGqlQuery
 			bodyVars["variables"] = argMap
 		}
-		fconf.Template = SubstituteVarsInBody(fconf.Template, bodyVars)
+		fconf.Template = fconf.SubstituteVarsInBody(bodyVars)
 	}
 	return fconf, nil
 }
 
-func (f *field) CustomHTTPConfig() (*FieldHTTPConfig, error) {
-	return getCustomHTTPConfig(f, false)
+func (f *field) CustomHTTPConfig(ctx context.Context) (*FieldHTTPConfig, error) {
+	return getCustomHTTPConfig(ctx, f, false)
 }
 
 func (f *field) EnumValues() []string {
@@ -1673,6 +1699,10 @@ func (q *query) IsAggregateField() bool {
 	return (*field)(q).IsAggregateField()
 }
 
+func (q *query) IsConnectionField() bool {
+	return false
+}
+
 func (q *query) GqlErrorf(path []interface{}, message string, args ...interface{}) *x.GqlError {
 	return (*field)(q).GqlErrorf(path, message, args...)
 }
@@ -1882,8 +1912,8 @@ func (q *query) GetObjectName() string {
 	return q.field.ObjectDefinition.Name
 }
 
-func (q *query) CustomHTTPConfig() (*FieldHTTPConfig, error) {
-	return getCustomHTTPConfig((*field)(q), true)
+func (q *query) CustomHTTPConfig(ctx context.Context) (*FieldHTTPConfig, error) {
+	return getCustomHTTPConfig(ctx, (*field)(q), true)
 }
 
 func (q *query) EnumValues() []string {
@@ -1895,8 +1925,17 @@ func (m *mutation) ConstructedFor() Type {
 }
 
 // In case the field f is of type <Type>Aggregate, the Type is retunred.
+// In case f is the connection field of a list field, the type of the list field is returned.
 // In all other case the function returns the type of field f.
 func (f *field) ConstructedFor() Type {
+	if f.IsConnectionField() {
+		fldName := f.Name()
+		return &astType{
+			typ:             f.field.ObjectDefinition.Fields.ForName(fldName[:len(fldName)-10]).Type,
+			inSchema:        f.op.inSchema,
+			dgraphPredicate: f.op.inSchema.dgraphPredicate,
+		}
+	}
 	if !f.IsAggregateField() {
 		return f.Type()
 	}
@@ -1938,8 +1977,15 @@ func (q *query) ConstructedForDgraphPredicate() string {
 }
 
 // In case, the field f is of type <Type>Aggregate it returns dgraph predicate of the Type.
+// In case f is the connection field of a list field, it returns dgraph predicate of the list field.
 // In all other cases it returns dgraph predicate of the field.
 func (f *field) ConstructedForDgraphPredicate() string {
+	if f.IsConnectionField() {
+		// Remove last 10 characters of the field name.
+		// Eg. to get "FieldName" from "FieldNameConnection"
+		fldName := f.Name()
+		return f.op.inSchema.dgraphPredicate[f.field.ObjectDefinition.Name][fldName[:len(fldName)-10]]
+	}
 	if !f.IsAggregateField() {
 		return f.DgraphPredicate()
 	}
@@ -2185,8 +2231,8 @@ func (m *mutation) MutatedType() Type {
 	return m.op.inSchema.mutatedType[m.Name()]
 }
 
-func (m *mutation) CustomHTTPConfig() (*FieldHTTPConfig, error) {
-	return getCustomHTTPConfig((*field)(m), true)
+func (m *mutation) CustomHTTPConfig(ctx context.Context) (*FieldHTTPConfig, error) {
+	return getCustomHTTPConfig(ctx, (*field)(m), true)
 }
 
 func (m *mutation) EnumValues() []string {
@@ -2240,6 +2286,10 @@ func (m *mutation) IsAggregateField() bool {
 	return (*field)(m).IsAggregateField()
 }
 
+func (m *mutation) IsConnectionField() bool {
+	return false
+}
+
 func (m *mutation) GqlErrorf(path []interface{}, message string, args ...interface{}) *x.GqlError {
 	return (*field)(m).GqlErrorf(path, message, args...)
 }
@@ -2879,6 +2929,11 @@ func parseAsJSONTemplate(value *ast.Value, vars map[string]bool, strictJSON bool
 		}
 		return l, nil
 	case ast.Variable:
+		// JWT claims aren't required from the arguments or the parent, they are read from the
+		// JWT while building the request.
+		if strings.HasPrefix(value.Raw, jwtVarInternalPrefix) {
+			return "$" + jwtVarPrefix + strings.TrimPrefix(value.Raw, jwtVarInternalPrefix), nil
+		}
 		vars[value.Raw] = true
 		return value.String(), nil
 	case ast.IntValue:
@@ -2910,6 +2965,12 @@ func parseAsJSONTemplate(value *ast.Value, vars map[string]bool, strictJSON bool
 // { "author" : "$id", "post": { "id": "$postID" }} and { "id": true, "postID": true}
 // If the final result is not a valid JSON, then an error is returned.
 //
+// A claim from the JWT can be referred as $JWT.<claim>, for e.g.
+// { userId: $id, tenant: $JWT.tenant }
+// would return
+// { "userId": "$id", "tenant": "$JWT.tenant" } and { "id": true }
+// The JWT claims are not part of the required variables.
+//
 // In strictJSON mode block strings and enums are invalid and throw an error.
 // strictJSON should be false when the body template is being used for custom graphql arg parsing,
 // otherwise it should be true.
@@ -2918,6 +2979,14 @@ func parseBodyTemplate(body string, strictJSON bool) (interface{}, map[string]bo
 		return nil, nil, nil
 	}
 
+	// $JWT.tenant isn't a valid GraphQL variable, so it is renamed to one before parsing. The
+	// strings are matched too, so that the text looking like a claim inside them is left as is.
+	body = jwtVarRegex.ReplaceAllStringFunc(body, func(match string) string {
+		if strings.HasPrefix(match, `"`) {
+			return match
+		}
+		return "$" + jwtVarInternalPrefix + strings.TrimPrefix(match, "$"+jwtVarPrefix)
+	})
 	parsedBodyTemplate, err := parseAsGraphQLArg(body)
 	if err != nil {
 		return nil, nil, err
@@ -2936,6 +3005,34 @@ func isVar(key string) bool {
 	return strings.HasPrefix(key, "$")
 }
 
+const (
+	jwtVarPrefix         = "JWT."
+	jwtVarInternalPrefix = "JWT__"
+)
+
+var jwtVarRegex = regexp.MustCompile(
+	`"""(?s:.*?)"""|"(?:[^"\\\n]|\\.)*"|\$JWT\.[_A-Za-z][_0-9A-Za-z]*`)
+
+// jwtVarsInBody returns the names of the JWT claims used in the given body template.
+func jwtVarsInBody(jsonTemplate interface{}) []string {
+	var claims []string
+	switch val := jsonTemplate.(type) {
+	case string:
+		if strings.HasPrefix(val, "$"+jwtVarPrefix) {
+			claims = append(claims, strings.TrimPrefix(val, "$"+jwtVarPrefix))
+		}
+	case map[string]interface{}:
+		for _, v := range val {
+			claims = append(claims, jwtVarsInBody(v)...)
+		}
+	case []interface{}:
+		for _, v := range val {
+			claims = append(claims, jwtVarsInBody(v)...)
+		}
+	}
+	return claims
+}
+
 func substituteVarInMapInBody(object, variables map[string]interface{}) map[string]interface{} {
 	objCopy := make(map[string]interface{}, len(object))
 	for k, v := range object {
@@ -3001,6 +3098,8 @@ func substituteVarInSliceInBody(slice []interface{}, variables map[string]interf
 //			"id": "0x9"
 //		}
 // }
+// A variable which is not present in variables is left out of an object, and is null inside a
+// list. The values are never parsed again, so a value can't inject another variable.
 func SubstituteVarsInBody(jsonTemplate interface{}, variables map[string]interface{}) interface{} {
 	if jsonTemplate == nil {
 		return nil
@@ -3021,6 +3120,22 @@ func SubstituteVarsInBody(jsonTemplate interface{}, variables map[string]interfa
 	return jsonTemplate
 }
 
+// SubstituteVarsInBody substitutes the given variables and the JWT claims in the body template
+// of this config and returns the final JSON.
+func (fconf *FieldHTTPConfig) SubstituteVarsInBody(variables map[string]interface{}) interface{} {
+	if len(fconf.jwtVars) == 0 {
+		return SubstituteVarsInBody(fconf.Template, variables)
+	}
+	vars := make(map[string]interface{}, len(variables)+len(fconf.jwtVars))
+	for k, v := range variables {
+		vars[k] = v
+	}
+	for k, v := range fconf.jwtVars {
+		vars[k] = v
+	}
+	return SubstituteVarsInBody(fconf.Template, vars)
+}
+
 // FieldOriginatedFrom returns the name of the interface from which given field was inherited.
 // If the field wasn't inherited, but belonged to this type, this type's name is returned.
 // Otherwise, empty string is returned.
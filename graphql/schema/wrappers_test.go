@@ -17,8 +17,10 @@
 package schema
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
+	"sort"
 	"strings"
 	"testing"
 
@@ -580,6 +582,22 @@ func TestSubstituteVarsInBody(t *testing.T) {
 			map[string]interface{}{"author": "$id", "post": map[string]interface{}{"id": "$postID"}},
 			map[string]interface{}{"post": map[string]interface{}{}},
 		},
+		{
+			"substitutes JWT claims along with variables",
+			map[string]interface{}{"id": "0x3", "JWT.tenant": "acme",
+				"JWT.roles": []interface{}{"ADMIN", "USER"}},
+			map[string]interface{}{"userId": "$id", "tenant": "$JWT.tenant",
+				"roles": "$JWT.roles", "groups": []interface{}{"$JWT.group"},
+				"team": "$JWT.team"},
+			map[string]interface{}{"userId": "0x3", "tenant": "acme",
+				"roles": []interface{}{"ADMIN", "USER"}, "groups": []interface{}{nil}},
+		},
+		{
+			"substituted values are not parsed as variables again",
+			map[string]interface{}{"name": "$JWT.tenant", "JWT.tenant": "acme"},
+			map[string]interface{}{"name": "$name"},
+			map[string]interface{}{"name": "$JWT.tenant"},
+		},
 	}
 
 	for _, test := range tcases {
@@ -708,6 +726,22 @@ func TestParseBodyTemplate(t *testing.T) {
 			map[string]bool{"id": true, "postID": true, "text": true},
 			nil,
 		},
+		{
+			"parses body template with JWT claims correctly",
+			`{ userId: $id, tenant: $JWT.tenant, roles: [$JWT.role, $role] }`,
+			map[string]interface{}{"userId": "$id", "tenant": "$JWT.tenant",
+				"roles": []interface{}{"$JWT.role", "$role"}},
+			map[string]bool{"id": true, "role": true},
+			nil,
+		},
+		{
+			"doesn't take JWT claims in strings for variables",
+			`{ tenant: $JWT.tenant, note: "a \" $JWT.tenant", empty: "", id: $id }`,
+			map[string]interface{}{"tenant": "$JWT.tenant", "note": `a " $JWT.tenant`,
+				"empty": "", "id": "$id"},
+			map[string]bool{"id": true},
+			nil,
+		},
 		{
 			"bad template error",
 			`{ author: $id, post: { id $postID }}`,
@@ -749,6 +783,15 @@ func TestParseBodyTemplate(t *testing.T) {
 	}
 }
 
+func TestJWTVarsInBody(t *testing.T) {
+	b, _, err := parseBodyTemplate(`{ userId: $id, tenant: $JWT.tenant,
+		teams: [{ id: $JWT.team }], note: "$JWT" }`, true)
+	require.NoError(t, err)
+	claims := jwtVarsInBody(b)
+	sort.Strings(claims)
+	require.Equal(t, []string{"team", "tenant"}, claims)
+}
+
 func TestSubstituteVarsInURL(t *testing.T) {
 	tcases := []struct {
 		name        string
@@ -953,7 +996,7 @@ func TestGraphQLQueryInCustomHTTPConfig(t *testing.T) {
 				field = q.SelectionSet()[0]
 			}
 
-			c, err := field.CustomHTTPConfig()
+			c, err := field.CustomHTTPConfig(context.Background())
 			require.NoError(t, err)
 
 			remoteSchemaHandler, errs := NewHandler(tcase.RemoteSchema, false)
@@ -1352,3 +1395,63 @@ func TestParseSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectionFields(t *testing.T) {
+	schemaStr := `
+type Author {
+	id: ID!
+	name: String! @search(by: [hash])
+	posts: [Post] @connection @dgraph(pred: "wrote")
+}
+
+type Post {
+	id: ID!
+	title: String
+}`
+
+	schHandler, errs := NewHandler(schemaStr, false)
+	require.NoError(t, errs)
+	sch, err := FromString(schHandler.GQLSchema(), x.GalaxyNamespace)
+	require.NoError(t, err)
+
+	s, ok := sch.(*schema)
+	require.True(t, ok, "expected to be able to convert sch to internal schema type")
+
+	conn := s.schema.Types["Author"].Fields.ForName("postsConnection")
+	require.NotNil(t, conn)
+	require.Equal(t, "PostConnection!", conn.Type.String())
+	var args []string
+	for _, arg := range conn.Arguments {
+		args = append(args, arg.Name+": "+arg.Type.String())
+	}
+	require.Equal(t, []string{"filter: PostFilter", "first: Int", "after: String"}, args)
+	require.Equal(t, "[PostEdge!]!",
+		s.schema.Types["PostConnection"].Fields.ForName("edges").Type.String())
+	require.Equal(t, "Post", s.schema.Types["PostEdge"].Fields.ForName("node").Type.String())
+	require.NotNil(t, s.schema.Types["PageInfo"].Fields.ForName("hasNextPage"))
+
+	op, err := sch.Operation(&Request{Query: `query {
+		queryAuthor {
+			postsConnection(first: 2) { edges { node { title } } }
+			posts { title }
+		}
+	}`})
+	require.NoError(t, err)
+	fields := op.Queries()[0].SelectionSet()
+	require.True(t, fields[0].IsConnectionField())
+	require.Equal(t, "Post", fields[0].ConstructedFor().Name())
+	require.Equal(t, "wrote", fields[0].ConstructedForDgraphPredicate())
+	require.False(t, fields[1].IsConnectionField())
+}
+
+func TestConnectionCursor(t *testing.T) {
+	cursor := ConnectionCursor("0x2a")
+	uid, err := ParseConnectionCursor(cursor)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0x2a), uid)
+
+	for _, cursor := range []string{"", "not a cursor", "bm90IGEgdWlk"} {
+		_, err := ParseConnectionCursor(cursor)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// An increment is stored in the mutable layer as a posting with the Incr op, whose value is the
+// amount to add. It isn't resolved when it's written, so that concurrent increments of the same
+// value commute and don't conflict. It's resolved when the list is read instead: the increments
+// of a uid are added, in the order of their commits, to the latest value set below them, or to
+// zero if there isn't any.
+
+// resolveIncrements replaces the latest posting of each uid, if it's an increment, by a posting
+// setting the resolved value. The postings must be sorted as pickPostings sorts them.
+func (l *List) resolveIncrements(posts []*pb.Posting, deleteBelowTs uint64) (
+	[]*pb.Posting, error) {
+
+	for i := 0; i < len(posts); {
+		end := i + 1
+		for end < len(posts) && posts[end].Uid == posts[i].Uid {
+			end++
+		}
+		if posts[i].Op != Incr {
+			i = end
+			continue
+		}
+
+		// The increments are on top of the first posting which isn't one, or of the immutable
+		// layer if there isn't any. A delete starts again from zero.
+		base := i
+		for base < end && posts[base].Op == Incr {
+			base++
+		}
+		var res *pb.Posting
+		switch {
+		case base < end:
+			if posts[base].Op == Set {
+				res = posts[base]
+			}
+		case deleteBelowTs == 0:
+			p, err := l.immutablePosting(posts[i].Uid)
+			if err != nil {
+				return nil, err
+			}
+			res = p
+		}
+		for j := base - 1; j >= i; j-- {
+			if res == nil {
+				res = posts[j]
+				continue
+			}
+			var err error
+			if res, err = addIncrement(res, posts[j]); err != nil {
+				return nil, err
+			}
+		}
+
+		resolved := proto.Clone(res).(*pb.Posting)
+		resolved.Op = Set
+		resolved.StartTs, resolved.CommitTs = posts[i].StartTs, posts[i].CommitTs
+		posts[i] = resolved
+		i = end
+	}
+	return posts, nil
+}
+
+// immutablePosting returns the posting of uid in the immutable layer, or nil if it doesn't have
+// a value there.
+func (l *List) immutablePosting(uid uint64) (*pb.Posting, error) {
+	var pitr pIterator
+	if err := pitr.seek(l, uid-1, 0); err != nil {
+		return nil, err
+	}
+	valid, err := pitr.valid()
+	if err != nil || !valid {
+		return nil, err
+	}
+	if p := pitr.posting(); p.Uid == uid && len(p.Value) > 0 {
+		return p, nil
+	}
+	return nil, nil
+}
+
+// addIncrement returns a copy of incr whose value is the sum of the values of p and incr, and
+// whose op is the one of p. The result is a float if either of them is.
+func addIncrement(p, incr *pb.Posting) (*pb.Posting, error) {
+	a, err := incrementValue(p)
+	if err != nil {
+		return nil, err
+	}
+	b, err := incrementValue(incr)
+	if err != nil {
+		return nil, err
+	}
+
+	var sum types.Val
+	if a.Tid == types.IntID && b.Tid == types.IntID {
+		sum = types.Val{Tid: types.IntID, Value: a.Value.(int64) + b.Value.(int64)}
+	} else {
+		sum = types.Val{Tid: types.FloatID, Value: toFloat(a) + toFloat(b)}
+	}
+	out := types.Val{Tid: types.BinaryID}
+	if err := types.Marshal(sum, &out); err != nil {
+		return nil, err
+	}
+
+	res := proto.Clone(incr).(*pb.Posting)
+	res.Op = p.Op
+	res.Value = out.Value.([]byte)
+	res.ValType = pb.Posting_ValType(sum.Tid)
+	return res, nil
+}
+
+func incrementValue(p *pb.Posting) (types.Val, error) {
+	tid := types.TypeID(p.ValType)
+	if tid != types.IntID && tid != types.FloatID {
+		return types.Val{}, errors.Errorf("Cannot increment a value of type %s", tid.Name())
+	}
+	return types.Convert(types.Val{Tid: types.BinaryID, Value: p.Value}, tid)
+}
+
+func toFloat(v types.Val) float64 {
+	if i, ok := v.Value.(int64); ok {
+		return float64(i)
+	}
+	return v.Value.(float64)
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func incrementEdge(t *testing.T, v types.Val) *pb.DirectedEdge {
+	out := types.Val{Tid: types.BinaryID}
+	require.NoError(t, types.Marshal(v, &out))
+	return &pb.DirectedEdge{
+		Value:     out.Value.([]byte),
+		ValueType: pb.Posting_ValType(v.Tid),
+		Op:        pb.DirectedEdge_INCR,
+	}
+}
+
+func checkNumber(t *testing.T, l *List, want types.Val, readTs uint64) {
+	p := getFirst(l, readTs)
+	require.Equal(t, uint64(math.MaxUint64), p.Uid)
+	require.Equal(t, Set, p.Op)
+	got, err := types.Convert(types.Val{Tid: types.BinaryID, Value: p.Value},
+		types.TypeID(p.ValType))
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func TestAddMutation_Increment(t *testing.T) {
+	key := x.DataKey(x.GalaxyAttr("views"), 1)
+	ol, err := getNew(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+	ctx := context.Background()
+	one := types.Val{Tid: types.IntID, Value: int64(1)}
+
+	// Concurrent increments of a missing value start from zero and don't conflict.
+	txn1, txn2 := &Txn{StartTs: 1}, &Txn{StartTs: 2}
+	require.NoError(t, ol.addMutation(ctx, txn1, incrementEdge(t, one)))
+	require.NoError(t, ol.addMutation(ctx, txn2, incrementEdge(t, one)))
+	require.NoError(t, ol.addMutation(ctx, txn2, incrementEdge(t, one)))
+	require.Empty(t, txn1.conflicts)
+	checkNumber(t, ol, types.Val{Tid: types.IntID, Value: int64(2)}, txn2.StartTs)
+	require.NoError(t, ol.commitMutation(2, 3))
+	require.NoError(t, ol.commitMutation(1, 4))
+	checkNumber(t, ol, types.Val{Tid: types.IntID, Value: int64(2)}, 3)
+	checkNumber(t, ol, types.Val{Tid: types.IntID, Value: int64(3)}, 5)
+
+	// An increment committed after a set is added to the set value.
+	txn := &Txn{StartTs: 5}
+	set := incrementEdge(t, types.Val{Tid: types.IntID, Value: int64(10)})
+	set.Op = pb.DirectedEdge_SET
+	require.NoError(t, ol.addMutation(ctx, txn, set))
+	require.NoError(t, ol.addMutation(ctx, txn, incrementEdge(t, one)))
+	checkNumber(t, ol, types.Val{Tid: types.IntID, Value: int64(11)}, txn.StartTs)
+	require.NoError(t, ol.commitMutation(5, 6))
+	checkNumber(t, ol, types.Val{Tid: types.IntID, Value: int64(11)}, 7)
+
+	// A float increment makes the value a float.
+	txn = &Txn{StartTs: 7}
+	half := types.Val{Tid: types.FloatID, Value: 0.5}
+	require.NoError(t, ol.addMutation(ctx, txn, incrementEdge(t, half)))
+	require.NoError(t, ol.commitMutation(7, 8))
+	checkNumber(t, ol, types.Val{Tid: types.FloatID, Value: 11.5}, 9)
+
+	// The resolved value is kept by a rollup.
+	kvs, err := ol.Rollup(nil)
+	require.NoError(t, err)
+	require.NoError(t, writePostingListToDisk(kvs))
+	ol, err = getNew(key, ps, math.MaxUint64)
+	require.NoError(t, err)
+	checkNumber(t, ol, types.Val{Tid: types.FloatID, Value: 11.5}, 9)
+}
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -267,10 +268,57 @@ func (txn *Txn) addReverseAndCountMutation(ctx context.Context, t *pb.DirectedEd
 	return nil
 }
 
+// addUndirectedMutation adds the given edge in the other direction, from t.ValueId to t.Entity.
+// It is used for predicates with the @undirected directive, so that the edge can be traversed
+// from both of its nodes.
+func (txn *Txn) addUndirectedMutation(ctx context.Context, t *pb.DirectedEdge) error {
+	key := x.DataKey(t.Attr, t.ValueId)
+	hasCountIndex := schema.State().HasCount(ctx, t.Attr)
+
+	var getFn func(key []byte) (*List, error)
+	if hasCountIndex || t.Op == pb.DirectedEdge_DEL {
+		// Same as in runMutation, the posting list needs to be read from disk to get the
+		// length of the list for the counts.
+		getFn = txn.Get
+	} else {
+		getFn = txn.GetFromDelta
+	}
+	plist, err := getFn(key)
+	if err != nil {
+		return err
+	}
+	if plist == nil {
+		return errors.Errorf("nil posting list for undirected key %s", hex.Dump(key))
+	}
+
+	// We must create a copy here.
+	edge := &pb.DirectedEdge{
+		Entity:    t.ValueId,
+		ValueId:   t.Entity,
+		ValueType: t.ValueType,
+		Attr:      t.Attr,
+		Op:        t.Op,
+		Facets:    t.Facets,
+	}
+	_, _, cp, err := txn.addMutationHelper(ctx, plist, false, hasCountIndex, edge)
+	if err != nil {
+		return err
+	}
+	ostats.Record(ctx, x.NumEdges.M(1))
+
+	if hasCountIndex && cp.countAfter != cp.countBefore {
+		if err := txn.updateCount(ctx, cp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (l *List) handleDeleteAll(ctx context.Context, edge *pb.DirectedEdge, txn *Txn) error {
 	isReversed := schema.State().IsReversed(ctx, edge.Attr)
 	isIndexed := schema.State().IsIndexed(ctx, edge.Attr)
 	hasCount := schema.State().HasCount(ctx, edge.Attr)
+	isUndirected := pstore != nil && schema.State().IsUndirected(ctx, edge.Attr)
 	delEdge := &pb.DirectedEdge{
 		Attr:   edge.Attr,
 		Op:     edge.Op,
@@ -278,9 +326,17 @@ func (l *List) handleDeleteAll(ctx context.Context, edge *pb.DirectedEdge, txn *
 	}
 	// To calculate length of posting list. Used for deletion of count index.
 	var plen int
+	// The edges in the other direction of an undirected predicate. They are deleted after the
+	// iteration, as they are stored in other data lists and we hold the lock on this one.
+	var undirectedUids []uint64
 	err := l.Iterate(txn.StartTs, 0, func(p *pb.Posting) error {
 		plen++
 		switch {
+		case isUndirected:
+			if p.Uid != edge.Entity {
+				undirectedUids = append(undirectedUids, p.Uid)
+			}
+			return nil
 		case isReversed:
 			// Delete reverse edge for each posting.
 			delEdge.ValueId = p.Uid
@@ -304,6 +360,17 @@ func (l *List) handleDeleteAll(ctx context.Context, edge *pb.DirectedEdge, txn *
 	if err != nil {
 		return err
 	}
+	for _, uid := range undirectedUids {
+		if err := txn.addUndirectedMutation(ctx, &pb.DirectedEdge{
+			Attr:      edge.Attr,
+			Op:        pb.DirectedEdge_DEL,
+			Entity:    edge.Entity,
+			ValueId:   uid,
+			ValueType: pb.Posting_UID,
+		}); err != nil {
+			return err
+		}
+	}
 	if hasCount {
 		// Delete uid from count index. Deletion of reverses is taken care by addReverseMutation
 		// above.
@@ -455,7 +522,7 @@ func (txn *Txn) addMutationHelper(ctx context.Context, l *List, doUpdateIndex bo
 }
 
 // AddMutationWithIndex is addMutation with support for indexing. It also
-// supports reverse edges.
+// supports reverse and undirected edges.
 func (l *List) AddMutationWithIndex(ctx context.Context, edge *pb.DirectedEdge, txn *Txn) error {
 	if edge.Attr == "" {
 		return errors.Errorf("Predicate cannot be empty for edge with subject: [%v], object: [%v]"+
@@ -487,6 +554,14 @@ func (l *List) AddMutationWithIndex(ctx context.Context, edge *pb.DirectedEdge,
 			return err
 		}
 	}
+	// Store the edge in the other direction as well. Edges from a node to itself are already
+	// stored in both directions.
+	if pstore != nil && edge.ValueId != 0 && edge.ValueId != edge.Entity &&
+		schema.State().IsUndirected(ctx, edge.Attr) {
+		if err := txn.addUndirectedMutation(ctx, edge); err != nil {
+			return err
+		}
+	}
 	if doUpdateIndex {
 		// Exact matches.
 		if found && val.Value != nil {
@@ -868,6 +943,30 @@ func (rb *IndexRebuild) needsTokIndexRebuild() indexRebuildInfo {
 
 	newTokenizers, deletedTokenizers := x.Diff(currTokens, prevTokens)
 
+	// Indexes using a plugin tokenizer need to be rebuilt if the version of the plugin has
+	// changed, since the tokens it generates might be different.
+	prevVersions := make(map[string]struct{})
+	for _, v := range old.TokenizerVersions {
+		prevVersions[v] = struct{}{}
+	}
+	currVersions := make(map[string]struct{})
+	for _, v := range rb.CurrentSchema.TokenizerVersions {
+		currVersions[v] = struct{}{}
+	}
+	changedVersions, removedVersions := x.Diff(currVersions, prevVersions)
+	changed := make(map[string]struct{})
+	for _, v := range append(changedVersions, removedVersions...) {
+		changed[strings.SplitN(v, "=", 2)[0]] = struct{}{}
+	}
+	for _, t := range rb.CurrentSchema.Tokenizer {
+		_, inPrev := prevTokens[t]
+		_, isChanged := changed[t]
+		if inPrev && isChanged {
+			newTokenizers = append(newTokenizers, t)
+			deletedTokenizers = append(deletedTokenizers, t)
+		}
+	}
+
 	// If the tokenizers are the same, nothing needs to be done.
 	if len(newTokenizers) == 0 && len(deletedTokenizers) == 0 {
 		return indexRebuildInfo{
@@ -1240,17 +1339,71 @@ func DeleteData() error {
 	return pstore.DropPrefix([]byte{x.DefaultPrefix})
 }
 
-// DeletePredicate deletes all entries and indices for a given predicate.
+// DeletePredicate deletes all entries and indices for a given predicate. Its data, index, reverse
+// and count keys are dropped along with the parts of their split lists, then its schema. The
+// data is dropped first, so that the predicate is left empty rather than without a schema if the
+// schema can't be deleted.
 func DeletePredicate(ctx context.Context, attr string, ts uint64) error {
 	glog.Infof("Dropping predicate: [%s]", attr)
-	prefix := x.PredicatePrefix(attr)
-	if err := pstore.DropPrefix(prefix); err != nil {
+	// The lists of the predicate read before the drop could still be cached, its keys are read
+	// before they are dropped so that only these lists are removed from the cache.
+	keys := keysWithPrefix(x.PredicatePrefix(attr))
+	prefixes := [][]byte{x.PredicatePrefix(attr), x.PredicateSplitPrefix(attr)}
+	if err := pstore.DropPrefix(prefixes...); err != nil {
 		return err
 	}
+	removeCachedLists(keys)
+	if hasKeys(prefixes...) {
+		// The replicas apply the same drop, so the schema is deleted anyway rather than leaving
+		// this replica with a schema the others don't have.
+		glog.Errorf("Keys of predicate %s remain after dropping it", x.ParseAttr(attr))
+	}
 
 	return schema.State().Delete(attr, ts)
 }
 
+// keysWithPrefix returns the keys of the store with the prefix.
+func keysWithPrefix(prefix []byte) [][]byte {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	iterOpts.PrefetchValues = false
+	iterOpts.Prefix = prefix
+	itr := txn.NewIterator(iterOpts)
+	defer itr.Close()
+
+	var keys [][]byte
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		key := itr.Item().Key()
+		if len(keys) > 0 && bytes.Equal(keys[len(keys)-1], key) {
+			continue
+		}
+		keys = append(keys, itr.Item().KeyCopy(nil))
+	}
+	return keys
+}
+
+// hasKeys returns true if the store has any version of a key with one of the prefixes.
+func hasKeys(prefixes ...[]byte) bool {
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	iterOpts.PrefetchValues = false
+	for _, prefix := range prefixes {
+		iterOpts.Prefix = prefix
+		itr := txn.NewIterator(iterOpts)
+		itr.Rewind()
+		found := itr.Valid()
+		itr.Close()
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
 // DeleteNamespace bans the namespace and deletes its predicates/types from the schema.
 func DeleteNamespace(ns uint64) error {
 	schema.State().DeletePredsForNs(ns)
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dgraph-io/dgraph/protos/pb"
@@ -424,6 +425,96 @@ func TestRebuildReverseEdges(t *testing.T) {
 	require.EqualValues(t, 1, uids1[0])
 }
 
+func TestCountKeysInTxnCache(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("follower: [uid] @count ."), 1))
+	attr := x.GalaxyAttr("follower")
+
+	txn := Oracle().RegisterStartTs(21)
+	l, err := txn.Get(x.DataKey(attr, 1))
+	require.NoError(t, err)
+	for _, uid := range []uint64{2, 3, 4} {
+		require.NoError(t, l.AddMutationWithIndex(context.Background(), &pb.DirectedEdge{
+			Entity: 1, ValueId: uid, ValueType: pb.Posting_UID, Attr: attr,
+			Op: pb.DirectedEdge_SET}, txn))
+	}
+	txn.Update()
+
+	// The count keys aren't committed, they are only found in the cache of the transaction.
+	prefix := x.ParsedKey{Attr: attr}.CountPrefix(false)
+	keys := txn.cache.KeysWithPrefix(prefix)
+	require.Contains(t, keys, x.CountKey(attr, 3, false))
+	for _, key := range keys {
+		require.True(t, bytes.HasPrefix(key, prefix))
+	}
+	cl, err := txn.cache.Get(x.CountKey(attr, 3, false))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, uids(cl, 21))
+
+	require.Empty(t, NoCache(21).KeysWithPrefix(prefix))
+}
+
+func TestUndirectedEdges(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("buddy: [uid] @undirected @count ."), 1))
+	attr := x.GalaxyAttr("buddy")
+
+	mutate := func(edge *pb.DirectedEdge, startTs, commitTs uint64) {
+		edge.Attr = attr
+		txn := Oracle().RegisterStartTs(startTs)
+		l, err := txn.Get(x.DataKey(attr, edge.Entity))
+		require.NoError(t, err)
+		require.NoError(t, l.AddMutationWithIndex(context.Background(), edge, txn))
+		txn.Update()
+		writer := NewTxnWriter(pstore)
+		require.NoError(t, txn.CommitToDisk(writer, commitTs))
+		require.NoError(t, writer.Flush())
+	}
+	buddies := func(uid, readTs uint64) []uint64 {
+		l, err := GetNoStore(x.DataKey(attr, uid), readTs)
+		require.NoError(t, err)
+		return uids(l, readTs)
+	}
+	withCount := func(count uint32, readTs uint64) []uint64 {
+		l, err := GetNoStore(x.CountKey(attr, count, false), readTs)
+		require.NoError(t, err)
+		return uids(l, readTs)
+	}
+
+	facet := &api.Facet{Key: "since", Value: []byte("2006"), ValType: api.Facet_STRING}
+	mutate(&pb.DirectedEdge{Entity: 1, ValueId: 2, ValueType: pb.Posting_UID,
+		Op: pb.DirectedEdge_SET, Facets: []*api.Facet{facet}}, 1, 2)
+	mutate(&pb.DirectedEdge{Entity: 1, ValueId: 3, ValueType: pb.Posting_UID,
+		Op: pb.DirectedEdge_SET}, 3, 4)
+	mutate(&pb.DirectedEdge{Entity: 4, ValueId: 4, ValueType: pb.Posting_UID,
+		Op: pb.DirectedEdge_SET}, 5, 6)
+
+	require.Equal(t, []uint64{2, 3}, buddies(1, 7))
+	require.Equal(t, []uint64{1}, buddies(2, 7))
+	require.Equal(t, []uint64{1}, buddies(3, 7))
+	require.Equal(t, []uint64{4}, buddies(4, 7))
+	require.Equal(t, []uint64{1}, withCount(2, 7))
+	require.Equal(t, []uint64{2, 3, 4}, withCount(1, 7))
+
+	// The facets are the same in both directions.
+	l, err := GetNoStore(x.DataKey(attr, 2), 7)
+	require.NoError(t, err)
+	require.NoError(t, l.Iterate(7, 0, func(p *pb.Posting) error {
+		require.Equal(t, []*api.Facet{facet}, p.Facets)
+		return nil
+	}))
+
+	// Deleting the edge from either node deletes it in both directions.
+	mutate(&pb.DirectedEdge{Entity: 2, ValueId: 1, ValueType: pb.Posting_UID,
+		Op: pb.DirectedEdge_DEL}, 8, 9)
+	require.Equal(t, []uint64{3}, buddies(1, 10))
+	require.Empty(t, buddies(2, 10))
+	require.Equal(t, []uint64{1, 3, 4}, withCount(1, 10))
+
+	mutate(&pb.DirectedEdge{Entity: 1, Value: []byte(x.Star), Op: pb.DirectedEdge_DEL}, 11, 12)
+	require.Empty(t, buddies(1, 13))
+	require.Empty(t, buddies(3, 13))
+	require.Equal(t, []uint64{4}, withCount(1, 13))
+}
+
 func TestNeedsTokIndexRebuild(t *testing.T) {
 	rb := IndexRebuild{}
 	rb.OldSchema = &pb.SchemaUpdate{ValueType: pb.Posting_UID}
@@ -476,6 +567,22 @@ func TestNeedsTokIndexRebuild(t *testing.T) {
 	require.Equal(t, indexOp(indexDelete), rebuildInfo.op)
 	require.Equal(t, []string{"exact"}, rebuildInfo.tokenizersToDelete)
 	require.Equal(t, []string(nil), rebuildInfo.tokenizersToRebuild)
+
+	// Only the indexes of plugin tokenizers whose version has changed are rebuilt.
+	rb.OldSchema = &pb.SchemaUpdate{ValueType: pb.Posting_STRING, Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact", "sku", "anagram"}, TokenizerVersions: []string{"sku=1",
+			"anagram=1"}}
+	rb.CurrentSchema = &pb.SchemaUpdate{ValueType: pb.Posting_STRING,
+		Directive: pb.SchemaUpdate_INDEX, Tokenizer: []string{"exact", "sku", "anagram"},
+		TokenizerVersions: []string{"sku=2", "anagram=1"}}
+	rebuildInfo = rb.needsTokIndexRebuild()
+	require.Equal(t, indexOp(indexRebuild), rebuildInfo.op)
+	require.Equal(t, []string{"sku"}, rebuildInfo.tokenizersToDelete)
+	require.Equal(t, []string{"sku"}, rebuildInfo.tokenizersToRebuild)
+
+	rb.OldSchema.TokenizerVersions = []string{"sku=2", "anagram=1"}
+	rebuildInfo = rb.needsTokIndexRebuild()
+	require.Equal(t, indexOp(indexNoop), rebuildInfo.op)
 }
 
 func TestNeedsCountIndexRebuild(t *testing.T) {
@@ -539,3 +646,39 @@ func TestNeedsListTypeRebuild(t *testing.T) {
 	require.False(t, rebuild)
 	require.Error(t, err)
 }
+
+func TestDeletePredicate(t *testing.T) {
+	ol, commits := createMultiPartList(t, 10000, false)
+	pk, err := x.Parse(ol.key)
+	require.NoError(t, err)
+	attr := pk.Attr
+	ts := uint64(commits + 10)
+
+	// A reverse edge of the predicate.
+	l, err := GetNoStore(x.ReverseKey(attr, 1), ts)
+	require.NoError(t, err)
+	addMutation(t, l, &pb.DirectedEdge{ValueId: 1331, Attr: attr, Entity: 1}, Set, ts, ts+1,
+		false)
+	require.True(t, hasKeys(x.PredicateSplitPrefix(attr)))
+
+	// Only the cached lists of the predicate are removed from the cache.
+	defer withListCache(t)()
+	other := x.DataKey(x.GalaxyAttr("deletepredicate_other"), 1)
+	for _, key := range [][]byte{ol.key, x.ReverseKey(attr, 1), other} {
+		_, err := getNew(key, pstore, math.MaxUint64)
+		require.NoError(t, err)
+		require.True(t, isCached(key))
+	}
+
+	require.NoError(t, DeletePredicate(context.Background(), attr, ts+2))
+	require.False(t, hasKeys(x.PredicatePrefix(attr), x.PredicateSplitPrefix(attr)))
+	require.False(t, isCached(ol.key))
+	require.False(t, isCached(x.ReverseKey(attr, 1)))
+	require.True(t, isCached(other))
+
+	for _, key := range [][]byte{ol.key, x.ReverseKey(attr, 1)} {
+		l, err := GetNoStore(key, math.MaxUint64)
+		require.NoError(t, err)
+		require.Empty(t, uids(l, math.MaxUint64))
+	}
+}
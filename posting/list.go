@@ -23,6 +23,7 @@ import (
 	"log"
 	"math"
 	"sort"
+	"strconv"
 
 	"github.com/dgryski/go-farm"
 	"github.com/pkg/errors"
@@ -57,6 +58,8 @@ const (
 	Set uint32 = 0x01
 	// Del means delete in mutation layer. It contributes -1 in Length.
 	Del uint32 = 0x02
+	// Incr means add to the value below in mutation layer. It contributes 0 in Length.
+	Incr uint32 = 0x04
 
 	// BitSchemaPosting signals that the value stores a schema or type.
 	BitSchemaPosting byte = 0x01
@@ -295,6 +298,8 @@ func NewPosting(t *pb.DirectedEdge) *pb.Posting {
 		op = Set
 	case pb.DirectedEdge_DEL:
 		op = Del
+	case pb.DirectedEdge_INCR:
+		op = Incr
 	default:
 		x.Fatalf("Unhandled operation: %+v", t)
 	}
@@ -328,7 +333,7 @@ func hasDeleteAll(mpost *pb.Posting) bool {
 // Ensure that you either abort the uncommitted postings or commit them before calling me.
 func (l *List) updateMutationLayer(mpost *pb.Posting, singleUidUpdate bool) error {
 	l.AssertLock()
-	x.AssertTrue(mpost.Op == Set || mpost.Op == Del)
+	x.AssertTrue(mpost.Op == Set || mpost.Op == Del || mpost.Op == Incr)
 
 	// If we have a delete all, then we replace the map entry with just one.
 	if hasDeleteAll(mpost) {
@@ -397,6 +402,18 @@ func (l *List) updateMutationLayer(mpost *pb.Posting, singleUidUpdate bool) erro
 	// the time, because it is O(N^2), where N = number of postings added.
 	for i, prev := range plist.Postings {
 		if prev.Uid == mpost.Uid {
+			switch {
+			case mpost.Op == Incr && prev.Op == Del:
+				// The value has been deleted by the transaction, so it's set to the increment.
+				mpost.Op = Set
+			case mpost.Op == Incr:
+				// The increment is merged with the previous increment or value of the transaction.
+				merged, err := addIncrement(prev, mpost)
+				if err != nil {
+					return err
+				}
+				mpost = merged
+			}
 			plist.Postings[i] = mpost
 			return nil
 		}
@@ -435,6 +452,67 @@ func fingerprintEdge(t *pb.DirectedEdge) uint64 {
 	return id
 }
 
+type appendDuplicatesKey struct{}
+
+// WithAppendDuplicates returns a context for mutations which keep every occurrence of a value set
+// more than once to a list predicate, as with the mutation option duplicates=append.
+func WithAppendDuplicates(ctx context.Context) context.Context {
+	return context.WithValue(ctx, appendDuplicatesKey{}, true)
+}
+
+// duplicateId returns the uid of the posting holding the nth occurrence of a value of a list
+// predicate. The first one is the fingerprint of the value, as for the lists without duplicates.
+func duplicateId(value []byte, n int) uint64 {
+	if n == 0 {
+		return farm.Fingerprint64(value)
+	}
+	buf := make([]byte, 0, len(value)+8)
+	buf = append(buf, value...)
+	buf = strconv.AppendInt(append(buf, 0), int64(n), 10)
+	return farm.Fingerprint64(buf)
+}
+
+// handleDuplicates gives a value set to a list predicate with duplicates=append the first
+// occurrence which isn't in the list yet, so that every occurrence has its own posting. A value
+// deleted from a list is deleted along with its other occurrences.
+func (l *List) handleDuplicates(ctx context.Context, txn *Txn, pk x.ParsedKey,
+	t *pb.DirectedEdge, mpost *pb.Posting) error {
+
+	appendDuplicates, _ := ctx.Value(appendDuplicatesKey{}).(bool)
+	switch {
+	case mpost.Op == Set && appendDuplicates:
+		for n := 0; ; n++ {
+			id := duplicateId(t.Value, n)
+			found, _, err := l.findPosting(txn.StartTs, id)
+			if err != nil {
+				return err
+			}
+			if !found {
+				t.ValueId, mpost.Uid = id, id
+				return nil
+			}
+		}
+	case mpost.Op == Del && !hasDeleteAll(mpost):
+		for n := 1; ; n++ {
+			id := duplicateId(t.Value, n)
+			found, _, err := l.findPosting(txn.StartTs, id)
+			if err != nil || !found {
+				return err
+			}
+			dup := proto.Clone(mpost).(*pb.Posting)
+			dup.Uid = id
+			if err := l.updateMutationLayer(dup, false); err != nil {
+				return err
+			}
+			if !x.WorkerConfig.LudicrousEnabled {
+				txn.addConflictKey(GetConflictKey(pk, l.key,
+					&pb.DirectedEdge{Attr: t.Attr, ValueId: id}))
+			}
+		}
+	}
+	return nil
+}
+
 func (l *List) addMutation(ctx context.Context, txn *Txn, t *pb.DirectedEdge) error {
 	l.Lock()
 	defer l.Unlock()
@@ -453,6 +531,10 @@ func GetConflictKey(pk x.ParsedKey, key []byte, t *pb.DirectedEdge) uint64 {
 	switch {
 	case schema.State().HasNoConflict(t.Attr):
 		break
+	case t.Op == pb.DirectedEdge_INCR:
+		// Increments commute, so the concurrent increments of a value don't conflict. The ones
+		// committed after a set of the value are added to it, the ones committed before are lost.
+		break
 	case schema.State().HasUpsert(t.Attr):
 		// Consider checking to see if a email id is unique. A user adds:
 		// <uid> <email> "email@email.org", and there's a string equal tokenizer
@@ -515,6 +597,11 @@ func (l *List) addMutationInternal(ctx context.Context, txn *Txn, t *pb.Directed
 		return errors.Wrapf(err, "cannot parse key when adding mutation to list with key %s",
 			hex.EncodeToString(l.key))
 	}
+	if mpost.PostingType == pb.Posting_VALUE && pk.IsData() && schema.State().IsList(t.Attr) {
+		if err := l.handleDuplicates(ctx, txn, pk, t, mpost); err != nil {
+			return err
+		}
+	}
 	pred, ok := schema.State().Get(ctx, t.Attr)
 	isSingleUidUpdate := ok && !pred.GetList() && pred.GetValueType() == pb.Posting_UID &&
 		pk.IsData() && mpost.Op == Set && mpost.PostingType == pb.Posting_REF
@@ -649,6 +736,10 @@ func (l *List) iterate(readTs uint64, afterUid uint64, f func(obj *pb.Posting) e
 	if readTs < l.minTs {
 		return errors.Errorf("readTs: %d less than minTs: %d for key: %q", readTs, l.minTs, l.key)
 	}
+	mposts, err := l.resolveIncrements(mposts, deleteBelowTs)
+	if err != nil {
+		return errors.Wrapf(err, "cannot resolve increments of list with key %q", l.key)
+	}
 
 	midx, mlen := 0, len(mposts)
 	if afterUid > 0 {
@@ -662,7 +753,6 @@ func (l *List) iterate(readTs uint64, afterUid uint64, f func(obj *pb.Posting) e
 		mp, pp  *pb.Posting
 		pitr    pIterator
 		prevUid uint64
-		err     error
 	)
 
 	// pitr iterates through immutable postings
@@ -393,6 +393,48 @@ func TestAddMutation_jchiu3(t *testing.T) {
 	require.Equal(t, 0, ol.Length(txn.StartTs, 0))
 }
 
+func TestAppendDuplicates(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("dup_tags: [string] ."), 1))
+	attr := x.GalaxyAttr("dup_tags")
+	ol, err := GetNoStore(x.DataKey(attr, 1), math.MaxUint64)
+	require.NoError(t, err)
+	edge := func(val string, op pb.DirectedEdge_Op) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Attr: attr, Value: []byte(val), Op: op}
+	}
+	ctx := WithAppendDuplicates(context.Background())
+
+	// Without the option, the value is kept once.
+	txn := &Txn{StartTs: 1}
+	require.NoError(t, ol.addMutation(context.Background(), txn, edge("a", pb.DirectedEdge_SET)))
+	require.NoError(t, ol.addMutation(context.Background(), txn, edge("a", pb.DirectedEdge_SET)))
+	require.NoError(t, ol.commitMutation(1, 2))
+	require.Equal(t, 1, ol.Length(2, 0))
+
+	// With it, the duplicates of the mutation and of the previous ones are all kept, each with
+	// its own facets.
+	txn = &Txn{StartTs: 3}
+	dup := edge("a", pb.DirectedEdge_SET)
+	dup.Facets = []*api.Facet{{Key: "weight"}}
+	require.NoError(t, ol.addMutation(ctx, txn, dup))
+	require.NoError(t, ol.addMutation(ctx, txn, edge("a", pb.DirectedEdge_SET)))
+	require.NoError(t, ol.addMutation(ctx, txn, edge("b", pb.DirectedEdge_SET)))
+	require.NoError(t, ol.commitMutation(3, 4))
+	require.Equal(t, 4, ol.Length(4, 0))
+	ol.RLock()
+	found, p, err := ol.findPosting(4, duplicateId([]byte("a"), 1))
+	ol.RUnlock()
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, p.Facets, 1)
+
+	// Deleting the value deletes all of its occurrences.
+	txn = &Txn{StartTs: 5}
+	require.NoError(t, ol.addMutation(context.Background(), txn, edge("a", pb.DirectedEdge_DEL)))
+	require.NoError(t, ol.commitMutation(5, 6))
+	require.Equal(t, 1, ol.Length(6, 0))
+	require.EqualValues(t, "b", getFirst(ol, 6).Value)
+}
+
 func TestAddMutation_mrjn1(t *testing.T) {
 	key := x.DataKey(x.GalaxyAttr("value"), 21)
 	ol, err := GetNoStore(key, math.MaxUint64)
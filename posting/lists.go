@@ -19,6 +19,7 @@ package posting
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -208,6 +209,26 @@ func (lc *LocalCache) GetFromDelta(key []byte) (*List, error) {
 	return lc.getInternal(key, false)
 }
 
+// KeysWithPrefix returns the keys with the given prefix of the posting lists held by the cache,
+// including the ones which only have the deltas of the transaction. Those aren't found when
+// iterating over Badger until the transaction is committed.
+func (lc *LocalCache) KeysWithPrefix(prefix []byte) [][]byte {
+	lc.RLock()
+	defer lc.RUnlock()
+	var keys [][]byte
+	for key := range lc.deltas {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, []byte(key))
+		}
+	}
+	for key := range lc.plists {
+		if _, ok := lc.deltas[key]; !ok && strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, []byte(key))
+		}
+	}
+	return keys
+}
+
 // UpdateDeltasAndDiscardLists updates the delta cache before removing the stored posting lists.
 func (lc *LocalCache) UpdateDeltasAndDiscardLists() {
 	lc.Lock()
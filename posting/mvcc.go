@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"math"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,6 +30,7 @@ import (
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
+	"github.com/dgryski/go-farm"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
@@ -202,17 +202,29 @@ func (txn *Txn) addConflictKey(conflictKey uint64) {
 	}
 }
 
+// AddKeyConflict adds a conflict on the key to the transaction, which isn't added by the
+// mutations for every kind of key, e.g. for reverse keys. Two concurrent transactions adding a
+// conflict on the same key can't both commit.
+func (txn *Txn) AddKeyConflict(key []byte) {
+	txn.addConflictKey(farm.Fingerprint64(key))
+}
+
 // FillContext updates the given transaction context with data from this transaction.
 func (txn *Txn) FillContext(ctx *api.TxnContext, gid uint32) {
 	txn.Lock()
 	ctx.StartTs = txn.StartTs
+	// The keys of a transaction in read-committed mode are checked for conflicts from the ts its
+	// mutations read the data at.
+	var readTs uint64
+	if txn.id != 0 && txn.id != txn.StartTs {
+		ctx.StartTs, readTs = txn.id, txn.StartTs
+	}
 
 	for key := range txn.conflicts {
 		// We don'txn need to send the whole conflict key to Zero. Solving #2338
 		// should be done by sending a list of mutating predicates to Zero,
 		// along with the keys to be used for conflict detection.
-		fps := strconv.FormatUint(key, 36)
-		ctx.Keys = append(ctx.Keys, fps)
+		ctx.Keys = append(ctx.Keys, x.ConflictKey(key, readTs))
 	}
 	ctx.Keys = x.Unique(ctx.Keys)
 
@@ -283,15 +295,32 @@ func (txn *Txn) CommitToDisk(writer *TxnWriter, commitTs uint64) error {
 	return nil
 }
 
+// cacheGen is incremented when the cache is reset or when lists are removed from it, so that the
+// lists read from disk before aren't kept in the cache.
+var cacheGen uint64
+
 // ResetCache will clear all the cached list.
 func ResetCache() {
+	atomic.AddUint64(&cacheGen, 1)
 	lCache.Clear()
+	resetPinned()
+}
+
+// removeCachedLists deletes the cached lists of the keys, e.g. once they are dropped. The lists
+// being read from disk meanwhile aren't kept in the cache either.
+func removeCachedLists(keys [][]byte) {
+	atomic.AddUint64(&cacheGen, 1)
+	for _, key := range keys {
+		lCache.Del(key)
+		removePinned(key)
+	}
 }
 
 // RemoveCacheFor will delete the list corresponding to the given key.
 func RemoveCacheFor(key []byte) {
 	// TODO: investigate if this can be done by calling Set with a nil value.
 	lCache.Del(key)
+	removePinned(key)
 }
 
 // RemoveCachedKeys will delete the cached list by this txn.
@@ -301,6 +330,7 @@ func (txn *Txn) RemoveCachedKeys() {
 	}
 	for key := range txn.cache.deltas {
 		lCache.Del(key)
+		removePinned([]byte(key))
 	}
 }
 
@@ -425,33 +455,50 @@ func ReadPostingList(key []byte, it *badger.Iterator) (*List, error) {
 	return l, nil
 }
 
+// copyCachedList returns a copy of a cached list, which can be modified by the caller.
+func copyCachedList(key []byte, l *List) *List {
+	// No need to clone the immutable layer or the key since mutations will not modify it.
+	lCopy := &List{
+		minTs: l.minTs,
+		maxTs: l.maxTs,
+		key:   key,
+		plist: l.plist,
+	}
+	l.RLock()
+	if l.mutationMap != nil {
+		lCopy.mutationMap = make(map[uint64]*pb.PostingList, len(l.mutationMap))
+		for ts, pl := range l.mutationMap {
+			lCopy.mutationMap[ts] = proto.Clone(pl).(*pb.PostingList)
+		}
+	}
+	l.RUnlock()
+	return lCopy
+}
+
 func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
-	cachedVal, ok := lCache.Get(key)
-	if ok {
-		l, ok := cachedVal.(*List)
-		if ok && l != nil {
-			// No need to clone the immutable layer or the key since mutations will not modify it.
-			lCopy := &List{
-				minTs: l.minTs,
-				maxTs: l.maxTs,
-				key:   key,
-				plist: l.plist,
-			}
-			l.RLock()
-			if l.mutationMap != nil {
-				lCopy.mutationMap = make(map[uint64]*pb.PostingList, len(l.mutationMap))
-				for ts, pl := range l.mutationMap {
-					lCopy.mutationMap[ts] = proto.Clone(pl).(*pb.PostingList)
-				}
+	pin := isPinned(key)
+	if pin {
+		if l := getPinned(key); l != nil {
+			return copyCachedList(key, l), nil
+		}
+		defer donePinned(key)
+	}
+	// The pinned predicates are always cached, even if they are in no-cache-predicates.
+	noCache := !pin && isNoCache(key)
+	if !noCache {
+		cachedVal, ok := lCache.Get(key)
+		if ok {
+			l, ok := cachedVal.(*List)
+			if ok && l != nil {
+				return copyCachedList(key, l), nil
 			}
-			l.RUnlock()
-			return lCopy, nil
 		}
 	}
 
 	if pstore.IsClosed() {
 		return nil, badger.ErrDBClosed
 	}
+	cgen := atomic.LoadUint64(&cacheGen)
 	txn := pstore.NewTransactionAt(readTs, false)
 	defer txn.Discard()
 
@@ -467,6 +514,14 @@ func getNew(key []byte, pstore *badger.DB, readTs uint64) (*List, error) {
 	if err != nil {
 		return l, err
 	}
+	if noCache || (pin && setPinned(key, l)) {
+		return l, nil
+	}
 	lCache.Set(key, l, 0)
+	if atomic.LoadUint64(&cacheGen) != cgen {
+		// The cache was reset while the list was read, e.g. by a predicate drop, so the list
+		// could be stale.
+		lCache.Del(key)
+	}
 	return l, nil
 }
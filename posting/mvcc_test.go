@@ -20,11 +20,36 @@ import (
 	"math"
 	"testing"
 
+	"github.com/dgraph-io/dgo/v210/protos/api"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/x"
+	"github.com/dgraph-io/ristretto"
 	"github.com/stretchr/testify/require"
 )
 
+// withListCache sets up lCache, as the tests run without it, and returns the function removing it.
+func withListCache(t *testing.T) func() {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 1000,
+		MaxCost:     1 << 20,
+		BufferItems: 64,
+		Cost:        func(interface{}) int64 { return 1 },
+	})
+	require.NoError(t, err)
+	lCache = cache
+	return func() {
+		lCache = nil
+		cache.Close()
+	}
+}
+
+// isCached returns true if the list of the key is in lCache.
+func isCached(key []byte) bool {
+	lCache.Wait()
+	_, ok := lCache.Get(key)
+	return ok
+}
+
 func TestRollupTimestamp(t *testing.T) {
 	attr := x.GalaxyAttr("rollup")
 	key := x.DataKey(attr, 1)
@@ -99,3 +124,172 @@ func TestPostingListRead(t *testing.T) {
 	addEdgeToUID(t, attr, 1, 7, 15, 16)
 	assertLength(17, 3)
 }
+
+// pinnedList returns the pinned list of the key, without starting a read from disk.
+func pinnedList(key []byte) *List {
+	pinned.RLock()
+	defer pinned.RUnlock()
+	return pinned.lists[string(key)]
+}
+
+func TestPinnedPredicate(t *testing.T) {
+	SetPinnedPredicates([]string{"pinned"}, 1<<20)
+	defer SetPinnedPredicates(nil, 0)
+
+	attr := x.GalaxyAttr("pinned")
+	key := x.DataKey(attr, 1)
+	require.True(t, isPinned(key))
+	require.False(t, isPinned(x.DataKey(x.GalaxyAttr("notpinned"), 1)))
+
+	assertLength := func(readTs, sz int) {
+		nl, err := getNew(key, pstore, math.MaxUint64)
+		require.NoError(t, err)
+		uidList, err := nl.Uids(ListOptions{ReadTs: uint64(readTs)})
+		require.NoError(t, err)
+		require.Equal(t, sz, len(uidList.Uids))
+	}
+
+	// As after a commit, the list read for the mutation isn't kept.
+	addEdgeToUID(t, attr, 1, 2, 1, 2)
+	RemoveCacheFor(key)
+	assertLength(3, 1)
+	l := pinnedList(key)
+	require.NotNil(t, l)
+
+	// The pinned list is removed when the key is updated, and read again from disk.
+	addEdgeToUID(t, attr, 1, 3, 3, 4)
+	RemoveCacheFor(key)
+	require.Nil(t, pinnedList(key))
+	assertLength(5, 2)
+	require.NotNil(t, pinnedList(key))
+
+	// A list read before a removal of its key isn't pinned.
+	RemoveCacheFor(key)
+	require.Nil(t, getPinned(key))
+	RemoveCacheFor(key)
+	require.True(t, setPinned(key, l))
+	donePinned(key)
+	require.Nil(t, pinnedList(key))
+	require.Empty(t, pinned.reads)
+	// The size of the lists is the one they had when they were pinned, even if they grew since.
+	require.Zero(t, pinned.size)
+}
+
+func TestPinnedRemovalPerKey(t *testing.T) {
+	SetPinnedPredicates([]string{"pinnedkeys"}, 1<<20)
+	defer SetPinnedPredicates(nil, 0)
+
+	attr := x.GalaxyAttr("pinnedkeys")
+	key1, key2 := x.DataKey(attr, 1), x.DataKey(attr, 2)
+	l := &List{key: key1, plist: new(pb.PostingList)}
+
+	// The removal of a key doesn't drop the reads of the other keys.
+	require.Nil(t, getPinned(key1))
+	require.Nil(t, getPinned(key2))
+	RemoveCacheFor(key1)
+	require.True(t, setPinned(key1, l))
+	require.True(t, setPinned(key2, l))
+	donePinned(key1)
+	donePinned(key2)
+	require.Nil(t, pinnedList(key1))
+	require.NotNil(t, pinnedList(key2))
+
+	// A read which started after the removal is kept once the older reads are done.
+	require.Nil(t, getPinned(key1))
+	require.True(t, setPinned(key1, l))
+	donePinned(key1)
+	require.NotNil(t, pinnedList(key1))
+
+	// A reset drops all the reads in progress.
+	RemoveCacheFor(key2)
+	require.Nil(t, getPinned(key2))
+	ResetCache()
+	require.True(t, setPinned(key2, l))
+	donePinned(key2)
+	require.Nil(t, pinnedList(key2))
+	require.Empty(t, pinned.reads)
+}
+
+func TestNoCachePredicate(t *testing.T) {
+	defer withListCache(t)()
+	require.NoError(t, SetNoCachePredicates([]string{"nocache"}))
+	defer func() { require.NoError(t, SetNoCachePredicates(nil)) }()
+
+	attr := x.GalaxyAttr("nocache")
+	key := x.DataKey(attr, 1)
+	cachedAttr := x.GalaxyAttr("cached")
+	cachedKey := x.DataKey(cachedAttr, 1)
+	require.True(t, isNoCache(key))
+	require.False(t, isNoCache(cachedKey))
+
+	addEdgeToUID(t, attr, 1, 2, 1, 2)
+	nl, err := getNew(key, pstore, math.MaxUint64)
+	require.NoError(t, err)
+	uidList, err := nl.Uids(ListOptions{ReadTs: 3})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, uidList.Uids)
+	// The list is read from the disk every time, while the one of another predicate is cached.
+	require.False(t, isCached(key))
+	addEdgeToUID(t, cachedAttr, 1, 2, 1, 2)
+	_, err = getNew(cachedKey, pstore, math.MaxUint64)
+	require.NoError(t, err)
+	require.True(t, isCached(cachedKey))
+
+	// A predicate can't be both pinned and not cached.
+	SetPinnedPredicates([]string{"nocache"}, 1<<20)
+	defer SetPinnedPredicates(nil, 0)
+	require.Error(t, SetNoCachePredicates([]string{"nocache"}))
+}
+
+func TestPinnedSizeLimit(t *testing.T) {
+	SetPinnedPredicates([]string{"pinnedsize"}, 1)
+	defer SetPinnedPredicates(nil, 0)
+
+	attr := x.GalaxyAttr("pinnedsize")
+	key := x.DataKey(attr, 1)
+	addEdgeToUID(t, attr, 1, 2, 1, 2)
+	_, err := getNew(key, pstore, math.MaxUint64)
+	require.NoError(t, err)
+	// The list doesn't fit in the pinned size, it is cached like the others.
+	require.Nil(t, pinnedList(key))
+}
+
+func TestReadCommitted(t *testing.T) {
+	attr := x.GalaxyAttr("readcommitted")
+	key := x.DataKey(attr, 1)
+	// The other tests leave transactions pending at the lower timestamps.
+	addEdgeToUID(t, attr, 1, 2, 31, 32)
+	// Committed after the start of the transaction at 33.
+	addEdgeToUID(t, attr, 1, 3, 34, 35)
+
+	txn := Oracle().RegisterReadCommitted(33, 36)
+	defer func() {
+		o.Lock()
+		delete(o.pendingTxns, 33)
+		o.Unlock()
+	}()
+	l, err := txn.Get(key)
+	require.NoError(t, err)
+	addMutationHelper(t, l, &pb.DirectedEdge{ValueId: 4, Attr: attr, Entity: 1}, Set, txn)
+	txn.Update()
+
+	// The cache of the transaction can't be read at its start ts.
+	require.Nil(t, Oracle().CacheAt(33))
+
+	lc := Oracle().ReadCommittedCache(33, 37)
+	l, err = lc.Get(key)
+	require.NoError(t, err)
+	uids, err := l.Uids(ListOptions{ReadTs: 37})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3, 4}, uids.Uids)
+
+	var ctx api.TxnContext
+	txn.FillContext(&ctx, 1)
+	require.Equal(t, uint64(33), ctx.StartTs)
+	require.NotEmpty(t, ctx.Keys)
+	for _, k := range ctx.Keys {
+		_, readTs, err := x.ParseConflictKey(k)
+		require.NoError(t, err)
+		require.Equal(t, uint64(36), readTs)
+	}
+}
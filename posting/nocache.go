@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// noCache holds the predicates of --cache no-cache-predicates, whose posting lists are read from
+// disk on every read instead of being cached in lCache. The lists of a predicate which is written
+// very often, like a counter, are updated before they are read again, and they evict the lists of
+// the other predicates. The reads of these predicates are slower, as each of them is served by
+// badger, whose block cache is still used for them.
+var noCache = struct {
+	sync.RWMutex
+	// preds holds the predicates, without their namespace.
+	preds map[string]struct{}
+}{}
+
+// SetNoCachePredicates sets the predicates whose posting lists aren't cached, in every namespace.
+// A predicate can't be both pinned and not cached.
+func SetNoCachePredicates(preds []string) error {
+	pinned.RLock()
+	for _, pred := range preds {
+		if _, ok := pinned.preds[pred]; ok {
+			pinned.RUnlock()
+			return errors.Errorf("Predicate %s can't be in both pin-predicates and "+
+				"no-cache-predicates", pred)
+		}
+	}
+	pinned.RUnlock()
+
+	noCache.Lock()
+	noCache.preds = make(map[string]struct{}, len(preds))
+	for _, pred := range preds {
+		noCache.preds[pred] = struct{}{}
+	}
+	noCache.Unlock()
+	// The lists of these predicates could already be cached.
+	if lCache != nil {
+		lCache.Clear()
+	}
+	return nil
+}
+
+// isNoCache returns true if the key belongs to a predicate whose lists aren't cached.
+func isNoCache(key []byte) bool {
+	noCache.RLock()
+	defer noCache.RUnlock()
+	if len(noCache.preds) == 0 {
+		return false
+	}
+	pk, err := x.Parse(key)
+	if err != nil {
+		return false
+	}
+	_, ok := noCache.preds[x.ParseAttr(pk.Attr)]
+	return ok
+}
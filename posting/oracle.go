@@ -45,7 +45,12 @@ func init() {
 
 // Txn represents a transaction.
 type Txn struct {
+	// StartTs is the ts the mutations of the transaction read the data at, and are kept at in the
+	// posting lists until they are committed. It is the start ts of the transaction, except in
+	// read-committed mode, see RegisterReadCommitted.
 	StartTs uint64
+	// id is the start ts of the transaction, by which it is known to the clients and Zero.
+	id uint64
 
 	// atomic
 	shouldAbort uint32
@@ -67,6 +72,7 @@ type Txn struct {
 func NewTxn(startTs uint64) *Txn {
 	return &Txn{
 		StartTs:    startTs,
+		id:         startTs,
 		cache:      NewLocalCache(startTs),
 		lastUpdate: time.Now(),
 	}
@@ -128,16 +134,61 @@ func (o *oracle) RegisterStartTs(ts uint64) *Txn {
 	return txn
 }
 
+// RegisterReadCommitted returns the transaction with the given start ts, as RegisterStartTs does,
+// for a mutation of a transaction in read-committed mode which reads the data at readTs. The
+// mutations of such a transaction read the data at the read ts of the first one applied by the
+// group, so its keys only conflict with the commits done after it, see x.ConflictKey. The data
+// read by its later mutations is older than theirs, which is fine as they are checked for
+// conflicts from the same ts.
+func (o *oracle) RegisterReadCommitted(startTs, readTs uint64) *Txn {
+	o.Lock()
+	defer o.Unlock()
+	txn, ok := o.pendingTxns[startTs]
+	if ok {
+		txn.lastUpdate = time.Now()
+	} else {
+		txn = NewTxn(readTs)
+		txn.id = startTs
+		o.pendingTxns[startTs] = txn
+	}
+	return txn
+}
+
 func (o *oracle) CacheAt(ts uint64) *LocalCache {
 	o.RLock()
 	defer o.RUnlock()
 	txn, ok := o.pendingTxns[ts]
-	if !ok {
+	if !ok || txn.StartTs != ts {
+		// The cache of a transaction in read-committed mode holds the data read later than
+		// its start ts, it can only be read with ReadCommittedCache.
 		return nil
 	}
 	return txn.cache
 }
 
+// ReadCommittedCache returns a cache reading the data committed up to readTs, along with the
+// mutations of the transaction with the given start ts, for the queries of a transaction in
+// read-committed mode.
+func (o *oracle) ReadCommittedCache(startTs, readTs uint64) *LocalCache {
+	lc := NewLocalCache(readTs)
+	o.RLock()
+	txn, ok := o.pendingTxns[startTs]
+	o.RUnlock()
+	if !ok {
+		return lc
+	}
+	txnCache := txn.cache
+	// The mutations are applied to the lists read at readTs as if they were done at readTs, so
+	// that they are seen along with the commits done after the start of the transaction. readTs
+	// is a new ts from Zero, which isn't the commit ts of any transaction.
+	txnCache.RLock()
+	defer txnCache.RUnlock()
+	for key, delta := range txnCache.deltas {
+		lc.deltas[key] = delta
+	}
+	return lc
+}
+
 // MinPendingStartTs returns the min start ts which is currently pending a commit or abort decision.
 func (o *oracle) MinPendingStartTs() uint64 {
 	o.RLock()
@@ -0,0 +1,242 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package posting
+
+import (
+	"bytes"
+	"math"
+	"sync"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/golang/glog"
+
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// pinned holds the posting lists of the predicates of --cache pin-predicates. Unlike the ones of
+// lCache, they are never evicted: they are only removed when they are updated, and read back from
+// disk on the next read. Their total size is limited by --cache pin-size-mb, so that pinning too
+// many predicates can't take the memory of the other reads. Once the limit is reached, the lists
+// of the pinned predicates are cached in lCache like the others.
+var pinned = struct {
+	sync.RWMutex
+	// preds holds the pinned predicates, without their namespace.
+	preds map[string]struct{}
+	lists map[string]*List
+	// sizes holds the size of each list when it was pinned, which is the one taken out of size
+	// once it's removed, as the list can grow once it's returned by getNew.
+	sizes   map[string]int64
+	size    int64
+	maxSize int64
+	// reads holds the keys being read from disk. A list read from disk is only kept if its key
+	// hasn't been removed since the read started, as it could have been read before the commit
+	// which removed it, and would then never be updated.
+	reads map[string]*pinnedRead
+}{lists: make(map[string]*List), sizes: make(map[string]int64),
+	reads: make(map[string]*pinnedRead)}
+
+// pinnedRead counts the reads of a key from disk in progress. Once the key is removed, none of
+// them is kept, including the ones which started after the removal, until they are all done.
+type pinnedRead struct {
+	count int
+	stale bool
+}
+
+// SetPinnedPredicates sets the predicates whose posting lists are kept in memory, in every
+// namespace, and the maximum size in bytes of these lists.
+func SetPinnedPredicates(preds []string, maxSize int64) {
+	pinned.Lock()
+	defer pinned.Unlock()
+	pinned.preds = make(map[string]struct{}, len(preds))
+	for _, pred := range preds {
+		pinned.preds[pred] = struct{}{}
+	}
+	pinned.maxSize = maxSize
+	pinned.lists = make(map[string]*List)
+	pinned.sizes = make(map[string]int64)
+	pinned.size = 0
+	for _, r := range pinned.reads {
+		r.stale = true
+	}
+}
+
+// isPinned returns true if the key belongs to a pinned predicate.
+func isPinned(key []byte) bool {
+	pinned.RLock()
+	defer pinned.RUnlock()
+	if len(pinned.preds) == 0 {
+		return false
+	}
+	pk, err := x.Parse(key)
+	if err != nil {
+		return false
+	}
+	_, ok := pinned.preds[x.ParseAttr(pk.Attr)]
+	return ok
+}
+
+// getPinned returns the pinned list of the key if there is one. Otherwise it starts a read of the
+// list from disk, which has to be ended with donePinned once the list is passed to setPinned.
+func getPinned(key []byte) *List {
+	pinned.RLock()
+	l := pinned.lists[string(key)]
+	pinned.RUnlock()
+	if l != nil {
+		return l
+	}
+
+	pinned.Lock()
+	defer pinned.Unlock()
+	if l := pinned.lists[string(key)]; l != nil {
+		return l
+	}
+	r, ok := pinned.reads[string(key)]
+	if !ok {
+		r = &pinnedRead{}
+		pinned.reads[string(key)] = r
+	}
+	r.count++
+	return nil
+}
+
+// donePinned ends a read of the list of the key from disk started by getPinned.
+func donePinned(key []byte) {
+	pinned.Lock()
+	defer pinned.Unlock()
+	if r, ok := pinned.reads[string(key)]; ok {
+		if r.count--; r.count <= 0 {
+			delete(pinned.reads, string(key))
+		}
+	}
+}
+
+// setPinned keeps the list read from disk in memory, and returns false if it doesn't fit in
+// pin-size-mb. The list isn't kept if the key has been removed since the read started.
+func setPinned(key []byte, l *List) bool {
+	size := int64(l.DeepSize())
+	pinned.Lock()
+	defer pinned.Unlock()
+	if r, ok := pinned.reads[string(key)]; ok && r.stale {
+		return true
+	}
+	pinned.size -= pinned.sizes[string(key)]
+	if pinned.size+size > pinned.maxSize {
+		delete(pinned.lists, string(key))
+		delete(pinned.sizes, string(key))
+		return false
+	}
+	pinned.lists[string(key)] = l
+	pinned.sizes[string(key)] = size
+	pinned.size += size
+	return true
+}
+
+func removePinned(key []byte) {
+	pinned.Lock()
+	defer pinned.Unlock()
+	if r, ok := pinned.reads[string(key)]; ok {
+		r.stale = true
+	}
+	pinned.size -= pinned.sizes[string(key)]
+	delete(pinned.lists, string(key))
+	delete(pinned.sizes, string(key))
+}
+
+func resetPinned() {
+	pinned.Lock()
+	defer pinned.Unlock()
+	for _, r := range pinned.reads {
+		r.stale = true
+	}
+	pinned.lists = make(map[string]*List)
+	pinned.sizes = make(map[string]int64)
+	pinned.size = 0
+}
+
+// PrewarmPinned reads the posting lists of the pinned predicates from disk, so that the first
+// reads after a restart don't have to. It stops once pin-size-mb is reached.
+func PrewarmPinned() {
+	var preds []string
+	for _, pred := range schema.State().Predicates() {
+		if isPinned(x.DataKey(pred, 0)) {
+			preds = append(preds, pred)
+		}
+	}
+	if len(preds) == 0 {
+		return
+	}
+
+	closer.AddRunning(1)
+	defer closer.Done()
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	var count int
+	for _, pred := range preds {
+		prefix := x.PredicatePrefix(pred)
+		iterOpts := badger.DefaultIteratorOptions
+		iterOpts.AllVersions = true
+		iterOpts.PrefetchValues = false
+		iterOpts.Prefix = prefix
+		it := txn.NewIterator(iterOpts)
+
+		var prevKey []byte
+		for it.Seek(prefix); it.Valid(); {
+			item := it.Item()
+			if bytes.Equal(item.Key(), prevKey) {
+				it.Next()
+				continue
+			}
+			prevKey = append(prevKey[:0], item.Key()...)
+
+			select {
+			case <-closer.HasBeenClosed():
+				it.Close()
+				return
+			default:
+			}
+			key := item.KeyCopy(nil)
+			read := getPinned(key) == nil
+			l, err := ReadPostingList(key, it)
+			fits := true
+			if read {
+				if err == nil {
+					fits = setPinned(key, l)
+				}
+				donePinned(key)
+			}
+			switch {
+			case err == ErrInvalidKey:
+				// The parts of a multi-part list are read through its main key.
+				continue
+			case err != nil:
+				glog.Errorf("While prewarming the pinned predicate %s: %v", x.ParseAttr(pred), err)
+				it.Close()
+				return
+			}
+			if !fits {
+				it.Close()
+				glog.Warningf("The pinned predicates don't fit in pin-size-mb, %d posting "+
+					"lists have been loaded", count)
+				return
+			}
+			count++
+		}
+		it.Close()
+	}
+	glog.Infof("Loaded %d posting lists of the pinned predicates", count)
+}
@@ -35,16 +35,20 @@ type DirectedEdge_Op int32
 const (
 	DirectedEdge_SET DirectedEdge_Op = 0
 	DirectedEdge_DEL DirectedEdge_Op = 1
+	// INCR adds the value to the current one of the predicate, which must be an int or a float.
+	DirectedEdge_INCR DirectedEdge_Op = 2
 )
 
 var DirectedEdge_Op_name = map[int32]string{
 	0: "SET",
 	1: "DEL",
+	2: "INCR",
 }
 
 var DirectedEdge_Op_value = map[string]int32{
-	"SET": 0,
-	"DEL": 1,
+	"SET":  0,
+	"DEL":  1,
+	"INCR": 2,
 }
 
 func (x DirectedEdge_Op) String() string {
@@ -136,6 +140,7 @@ const (
 	Posting_PASSWORD Posting_ValType = 8
 	Posting_STRING   Posting_ValType = 9
 	Posting_OBJECT   Posting_ValType = 10
+	Posting_DECIMAL  Posting_ValType = 11
 )
 
 var Posting_ValType_name = map[int32]string{
@@ -150,6 +155,7 @@ var Posting_ValType_name = map[int32]string{
 	8:  "PASSWORD",
 	9:  "STRING",
 	10: "OBJECT",
+	11: "DECIMAL",
 }
 
 var Posting_ValType_value = map[string]int32{
@@ -164,6 +170,7 @@ var Posting_ValType_value = map[string]int32{
 	"PASSWORD": 8,
 	"STRING":   9,
 	"OBJECT":   10,
+	"DECIMAL":  11,
 }
 
 func (x Posting_ValType) String() string {
@@ -509,6 +516,13 @@ type Query struct {
 	First        int32        `protobuf:"varint,15,opt,name=first,proto3" json:"first,omitempty"`
 	// field. Now, It's been used only for has query.
 	Offset int32 `protobuf:"varint,16,opt,name=offset,proto3" json:"offset,omitempty"`
+	// no filter and order.
+	Sample     int32 `protobuf:"varint,17,opt,name=sample,proto3" json:"sample,omitempty"`
+	SampleSeed int64 `protobuf:"varint,18,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`
+	FuncLimit  int32 `protobuf:"varint,19,opt,name=func_limit,json=funcLimit,proto3" json:"func_limit,omitempty"`
+	// start ts of the transaction in read-committed mode whose own mutations are seen by the
+	// query, which reads the committed data at read_ts.
+	TxnStartTs uint64 `protobuf:"varint,20,opt,name=txn_start_ts,json=txnStartTs,proto3" json:"txn_start_ts,omitempty"`
 }
 
 func (m *Query) Reset()         { *m = Query{} }
@@ -642,6 +656,34 @@ func (m *Query) GetOffset() int32 {
 	return 0
 }
 
+func (m *Query) GetSample() int32 {
+	if m != nil {
+		return m.Sample
+	}
+	return 0
+}
+
+func (m *Query) GetSampleSeed() int64 {
+	if m != nil {
+		return m.SampleSeed
+	}
+	return 0
+}
+
+func (m *Query) GetFuncLimit() int32 {
+	if m != nil {
+		return m.FuncLimit
+	}
+	return 0
+}
+
+func (m *Query) GetTxnStartTs() uint64 {
+	if m != nil {
+		return m.TxnStartTs
+	}
+	return 0
+}
+
 type ValueList struct {
 	Values []*TaskValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 }
@@ -826,6 +868,10 @@ type Order struct {
 	Attr  string   `protobuf:"bytes,1,opt,name=attr,proto3" json:"attr,omitempty"`
 	Desc  bool     `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
 	Langs []string `protobuf:"bytes,3,rep,name=langs,proto3" json:"langs,omitempty"`
+	// count orders by the number of edges of attr, using its count index.
+	Count bool `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	// reverse orders by the number of reverse edges, for count(~attr).
+	Reverse bool `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`
 }
 
 func (m *Order) Reset()         { *m = Order{} }
@@ -882,6 +928,20 @@ func (m *Order) GetLangs() []string {
 	return nil
 }
 
+func (m *Order) GetCount() bool {
+	if m != nil {
+		return m.Count
+	}
+	return false
+}
+
+func (m *Order) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
 type SortMessage struct {
 	Order     []*Order `protobuf:"bytes,1,rep,name=order,proto3" json:"order,omitempty"`
 	UidMatrix []*List  `protobuf:"bytes,2,rep,name=uid_matrix,json=uidMatrix,proto3" json:"uid_matrix,omitempty"`
@@ -1091,6 +1151,8 @@ type Member struct {
 	Learner         bool   `protobuf:"varint,7,opt,name=learner,proto3" json:"learner,omitempty"`
 	ClusterInfoOnly bool   `protobuf:"varint,13,opt,name=cluster_info_only,json=clusterInfoOnly,proto3" json:"clusterInfoOnly,omitempty"`
 	ForceGroupId    bool   `protobuf:"varint,14,opt,name=force_group_id,json=forceGroupId,proto3" json:"forceGroupId,omitempty"`
+	// Region or zone the node runs in, used to route the queries to the nodes close by.
+	Region string `protobuf:"bytes,15,opt,name=region,proto3" json:"region,omitempty"`
 }
 
 func (m *Member) Reset()         { *m = Member{} }
@@ -1189,6 +1251,13 @@ func (m *Member) GetForceGroupId() bool {
 	return false
 }
 
+func (m *Member) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
 type Group struct {
 	Members      map[uint64]*Member `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Tablets      map[string]*Tablet `protobuf:"bytes,2,rep,name=tablets,proto3" json:"tablets,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -1346,8 +1415,9 @@ type ZeroProposal struct {
 	License    *License          `protobuf:"bytes,10,opt,name=license,proto3" json:"license,omitempty"`
 	Snapshot   *ZeroSnapshot     `protobuf:"bytes,11,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 	// 12 has already been used.
-	DeleteNs *DeleteNsRequest `protobuf:"bytes,13,opt,name=delete_ns,json=deleteNs,proto3" json:"delete_ns,omitempty"`
-	Tablets  []*Tablet        `protobuf:"bytes,14,rep,name=tablets,proto3" json:"tablets,omitempty"`
+	DeleteNs    *DeleteNsRequest   `protobuf:"bytes,13,opt,name=delete_ns,json=deleteNs,proto3" json:"delete_ns,omitempty"`
+	Tablets     []*Tablet          `protobuf:"bytes,14,rep,name=tablets,proto3" json:"tablets,omitempty"`
+	Maintenance *MaintenancePolicy `protobuf:"bytes,15,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
 }
 
 func (m *ZeroProposal) Reset()         { *m = ZeroProposal{} }
@@ -1474,6 +1544,13 @@ func (m *ZeroProposal) GetTablets() []*Tablet {
 	return nil
 }
 
+func (m *ZeroProposal) GetMaintenance() *MaintenancePolicy {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
 // MembershipState is used to pack together the current membership state of all
 // the nodes in the caller server; and the membership updates recorded by the
 // callee server since the provided lastUpdate.
@@ -1488,6 +1565,8 @@ type MembershipState struct {
 	Removed   []*Member          `protobuf:"bytes,7,rep,name=removed,proto3" json:"removed,omitempty"`
 	Cid       string             `protobuf:"bytes,8,opt,name=cid,proto3" json:"cid,omitempty"`
 	License   *License           `protobuf:"bytes,9,opt,name=license,proto3" json:"license,omitempty"`
+	// 10 has already been used.
+	Maintenance *MaintenancePolicy `protobuf:"bytes,11,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
 }
 
 func (m *MembershipState) Reset()         { *m = MembershipState{} }
@@ -1593,6 +1672,13 @@ func (m *MembershipState) GetLicense() *License {
 	return nil
 }
 
+func (m *MembershipState) GetMaintenance() *MaintenancePolicy {
+	if m != nil {
+		return m.Maintenance
+	}
+	return nil
+}
+
 type ConnectionState struct {
 	Member     *Member          `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
 	State      *MembershipState `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
@@ -2002,6 +2088,19 @@ type Mutations struct {
 	DropOp    Mutations_DropOp `protobuf:"varint,7,opt,name=drop_op,json=dropOp,proto3,enum=pb.Mutations_DropOp" json:"drop_op,omitempty"`
 	DropValue string           `protobuf:"bytes,8,opt,name=drop_value,json=dropValue,proto3" json:"drop_value,omitempty"`
 	Metadata  *Metadata        `protobuf:"bytes,9,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Derivations of the predicates in a schema update, sent to all the groups so that every
+	// alpha knows which predicates are derived from the ones it's mutating. Only the predicate
+	// and derived fields are set.
+	Derivations []*SchemaUpdate `protobuf:"bytes,10,rep,name=derivations,proto3" json:"derivations,omitempty"`
+	// Aliases of the predicates in a schema update, sent to all the groups so that every alpha
+	// can resolve them in queries and mutations. Only the predicate and aliases fields are set.
+	Aliases []*SchemaUpdate `protobuf:"bytes,11,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Read ts of a mutation of a transaction in read-committed mode. The mutations of the
+	// transaction read the data at the read ts of its first one, instead of its start ts.
+	ReadCommittedTs uint64 `protobuf:"varint,12,opt,name=read_committed_ts,json=readCommittedTs,proto3" json:"read_committed_ts,omitempty"`
+	// Keeps every occurrence of a value set more than once to a list predicate, with the
+	// mutation option duplicates=append. Otherwise, a list holds each of its values once.
+	AppendDuplicates bool `protobuf:"varint,13,opt,name=append_duplicates,json=appendDuplicates,proto3" json:"append_duplicates,omitempty"`
 }
 
 func (m *Mutations) Reset()         { *m = Mutations{} }
@@ -2093,6 +2192,34 @@ func (m *Mutations) GetMetadata() *Metadata {
 	return nil
 }
 
+func (m *Mutations) GetDerivations() []*SchemaUpdate {
+	if m != nil {
+		return m.Derivations
+	}
+	return nil
+}
+
+func (m *Mutations) GetAliases() []*SchemaUpdate {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *Mutations) GetReadCommittedTs() uint64 {
+	if m != nil {
+		return m.ReadCommittedTs
+	}
+	return 0
+}
+
+func (m *Mutations) GetAppendDuplicates() bool {
+	if m != nil {
+		return m.AppendDuplicates
+	}
+	return false
+}
+
 type Metadata struct {
 	// Map of predicates to their hints.
 	PredHints map[string]Metadata_HintType `protobuf:"bytes,1,rep,name=pred_hints,json=predHints,proto3" json:"pred_hints,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=pb.Metadata_HintType"`
@@ -3395,6 +3522,16 @@ type SchemaNode struct {
 	Upsert     bool     `protobuf:"varint,8,opt,name=upsert,proto3" json:"upsert,omitempty"`
 	Lang       bool     `protobuf:"varint,9,opt,name=lang,proto3" json:"lang,omitempty"`
 	NoConflict bool     `protobuf:"varint,10,opt,name=no_conflict,json=noConflict,proto3" json:"no_conflict,omitempty"`
+	Undirected bool     `protobuf:"varint,11,opt,name=undirected,proto3" json:"undirected,omitempty"`
+	Derived    []string `protobuf:"bytes,12,rep,name=derived,proto3" json:"derived,omitempty"`
+	Pattern    string   `protobuf:"bytes,13,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Aliases    []string `protobuf:"bytes,14,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// group is the id of the group serving the predicate.
+	Group             uint32   `protobuf:"varint,15,opt,name=group,proto3" json:"group,omitempty"`
+	EnumValues        []string `protobuf:"bytes,16,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	Cardinality       string   `protobuf:"bytes,17,opt,name=cardinality,proto3" json:"cardinality,omitempty"`
+	CardinalityReject bool     `protobuf:"varint,18,opt,name=cardinality_reject,json=cardinalityReject,proto3" json:"cardinality_reject,omitempty"`
+	Key               bool     `protobuf:"varint,19,opt,name=key,proto3" json:"key,omitempty"`
 }
 
 func (m *SchemaNode) Reset()         { *m = SchemaNode{} }
@@ -3500,6 +3637,69 @@ func (m *SchemaNode) GetNoConflict() bool {
 	return false
 }
 
+func (m *SchemaNode) GetUndirected() bool {
+	if m != nil {
+		return m.Undirected
+	}
+	return false
+}
+
+func (m *SchemaNode) GetDerived() []string {
+	if m != nil {
+		return m.Derived
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *SchemaNode) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetGroup() uint32 {
+	if m != nil {
+		return m.Group
+	}
+	return 0
+}
+
+func (m *SchemaNode) GetEnumValues() []string {
+	if m != nil {
+		return m.EnumValues
+	}
+	return nil
+}
+
+func (m *SchemaNode) GetCardinality() string {
+	if m != nil {
+		return m.Cardinality
+	}
+	return ""
+}
+
+func (m *SchemaNode) GetCardinalityReject() bool {
+	if m != nil {
+		return m.CardinalityReject
+	}
+	return false
+}
+
+func (m *SchemaNode) GetKey() bool {
+	if m != nil {
+		return m.Key
+	}
+	return false
+}
+
 type SchemaResult struct {
 	Schema []*SchemaNode `protobuf:"bytes,1,rep,name=schema,proto3" json:"schema,omitempty"` // Deprecated: Do not use.
 }
@@ -3561,6 +3761,31 @@ type SchemaUpdate struct {
 	// custom name. This field stores said name.
 	ObjectTypeName string `protobuf:"bytes,12,opt,name=object_type_name,json=objectTypeName,proto3" json:"object_type_name,omitempty"`
 	NoConflict     bool   `protobuf:"varint,13,opt,name=no_conflict,json=noConflict,proto3" json:"no_conflict,omitempty"`
+	// Number of digits after the decimal point, if value_type is DECIMAL. If it's 0, the values
+	// keep the digits after the decimal point they are given.
+	DecimalScale uint32 `protobuf:"varint,14,opt,name=decimal_scale,json=decimalScale,proto3" json:"decimal_scale,omitempty"`
+	// If true, an edge from A to B is also stored as an edge from B to A.
+	Undirected bool `protobuf:"varint,15,opt,name=undirected,proto3" json:"undirected,omitempty"`
+	// Versions of the plugin tokenizers the index was built with, as name=version. The index is
+	// rebuilt when a plugin with a different version is loaded.
+	TokenizerVersions []string `protobuf:"bytes,16,rep,name=tokenizer_versions,json=tokenizerVersions,proto3" json:"tokenizer_versions,omitempty"`
+	// If set, the value of the predicate is computed by the server from other predicates of the
+	// same node. Each term is either the name of a source predicate or a quoted string literal,
+	// and the value is the concatenation of the terms.
+	Derived []string `protobuf:"bytes,17,rep,name=derived,proto3" json:"derived,omitempty"`
+	// If set, the string values of the predicate must match this regular expression.
+	Pattern string `protobuf:"bytes,18,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Other names of the predicate, which are resolved to it in queries and mutations.
+	Aliases []string `protobuf:"bytes,19,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// If set, the predicate is an enum, whose string values must be one of these.
+	EnumValues []string `protobuf:"bytes,20,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	// If set, the cardinality of the uid edges of the predicate: one, one-to-one or one-to-many.
+	// The edges breaking it are replaced, or rejected if cardinality_reject is true.
+	Cardinality       string `protobuf:"bytes,21,opt,name=cardinality,proto3" json:"cardinality,omitempty"`
+	CardinalityReject bool   `protobuf:"varint,22,opt,name=cardinality_reject,json=cardinalityReject,proto3" json:"cardinality_reject,omitempty"`
+	// If true, the values of the predicate identify the nodes, which can then be given as key
+	// blank nodes, like _:(email="a@b.com").
+	Key bool `protobuf:"varint,23,opt,name=key,proto3" json:"key,omitempty"`
 }
 
 func (m *SchemaUpdate) Reset()         { *m = SchemaUpdate{} }
@@ -3680,9 +3905,87 @@ func (m *SchemaUpdate) GetNoConflict() bool {
 	return false
 }
 
+func (m *SchemaUpdate) GetDecimalScale() uint32 {
+	if m != nil {
+		return m.DecimalScale
+	}
+	return 0
+}
+
+func (m *SchemaUpdate) GetUndirected() bool {
+	if m != nil {
+		return m.Undirected
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetTokenizerVersions() []string {
+	if m != nil {
+		return m.TokenizerVersions
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetDerived() []string {
+	if m != nil {
+		return m.Derived
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *SchemaUpdate) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetEnumValues() []string {
+	if m != nil {
+		return m.EnumValues
+	}
+	return nil
+}
+
+func (m *SchemaUpdate) GetCardinality() string {
+	if m != nil {
+		return m.Cardinality
+	}
+	return ""
+}
+
+func (m *SchemaUpdate) GetCardinalityReject() bool {
+	if m != nil {
+		return m.CardinalityReject
+	}
+	return false
+}
+
+func (m *SchemaUpdate) GetKey() bool {
+	if m != nil {
+		return m.Key
+	}
+	return false
+}
+
 type TypeUpdate struct {
 	TypeName string          `protobuf:"bytes,1,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
 	Fields   []*SchemaUpdate `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Predicate, without a namespace, marking the nodes of the type as deleted when it's true.
+	// These nodes are left out of the results of queries.
+	SoftDelete string `protobuf:"bytes,3,opt,name=soft_delete,json=softDelete,proto3" json:"soft_delete,omitempty"`
+	// Predicate, without a namespace, along which the deletion of a node of the type is cascaded
+	// to the nodes it points to. Unless force is set, the nodes also referenced by nodes which
+	// aren't deleted are kept.
+	CascadeDelete      string `protobuf:"bytes,4,opt,name=cascade_delete,json=cascadeDelete,proto3" json:"cascade_delete,omitempty"`
+	CascadeDeleteForce bool   `protobuf:"varint,5,opt,name=cascade_delete_force,json=cascadeDeleteForce,proto3" json:"cascade_delete_force,omitempty"`
 }
 
 func (m *TypeUpdate) Reset()         { *m = TypeUpdate{} }
@@ -3732,6 +4035,27 @@ func (m *TypeUpdate) GetFields() []*SchemaUpdate {
 	return nil
 }
 
+func (m *TypeUpdate) GetSoftDelete() string {
+	if m != nil {
+		return m.SoftDelete
+	}
+	return ""
+}
+
+func (m *TypeUpdate) GetCascadeDelete() string {
+	if m != nil {
+		return m.CascadeDelete
+	}
+	return ""
+}
+
+func (m *TypeUpdate) GetCascadeDeleteForce() bool {
+	if m != nil {
+		return m.CascadeDeleteForce
+	}
+	return false
+}
+
 type MapHeader struct {
 	PartitionKeys [][]byte `protobuf:"bytes,1,rep,name=partition_keys,json=partitionKeys,proto3" json:"partition_keys,omitempty"`
 }
@@ -4929,6 +5253,11 @@ type ExportRequest struct {
 	SessionToken string `protobuf:"bytes,8,opt,name=session_token,json=sessionToken,proto3" json:"session_token,omitempty"`
 	Anonymous    bool   `protobuf:"varint,9,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 	Namespace    uint64 `protobuf:"varint,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// If bundle is set, the schema and data are written to a single archive which can be
+	// restored using the import admin operation.
+	Bundle bool `protobuf:"varint,11,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// ACL data is left out of bundles unless include_acl is set.
+	IncludeAcl bool `protobuf:"varint,12,opt,name=include_acl,json=includeAcl,proto3" json:"include_acl,omitempty"`
 }
 
 func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
@@ -5034,6 +5363,20 @@ func (m *ExportRequest) GetNamespace() uint64 {
 	return 0
 }
 
+func (m *ExportRequest) GetBundle() bool {
+	if m != nil {
+		return m.Bundle
+	}
+	return false
+}
+
+func (m *ExportRequest) GetIncludeAcl() bool {
+	if m != nil {
+		return m.IncludeAcl
+	}
+	return false
+}
+
 type ExportResponse struct {
 	// 0 indicates a success, and a non-zero code indicates failure
 	Code  int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -5578,72 +5921,240 @@ func (m *TaskStatusResponse) GetTaskMeta() uint64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
-	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
-	proto.RegisterEnum("pb.Metadata_HintType", Metadata_HintType_name, Metadata_HintType_value)
-	proto.RegisterEnum("pb.Posting_ValType", Posting_ValType_name, Posting_ValType_value)
-	proto.RegisterEnum("pb.Posting_PostingType", Posting_PostingType_name, Posting_PostingType_value)
-	proto.RegisterEnum("pb.SchemaUpdate_Directive", SchemaUpdate_Directive_name, SchemaUpdate_Directive_value)
-	proto.RegisterEnum("pb.NumLeaseType", NumLeaseType_name, NumLeaseType_value)
-	proto.RegisterEnum("pb.DropOperation_DropOp", DropOperation_DropOp_name, DropOperation_DropOp_value)
-	proto.RegisterEnum("pb.BackupKey_KeyType", BackupKey_KeyType_name, BackupKey_KeyType_value)
-	proto.RegisterType((*List)(nil), "pb.List")
-	proto.RegisterType((*TaskValue)(nil), "pb.TaskValue")
-	proto.RegisterType((*SrcFunction)(nil), "pb.SrcFunction")
-	proto.RegisterType((*Query)(nil), "pb.Query")
-	proto.RegisterType((*ValueList)(nil), "pb.ValueList")
-	proto.RegisterType((*LangList)(nil), "pb.LangList")
-	proto.RegisterType((*Result)(nil), "pb.Result")
-	proto.RegisterType((*Order)(nil), "pb.Order")
-	proto.RegisterType((*SortMessage)(nil), "pb.SortMessage")
-	proto.RegisterType((*SortResult)(nil), "pb.SortResult")
-	proto.RegisterType((*RaftContext)(nil), "pb.RaftContext")
-	proto.RegisterType((*Member)(nil), "pb.Member")
-	proto.RegisterType((*Group)(nil), "pb.Group")
-	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.Group.MembersEntry")
-	proto.RegisterMapType((map[string]*Tablet)(nil), "pb.Group.TabletsEntry")
-	proto.RegisterType((*License)(nil), "pb.License")
-	proto.RegisterType((*ZeroProposal)(nil), "pb.ZeroProposal")
-	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.ZeroProposal.SnapshotTsEntry")
-	proto.RegisterType((*MembershipState)(nil), "pb.MembershipState")
-	proto.RegisterMapType((map[uint32]*Group)(nil), "pb.MembershipState.GroupsEntry")
-	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.MembershipState.ZerosEntry")
-	proto.RegisterType((*ConnectionState)(nil), "pb.ConnectionState")
-	proto.RegisterType((*HealthInfo)(nil), "pb.HealthInfo")
-	proto.RegisterType((*Tablet)(nil), "pb.Tablet")
-	proto.RegisterType((*DirectedEdge)(nil), "pb.DirectedEdge")
-	proto.RegisterType((*Mutations)(nil), "pb.Mutations")
-	proto.RegisterType((*Metadata)(nil), "pb.Metadata")
-	proto.RegisterMapType((map[string]Metadata_HintType)(nil), "pb.Metadata.PredHintsEntry")
-	proto.RegisterType((*Snapshot)(nil), "pb.Snapshot")
-	proto.RegisterType((*ZeroSnapshot)(nil), "pb.ZeroSnapshot")
-	proto.RegisterType((*RestoreRequest)(nil), "pb.RestoreRequest")
-	proto.RegisterType((*Proposal)(nil), "pb.Proposal")
-	proto.RegisterType((*CDCState)(nil), "pb.CDCState")
-	proto.RegisterType((*KVS)(nil), "pb.KVS")
-	proto.RegisterType((*Posting)(nil), "pb.Posting")
-	proto.RegisterType((*UidBlock)(nil), "pb.UidBlock")
-	proto.RegisterType((*UidPack)(nil), "pb.UidPack")
-	proto.RegisterType((*PostingList)(nil), "pb.PostingList")
-	proto.RegisterType((*FacetParam)(nil), "pb.FacetParam")
-	proto.RegisterType((*FacetParams)(nil), "pb.FacetParams")
-	proto.RegisterType((*Facets)(nil), "pb.Facets")
-	proto.RegisterType((*FacetsList)(nil), "pb.FacetsList")
-	proto.RegisterType((*Function)(nil), "pb.Function")
-	proto.RegisterType((*FilterTree)(nil), "pb.FilterTree")
-	proto.RegisterType((*SchemaRequest)(nil), "pb.SchemaRequest")
-	proto.RegisterType((*SchemaNode)(nil), "pb.SchemaNode")
-	proto.RegisterType((*SchemaResult)(nil), "pb.SchemaResult")
-	proto.RegisterType((*SchemaUpdate)(nil), "pb.SchemaUpdate")
-	proto.RegisterType((*TypeUpdate)(nil), "pb.TypeUpdate")
-	proto.RegisterType((*MapHeader)(nil), "pb.MapHeader")
-	proto.RegisterType((*MovePredicatePayload)(nil), "pb.MovePredicatePayload")
-	proto.RegisterType((*TxnStatus)(nil), "pb.TxnStatus")
-	proto.RegisterType((*OracleDelta)(nil), "pb.OracleDelta")
-	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.OracleDelta.GroupChecksumsEntry")
-	proto.RegisterType((*TxnTimestamps)(nil), "pb.TxnTimestamps")
-	proto.RegisterType((*PeerResponse)(nil), "pb.PeerResponse")
+type HistoryRequest struct {
+	Attr   string `protobuf:"bytes,1,opt,name=attr,proto3" json:"attr,omitempty"`
+	Uid    uint64 `protobuf:"fixed64,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	ReadTs uint64 `protobuf:"varint,3,opt,name=read_ts,json=readTs,proto3" json:"read_ts,omitempty"`
+}
+
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{74}
+}
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(m, src)
+}
+func (m *HistoryRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
+
+func (m *HistoryRequest) GetAttr() string {
+	if m != nil {
+		return m.Attr
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetUid() uint64 {
+	if m != nil {
+		return m.Uid
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetReadTs() uint64 {
+	if m != nil {
+		return m.ReadTs
+	}
+	return 0
+}
+
+type HistoryResult struct {
+	// The values of the predicate after each commit which changed them, from the oldest to the
+	// newest. Only the commit_ts and postings fields are set.
+	Versions []*PostingList `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"`
+	List     bool           `protobuf:"varint,2,opt,name=list,proto3" json:"list,omitempty"`
+}
+
+func (m *HistoryResult) Reset()         { *m = HistoryResult{} }
+func (m *HistoryResult) String() string { return proto.CompactTextString(m) }
+func (*HistoryResult) ProtoMessage()    {}
+func (*HistoryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{75}
+}
+func (m *HistoryResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HistoryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HistoryResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HistoryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryResult.Merge(m, src)
+}
+func (m *HistoryResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *HistoryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryResult proto.InternalMessageInfo
+
+func (m *HistoryResult) GetVersions() []*PostingList {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+func (m *HistoryResult) GetList() bool {
+	if m != nil {
+		return m.List
+	}
+	return false
+}
+
+type MaintenancePolicy struct {
+	// The window of the day in UTC, e.g. "22:00-06:00", during which the heavy operations can be
+	// started. They can be started at any time if it's empty.
+	Window   string `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	Override bool   `protobuf:"varint,2,opt,name=override,proto3" json:"override,omitempty"`
+}
+
+func (m *MaintenancePolicy) Reset()         { *m = MaintenancePolicy{} }
+func (m *MaintenancePolicy) String() string { return proto.CompactTextString(m) }
+func (*MaintenancePolicy) ProtoMessage()    {}
+func (*MaintenancePolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{76}
+}
+func (m *MaintenancePolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MaintenancePolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MaintenancePolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MaintenancePolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaintenancePolicy.Merge(m, src)
+}
+func (m *MaintenancePolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *MaintenancePolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaintenancePolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaintenancePolicy proto.InternalMessageInfo
+
+func (m *MaintenancePolicy) GetWindow() string {
+	if m != nil {
+		return m.Window
+	}
+	return ""
+}
+
+func (m *MaintenancePolicy) GetOverride() bool {
+	if m != nil {
+		return m.Override
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterEnum("pb.DirectedEdge_Op", DirectedEdge_Op_name, DirectedEdge_Op_value)
+	proto.RegisterEnum("pb.Mutations_DropOp", Mutations_DropOp_name, Mutations_DropOp_value)
+	proto.RegisterEnum("pb.Metadata_HintType", Metadata_HintType_name, Metadata_HintType_value)
+	proto.RegisterEnum("pb.Posting_ValType", Posting_ValType_name, Posting_ValType_value)
+	proto.RegisterEnum("pb.Posting_PostingType", Posting_PostingType_name, Posting_PostingType_value)
+	proto.RegisterEnum("pb.SchemaUpdate_Directive", SchemaUpdate_Directive_name, SchemaUpdate_Directive_value)
+	proto.RegisterEnum("pb.NumLeaseType", NumLeaseType_name, NumLeaseType_value)
+	proto.RegisterEnum("pb.DropOperation_DropOp", DropOperation_DropOp_name, DropOperation_DropOp_value)
+	proto.RegisterEnum("pb.BackupKey_KeyType", BackupKey_KeyType_name, BackupKey_KeyType_value)
+	proto.RegisterType((*List)(nil), "pb.List")
+	proto.RegisterType((*TaskValue)(nil), "pb.TaskValue")
+	proto.RegisterType((*SrcFunction)(nil), "pb.SrcFunction")
+	proto.RegisterType((*Query)(nil), "pb.Query")
+	proto.RegisterType((*ValueList)(nil), "pb.ValueList")
+	proto.RegisterType((*LangList)(nil), "pb.LangList")
+	proto.RegisterType((*Result)(nil), "pb.Result")
+	proto.RegisterType((*Order)(nil), "pb.Order")
+	proto.RegisterType((*SortMessage)(nil), "pb.SortMessage")
+	proto.RegisterType((*SortResult)(nil), "pb.SortResult")
+	proto.RegisterType((*RaftContext)(nil), "pb.RaftContext")
+	proto.RegisterType((*Member)(nil), "pb.Member")
+	proto.RegisterType((*Group)(nil), "pb.Group")
+	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.Group.MembersEntry")
+	proto.RegisterMapType((map[string]*Tablet)(nil), "pb.Group.TabletsEntry")
+	proto.RegisterType((*License)(nil), "pb.License")
+	proto.RegisterType((*ZeroProposal)(nil), "pb.ZeroProposal")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.ZeroProposal.SnapshotTsEntry")
+	proto.RegisterType((*MembershipState)(nil), "pb.MembershipState")
+	proto.RegisterMapType((map[uint32]*Group)(nil), "pb.MembershipState.GroupsEntry")
+	proto.RegisterMapType((map[uint64]*Member)(nil), "pb.MembershipState.ZerosEntry")
+	proto.RegisterType((*ConnectionState)(nil), "pb.ConnectionState")
+	proto.RegisterType((*HealthInfo)(nil), "pb.HealthInfo")
+	proto.RegisterType((*Tablet)(nil), "pb.Tablet")
+	proto.RegisterType((*DirectedEdge)(nil), "pb.DirectedEdge")
+	proto.RegisterType((*Mutations)(nil), "pb.Mutations")
+	proto.RegisterType((*Metadata)(nil), "pb.Metadata")
+	proto.RegisterMapType((map[string]Metadata_HintType)(nil), "pb.Metadata.PredHintsEntry")
+	proto.RegisterType((*Snapshot)(nil), "pb.Snapshot")
+	proto.RegisterType((*ZeroSnapshot)(nil), "pb.ZeroSnapshot")
+	proto.RegisterType((*RestoreRequest)(nil), "pb.RestoreRequest")
+	proto.RegisterType((*Proposal)(nil), "pb.Proposal")
+	proto.RegisterType((*CDCState)(nil), "pb.CDCState")
+	proto.RegisterType((*KVS)(nil), "pb.KVS")
+	proto.RegisterType((*Posting)(nil), "pb.Posting")
+	proto.RegisterType((*UidBlock)(nil), "pb.UidBlock")
+	proto.RegisterType((*UidPack)(nil), "pb.UidPack")
+	proto.RegisterType((*PostingList)(nil), "pb.PostingList")
+	proto.RegisterType((*FacetParam)(nil), "pb.FacetParam")
+	proto.RegisterType((*FacetParams)(nil), "pb.FacetParams")
+	proto.RegisterType((*Facets)(nil), "pb.Facets")
+	proto.RegisterType((*FacetsList)(nil), "pb.FacetsList")
+	proto.RegisterType((*Function)(nil), "pb.Function")
+	proto.RegisterType((*FilterTree)(nil), "pb.FilterTree")
+	proto.RegisterType((*SchemaRequest)(nil), "pb.SchemaRequest")
+	proto.RegisterType((*SchemaNode)(nil), "pb.SchemaNode")
+	proto.RegisterType((*SchemaResult)(nil), "pb.SchemaResult")
+	proto.RegisterType((*SchemaUpdate)(nil), "pb.SchemaUpdate")
+	proto.RegisterType((*TypeUpdate)(nil), "pb.TypeUpdate")
+	proto.RegisterType((*MapHeader)(nil), "pb.MapHeader")
+	proto.RegisterType((*MovePredicatePayload)(nil), "pb.MovePredicatePayload")
+	proto.RegisterType((*TxnStatus)(nil), "pb.TxnStatus")
+	proto.RegisterType((*OracleDelta)(nil), "pb.OracleDelta")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "pb.OracleDelta.GroupChecksumsEntry")
+	proto.RegisterType((*TxnTimestamps)(nil), "pb.TxnTimestamps")
+	proto.RegisterType((*PeerResponse)(nil), "pb.PeerResponse")
 	proto.RegisterType((*RaftBatch)(nil), "pb.RaftBatch")
 	proto.RegisterType((*TabletResponse)(nil), "pb.TabletResponse")
 	proto.RegisterType((*TabletRequest)(nil), "pb.TabletRequest")
@@ -5670,351 +6181,391 @@ func init() {
 	proto.RegisterType((*DeleteNsRequest)(nil), "pb.DeleteNsRequest")
 	proto.RegisterType((*TaskStatusRequest)(nil), "pb.TaskStatusRequest")
 	proto.RegisterType((*TaskStatusResponse)(nil), "pb.TaskStatusResponse")
+	proto.RegisterType((*HistoryRequest)(nil), "pb.HistoryRequest")
+	proto.RegisterType((*HistoryResult)(nil), "pb.HistoryResult")
+	proto.RegisterType((*MaintenancePolicy)(nil), "pb.MaintenancePolicy")
 }
 
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 5418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4b, 0x8f, 0x1c, 0x69,
-	0x52, 0x5d, 0xef, 0xca, 0xa8, 0x87, 0xab, 0x3f, 0x7b, 0x3c, 0xb5, 0xe5, 0x19, 0xdb, 0x93, 0x1e,
-	0xcf, 0xf4, 0xd8, 0xe3, 0xb6, 0xdd, 0xde, 0x85, 0x9d, 0x59, 0xad, 0x44, 0x3f, 0xaa, 0x3d, 0x3d,
-	0x6e, 0x77, 0x7b, 0xb3, 0xcb, 0xde, 0x87, 0x04, 0xa5, 0xec, 0xcc, 0xaf, 0xbb, 0x73, 0x3b, 0x2b,
-	0x33, 0x37, 0x33, 0xab, 0xb7, 0x7b, 0x6e, 0x08, 0x89, 0xbd, 0x70, 0x58, 0x89, 0x0b, 0x27, 0x0e,
-	0x1c, 0xb8, 0xc0, 0x05, 0x04, 0x82, 0x0b, 0x37, 0x84, 0x10, 0xa7, 0x3d, 0x82, 0x80, 0x11, 0x9a,
-	0x45, 0x1c, 0x7c, 0x40, 0x42, 0xfc, 0x01, 0x14, 0x11, 0x5f, 0xbe, 0xaa, 0xab, 0x6d, 0xcf, 0x22,
-	0x0e, 0x9c, 0xea, 0x8b, 0xf8, 0xde, 0xf1, 0xc5, 0x3b, 0xb2, 0xa0, 0x19, 0xec, 0x2f, 0x07, 0xa1,
-	0x1f, 0xfb, 0xa2, 0x1c, 0xec, 0x0f, 0x34, 0x33, 0x70, 0x18, 0x1c, 0xdc, 0x39, 0x74, 0xe2, 0xa3,
-	0xe9, 0xfe, 0xb2, 0xe5, 0x4f, 0xee, 0xdb, 0x87, 0xa1, 0x19, 0x1c, 0xdd, 0x73, 0xfc, 0xfb, 0xfb,
-	0xa6, 0x7d, 0x28, 0xc3, 0xfb, 0x27, 0x8f, 0xee, 0x07, 0xfb, 0xf7, 0x93, 0xa9, 0x83, 0x7b, 0xb9,
-	0xb1, 0x87, 0xfe, 0xa1, 0x7f, 0x9f, 0xd0, 0xfb, 0xd3, 0x03, 0x82, 0x08, 0xa0, 0x16, 0x0f, 0xd7,
-	0x07, 0x50, 0xdd, 0x76, 0xa2, 0x58, 0x08, 0xa8, 0x4e, 0x1d, 0x3b, 0xea, 0x97, 0x6e, 0x56, 0x96,
-	0xea, 0x06, 0xb5, 0xf5, 0xa7, 0xa0, 0x8d, 0xcc, 0xe8, 0xf8, 0x85, 0xe9, 0x4e, 0xa5, 0xe8, 0x41,
-	0xe5, 0xc4, 0x74, 0xfb, 0xa5, 0x9b, 0xa5, 0xa5, 0xb6, 0x81, 0x4d, 0xb1, 0x0c, 0xcd, 0x13, 0xd3,
-	0x1d, 0xc7, 0x67, 0x81, 0xec, 0x97, 0x6f, 0x96, 0x96, 0xba, 0x2b, 0x97, 0x97, 0x83, 0xfd, 0xe5,
-	0x67, 0x7e, 0x14, 0x3b, 0xde, 0xe1, 0xf2, 0x0b, 0xd3, 0x1d, 0x9d, 0x05, 0xd2, 0x68, 0x9c, 0x70,
-	0x43, 0xdf, 0x85, 0xd6, 0x5e, 0x68, 0x6d, 0x4e, 0x3d, 0x2b, 0x76, 0x7c, 0x0f, 0x77, 0xf4, 0xcc,
-	0x89, 0xa4, 0x15, 0x35, 0x83, 0xda, 0x88, 0x33, 0xc3, 0xc3, 0xa8, 0x5f, 0xb9, 0x59, 0x41, 0x1c,
-	0xb6, 0x45, 0x1f, 0x1a, 0x4e, 0xb4, 0xee, 0x4f, 0xbd, 0xb8, 0x5f, 0xbd, 0x59, 0x5a, 0x6a, 0x1a,
-	0x09, 0xa8, 0xff, 0x55, 0x05, 0x6a, 0xdf, 0x9b, 0xca, 0xf0, 0x8c, 0xe6, 0xc5, 0x71, 0x98, 0xac,
-	0x85, 0x6d, 0x71, 0x05, 0x6a, 0xae, 0xe9, 0x1d, 0x46, 0xfd, 0x32, 0x2d, 0xc6, 0x80, 0xb8, 0x06,
-	0x9a, 0x79, 0x10, 0xcb, 0x70, 0x3c, 0x75, 0xec, 0x7e, 0xe5, 0x66, 0x69, 0xa9, 0x6e, 0x34, 0x09,
-	0xf1, 0xdc, 0xb1, 0xc5, 0x37, 0xa0, 0x69, 0xfb, 0x63, 0x2b, 0xbf, 0x97, 0xed, 0xd3, 0x5e, 0xe2,
-	0x16, 0x34, 0xa7, 0x8e, 0x3d, 0x76, 0x9d, 0x28, 0xee, 0xd7, 0x6e, 0x96, 0x96, 0x5a, 0x2b, 0x4d,
-	0xbc, 0x2c, 0xd2, 0xce, 0x68, 0x4c, 0x1d, 0x9b, 0x88, 0x78, 0x07, 0x9a, 0x51, 0x68, 0x8d, 0x0f,
-	0xa6, 0x9e, 0xd5, 0xaf, 0xd3, 0xa0, 0x4b, 0x38, 0x28, 0x77, 0x6b, 0xa3, 0x11, 0x31, 0x80, 0xd7,
-	0x0a, 0xe5, 0x89, 0x0c, 0x23, 0xd9, 0x6f, 0xf0, 0x56, 0x0a, 0x14, 0x0f, 0xa0, 0x75, 0x60, 0x5a,
-	0x32, 0x1e, 0x07, 0x66, 0x68, 0x4e, 0xfa, 0xcd, 0x6c, 0xa1, 0x4d, 0x44, 0x3f, 0x43, 0x6c, 0x64,
-	0xc0, 0x41, 0x0a, 0x88, 0x47, 0xd0, 0x21, 0x28, 0x1a, 0x1f, 0x38, 0x6e, 0x2c, 0xc3, 0xbe, 0x46,
-	0x73, 0xba, 0x34, 0x87, 0x30, 0xa3, 0x50, 0x4a, 0xa3, 0xcd, 0x83, 0x18, 0x23, 0xde, 0x05, 0x90,
-	0xa7, 0x81, 0xe9, 0xd9, 0x63, 0xd3, 0x75, 0xfb, 0x40, 0x67, 0xd0, 0x18, 0xb3, 0xea, 0xba, 0xe2,
-	0x6d, 0x3c, 0x9f, 0x69, 0x8f, 0xe3, 0xa8, 0xdf, 0xb9, 0x59, 0x5a, 0xaa, 0x1a, 0x75, 0x04, 0x47,
-	0x11, 0xd2, 0xd5, 0x32, 0xad, 0x23, 0xd9, 0xef, 0xde, 0x2c, 0x2d, 0xd5, 0x0c, 0x06, 0x10, 0x7b,
-	0xe0, 0x84, 0x51, 0xdc, 0xbf, 0xc4, 0x58, 0x02, 0xc4, 0x55, 0xa8, 0xfb, 0x07, 0x07, 0x91, 0x8c,
-	0xfb, 0x3d, 0x42, 0x2b, 0x48, 0x5f, 0x01, 0x8d, 0xb8, 0x8a, 0xa8, 0x76, 0x1b, 0xea, 0x27, 0x08,
-	0x30, 0xf3, 0xb5, 0x56, 0x3a, 0x78, 0xec, 0x94, 0xf1, 0x0c, 0xd5, 0xa9, 0x5f, 0x87, 0xe6, 0xb6,
-	0xe9, 0x1d, 0x26, 0xdc, 0x8a, 0xcf, 0x49, 0x13, 0x34, 0x83, 0xda, 0xfa, 0x1f, 0x94, 0xa1, 0x6e,
-	0xc8, 0x68, 0xea, 0xc6, 0xe2, 0x43, 0x00, 0x7c, 0xac, 0x89, 0x19, 0x87, 0xce, 0xa9, 0x5a, 0x35,
-	0x7b, 0x2e, 0x6d, 0xea, 0xd8, 0x4f, 0xa9, 0x4b, 0x3c, 0x80, 0x36, 0xad, 0x9e, 0x0c, 0x2d, 0x67,
-	0x07, 0x48, 0xcf, 0x67, 0xb4, 0x68, 0x88, 0x9a, 0x71, 0x15, 0xea, 0xc4, 0x1f, 0xcc, 0xa3, 0x1d,
-	0x43, 0x41, 0xe2, 0x36, 0x74, 0x1d, 0x2f, 0xc6, 0xf7, 0xb3, 0xe2, 0xb1, 0x2d, 0xa3, 0x84, 0x81,
-	0x3a, 0x29, 0x76, 0x43, 0x46, 0xb1, 0x78, 0x08, 0xfc, 0x08, 0xc9, 0x86, 0x35, 0xda, 0xb0, 0x9b,
-	0x3e, 0x6e, 0xc4, 0x3b, 0xd2, 0x18, 0xb5, 0xe3, 0x3d, 0x68, 0xe1, 0xfd, 0x92, 0x19, 0x75, 0x9a,
-	0xd1, 0xa6, 0xdb, 0x28, 0x72, 0x18, 0x80, 0x03, 0xd4, 0x70, 0x24, 0x0d, 0x32, 0x29, 0x33, 0x15,
-	0xb5, 0xf5, 0x21, 0xd4, 0x76, 0x43, 0x5b, 0x86, 0x73, 0xe5, 0x44, 0x40, 0xd5, 0x96, 0x91, 0x45,
-	0x22, 0xdc, 0x34, 0xa8, 0x9d, 0xc9, 0x4e, 0x25, 0x27, 0x3b, 0xfa, 0x1f, 0x96, 0xa0, 0xb5, 0xe7,
-	0x87, 0xf1, 0x53, 0x19, 0x45, 0xe6, 0xa1, 0x14, 0x37, 0xa0, 0xe6, 0xe3, 0xb2, 0x8a, 0xc2, 0x1a,
-	0x9e, 0x89, 0xf6, 0x31, 0x18, 0x3f, 0xf3, 0x0e, 0xe5, 0x8b, 0xdf, 0x01, 0x79, 0x8a, 0xa4, 0xae,
-	0xa2, 0x78, 0x8a, 0x64, 0x2e, 0xe3, 0x9e, 0x6a, 0x9e, 0x7b, 0x2e, 0x64, 0x4d, 0xfd, 0x5b, 0x00,
-	0x78, 0xbe, 0xaf, 0xc9, 0x05, 0xfa, 0xcf, 0x4a, 0xd0, 0x32, 0xcc, 0x83, 0x78, 0xdd, 0xf7, 0x62,
-	0x79, 0x1a, 0x8b, 0x2e, 0x94, 0x1d, 0x9b, 0x68, 0x54, 0x37, 0xca, 0x8e, 0x8d, 0xa7, 0x3b, 0x0c,
-	0xfd, 0x69, 0x40, 0x24, 0xea, 0x18, 0x0c, 0x10, 0x2d, 0x6d, 0x3b, 0xa4, 0x23, 0x23, 0x2d, 0x6d,
-	0x3b, 0x14, 0x37, 0xa0, 0x15, 0x79, 0x66, 0x10, 0x1d, 0xf9, 0x31, 0x9e, 0xae, 0x4a, 0xa7, 0x83,
-	0x04, 0x35, 0x8a, 0x50, 0xe8, 0x9c, 0x68, 0xec, 0x4a, 0x33, 0xf4, 0x64, 0x48, 0x8a, 0xa4, 0x69,
-	0x68, 0x4e, 0xb4, 0xcd, 0x08, 0xfd, 0x67, 0x15, 0xa8, 0x3f, 0x95, 0x93, 0x7d, 0x19, 0x9e, 0x3b,
-	0xc4, 0x03, 0x68, 0xd2, 0xbe, 0x63, 0xc7, 0xe6, 0x73, 0xac, 0xbd, 0xf5, 0xf2, 0xcb, 0x1b, 0x8b,
-	0x84, 0xdb, 0xb2, 0x3f, 0xf6, 0x27, 0x4e, 0x2c, 0x27, 0x41, 0x7c, 0x66, 0x34, 0x14, 0x6a, 0xee,
-	0x01, 0xaf, 0x42, 0xdd, 0x95, 0x26, 0xbe, 0x19, 0xb3, 0xa7, 0x82, 0xc4, 0x3d, 0x68, 0x98, 0x93,
-	0xb1, 0x2d, 0x4d, 0x9b, 0x0f, 0xb5, 0x76, 0xe5, 0xe5, 0x97, 0x37, 0x7a, 0xe6, 0x64, 0x43, 0x9a,
-	0xf9, 0xb5, 0xeb, 0x8c, 0x11, 0x9f, 0x20, 0x4f, 0x46, 0xf1, 0x78, 0x1a, 0xd8, 0x66, 0x2c, 0x49,
-	0xd7, 0x55, 0xd7, 0xfa, 0x2f, 0xbf, 0xbc, 0x71, 0x05, 0xd1, 0xcf, 0x09, 0x9b, 0x9b, 0x06, 0x19,
-	0x16, 0xf5, 0x5e, 0x72, 0x7d, 0xa5, 0xf7, 0x14, 0x28, 0xb6, 0x60, 0xd1, 0x72, 0xa7, 0x11, 0x2a,
-	0x67, 0xc7, 0x3b, 0xf0, 0xc7, 0xbe, 0xe7, 0x9e, 0xd1, 0x03, 0x37, 0xd7, 0xde, 0x7d, 0xf9, 0xe5,
-	0x8d, 0x6f, 0xa8, 0xce, 0x2d, 0xef, 0xc0, 0xdf, 0xf5, 0xdc, 0xb3, 0xdc, 0xfa, 0x97, 0x66, 0xba,
-	0xc4, 0x6f, 0x40, 0xf7, 0xc0, 0x0f, 0x2d, 0x39, 0x4e, 0x49, 0xd6, 0xa5, 0x75, 0x06, 0x2f, 0xbf,
-	0xbc, 0x71, 0x95, 0x7a, 0x1e, 0x9f, 0xa3, 0x5b, 0x3b, 0x8f, 0xd7, 0xff, 0xb5, 0x0c, 0x35, 0x6a,
-	0x8b, 0x07, 0xd0, 0x98, 0xd0, 0x93, 0x24, 0xfa, 0xe9, 0x2a, 0xf2, 0x10, 0xf5, 0x2d, 0xf3, 0x5b,
-	0x45, 0x43, 0x2f, 0x0e, 0xcf, 0x8c, 0x64, 0x18, 0xce, 0x88, 0xcd, 0x7d, 0x57, 0xc6, 0x91, 0xe2,
-	0xf9, 0xdc, 0x8c, 0x11, 0x77, 0xa8, 0x19, 0x6a, 0xd8, 0x2c, 0xdf, 0x54, 0xce, 0xf1, 0xcd, 0x00,
-	0x9a, 0xd6, 0x91, 0xb4, 0x8e, 0xa3, 0xe9, 0x44, 0x71, 0x55, 0x0a, 0x8b, 0x5b, 0xd0, 0xa1, 0x76,
-	0xe0, 0x3b, 0x1e, 0x4d, 0xaf, 0xd1, 0x80, 0x76, 0x86, 0x1c, 0x45, 0x83, 0x4d, 0x68, 0xe7, 0x0f,
-	0x8b, 0xe6, 0xfc, 0x58, 0x9e, 0x11, 0x7f, 0x55, 0x0d, 0x6c, 0x8a, 0x9b, 0x50, 0x23, 0x45, 0x47,
-	0xdc, 0xd5, 0x5a, 0x01, 0x3c, 0x33, 0x4f, 0x31, 0xb8, 0xe3, 0xd3, 0xf2, 0xb7, 0x4b, 0xb8, 0x4e,
-	0xfe, 0x0a, 0xf9, 0x75, 0xb4, 0x8b, 0xd7, 0xe1, 0x29, 0xb9, 0x75, 0x74, 0x1f, 0x1a, 0xdb, 0x8e,
-	0x25, 0xbd, 0x88, 0x8c, 0xfe, 0x34, 0x92, 0xa9, 0x52, 0xc2, 0x36, 0xde, 0x77, 0x62, 0x9e, 0xee,
-	0xf8, 0xb6, 0x8c, 0x68, 0x9d, 0xaa, 0x91, 0xc2, 0xd8, 0x27, 0x4f, 0x03, 0x27, 0x3c, 0x1b, 0x31,
-	0xa5, 0x2a, 0x46, 0x0a, 0x23, 0x77, 0x49, 0x0f, 0x37, 0xb3, 0x13, 0x03, 0xae, 0x40, 0xfd, 0x4f,
-	0xab, 0xd0, 0xfe, 0x91, 0x0c, 0xfd, 0x67, 0xa1, 0x1f, 0xf8, 0x91, 0xe9, 0x8a, 0xd5, 0x22, 0xcd,
-	0xf9, 0x6d, 0x6f, 0xe2, 0x69, 0xf3, 0xc3, 0x96, 0xf7, 0xd2, 0x47, 0xe0, 0x37, 0xcb, 0xbf, 0x8a,
-	0x0e, 0x75, 0x7e, 0xf3, 0x39, 0x34, 0x53, 0x3d, 0x38, 0x86, 0x5f, 0x99, 0xce, 0x5a, 0xa4, 0x87,
-	0xea, 0x41, 0xa9, 0x9c, 0x98, 0xa7, 0xcf, 0xb7, 0x36, 0xd4, 0xdb, 0x2a, 0x48, 0x51, 0x61, 0x74,
-	0xea, 0x8d, 0x92, 0x47, 0x4d, 0x61, 0xbc, 0x29, 0x52, 0x24, 0xda, 0xda, 0xe8, 0xb7, 0xa9, 0x2b,
-	0x01, 0xc5, 0x3b, 0xa0, 0x4d, 0xcc, 0x53, 0x54, 0x68, 0x5b, 0x36, 0x8b, 0xa6, 0x91, 0x21, 0xc4,
-	0x7b, 0x50, 0x89, 0x4f, 0x3d, 0x92, 0x3d, 0xf4, 0x2a, 0xd0, 0xc9, 0x1c, 0x9d, 0x7a, 0x4a, 0xf5,
-	0x19, 0xd8, 0x87, 0x6f, 0x6a, 0x39, 0x36, 0x39, 0x11, 0x9a, 0x81, 0x4d, 0x71, 0x1b, 0x1a, 0x2e,
-	0xbf, 0x16, 0x39, 0x0a, 0xad, 0x95, 0x16, 0xeb, 0x51, 0x42, 0x19, 0x49, 0x9f, 0xf8, 0x18, 0x9a,
-	0x09, 0x75, 0xfa, 0x2d, 0x1a, 0xd7, 0x4b, 0xe8, 0x99, 0x90, 0xd1, 0x48, 0x47, 0x88, 0x07, 0xa0,
-	0xd9, 0xd2, 0x95, 0xb1, 0x1c, 0x7b, 0xac, 0xc8, 0x5b, 0xec, 0x40, 0x6e, 0x10, 0x72, 0x27, 0x32,
-	0xe4, 0x4f, 0xa6, 0x32, 0x8a, 0x8d, 0xa6, 0xad, 0x10, 0xe2, 0xfd, 0x4c, 0xb0, 0xba, 0xf4, 0x5c,
-	0x79, 0x62, 0x26, 0x5d, 0x83, 0xef, 0xc2, 0xa5, 0x99, 0x47, 0xcb, 0x73, 0x69, 0x87, 0xb9, 0xf4,
-	0x4a, 0x9e, 0x4b, 0xab, 0x39, 0xce, 0xfc, 0xbc, 0xda, 0x6c, 0xf6, 0x34, 0xfd, 0xbf, 0x2a, 0x70,
-	0x49, 0x09, 0xcc, 0x91, 0x13, 0xec, 0xc5, 0x4a, 0x75, 0x91, 0x61, 0x52, 0xbc, 0x5a, 0x35, 0x12,
-	0x50, 0xfc, 0x3a, 0xd4, 0x49, 0xd3, 0x24, 0x02, 0x7f, 0x23, 0x63, 0x84, 0x74, 0x3a, 0x2b, 0x00,
-	0xc5, 0x45, 0x6a, 0xb8, 0xf8, 0x26, 0xd4, 0xbe, 0x90, 0xa1, 0xcf, 0x86, 0xb6, 0xb5, 0x72, 0x7d,
-	0xde, 0x3c, 0x24, 0x9f, 0x9a, 0xc6, 0x83, 0xff, 0xb7, 0xfc, 0x02, 0x5f, 0x87, 0x5f, 0xde, 0x47,
-	0x63, 0x3b, 0xf1, 0x4f, 0xa4, 0xdd, 0x6f, 0x64, 0x34, 0x57, 0x4c, 0x9e, 0x74, 0x25, 0x2c, 0xd3,
-	0x9c, 0xcb, 0x32, 0xda, 0xc5, 0x2c, 0x33, 0xd8, 0x80, 0x56, 0x8e, 0x2e, 0x73, 0x1e, 0xea, 0x46,
-	0x51, 0x9d, 0x68, 0xa9, 0x2a, 0xcd, 0x6b, 0xa5, 0x0d, 0x80, 0x8c, 0x4a, 0xbf, 0xaa, 0x6e, 0xd3,
-	0x7f, 0xbb, 0x04, 0x97, 0xd6, 0x7d, 0xcf, 0x93, 0xe4, 0xaa, 0xf3, 0x9b, 0x67, 0x22, 0x5e, 0xba,
-	0x50, 0xc4, 0x3f, 0x82, 0x5a, 0x84, 0x83, 0xd5, 0xea, 0x97, 0xe7, 0x3c, 0xa2, 0xc1, 0x23, 0x50,
-	0xd1, 0x4f, 0xcc, 0xd3, 0x71, 0x20, 0x3d, 0xdb, 0xf1, 0x0e, 0x13, 0x45, 0x3f, 0x31, 0x4f, 0x9f,
-	0x31, 0x46, 0xff, 0xeb, 0x32, 0xc0, 0x67, 0xd2, 0x74, 0xe3, 0x23, 0x34, 0x66, 0xf8, 0xa2, 0x8e,
-	0x17, 0xc5, 0xa6, 0x67, 0x25, 0x81, 0x52, 0x0a, 0xe3, 0x8b, 0xa2, 0x4d, 0x97, 0x11, 0xab, 0x48,
-	0xcd, 0x48, 0x40, 0xe4, 0x0f, 0xdc, 0x6e, 0x1a, 0x29, 0xdb, 0xaf, 0xa0, 0xcc, 0x91, 0xa9, 0x12,
-	0x5a, 0x39, 0x32, 0x7d, 0x68, 0x60, 0xe0, 0xe1, 0xf8, 0x1e, 0x31, 0x8d, 0x66, 0x24, 0x20, 0xae,
-	0x33, 0x0d, 0x62, 0x67, 0xc2, 0x16, 0xbe, 0x62, 0x28, 0x08, 0x4f, 0x85, 0x16, 0x7d, 0x68, 0x1d,
-	0xf9, 0xa4, 0x48, 0x2a, 0x46, 0x0a, 0xe3, 0x6a, 0xbe, 0x77, 0xe8, 0xe3, 0xed, 0x9a, 0xe4, 0x3c,
-	0x26, 0x20, 0xdf, 0xc5, 0x96, 0xa7, 0xd8, 0xa5, 0x51, 0x57, 0x0a, 0x23, 0x5d, 0xa4, 0x1c, 0x1f,
-	0x48, 0x33, 0x9e, 0x86, 0x32, 0xea, 0x03, 0x75, 0x83, 0x94, 0x9b, 0x0a, 0x23, 0xde, 0x83, 0x36,
-	0x12, 0xce, 0x8c, 0x22, 0xe7, 0xd0, 0x93, 0x36, 0xa9, 0x97, 0xaa, 0x81, 0xc4, 0x5c, 0x55, 0x28,
-	0xfd, 0x6f, 0xca, 0x50, 0x67, 0x5d, 0x50, 0x70, 0x96, 0x4a, 0x6f, 0xe4, 0x2c, 0xbd, 0x03, 0x5a,
-	0x10, 0x4a, 0xdb, 0xb1, 0x92, 0x77, 0xd4, 0x8c, 0x0c, 0x41, 0xd1, 0x0d, 0x7a, 0x07, 0x44, 0xcf,
-	0xa6, 0xc1, 0x80, 0xd0, 0xa1, 0xe3, 0x7b, 0x63, 0xdb, 0x89, 0x8e, 0xc7, 0xfb, 0x67, 0xb1, 0x8c,
-	0x14, 0x2d, 0x5a, 0xbe, 0xb7, 0xe1, 0x44, 0xc7, 0x6b, 0x88, 0x42, 0x12, 0xb2, 0x8c, 0x90, 0x6c,
-	0x34, 0x0d, 0x05, 0x89, 0x47, 0xa0, 0x91, 0x0f, 0x4b, 0x4e, 0x8e, 0x46, 0xce, 0xc9, 0xd5, 0x97,
-	0x5f, 0xde, 0x10, 0x88, 0x9c, 0xf1, 0x6e, 0x9a, 0x09, 0x0e, 0xbd, 0x34, 0x9c, 0x8c, 0xe6, 0x8a,
-	0x64, 0x98, 0xbd, 0x34, 0x44, 0x8d, 0xa2, 0xbc, 0x97, 0xc6, 0x18, 0x71, 0x0f, 0xc4, 0xd4, 0xb3,
-	0xfc, 0x49, 0x80, 0x4c, 0x21, 0x6d, 0x75, 0xc8, 0x16, 0x1d, 0x72, 0x31, 0xdf, 0x43, 0x47, 0xd5,
-	0xff, 0xa5, 0x0c, 0xed, 0x0d, 0x27, 0x94, 0x56, 0x2c, 0xed, 0xa1, 0x7d, 0x28, 0xf1, 0xec, 0xd2,
-	0x8b, 0x9d, 0xf8, 0x4c, 0xb9, 0xa1, 0x0a, 0x4a, 0xa3, 0x88, 0x72, 0x31, 0xda, 0x66, 0x09, 0xab,
-	0x50, 0x82, 0x80, 0x01, 0xb1, 0x02, 0xc0, 0xf1, 0x15, 0x25, 0x09, 0xaa, 0x17, 0x27, 0x09, 0x34,
-	0x1a, 0x86, 0x4d, 0x0c, 0xc2, 0x79, 0x8e, 0xc3, 0xbe, 0x68, 0x9d, 0x32, 0x08, 0x53, 0xc9, 0x1e,
-	0x2d, 0x85, 0x7d, 0x0d, 0xde, 0x18, 0xdb, 0xe2, 0x16, 0x94, 0xfd, 0x80, 0x88, 0xab, 0x96, 0xce,
-	0x5f, 0x61, 0x79, 0x37, 0x30, 0xca, 0x7e, 0x80, 0x52, 0xcc, 0xb1, 0x2f, 0x31, 0x1e, 0x4a, 0x31,
-	0xda, 0x3d, 0x8a, 0xb8, 0x0c, 0xd5, 0x23, 0x74, 0x68, 0x9b, 0xae, 0xeb, 0xff, 0x54, 0xda, 0xcf,
-	0x42, 0x69, 0x27, 0x3c, 0x58, 0xc0, 0x21, 0x97, 0x78, 0xe6, 0x44, 0x46, 0x81, 0x69, 0x49, 0xc5,
-	0x82, 0x19, 0x42, 0xbf, 0x0a, 0xe5, 0xdd, 0x40, 0x34, 0xa0, 0xb2, 0x37, 0x1c, 0xf5, 0x16, 0xb0,
-	0xb1, 0x31, 0xdc, 0xee, 0xa1, 0x45, 0xa9, 0xf7, 0x1a, 0xfa, 0x57, 0x65, 0xd0, 0x9e, 0x4e, 0x63,
-	0x13, 0x75, 0x4b, 0x84, 0xb7, 0x2c, 0x72, 0x68, 0xc6, 0x8a, 0xdf, 0x80, 0x66, 0x14, 0x9b, 0x21,
-	0x79, 0x25, 0x6c, 0x9d, 0x1a, 0x04, 0x8f, 0x22, 0xf1, 0x01, 0xd4, 0xa4, 0x7d, 0x28, 0x13, 0x73,
-	0xd1, 0x9b, 0xbd, 0xaf, 0xc1, 0xdd, 0x62, 0x09, 0xea, 0x91, 0x75, 0x24, 0x27, 0x66, 0xbf, 0x9a,
-	0x0d, 0xdc, 0x23, 0x0c, 0xbb, 0xe1, 0x86, 0xea, 0x17, 0xef, 0x43, 0x0d, 0xdf, 0x26, 0x52, 0x71,
-	0x25, 0x45, 0xa2, 0xf8, 0x0c, 0x6a, 0x18, 0x77, 0x22, 0xe3, 0xd9, 0xa1, 0x1f, 0x8c, 0xfd, 0x80,
-	0x68, 0xdf, 0x5d, 0xb9, 0x42, 0x3a, 0x2e, 0xb9, 0xcd, 0xf2, 0x46, 0xe8, 0x07, 0xbb, 0x81, 0x51,
-	0xb7, 0xe9, 0x17, 0xa3, 0x1c, 0x1a, 0xce, 0x1c, 0xc1, 0x46, 0x41, 0x43, 0x0c, 0xa7, 0x92, 0x96,
-	0xa0, 0x39, 0x91, 0xb1, 0x69, 0x9b, 0xb1, 0xa9, 0x6c, 0x43, 0x9b, 0x55, 0x26, 0xe3, 0x8c, 0xb4,
-	0x57, 0xbf, 0x0f, 0x75, 0x5e, 0x5a, 0x34, 0xa1, 0xba, 0xb3, 0xbb, 0x33, 0x64, 0xb2, 0xae, 0x6e,
-	0x6f, 0xf7, 0x4a, 0x88, 0xda, 0x58, 0x1d, 0xad, 0xf6, 0xca, 0xd8, 0x1a, 0xfd, 0xf0, 0xd9, 0xb0,
-	0x57, 0xd1, 0xff, 0xa1, 0x04, 0xcd, 0x64, 0x1d, 0xf1, 0x29, 0x00, 0x8a, 0xf0, 0xf8, 0xc8, 0xf1,
-	0x52, 0x07, 0xef, 0x5a, 0x7e, 0xa7, 0x65, 0x7c, 0xd5, 0xcf, 0xb0, 0x97, 0xcd, 0x2b, 0x49, 0x3c,
-	0xc1, 0x83, 0x3d, 0xe8, 0x16, 0x3b, 0xe7, 0x78, 0xba, 0x77, 0xf3, 0x56, 0xa5, 0xbb, 0xf2, 0x56,
-	0x61, 0x69, 0x9c, 0x49, 0xac, 0x9d, 0x33, 0x30, 0xf7, 0xa0, 0x99, 0xa0, 0x45, 0x0b, 0x1a, 0x1b,
-	0xc3, 0xcd, 0xd5, 0xe7, 0xdb, 0xc8, 0x2a, 0x00, 0xf5, 0xbd, 0xad, 0x9d, 0xc7, 0xdb, 0x43, 0xbe,
-	0xd6, 0xf6, 0xd6, 0xde, 0xa8, 0x57, 0xd6, 0x7f, 0xbf, 0x04, 0xcd, 0xc4, 0x93, 0x11, 0x1f, 0xa1,
-	0xf3, 0x41, 0x4e, 0x9a, 0xb2, 0x44, 0x94, 0x11, 0xca, 0x85, 0xad, 0x46, 0xd2, 0x8f, 0xb2, 0x48,
-	0x8a, 0x35, 0xf1, 0x6d, 0x08, 0xc8, 0x47, 0xcd, 0x95, 0x42, 0x42, 0x47, 0x40, 0xd5, 0xf6, 0x3d,
-	0xa9, 0x1c, 0x66, 0x6a, 0x13, 0x0f, 0x3a, 0x9e, 0x25, 0xb3, 0x70, 0xa2, 0x41, 0xf0, 0x28, 0xd2,
-	0x63, 0xf6, 0xa3, 0xd3, 0x83, 0xa5, 0xbb, 0x95, 0xf2, 0xbb, 0x9d, 0x0b, 0x4a, 0xca, 0xe7, 0x83,
-	0x92, 0xcc, 0x70, 0xd6, 0x5e, 0x67, 0x38, 0xf5, 0x3f, 0xab, 0x42, 0xd7, 0x90, 0x51, 0xec, 0x87,
-	0x52, 0xf9, 0x85, 0xaf, 0x12, 0xa1, 0x77, 0x01, 0x42, 0x1e, 0x9c, 0x6d, 0xad, 0x29, 0x0c, 0x47,
-	0x53, 0xae, 0x6f, 0x11, 0xef, 0x2a, 0x0b, 0x99, 0xc2, 0xe2, 0x1a, 0x68, 0xfb, 0xa6, 0x75, 0xcc,
-	0xcb, 0xb2, 0x9d, 0x6c, 0x32, 0x82, 0xd7, 0x35, 0x2d, 0x4b, 0x46, 0xd1, 0x18, 0x59, 0x81, 0xad,
-	0xa5, 0xc6, 0x98, 0x27, 0xf2, 0x0c, 0xbb, 0x23, 0x69, 0x85, 0x32, 0xa6, 0xee, 0x3a, 0x77, 0x33,
-	0x06, 0xbb, 0x6f, 0x41, 0x27, 0x92, 0x11, 0x5a, 0xd6, 0x71, 0xec, 0x1f, 0x4b, 0x4f, 0xe9, 0xb1,
-	0xb6, 0x42, 0x8e, 0x10, 0x87, 0x2a, 0xc6, 0xf4, 0x7c, 0xef, 0x6c, 0xe2, 0x4f, 0x23, 0x65, 0x33,
-	0x32, 0x84, 0x58, 0x86, 0xcb, 0xd2, 0xb3, 0xc2, 0xb3, 0x00, 0xcf, 0x8a, 0xbb, 0x8c, 0x0f, 0x1c,
-	0x57, 0x2a, 0x57, 0x7d, 0x31, 0xeb, 0x7a, 0x22, 0xcf, 0x36, 0x1d, 0x57, 0xe2, 0x89, 0x4e, 0xcc,
-	0xa9, 0x1b, 0x8f, 0x29, 0x13, 0x00, 0x7c, 0x22, 0xc2, 0xac, 0xda, 0x76, 0x28, 0xee, 0xc0, 0x22,
-	0x77, 0x87, 0xbe, 0x2b, 0x1d, 0x9b, 0x17, 0x6b, 0xd1, 0xa8, 0x4b, 0xd4, 0x61, 0x10, 0x9e, 0x96,
-	0x5a, 0x86, 0xcb, 0x3c, 0x96, 0x2f, 0x94, 0x8c, 0x6e, 0xf3, 0xd6, 0xd4, 0xb5, 0xa7, 0x7a, 0x8a,
-	0x5b, 0x07, 0x66, 0x7c, 0x44, 0xfe, 0x7d, 0xb2, 0xf5, 0x33, 0x33, 0x3e, 0x42, 0x8b, 0xcf, 0xdd,
-	0x07, 0x8e, 0x74, 0x39, 0x3e, 0xd7, 0x0c, 0x9e, 0xb1, 0x89, 0x18, 0xb4, 0xf8, 0x6a, 0x80, 0x1f,
-	0x4e, 0x4c, 0x4e, 0x2c, 0x6a, 0x06, 0x4f, 0xda, 0x24, 0x14, 0x6e, 0xa1, 0xde, 0xca, 0x9b, 0x4e,
-	0x28, 0xc5, 0x58, 0x35, 0xd4, 0xeb, 0xed, 0x4c, 0x27, 0xfa, 0xcb, 0x0a, 0x34, 0xd3, 0x70, 0xef,
-	0x2e, 0x68, 0x93, 0x44, 0x5f, 0x29, 0x47, 0xad, 0x53, 0x50, 0x62, 0x46, 0xd6, 0x2f, 0xde, 0x85,
-	0xf2, 0xf1, 0x89, 0xd2, 0x9d, 0x9d, 0x65, 0x4e, 0xb4, 0x07, 0xfb, 0x8f, 0x96, 0x9f, 0xbc, 0x30,
-	0xca, 0xc7, 0x27, 0x5f, 0x83, 0x6f, 0xc5, 0x87, 0x70, 0xc9, 0x72, 0xa5, 0xe9, 0x8d, 0x33, 0xef,
-	0x82, 0xf9, 0xa2, 0x4b, 0xe8, 0x67, 0xa9, 0x8b, 0x71, 0x1b, 0x6a, 0xb6, 0x74, 0x63, 0x33, 0x9f,
-	0xef, 0xdd, 0x0d, 0x4d, 0xcb, 0x95, 0x1b, 0x88, 0x36, 0xb8, 0x17, 0x75, 0x67, 0x1a, 0x62, 0xe5,
-	0x74, 0xe7, 0x9c, 0xf0, 0x2a, 0x95, 0x4b, 0xc8, 0xcb, 0xe5, 0x5d, 0x58, 0x94, 0xa7, 0x01, 0x19,
-	0x8c, 0x71, 0x9a, 0x51, 0x60, 0x4b, 0xd6, 0x4b, 0x3a, 0xd6, 0x93, 0xcc, 0xc2, 0xc7, 0xa8, 0x32,
-	0x48, 0x68, 0xe8, 0x99, 0x5b, 0x2b, 0x82, 0x74, 0x4e, 0x41, 0x0c, 0x8d, 0x64, 0x88, 0xf8, 0x08,
-	0x34, 0xcb, 0xb6, 0xc6, 0x4c, 0x99, 0x4e, 0x76, 0xb6, 0xf5, 0x8d, 0x75, 0x26, 0x49, 0xd3, 0xb2,
-	0x2d, 0xf6, 0xaa, 0x0b, 0xa1, 0x5f, 0xf7, 0x4d, 0x42, 0xbf, 0xbc, 0x51, 0xec, 0x15, 0x8c, 0xe2,
-	0xe7, 0xd5, 0x66, 0xa3, 0xd7, 0xd4, 0x6f, 0x41, 0x33, 0xd9, 0x08, 0x55, 0x5d, 0x24, 0x3d, 0x15,
-	0xd6, 0x93, 0xaa, 0x43, 0x70, 0x14, 0xe9, 0x16, 0x54, 0x9e, 0xbc, 0xd8, 0x23, 0x8d, 0x87, 0xc6,
-	0xa7, 0x46, 0xbe, 0x0a, 0xb5, 0x53, 0x2d, 0x58, 0xce, 0x69, 0xc1, 0xeb, 0x6c, 0x40, 0xe8, 0x81,
-	0x92, 0x5c, 0x68, 0x0e, 0x83, 0x24, 0x66, 0xe3, 0x59, 0xe5, 0x34, 0x29, 0x01, 0xfa, 0x7f, 0x54,
-	0xa0, 0xa1, 0xfc, 0x1b, 0x34, 0x1a, 0xd3, 0x34, 0x8d, 0x87, 0xcd, 0x62, 0xe0, 0x99, 0x3a, 0x4a,
-	0xf9, 0x5a, 0x4a, 0xe5, 0xf5, 0xb5, 0x14, 0xf1, 0x29, 0xb4, 0x03, 0xee, 0xcb, 0xbb, 0x56, 0x6f,
-	0xe7, 0xe7, 0xa8, 0x5f, 0x9a, 0xd7, 0x0a, 0x32, 0x00, 0x49, 0x49, 0x09, 0xe5, 0xd8, 0x3c, 0x54,
-	0x14, 0x68, 0x20, 0x3c, 0x32, 0x0f, 0xdf, 0xc8, 0x4f, 0xea, 0x92, 0xc3, 0xd5, 0x26, 0x85, 0x8b,
-	0xbe, 0x55, 0xfe, 0x65, 0x3a, 0x45, 0x77, 0xe5, 0x1a, 0x68, 0x96, 0x3f, 0x99, 0x38, 0xd4, 0xd7,
-	0x55, 0x69, 0x2b, 0x42, 0x8c, 0x22, 0xfd, 0x77, 0x4b, 0xd0, 0x50, 0xf7, 0x3a, 0x67, 0x0c, 0xd7,
-	0xb6, 0x76, 0x56, 0x8d, 0x1f, 0xf6, 0x4a, 0x68, 0xec, 0xb7, 0x76, 0x46, 0xbd, 0xb2, 0xd0, 0xa0,
-	0xb6, 0xb9, 0xbd, 0xbb, 0x3a, 0xea, 0x55, 0xd0, 0x40, 0xae, 0xed, 0xee, 0x6e, 0xf7, 0xaa, 0xa2,
-	0x0d, 0xcd, 0x8d, 0xd5, 0xd1, 0x70, 0xb4, 0xf5, 0x74, 0xd8, 0xab, 0xe1, 0xd8, 0xc7, 0xc3, 0xdd,
-	0x5e, 0x1d, 0x1b, 0xcf, 0xb7, 0x36, 0x7a, 0x0d, 0xec, 0x7f, 0xb6, 0xba, 0xb7, 0xf7, 0xfd, 0x5d,
-	0x63, 0xa3, 0xd7, 0x24, 0x23, 0x3b, 0x32, 0xb6, 0x76, 0x1e, 0xf7, 0x34, 0x6c, 0xef, 0xae, 0x7d,
-	0x3e, 0x5c, 0x1f, 0xf5, 0x40, 0x7f, 0x08, 0xad, 0x1c, 0xad, 0x70, 0xb6, 0x31, 0xdc, 0xec, 0x2d,
-	0xe0, 0x96, 0x2f, 0x56, 0xb7, 0x9f, 0xa3, 0x4d, 0xee, 0x02, 0x50, 0x73, 0xbc, 0xbd, 0xba, 0xf3,
-	0xb8, 0x57, 0x56, 0x1e, 0xdd, 0xf7, 0xa0, 0xf9, 0xdc, 0xb1, 0xd7, 0x5c, 0xdf, 0x3a, 0x46, 0xf6,
-	0xd9, 0x37, 0x23, 0xa9, 0xf8, 0x8d, 0xda, 0xe8, 0x3f, 0x93, 0xd0, 0x46, 0xea, 0xad, 0x15, 0x84,
-	0x14, 0xf3, 0xa6, 0x93, 0x31, 0xd5, 0xdb, 0x2a, 0x6c, 0xb8, 0xbc, 0xe9, 0xe4, 0xb9, 0x63, 0x47,
-	0xfa, 0x31, 0x34, 0x9e, 0x3b, 0xf6, 0x33, 0xd3, 0x3a, 0x26, 0xe5, 0x86, 0x4b, 0x8f, 0x23, 0xe7,
-	0x0b, 0xa9, 0x0c, 0x9c, 0x46, 0x98, 0x3d, 0xe7, 0x0b, 0x29, 0xde, 0x87, 0x3a, 0x01, 0x49, 0xca,
-	0x81, 0x44, 0x2d, 0x39, 0x8e, 0xa1, 0xfa, 0xa8, 0xdc, 0xe5, 0xba, 0xbe, 0x35, 0x0e, 0xe5, 0x41,
-	0xff, 0x6d, 0x7e, 0x01, 0x42, 0x18, 0xf2, 0x40, 0xff, 0xbd, 0x52, 0x7a, 0x73, 0xaa, 0xaa, 0xdc,
-	0x80, 0x6a, 0x60, 0x5a, 0xc7, 0xca, 0xbf, 0x68, 0xa9, 0x05, 0xf1, 0x30, 0x06, 0x75, 0x88, 0x0f,
-	0xa1, 0xa9, 0x18, 0x29, 0xd9, 0xb5, 0x95, 0xe3, 0x38, 0x23, 0xed, 0x2c, 0x3e, 0x7c, 0xa5, 0xf8,
-	0xf0, 0x14, 0x9d, 0x06, 0xae, 0x13, 0xb3, 0xd8, 0xa0, 0x70, 0x12, 0xa4, 0x7f, 0x13, 0x20, 0x2b,
-	0x70, 0xcd, 0x71, 0xb7, 0xae, 0x40, 0xcd, 0x74, 0x1d, 0x33, 0x89, 0x76, 0x19, 0xd0, 0x77, 0xa0,
-	0x95, 0x2b, 0x8b, 0x21, 0x6d, 0x4d, 0xd7, 0x45, 0xcb, 0xc8, 0xb2, 0xdf, 0x34, 0x1a, 0xa6, 0xeb,
-	0x3e, 0x91, 0x67, 0x11, 0xba, 0xba, 0x5c, 0x51, 0x2b, 0xcf, 0x14, 0x5d, 0x68, 0xaa, 0xc1, 0x9d,
-	0xfa, 0xc7, 0x50, 0xdf, 0x4c, 0x02, 0x82, 0x44, 0x18, 0x4a, 0x17, 0x09, 0x83, 0xfe, 0x89, 0x3a,
-	0x33, 0xd5, 0x6d, 0xc4, 0x5d, 0x55, 0xb9, 0x8b, 0xb8, 0x4e, 0x58, 0xca, 0xf2, 0x25, 0x3c, 0x48,
-	0x15, 0xed, 0x68, 0xb0, 0xbe, 0x01, 0xcd, 0x57, 0xd6, 0x42, 0x15, 0x01, 0xca, 0x19, 0x01, 0xe6,
-	0x54, 0x47, 0xf5, 0x1f, 0x03, 0x64, 0x15, 0x3e, 0x25, 0x9b, 0xbc, 0x0a, 0xca, 0xe6, 0x1d, 0x68,
-	0x5a, 0x47, 0x8e, 0x6b, 0x87, 0xd2, 0x2b, 0xdc, 0x3a, 0xab, 0x09, 0xa6, 0xfd, 0xe2, 0x26, 0x54,
-	0xa9, 0x70, 0x59, 0xc9, 0x34, 0x77, 0x5a, 0xb5, 0xa4, 0x1e, 0xfd, 0x14, 0x3a, 0x1c, 0x43, 0xbc,
-	0x81, 0x07, 0x56, 0x54, 0x9d, 0xe5, 0x73, 0xaa, 0xf3, 0x2a, 0xd4, 0xc9, 0xf0, 0x27, 0xb7, 0x51,
-	0xd0, 0x05, 0x2a, 0xf5, 0x77, 0xca, 0x00, 0xbc, 0xf5, 0x8e, 0x6f, 0xcb, 0x62, 0xb0, 0x5e, 0x9a,
-	0x0d, 0xd6, 0x05, 0x54, 0xd3, 0x9a, 0xb4, 0x66, 0x50, 0x3b, 0x33, 0x86, 0x2a, 0x80, 0x67, 0x63,
-	0xf8, 0x0e, 0x68, 0xe4, 0x88, 0x39, 0x5f, 0x50, 0x41, 0x04, 0x37, 0xcc, 0x10, 0xf9, 0x0a, 0x6d,
-	0xad, 0x58, 0xa1, 0x4d, 0xcb, 0x55, 0x75, 0x5e, 0x8d, 0xcb, 0x55, 0x73, 0x2a, 0x6f, 0x9c, 0x41,
-	0x89, 0x64, 0x18, 0x27, 0xe1, 0x3f, 0x43, 0x69, 0x24, 0xab, 0xa9, 0xb1, 0x26, 0xe7, 0x40, 0x3c,
-	0x7f, 0x6c, 0xf9, 0xde, 0x81, 0xeb, 0x58, 0xb1, 0xaa, 0xc8, 0x82, 0xe7, 0xaf, 0x2b, 0x8c, 0xfe,
-	0x29, 0xb4, 0x13, 0xfa, 0x53, 0x81, 0xeb, 0x4e, 0x1a, 0xe5, 0x95, 0xb2, 0xb7, 0xcd, 0xc8, 0xb4,
-	0x56, 0xee, 0x97, 0x92, 0x38, 0x4f, 0xff, 0xef, 0x4a, 0x32, 0x59, 0xd5, 0x61, 0x5e, 0x4d, 0xc3,
-	0x62, 0xe0, 0x5e, 0x7e, 0xa3, 0xc0, 0xfd, 0xdb, 0xa0, 0xd9, 0x14, 0x8b, 0x3a, 0x27, 0x89, 0x11,
-	0x1b, 0xcc, 0xc6, 0x9d, 0x2a, 0x5a, 0x75, 0x4e, 0xa4, 0x91, 0x0d, 0x7e, 0xcd, 0x3b, 0xa4, 0xd4,
-	0xae, 0xcd, 0xa3, 0x76, 0xfd, 0x57, 0xa4, 0xf6, 0x7b, 0xd0, 0xf6, 0x7c, 0x6f, 0xec, 0x4d, 0x5d,
-	0xd7, 0xdc, 0x77, 0xa5, 0x22, 0x77, 0xcb, 0xf3, 0xbd, 0x1d, 0x85, 0x42, 0xef, 0x38, 0x3f, 0x84,
-	0x85, 0xba, 0x45, 0xe3, 0x2e, 0xe5, 0xc6, 0x91, 0xe8, 0x2f, 0x41, 0xcf, 0xdf, 0xff, 0xb1, 0xb4,
-	0x62, 0xa2, 0xd8, 0x98, 0xa4, 0x99, 0x5d, 0xe3, 0x2e, 0xe3, 0x91, 0x44, 0x3b, 0x28, 0xd7, 0x33,
-	0xcf, 0xdc, 0x39, 0xf7, 0xcc, 0x9f, 0x80, 0x96, 0x52, 0x29, 0x17, 0xf7, 0x6a, 0x50, 0xdb, 0xda,
-	0xd9, 0x18, 0xfe, 0xa0, 0x57, 0x42, 0x73, 0x69, 0x0c, 0x5f, 0x0c, 0x8d, 0xbd, 0x61, 0xaf, 0x8c,
-	0xa6, 0x6c, 0x63, 0xb8, 0x3d, 0x1c, 0x0d, 0x7b, 0x15, 0x76, 0x85, 0xa8, 0x1c, 0xe2, 0x3a, 0x96,
-	0x13, 0xeb, 0x7b, 0x00, 0x59, 0x30, 0x8f, 0x5a, 0x39, 0x3b, 0x9c, 0xca, 0x26, 0xc6, 0xc9, 0xb1,
-	0x96, 0x52, 0x81, 0x2c, 0x5f, 0x94, 0x32, 0xe0, 0x7e, 0x7d, 0x05, 0xb4, 0xa7, 0x66, 0xf0, 0x19,
-	0x17, 0x0e, 0x6f, 0x43, 0x37, 0x30, 0xc3, 0xd8, 0x49, 0xe2, 0x11, 0x56, 0x96, 0x6d, 0xa3, 0x93,
-	0x62, 0x51, 0xf7, 0xea, 0x7f, 0x5e, 0x82, 0x2b, 0x4f, 0xfd, 0x13, 0x99, 0xfa, 0xbb, 0xcf, 0xcc,
-	0x33, 0xd7, 0x37, 0xed, 0xd7, 0xb0, 0x21, 0x06, 0x54, 0xfe, 0x94, 0x0a, 0x79, 0x49, 0xd9, 0xd3,
-	0xd0, 0x18, 0xf3, 0x58, 0x7d, 0xaf, 0x21, 0xa3, 0x98, 0x3a, 0x95, 0x21, 0x45, 0x18, 0xbb, 0xde,
-	0x82, 0x7a, 0x7c, 0xea, 0x65, 0x45, 0xd8, 0x5a, 0x4c, 0x59, 0xf0, 0xb9, 0xee, 0x6f, 0x6d, 0xbe,
-	0xfb, 0xab, 0xaf, 0x83, 0x36, 0x3a, 0xa5, 0x3c, 0xf0, 0xb4, 0xe8, 0x80, 0x96, 0x5e, 0xe1, 0xe6,
-	0x94, 0x67, 0xdc, 0x9c, 0x7f, 0x2f, 0x41, 0x2b, 0xe7, 0xc7, 0x8b, 0xf7, 0xa0, 0x1a, 0x9f, 0x7a,
-	0xc5, 0x6f, 0x1d, 0x92, 0x4d, 0x0c, 0xea, 0x3a, 0x97, 0xeb, 0x2c, 0x9f, 0xcb, 0x75, 0x8a, 0x6d,
-	0xb8, 0xc4, 0x9a, 0x37, 0xb9, 0x44, 0x92, 0x12, 0xba, 0x35, 0x13, 0x37, 0x70, 0xae, 0x3c, 0xb9,
-	0x92, 0xca, 0x73, 0x74, 0x0f, 0x0b, 0xc8, 0xc1, 0x2a, 0x5c, 0x9e, 0x33, 0xec, 0xeb, 0x54, 0x4d,
-	0xf4, 0x1b, 0xd0, 0x19, 0x9d, 0x7a, 0x23, 0x67, 0x22, 0xa3, 0xd8, 0x9c, 0x04, 0xe4, 0x26, 0x2a,
-	0xcb, 0x59, 0x35, 0xca, 0x71, 0xa4, 0x7f, 0x00, 0xed, 0x67, 0x52, 0x86, 0x86, 0x8c, 0x02, 0xdf,
-	0x63, 0xe7, 0x48, 0xe5, 0xa8, 0xd9, 0x4c, 0x2b, 0x48, 0xff, 0x2d, 0xd0, 0x0c, 0xf3, 0x20, 0x5e,
-	0x33, 0x63, 0xeb, 0xe8, 0xeb, 0x24, 0x3d, 0x3e, 0x80, 0x46, 0xc0, 0x3c, 0xa5, 0xa2, 0xbb, 0x36,
-	0x99, 0x6b, 0xc5, 0x67, 0x46, 0xd2, 0xa9, 0xff, 0x1a, 0x74, 0x55, 0xc1, 0x28, 0x39, 0x49, 0xae,
-	0xaa, 0x54, 0xba, 0xb0, 0xaa, 0xa4, 0x1f, 0x42, 0x27, 0x99, 0xc7, 0xc6, 0xef, 0x8d, 0xa6, 0x7d,
-	0xfd, 0xb2, 0xbd, 0xfe, 0x9b, 0x70, 0x79, 0x6f, 0xba, 0x1f, 0x59, 0xa1, 0x43, 0x91, 0x7c, 0xb2,
-	0xdd, 0x00, 0x9a, 0x41, 0x28, 0x0f, 0x9c, 0x53, 0x99, 0x88, 0x58, 0x0a, 0x8b, 0x3b, 0xd0, 0x98,
-	0x20, 0xbd, 0x64, 0x26, 0xbc, 0x59, 0xcc, 0xfa, 0x14, 0x7b, 0x8c, 0x64, 0x80, 0xfe, 0x1d, 0xb8,
-	0x52, 0x5c, 0x5e, 0x51, 0xe1, 0x16, 0x54, 0x8e, 0x4f, 0x22, 0x45, 0xe6, 0xc5, 0x42, 0xcc, 0x4b,
-	0xdf, 0x4b, 0x60, 0xaf, 0xfe, 0xc7, 0x25, 0xa8, 0xec, 0x4c, 0x27, 0xf9, 0x8f, 0xc1, 0xaa, 0xfc,
-	0x31, 0xd8, 0xb5, 0x7c, 0x3e, 0x9b, 0x63, 0xa8, 0x2c, 0x6f, 0xfd, 0x0e, 0x68, 0x07, 0x7e, 0xf8,
-	0x53, 0x33, 0xb4, 0xa5, 0xad, 0x2c, 0x70, 0x86, 0x10, 0xb7, 0x95, 0xbd, 0xe6, 0x18, 0x66, 0x11,
-	0xa9, 0xb8, 0x33, 0x9d, 0x2c, 0xbb, 0xd2, 0x8c, 0xc8, 0xb0, 0xb0, 0x09, 0xd7, 0xef, 0x82, 0x96,
-	0xa2, 0x50, 0x19, 0xee, 0xec, 0x8d, 0xb7, 0x36, 0x38, 0x1f, 0x88, 0xde, 0x7e, 0x09, 0x15, 0xe1,
-	0xe8, 0x07, 0x3b, 0xe3, 0xd1, 0x5e, 0xaf, 0xac, 0xff, 0x08, 0x5a, 0x89, 0xac, 0x6c, 0xd9, 0x54,
-	0xfc, 0x22, 0x61, 0xdd, 0xb2, 0x0b, 0xb2, 0xbb, 0x45, 0xe1, 0x98, 0xf4, 0xec, 0xad, 0x44, 0xc8,
-	0x18, 0x28, 0xde, 0x46, 0x55, 0xd2, 0x92, 0xdb, 0xe8, 0x43, 0x58, 0x34, 0x28, 0x89, 0x8f, 0x46,
-	0x36, 0x79, 0x9e, 0xab, 0x50, 0xf7, 0x7c, 0x5b, 0xa6, 0x1b, 0x28, 0x08, 0x77, 0x56, 0x0f, 0xab,
-	0xd4, 0x57, 0xfa, 0xce, 0x12, 0x16, 0x51, 0x23, 0x16, 0x99, 0xaa, 0x90, 0x60, 0x2e, 0xcd, 0x24,
-	0x98, 0x71, 0x13, 0x55, 0x4b, 0x66, 0xdf, 0x26, 0xa9, 0x1f, 0x0f, 0xa0, 0x69, 0x47, 0x31, 0x89,
-	0xb0, 0xd2, 0x83, 0x29, 0xac, 0xdf, 0x87, 0xcb, 0xab, 0x41, 0xe0, 0x9e, 0x25, 0x95, 0x37, 0xb5,
-	0x51, 0x3f, 0x2b, 0xcf, 0x95, 0x54, 0x0c, 0xc8, 0xa0, 0xbe, 0x09, 0xed, 0x24, 0x9b, 0xf0, 0x54,
-	0xc6, 0x26, 0x69, 0x37, 0xd7, 0x29, 0x84, 0xd3, 0x4d, 0x46, 0x8c, 0x8a, 0x69, 0xec, 0x99, 0xfb,
-	0x2d, 0x43, 0x5d, 0xa9, 0x4e, 0x01, 0x55, 0xcb, 0xb7, 0x79, 0xa3, 0x9a, 0x41, 0x6d, 0xe4, 0xa0,
-	0x49, 0x74, 0x98, 0x78, 0xb7, 0x93, 0xe8, 0x50, 0xff, 0xa7, 0x32, 0x74, 0xd6, 0x28, 0x77, 0x93,
-	0x9c, 0x31, 0x97, 0xb1, 0x2c, 0x15, 0x32, 0x96, 0xf9, 0xec, 0x64, 0xb9, 0x90, 0x9d, 0x2c, 0x1c,
-	0xa8, 0x52, 0x74, 0x49, 0xdf, 0x86, 0xc6, 0xd4, 0x73, 0x4e, 0x13, 0x9b, 0xa0, 0x19, 0x75, 0x04,
-	0x47, 0x91, 0xb8, 0x09, 0x2d, 0x34, 0x1b, 0x8e, 0xc7, 0x19, 0x41, 0x4e, 0xeb, 0xe5, 0x51, 0x33,
-	0x79, 0xbf, 0xfa, 0xab, 0xf3, 0x7e, 0x8d, 0xd7, 0xe6, 0xfd, 0x9a, 0xaf, 0xcb, 0xfb, 0x69, 0xb3,
-	0x79, 0xbf, 0xa2, 0x3b, 0x0d, 0xe7, 0xdc, 0xe9, 0x77, 0x01, 0xf8, 0x83, 0x97, 0x83, 0xa9, 0xeb,
-	0x2a, 0x1f, 0x45, 0x23, 0xcc, 0xe6, 0xd4, 0x75, 0xf5, 0x6d, 0xe8, 0x26, 0xa4, 0x55, 0xe2, 0xfe,
-	0x29, 0x5c, 0x52, 0x19, 0x7d, 0x19, 0xaa, 0xa4, 0x18, 0x6b, 0x31, 0x92, 0x3f, 0x4e, 0xba, 0xab,
-	0x1e, 0xa3, 0x6b, 0xe7, 0xc1, 0x48, 0xff, 0x79, 0x09, 0x3a, 0x85, 0x11, 0xe2, 0x61, 0x56, 0x1f,
-	0x28, 0x91, 0x14, 0xf7, 0xcf, 0xad, 0xf2, 0xea, 0x1a, 0x41, 0x79, 0xa6, 0x46, 0xa0, 0xdf, 0x4b,
-	0x33, 0xff, 0x2a, 0xdf, 0xbf, 0x90, 0xe6, 0xfb, 0x29, 0x45, 0xbe, 0x3a, 0x1a, 0x19, 0xbd, 0xb2,
-	0xa8, 0x43, 0x79, 0x67, 0xaf, 0x57, 0xd1, 0xff, 0xb2, 0x0c, 0x9d, 0xe1, 0x69, 0x40, 0x1f, 0x7f,
-	0xbd, 0x36, 0x36, 0xc9, 0xf1, 0x55, 0xb9, 0xc0, 0x57, 0x39, 0x0e, 0xa9, 0xa8, 0x82, 0x27, 0x73,
-	0x08, 0x46, 0x2b, 0x9c, 0x85, 0x54, 0x9c, 0xc3, 0xd0, 0xff, 0x07, 0xce, 0x29, 0x68, 0x14, 0x98,
-	0x2d, 0x59, 0x6d, 0x43, 0x37, 0x21, 0x9b, 0x62, 0x8c, 0x37, 0x12, 0x56, 0xfe, 0xdc, 0xd3, 0x4d,
-	0x93, 0x62, 0x0c, 0xe8, 0x7f, 0x52, 0x06, 0x8d, 0xf9, 0x0c, 0x0f, 0xff, 0x91, 0xd2, 0xeb, 0xa5,
-	0xac, 0x3a, 0x92, 0x76, 0x2e, 0x3f, 0x91, 0x67, 0x99, 0x6e, 0x9f, 0x5b, 0x51, 0x54, 0xa9, 0x33,
-	0xce, 0x1e, 0x50, 0xea, 0xec, 0x1a, 0x68, 0xec, 0x82, 0x4d, 0x55, 0x6a, 0xbe, 0x6a, 0xb0, 0x4f,
-	0xf6, 0xdc, 0xa1, 0xda, 0x60, 0x2c, 0xc3, 0x89, 0x7a, 0x03, 0x6a, 0x17, 0xe3, 0xb4, 0x4e, 0x12,
-	0x39, 0x14, 0x28, 0xd2, 0x98, 0xa5, 0xc8, 0x11, 0x34, 0xd4, 0xd9, 0xd0, 0xcd, 0x7e, 0xbe, 0xf3,
-	0x64, 0x67, 0xf7, 0xfb, 0x3b, 0x05, 0xee, 0x4b, 0x1d, 0xf1, 0x72, 0xde, 0x11, 0xaf, 0x20, 0x7e,
-	0x7d, 0xf7, 0xf9, 0xce, 0xa8, 0x57, 0x15, 0x1d, 0xd0, 0xa8, 0x39, 0x36, 0x86, 0x2f, 0x7a, 0x35,
-	0xca, 0x3c, 0xad, 0x7f, 0x36, 0x7c, 0xba, 0xda, 0xab, 0xa7, 0xb5, 0xaa, 0x86, 0xfe, 0x47, 0x25,
-	0x58, 0x64, 0x82, 0xe4, 0x93, 0x30, 0xf9, 0x0f, 0xb1, 0xab, 0xfc, 0x21, 0xf6, 0xff, 0x6d, 0xde,
-	0x05, 0x27, 0x4d, 0x9d, 0xa4, 0x3a, 0xcc, 0x09, 0xc1, 0xe6, 0xd4, 0x51, 0x45, 0xe1, 0xbf, 0x2b,
-	0xc1, 0x80, 0xfd, 0xff, 0xc7, 0xa1, 0x19, 0x1c, 0x7d, 0x6f, 0xfb, 0x5c, 0x06, 0xe0, 0x22, 0xaf,
-	0xf8, 0x36, 0x74, 0xe9, 0x53, 0xf5, 0x9f, 0xb8, 0x63, 0x15, 0xa5, 0xf2, 0xeb, 0x76, 0x14, 0x96,
-	0x17, 0x12, 0x8f, 0xa0, 0xcd, 0x9f, 0xb4, 0x53, 0x86, 0xbc, 0x50, 0xd9, 0x2c, 0x44, 0x1f, 0x2d,
-	0x1e, 0xc5, 0x75, 0xd8, 0x87, 0xe9, 0xa4, 0x2c, 0x59, 0x70, 0xbe, 0x78, 0xa9, 0xa6, 0x8c, 0x28,
-	0x85, 0x70, 0x1f, 0xae, 0xcd, 0xbd, 0x87, 0x62, 0xfb, 0x5c, 0xa2, 0x96, 0xb9, 0x4d, 0xff, 0xe7,
-	0x12, 0x34, 0xd7, 0xa6, 0xee, 0x31, 0x19, 0xc1, 0x77, 0x01, 0xa4, 0x7d, 0x28, 0xd5, 0xb7, 0xe1,
-	0x25, 0x52, 0x0e, 0x1a, 0x62, 0xf8, 0xeb, 0xf0, 0x4f, 0x01, 0xf8, 0x8e, 0xe3, 0x89, 0x19, 0xa8,
-	0x27, 0xa2, 0x4a, 0x63, 0xb2, 0x80, 0xba, 0xcb, 0x53, 0x33, 0x50, 0x95, 0xc6, 0x28, 0x81, 0xb3,
-	0x0a, 0x6c, 0xe5, 0x15, 0x15, 0xd8, 0xc1, 0x0e, 0x74, 0x8b, 0x4b, 0xcc, 0x49, 0x90, 0x7d, 0x50,
-	0xfc, 0xca, 0xe5, 0x3c, 0x0d, 0x73, 0xfe, 0xfa, 0xe7, 0x70, 0x69, 0x26, 0xd9, 0xfe, 0x2a, 0x8d,
-	0x59, 0x10, 0x99, 0xf2, 0xac, 0xc8, 0x7c, 0x0c, 0x8b, 0x23, 0x33, 0x3a, 0x56, 0x31, 0x4c, 0x66,
-	0xbc, 0x63, 0x33, 0x3a, 0x1e, 0xa7, 0x44, 0xad, 0x23, 0xb8, 0x65, 0xeb, 0x0f, 0x41, 0xe4, 0x47,
-	0x2b, 0xfa, 0x63, 0x6c, 0x8a, 0xc3, 0x27, 0x32, 0x36, 0x13, 0x2f, 0x03, 0x11, 0x48, 0xbc, 0x95,
-	0xbf, 0x2d, 0x41, 0x15, 0x9d, 0x7e, 0x71, 0x0f, 0xb4, 0xcf, 0xa4, 0x19, 0xc6, 0xfb, 0xd2, 0x8c,
-	0x45, 0xc1, 0xc1, 0x1f, 0x10, 0xdd, 0xb2, 0x2f, 0x67, 0xf4, 0x85, 0x07, 0x25, 0xb1, 0xcc, 0xdf,
-	0xf5, 0x26, 0xdf, 0x2b, 0x77, 0x92, 0xe0, 0x81, 0x82, 0x8b, 0x41, 0x61, 0xbe, 0xbe, 0xb0, 0x44,
-	0xe3, 0x3f, 0xf7, 0x1d, 0x6f, 0x9d, 0xbf, 0x26, 0x15, 0xb3, 0xc1, 0xc6, 0xec, 0x0c, 0x71, 0x0f,
-	0xea, 0x5b, 0x11, 0x46, 0x35, 0xe7, 0x87, 0x12, 0xf1, 0xf3, 0x01, 0x8f, 0xbe, 0xb0, 0xf2, 0x17,
-	0x35, 0xa8, 0xfe, 0x48, 0x86, 0xbe, 0xf8, 0x18, 0x1a, 0xea, 0x3b, 0x23, 0x91, 0xfb, 0x9e, 0x68,
-	0x40, 0x09, 0x96, 0x99, 0x0f, 0x90, 0x68, 0x97, 0x1e, 0xbf, 0x5f, 0x56, 0x62, 0x12, 0xd9, 0x67,
-	0x50, 0xe7, 0x0e, 0xf5, 0x09, 0xf4, 0xf6, 0xe2, 0x50, 0x9a, 0x93, 0xdc, 0xf0, 0x22, 0xa9, 0xe6,
-	0xd5, 0xab, 0x88, 0x5e, 0x77, 0xa1, 0xce, 0xa1, 0xe3, 0xcc, 0x84, 0xd9, 0x62, 0x14, 0x0d, 0xfe,
-	0x10, 0x5a, 0x7b, 0x47, 0xfe, 0xd4, 0xb5, 0xf7, 0x64, 0x78, 0x22, 0x45, 0x2e, 0xfa, 0x19, 0xe4,
-	0xda, 0xfa, 0x82, 0x78, 0x08, 0x75, 0x7c, 0x91, 0x70, 0x22, 0x16, 0x73, 0x11, 0x12, 0xb3, 0xc9,
-	0x40, 0xe4, 0x51, 0x09, 0xa5, 0xc4, 0x87, 0xa0, 0xb1, 0xfb, 0x8e, 0xce, 0x7b, 0x43, 0x45, 0x04,
-	0x7c, 0x8c, 0x9c, 0x5b, 0xaf, 0x2f, 0x88, 0x25, 0x80, 0x5c, 0xcc, 0xf9, 0xaa, 0x91, 0x8f, 0xa0,
-	0xb3, 0x4e, 0x9a, 0x70, 0x37, 0x5c, 0xdd, 0xf7, 0xc3, 0x58, 0xcc, 0x7e, 0xfb, 0x38, 0x98, 0x45,
-	0xe8, 0x0b, 0x18, 0xbd, 0x8d, 0xc2, 0x33, 0x1e, 0xbf, 0xa8, 0x42, 0xf5, 0x6c, 0xbf, 0x39, 0x74,
-	0x11, 0xdf, 0x4c, 0xe5, 0x2a, 0xf5, 0xda, 0xe7, 0x55, 0xb6, 0x98, 0x44, 0x2c, 0x03, 0x44, 0x22,
-	0xc8, 0x42, 0x0a, 0xf1, 0x16, 0x57, 0xd9, 0x66, 0x42, 0x8c, 0xf3, 0x53, 0xb2, 0xf0, 0x81, 0xa7,
-	0x9c, 0x0b, 0x27, 0x66, 0xa6, 0x7c, 0x0b, 0xda, 0xf9, 0x50, 0x40, 0x50, 0xb9, 0x68, 0x4e, 0x70,
-	0x50, 0x9c, 0xb6, 0xf2, 0x9f, 0x35, 0xa8, 0x7f, 0xdf, 0x0f, 0x8f, 0x65, 0x28, 0xee, 0x40, 0x9d,
-	0xea, 0xa5, 0x4a, 0x96, 0xd2, 0xda, 0xe9, 0x3c, 0xda, 0xbd, 0x0f, 0x1a, 0x71, 0x06, 0x0a, 0x3b,
-	0xf3, 0x2b, 0xfd, 0x57, 0x87, 0x17, 0xe7, 0x0c, 0x26, 0x31, 0x77, 0x97, 0xb9, 0x35, 0xfd, 0x9e,
-	0xa0, 0x50, 0xcf, 0x1c, 0xd0, 0x93, 0x3e, 0x79, 0xb1, 0x87, 0xf2, 0xf9, 0xa0, 0x84, 0x3e, 0xc5,
-	0x1e, 0x3f, 0x1e, 0x0e, 0xca, 0xfe, 0x8b, 0xc0, 0xe2, 0x9f, 0x7d, 0xfc, 0xaf, 0x2f, 0x88, 0xfb,
-	0x50, 0x57, 0x26, 0x66, 0x31, 0x53, 0x84, 0xc9, 0x0d, 0x7b, 0x79, 0x94, 0x9a, 0xf0, 0x10, 0xea,
-	0x6c, 0x8e, 0x79, 0x42, 0x21, 0x16, 0x61, 0x3e, 0x2d, 0xfa, 0xd0, 0xfa, 0x82, 0xb8, 0x0b, 0x0d,
-	0x55, 0x0d, 0x15, 0x73, 0x4a, 0xa3, 0xe7, 0x5e, 0xac, 0xce, 0xbe, 0x16, 0xaf, 0x5f, 0x70, 0x57,
-	0x79, 0xfd, 0xa2, 0x2b, 0xc6, 0xa2, 0x6f, 0x48, 0x4b, 0x3a, 0xb9, 0xc4, 0x99, 0x48, 0x28, 0x32,
-	0x47, 0x7f, 0x7d, 0x02, 0x9d, 0x42, 0x92, 0x4d, 0xf4, 0x13, 0xb6, 0x98, 0xcd, 0xbb, 0x9d, 0xd3,
-	0x1a, 0xdf, 0x01, 0x4d, 0xa5, 0x05, 0xf6, 0x15, 0x63, 0xcc, 0x49, 0x42, 0x0c, 0xce, 0xe7, 0x05,
-	0x48, 0x15, 0xfc, 0x00, 0x2e, 0xcf, 0xb1, 0xad, 0x82, 0xbe, 0x66, 0xbd, 0xd8, 0x79, 0x18, 0xdc,
-	0xb8, 0xb0, 0x3f, 0x25, 0xc0, 0xaf, 0x26, 0x4e, 0xdf, 0x05, 0xc8, 0x4c, 0x0c, 0xcb, 0xc6, 0x39,
-	0x03, 0x35, 0xb8, 0x3a, 0x8b, 0x4e, 0x36, 0x5d, 0xeb, 0xff, 0xfd, 0x57, 0xd7, 0x4b, 0xbf, 0xf8,
-	0xea, 0x7a, 0xe9, 0xdf, 0xbe, 0xba, 0x5e, 0xfa, 0xf9, 0x2f, 0xaf, 0x2f, 0xfc, 0xe2, 0x97, 0xd7,
-	0x17, 0xfe, 0xf1, 0x97, 0xd7, 0x17, 0xf6, 0xeb, 0xf4, 0xa7, 0xb9, 0x47, 0xff, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x8f, 0xfa, 0xf4, 0xef, 0xaa, 0x37, 0x00, 0x00,
+	// 6012 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7b, 0x49, 0x6f, 0x1c, 0x59,
+	0x72, 0x30, 0x6b, 0xcf, 0x8c, 0x5a, 0x58, 0x7c, 0x52, 0xab, 0xab, 0xd9, 0xdd, 0x22, 0x3b, 0xd5,
+	0xea, 0x66, 0x6b, 0xa1, 0x24, 0x6a, 0xe6, 0x9b, 0xe9, 0x1e, 0x0c, 0xf0, 0x71, 0x55, 0xb3, 0x45,
+	0x91, 0x9c, 0x64, 0x49, 0xb3, 0x00, 0xdf, 0x57, 0x48, 0x66, 0x3e, 0x92, 0x39, 0xcc, 0xca, 0xac,
+	0xc9, 0xcc, 0x62, 0x93, 0x7d, 0xfb, 0x4e, 0xdf, 0xc1, 0x3e, 0x0c, 0xe0, 0x8b, 0x01, 0x03, 0x36,
+	0xe0, 0x83, 0x0f, 0xf6, 0x69, 0x7c, 0xb2, 0x0f, 0xbe, 0x19, 0x86, 0x61, 0xc0, 0xc0, 0x1c, 0x6d,
+	0x18, 0x6e, 0x18, 0x33, 0xb6, 0x0f, 0x3a, 0xcc, 0xc5, 0x7f, 0xc0, 0x88, 0x88, 0x97, 0x5b, 0xb1,
+	0x28, 0xa9, 0xc7, 0xf0, 0xc1, 0xa7, 0xca, 0x88, 0x78, 0x6b, 0xbc, 0x88, 0x78, 0xb1, 0xbc, 0x02,
+	0x6d, 0x74, 0xb8, 0x3c, 0x0a, 0x83, 0x38, 0x10, 0xe5, 0xd1, 0xe1, 0xbc, 0x6e, 0x8d, 0x5c, 0x06,
+	0xe7, 0xef, 0x1c, 0xbb, 0xf1, 0xc9, 0xf8, 0x70, 0xd9, 0x0e, 0x86, 0x0f, 0x9c, 0xe3, 0xd0, 0x1a,
+	0x9d, 0xdc, 0x77, 0x83, 0x07, 0x87, 0x96, 0x73, 0x2c, 0xc3, 0x07, 0x67, 0x8f, 0x1f, 0x8c, 0x0e,
+	0x1f, 0x24, 0x5d, 0xe7, 0xef, 0xe7, 0xda, 0x1e, 0x07, 0xc7, 0xc1, 0x03, 0x42, 0x1f, 0x8e, 0x8f,
+	0x08, 0x22, 0x80, 0xbe, 0xb8, 0xb9, 0x31, 0x0f, 0xd5, 0x1d, 0x37, 0x8a, 0x85, 0x80, 0xea, 0xd8,
+	0x75, 0xa2, 0x5e, 0x69, 0xb1, 0xb2, 0x54, 0x37, 0xe9, 0xdb, 0x78, 0x06, 0x7a, 0xdf, 0x8a, 0x4e,
+	0x5f, 0x58, 0xde, 0x58, 0x8a, 0x2e, 0x54, 0xce, 0x2c, 0xaf, 0x57, 0x5a, 0x2c, 0x2d, 0xb5, 0x4c,
+	0xfc, 0x14, 0xcb, 0xa0, 0x9d, 0x59, 0xde, 0x20, 0xbe, 0x18, 0xc9, 0x5e, 0x79, 0xb1, 0xb4, 0xd4,
+	0x59, 0xb9, 0xb6, 0x3c, 0x3a, 0x5c, 0xde, 0x0f, 0xa2, 0xd8, 0xf5, 0x8f, 0x97, 0x5f, 0x58, 0x5e,
+	0xff, 0x62, 0x24, 0xcd, 0xc6, 0x19, 0x7f, 0x18, 0x7b, 0xd0, 0x3c, 0x08, 0xed, 0xad, 0xb1, 0x6f,
+	0xc7, 0x6e, 0xe0, 0xe3, 0x8c, 0xbe, 0x35, 0x94, 0x34, 0xa2, 0x6e, 0xd2, 0x37, 0xe2, 0xac, 0xf0,
+	0x38, 0xea, 0x55, 0x16, 0x2b, 0x88, 0xc3, 0x6f, 0xd1, 0x83, 0x86, 0x1b, 0xad, 0x07, 0x63, 0x3f,
+	0xee, 0x55, 0x17, 0x4b, 0x4b, 0x9a, 0x99, 0x80, 0xc6, 0x2f, 0xaa, 0x50, 0xfb, 0xc1, 0x58, 0x86,
+	0x17, 0xd4, 0x2f, 0x8e, 0xc3, 0x64, 0x2c, 0xfc, 0x16, 0xd7, 0xa1, 0xe6, 0x59, 0xfe, 0x71, 0xd4,
+	0x2b, 0xd3, 0x60, 0x0c, 0x88, 0x77, 0x41, 0xb7, 0x8e, 0x62, 0x19, 0x0e, 0xc6, 0xae, 0xd3, 0xab,
+	0x2c, 0x96, 0x96, 0xea, 0xa6, 0x46, 0x88, 0xe7, 0xae, 0x23, 0xde, 0x01, 0xcd, 0x09, 0x06, 0x76,
+	0x7e, 0x2e, 0x27, 0xa0, 0xb9, 0xc4, 0x2d, 0xd0, 0xc6, 0xae, 0x33, 0xf0, 0xdc, 0x28, 0xee, 0xd5,
+	0x16, 0x4b, 0x4b, 0xcd, 0x15, 0x0d, 0x37, 0x8b, 0xbc, 0x33, 0x1b, 0x63, 0xd7, 0x21, 0x26, 0xde,
+	0x01, 0x2d, 0x0a, 0xed, 0xc1, 0xd1, 0xd8, 0xb7, 0x7b, 0x75, 0x6a, 0x34, 0x8b, 0x8d, 0x72, 0xbb,
+	0x36, 0x1b, 0x11, 0x03, 0xb8, 0xad, 0x50, 0x9e, 0xc9, 0x30, 0x92, 0xbd, 0x06, 0x4f, 0xa5, 0x40,
+	0xf1, 0x10, 0x9a, 0x47, 0x96, 0x2d, 0xe3, 0xc1, 0xc8, 0x0a, 0xad, 0x61, 0x4f, 0xcb, 0x06, 0xda,
+	0x42, 0xf4, 0x3e, 0x62, 0x23, 0x13, 0x8e, 0x52, 0x40, 0x3c, 0x86, 0x36, 0x41, 0xd1, 0xe0, 0xc8,
+	0xf5, 0x62, 0x19, 0xf6, 0x74, 0xea, 0xd3, 0xa1, 0x3e, 0x84, 0xe9, 0x87, 0x52, 0x9a, 0x2d, 0x6e,
+	0xc4, 0x18, 0xf1, 0x3e, 0x80, 0x3c, 0x1f, 0x59, 0xbe, 0x33, 0xb0, 0x3c, 0xaf, 0x07, 0xb4, 0x06,
+	0x9d, 0x31, 0xab, 0x9e, 0x27, 0xde, 0xc6, 0xf5, 0x59, 0xce, 0x20, 0x8e, 0x7a, 0xed, 0xc5, 0xd2,
+	0x52, 0xd5, 0xac, 0x23, 0xd8, 0x8f, 0x90, 0xaf, 0xb6, 0x65, 0x9f, 0xc8, 0x5e, 0x67, 0xb1, 0xb4,
+	0x54, 0x33, 0x19, 0x40, 0xec, 0x91, 0x1b, 0x46, 0x71, 0x6f, 0x96, 0xb1, 0x04, 0x88, 0x1b, 0x50,
+	0x0f, 0x8e, 0x8e, 0x22, 0x19, 0xf7, 0xba, 0x84, 0x56, 0x10, 0xe2, 0x23, 0x6b, 0x38, 0xf2, 0x64,
+	0x6f, 0x8e, 0xf1, 0x0c, 0x89, 0x05, 0x68, 0xf2, 0xd7, 0x20, 0x92, 0xd2, 0xe9, 0x89, 0xc5, 0xd2,
+	0x52, 0xc5, 0x04, 0x46, 0x1d, 0x48, 0xe9, 0xe0, 0xa2, 0x91, 0xbb, 0x03, 0xcf, 0x1d, 0xba, 0x71,
+	0xef, 0x1a, 0x75, 0xd6, 0x11, 0xb3, 0x83, 0x08, 0xb1, 0x08, 0xad, 0xf8, 0xdc, 0x1f, 0x44, 0xb1,
+	0x15, 0xc6, 0xb8, 0xf2, 0xeb, 0xb4, 0x72, 0x88, 0xcf, 0xfd, 0x03, 0x44, 0xf5, 0x23, 0x63, 0x05,
+	0x74, 0x92, 0x67, 0x3a, 0xaf, 0xdb, 0x50, 0x3f, 0x43, 0x80, 0xc5, 0xbe, 0xb9, 0xd2, 0x46, 0x86,
+	0xa5, 0x22, 0x6f, 0x2a, 0xa2, 0x71, 0x13, 0xb4, 0x1d, 0xcb, 0x3f, 0x4e, 0xf4, 0x04, 0x05, 0x89,
+	0x3a, 0xe8, 0x26, 0x7d, 0x1b, 0xbf, 0x5f, 0x86, 0xba, 0x29, 0xa3, 0xb1, 0x17, 0x8b, 0x8f, 0x01,
+	0x50, 0x4c, 0x86, 0x56, 0x1c, 0xba, 0xe7, 0x6a, 0xd4, 0x4c, 0x50, 0xf4, 0xb1, 0xeb, 0x3c, 0x23,
+	0x92, 0x78, 0x08, 0x2d, 0x1a, 0x3d, 0x69, 0x5a, 0xce, 0x16, 0x90, 0xae, 0xcf, 0x6c, 0x52, 0x13,
+	0xd5, 0xe3, 0x06, 0xd4, 0x49, 0x32, 0x59, 0x3b, 0xda, 0xa6, 0x82, 0xc4, 0x6d, 0xe8, 0xb8, 0x7e,
+	0x8c, 0x92, 0x63, 0xc7, 0x03, 0x47, 0x46, 0x89, 0xe8, 0xb6, 0x53, 0xec, 0x86, 0x8c, 0x62, 0xf1,
+	0x08, 0xf8, 0xf8, 0x93, 0x09, 0x6b, 0x34, 0x61, 0x27, 0x15, 0xab, 0x88, 0x67, 0xa4, 0x36, 0x6a,
+	0xc6, 0xfb, 0xd0, 0xc4, 0xfd, 0x25, 0x3d, 0xea, 0xd4, 0xa3, 0x45, 0xbb, 0x51, 0xec, 0x30, 0x01,
+	0x1b, 0xa8, 0xe6, 0xc8, 0x1a, 0x54, 0x0f, 0x16, 0x67, 0xfa, 0x36, 0xc6, 0x50, 0xdb, 0x0b, 0x1d,
+	0x19, 0x4e, 0xd5, 0x50, 0x01, 0x55, 0x47, 0x46, 0x36, 0x19, 0x0f, 0xcd, 0xa4, 0xef, 0x4c, 0x6b,
+	0x2b, 0x79, 0xad, 0x45, 0x99, 0xcb, 0x69, 0x25, 0x03, 0x79, 0x15, 0xaa, 0x15, 0x54, 0xc8, 0xf8,
+	0xc3, 0x12, 0x34, 0x0f, 0x82, 0x30, 0x7e, 0x26, 0xa3, 0xc8, 0x3a, 0x46, 0xb9, 0xaa, 0x05, 0xb8,
+	0x0c, 0x75, 0x22, 0x3a, 0xee, 0x81, 0xd6, 0x65, 0x32, 0x7e, 0xe2, 0xdc, 0xca, 0x57, 0x9f, 0x5b,
+	0xba, 0x92, 0x8a, 0x92, 0x7e, 0x5a, 0x49, 0x26, 0xe7, 0xd5, 0x82, 0x9c, 0x5f, 0xa5, 0x44, 0xc6,
+	0xb7, 0x01, 0x70, 0x7d, 0xdf, 0x50, 0x6a, 0x8c, 0xff, 0x5f, 0x82, 0xa6, 0x69, 0x1d, 0xc5, 0xeb,
+	0x81, 0x1f, 0xcb, 0xf3, 0x58, 0x74, 0xa0, 0xec, 0x3a, 0xc4, 0xd3, 0xba, 0x59, 0x76, 0x1d, 0x5c,
+	0xdd, 0x71, 0x18, 0x8c, 0x47, 0xc4, 0xd2, 0xb6, 0xc9, 0x00, 0xf1, 0xde, 0x71, 0x42, 0x5a, 0x32,
+	0xf2, 0xde, 0x71, 0x42, 0xd2, 0x34, 0xdf, 0x1a, 0x45, 0x27, 0x01, 0x29, 0x4a, 0x95, 0x15, 0x25,
+	0x41, 0xf5, 0x23, 0xd4, 0x34, 0x37, 0x1a, 0x78, 0xd2, 0x0a, 0x7d, 0x19, 0x2a, 0xfe, 0xea, 0x6e,
+	0xb4, 0xc3, 0x08, 0xe3, 0x8f, 0x2a, 0x50, 0x7f, 0x26, 0x87, 0x87, 0x32, 0xbc, 0xb4, 0x88, 0x87,
+	0xa0, 0xd1, 0xbc, 0x03, 0xd7, 0xe1, 0x75, 0xac, 0xbd, 0xf5, 0xf2, 0xeb, 0x85, 0x39, 0xc2, 0x6d,
+	0x3b, 0xf7, 0x82, 0xa1, 0x1b, 0xcb, 0xe1, 0x28, 0xbe, 0x30, 0x1b, 0x0a, 0x35, 0x75, 0x81, 0x37,
+	0xa0, 0xee, 0x49, 0x0b, 0xcf, 0x8c, 0xcf, 0x5c, 0x41, 0xe2, 0x3e, 0x34, 0xac, 0xe1, 0xc0, 0x91,
+	0x96, 0xc3, 0x8b, 0x5a, 0xbb, 0xfe, 0xf2, 0xeb, 0x85, 0xae, 0x35, 0xdc, 0x90, 0x56, 0x7e, 0xec,
+	0x3a, 0x63, 0xc4, 0xa7, 0x28, 0xc3, 0x51, 0x3c, 0x18, 0x8f, 0x1c, 0x2b, 0x96, 0x64, 0x95, 0xab,
+	0x6b, 0xbd, 0x97, 0x5f, 0x2f, 0x5c, 0x47, 0xf4, 0x73, 0xc2, 0xe6, 0xba, 0x41, 0x86, 0x45, 0xf1,
+	0x4a, 0xb6, 0xaf, 0x2c, 0xb4, 0x02, 0xc5, 0x36, 0xcc, 0xd9, 0xde, 0x38, 0xc2, 0x6b, 0xc4, 0xf5,
+	0x8f, 0x82, 0x41, 0xe0, 0x7b, 0x17, 0x74, 0xc0, 0xda, 0xda, 0xfb, 0x2f, 0xbf, 0x5e, 0x78, 0x47,
+	0x11, 0xb7, 0xfd, 0xa3, 0x60, 0xcf, 0xf7, 0x2e, 0x72, 0xe3, 0xcf, 0x4e, 0x90, 0xc4, 0xff, 0x86,
+	0xce, 0x51, 0x10, 0xda, 0x72, 0x90, 0xb2, 0xac, 0x43, 0xe3, 0xcc, 0xbf, 0xfc, 0x7a, 0xe1, 0x06,
+	0x51, 0x9e, 0x5c, 0xe2, 0x5b, 0x2b, 0x8f, 0x47, 0x46, 0x85, 0xf2, 0xd8, 0x0d, 0x7c, 0x32, 0xbd,
+	0xba, 0xa9, 0x20, 0xe3, 0x9f, 0xcb, 0x50, 0xa3, 0x36, 0xe2, 0x21, 0x34, 0x86, 0x74, 0x54, 0x89,
+	0x9d, 0xbb, 0x81, 0xb2, 0x45, 0xb4, 0x65, 0x3e, 0xc3, 0x68, 0xd3, 0x8f, 0xc3, 0x0b, 0x33, 0x69,
+	0x86, 0x3d, 0x62, 0xeb, 0xd0, 0x93, 0x71, 0xa4, 0x74, 0x21, 0xd7, 0xa3, 0xcf, 0x04, 0xd5, 0x43,
+	0x35, 0x9b, 0x94, 0xa7, 0xca, 0x25, 0x79, 0x9a, 0x07, 0xcd, 0x3e, 0x91, 0xf6, 0x69, 0x34, 0x1e,
+	0x2a, 0x69, 0x4b, 0x61, 0x71, 0x0b, 0xda, 0xf4, 0x3d, 0x0a, 0x5c, 0x9f, 0xba, 0xd7, 0xa8, 0x41,
+	0x2b, 0x43, 0xf6, 0xa3, 0xf9, 0x2d, 0x68, 0xe5, 0x17, 0x8b, 0x0e, 0xc9, 0xa9, 0xbc, 0x20, 0xb9,
+	0xab, 0x9a, 0xf8, 0x29, 0x16, 0xa1, 0x46, 0x06, 0x93, 0xa4, 0xae, 0xb9, 0x02, 0xb8, 0x66, 0xee,
+	0x62, 0x32, 0xe1, 0xb3, 0xf2, 0x77, 0x4b, 0x38, 0x4e, 0x7e, 0x0b, 0xf9, 0x71, 0xf4, 0xab, 0xc7,
+	0xe1, 0x2e, 0xb9, 0x71, 0x8c, 0x00, 0x1a, 0x3b, 0xae, 0x2d, 0xfd, 0x88, 0xdc, 0x96, 0x71, 0x24,
+	0x53, 0xe3, 0x86, 0xdf, 0xb8, 0xdf, 0xa1, 0x75, 0xbe, 0x1b, 0x38, 0x32, 0xa2, 0x71, 0xaa, 0x66,
+	0x0a, 0x23, 0x4d, 0x9e, 0x8f, 0xdc, 0xf0, 0xa2, 0xcf, 0x9c, 0xaa, 0x98, 0x29, 0x8c, 0x52, 0x27,
+	0x7d, 0x9c, 0xcc, 0x49, 0x5c, 0x10, 0x05, 0x1a, 0xff, 0x56, 0x85, 0xd6, 0x4f, 0x64, 0x18, 0xec,
+	0x87, 0xc1, 0x28, 0x88, 0x2c, 0x4f, 0xac, 0x16, 0x79, 0xce, 0x67, 0xbb, 0x88, 0xab, 0xcd, 0x37,
+	0x5b, 0x3e, 0x48, 0x0f, 0x81, 0xcf, 0x2c, 0x7f, 0x2a, 0x06, 0xd4, 0xf9, 0xcc, 0xa7, 0xf0, 0x4c,
+	0x51, 0xb0, 0x0d, 0x9f, 0x32, 0xad, 0xb5, 0xc8, 0x0f, 0x45, 0x41, 0x21, 0x1c, 0x5a, 0xe7, 0xcf,
+	0xb7, 0x37, 0xd4, 0xd9, 0x2a, 0x48, 0x71, 0xa1, 0x7f, 0xee, 0xf7, 0x93, 0x43, 0x4d, 0x61, 0xdc,
+	0x29, 0x72, 0x24, 0xda, 0xde, 0xe8, 0xb5, 0x88, 0x94, 0x80, 0xe2, 0x3d, 0xd0, 0x87, 0xd6, 0x39,
+	0x1a, 0xba, 0x6d, 0x87, 0x55, 0xd6, 0xcc, 0x10, 0xe2, 0x03, 0xa8, 0xc4, 0xe7, 0x3e, 0xe9, 0x24,
+	0xfa, 0x45, 0xe8, 0x26, 0xf7, 0xcf, 0x7d, 0x65, 0x12, 0x4d, 0xa4, 0xe1, 0x99, 0xda, 0xae, 0x43,
+	0x6e, 0x90, 0x6e, 0xe2, 0xa7, 0xb8, 0x0d, 0x0d, 0x8f, 0x4f, 0x8b, 0x5c, 0x9d, 0xe6, 0x4a, 0x93,
+	0xed, 0x2b, 0xa1, 0xcc, 0x84, 0x26, 0xee, 0x81, 0x96, 0x70, 0xa7, 0xd7, 0xa4, 0x76, 0xdd, 0x84,
+	0x9f, 0x09, 0x1b, 0xcd, 0xb4, 0x85, 0x78, 0x08, 0xba, 0x23, 0x3d, 0x19, 0xcb, 0x81, 0xcf, 0x06,
+	0xbe, 0xc9, 0x2e, 0xf0, 0x06, 0x21, 0x77, 0x23, 0x53, 0xfe, 0x6c, 0x2c, 0xa3, 0xd8, 0xd4, 0x1c,
+	0x85, 0x10, 0x1f, 0x66, 0x8a, 0xd5, 0xa1, 0xe3, 0xca, 0x33, 0x33, 0x55, 0xa6, 0xef, 0x40, 0x73,
+	0x68, 0xe1, 0xf5, 0xed, 0x5b, 0xbe, 0x2d, 0x49, 0xaf, 0x9b, 0x2b, 0x6f, 0xd1, 0xd1, 0x64, 0xe8,
+	0xfd, 0xc0, 0x73, 0xed, 0x0b, 0x33, 0xdf, 0x72, 0xfe, 0xfb, 0x30, 0x3b, 0x71, 0xda, 0x79, 0xf1,
+	0x6e, 0xb3, 0x78, 0x5f, 0xcf, 0x8b, 0x77, 0x35, 0x27, 0xd2, 0x5f, 0x54, 0x35, 0xad, 0xab, 0x1b,
+	0x7f, 0x5a, 0x85, 0x59, 0xa5, 0x69, 0x27, 0xee, 0xe8, 0x20, 0x56, 0xb6, 0x90, 0x6e, 0x3a, 0x25,
+	0xe4, 0x55, 0x33, 0x01, 0xc5, 0x77, 0xa0, 0x4e, 0xa6, 0x2b, 0xb1, 0x14, 0x0b, 0x99, 0x04, 0xa5,
+	0xdd, 0xd9, 0x72, 0x28, 0xf1, 0x53, 0xcd, 0xc5, 0xb7, 0xa0, 0xf6, 0x95, 0x0c, 0x03, 0xbe, 0xe9,
+	0x9b, 0x2b, 0x37, 0xa7, 0xf5, 0x43, 0xbe, 0xab, 0x6e, 0xdc, 0xf8, 0xbf, 0x2a, 0x68, 0xf0, 0x4d,
+	0x04, 0xed, 0x43, 0xbc, 0xbd, 0x87, 0xc1, 0x99, 0x74, 0x7a, 0x8d, 0xec, 0xb0, 0x94, 0x76, 0x24,
+	0xa4, 0x44, 0xd6, 0xb4, 0xa9, 0xb2, 0xa6, 0xbf, 0x42, 0xd6, 0x26, 0x4e, 0xb9, 0xf9, 0xc6, 0xa7,
+	0xbc, 0x01, 0xcd, 0x1c, 0x43, 0xa7, 0x9c, 0xf0, 0x42, 0xd1, 0x80, 0xe9, 0xa9, 0xf1, 0xce, 0xdb,
+	0xc1, 0x0d, 0x80, 0x8c, 0xbd, 0xbf, 0xad, 0x35, 0x35, 0xfe, 0x5f, 0x09, 0x66, 0xd7, 0x03, 0xdf,
+	0x97, 0x14, 0xde, 0xb0, 0xb0, 0x64, 0x46, 0xa5, 0x74, 0xa5, 0x51, 0xf9, 0x04, 0x6a, 0x11, 0x36,
+	0x56, 0xa3, 0x5f, 0x9b, 0x72, 0xfa, 0x26, 0xb7, 0xc0, 0xab, 0x65, 0x68, 0x9d, 0x0f, 0x46, 0xd2,
+	0x77, 0x5c, 0xff, 0x38, 0xb9, 0x5a, 0x86, 0xd6, 0xf9, 0x3e, 0x63, 0x8c, 0xbf, 0x28, 0x03, 0x7c,
+	0x2e, 0x2d, 0x2f, 0x3e, 0xc1, 0x6b, 0x15, 0x45, 0xc1, 0xf5, 0xa3, 0x98, 0x98, 0xca, 0x16, 0x39,
+	0x85, 0x51, 0x14, 0xd0, 0xbb, 0x90, 0x11, 0x1b, 0x65, 0xdd, 0x4c, 0x40, 0x0a, 0x49, 0x62, 0x2b,
+	0x1e, 0x47, 0xca, 0x0b, 0x51, 0x50, 0xe6, 0x52, 0x55, 0x09, 0xad, 0x5c, 0xaa, 0x1e, 0x34, 0xd0,
+	0xd3, 0xc4, 0x5b, 0xb7, 0xc6, 0xe3, 0x28, 0x10, 0xc7, 0x19, 0x8f, 0x62, 0x77, 0xc8, 0xbe, 0x46,
+	0xc5, 0x54, 0x10, 0xae, 0x0a, 0x7d, 0x8b, 0x4d, 0xfb, 0x24, 0x20, 0xd3, 0x55, 0x31, 0x53, 0x18,
+	0x47, 0x0b, 0xfc, 0xe3, 0x00, 0x77, 0xa7, 0x91, 0xdb, 0x9b, 0x80, 0xbc, 0x17, 0x47, 0x9e, 0x23,
+	0x49, 0x27, 0x52, 0x0a, 0x23, 0x5f, 0xa4, 0x1c, 0x1c, 0x49, 0x2b, 0x1e, 0x87, 0x32, 0xea, 0x01,
+	0x91, 0x41, 0xca, 0x2d, 0x85, 0x11, 0x1f, 0x40, 0x0b, 0x19, 0x67, 0x45, 0x91, 0x7b, 0xec, 0x4b,
+	0x87, 0x24, 0xac, 0x8a, 0xa2, 0x74, 0xbe, 0xaa, 0x50, 0xc6, 0x5f, 0x95, 0xa1, 0xce, 0xd6, 0xa7,
+	0xe0, 0xb6, 0x95, 0xde, 0xc8, 0x6d, 0x7b, 0x0f, 0xf4, 0x51, 0x28, 0x1d, 0xd7, 0x4e, 0xce, 0x51,
+	0x37, 0x33, 0x04, 0x45, 0x84, 0xe8, 0xa7, 0x10, 0x3f, 0x35, 0x93, 0x01, 0x61, 0x40, 0x3b, 0xf0,
+	0x07, 0x8e, 0x1b, 0x9d, 0x0e, 0x0e, 0x2f, 0x62, 0x19, 0x29, 0x5e, 0x34, 0x03, 0x7f, 0xc3, 0x8d,
+	0x4e, 0xd7, 0x10, 0xc5, 0x1e, 0x0d, 0x2a, 0x17, 0x29, 0x95, 0x66, 0x2a, 0x48, 0x3c, 0x06, 0x9d,
+	0xbc, 0x69, 0x72, 0xb7, 0x74, 0x72, 0x93, 0x6e, 0xbc, 0xfc, 0x7a, 0x41, 0x20, 0x72, 0xc2, 0xcf,
+	0xd2, 0x12, 0x1c, 0xfa, 0x8b, 0xd8, 0x19, 0x2f, 0x48, 0x52, 0x7e, 0xf6, 0x17, 0x11, 0xd5, 0x8f,
+	0xf2, 0xfe, 0x22, 0x63, 0xc4, 0x7d, 0x10, 0x63, 0xdf, 0x0e, 0x86, 0x23, 0x14, 0x0a, 0xe9, 0xa8,
+	0x45, 0x36, 0x69, 0x91, 0x73, 0x79, 0x0a, 0x2d, 0xd5, 0xf8, 0xf7, 0x32, 0xb4, 0x36, 0xdc, 0x50,
+	0xda, 0xb1, 0x74, 0x36, 0x9d, 0x63, 0x89, 0x6b, 0x97, 0x7e, 0xec, 0xc6, 0x17, 0xca, 0x21, 0x56,
+	0x50, 0x1a, 0xff, 0x94, 0x8b, 0x19, 0x0a, 0xd6, 0xb0, 0x0a, 0x25, 0x55, 0x18, 0x10, 0x2b, 0x00,
+	0x1c, 0x19, 0x52, 0x62, 0xa5, 0x7a, 0x75, 0x62, 0x45, 0xa7, 0x66, 0xf8, 0x29, 0xde, 0xa1, 0x54,
+	0xcc, 0x58, 0xe2, 0xd9, 0xd5, 0x68, 0xde, 0x06, 0xc1, 0xec, 0x5b, 0x53, 0xc0, 0xda, 0xe0, 0x89,
+	0xf1, 0x5b, 0xdc, 0x82, 0x72, 0x30, 0x22, 0xe6, 0xaa, 0xa1, 0xf3, 0x5b, 0x58, 0xde, 0x1b, 0x99,
+	0xe5, 0x60, 0x84, 0x5a, 0xcc, 0xf9, 0x02, 0x12, 0x3c, 0xd4, 0x62, 0xbc, 0x69, 0x29, 0x56, 0x34,
+	0x15, 0x45, 0x18, 0xd0, 0xb2, 0x3c, 0x2f, 0xf8, 0x52, 0x3a, 0xfb, 0xa1, 0x74, 0x12, 0x19, 0x2c,
+	0xe0, 0x50, 0x4a, 0x7c, 0x6b, 0x28, 0xa3, 0x91, 0xa5, 0x8c, 0x5c, 0xd5, 0xcc, 0x10, 0xc6, 0x22,
+	0x94, 0xf7, 0x46, 0xa2, 0x01, 0x95, 0x83, 0xcd, 0x7e, 0x77, 0x06, 0x3f, 0x36, 0x36, 0x77, 0xba,
+	0x25, 0xa1, 0x41, 0x75, 0x7b, 0x77, 0xdd, 0xec, 0x96, 0xbf, 0xa8, 0x6a, 0xf5, 0x6e, 0xc3, 0xf8,
+	0x83, 0x2a, 0xe8, 0xcf, 0xc6, 0xb1, 0x85, 0x56, 0x26, 0xc2, 0xfd, 0x16, 0x65, 0x35, 0x13, 0xca,
+	0x77, 0x40, 0x4b, 0xc3, 0x7f, 0xbe, 0xe0, 0x1a, 0x11, 0xc7, 0xfe, 0xe2, 0x23, 0xa8, 0x49, 0xe7,
+	0x58, 0x26, 0x37, 0x4e, 0x77, 0x72, 0xe7, 0x26, 0x93, 0xc5, 0x12, 0xd4, 0x23, 0xfb, 0x44, 0x0e,
+	0xad, 0x5e, 0x35, 0x6b, 0x78, 0x40, 0x18, 0x0e, 0x0d, 0x4c, 0x45, 0x17, 0x1f, 0x42, 0x0d, 0x4f,
+	0x29, 0x52, 0xb1, 0x31, 0x45, 0xd3, 0x78, 0x20, 0xaa, 0x19, 0x13, 0x51, 0x04, 0x9d, 0x30, 0x18,
+	0x0d, 0x82, 0x11, 0x9d, 0x42, 0x67, 0xe5, 0x3a, 0x59, 0xbb, 0x64, 0x37, 0xcb, 0x1b, 0x61, 0x30,
+	0xda, 0x1b, 0x99, 0x75, 0x87, 0x7e, 0x31, 0xf2, 0xa2, 0xe6, 0x2c, 0x1b, 0x7c, 0xaf, 0xe8, 0x88,
+	0xe1, 0x44, 0xdc, 0x12, 0x68, 0x43, 0x19, 0x5b, 0x8e, 0x15, 0x5b, 0xea, 0x7a, 0x69, 0xb1, 0xf1,
+	0x64, 0x9c, 0x99, 0x52, 0xc5, 0x0a, 0x34, 0x1d, 0x19, 0xba, 0x67, 0x3c, 0x0d, 0x1d, 0xce, 0xb4,
+	0xcd, 0xe4, 0x1b, 0x89, 0x3b, 0xd0, 0xb0, 0x3c, 0xd7, 0x8a, 0x48, 0xe8, 0xa7, 0xb7, 0x4f, 0x1a,
+	0x88, 0x3b, 0x30, 0x47, 0xfa, 0x68, 0x07, 0xc3, 0xa1, 0x1b, 0xc7, 0x92, 0xe2, 0x5c, 0x76, 0xe5,
+	0x66, 0x91, 0xb0, 0x9e, 0xe0, 0xfb, 0x91, 0xb8, 0x0b, 0x73, 0xd6, 0x08, 0x4d, 0xf8, 0xc0, 0x19,
+	0x8f, 0x3c, 0xb2, 0x10, 0xec, 0x32, 0x69, 0x66, 0x97, 0x09, 0x1b, 0x29, 0xde, 0x78, 0x00, 0x75,
+	0xe6, 0x09, 0x8a, 0xc1, 0xee, 0xde, 0xee, 0x26, 0x4b, 0xc6, 0xea, 0x8e, 0x92, 0x8c, 0x8d, 0xd5,
+	0xfe, 0x6a, 0xb7, 0x8c, 0x5f, 0xfd, 0x1f, 0xef, 0x6f, 0x76, 0x2b, 0xc6, 0xdf, 0x95, 0x40, 0x4b,
+	0x18, 0x20, 0x3e, 0x03, 0x40, 0x2b, 0x34, 0x38, 0x71, 0xfd, 0xd4, 0x2b, 0x7e, 0x37, 0xcf, 0xa2,
+	0x65, 0x14, 0xcc, 0xcf, 0x91, 0xca, 0xae, 0x05, 0x19, 0x2d, 0x82, 0xe7, 0x0f, 0xa0, 0x53, 0x24,
+	0x4e, 0x09, 0x0f, 0xee, 0xe6, 0x2f, 0xc6, 0x8e, 0xba, 0xb1, 0x93, 0xa1, 0xb1, 0x27, 0x69, 0x67,
+	0xee, 0x8e, 0xbc, 0x0f, 0x5a, 0x82, 0x16, 0x4d, 0x68, 0x6c, 0x6c, 0x6e, 0xad, 0x3e, 0xdf, 0x41,
+	0x69, 0x07, 0xa8, 0x1f, 0x6c, 0xef, 0x3e, 0xd9, 0xd9, 0xe4, 0x6d, 0xed, 0x6c, 0x1f, 0xf4, 0xbb,
+	0x65, 0xe3, 0xf7, 0x4a, 0xa0, 0x25, 0x5e, 0x9c, 0xf8, 0x04, 0x1d, 0x2f, 0xf2, 0x6c, 0xd5, 0x65,
+	0x4a, 0x89, 0xc0, 0x5c, 0x0e, 0xc0, 0x4c, 0xe8, 0x68, 0x4e, 0xe8, 0x6e, 0x48, 0xfc, 0x3a, 0x02,
+	0xf2, 0x29, 0x88, 0x4a, 0x21, 0x8f, 0x27, 0xa0, 0xea, 0x04, 0xbe, 0x54, 0x51, 0x06, 0x7d, 0x93,
+	0xf2, 0xb8, 0xbe, 0x2d, 0xb3, 0x18, 0xac, 0x41, 0x70, 0x3f, 0x32, 0x62, 0x0e, 0x3e, 0xd2, 0x85,
+	0xa5, 0xb3, 0x95, 0xf2, 0xb3, 0x5d, 0x8a, 0xe4, 0xca, 0x97, 0x23, 0xb9, 0xec, 0xee, 0xaf, 0xbd,
+	0xee, 0xee, 0x37, 0x7e, 0x51, 0x85, 0x8e, 0x29, 0xa3, 0x38, 0x08, 0xa5, 0x72, 0xa6, 0x5f, 0xa5,
+	0xfb, 0xef, 0x03, 0x84, 0xdc, 0x38, 0x9b, 0x5a, 0x57, 0x18, 0x0e, 0x41, 0xbd, 0xc0, 0x26, 0x41,
+	0x57, 0x97, 0x7c, 0x0a, 0x8b, 0x77, 0x41, 0x3f, 0xb4, 0xec, 0x53, 0x1e, 0x96, 0xaf, 0x7a, 0x8d,
+	0x11, 0x3c, 0xae, 0x65, 0xdb, 0x32, 0x8a, 0x06, 0x28, 0x0a, 0x7c, 0xe1, 0xeb, 0x8c, 0x79, 0x2a,
+	0x2f, 0x90, 0x1c, 0x49, 0x3b, 0x94, 0x31, 0x91, 0xeb, 0x4c, 0x66, 0x0c, 0x92, 0x6f, 0x41, 0x3b,
+	0x92, 0x11, 0x3a, 0x07, 0x83, 0x38, 0x38, 0x95, 0xbe, 0x32, 0xc5, 0x2d, 0x85, 0xec, 0x23, 0x0e,
+	0xad, 0xa4, 0xe5, 0x07, 0xfe, 0xc5, 0x30, 0x18, 0x47, 0xea, 0xda, 0xcb, 0x10, 0x62, 0x19, 0xae,
+	0x49, 0xdf, 0x0e, 0x2f, 0x46, 0xb8, 0x56, 0x9c, 0x65, 0x70, 0xe4, 0x7a, 0x52, 0xc5, 0x37, 0x73,
+	0x19, 0xe9, 0xa9, 0xbc, 0xd8, 0x72, 0x3d, 0x89, 0x2b, 0x3a, 0xb3, 0xc6, 0x5e, 0x3c, 0xa0, 0xb4,
+	0x0a, 0xf0, 0x8a, 0x08, 0xb3, 0xea, 0x38, 0x21, 0x2a, 0x2e, 0x93, 0xc3, 0xc0, 0x93, 0xae, 0xc3,
+	0x83, 0x35, 0xa9, 0xd5, 0x2c, 0x11, 0x4c, 0xc2, 0xd3, 0x50, 0xcb, 0x70, 0x8d, 0xdb, 0xf2, 0x86,
+	0x92, 0xd6, 0x2d, 0x9e, 0x9a, 0x48, 0x07, 0x8a, 0x52, 0x9c, 0x7a, 0x64, 0xc5, 0x27, 0xa4, 0xe1,
+	0xc9, 0xd4, 0xfb, 0x56, 0x7c, 0x82, 0x4e, 0x0b, 0x93, 0x8f, 0x5c, 0xe9, 0x71, 0xb2, 0x43, 0x37,
+	0xb9, 0xc7, 0x16, 0x62, 0xd0, 0x69, 0x51, 0x0d, 0x82, 0x70, 0x68, 0xc5, 0x2a, 0xa9, 0xc1, 0x9d,
+	0xb6, 0x08, 0x85, 0x53, 0xa8, 0xb3, 0xf2, 0xc7, 0x43, 0xca, 0x2c, 0x57, 0x4d, 0x75, 0x7a, 0xbb,
+	0xe3, 0xa1, 0xf1, 0xb2, 0x02, 0x5a, 0x1a, 0x23, 0xdf, 0x05, 0x7d, 0x98, 0x18, 0x5a, 0xe5, 0x6b,
+	0xb6, 0x0b, 0xd6, 0xd7, 0xcc, 0xe8, 0xe2, 0x7d, 0x28, 0x9f, 0x9e, 0x29, 0xa3, 0xdf, 0x5e, 0xe6,
+	0xfa, 0xca, 0xe8, 0xf0, 0xf1, 0xf2, 0xd3, 0x17, 0x66, 0xf9, 0xf4, 0xec, 0x1b, 0xc8, 0xad, 0xf8,
+	0x18, 0x66, 0x6d, 0x4f, 0x5a, 0xfe, 0x20, 0x73, 0x90, 0x58, 0x2e, 0x3a, 0x84, 0xde, 0x4f, 0xbd,
+	0xa4, 0xdb, 0x50, 0x73, 0xa4, 0x17, 0x5b, 0xf9, 0x34, 0xff, 0x5e, 0x68, 0xd9, 0x9e, 0xdc, 0x40,
+	0xb4, 0xc9, 0x54, 0x34, 0xfa, 0x69, 0x5c, 0x9a, 0x33, 0xfa, 0x53, 0x62, 0xd2, 0x54, 0x2f, 0x21,
+	0xaf, 0x97, 0x77, 0x61, 0x4e, 0x9e, 0x8f, 0xe8, 0xa6, 0x1b, 0xa4, 0x69, 0x18, 0xbe, 0x8c, 0xbb,
+	0x09, 0x61, 0x3d, 0x49, 0xc7, 0xdc, 0x43, 0x93, 0x41, 0x4a, 0x43, 0xc7, 0xdc, 0x5c, 0x11, 0x64,
+	0x73, 0x0a, 0x6a, 0x68, 0x26, 0x4d, 0xc4, 0x27, 0xa0, 0xdb, 0x8e, 0x3d, 0x60, 0xce, 0xb4, 0xb3,
+	0xb5, 0xad, 0x6f, 0xac, 0x33, 0x4b, 0x34, 0xdb, 0xb1, 0x39, 0x30, 0x28, 0xc4, 0xcb, 0x9d, 0x37,
+	0x89, 0x97, 0xf3, 0xb7, 0x79, 0xb7, 0x70, 0x9b, 0x7f, 0x51, 0xd5, 0x1a, 0x5d, 0xcd, 0xb8, 0x05,
+	0x5a, 0x32, 0x11, 0x9a, 0xba, 0x48, 0xfa, 0x2a, 0x17, 0x42, 0xa6, 0x0e, 0xc1, 0x7e, 0x64, 0xd8,
+	0x50, 0x79, 0xfa, 0xe2, 0x80, 0x2c, 0x1e, 0xde, 0x9a, 0x35, 0x72, 0xb7, 0xe8, 0x3b, 0xb5, 0x82,
+	0xe5, 0x9c, 0x15, 0xbc, 0xc9, 0x17, 0x88, 0xba, 0xa4, 0x38, 0x11, 0x9d, 0xc3, 0x20, 0x8b, 0xf9,
+	0xd6, 0xaf, 0x72, 0x8e, 0x9a, 0x00, 0xe3, 0x3f, 0x2a, 0xd0, 0x50, 0x2e, 0x1a, 0x5e, 0x1a, 0xe3,
+	0x34, 0x27, 0x8a, 0x9f, 0xc5, 0xa0, 0x3b, 0xf5, 0xf5, 0xf2, 0x25, 0xb4, 0xca, 0xeb, 0x4b, 0x68,
+	0xe2, 0x33, 0x68, 0x8d, 0x98, 0x96, 0xf7, 0x0e, 0xdf, 0xce, 0xf7, 0x51, 0xbf, 0xd4, 0xaf, 0x39,
+	0xca, 0x00, 0x64, 0x25, 0x65, 0xf3, 0x63, 0xeb, 0x58, 0x71, 0xa0, 0x81, 0x70, 0xdf, 0x3a, 0x7e,
+	0x23, 0x57, 0xaf, 0x43, 0x3e, 0x63, 0x8b, 0x0c, 0x2e, 0xba, 0x87, 0xf9, 0x93, 0x69, 0x17, 0xfd,
+	0xac, 0x77, 0x41, 0x67, 0x97, 0x60, 0x10, 0xf3, 0x31, 0x57, 0x4d, 0x8d, 0x11, 0xfd, 0x08, 0x6f,
+	0xb7, 0x86, 0xda, 0xd7, 0xa5, 0xcb, 0x70, 0x6d, 0x7b, 0x77, 0xd5, 0xfc, 0x71, 0xb7, 0x84, 0x97,
+	0xfd, 0xf6, 0x6e, 0xbf, 0x5b, 0x16, 0x3a, 0xd4, 0xb6, 0x76, 0xf6, 0x56, 0xfb, 0xdd, 0x0a, 0x5e,
+	0x90, 0x6b, 0x7b, 0x7b, 0x3b, 0xdd, 0xaa, 0x68, 0x81, 0xb6, 0xb1, 0xda, 0xdf, 0xec, 0x6f, 0x3f,
+	0xdb, 0xec, 0xd6, 0xb0, 0xed, 0x93, 0xcd, 0xbd, 0x6e, 0x1d, 0x3f, 0x9e, 0x6f, 0x6f, 0x74, 0x1b,
+	0x48, 0xdf, 0x5f, 0x3d, 0x38, 0xf8, 0xe1, 0x9e, 0xb9, 0xd1, 0xd5, 0xe8, 0x92, 0xed, 0x9b, 0xdb,
+	0xbb, 0x4f, 0xba, 0x3a, 0x7e, 0xef, 0xad, 0x7d, 0xb1, 0xb9, 0xde, 0xef, 0x02, 0x4f, 0xbe, 0xbe,
+	0xfd, 0x6c, 0x75, 0xa7, 0xdb, 0x34, 0x1e, 0x41, 0x33, 0xc7, 0x38, 0x1c, 0xca, 0xdc, 0xdc, 0xea,
+	0xce, 0xe0, 0xfc, 0x2f, 0x56, 0x77, 0x9e, 0xe3, 0x05, 0xdd, 0x01, 0xa0, 0xcf, 0xc1, 0xce, 0xea,
+	0xee, 0x93, 0xd4, 0x2f, 0xfd, 0x01, 0x68, 0xcf, 0x5d, 0x67, 0xcd, 0x0b, 0xec, 0x53, 0x94, 0xa5,
+	0x43, 0x2b, 0x92, 0x4a, 0xf8, 0xe8, 0x1b, 0xe3, 0x01, 0xd2, 0xe0, 0x48, 0x1d, 0xbc, 0x82, 0x90,
+	0x7d, 0xfe, 0x78, 0x38, 0xa0, 0x9a, 0x6b, 0x85, 0x6f, 0x31, 0x7f, 0x3c, 0x7c, 0xee, 0x3a, 0x91,
+	0x71, 0x0a, 0x8d, 0xe7, 0xae, 0xb3, 0x6f, 0xd9, 0xa7, 0x64, 0xe9, 0x70, 0xe8, 0x41, 0xe4, 0x7e,
+	0x25, 0xd5, 0x6d, 0xa7, 0x13, 0xe6, 0xc0, 0xfd, 0x4a, 0x8a, 0x0f, 0xa1, 0x4e, 0x40, 0x92, 0x7b,
+	0x21, 0xbd, 0x4b, 0x96, 0x63, 0x2a, 0x1a, 0x95, 0x3c, 0x3d, 0x2f, 0xb0, 0x07, 0xa1, 0x3c, 0xea,
+	0xbd, 0xcd, 0xc7, 0x41, 0x08, 0x53, 0x1e, 0x19, 0xbf, 0x5b, 0x4a, 0x77, 0x4e, 0xf5, 0xad, 0x05,
+	0xa8, 0x8e, 0x2c, 0xfb, 0x54, 0x39, 0x1b, 0x4d, 0x35, 0x20, 0x2e, 0xc6, 0x24, 0x82, 0xf8, 0x18,
+	0x34, 0x25, 0x55, 0xc9, 0xac, 0xcd, 0x9c, 0xf8, 0x99, 0x29, 0xb1, 0x28, 0x05, 0x95, 0xa2, 0x14,
+	0x50, 0xb4, 0x3d, 0xf2, 0xdc, 0x98, 0x75, 0x08, 0x35, 0x95, 0x20, 0xe3, 0x5b, 0x00, 0x59, 0x91,
+	0x73, 0x8a, 0xef, 0x75, 0x1d, 0x6a, 0xe4, 0x7d, 0xaa, 0x38, 0x8a, 0x01, 0x63, 0x17, 0x9a, 0xb9,
+	0xd2, 0x28, 0xf2, 0xd6, 0xf2, 0x3c, 0xbc, 0x26, 0xd9, 0x10, 0x68, 0xe8, 0xb2, 0x7a, 0x4f, 0xe5,
+	0x45, 0x84, 0x0e, 0x3b, 0x57, 0x55, 0xcb, 0x13, 0xe5, 0x2f, 0xea, 0x6a, 0x32, 0xd1, 0xb8, 0x07,
+	0xf5, 0xad, 0x24, 0xc0, 0x49, 0x34, 0xa3, 0x74, 0x95, 0x66, 0x18, 0x9f, 0xaa, 0x35, 0x53, 0x05,
+	0x4d, 0xdc, 0x55, 0xd5, 0xdb, 0x88, 0x6b, 0xc5, 0xa5, 0x2c, 0x71, 0xc4, 0x8d, 0x54, 0xe1, 0x96,
+	0x1a, 0x1b, 0x1b, 0xa0, 0xbd, 0xb2, 0x1e, 0xae, 0x18, 0x50, 0xce, 0x18, 0x30, 0xa5, 0x42, 0x6e,
+	0xfc, 0x14, 0x20, 0xab, 0xf2, 0x2a, 0x45, 0xe5, 0x51, 0x50, 0x51, 0xef, 0x80, 0x66, 0x9f, 0xb8,
+	0x9e, 0x13, 0x4a, 0xbf, 0xb0, 0xeb, 0xac, 0x2e, 0x9c, 0xd2, 0xc5, 0x22, 0x54, 0xa9, 0x78, 0x5d,
+	0xc9, 0xcc, 0x78, 0x5a, 0xb9, 0x26, 0x8a, 0x71, 0x0e, 0x6d, 0x0e, 0x06, 0xde, 0xc0, 0x1d, 0x2b,
+	0xda, 0xd1, 0xf2, 0x25, 0x3b, 0x7a, 0x03, 0xea, 0xe4, 0x05, 0x24, 0xbb, 0x51, 0xd0, 0x15, 0xf6,
+	0xf5, 0x77, 0xaa, 0x00, 0x3c, 0xf5, 0x6e, 0xe0, 0xc8, 0x62, 0xf2, 0xa1, 0x34, 0x99, 0x7c, 0x10,
+	0x50, 0x4d, 0xdf, 0x25, 0xe8, 0x26, 0x7d, 0x67, 0x37, 0xa3, 0x4a, 0x48, 0xf0, 0xcd, 0xf8, 0x1e,
+	0xe8, 0xe4, 0x95, 0xb9, 0x5f, 0x51, 0xa9, 0x09, 0x27, 0xcc, 0x10, 0x57, 0x97, 0x18, 0xb3, 0x42,
+	0x60, 0x3d, 0x5f, 0x92, 0x9c, 0x52, 0x03, 0xe5, 0x8c, 0x50, 0x24, 0xc3, 0x38, 0x49, 0x67, 0x30,
+	0x94, 0x46, 0xe6, 0xba, 0x6a, 0x6b, 0x71, 0x4e, 0xc7, 0x0f, 0x06, 0x76, 0xe0, 0x1f, 0x79, 0xae,
+	0x1d, 0xab, 0xaa, 0x3c, 0xf8, 0xc1, 0xba, 0xc2, 0x20, 0x4f, 0xc7, 0xbe, 0xa3, 0x82, 0x56, 0xba,
+	0xc1, 0x35, 0x33, 0x87, 0xc1, 0x05, 0x53, 0x38, 0x27, 0x9d, 0x5e, 0x8b, 0x53, 0x49, 0x0a, 0x44,
+	0xca, 0xc8, 0x8a, 0x63, 0x19, 0xfa, 0xca, 0x2b, 0x4b, 0x40, 0x4a, 0x8a, 0xa9, 0x98, 0xaf, 0xc3,
+	0x7d, 0x92, 0x08, 0x2f, 0x4d, 0x7e, 0xcd, 0xe6, 0xeb, 0x89, 0x0b, 0xd0, 0x94, 0x68, 0xbc, 0x54,
+	0xed, 0xbc, 0xab, 0x12, 0x4f, 0xfe, 0x78, 0x48, 0x11, 0x6a, 0x24, 0x16, 0xa1, 0x69, 0x5b, 0xa1,
+	0xe3, 0xfa, 0x96, 0xe7, 0xc6, 0x17, 0x54, 0xe3, 0xd7, 0xcd, 0x3c, 0x4a, 0xdc, 0x07, 0x91, 0x03,
+	0x07, 0xa1, 0xfc, 0xa9, 0xb4, 0x63, 0xaa, 0xf7, 0x6b, 0xe6, 0x5c, 0x8e, 0x62, 0x12, 0x21, 0xd1,
+	0x83, 0x6b, 0x44, 0xc7, 0x4f, 0xe3, 0x33, 0x68, 0x25, 0x72, 0x48, 0x25, 0xd4, 0x3b, 0x69, 0xcc,
+	0x5e, 0xca, 0x64, 0x3c, 0x13, 0x97, 0xb5, 0x72, 0xaf, 0x94, 0x44, 0xed, 0xc6, 0x5f, 0xd6, 0x93,
+	0xce, 0xaa, 0xd2, 0xf7, 0x6a, 0x59, 0x2a, 0x26, 0x64, 0xca, 0x6f, 0x94, 0x90, 0xf9, 0x2e, 0xe8,
+	0x7c, 0x24, 0xee, 0x59, 0x72, 0xb3, 0xcf, 0x4f, 0x06, 0xd2, 0x2a, 0xf7, 0xe0, 0x9e, 0x49, 0x33,
+	0x6b, 0xfc, 0x1a, 0x79, 0x4c, 0xa5, 0xae, 0x36, 0x4d, 0xea, 0xea, 0xbf, 0xa5, 0xd4, 0x7d, 0x00,
+	0x2d, 0x3f, 0xf0, 0x07, 0xfe, 0xd8, 0xf3, 0xac, 0x43, 0x4f, 0x2a, 0xb1, 0x6b, 0xfa, 0x81, 0xbf,
+	0xab, 0x50, 0x18, 0x32, 0xe4, 0x9b, 0xb0, 0x71, 0x63, 0xf1, 0x9b, 0xcd, 0xb5, 0x23, 0x13, 0xb8,
+	0x04, 0xdd, 0xe0, 0x10, 0xcf, 0x8d, 0x38, 0x36, 0x20, 0xab, 0xc6, 0xf1, 0x42, 0x87, 0xf1, 0xc8,
+	0xa2, 0x5d, 0xb4, 0x6f, 0x13, 0xe2, 0xde, 0xbe, 0x24, 0xee, 0xb7, 0xa0, 0xed, 0x48, 0xdb, 0x1d,
+	0x5a, 0xde, 0x20, 0xb2, 0x2d, 0x8f, 0x1f, 0x9d, 0xb4, 0xcd, 0x96, 0x42, 0x1e, 0x20, 0x6e, 0x42,
+	0x27, 0x66, 0x2f, 0xe9, 0xc4, 0x7d, 0x10, 0x29, 0x07, 0x07, 0x2a, 0x4f, 0x9b, 0x88, 0xed, 0x5c,
+	0x4a, 0x79, 0xa1, 0x08, 0x79, 0x15, 0x9a, 0xbb, 0x52, 0x85, 0xc4, 0x95, 0x2a, 0x74, 0xad, 0xa8,
+	0x42, 0x13, 0xca, 0x72, 0xfd, 0x75, 0xca, 0xf2, 0xd6, 0x9b, 0x2a, 0xcb, 0x8d, 0xd7, 0x28, 0xcb,
+	0xdb, 0x99, 0xb2, 0x7c, 0x0a, 0x7a, 0x2a, 0x6b, 0xb9, 0x94, 0x8a, 0x0e, 0xb5, 0xed, 0xdd, 0x8d,
+	0xcd, 0x1f, 0x75, 0x4b, 0xe8, 0x0c, 0x99, 0x9b, 0x2f, 0x36, 0xcd, 0x83, 0xcd, 0x6e, 0x19, 0xbd,
+	0xa4, 0x8d, 0xcd, 0x9d, 0xcd, 0xfe, 0x66, 0xb7, 0xc2, 0x5e, 0x36, 0x95, 0x27, 0x3d, 0xd7, 0x76,
+	0x63, 0xe3, 0xef, 0x4b, 0x00, 0x59, 0x86, 0x0b, 0x2f, 0xf9, 0xec, 0x8c, 0x55, 0xb2, 0x3d, 0x4e,
+	0x4e, 0x77, 0x29, 0xb5, 0xef, 0xe5, 0xab, 0xf2, 0x68, 0xca, 0xe2, 0x2f, 0x40, 0x33, 0x0a, 0x8e,
+	0xe2, 0x01, 0xfb, 0xfd, 0x2a, 0x38, 0x07, 0x44, 0x71, 0x68, 0x20, 0x6e, 0x43, 0xc7, 0xb6, 0x22,
+	0xdb, 0x72, 0x64, 0xd2, 0x86, 0x63, 0xf4, 0xb6, 0xc2, 0xaa, 0x66, 0x0f, 0xe1, 0x7a, 0xb1, 0xd9,
+	0x80, 0x53, 0xd0, 0xac, 0x2d, 0xa2, 0xd0, 0x78, 0x0b, 0x29, 0xc6, 0x0a, 0xe8, 0xcf, 0xac, 0xd1,
+	0xe7, 0xfc, 0xb6, 0xe0, 0x36, 0x74, 0x46, 0x56, 0x18, 0xbb, 0x49, 0x94, 0xcd, 0xb7, 0x7e, 0xcb,
+	0x6c, 0xa7, 0x58, 0x74, 0x22, 0x8c, 0x3f, 0x2f, 0xc1, 0xf5, 0x67, 0xc1, 0x99, 0x4c, 0xa3, 0xb8,
+	0x7d, 0xeb, 0xc2, 0x0b, 0x2c, 0xe7, 0x35, 0x76, 0xe4, 0x7d, 0x80, 0x28, 0x18, 0x53, 0xad, 0x3f,
+	0x79, 0x19, 0x61, 0xea, 0x8c, 0x79, 0xa2, 0x1e, 0x9f, 0xc9, 0x28, 0x26, 0xa2, 0xf2, 0x08, 0x11,
+	0x46, 0xd2, 0x5b, 0x50, 0x8f, 0xcf, 0xfd, 0xec, 0x9d, 0x46, 0x2d, 0xa6, 0xba, 0xd6, 0xd4, 0xa0,
+	0xae, 0x36, 0x3d, 0xa8, 0x33, 0xd6, 0x41, 0xef, 0xd3, 0x33, 0xa8, 0x78, 0x5c, 0x0c, 0xab, 0x4a,
+	0xaf, 0x70, 0xde, 0xcb, 0x13, 0xce, 0xfb, 0xbf, 0x96, 0xa0, 0x99, 0x8b, 0x4e, 0xc5, 0x07, 0x50,
+	0x8d, 0xcf, 0xfd, 0xe2, 0xf3, 0xa9, 0x64, 0x12, 0x93, 0x48, 0x97, 0x8a, 0x10, 0xe5, 0x4b, 0x45,
+	0x08, 0xb1, 0x03, 0xb3, 0xec, 0x42, 0x24, 0x9b, 0x48, 0x32, 0xb4, 0xb7, 0x26, 0xa2, 0x61, 0x2e,
+	0x62, 0x25, 0x5b, 0x52, 0xd9, 0xbb, 0xce, 0x71, 0x01, 0x39, 0xbf, 0x0a, 0xd7, 0xa6, 0x34, 0xfb,
+	0x26, 0x75, 0x50, 0x63, 0x01, 0xda, 0xfd, 0x73, 0xbf, 0xef, 0x0e, 0x65, 0x14, 0x5b, 0xc3, 0x11,
+	0x05, 0x3f, 0xca, 0x05, 0xac, 0x9a, 0xe5, 0x38, 0x32, 0x3e, 0x82, 0xd6, 0xbe, 0x94, 0xa1, 0x29,
+	0xa3, 0x51, 0xe0, 0xb3, 0x97, 0xaf, 0x8a, 0x47, 0xec, 0x6f, 0x2a, 0xc8, 0xf8, 0xbf, 0xa0, 0x9b,
+	0xd6, 0x51, 0xbc, 0x66, 0xc5, 0xf6, 0xc9, 0x37, 0x49, 0xe5, 0x7d, 0x84, 0x86, 0x86, 0x64, 0x4a,
+	0xe5, 0x2c, 0x5a, 0xe4, 0x77, 0x2a, 0x39, 0x33, 0x13, 0xa2, 0xf1, 0xbf, 0xa0, 0xa3, 0x6a, 0xc7,
+	0xc9, 0x4a, 0x72, 0x05, 0xe6, 0xd2, 0x95, 0x05, 0x66, 0xe3, 0x18, 0xda, 0x49, 0x3f, 0xf6, 0xe2,
+	0xde, 0xa8, 0xdb, 0x37, 0x7f, 0xd9, 0x63, 0xfc, 0x1f, 0xb8, 0x76, 0x30, 0x3e, 0x8c, 0xec, 0xd0,
+	0xa5, 0xfc, 0x54, 0x32, 0xdd, 0x3c, 0x68, 0xa3, 0x50, 0x1e, 0xb9, 0xe7, 0x32, 0x51, 0xb1, 0x14,
+	0x16, 0x77, 0xa0, 0x31, 0x44, 0x7e, 0xc9, 0xcc, 0x6c, 0x64, 0x99, 0x98, 0x67, 0x48, 0x31, 0x93,
+	0x06, 0xc6, 0xf7, 0xe0, 0x7a, 0x71, 0x78, 0xc5, 0x85, 0x5b, 0x50, 0x39, 0x3d, 0x8b, 0x14, 0x9b,
+	0xe7, 0x0a, 0x99, 0x1c, 0x7a, 0x52, 0x85, 0x54, 0xe3, 0x4f, 0x4a, 0x50, 0xd9, 0x1d, 0x0f, 0xf3,
+	0x2f, 0x5b, 0xab, 0xfc, 0xb2, 0xf5, 0xdd, 0x7c, 0xa1, 0x89, 0x33, 0x03, 0x59, 0x41, 0xe9, 0x3d,
+	0xd0, 0x8f, 0x82, 0xf0, 0x4b, 0x2b, 0x74, 0xa4, 0xa3, 0x5c, 0xc9, 0x0c, 0x21, 0x6e, 0x2b, 0xc7,
+	0x93, 0x23, 0xf3, 0x39, 0xe4, 0xe2, 0xee, 0x78, 0xb8, 0xec, 0x49, 0x2b, 0x22, 0xcf, 0x80, 0x7d,
+	0x51, 0xe3, 0x2e, 0xe8, 0x29, 0x0a, 0xed, 0xf0, 0xee, 0xc1, 0x60, 0x7b, 0x83, 0xb3, 0xdc, 0x18,
+	0xc3, 0x96, 0xd0, 0x06, 0xf7, 0x7f, 0xb4, 0x3b, 0xe8, 0x1f, 0x74, 0xcb, 0xc6, 0x4f, 0xa0, 0x99,
+	0xe8, 0xca, 0xb6, 0x43, 0xf7, 0x13, 0x29, 0xeb, 0xb6, 0x53, 0xd0, 0xdd, 0x6d, 0x4a, 0x32, 0x48,
+	0xdf, 0xd9, 0x4e, 0x94, 0x8c, 0x81, 0xe2, 0x6e, 0x54, 0x6d, 0x3c, 0xd9, 0x8d, 0xb1, 0x09, 0x73,
+	0x26, 0x55, 0xd7, 0xd0, 0x4b, 0x4a, 0x8e, 0xe7, 0x06, 0xd4, 0xfd, 0xc0, 0x91, 0xe9, 0x04, 0x0a,
+	0xc2, 0x99, 0xd5, 0xc1, 0x2a, 0xf3, 0x95, 0x9e, 0xb3, 0x84, 0x39, 0xb4, 0x88, 0x45, 0xa1, 0x2a,
+	0x54, 0x7e, 0x4a, 0x13, 0x95, 0x1f, 0x9c, 0x44, 0x3d, 0x2b, 0x61, 0x27, 0x3d, 0x79, 0x4a, 0x32,
+	0x0f, 0x9a, 0x13, 0xc5, 0xa4, 0xc2, 0xca, 0x0e, 0xa6, 0xb0, 0xf1, 0x00, 0xae, 0xad, 0x8e, 0x46,
+	0xde, 0x45, 0x52, 0x4b, 0x57, 0x13, 0xf5, 0xb2, 0x82, 0x7b, 0x49, 0x65, 0x36, 0x18, 0x34, 0xb6,
+	0xa0, 0x95, 0xe4, 0xc8, 0x9e, 0xc9, 0xd8, 0x22, 0xeb, 0xe6, 0xb9, 0x85, 0x24, 0x91, 0xc6, 0x88,
+	0x7e, 0xb1, 0xaa, 0x34, 0xb1, 0xbf, 0x65, 0xa8, 0x2b, 0xd3, 0x29, 0xa0, 0x6a, 0x07, 0x0e, 0x4f,
+	0x54, 0x33, 0xe9, 0x1b, 0x25, 0x68, 0x18, 0x1d, 0x27, 0x61, 0xda, 0x30, 0x3a, 0x36, 0xfe, 0xb1,
+	0x0c, 0xed, 0x35, 0xca, 0x48, 0x26, 0x6b, 0xcc, 0xe5, 0xe1, 0x4b, 0x85, 0x3c, 0x7c, 0x3e, 0xe7,
+	0x5e, 0x2e, 0xe4, 0xdc, 0x0b, 0x0b, 0xaa, 0x14, 0x63, 0xab, 0xb7, 0xa1, 0x31, 0xf6, 0xdd, 0xf3,
+	0xe4, 0x4e, 0xd0, 0xcd, 0x3a, 0x82, 0x7d, 0x72, 0x27, 0xf0, 0xda, 0x70, 0x7d, 0xce, 0x73, 0x73,
+	0xb2, 0x3a, 0x8f, 0x9a, 0xc8, 0x66, 0xd7, 0x5f, 0x9d, 0xcd, 0x6e, 0xbc, 0x36, 0x9b, 0xad, 0xbd,
+	0x2e, 0x9b, 0xad, 0x4f, 0x66, 0xb3, 0x8b, 0x71, 0x21, 0x5c, 0x8a, 0x0b, 0xdf, 0x07, 0xe0, 0x37,
+	0x71, 0x47, 0x63, 0xcf, 0x53, 0x4e, 0xa6, 0x4e, 0x98, 0xad, 0xb1, 0xe7, 0x19, 0x3b, 0xd0, 0x49,
+	0x58, 0xab, 0xd4, 0xfd, 0x33, 0x98, 0x55, 0x05, 0x36, 0x19, 0xaa, 0x54, 0x2f, 0x5b, 0x31, 0xd2,
+	0x3f, 0x2e, 0x25, 0x29, 0x8a, 0xd9, 0x71, 0xf2, 0x60, 0x64, 0xfc, 0xbc, 0x04, 0xed, 0x42, 0x0b,
+	0xf1, 0x28, 0x2b, 0xd7, 0x95, 0x48, 0x8b, 0x7b, 0x97, 0x46, 0x79, 0x75, 0xc9, 0xae, 0x3c, 0x51,
+	0xb2, 0x33, 0xee, 0xa7, 0xf5, 0x2c, 0x55, 0xc5, 0x9a, 0x49, 0xab, 0x58, 0x54, 0xf8, 0x59, 0xed,
+	0xf7, 0xcd, 0x6e, 0x59, 0xd4, 0xa1, 0xbc, 0x7b, 0xd0, 0xad, 0x18, 0xbf, 0x29, 0x43, 0x7b, 0xf3,
+	0x7c, 0x44, 0xef, 0x43, 0x5f, 0x1b, 0x64, 0xe7, 0xe4, 0xaa, 0x5c, 0x90, 0xab, 0x9c, 0x84, 0x54,
+	0xd4, 0x4b, 0x04, 0x96, 0x10, 0x0c, 0xbb, 0x39, 0xb7, 0xae, 0x24, 0x87, 0xa1, 0xff, 0x09, 0x92,
+	0x53, 0xb0, 0x28, 0x30, 0xc5, 0xa2, 0x1c, 0x8e, 0x7d, 0x47, 0xd5, 0x32, 0x34, 0x53, 0x41, 0xe8,
+	0x5d, 0xba, 0xbe, 0xed, 0x8d, 0x1d, 0x39, 0xb0, 0x6c, 0x8f, 0x42, 0x11, 0xcd, 0x04, 0x85, 0x5a,
+	0xb5, 0x49, 0xa2, 0x12, 0x7e, 0x2b, 0x89, 0x7a, 0x23, 0x2d, 0xe7, 0x47, 0xef, 0x5e, 0x9a, 0x23,
+	0x66, 0xc0, 0xf8, 0xb3, 0x32, 0xe8, 0x2c, 0xa0, 0xb8, 0xeb, 0x4f, 0xd4, 0x85, 0x50, 0xca, 0x8a,
+	0x85, 0x29, 0x71, 0xf9, 0xa9, 0xbc, 0xc8, 0x2e, 0x85, 0xa9, 0x6f, 0x04, 0x54, 0x26, 0x99, 0xf3,
+	0x67, 0x94, 0x49, 0x7e, 0x17, 0x74, 0xf6, 0xdd, 0xc6, 0xaa, 0x52, 0x55, 0x35, 0xd9, 0x99, 0x7b,
+	0xee, 0x52, 0xb5, 0x3f, 0x96, 0xe1, 0x50, 0x1d, 0x1e, 0x7d, 0x17, 0x33, 0x15, 0xed, 0x24, 0x66,
+	0x2c, 0xb0, 0xb2, 0x31, 0x59, 0x96, 0x3f, 0x81, 0x86, 0x5a, 0x1b, 0x86, 0x06, 0xcf, 0x77, 0x9f,
+	0xee, 0xee, 0xfd, 0x70, 0xb7, 0x20, 0xb6, 0x69, 0xf0, 0x50, 0xce, 0x07, 0x0f, 0x15, 0xc4, 0xaf,
+	0xef, 0x3d, 0xdf, 0xed, 0x77, 0xab, 0xa2, 0x0d, 0x3a, 0x7d, 0x0e, 0xcc, 0xcd, 0x17, 0xdd, 0x1a,
+	0x25, 0x62, 0xd7, 0x3f, 0xdf, 0x7c, 0xb6, 0xda, 0xad, 0xa7, 0xa5, 0xdb, 0x86, 0xf1, 0xc7, 0x25,
+	0x98, 0x63, 0x86, 0xe4, 0xd3, 0x90, 0xf9, 0xbf, 0xa3, 0x54, 0xf9, 0xef, 0x28, 0xff, 0xbd, 0x99,
+	0x47, 0xec, 0x34, 0x76, 0x93, 0xf7, 0x1e, 0x9c, 0x1f, 0xd7, 0xc6, 0xae, 0x7a, 0xe6, 0xf1, 0x37,
+	0x25, 0x98, 0xe7, 0x90, 0xe5, 0x49, 0x68, 0x8d, 0x4e, 0x7e, 0xb0, 0x73, 0x29, 0x07, 0x76, 0x95,
+	0x3b, 0x7d, 0x1b, 0x3a, 0xf4, 0x87, 0x9d, 0x9f, 0x61, 0x00, 0x4b, 0xf9, 0x09, 0x3e, 0xdd, 0xb6,
+	0xc2, 0xf2, 0x40, 0xe2, 0x31, 0xb4, 0xf8, 0x8f, 0x3d, 0x54, 0x30, 0x2a, 0xbc, 0x50, 0x98, 0xa8,
+	0xd5, 0x53, 0x2b, 0x7e, 0x59, 0xf1, 0x28, 0xed, 0x94, 0xa5, 0xcb, 0x2e, 0x3f, 0x42, 0x50, 0x5d,
+	0xfa, 0x94, 0x44, 0x7b, 0x00, 0xef, 0x4e, 0xdd, 0x87, 0x12, 0xfb, 0x5c, 0xdd, 0x82, 0xa5, 0xcd,
+	0xf8, 0xa7, 0x12, 0x68, 0x6b, 0x63, 0xef, 0x94, 0x6e, 0xcf, 0xf7, 0x01, 0xa4, 0x73, 0x2c, 0xd5,
+	0x3f, 0x64, 0x4a, 0x64, 0x55, 0x74, 0xc4, 0xf0, 0x7f, 0x64, 0x3e, 0x03, 0xe0, 0x3d, 0x0e, 0x86,
+	0xd6, 0x48, 0x1d, 0x11, 0x15, 0xde, 0x93, 0x01, 0xd4, 0x5e, 0x9e, 0x59, 0x23, 0x55, 0x78, 0x8f,
+	0x12, 0x38, 0x7b, 0x49, 0x51, 0x79, 0xc5, 0x4b, 0x8a, 0xf9, 0x5d, 0xe8, 0x14, 0x87, 0x98, 0x92,
+	0x22, 0xfe, 0xa8, 0xf8, 0x6e, 0xed, 0x32, 0x0f, 0x73, 0x8e, 0xfe, 0x17, 0x30, 0x3b, 0x51, 0x7b,
+	0x7a, 0x95, 0xa9, 0x2d, 0xa8, 0x4c, 0x79, 0x52, 0x65, 0xee, 0xc1, 0x5c, 0xdf, 0x8a, 0x4e, 0x55,
+	0xf0, 0x93, 0xdd, 0xfa, 0xb1, 0x15, 0x9d, 0x0e, 0x52, 0xa6, 0xd6, 0x11, 0xdc, 0x76, 0x8c, 0x47,
+	0x20, 0xf2, 0xad, 0x15, 0xff, 0x31, 0x9c, 0xc6, 0xe6, 0x43, 0x19, 0x5b, 0x89, 0x7b, 0x82, 0x08,
+	0x64, 0x9e, 0xb1, 0x07, 0x9d, 0xcf, 0xdd, 0x28, 0x0e, 0xc2, 0x8b, 0x64, 0xf4, 0x69, 0x7f, 0xaa,
+	0x50, 0x47, 0x58, 0xce, 0x4a, 0x4f, 0x57, 0xbd, 0x00, 0x30, 0xf6, 0xa1, 0x9d, 0x0e, 0x48, 0x49,
+	0xb4, 0xbb, 0xa0, 0xa5, 0xe9, 0x11, 0xbe, 0x40, 0x67, 0x73, 0x1a, 0x46, 0x9e, 0x73, 0xda, 0x20,
+	0x4d, 0x3a, 0x95, 0x73, 0x7f, 0xf7, 0x78, 0x02, 0x73, 0x97, 0xde, 0x2e, 0xa2, 0xc6, 0x7d, 0xe9,
+	0xfa, 0x4e, 0xf0, 0xa5, 0x5a, 0xa7, 0x82, 0xd0, 0xd1, 0x0b, 0xce, 0x64, 0x18, 0xba, 0x4e, 0x52,
+	0x7e, 0x4b, 0xe1, 0x95, 0xbf, 0x2e, 0x41, 0x15, 0x23, 0x23, 0x71, 0x1f, 0xf4, 0xcf, 0xa5, 0x15,
+	0xc6, 0x87, 0xd2, 0x8a, 0x45, 0x21, 0x0a, 0x9a, 0x27, 0x19, 0xc9, 0xde, 0xfd, 0x19, 0x33, 0x0f,
+	0x4b, 0x62, 0x99, 0xff, 0x1f, 0x91, 0xfc, 0xef, 0xa3, 0x9d, 0x44, 0x58, 0x14, 0x81, 0xcd, 0x17,
+	0xfa, 0x1b, 0x33, 0x4b, 0xd4, 0xfe, 0x8b, 0xc0, 0xf5, 0xd7, 0xf9, 0x55, 0xbe, 0x98, 0x8c, 0xc8,
+	0x26, 0x7b, 0x88, 0xfb, 0x50, 0xdf, 0x8e, 0x30, 0xf4, 0xbb, 0xdc, 0x94, 0x04, 0x2d, 0x1f, 0x15,
+	0x1a, 0x33, 0x2b, 0xbf, 0xa9, 0x41, 0xf5, 0x27, 0x32, 0x0c, 0xc4, 0x3d, 0x68, 0xa8, 0x57, 0x92,
+	0x22, 0xf7, 0x1a, 0x72, 0x9e, 0xd2, 0x88, 0x13, 0xcf, 0x27, 0x69, 0x96, 0x2e, 0xcb, 0x6a, 0x56,
+	0x5d, 0x16, 0xd9, 0x23, 0xce, 0x4b, 0x8b, 0xfa, 0x14, 0xba, 0x07, 0x71, 0x28, 0xad, 0x61, 0xae,
+	0x79, 0x91, 0x55, 0xd3, 0x4a, 0xd5, 0xc4, 0xaf, 0xbb, 0x50, 0xe7, 0xf8, 0x7a, 0xa2, 0xc3, 0x64,
+	0x1d, 0x9a, 0x1a, 0x7f, 0x0c, 0xcd, 0x83, 0x93, 0x60, 0xec, 0x39, 0x07, 0x32, 0x3c, 0x93, 0x22,
+	0x17, 0x22, 0xce, 0xe7, 0xbe, 0x8d, 0x19, 0xf1, 0x08, 0xea, 0x78, 0x22, 0xe1, 0x50, 0xcc, 0xe5,
+	0xc2, 0x48, 0x16, 0xda, 0x79, 0x91, 0x47, 0x25, 0x9c, 0x12, 0x1f, 0x83, 0xce, 0x31, 0x0e, 0x46,
+	0x38, 0x0d, 0x15, 0x36, 0xf1, 0x32, 0x72, 0xb1, 0x8f, 0x31, 0x23, 0x96, 0x00, 0x72, 0x81, 0xf9,
+	0xab, 0x5a, 0x3e, 0x86, 0x36, 0xbf, 0x40, 0xda, 0x0b, 0x57, 0x0f, 0x83, 0x30, 0x16, 0x93, 0x6f,
+	0xc5, 0xe7, 0x27, 0x11, 0xc6, 0x0c, 0x86, 0xb8, 0xfd, 0xf0, 0x82, 0xdb, 0xcf, 0xa9, 0x7c, 0x46,
+	0x36, 0xdf, 0x14, 0xbe, 0x88, 0x6f, 0xa5, 0x36, 0x24, 0x75, 0x44, 0xa6, 0x15, 0xb5, 0x99, 0x45,
+	0xac, 0xef, 0xc4, 0x22, 0xc8, 0xe2, 0x2e, 0xf1, 0x16, 0x17, 0xd8, 0x27, 0xe2, 0xb0, 0xcb, 0x5d,
+	0xb2, 0x18, 0x8b, 0xbb, 0x5c, 0x8a, 0xb9, 0x26, 0xba, 0x7c, 0x1b, 0x5a, 0xf9, 0x78, 0x49, 0x50,
+	0xa5, 0x78, 0x4a, 0x04, 0x35, 0xd1, 0xed, 0xbb, 0x30, 0xa7, 0xe4, 0x2f, 0x53, 0x66, 0x31, 0xfd,
+	0x65, 0x72, 0xb1, 0xe7, 0xca, 0x2f, 0xeb, 0x50, 0xff, 0x61, 0x10, 0x9e, 0xca, 0x50, 0xdc, 0x81,
+	0x3a, 0x3d, 0xb2, 0x50, 0x5a, 0x98, 0x3e, 0xb8, 0x98, 0xc6, 0xf5, 0x0f, 0x41, 0x27, 0x99, 0x42,
+	0x93, 0xc8, 0x92, 0x4e, 0xff, 0xeb, 0xe4, 0xc1, 0xd9, 0x38, 0x91, 0x5a, 0x74, 0x58, 0xce, 0xd3,
+	0x47, 0x48, 0x85, 0x47, 0x10, 0xf3, 0x24, 0x0c, 0x4f, 0x5f, 0x1c, 0xa0, 0x66, 0x3f, 0x2c, 0xa1,
+	0xe7, 0x75, 0xc0, 0xc7, 0x8e, 0x8d, 0xb2, 0x7f, 0x83, 0xb1, 0xe1, 0xc8, 0xfe, 0x7e, 0x65, 0xcc,
+	0x88, 0x07, 0x50, 0x57, 0x17, 0xf1, 0x5c, 0x76, 0x5d, 0x24, 0xbc, 0xe9, 0xe6, 0x51, 0xaa, 0xc3,
+	0x23, 0xa8, 0xb3, 0xd3, 0xc2, 0x1d, 0x0a, 0xa1, 0x1e, 0x4b, 0x78, 0x31, 0x44, 0x31, 0x66, 0xc4,
+	0x5d, 0x68, 0xa8, 0x27, 0x14, 0x62, 0xca, 0x7b, 0x8a, 0x4b, 0x67, 0x5d, 0x67, 0x8f, 0x94, 0xc7,
+	0x2f, 0x44, 0x03, 0x3c, 0x7e, 0xd1, 0x61, 0x65, 0xa3, 0x61, 0x4a, 0x5b, 0xba, 0xb9, 0xbc, 0xa4,
+	0x48, 0x38, 0x32, 0xc5, 0xf2, 0x7d, 0x0a, 0xed, 0x42, 0x0e, 0x53, 0xf4, 0x12, 0x81, 0x9a, 0x4c,
+	0x6b, 0x5e, 0xb2, 0x37, 0xdf, 0x03, 0x5d, 0x65, 0x5d, 0x0e, 0x95, 0x48, 0x4d, 0xc9, 0xf1, 0xcc,
+	0x5f, 0x4e, 0xbb, 0x90, 0x11, 0xf9, 0x11, 0x5c, 0x9b, 0xe2, 0x81, 0x08, 0x7a, 0xfe, 0x7f, 0xb5,
+	0x8b, 0x35, 0xbf, 0x70, 0x25, 0x3d, 0x65, 0xc0, 0x6f, 0xa7, 0x88, 0xdf, 0x07, 0xc8, 0x2e, 0x62,
+	0x16, 0xf2, 0x4b, 0xd7, 0xf8, 0xfc, 0x8d, 0x49, 0x74, 0x3a, 0xe9, 0x0a, 0x34, 0xd4, 0x1d, 0xca,
+	0xa7, 0x5a, 0xbc, 0xa1, 0xe7, 0xe7, 0x0a, 0x38, 0x25, 0x3c, 0xf7, 0x60, 0x76, 0x7b, 0x88, 0xa7,
+	0xf7, 0x54, 0x5e, 0xa8, 0x22, 0xc0, 0xd5, 0x07, 0xb5, 0xd6, 0xfb, 0xdb, 0x5f, 0xdd, 0x2c, 0xfd,
+	0xf2, 0x57, 0x37, 0x4b, 0xff, 0xf2, 0xab, 0x9b, 0xa5, 0x9f, 0xff, 0xfa, 0xe6, 0xcc, 0x2f, 0x7f,
+	0x7d, 0x73, 0xe6, 0x1f, 0x7e, 0x7d, 0x73, 0xe6, 0xb0, 0x4e, 0x7f, 0xe1, 0x7e, 0xfc, 0x9f, 0x01,
+	0x00, 0x00, 0xff, 0xff, 0x03, 0x27, 0x8a, 0xb9, 0x38, 0x3e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -6286,6 +6837,7 @@ type ZeroClient interface {
 	RemoveNode(ctx context.Context, in *RemoveNodeRequest, opts ...grpc.CallOption) (*Status, error)
 	MoveTablet(ctx context.Context, in *MoveTabletRequest, opts ...grpc.CallOption) (*Status, error)
 	ApplyLicense(ctx context.Context, in *ApplyLicenseRequest, opts ...grpc.CallOption) (*Status, error)
+	UpdateMaintenance(ctx context.Context, in *MaintenancePolicy, opts ...grpc.CallOption) (*Status, error)
 }
 
 type zeroClient struct {
@@ -6468,6 +7020,15 @@ func (c *zeroClient) ApplyLicense(ctx context.Context, in *ApplyLicenseRequest,
 	return out, nil
 }
 
+func (c *zeroClient) UpdateMaintenance(ctx context.Context, in *MaintenancePolicy, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	err := c.cc.Invoke(ctx, "/pb.Zero/UpdateMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ZeroServer is the server API for Zero service.
 type ZeroServer interface {
 	// These 3 endpoints are for handling membership.
@@ -6485,6 +7046,7 @@ type ZeroServer interface {
 	RemoveNode(context.Context, *RemoveNodeRequest) (*Status, error)
 	MoveTablet(context.Context, *MoveTabletRequest) (*Status, error)
 	ApplyLicense(context.Context, *ApplyLicenseRequest) (*Status, error)
+	UpdateMaintenance(context.Context, *MaintenancePolicy) (*Status, error)
 }
 
 // UnimplementedZeroServer can be embedded to have forward compatible implementations.
@@ -6533,6 +7095,9 @@ func (*UnimplementedZeroServer) MoveTablet(ctx context.Context, req *MoveTabletR
 func (*UnimplementedZeroServer) ApplyLicense(ctx context.Context, req *ApplyLicenseRequest) (*Status, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApplyLicense not implemented")
 }
+func (*UnimplementedZeroServer) UpdateMaintenance(ctx context.Context, req *MaintenancePolicy) (*Status, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMaintenance not implemented")
+}
 
 func RegisterZeroServer(s *grpc.Server, srv ZeroServer) {
 	s.RegisterService(&_Zero_serviceDesc, srv)
@@ -6796,6 +7361,24 @@ func _Zero_ApplyLicense_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Zero_UpdateMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenancePolicy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ZeroServer).UpdateMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Zero/UpdateMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ZeroServer).UpdateMaintenance(ctx, req.(*MaintenancePolicy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Zero_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.Zero",
 	HandlerType: (*ZeroServer)(nil),
@@ -6848,6 +7431,10 @@ var _Zero_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ApplyLicense",
 			Handler:    _Zero_ApplyLicense_Handler,
 		},
+		{
+			MethodName: "UpdateMaintenance",
+			Handler:    _Zero_UpdateMaintenance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -6883,6 +7470,8 @@ type WorkerClient interface {
 	UpdateGraphQLSchema(ctx context.Context, in *UpdateGraphQLSchemaRequest, opts ...grpc.CallOption) (*UpdateGraphQLSchemaResponse, error)
 	DeleteNamespace(ctx context.Context, in *DeleteNsRequest, opts ...grpc.CallOption) (*Status, error)
 	TaskStatus(ctx context.Context, in *TaskStatusRequest, opts ...grpc.CallOption) (*TaskStatusResponse, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResult, error)
+	ImportKeyValues(ctx context.Context, opts ...grpc.CallOption) (Worker_ImportKeyValuesClient, error)
 }
 
 type workerClient struct {
@@ -7089,6 +7678,49 @@ func (c *workerClient) TaskStatus(ctx context.Context, in *TaskStatusRequest, op
 	return out, nil
 }
 
+func (c *workerClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResult, error) {
+	out := new(HistoryResult)
+	err := c.cc.Invoke(ctx, "/pb.Worker/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) ImportKeyValues(ctx context.Context, opts ...grpc.CallOption) (Worker_ImportKeyValuesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Worker_serviceDesc.Streams[3], "/pb.Worker/ImportKeyValues", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workerImportKeyValuesClient{stream}
+	return x, nil
+}
+
+type Worker_ImportKeyValuesClient interface {
+	Send(*KVS) error
+	CloseAndRecv() (*api.Payload, error)
+	grpc.ClientStream
+}
+
+type workerImportKeyValuesClient struct {
+	grpc.ClientStream
+}
+
+func (x *workerImportKeyValuesClient) Send(m *KVS) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *workerImportKeyValuesClient) CloseAndRecv() (*api.Payload, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(api.Payload)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // WorkerServer is the server API for Worker service.
 type WorkerServer interface {
 	// Data serving RPCs.
@@ -7106,6 +7738,8 @@ type WorkerServer interface {
 	UpdateGraphQLSchema(context.Context, *UpdateGraphQLSchemaRequest) (*UpdateGraphQLSchemaResponse, error)
 	DeleteNamespace(context.Context, *DeleteNsRequest) (*Status, error)
 	TaskStatus(context.Context, *TaskStatusRequest) (*TaskStatusResponse, error)
+	History(context.Context, *HistoryRequest) (*HistoryResult, error)
+	ImportKeyValues(Worker_ImportKeyValuesServer) error
 }
 
 // UnimplementedWorkerServer can be embedded to have forward compatible implementations.
@@ -7154,6 +7788,12 @@ func (*UnimplementedWorkerServer) DeleteNamespace(ctx context.Context, req *Dele
 func (*UnimplementedWorkerServer) TaskStatus(ctx context.Context, req *TaskStatusRequest) (*TaskStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TaskStatus not implemented")
 }
+func (*UnimplementedWorkerServer) History(ctx context.Context, req *HistoryRequest) (*HistoryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedWorkerServer) ImportKeyValues(srv Worker_ImportKeyValuesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportKeyValues not implemented")
+}
 
 func RegisterWorkerServer(s *grpc.Server, srv WorkerServer) {
 	s.RegisterService(&_Worker_serviceDesc, srv)
@@ -7430,36 +8070,80 @@ func _Worker_TaskStatus_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Worker_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.Worker",
-	HandlerType: (*WorkerServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Mutate",
-			Handler:    _Worker_Mutate_Handler,
-		},
-		{
-			MethodName: "ServeTask",
-			Handler:    _Worker_ServeTask_Handler,
-		},
-		{
-			MethodName: "Sort",
-			Handler:    _Worker_Sort_Handler,
-		},
-		{
-			MethodName: "Schema",
-			Handler:    _Worker_Schema_Handler,
-		},
-		{
-			MethodName: "Backup",
-			Handler:    _Worker_Backup_Handler,
-		},
-		{
-			MethodName: "Restore",
-			Handler:    _Worker_Restore_Handler,
-		},
-		{
-			MethodName: "Export",
+func _Worker_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Worker/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_ImportKeyValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WorkerServer).ImportKeyValues(&workerImportKeyValuesServer{stream})
+}
+
+type Worker_ImportKeyValuesServer interface {
+	SendAndClose(*api.Payload) error
+	Recv() (*KVS, error)
+	grpc.ServerStream
+}
+
+type workerImportKeyValuesServer struct {
+	grpc.ServerStream
+}
+
+func (x *workerImportKeyValuesServer) SendAndClose(m *api.Payload) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *workerImportKeyValuesServer) Recv() (*KVS, error) {
+	m := new(KVS)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _Worker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Worker",
+	HandlerType: (*WorkerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Mutate",
+			Handler:    _Worker_Mutate_Handler,
+		},
+		{
+			MethodName: "ServeTask",
+			Handler:    _Worker_ServeTask_Handler,
+		},
+		{
+			MethodName: "Sort",
+			Handler:    _Worker_Sort_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _Worker_Schema_Handler,
+		},
+		{
+			MethodName: "Backup",
+			Handler:    _Worker_Backup_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Worker_Restore_Handler,
+		},
+		{
+			MethodName: "Export",
 			Handler:    _Worker_Export_Handler,
 		},
 		{
@@ -7478,6 +8162,10 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TaskStatus",
 			Handler:    _Worker_TaskStatus_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _Worker_History_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -7496,6 +8184,11 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Worker_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ImportKeyValues",
+			Handler:       _Worker_ImportKeyValues_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "pb.proto",
 }
@@ -7636,6 +8329,34 @@ func (m *Query) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.TxnStartTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.TxnStartTs))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.FuncLimit != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.FuncLimit))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.SampleSeed != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.SampleSeed))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.Sample != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Sample))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
 	if m.Offset != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Offset))
 		i--
@@ -7967,6 +8688,26 @@ func (m *Order) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Reverse {
+		i--
+		if m.Reverse {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Count {
+		i--
+		if m.Count {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Langs) > 0 {
 		for iNdEx := len(m.Langs) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Langs[iNdEx])
@@ -8175,6 +8916,13 @@ func (m *Member) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Region) > 0 {
+		i -= len(m.Region)
+		copy(dAtA[i:], m.Region)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Region)))
+		i--
+		dAtA[i] = 0x7a
+	}
 	if m.ForceGroupId {
 		i--
 		if m.ForceGroupId {
@@ -8409,6 +9157,18 @@ func (m *ZeroProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Maintenance != nil {
+		{
+			size, err := m.Maintenance.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
 	if len(m.Tablets) > 0 {
 		for iNdEx := len(m.Tablets) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -8560,6 +9320,18 @@ func (m *MembershipState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Maintenance != nil {
+		{
+			size, err := m.Maintenance.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPb(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
 	if m.MaxNsID != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.MaxNsID))
 		i--
@@ -9015,6 +9787,49 @@ func (m *Mutations) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppendDuplicates {
+		i--
+		if m.AppendDuplicates {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.ReadCommittedTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ReadCommittedTs))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Aliases) > 0 {
+		for iNdEx := len(m.Aliases) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Aliases[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.Derivations) > 0 {
+		for iNdEx := len(m.Derivations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Derivations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
 	if m.Metadata != nil {
 		{
 			size, err := m.Metadata.MarshalToSizedBuffer(dAtA[:i])
@@ -9796,20 +10611,20 @@ func (m *PostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Splits) > 0 {
-		dAtA31 := make([]byte, len(m.Splits)*10)
-		var j30 int
+		dAtA33 := make([]byte, len(m.Splits)*10)
+		var j32 int
 		for _, num := range m.Splits {
 			for num >= 1<<7 {
-				dAtA31[j30] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA33[j32] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j30++
+				j32++
 			}
-			dAtA31[j30] = uint8(num)
-			j30++
+			dAtA33[j32] = uint8(num)
+			j32++
 		}
-		i -= j30
-		copy(dAtA[i:], dAtA31[:j30])
-		i = encodeVarintPb(dAtA, i, uint64(j30))
+		i -= j32
+		copy(dAtA[i:], dAtA33[:j32])
+		i = encodeVarintPb(dAtA, i, uint64(j32))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -10182,6 +10997,90 @@ func (m *SchemaNode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Key {
+		i--
+		if m.Key {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.CardinalityReject {
+		i--
+		if m.CardinalityReject {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if len(m.Cardinality) > 0 {
+		i -= len(m.Cardinality)
+		copy(dAtA[i:], m.Cardinality)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Cardinality)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if len(m.EnumValues) > 0 {
+		for iNdEx := len(m.EnumValues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EnumValues[iNdEx])
+			copy(dAtA[i:], m.EnumValues[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.EnumValues[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if m.Group != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.Group))
+		i--
+		dAtA[i] = 0x78
+	}
+	if len(m.Aliases) > 0 {
+		for iNdEx := len(m.Aliases) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Aliases[iNdEx])
+			copy(dAtA[i:], m.Aliases[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Aliases[iNdEx])))
+			i--
+			dAtA[i] = 0x72
+		}
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Pattern)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.Derived) > 0 {
+		for iNdEx := len(m.Derived) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Derived[iNdEx])
+			copy(dAtA[i:], m.Derived[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Derived[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
+	if m.Undirected {
+		i--
+		if m.Undirected {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if m.NoConflict {
 		i--
 		if m.NoConflict {
@@ -10335,56 +11234,157 @@ func (m *SchemaUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	if m.NoConflict {
+	if m.Key {
 		i--
-		if m.NoConflict {
+		if m.Key {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x68
-	}
-	if len(m.ObjectTypeName) > 0 {
-		i -= len(m.ObjectTypeName)
-		copy(dAtA[i:], m.ObjectTypeName)
-		i = encodeVarintPb(dAtA, i, uint64(len(m.ObjectTypeName)))
+		dAtA[i] = 0x1
 		i--
-		dAtA[i] = 0x62
+		dAtA[i] = 0xb8
 	}
-	if m.NonNullableList {
+	if m.CardinalityReject {
 		i--
-		if m.NonNullableList {
+		if m.CardinalityReject {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x58
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
 	}
-	if m.NonNullable {
+	if len(m.Cardinality) > 0 {
+		i -= len(m.Cardinality)
+		copy(dAtA[i:], m.Cardinality)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Cardinality)))
 		i--
-		if m.NonNullable {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	if len(m.EnumValues) > 0 {
+		for iNdEx := len(m.EnumValues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EnumValues[iNdEx])
+			copy(dAtA[i:], m.EnumValues[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.EnumValues[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.Aliases) > 0 {
+		for iNdEx := len(m.Aliases) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Aliases[iNdEx])
+			copy(dAtA[i:], m.Aliases[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Aliases[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
 		}
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Pattern)))
 		i--
-		dAtA[i] = 0x50
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
 	}
-	if m.Lang {
+	if len(m.Derived) > 0 {
+		for iNdEx := len(m.Derived) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Derived[iNdEx])
+			copy(dAtA[i:], m.Derived[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.Derived[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.TokenizerVersions) > 0 {
+		for iNdEx := len(m.TokenizerVersions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TokenizerVersions[iNdEx])
+			copy(dAtA[i:], m.TokenizerVersions[iNdEx])
+			i = encodeVarintPb(dAtA, i, uint64(len(m.TokenizerVersions[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if m.Undirected {
 		i--
-		if m.Lang {
+		if m.Undirected {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
 		}
 		i--
-		dAtA[i] = 0x48
+		dAtA[i] = 0x78
 	}
-	if m.Upsert {
+	if m.DecimalScale != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.DecimalScale))
 		i--
-		if m.Upsert {
+		dAtA[i] = 0x70
+	}
+	if m.NoConflict {
+		i--
+		if m.NoConflict {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if len(m.ObjectTypeName) > 0 {
+		i -= len(m.ObjectTypeName)
+		copy(dAtA[i:], m.ObjectTypeName)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.ObjectTypeName)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.NonNullableList {
+		i--
+		if m.NonNullableList {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.NonNullable {
+		i--
+		if m.NonNullable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Lang {
+		i--
+		if m.Lang {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.Upsert {
+		i--
+		if m.Upsert {
 			dAtA[i] = 1
 		} else {
 			dAtA[i] = 0
@@ -10461,6 +11461,30 @@ func (m *TypeUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.CascadeDeleteForce {
+		i--
+		if m.CascadeDeleteForce {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.CascadeDelete) > 0 {
+		i -= len(m.CascadeDelete)
+		copy(dAtA[i:], m.CascadeDelete)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.CascadeDelete)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.SoftDelete) > 0 {
+		i -= len(m.SoftDelete)
+		copy(dAtA[i:], m.SoftDelete)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.SoftDelete)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Fields) > 0 {
 		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -10678,20 +11702,20 @@ func (m *TxnTimestamps) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	var l int
 	_ = l
 	if len(m.Ts) > 0 {
-		dAtA35 := make([]byte, len(m.Ts)*10)
-		var j34 int
+		dAtA37 := make([]byte, len(m.Ts)*10)
+		var j36 int
 		for _, num := range m.Ts {
 			for num >= 1<<7 {
-				dAtA35[j34] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA37[j36] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j34++
+				j36++
 			}
-			dAtA35[j34] = uint8(num)
-			j34++
+			dAtA37[j36] = uint8(num)
+			j36++
 		}
-		i -= j34
-		copy(dAtA[i:], dAtA35[:j34])
-		i = encodeVarintPb(dAtA, i, uint64(j34))
+		i -= j36
+		copy(dAtA[i:], dAtA37[:j36])
+		i = encodeVarintPb(dAtA, i, uint64(j36))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -11394,6 +12418,26 @@ func (m *ExportRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IncludeAcl {
+		i--
+		if m.IncludeAcl {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Bundle {
+		i--
+		if m.Bundle {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if m.Namespace != 0 {
 		i = encodeVarintPb(dAtA, i, uint64(m.Namespace))
 		i--
@@ -11596,20 +12640,20 @@ func (m *BackupPostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x2a
 	}
 	if len(m.Splits) > 0 {
-		dAtA40 := make([]byte, len(m.Splits)*10)
-		var j39 int
+		dAtA42 := make([]byte, len(m.Splits)*10)
+		var j41 int
 		for _, num := range m.Splits {
 			for num >= 1<<7 {
-				dAtA40[j39] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j39++
+				j41++
 			}
-			dAtA40[j39] = uint8(num)
-			j39++
+			dAtA42[j41] = uint8(num)
+			j41++
 		}
-		i -= j39
-		copy(dAtA[i:], dAtA40[:j39])
-		i = encodeVarintPb(dAtA, i, uint64(j39))
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintPb(dAtA, i, uint64(j41))
 		i--
 		dAtA[i] = 0x22
 	}
@@ -11633,20 +12677,20 @@ func (m *BackupPostingList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		}
 	}
 	if len(m.Uids) > 0 {
-		dAtA42 := make([]byte, len(m.Uids)*10)
-		var j41 int
+		dAtA44 := make([]byte, len(m.Uids)*10)
+		var j43 int
 		for _, num := range m.Uids {
 			for num >= 1<<7 {
-				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA44[j43] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j41++
+				j43++
 			}
-			dAtA42[j41] = uint8(num)
-			j41++
+			dAtA44[j43] = uint8(num)
+			j43++
 		}
-		i -= j41
-		copy(dAtA[i:], dAtA42[:j41])
-		i = encodeVarintPb(dAtA, i, uint64(j41))
+		i -= j43
+		copy(dAtA[i:], dAtA44[:j43])
+		i = encodeVarintPb(dAtA, i, uint64(j43))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -11901,6 +12945,134 @@ func (m *TaskStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *HistoryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HistoryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HistoryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ReadTs != 0 {
+		i = encodeVarintPb(dAtA, i, uint64(m.ReadTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Uid != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Uid))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Attr) > 0 {
+		i -= len(m.Attr)
+		copy(dAtA[i:], m.Attr)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Attr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HistoryResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HistoryResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HistoryResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.List {
+		i--
+		if m.List {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Versions) > 0 {
+		for iNdEx := len(m.Versions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Versions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPb(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MaintenancePolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintenancePolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaintenancePolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Override {
+		i--
+		if m.Override {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Window) > 0 {
+		i -= len(m.Window)
+		copy(dAtA[i:], m.Window)
+		i = encodeVarintPb(dAtA, i, uint64(len(m.Window)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintPb(dAtA []byte, offset int, v uint64) int {
 	offset -= sovPb(v)
 	base := offset
@@ -12018,6 +13190,18 @@ func (m *Query) Size() (n int) {
 	if m.Offset != 0 {
 		n += 2 + sovPb(uint64(m.Offset))
 	}
+	if m.Sample != 0 {
+		n += 2 + sovPb(uint64(m.Sample))
+	}
+	if m.SampleSeed != 0 {
+		n += 2 + sovPb(uint64(m.SampleSeed))
+	}
+	if m.FuncLimit != 0 {
+		n += 2 + sovPb(uint64(m.FuncLimit))
+	}
+	if m.TxnStartTs != 0 {
+		n += 2 + sovPb(uint64(m.TxnStartTs))
+	}
 	return n
 }
 
@@ -12116,6 +13300,12 @@ func (m *Order) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	if m.Count {
+		n += 2
+	}
+	if m.Reverse {
+		n += 2
+	}
 	return n
 }
 
@@ -12223,6 +13413,10 @@ func (m *Member) Size() (n int) {
 	if m.ForceGroupId {
 		n += 2
 	}
+	l = len(m.Region)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -12352,6 +13546,10 @@ func (m *ZeroProposal) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	if m.Maintenance != nil {
+		l = m.Maintenance.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -12416,6 +13614,10 @@ func (m *MembershipState) Size() (n int) {
 	if m.MaxNsID != 0 {
 		n += 1 + sovPb(uint64(m.MaxNsID))
 	}
+	if m.Maintenance != nil {
+		l = m.Maintenance.Size()
+		n += 1 + l + sovPb(uint64(l))
+	}
 	return n
 }
 
@@ -12618,6 +13820,24 @@ func (m *Mutations) Size() (n int) {
 		l = m.Metadata.Size()
 		n += 1 + l + sovPb(uint64(l))
 	}
+	if len(m.Derivations) > 0 {
+		for _, e := range m.Derivations {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if len(m.Aliases) > 0 {
+		for _, e := range m.Aliases {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.ReadCommittedTs != 0 {
+		n += 1 + sovPb(uint64(m.ReadCommittedTs))
+	}
+	if m.AppendDuplicates {
+		n += 2
+	}
 	return n
 }
 
@@ -13139,27 +14359,65 @@ func (m *SchemaNode) Size() (n int) {
 	if m.NoConflict {
 		n += 2
 	}
-	return n
-}
-
-func (m *SchemaResult) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Undirected {
+		n += 2
 	}
-	var l int
-	_ = l
-	if len(m.Schema) > 0 {
-		for _, e := range m.Schema {
-			l = e.Size()
+	if len(m.Derived) > 0 {
+		for _, s := range m.Derived {
+			l = len(s)
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
-	return n
-}
-
-func (m *SchemaUpdate) Size() (n int) {
-	if m == nil {
-		return 0
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if len(m.Aliases) > 0 {
+		for _, s := range m.Aliases {
+			l = len(s)
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.Group != 0 {
+		n += 1 + sovPb(uint64(m.Group))
+	}
+	if len(m.EnumValues) > 0 {
+		for _, s := range m.EnumValues {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.Cardinality)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if m.CardinalityReject {
+		n += 3
+	}
+	if m.Key {
+		n += 3
+	}
+	return n
+}
+
+func (m *SchemaResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Schema) > 0 {
+		for _, e := range m.Schema {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SchemaUpdate) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -13204,6 +14462,50 @@ func (m *SchemaUpdate) Size() (n int) {
 	if m.NoConflict {
 		n += 2
 	}
+	if m.DecimalScale != 0 {
+		n += 1 + sovPb(uint64(m.DecimalScale))
+	}
+	if m.Undirected {
+		n += 2
+	}
+	if len(m.TokenizerVersions) > 0 {
+		for _, s := range m.TokenizerVersions {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	if len(m.Derived) > 0 {
+		for _, s := range m.Derived {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if len(m.Aliases) > 0 {
+		for _, s := range m.Aliases {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	if len(m.EnumValues) > 0 {
+		for _, s := range m.EnumValues {
+			l = len(s)
+			n += 2 + l + sovPb(uint64(l))
+		}
+	}
+	l = len(m.Cardinality)
+	if l > 0 {
+		n += 2 + l + sovPb(uint64(l))
+	}
+	if m.CardinalityReject {
+		n += 3
+	}
+	if m.Key {
+		n += 3
+	}
 	return n
 }
 
@@ -13223,6 +14525,17 @@ func (m *TypeUpdate) Size() (n int) {
 			n += 1 + l + sovPb(uint64(l))
 		}
 	}
+	l = len(m.SoftDelete)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	l = len(m.CascadeDelete)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.CascadeDeleteForce {
+		n += 2
+	}
 	return n
 }
 
@@ -13658,6 +14971,12 @@ func (m *ExportRequest) Size() (n int) {
 	if m.Namespace != 0 {
 		n += 1 + sovPb(uint64(m.Namespace))
 	}
+	if m.Bundle {
+		n += 2
+	}
+	if m.IncludeAcl {
+		n += 2
+	}
 	return n
 }
 
@@ -13861,6 +15180,59 @@ func (m *TaskStatusResponse) Size() (n int) {
 	return n
 }
 
+func (m *HistoryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Attr)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Uid != 0 {
+		n += 9
+	}
+	if m.ReadTs != 0 {
+		n += 1 + sovPb(uint64(m.ReadTs))
+	}
+	return n
+}
+
+func (m *HistoryResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Versions) > 0 {
+		for _, e := range m.Versions {
+			l = e.Size()
+			n += 1 + l + sovPb(uint64(l))
+		}
+	}
+	if m.List {
+		n += 2
+	}
+	return n
+}
+
+func (m *MaintenancePolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Window)
+	if l > 0 {
+		n += 1 + l + sovPb(uint64(l))
+	}
+	if m.Override {
+		n += 2
+	}
+	return n
+}
+
 func sovPb(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -14589,6 +15961,82 @@ func (m *Query) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sample", wireType)
+			}
+			m.Sample = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sample |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleSeed", wireType)
+			}
+			m.SampleSeed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleSeed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FuncLimit", wireType)
+			}
+			m.FuncLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FuncLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnStartTs", wireType)
+			}
+			m.TxnStartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxnStartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -15191,6 +16639,46 @@ func (m *Order) Unmarshal(dAtA []byte) error {
 			}
 			m.Langs = append(m.Langs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reverse", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reverse = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -15830,6 +17318,38 @@ func (m *Member) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ForceGroupId = bool(v != 0)
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Region = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -16828,16 +18348,52 @@ func (m *ZeroProposal) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Maintenance", wireType)
 			}
-			if (iNdEx + skippy) > l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Maintenance == nil {
+				m.Maintenance = &MaintenancePolicy{}
+			}
+			if err := m.Maintenance.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
 			iNdEx += skippy
@@ -17305,6 +18861,42 @@ func (m *MembershipState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Maintenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Maintenance == nil {
+				m.Maintenance = &MaintenancePolicy{}
+			}
+			if err := m.Maintenance.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -18595,6 +20187,113 @@ func (m *Mutations) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Derivations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Derivations = append(m.Derivations, &SchemaUpdate{})
+			if err := m.Derivations[len(m.Derivations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aliases = append(m.Aliases, &SchemaUpdate{})
+			if err := m.Aliases[len(m.Aliases)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadCommittedTs", wireType)
+			}
+			m.ReadCommittedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReadCommittedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppendDuplicates", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AppendDuplicates = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -22069,61 +23768,31 @@ func (m *SchemaNode) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.NoConflict = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SchemaResult) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Undirected", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaResult: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaResult: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Undirected = bool(v != 0)
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Derived", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22133,79 +23802,27 @@ func (m *SchemaResult) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Schema = append(m.Schema, &SchemaNode{})
-			if err := m.Schema[len(m.Schema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Derived = append(m.Derived, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPb(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthPb
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPb
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: SchemaUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SchemaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -22233,13 +23850,13 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Predicate = string(dAtA[iNdEx:postIndex])
+			m.Pattern = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
 			}
-			m.ValueType = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22249,16 +23866,29 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.ValueType |= Posting_ValType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aliases = append(m.Aliases, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 15:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Directive", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
 			}
-			m.Directive = 0
+			m.Group = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22268,14 +23898,14 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Directive |= SchemaUpdate_Directive(b&0x7F) << shift
+				m.Group |= uint32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tokenizer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnumValues", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -22303,13 +23933,13 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tokenizer = append(m.Tokenizer, string(dAtA[iNdEx:postIndex]))
+			m.EnumValues = append(m.EnumValues, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cardinality", wireType)
 			}
-			var v int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22319,35 +23949,27 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Count = bool(v != 0)
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.List = bool(v != 0)
-		case 8:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cardinality = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Upsert", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CardinalityReject", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -22364,10 +23986,10 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Upsert = bool(v != 0)
-		case 9:
+			m.CardinalityReject = bool(v != 0)
+		case 19:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Lang", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var v int
 			for shift := uint(0); ; shift += 7 {
@@ -22384,52 +24006,62 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-			m.Lang = bool(v != 0)
-		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NonNullable", wireType)
+			m.Key = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
 			}
-			m.NonNullable = bool(v != 0)
-		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NonNullableList", wireType)
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SchemaResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
 			}
-			m.NonNullableList = bool(v != 0)
-		case 12:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SchemaResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SchemaResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ObjectTypeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Schema", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -22439,44 +24071,26 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPb
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPb
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ObjectTypeName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 13:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NoConflict", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPb
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			m.Schema = append(m.Schema, &SchemaNode{})
+			if err := m.Schema[len(m.Schema)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.NoConflict = bool(v != 0)
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -22498,7 +24112,7 @@ func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
+func (m *SchemaUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -22521,15 +24135,15 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TypeUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchemaUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TypeUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchemaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Predicate", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -22557,11 +24171,606 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TypeName = string(dAtA[iNdEx:postIndex])
+			m.Predicate = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueType", wireType)
+			}
+			m.ValueType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueType |= Posting_ValType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Directive", wireType)
+			}
+			m.Directive = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Directive |= SchemaUpdate_Directive(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tokenizer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tokenizer = append(m.Tokenizer, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.List = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upsert", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Upsert = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lang", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Lang = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NonNullable", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NonNullable = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NonNullableList", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NonNullableList = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectTypeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObjectTypeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoConflict", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.NoConflict = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecimalScale", wireType)
+			}
+			m.DecimalScale = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DecimalScale |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Undirected", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Undirected = bool(v != 0)
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenizerVersions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenizerVersions = append(m.TokenizerVersions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Derived", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Derived = append(m.Derived, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aliases", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Aliases = append(m.Aliases, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnumValues", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EnumValues = append(m.EnumValues, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cardinality", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cardinality = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CardinalityReject", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CardinalityReject = bool(v != 0)
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Key = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TypeUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TypeUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TypeName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TypeName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -22593,6 +24802,90 @@ func (m *TypeUpdate) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SoftDelete", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SoftDelete = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CascadeDelete", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CascadeDelete = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CascadeDeleteForce", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CascadeDeleteForce = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -25376,6 +27669,46 @@ func (m *ExportRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bundle", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Bundle = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeAcl", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeAcl = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -26441,13 +28774,185 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 					iNdEx += skippy
 				}
 			}
-			m.SchemaMap[mapkey] = mapvalue
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+			m.SchemaMap[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Types = append(m.Types, &TypeUpdate{})
+			if err := m.Types[len(m.Types)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteNsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteNsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			}
+			m.GroupId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			m.Namespace = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Namespace |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthPb
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TaskStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TaskStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
+			}
+			m.TaskId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26457,26 +28962,80 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.TaskId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthPb
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPb(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthPb
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Types = append(m.Types, &TypeUpdate{})
-			if err := m.Types[len(m.Types)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPb
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TaskStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TaskStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TaskMeta", wireType)
+			}
+			m.TaskMeta = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TaskMeta |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -26498,7 +29057,7 @@ func (m *BulkMeta) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
+func (m *HistoryRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -26521,17 +29080,17 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteNsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HistoryRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteNsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HistoryRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attr", wireType)
 			}
-			m.GroupId = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26541,16 +29100,39 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupId |= uint32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attr = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			m.Uid = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadTs", wireType)
 			}
-			m.Namespace = 0
+			m.ReadTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26560,7 +29142,7 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Namespace |= uint64(b&0x7F) << shift
+				m.ReadTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -26586,7 +29168,7 @@ func (m *DeleteNsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
+func (m *HistoryResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -26609,17 +29191,51 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TaskStatusRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: HistoryResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TaskStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HistoryResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Versions = append(m.Versions, &PostingList{})
+			if err := m.Versions[len(m.Versions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field List", wireType)
 			}
-			m.TaskId = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26629,11 +29245,12 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TaskId |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.List = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -26655,7 +29272,7 @@ func (m *TaskStatusRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
+func (m *MaintenancePolicy) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -26678,17 +29295,49 @@ func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TaskStatusResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintenancePolicy: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TaskStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintenancePolicy: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPb
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPb
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPb
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Window = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TaskMeta", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Override", wireType)
 			}
-			m.TaskMeta = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPb
@@ -26698,11 +29347,12 @@ func (m *TaskStatusResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TaskMeta |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.Override = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPb(dAtA[iNdEx:])
@@ -47,9 +47,14 @@ func isTernary(f string) bool {
 	return f == "cond"
 }
 
+func isCoalesce(f string) bool {
+	return f == "coalesce"
+}
+
 func isBinary(f string) bool {
 	return f == "+" || f == "*" || f == "-" || f == "/" || f == "%" ||
-		f == "max" || f == "min" || f == "logbase" || f == "pow"
+		f == "max" || f == "min" || f == "logbase" || f == "pow" ||
+		f == "jaro" || f == "jarowinkler" || f == "cosine"
 }
 
 func convertTo(from *pb.TaskValue) (types.Val, error) {
@@ -73,6 +78,13 @@ func compareValues(ag string, va, vb types.Val) (bool, error) {
 	if err != nil {
 		//Try to convert values.
 		switch {
+		case va.Tid == types.DecimalID || vb.Tid == types.DecimalID:
+			if err := toDecimal(&va); err != nil {
+				return false, err
+			}
+			if err := toDecimal(&vb); err != nil {
+				return false, err
+			}
 		case va.Tid == types.IntID:
 			va.Tid = types.FloatID
 			va.Value = float64(va.Value.(int64))
@@ -128,6 +140,9 @@ func applyAdd(a, b, c *types.Val) error {
 	case FLOAT:
 		c.Value = a.Value.(float64) + b.Value.(float64)
 
+	case DECIMAL:
+		c.Value = a.Value.(types.Decimal).Add(b.Value.(types.Decimal))
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func +", a.Tid)
 	}
@@ -150,6 +165,9 @@ func applySub(a, b, c *types.Val) error {
 	case FLOAT:
 		c.Value = a.Value.(float64) - b.Value.(float64)
 
+	case DECIMAL:
+		c.Value = a.Value.(types.Decimal).Sub(b.Value.(types.Decimal))
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func -", a.Tid)
 	}
@@ -172,6 +190,9 @@ func applyMul(a, b, c *types.Val) error {
 	case FLOAT:
 		c.Value = a.Value.(float64) * b.Value.(float64)
 
+	case DECIMAL:
+		c.Value = a.Value.(types.Decimal).Mul(b.Value.(types.Decimal))
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func *", a.Tid)
 	}
@@ -193,6 +214,22 @@ func applyDiv(a, b, c *types.Val) error {
 		}
 		c.Value = a.Value.(float64) / b.Value.(float64)
 
+	case DECIMAL:
+		// The quotient is rounded to the larger of the two scales.
+		da, db := a.Value.(types.Decimal), b.Value.(types.Decimal)
+		if db.Sign() == 0 {
+			return ErrorDivisionByZero
+		}
+		scale := da.Scale
+		if db.Scale > scale {
+			scale = db.Scale
+		}
+		q, err := da.Quo(db, scale)
+		if err != nil {
+			return err
+		}
+		c.Value = q
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func /", a.Tid)
 	}
@@ -214,6 +251,16 @@ func applyMod(a, b, c *types.Val) error {
 		}
 		c.Value = math.Mod(a.Value.(float64), b.Value.(float64))
 
+	case DECIMAL:
+		if b.Value.(types.Decimal).Sign() == 0 {
+			return ErrorDivisionByZero
+		}
+		r, err := a.Value.(types.Decimal).Rem(b.Value.(types.Decimal))
+		if err != nil {
+			return err
+		}
+		c.Value = r
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func %%", a.Tid)
 	}
@@ -222,6 +269,10 @@ func applyMod(a, b, c *types.Val) error {
 
 func applyPow(a, b, c *types.Val) error {
 	vBase := getValType(a)
+	if vBase == DECIMAL {
+		decimalToFloat(a, b)
+		c.Tid, vBase = types.FloatID, FLOAT
+	}
 	switch vBase {
 	case INT:
 		c.Value = math.Pow(float64(a.Value.(int64)), float64(b.Value.(int64)))
@@ -243,6 +294,10 @@ func applyPow(a, b, c *types.Val) error {
 
 func applyLog(a, b, c *types.Val) error {
 	vBase := getValType(a)
+	if vBase == DECIMAL {
+		decimalToFloat(a, b)
+		c.Tid, vBase = types.FloatID, FLOAT
+	}
 	switch vBase {
 	case INT:
 		if a.Value.(int64) < 0 || b.Value.(int64) < 0 {
@@ -267,6 +322,25 @@ func applyLog(a, b, c *types.Val) error {
 	return nil
 }
 
+// similarityFunc returns the binary function computing the similarity of two strings, using
+// the given measure.
+func similarityFunc(name string, measure func(a, b []rune) float64) binaryFunc {
+	return func(a, b, c *types.Val) error {
+		if getValType(a) != DEFAULT || getValType(b) != DEFAULT {
+			return errors.Errorf("Wrong types %v, %v encountered for func %s", a.Tid, b.Tid, name)
+		}
+		sa, aOk := a.Value.(string)
+		sb, bOk := b.Value.(string)
+		if !aOk || !bOk {
+			return errors.Errorf("Func %s expects string values, got %v and %v", name, a.Value,
+				b.Value)
+		}
+		c.Tid = types.FloatID
+		c.Value = measure(normalizeForSimilarity(sa), normalizeForSimilarity(sb))
+		return nil
+	}
+}
+
 func applyMin(a, b, c *types.Val) error {
 	r, err := types.Less(*a, *b)
 	if err != nil {
@@ -295,6 +369,10 @@ func applyMax(a, b, c *types.Val) error {
 
 func applyLn(a, res *types.Val) error {
 	vBase := getValType(a)
+	if vBase == DECIMAL {
+		decimalToFloat(a)
+		res.Tid, vBase = types.FloatID, FLOAT
+	}
 	switch vBase {
 	case INT:
 		if a.Value.(int64) < 0 {
@@ -317,6 +395,10 @@ func applyLn(a, res *types.Val) error {
 
 func applyExp(a, res *types.Val) error {
 	vBase := getValType(a)
+	if vBase == DECIMAL {
+		decimalToFloat(a)
+		res.Tid, vBase = types.FloatID, FLOAT
+	}
 	switch vBase {
 	case INT:
 		res.Value = math.Exp(float64(a.Value.(int64)))
@@ -344,6 +426,9 @@ func applyNeg(a, res *types.Val) error {
 	case FLOAT:
 		res.Value = -a.Value.(float64)
 
+	case DECIMAL:
+		res.Value = a.Value.(types.Decimal).Neg()
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func u-", a.Tid)
 	}
@@ -352,6 +437,10 @@ func applyNeg(a, res *types.Val) error {
 
 func applySqrt(a, res *types.Val) error {
 	vBase := getValType(a)
+	if vBase == DECIMAL {
+		decimalToFloat(a)
+		res.Tid, vBase = types.FloatID, FLOAT
+	}
 	switch vBase {
 	case INT:
 		if a.Value.(int64) < 0 {
@@ -381,6 +470,9 @@ func applyFloor(a, res *types.Val) error {
 	case FLOAT:
 		res.Value = math.Floor(a.Value.(float64))
 
+	case DECIMAL:
+		res.Value = a.Value.(types.Decimal).Floor()
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for func floor", a.Tid)
 	}
@@ -396,6 +488,9 @@ func applyCeil(a, res *types.Val) error {
 	case FLOAT:
 		res.Value = math.Ceil(a.Value.(float64))
 
+	case DECIMAL:
+		res.Value = a.Value.(types.Decimal).Ceil()
+
 	case DEFAULT:
 		return errors.Errorf("Wrong type %v encountered for fun ceil", a.Tid)
 	}
@@ -435,6 +530,10 @@ var binaryFunctions = map[string]binaryFunc{
 	"logbase": applyLog,
 	"min":     applyMin,
 	"max":     applyMax,
+
+	"jaro":        similarityFunc("jaro", jaroSimilarity),
+	"jarowinkler": similarityFunc("jarowinkler", jaroWinklerSimilarity),
+	"cosine":      similarityFunc("cosine", cosineSimilarity),
 }
 
 type valType int
@@ -443,6 +542,7 @@ const (
 	INT valType = iota
 	FLOAT
 	DEFAULT
+	DECIMAL
 )
 
 func getValType(v *types.Val) valType {
@@ -452,6 +552,8 @@ func getValType(v *types.Val) valType {
 		vBase = INT
 	case types.FloatID:
 		vBase = FLOAT
+	case types.DecimalID:
+		vBase = DECIMAL
 	default:
 		vBase = DEFAULT
 	}
@@ -470,6 +572,17 @@ func (ag *aggregator) matchType(v, va *types.Val) error {
 			va.Tid, ag.name)
 	}
 
+	// Ints and floats used along with decimals are converted to decimals, so that the result is
+	// exact. Floats are converted using their shortest representation, so 1.1 is exactly 1.1.
+	if vBase == DECIMAL || vaBase == DECIMAL {
+		for _, val := range []*types.Val{v, va} {
+			if err := toDecimal(val); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// One of them is int and one is float
 	if vBase == INT {
 		v.Tid = types.FloatID
@@ -484,6 +597,37 @@ func (ag *aggregator) matchType(v, va *types.Val) error {
 	return nil
 }
 
+// toDecimal converts an int or float value to a decimal.
+func toDecimal(v *types.Val) error {
+	switch v.Tid {
+	case types.IntID:
+		v.Value = types.DecimalFromInt(v.Value.(int64))
+	case types.FloatID:
+		d, err := types.DecimalFromFloat(v.Value.(float64))
+		if err != nil {
+			return err
+		}
+		v.Value = d
+	case types.DecimalID:
+		// Already a decimal.
+	default:
+		return errors.Errorf("Wrong type %v encountered, expected a number", v.Tid)
+	}
+	v.Tid = types.DecimalID
+	return nil
+}
+
+// decimalToFloat converts the decimal values to floats, for the functions which can't give an
+// exact result.
+func decimalToFloat(vals ...*types.Val) {
+	for _, v := range vals {
+		if v.Tid == types.DecimalID {
+			v.Tid = types.FloatID
+			v.Value = v.Value.(types.Decimal).Float64()
+		}
+	}
+}
+
 func (ag *aggregator) ApplyVal(v types.Val) error {
 	if v.Value == nil {
 		// If the value is missing, treat it as 0.
@@ -557,6 +701,8 @@ func (ag *aggregator) Apply(val types.Val) {
 			va.Value = va.Value.(int64) + vb.Value.(int64)
 		case va.Tid == types.FloatID && vb.Tid == types.FloatID:
 			va.Value = va.Value.(float64) + vb.Value.(float64)
+		case va.Tid == types.DecimalID && vb.Tid == types.DecimalID:
+			va.Value = va.Value.(types.Decimal).Add(vb.Value.(types.Decimal))
 		}
 		// Skipping the else case since that means the pair cannot be summed.
 		res = va
@@ -587,6 +733,15 @@ func (ag *aggregator) divideByCount() {
 	if ag.name != "avg" || ag.count == 0 || ag.result.Value == nil {
 		return
 	}
+	// The average of decimals is a decimal, rounded to the scale of the values.
+	if ag.result.Tid == types.DecimalID {
+		d := ag.result.Value.(types.Decimal)
+		avg, err := d.Quo(types.DecimalFromInt(int64(ag.count)), d.Scale)
+		x.Check(err)
+		ag.result.Value = avg
+		return
+	}
+
 	var v float64
 	switch ag.result.Tid {
 	case types.IntID:
@@ -616,3 +771,33 @@ func (ag *aggregator) Value() (types.Val, error) {
 	}
 	return ag.result, nil
 }
+
+// weightedAvg computes the average of values weighted by other values, sum(w*v) / sum(w).
+type weightedAvg struct {
+	sum     float64
+	weights float64
+}
+
+func (wa *weightedAvg) apply(val, weight types.Val) error {
+	decimalToFloat(&val, &weight)
+	for _, f := range []*types.Val{&val, &weight} {
+		switch f.Tid {
+		case types.IntID:
+			f.Value = float64(f.Value.(int64))
+		case types.FloatID:
+		default:
+			return errors.Errorf("Wrong type %v encountered for weighted avg, expected a number",
+				f.Tid)
+		}
+	}
+	wa.sum += val.Value.(float64) * weight.Value.(float64)
+	wa.weights += weight.Value.(float64)
+	return nil
+}
+
+func (wa *weightedAvg) value() (types.Val, error) {
+	if wa.weights == 0 {
+		return types.Val{}, ErrEmptyVal
+	}
+	return types.Val{Tid: types.FloatID, Value: wa.sum / wa.weights}, nil
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// maxCascadeDeleteDepth is the number of levels of nodes a deletion can be cascaded through. A
+// deletion going deeper is rejected rather than partially applied.
+const maxCascadeDeleteDepth = 64
+
+// addCascadeDeletes adds an S * * deletion for the nodes reached from the nodes deleted with
+// S * * along the predicate of the @cascadeDelete directive of their types. The nodes reached
+// are deleted in turn, following the directives of their own types. Each node is only visited
+// once, so cycles end there. Unless force is set, a node which is also pointed to by a node that
+// isn't deleted is kept, which needs @reverse on the predicate to find the nodes pointing to it.
+func addCascadeDeletes(ctx context.Context, m *pb.Mutations) error {
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "While cascading deletes")
+	}
+	isGalaxyQuery := x.IsGalaxyOperation(ctx)
+
+	// The deletion of a node is copied for the nodes it cascades to, so that they are deleted
+	// with the same namespace and the same predicates allowed by ACL.
+	deleted := make(map[uint64]map[uint64]*pb.DirectedEdge)
+	for _, edge := range m.Edges {
+		if edge.Attr != x.Star || edge.Op != pb.DirectedEdge_DEL {
+			continue
+		}
+		ns := namespace
+		if isGalaxyQuery {
+			ns = edge.GetNamespace()
+		}
+		if deleted[ns] == nil {
+			deleted[ns] = make(map[uint64]*pb.DirectedEdge)
+		}
+		if _, ok := deleted[ns][edge.Entity]; !ok {
+			deleted[ns][edge.Entity] = edge
+		}
+	}
+
+	for ns, nodes := range deleted {
+		cascades := schema.State().CascadeDeleteTypes(ns)
+		if len(cascades) == 0 {
+			continue
+		}
+		edges, err := cascadeDeletes(x.AttachNamespace(ctx, ns), ns, m.StartTs, cascades, nodes)
+		if err != nil {
+			return err
+		}
+		m.Edges = append(m.Edges, edges...)
+	}
+	return nil
+}
+
+// cascadeDeletes returns the deletions of the nodes reached from the deleted nodes, which are
+// added to deleted. The nodes are visited level by level.
+func cascadeDeletes(ctx context.Context, ns, readTs uint64,
+	cascades map[string]schema.CascadeDelete,
+	deleted map[uint64]*pb.DirectedEdge) ([]*pb.DirectedEdge, error) {
+
+	var edges []*pb.DirectedEdge
+	level := make([]uint64, 0, len(deleted))
+	for uid := range deleted {
+		level = append(level, uid)
+	}
+	for depth := 0; len(level) > 0; depth++ {
+		if depth > maxCascadeDeleteDepth {
+			return nil, errors.Errorf("Deletion cascades through more than %d levels of nodes",
+				maxCascadeDeleteDepth)
+		}
+		sort.Slice(level, func(i, j int) bool { return level[i] < level[j] })
+		types, err := fetchNodeTypes(ctx, level, readTs)
+		if err != nil {
+			return nil, err
+		}
+
+		// The nodes of the level, sorted, by the directive of their types.
+		byCascade := make(map[schema.CascadeDelete][]uint64)
+		for _, uid := range level {
+			for _, typ := range types[uid] {
+				cd, ok := cascades[typ]
+				if !ok {
+					continue
+				}
+				if uids := byCascade[cd]; len(uids) == 0 || uids[len(uids)-1] != uid {
+					byCascade[cd] = append(uids, uid)
+				}
+			}
+		}
+
+		var next []uint64
+		for cd, parents := range byCascade {
+			children, err := cascadeChildren(ctx, ns, readTs, cd, parents, deleted)
+			if err != nil {
+				return nil, err
+			}
+			for child, parent := range children {
+				if _, ok := deleted[child]; ok {
+					continue
+				}
+				edge := *deleted[parent]
+				edge.Entity = child
+				deleted[child] = &edge
+				edges = append(edges, &edge)
+				next = append(next, child)
+			}
+		}
+		level = next
+	}
+	return edges, nil
+}
+
+// cascadeChildren returns the nodes which aren't deleted yet pointed to by the parents along the
+// predicate of the directive, mapped to one of their parents.
+func cascadeChildren(ctx context.Context, ns, readTs uint64, cd schema.CascadeDelete,
+	parents []uint64, deleted map[uint64]*pb.DirectedEdge) (map[uint64]uint64, error) {
+
+	taskQuery, err := createTaskQuery(ctx, &SubGraph{
+		Attr:    cd.Via,
+		SrcUIDs: &pb.List{Uids: parents},
+		ReadTs:  readTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := worker.ProcessTaskOverNetwork(ctx, taskQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while cascading the deletion along %s", cd.Via)
+	}
+	children := make(map[uint64]uint64)
+	var uids []uint64
+	for i, list := range result.UidMatrix {
+		for _, uid := range list.Uids {
+			if _, ok := deleted[uid]; ok {
+				continue
+			}
+			if _, ok := children[uid]; !ok {
+				children[uid] = parents[i]
+				uids = append(uids, uid)
+			}
+		}
+	}
+	if cd.Force || len(uids) == 0 {
+		return children, nil
+	}
+
+	// The nodes also pointed to by nodes which aren't deleted are kept.
+	if !schema.State().IsReversed(ctx, x.NamespaceAttr(ns, cd.Via)) {
+		return nil, errors.Errorf("Predicate %s of @cascadeDelete needs @reverse to find the "+
+			"other nodes pointing to the nodes to delete, or force: true to delete them anyway",
+			cd.Via)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	taskQuery, err = createTaskQuery(ctx, &SubGraph{
+		Attr:    "~" + cd.Via,
+		SrcUIDs: &pb.List{Uids: uids},
+		ReadTs:  readTs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err = worker.ProcessTaskOverNetwork(ctx, taskQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while cascading the deletion along %s", cd.Via)
+	}
+	for i, list := range result.UidMatrix {
+		for _, ref := range list.Uids {
+			if _, ok := deleted[ref]; !ok {
+				delete(children, uids[i])
+				break
+			}
+		}
+	}
+	return children, nil
+}
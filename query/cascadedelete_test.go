@@ -0,0 +1,176 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+)
+
+// setCascadeDeleteSchema applies the schema of the tests and returns the function dropping it.
+func setCascadeDeleteSchema(t *testing.T) func() {
+	setSchema(testSchema + `
+		cd_name: string @index(exact) .
+		cd_owns: [uid] @reverse .
+		cd_files: [uid] .
+		cd_tags: [uid] .
+		type CdUser @cascadeDelete(via: cd_owns) {
+			cd_name
+			cd_owns
+		}
+		type CdFolder @cascadeDelete(via: cd_files, force: true) {
+			cd_name
+			cd_files
+		}
+		type CdTag @cascadeDelete(via: cd_tags) {
+			cd_name
+			cd_tags
+		}
+	`)
+	return func() {
+		for _, typ := range []string{"CdUser", "CdFolder", "CdTag"} {
+			require.NoError(t, client.Alter(context.Background(), &api.Operation{
+				DropOp: api.Operation_TYPE, DropValue: typ}))
+		}
+		for _, pred := range []string{"cd_name", "cd_owns", "cd_files", "cd_tags"} {
+			dropPredicate(pred)
+		}
+	}
+}
+
+func deleteNode(uid string) error {
+	txn := client.NewTxn()
+	ctx := context.Background()
+	defer txn.Discard(ctx)
+
+	_, err := txn.Mutate(ctx, &api.Mutation{
+		DelNquads: []byte(fmt.Sprintf("<%s> * * .", uid)),
+		CommitNow: true,
+	})
+	return err
+}
+
+// cascadeNames returns the names of the nodes which are left.
+func cascadeNames(t *testing.T) string {
+	return processQueryNoErr(t, `{q(func: has(cd_name), orderasc: cd_name) { cd_name }}`)
+}
+
+func TestCascadeDelete(t *testing.T) {
+	defer setCascadeDeleteSchema(t)()
+
+	// 0x6000 and 0x6001 own each other, the cycle ends at the nodes already deleted.
+	require.NoError(t, addTriplesToCluster(`
+		<0x6000> <dgraph.type> "CdUser" .
+		<0x6000> <cd_name> "a" .
+		<0x6000> <cd_owns> <0x6001> .
+		<0x6001> <dgraph.type> "CdUser" .
+		<0x6001> <cd_name> "b" .
+		<0x6001> <cd_owns> <0x6000> .
+		<0x6001> <cd_owns> <0x6002> .
+		<0x6002> <dgraph.type> "CdUser" .
+		<0x6002> <cd_name> "c" .
+		<0x6003> <dgraph.type> "CdUser" .
+		<0x6003> <cd_name> "d" .
+	`))
+	require.NoError(t, deleteNode("0x6000"))
+	require.JSONEq(t, `{"data": {"q": [{"cd_name": "d"}]}}`, cascadeNames(t))
+}
+
+func TestCascadeDeleteSharedChildren(t *testing.T) {
+	defer setCascadeDeleteSchema(t)()
+
+	// 0x6012 is also owned by 0x6011, which isn't deleted, so it's kept. With force, the file
+	// 0x6022 is deleted along with 0x6020 although 0x6021 points to it too.
+	require.NoError(t, addTriplesToCluster(`
+		<0x6010> <dgraph.type> "CdUser" .
+		<0x6010> <cd_name> "a" .
+		<0x6010> <cd_owns> <0x6012> .
+		<0x6010> <cd_owns> <0x6013> .
+		<0x6011> <dgraph.type> "CdUser" .
+		<0x6011> <cd_name> "b" .
+		<0x6011> <cd_owns> <0x6012> .
+		<0x6012> <cd_name> "c" .
+		<0x6013> <cd_name> "d" .
+		<0x6020> <dgraph.type> "CdFolder" .
+		<0x6020> <cd_name> "e" .
+		<0x6020> <cd_files> <0x6022> .
+		<0x6021> <dgraph.type> "CdFolder" .
+		<0x6021> <cd_name> "f" .
+		<0x6021> <cd_files> <0x6022> .
+		<0x6022> <cd_name> "g" .
+	`))
+	require.NoError(t, deleteNode("0x6010"))
+	require.NoError(t, deleteNode("0x6020"))
+	require.JSONEq(t, `{"data": {"q": [{"cd_name": "b"}, {"cd_name": "c"}, {"cd_name": "f"}]}}`,
+		cascadeNames(t))
+
+	// Once its other owner is deleted too, the shared node goes with it.
+	require.NoError(t, deleteNode("0x6011"))
+	require.JSONEq(t, `{"data": {"q": [{"cd_name": "f"}]}}`, cascadeNames(t))
+}
+
+func TestCascadeDeleteNeedsReverse(t *testing.T) {
+	defer setCascadeDeleteSchema(t)()
+
+	require.NoError(t, addTriplesToCluster(`
+		<0x6030> <dgraph.type> "CdTag" .
+		<0x6030> <cd_name> "a" .
+		<0x6030> <cd_tags> <0x6031> .
+		<0x6031> <cd_name> "b" .
+	`))
+	err := deleteNode("0x6030")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate cd_tags of @cascadeDelete needs @reverse")
+	// The deletion is rejected, not applied to the node itself only.
+	require.JSONEq(t, `{"data": {"q": [{"cd_name": "a"}, {"cd_name": "b"}]}}`, cascadeNames(t))
+}
+
+func TestCascadeDeleteMaxDepth(t *testing.T) {
+	defer setCascadeDeleteSchema(t)()
+
+	// A chain of maxCascadeDeleteDepth+2 nodes, the last one is one level too deep.
+	var sb strings.Builder
+	n := maxCascadeDeleteDepth + 2
+	for i := 0; i < n; i++ {
+		uid := 0x6100 + i
+		sb.WriteString(fmt.Sprintf("<%#x> <dgraph.type> \"CdUser\" .\n", uid))
+		sb.WriteString(fmt.Sprintf("<%#x> <cd_name> \"%03d\" .\n", uid, i))
+		if i+1 < n {
+			sb.WriteString(fmt.Sprintf("<%#x> <cd_owns> <%#x> .\n", uid, uid+1))
+		}
+	}
+	require.NoError(t, addTriplesToCluster(sb.String()))
+	count := func() string {
+		return processQueryNoErr(t, `{q(func: has(cd_name)) { count(uid) }}`)
+	}
+
+	err := deleteNode("0x6100")
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		fmt.Sprintf("Deletion cascades through more than %d levels of nodes", maxCascadeDeleteDepth))
+	require.JSONEq(t, fmt.Sprintf(`{"data": {"q": [{"count": %d}]}}`, n), count())
+
+	// Without the last node, the chain is deep enough to be deleted.
+	require.NoError(t, deleteNode(fmt.Sprintf("%#x", 0x6100+n-1)))
+	require.NoError(t, deleteNode("0x6100"))
+	require.JSONEq(t, `{"data": {"q": [{"count": 0}]}}`, count())
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// The coercion functions convert the values of a value variable to another type, so that e.g.
+// numbers stored as strings can be compared numerically with gt(toint(val(x)), 5), or used in
+// math(tofloat(x) * 2).
+const (
+	toIntFn    = "toint"
+	toFloatFn  = "tofloat"
+	toStringFn = "tostring"
+)
+
+func isCoercion(f string) bool {
+	return f == toIntFn || f == toFloatFn || f == toStringFn
+}
+
+// coercionType returns the type of the values returned by the given coercion function.
+func coercionType(f string) types.TypeID {
+	switch f {
+	case toIntFn:
+		return types.IntID
+	case toFloatFn:
+		return types.FloatID
+	}
+	return types.StringID
+}
+
+// coerceVal converts v using the given coercion function. Strings are parsed as numbers written
+// in Go syntax, with a dot as the decimal separator and without digit grouping, so "1,000" and
+// "1.000,5" can't be parsed. Surrounding spaces are ignored. toint truncates fractional numbers
+// towards zero.
+func coerceVal(f string, v types.Val) (types.Val, error) {
+	to := types.Val{Tid: coercionType(f)}
+	if v.Tid == to.Tid {
+		return v, nil
+	}
+	if to.Tid == types.StringID {
+		err := types.Marshal(v, &to)
+		return to, err
+	}
+
+	var fv float64
+	switch v.Tid {
+	case types.IntID:
+		// Only tofloat gets here, the other types have returned already.
+		to.Value = float64(v.Value.(int64))
+		return to, nil
+	case types.FloatID:
+		fv = v.Value.(float64)
+	case types.DecimalID:
+		fv = v.Value.(types.Decimal).Float64()
+	case types.BoolID:
+		if v.Value.(bool) {
+			fv = 1
+		}
+	case types.StringID, types.DefaultID:
+		s := strings.TrimSpace(v.Value.(string))
+		if to.Tid == types.IntID {
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				to.Value = i
+				return to, nil
+			}
+		}
+		var err error
+		if fv, err = strconv.ParseFloat(s, 64); err != nil {
+			return to, errors.Errorf("Can't parse %q as a number in %s", s, f)
+		}
+	default:
+		return to, errors.Errorf("Can't convert a value of type %s in %s", v.Tid.Name(), f)
+	}
+
+	if to.Tid == types.FloatID {
+		to.Value = fv
+		return to, nil
+	}
+	if math.IsNaN(fv) || fv >= math.MaxInt64 || fv < math.MinInt64 {
+		return to, errors.Errorf("Value %v is out of the range of int in %s", fv, f)
+	}
+	to.Value = int64(fv)
+	return to, nil
+}
+
+// coerceVals converts the values of a value variable using the given coercion function. Values
+// which can't be converted are left out, as if the uid didn't have a value, so that data with
+// a few malformed values can still be queried.
+func coerceVals(f string, vals map[uint64]types.Val) map[uint64]types.Val {
+	out := make(map[uint64]types.Val, len(vals))
+	for uid, v := range vals {
+		if v.Value == nil {
+			continue
+		}
+		if cv, err := coerceVal(f, v); err == nil {
+			out[uid] = cv
+		}
+	}
+	return out
+}
+
+// processCoercion evaluates a coercion function in a math expression. A constant which can't be
+// converted is an error, as it is part of the query.
+func processCoercion(mNode *mathTree) error {
+	ch := mNode.Child[0]
+	if ch.Const.Value != nil {
+		var err error
+		mNode.Const, err = coerceVal(mNode.Fn, ch.Const)
+		return err
+	}
+	mNode.Val = coerceVals(mNode.Fn, ch.Val)
+	return nil
+}
@@ -337,6 +337,9 @@ tweet-a                        : string @index(trigram) .
 tweet-b                        : string @index(term) .
 tweet-c                        : string @index(fulltext) .
 tweet-d                        : string @index(trigram) .
+synopsis                       : string @index(fulltext, bm25) .
+headline                       : string @index(bm25) .
+blurb                          : string @index(bm25) @lang .
 name2                          : string @index(term)  .
 age2                           : int @index(int) .
 `
@@ -858,6 +861,16 @@ func populateCluster() {
 		<63> <tweet-c> "I am a citizen" .
 		<64> <tweet-c> "I am a citizen of Paradis Island" .
 
+		<5401> <synopsis> "graph database for search" .
+		<5402> <synopsis> "a graph of a graph database with a graph query language" .
+		<5403> <synopsis> "relational database" .
+		<5404> <synopsis> "search engine" .
+		<5401> <headline> "search tips" .
+		<5404> <headline> "graph search" .
+		<5405> <blurb> "graph search"@en .
+		<5405> <blurb> "base de données"@fr .
+		<5406> <blurb> "graph search"@en .
+
 		<61> <tweet-d> "aaabxxx" .
 		<62> <tweet-d> "aaacdxx" .
 		<63> <tweet-d> "aaabcd" .
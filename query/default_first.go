@@ -0,0 +1,89 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Truncated holds the root blocks whose results were limited by --limit default-first. It's
+// passed in the context of a query with the key TruncatedKey, and the blocks are reported in the
+// extensions of the response over HTTP and in the header dgraph-truncated over gRPC.
+type Truncated struct {
+	Blocks []string
+}
+
+// applyDefaultFirst limits the results of the root block sg to --limit default-first if it uses
+// it. definesVars is true if the block defines variables, which are used by the other blocks or
+// mutations, so they aren't limited either.
+func (req *Request) applyDefaultFirst(sg *SubGraph, gq *gql.GraphQuery, definesVars bool) {
+	if req.Upsert || definesVars || !usesDefaultFirst(gq, &sg.Params) {
+		return
+	}
+	sg.Params.Count = int(x.Config.LimitDefaultFirst) + 1
+	sg.Params.DefaultFirst = true
+}
+
+// usesDefaultFirst returns true if the results of the root block gq are to be limited by
+// --limit default-first. It only applies to the blocks which return uids without any pagination.
+// The blocks counting their results with count(uid) aren't limited, nor are the var, shortest
+// path, recurse, groupby and cascade blocks, whose output depends on all the results.
+func usesDefaultFirst(gq *gql.GraphQuery, args *params) bool {
+	if x.Config.LimitDefaultFirst == 0 || args.Offset != 0 || args.AfterUID != 0 {
+		return false
+	}
+	if _, ok := gq.Args["first"]; ok {
+		// first: 0 opts out of the limit.
+		return false
+	}
+	if args.Alias == "var" || args.Alias == "shortest" || args.Recurse || args.IsGroupBy ||
+		len(args.Cascade.Fields) > 0 || gq.Func == nil {
+		return false
+	}
+	for _, child := range gq.Children {
+		if child.IsCount && child.Attr == "uid" {
+			return false
+		}
+	}
+	return true
+}
+
+// truncateToDefaultFirst keeps the first default-first results of a root block limited by
+// --limit default-first, and marks it as truncated if there were more of them.
+func (sg *SubGraph) truncateToDefaultFirst() {
+	limit := int(x.Config.LimitDefaultFirst)
+	if len(sg.uidMatrix) == 0 || len(sg.uidMatrix[0].Uids) <= limit {
+		return
+	}
+	sg.truncated = true
+	// The list is replaced instead of being updated as it can also be the DestUIDs.
+	uids := sg.uidMatrix[0].Uids[:limit]
+	kept := make(map[uint64]struct{}, len(uids))
+	for _, uid := range uids {
+		kept[uid] = struct{}{}
+	}
+	dest := make([]uint64, 0, len(uids))
+	for _, uid := range sg.DestUIDs.GetUids() {
+		if _, ok := kept[uid]; ok {
+			dest = append(dest, uid)
+		}
+	}
+	sg.uidMatrix[0] = &pb.List{Uids: uids}
+	sg.DestUIDs = &pb.List{Uids: dest}
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestUsesDefaultFirst(t *testing.T) {
+	defer func(limit uint64) { x.Config.LimitDefaultFirst = limit }(x.Config.LimitDefaultFirst)
+	x.Config.LimitDefaultFirst = 10
+
+	tests := []struct {
+		query   string
+		limited bool
+	}{
+		{`{ q(func: has(name)) { name } }`, true},
+		{`{ q(func: has(name), orderasc: name) { name } }`, true},
+		{`{ q(func: has(name), first: 20) { name } }`, false},
+		{`{ q(func: has(name), first: 0) { name } }`, false},
+		{`{ q(func: has(name), offset: 5) { name } }`, false},
+		{`{ q(func: has(name)) { count(uid) } }`, false},
+		{`{ q(func: has(name)) @cascade { name } }`, false},
+		{`{ q(func: has(name)) @groupby(age) { count(uid) } }`, false},
+		{`{ q(func: has(name)) @recurse(depth: 2) { friend } }`, false},
+		{`{ var(func: has(name)) { n as name } q(func: eq(name, val(n))) { name } }`, false},
+		{`{ q(func: has(name)) { n as name } p(func: eq(name, val(n))) { name } }`, false},
+		{`{ q(func: has(name)) { f as friend } p(func: uid(f)) { name } }`, false},
+	}
+	apply := func(req *Request, query string) *SubGraph {
+		res, err := gql.Parse(gql.Request{Str: query})
+		require.NoError(t, err)
+		sg, err := newGraph(context.Background(), res.Query[0])
+		require.NoError(t, err)
+		req.applyDefaultFirst(sg, res.Query[0], len(res.QueryVars[0].Defines) > 0)
+		return sg
+	}
+	for _, tc := range tests {
+		sg := apply(&Request{}, tc.query)
+		require.Equal(t, tc.limited, sg.Params.DefaultFirst, tc.query)
+		if tc.limited {
+			require.Equal(t, 11, sg.Params.Count, tc.query)
+		}
+	}
+
+	// The query of an upsert block isn't limited, as the mutations apply to all its results.
+	sg := apply(&Request{Upsert: true}, `{ q(func: has(name)) { name } }`)
+	require.False(t, sg.Params.DefaultFirst)
+	require.Zero(t, sg.Params.Count)
+
+	x.Config.LimitDefaultFirst = 0
+	sg = apply(&Request{}, `{ q(func: has(name)) { name } }`)
+	require.False(t, sg.Params.DefaultFirst)
+	require.Zero(t, sg.Params.Count)
+}
+
+func TestTruncateToDefaultFirst(t *testing.T) {
+	defer func(limit uint64) { x.Config.LimitDefaultFirst = limit }(x.Config.LimitDefaultFirst)
+	x.Config.LimitDefaultFirst = 2
+
+	// The results are ordered, so the first ones aren't the smallest uids.
+	list := &pb.List{Uids: []uint64{5, 1, 3}}
+	sg := &SubGraph{uidMatrix: []*pb.List{list}, DestUIDs: &pb.List{Uids: []uint64{1, 3, 5}}}
+	sg.truncateToDefaultFirst()
+	require.True(t, sg.truncated)
+	require.Equal(t, []uint64{5, 1}, sg.uidMatrix[0].Uids)
+	require.Equal(t, []uint64{1, 5}, sg.DestUIDs.Uids)
+	require.Equal(t, []uint64{5, 1, 3}, list.Uids)
+
+	// There were no more results than the limit.
+	sg = &SubGraph{uidMatrix: []*pb.List{{Uids: []uint64{1, 2}}},
+		DestUIDs: &pb.List{Uids: []uint64{1, 2}}}
+	sg.truncateToDefaultFirst()
+	require.False(t, sg.truncated)
+	require.Equal(t, []uint64{1, 2}, sg.DestUIDs.Uids)
+}
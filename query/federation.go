@@ -0,0 +1,502 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/task"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// With --federation, some predicates are served by other Dgraph clusters. The uids of two clusters
+// are unrelated, so the nodes are matched by the values of a key predicate, like an xid, which
+// both clusters hold. When a federated predicate is queried, the keys of the nodes are read
+// locally and the values of the predicate are fetched from the remote cluster with a best-effort
+// query, so they may not be consistent with the local read. The functions and the orders on a
+// federated predicate are run on the keys of the nodes too. Only scalar predicates can be
+// federated, as the uids a remote cluster would return can't be used locally.
+
+// federation holds the remote clusters of the federated predicates.
+var federation struct {
+	sync.RWMutex
+	key     string
+	timeout time.Duration
+	// remotes maps a predicate to the client of the cluster serving it.
+	remotes map[string]*federatedRemote
+}
+
+type federatedRemote struct {
+	addr   string
+	client *dgo.Dgraph
+}
+
+// InitFederation parses the --federation SuperFlag and connects to the remote clusters.
+func InitFederation(sf *z.SuperFlag) error {
+	preds, err := parseFederatedPredicates(sf.GetString("predicates"))
+	if err != nil {
+		return err
+	}
+	key := strings.TrimSpace(sf.GetString("key"))
+	if len(preds) > 0 && key == "" {
+		return errors.Errorf("--federation key must be set to federate predicates")
+	}
+
+	// The clients of the predicates served by the same cluster share the connection.
+	clients := make(map[string]*dgo.Dgraph)
+	remotes := make(map[string]*federatedRemote, len(preds))
+	for pred, addr := range preds {
+		if pred == key {
+			return errors.Errorf("--federation key %s can't be a federated predicate", key)
+		}
+		client, ok := clients[addr]
+		if !ok {
+			conn, err := grpc.Dial(addr, grpc.WithInsecure())
+			if err != nil {
+				return errors.Wrapf(err, "while connecting to the federated cluster %s", addr)
+			}
+			client = dgo.NewDgraphClient(api.NewDgraphClient(conn))
+			clients[addr] = client
+		}
+		remotes[pred] = &federatedRemote{addr: addr, client: client}
+	}
+
+	federation.Lock()
+	defer federation.Unlock()
+	federation.key = key
+	federation.timeout = sf.GetDuration("timeout")
+	federation.remotes = remotes
+	return nil
+}
+
+// parseFederatedPredicates parses a comma separated list of predicate@host:port.
+func parseFederatedPredicates(s string) (map[string]string, error) {
+	preds := make(map[string]string)
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		idx := strings.LastIndex(p, "@")
+		if idx <= 0 || idx == len(p)-1 {
+			return nil, errors.Errorf("invalid --federation predicate %q, expected "+
+				"predicate@host:port", p)
+		}
+		pred, addr := p[:idx], p[idx+1:]
+		if _, ok := preds[pred]; ok {
+			return nil, errors.Errorf("--federation predicate %s is set more than once", pred)
+		}
+		preds[pred] = addr
+	}
+	return preds, nil
+}
+
+// federatedRemoteOf returns the remote cluster serving attr, or nil if attr isn't federated.
+func federatedRemoteOf(attr string) *federatedRemote {
+	federation.RLock()
+	defer federation.RUnlock()
+	return federation.remotes[attr]
+}
+
+// processFederated fetches the values of a federated predicate for the uids of the task query.
+// The result is built as if the predicate was served locally: there is a list of values for each
+// uid, in the same order.
+func (sg *SubGraph) processFederated(ctx context.Context, q *pb.Query,
+	remote *federatedRemote) (*pb.Result, error) {
+
+	if len(sg.Children) > 0 || sg.Params.DoCount || sg.Params.Facet != nil {
+		return nil, errors.Errorf("Federated predicate %s can only be queried for its values",
+			sg.Attr)
+	}
+	return fetchFederated(ctx, q, sg.Attr, remote)
+}
+
+// fetchFederated fetches the values of attr in the language of q for the uids of q.
+func fetchFederated(ctx context.Context, q *pb.Query, attr string,
+	remote *federatedRemote) (*pb.Result, error) {
+
+	uids := q.UidList.GetUids()
+	keys, toFetch, err := federationKeys(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var remoteVals map[string][]*pb.TaskValue
+	if len(toFetch) > 0 {
+		data, err := queryFederated(ctx, remote, attr,
+			federatedQuery(federationKey(), attr, q.Langs, toFetch))
+		if err != nil {
+			return nil, err
+		}
+		if remoteVals, err = federatedValues(data); err != nil {
+			return nil, errors.Wrapf(err, "while reading predicate %s from %s", attr,
+				remote.addr)
+		}
+	}
+
+	res := &pb.Result{
+		UidMatrix:   make([]*pb.List, len(uids)),
+		ValueMatrix: make([]*pb.ValueList, len(uids)),
+	}
+	for i := range uids {
+		res.UidMatrix[i] = &pb.List{}
+		res.ValueMatrix[i] = &pb.ValueList{}
+		if keys[i] != "" {
+			res.ValueMatrix[i].Values = remoteVals[keys[i]]
+		}
+	}
+	return res, nil
+}
+
+// processFederatedFunc runs the function of a root or a filter on a federated predicate. The
+// remote cluster returns the keys of the nodes matching the function, which are then mapped to the
+// local uids: the uids of the task query for a filter, the nodes having these keys for a root.
+func (sg *SubGraph) processFederatedFunc(ctx context.Context, q *pb.Query,
+	remote *federatedRemote, isRoot bool) (*pb.Result, error) {
+
+	fn, err := federatedFunc(sg.SrcFunc, sg.Attr, q.Langs)
+	if err != nil {
+		return nil, err
+	}
+	key := federationKey()
+
+	if !isRoot {
+		uids := q.UidList.GetUids()
+		keys, toFetch, err := federationKeys(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		if len(toFetch) == 0 {
+			return &pb.Result{UidMatrix: []*pb.List{{}}}, nil
+		}
+		data, err := queryFederated(ctx, remote, sg.Attr, fmt.Sprintf(
+			"{ q(func: eq(<%s>, [%s])) @filter(%s) { k: <%s> } }", key,
+			quoteKeys(toFetch), fn, key))
+		if err != nil {
+			return nil, err
+		}
+		matched, err := federatedKeys(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while reading predicate %s from %s", sg.Attr,
+				remote.addr)
+		}
+		out := &pb.List{}
+		for i, uid := range uids {
+			if keys[i] != "" && matched[keys[i]] {
+				out.Uids = append(out.Uids, uid)
+			}
+		}
+		return &pb.Result{UidMatrix: []*pb.List{out}}, nil
+	}
+
+	data, err := queryFederated(ctx, remote, sg.Attr,
+		fmt.Sprintf("{ q(func: %s) { k: <%s> } }", fn, key))
+	if err != nil {
+		return nil, err
+	}
+	matched, err := federatedKeys(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while reading predicate %s from %s", sg.Attr,
+			remote.addr)
+	}
+	if len(matched) == 0 {
+		return &pb.Result{UidMatrix: []*pb.List{{}}}, nil
+	}
+	args := make([]string, 0, len(matched))
+	for k := range matched {
+		args = append(args, k)
+	}
+	res, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    x.NamespaceAttr(x.ParseNamespace(q.Attr), key),
+		SrcFunc: &pb.SrcFunction{Name: "eq", Args: args},
+		ReadTs:  q.ReadTs,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "while finding the nodes of the federation keys")
+	}
+	return &pb.Result{UidMatrix: []*pb.List{algo.MergeSorted(res.UidMatrix)}}, nil
+}
+
+// sortFederated orders the lists of the uid matrix by the values of a federated predicate, which
+// are fetched from the remote cluster.
+func (sg *SubGraph) sortFederated(ctx context.Context, ns uint64,
+	remote *federatedRemote) error {
+
+	order := sg.Params.Order[0]
+	if len(sg.Params.Order) > 1 || order.Count || order.Reverse {
+		return errors.Errorf("Federated predicate %s can only be the single predicate of "+
+			"an order", order.Attr)
+	}
+	uids := algo.MergeSorted(sg.uidMatrix)
+	res, err := fetchFederated(ctx, &pb.Query{
+		Attr:    x.NamespaceAttr(ns, order.Attr),
+		UidList: uids,
+		Langs:   order.Langs,
+		ReadTs:  sg.ReadTs,
+	}, order.Attr, remote)
+	if err != nil {
+		return err
+	}
+
+	uidToVal := make(map[uint64]types.Val, len(uids.Uids))
+	for i, uid := range uids.Uids {
+		if len(res.ValueMatrix[i].Values) == 0 {
+			continue
+		}
+		v, err := getValue(res.ValueMatrix[i].Values[0])
+		if err != nil {
+			return err
+		}
+		uidToVal[uid] = v
+	}
+	return sg.sortByValues(uidToVal, order.Desc)
+}
+
+// federationKey returns the predicate matching the local and the remote nodes.
+func federationKey() string {
+	federation.RLock()
+	defer federation.RUnlock()
+	return federation.key
+}
+
+// federationKeys reads the federation keys of the uids of the task query. It returns the key of
+// each uid, empty if it has none, and the keys which were found.
+func federationKeys(ctx context.Context, q *pb.Query) ([]string, []string, error) {
+	uids := q.UidList.GetUids()
+	keyRes, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    x.NamespaceAttr(x.ParseNamespace(q.Attr), federationKey()),
+		UidList: q.UidList,
+		ReadTs:  q.ReadTs,
+	})
+	switch {
+	case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
+		keyRes = &pb.Result{}
+	case err != nil:
+		return nil, nil, err
+	}
+
+	keys := make([]string, len(uids))
+	var found []string
+	for i := range uids {
+		if i >= len(keyRes.ValueMatrix) || len(keyRes.ValueMatrix[i].Values) == 0 {
+			continue
+		}
+		val, err := getValue(keyRes.ValueMatrix[i].Values[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		str, err := types.Convert(val, types.StringID)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "while reading the federation key of %#x", uids[i])
+		}
+		keys[i] = str.Value.(string)
+		found = append(found, keys[i])
+	}
+	return keys, found, nil
+}
+
+// queryFederated runs a best-effort query on the remote cluster serving attr.
+func queryFederated(ctx context.Context, remote *federatedRemote, attr,
+	query string) ([]byte, error) {
+
+	federation.RLock()
+	timeout := federation.timeout
+	federation.RUnlock()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	resp, err := remote.client.NewReadOnlyTxn().BestEffort().Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while querying predicate %s from %s", attr, remote.addr)
+	}
+	return resp.Json, nil
+}
+
+// federatedFunc returns the DQL of a function on a federated predicate, to be run by the remote
+// cluster. The functions on variables or counts can't be sent, as they depend on the local data.
+func federatedFunc(fn *Function, attr string, langs []string) (string, error) {
+	if fn.IsCount || fn.IsValueVar || fn.IsLenVar {
+		return "", errors.Errorf("Function %s on federated predicate %s can't use a count or "+
+			"a variable", fn.Name, attr)
+	}
+	for _, arg := range fn.Args {
+		if arg.IsValueVar {
+			return "", errors.Errorf("Function %s on federated predicate %s can't use a "+
+				"variable", fn.Name, attr)
+		}
+	}
+
+	pred := "<" + attr + ">"
+	if len(langs) > 0 {
+		pred += "@" + strings.Join(langs, ":")
+	}
+	var args []string
+	switch fn.Name {
+	case "has":
+		return fmt.Sprintf("has(%s)", pred), nil
+	case "regexp":
+		if len(fn.Args) != 2 {
+			return "", errors.Errorf("Function regexp expects a pattern and its flags")
+		}
+		args = []string{"/" + fn.Args[0].Value + "/" + fn.Args[1].Value}
+	case "eq", "le", "lt", "ge", "gt", "between", "anyofterms", "allofterms", "anyoftext",
+		"alloftext", "match":
+		for _, arg := range fn.Args {
+			args = append(args, strconv.Quote(arg.Value))
+		}
+		if fn.Name == "eq" && len(args) > 1 {
+			args = []string{"[" + strings.Join(args, ", ") + "]"}
+		}
+	default:
+		return "", errors.Errorf("Function %s isn't supported on federated predicate %s",
+			fn.Name, attr)
+	}
+	return fmt.Sprintf("%s(%s, %s)", fn.Name, pred, strings.Join(args, ", ")), nil
+}
+
+func quoteKeys(keys []string) string {
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = strconv.Quote(k)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// federatedQuery returns the DQL query fetching the values of attr for the given keys.
+func federatedQuery(key, attr string, langs, keys []string) string {
+	pred := "<" + attr + ">"
+	if len(langs) > 0 {
+		pred += "@" + strings.Join(langs, ":")
+	}
+	return fmt.Sprintf("{ q(func: eq(<%s>, [%s])) { k: <%s> v: %s } }", key,
+		quoteKeys(keys), key, pred)
+}
+
+// federatedValues reads the response of federatedQuery, returning the values of the predicate
+// for each key. A key matching several remote nodes gets the values of all of them.
+func federatedValues(data []byte) (map[string][]*pb.TaskValue, error) {
+	var resp struct {
+		Q []map[string]json.RawMessage `json:"q"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	vals := make(map[string][]*pb.TaskValue)
+	for _, node := range resp.Q {
+		var key interface{}
+		if err := unmarshalNumber(node["k"], &key); err != nil {
+			return nil, err
+		}
+		raw, ok := node["v"]
+		if key == nil || !ok {
+			continue
+		}
+		var v interface{}
+		if err := unmarshalNumber(raw, &v); err != nil {
+			return nil, err
+		}
+		list, ok := v.([]interface{})
+		if !ok {
+			list = []interface{}{v}
+		}
+		k := fmt.Sprint(key)
+		for _, item := range list {
+			tv, err := federatedTaskValue(item)
+			if err != nil {
+				return nil, err
+			}
+			vals[k] = append(vals[k], tv)
+		}
+	}
+	return vals, nil
+}
+
+// federatedKeys reads the keys of a response of the remote cluster.
+func federatedKeys(data []byte) (map[string]bool, error) {
+	var resp struct {
+		Q []map[string]json.RawMessage `json:"q"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool, len(resp.Q))
+	for _, node := range resp.Q {
+		var key interface{}
+		if err := unmarshalNumber(node["k"], &key); err != nil {
+			return nil, err
+		}
+		if key != nil {
+			keys[fmt.Sprint(key)] = true
+		}
+	}
+	return keys, nil
+}
+
+func unmarshalNumber(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func federatedTaskValue(v interface{}) (*pb.TaskValue, error) {
+	switch v := v.(type) {
+	case string:
+		return task.FromString(v), nil
+	case bool:
+		// task.FromBool returns an int, the value has to keep its type to be output as a bool.
+		bs := []byte{0}
+		if v {
+			bs[0] = 1
+		}
+		return &pb.TaskValue{Val: bs, ValType: pb.Posting_BOOL}, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return task.FromInt(int(i)), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return task.FromFloat(f), nil
+	default:
+		return nil, errors.Errorf("only scalar predicates can be federated, got a value of "+
+			"type %T", v)
+	}
+}
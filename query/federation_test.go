@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func TestParseFederatedPredicates(t *testing.T) {
+	preds, err := parseFederatedPredicates(" price@eu-alpha:9080, stock@eu-alpha:9080,rating@us:9080")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"price":  "eu-alpha:9080",
+		"stock":  "eu-alpha:9080",
+		"rating": "us:9080",
+	}, preds)
+
+	preds, err = parseFederatedPredicates("")
+	require.NoError(t, err)
+	require.Empty(t, preds)
+
+	for _, s := range []string{"price", "@eu:9080", "price@", "price@a:9080,price@b:9080"} {
+		_, err := parseFederatedPredicates(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestFederatedQuery(t *testing.T) {
+	require.Equal(t, `{ q(func: eq(<xid>, ["a", "b\"c"])) { k: <xid> v: <name>@en:fr } }`,
+		federatedQuery("xid", "name", []string{"en", "fr"}, []string{"a", `b"c`}))
+}
+
+func TestFederatedValues(t *testing.T) {
+	vals, err := federatedValues([]byte(`{"q": [
+		{"k": "a", "v": 12},
+		{"k": "b", "v": [1.5, 2]},
+		{"k": "c", "v": true},
+		{"k": "d", "v": "text"},
+		{"k": "e"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, vals, 4)
+
+	toVals := func(tvs []*pb.TaskValue) []interface{} {
+		var res []interface{}
+		for _, tv := range tvs {
+			v, err := getValue(tv)
+			require.NoError(t, err)
+			out, err := types.Convert(v, v.Tid)
+			require.NoError(t, err)
+			res = append(res, out.Value)
+		}
+		return res
+	}
+	require.Equal(t, []interface{}{int64(12)}, toVals(vals["a"]))
+	require.Equal(t, []interface{}{1.5, int64(2)}, toVals(vals["b"]))
+	require.Equal(t, []interface{}{true}, toVals(vals["c"]))
+	require.Equal(t, []interface{}{"text"}, toVals(vals["d"]))
+
+	_, err = federatedValues([]byte(`{"q": [{"k": "a", "v": {"uid": "0x1"}}]}`))
+	require.Error(t, err)
+}
+
+func TestFederatedFunc(t *testing.T) {
+	fn := func(name string, args ...string) *Function {
+		f := &Function{Name: name}
+		for _, arg := range args {
+			f.Args = append(f.Args, gql.Arg{Value: arg})
+		}
+		return f
+	}
+	for _, tc := range []struct {
+		fn    *Function
+		langs []string
+		out   string
+	}{
+		{fn("has"), nil, `has(<price>)`},
+		{fn("eq", "12"), nil, `eq(<price>, "12")`},
+		{fn("eq", "a", "b"), nil, `eq(<price>, ["a", "b"])`},
+		{fn("between", "1", "5"), nil, `between(<price>, "1", "5")`},
+		{fn("anyofterms", `big "red"`), []string{"en"}, `anyofterms(<price>@en, "big \"red\"")`},
+		{fn("regexp", "^a.*", "i"), nil, `regexp(<price>, /^a.*/i)`},
+	} {
+		out, err := federatedFunc(tc.fn, "price", tc.langs)
+		require.NoError(t, err)
+		require.Equal(t, tc.out, out)
+	}
+
+	for _, f := range []*Function{
+		fn("uid_in", "0x1"),
+		{Name: "eq", IsCount: true, Args: []gql.Arg{{Value: "1"}}},
+		{Name: "eq", Args: []gql.Arg{{Value: "v", IsValueVar: true}}},
+	} {
+		_, err := federatedFunc(f, "price", nil)
+		require.Error(t, err, f.Name)
+	}
+}
+
+func TestFederatedKeys(t *testing.T) {
+	keys, err := federatedKeys([]byte(`{"q": [{"k": "a"}, {"k": 12}, {}]}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"a": true, "12": true}, keys)
+}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 
 	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/pkg/errors"
@@ -38,15 +39,25 @@ type groupResult struct {
 	uids       []uint64
 }
 
+// aggregateName returns the name of the aggregate of a child of a groupby block in the result.
+func aggregateName(child *SubGraph) string {
+	switch {
+	case child.Params.Alias != "":
+		return child.Params.Alias
+	case child.Params.DoCount:
+		return "count"
+	case child.SrcFunc != nil:
+		return fmt.Sprintf("%s(%s)", child.SrcFunc.Name, child.Attr)
+	}
+	return ""
+}
+
 func (grp *groupResult) aggregateChild(child *SubGraph) error {
-	fieldName := child.Params.Alias
+	fieldName := aggregateName(child)
 	if child.Params.DoCount {
 		if child.Attr != "uid" {
 			return errors.Errorf("Only uid predicate is allowed in count within groupby")
 		}
-		if fieldName == "" {
-			fieldName = "count"
-		}
 		grp.aggregates = append(grp.aggregates, groupPair{
 			attr: fieldName,
 			key: types.Val{
@@ -57,9 +68,6 @@ func (grp *groupResult) aggregateChild(child *SubGraph) error {
 		return nil
 	}
 	if child.SrcFunc != nil && isAggregatorFn(child.SrcFunc.Name) {
-		if fieldName == "" {
-			fieldName = fmt.Sprintf("%s(%s)", child.SrcFunc.Name, child.Attr)
-		}
 		finalVal, err := aggregateGroup(grp, child)
 		if err != nil {
 			return err
@@ -137,6 +145,22 @@ func (d *dedup) addValue(attr string, value types.Val, uid uint64) {
 	curEntity.Uids = append(curEntity.Uids, uid)
 }
 
+// addValueList adds the uid to the group of the value of a value node. With split(attr, sep),
+// the uid is added to the group of each token of the value instead.
+func (d *dedup) addValueList(attr string, child *SubGraph, v *pb.ValueList, uid uint64) {
+	val, err := convertTo(v.Values[0])
+	if err != nil {
+		return
+	}
+	if child.Params.Split == "" {
+		d.addValue(attr, val, uid)
+		return
+	}
+	for _, tok := range splitVal(val, child.Params.Split) {
+		d.addValue(attr, tok, uid)
+	}
+}
+
 func aggregateGroup(grp *groupResult, child *SubGraph) (types.Val, error) {
 	ag := aggregator{
 		name: child.SrcFunc.Name,
@@ -234,11 +258,7 @@ func (sg *SubGraph) formResult(ul *pb.List) (*groupResults, error) {
 				if len(v.Values) == 0 || algo.IndexOf(ul, srcUid) < 0 {
 					continue
 				}
-				val, err := convertTo(v.Values[0])
-				if err != nil {
-					continue
-				}
-				dedupMap.addValue(attr, val, srcUid)
+				dedupMap.addValueList(attr, child, v, srcUid)
 			}
 		}
 	}
@@ -263,10 +283,75 @@ func (sg *SubGraph) formResult(ul *pb.List) (*groupResults, error) {
 	sort.Slice(res.group, func(i, j int) bool {
 		return groupLess(res.group[i], res.group[j])
 	})
+	if page := sg.Params.GroupbyPage; page != nil {
+		if err := sg.checkGroupbyOrder(page); err != nil {
+			return res, err
+		}
+		res.orderAndPage(page)
+	}
 
 	return res, nil
 }
 
+// checkGroupbyOrder returns an error if the groups are ordered by a name which isn't the one of a
+// group key or of an aggregate of the block.
+func (sg *SubGraph) checkGroupbyOrder(page *gql.GroupByPage) error {
+	if page.Order == "" {
+		return nil
+	}
+	for _, child := range sg.Children {
+		name := aggregateName(child)
+		if child.Params.IgnoreResult && child.Params.Alias == "" {
+			name = child.Attr
+		}
+		if name == page.Order {
+			return nil
+		}
+	}
+	return errors.Errorf("Cannot order the groups of groupby by %s, which is neither a key nor "+
+		"an aggregate of the groups", page.Order)
+}
+
+// orderAndPage orders the groups by the key or the aggregate given in page, and keeps the groups
+// of the page. The groups have been sorted by groupLess before, and keep that order when they
+// have the same value, so that the pages of a query don't overlap. The groups without a value,
+// e.g. an aggregate of empty values, are last.
+func (res *groupResults) orderAndPage(page *gql.GroupByPage) {
+	if page.Order != "" {
+		value := func(grp *groupResult) (types.Val, bool) {
+			for _, pairs := range [][]groupPair{grp.keys, grp.aggregates} {
+				for _, pair := range pairs {
+					if pair.attr == page.Order {
+						return pair.key, true
+					}
+				}
+			}
+			return types.Val{}, false
+		}
+		sort.SliceStable(res.group, func(i, j int) bool {
+			a, aok := value(res.group[i])
+			b, bok := value(res.group[j])
+			if !aok || !bok {
+				return aok && !bok
+			}
+			if page.Desc {
+				a, b = b, a
+			}
+			l, err := types.Less(a, b)
+			return err == nil && l
+		})
+	}
+
+	if page.Offset >= len(res.group) {
+		res.group = nil
+		return
+	}
+	res.group = res.group[page.Offset:]
+	if page.First > 0 && page.First < len(res.group) {
+		res.group = res.group[:page.First]
+	}
+}
+
 // This function is to use the fillVars. It is similar to formResult, the only difference being
 // that it considers the whole uidMatrix to do the grouping before assigning the variable.
 // TODO - Check if we can reduce this duplication.
@@ -312,11 +397,7 @@ func (sg *SubGraph) fillGroupedVars(doneVars map[string]varValue, path []*SubGra
 				if len(v.Values) == 0 {
 					continue
 				}
-				val, err := convertTo(v.Values[0])
-				if err != nil {
-					continue
-				}
-				dedupMap.addValue(attr, val, srcUid)
+				dedupMap.addValueList(attr, child, v, srcUid)
 			}
 		}
 	}
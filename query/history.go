@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// HistoryToJson returns the versions of a history block as a JSON array, from the oldest to the
+// newest, e.g. [{"ts": 5, "value": "active"}, {"ts": 8, "deleted": true}]. The values of a list
+// predicate are returned as an array, and the values with a language tag are returned under a
+// key with the tag, as in the other queries, e.g. "value@en".
+func HistoryToJson(h *pb.HistoryResult) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteRune('[')
+	for i, version := range h.GetVersions() {
+		if i > 0 {
+			buf.WriteRune(',')
+		}
+		buf.WriteString(`{"ts":`)
+		buf.WriteString(strconv.FormatUint(version.CommitTs, 10))
+		if len(version.Postings) == 0 {
+			buf.WriteString(`,"deleted":true}`)
+			continue
+		}
+
+		// The values are grouped by language tag, in the order of the postings.
+		var langs []string
+		vals := make(map[string][][]byte)
+		for _, p := range version.Postings {
+			b, err := historyValToBytes(p)
+			if err != nil {
+				return nil, err
+			}
+			lang := string(p.LangTag)
+			if _, ok := vals[lang]; !ok {
+				langs = append(langs, lang)
+			}
+			vals[lang] = append(vals[lang], b)
+		}
+		for _, lang := range langs {
+			key := "value"
+			if lang != "" {
+				key += "@" + lang
+			}
+			buf.WriteRune(',')
+			buf.Write(stringJsonMarshal(key))
+			buf.WriteRune(':')
+			if !h.List {
+				buf.Write(vals[lang][0])
+				continue
+			}
+			buf.WriteRune('[')
+			buf.Write(bytes.Join(vals[lang], []byte{','}))
+			buf.WriteRune(']')
+		}
+		buf.WriteRune('}')
+	}
+	buf.WriteRune(']')
+	return buf.Bytes(), nil
+}
+
+func historyValToBytes(p *pb.Posting) ([]byte, error) {
+	if p.ValType == pb.Posting_UID {
+		return valToBytes(types.Val{Tid: types.UidID, Value: p.Uid})
+	}
+	typ := types.TypeID(p.ValType)
+	val, err := types.Convert(types.Val{Tid: typ, Value: p.Value}, typ)
+	if err != nil {
+		return nil, err
+	}
+	return valToBytes(val)
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func TestHistoryToJson(t *testing.T) {
+	str := func(s, lang string) *pb.Posting {
+		return &pb.Posting{Value: []byte(s), ValType: pb.Posting_STRING, LangTag: []byte(lang)}
+	}
+	val := types.ValueForType(types.BinaryID)
+	require.NoError(t, types.Marshal(types.Val{Tid: types.IntID, Value: int64(42)}, &val))
+
+	tests := []struct {
+		name string
+		h    *pb.HistoryResult
+		json string
+	}{
+		{"empty", &pb.HistoryResult{}, `[]`},
+		{"scalar", &pb.HistoryResult{Versions: []*pb.PostingList{
+			{CommitTs: 5, Postings: []*pb.Posting{str("active", "")}},
+			{CommitTs: 8, Postings: []*pb.Posting{str("inactive", ""), str("inactif", "fr")}},
+			{CommitTs: 9},
+		}}, `[{"ts":5,"value":"active"},{"ts":8,"value":"inactive","value@fr":"inactif"},` +
+			`{"ts":9,"deleted":true}]`},
+		{"int", &pb.HistoryResult{Versions: []*pb.PostingList{
+			{CommitTs: 3, Postings: []*pb.Posting{
+				{Value: val.Value.([]byte), ValType: pb.Posting_INT}}},
+		}}, `[{"ts":3,"value":42}]`},
+		{"list", &pb.HistoryResult{List: true, Versions: []*pb.PostingList{
+			{CommitTs: 3, Postings: []*pb.Posting{str("a", ""), str("b", "")}},
+			{CommitTs: 4, Postings: []*pb.Posting{str("b", "")}},
+		}}, `[{"ts":3,"value":["a","b"]},{"ts":4,"value":["b"]}]`},
+		{"uids", &pb.HistoryResult{List: true, Versions: []*pb.PostingList{
+			{CommitTs: 3, Postings: []*pb.Posting{
+				{Uid: 0x1, ValType: pb.Posting_UID}, {Uid: 0x2a, ValType: pb.Posting_UID}}},
+		}}, `[{"ts":3,"value":["0x1","0x2a"]}]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := HistoryToJson(tc.h)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.json, string(b))
+		})
+	}
+}
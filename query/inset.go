@@ -0,0 +1,113 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const inSetFn = "in_set"
+
+// The uid sets are stored as nodes of type dgraph.uidset, with their members in a list of uids,
+// so that they are replicated and cached like the other posting lists. They are set and deleted
+// through the admin API.
+const (
+	uidSetName    = "dgraph.uidset.name"
+	uidSetMembers = "dgraph.uidset.members"
+	uidSetExpiry  = "dgraph.uidset.expiry"
+)
+
+// applyInSetFunc evaluates in_set("name"). It returns the members of the uid set with the given
+// name in the namespace of the request. In a filter, the uids being filtered are intersected with
+// the members. A set which doesn't exist, or has expired, is an error, so that a typo in the name
+// doesn't silently return nothing.
+func (sg *SubGraph) applyInSetFunc(ctx context.Context) error {
+	name := sg.SrcFunc.Args[0].Value
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "While evaluating function %s", inSetFn)
+	}
+	process := func(q *pb.Query) (*pb.Result, error) {
+		q.ReadTs = sg.ReadTs
+		q.Cache = int32(sg.Cache)
+		q.TxnStartTs = sg.TxnStartTs
+		res, err := worker.ProcessTaskOverNetwork(ctx, q)
+		switch {
+		case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
+			// No uid set has been stored yet.
+			return &pb.Result{}, nil
+		case err != nil:
+			return nil, errors.Wrapf(err, "While evaluating function %s", inSetFn)
+		}
+		return res, nil
+	}
+
+	res, err := process(&pb.Query{
+		Attr:    x.NamespaceAttr(namespace, uidSetName),
+		SrcFunc: &pb.SrcFunction{Name: "eq", Args: []string{name}},
+	})
+	if err != nil {
+		return err
+	}
+	set := algo.MergeSorted(res.UidMatrix)
+	if len(set.Uids) == 0 {
+		return errors.Errorf("Uid set %q of function %s not found", name, inSetFn)
+	}
+	// The name has an @upsert index, so there is a single node for each set.
+	set.Uids = set.Uids[:1]
+
+	res, err = process(&pb.Query{Attr: x.NamespaceAttr(namespace, uidSetExpiry), UidList: set})
+	if err != nil {
+		return err
+	}
+	if len(res.ValueMatrix) > 0 && len(res.ValueMatrix[0].Values) > 0 {
+		expiry, err := convertTo(res.ValueMatrix[0].Values[0])
+		if err != nil && err != ErrEmptyVal {
+			return errors.Wrapf(err, "While reading the expiry of uid set %q", name)
+		}
+		if t, ok := expiry.Value.(time.Time); ok && !t.After(time.Now()) {
+			return errors.Errorf("Uid set %q of function %s expired at %s", name, inSetFn,
+				t.Format(time.RFC3339))
+		}
+	}
+
+	res, err = process(&pb.Query{Attr: x.NamespaceAttr(namespace, uidSetMembers), UidList: set})
+	if err != nil {
+		return err
+	}
+	if err := memoryAccountFromContext(ctx).add(resultMemory(res)); err != nil {
+		return err
+	}
+	members := algo.MergeSorted(res.UidMatrix)
+
+	if sg.SrcUIDs != nil {
+		sg.DestUIDs = algo.IntersectSorted([]*pb.List{sg.SrcUIDs, members})
+		return nil
+	}
+	sg.DestUIDs = members
+	sg.uidMatrix = []*pb.List{sg.DestUIDs}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/testutil"
+)
+
+// uidSetAdmin runs a mutation of the uid sets through the admin API, and returns its error if any.
+func uidSetAdmin(t *testing.T, query string, vars map[string]interface{}) string {
+	token := testutil.GrootHttpLogin(testutil.AdminUrl())
+	resp := testutil.MakeGQLRequestWithAccessJwt(t, &testutil.GraphQLParams{
+		Query:     query,
+		Variables: vars,
+	}, token.AccessJwt)
+	if len(resp.Errors) > 0 {
+		return resp.Errors[0].Message
+	}
+	return ""
+}
+
+func setUidSet(t *testing.T, name string, uids []string, expiresAt string) string {
+	vars := map[string]interface{}{"name": name, "uids": uids}
+	if expiresAt != "" {
+		vars["expiresAt"] = expiresAt
+	}
+	return uidSetAdmin(t, `mutation($name: String!, $uids: [String!]!, $expiresAt: DateTime) {
+		setUidSet(name: $name, uids: $uids, expiresAt: $expiresAt) { response { code } }
+	}`, vars)
+}
+
+func deleteUidSet(t *testing.T, name string) string {
+	return uidSetAdmin(t, `mutation($name: String!) {
+		deleteUidSet(name: $name) { response { code } }
+	}`, map[string]interface{}{"name": name})
+}
+
+func TestInSet(t *testing.T) {
+	require.Empty(t, setUidSet(t, "in_set_friends", []string{"0x17", "0x18", "0x1f"}, ""))
+	root := `{q(func: in_set("in_set_friends"), orderasc: name) { name }}`
+	require.JSONEq(t, `{"data": {"q": [{"name": "Andrea"}, {"name": "Glenn Rhee"},
+		{"name": "Rick Grimes"}]}}`, processQueryNoErr(t, root))
+
+	// In a filter, the members are intersected with the uids being filtered.
+	filter := `{q(func: uid(0x1)) { friend @filter(in_set("in_set_friends")) { name } }}`
+	require.JSONEq(t, `{"data": {"q": [{"friend": [{"name": "Rick Grimes"},
+		{"name": "Glenn Rhee"}, {"name": "Andrea"}]}]}}`, processQueryNoErr(t, filter))
+
+	// None of the previous members are left once the set is replaced.
+	require.Empty(t, setUidSet(t, "in_set_friends", []string{"0x19"}, ""))
+	require.JSONEq(t, `{"data": {"q": [{"name": "Daryl Dixon"}]}}`, processQueryNoErr(t, root))
+	require.JSONEq(t, `{"data": {"q": [{"friend": [{"name": "Daryl Dixon"}]}]}}`,
+		processQueryNoErr(t, filter))
+
+	require.Empty(t, deleteUidSet(t, "in_set_friends"))
+	_, err := processQuery(context.Background(), t, root)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Uid set "in_set_friends" of function in_set not found`)
+	require.Contains(t, deleteUidSet(t, "in_set_friends"), `Uid set "in_set_friends" not found`)
+
+	require.Contains(t, setUidSet(t, "", []string{"0x1"}, ""),
+		"The name of a uid set can't be empty")
+	require.Contains(t, setUidSet(t, "in_set_friends", []string{"0x0"}, ""), `invalid uid "0x0"`)
+}
+
+func TestInSetExpiry(t *testing.T) {
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	require.Contains(t, setUidSet(t, "in_set_expiry", []string{"0x17"}, past),
+		`The expiry of uid set "in_set_expiry" is in the past`)
+
+	expiresAt := time.Now().Add(3 * time.Second).UTC().Format(time.RFC3339)
+	require.Empty(t, setUidSet(t, "in_set_expiry", []string{"0x17"}, expiresAt))
+	defer deleteUidSet(t, "in_set_expiry")
+	query := `{q(func: in_set("in_set_expiry")) { name }}`
+	require.JSONEq(t, `{"data": {"q": [{"name": "Rick Grimes"}]}}`, processQueryNoErr(t, query))
+
+	time.Sleep(4 * time.Second)
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Uid set "in_set_expiry" of function in_set expired at`)
+}
@@ -218,7 +218,73 @@ func processTernary(mNode *mathTree) error {
 	return nil
 }
 
-func evalMathTree(mNode *mathTree) error {
+// processCoalesce handles coalesce(a, b). For every uid, it picks the value of a and falls back
+// to b if a doesn't have a value for the uid. The uids considered are the ones having a value in
+// either a or b, along with the given uids of the level the math expression is evaluated at.
+func processCoalesce(mNode *mathTree, uids []uint64) error {
+	lhs, rhs := mNode.Child[0], mNode.Child[1]
+	if lhs.Const.Value != nil {
+		mNode.Const = lhs.Const
+		return nil
+	}
+
+	def := rhs.Const
+	var toFloat bool
+	if def.Value != nil {
+		// The default needs to have the same type as the values of the variable, otherwise
+		// the values of the variable can't be used together in further computations.
+		for _, v := range lhs.Val {
+			if v.Value == nil || v.Tid == def.Tid {
+				continue
+			}
+			switch {
+			case v.Tid == types.FloatID && def.Tid == types.IntID:
+				def = types.Val{Tid: types.FloatID, Value: float64(def.Value.(int64))}
+			case v.Tid == types.IntID && def.Tid == types.FloatID:
+				toFloat = true
+			default:
+				return errors.Errorf("Default value of type %s in %s doesn't match the value "+
+					"of type %s", def.Tid.Name(), mNode.Fn, v.Tid.Name())
+			}
+			break
+		}
+		if len(lhs.Val) == 0 && len(uids) == 0 {
+			mNode.Const = def
+			return nil
+		}
+	}
+
+	destMap := make(map[uint64]types.Val)
+	pick := func(k uint64) {
+		if v, ok := lhs.Val[k]; ok && v.Value != nil {
+			if toFloat && v.Tid == types.IntID {
+				v = types.Val{Tid: types.FloatID, Value: float64(v.Value.(int64))}
+			}
+			destMap[k] = v
+			return
+		}
+		if def.Value != nil {
+			destMap[k] = def
+			return
+		}
+		if v, ok := rhs.Val[k]; ok && v.Value != nil {
+			destMap[k] = v
+		}
+	}
+	for k := range lhs.Val {
+		pick(k)
+	}
+	for k := range rhs.Val {
+		pick(k)
+	}
+	for _, k := range uids {
+		pick(k)
+	}
+	mNode.Val = destMap
+	return nil
+}
+
+func evalMathTree(mNode *mathTree, uids []uint64) error {
 	if mNode.Const.Value != nil {
 		return nil
 	}
@@ -232,13 +298,21 @@ func evalMathTree(mNode *mathTree) error {
 
 	for _, child := range mNode.Child {
 		// Process the child nodes first.
-		err := evalMathTree(child)
+		err := evalMathTree(child, uids)
 		if err != nil {
 			return err
 		}
 	}
 
 	aggName := mNode.Fn
+	if isCoercion(aggName) {
+		if len(mNode.Child) != 1 {
+			return errors.Errorf("Function %v expects 1 argument. But got: %v", aggName,
+				len(mNode.Child))
+		}
+		return processCoercion(mNode)
+	}
+
 	if isUnary(aggName) {
 		if len(mNode.Child) != 1 {
 			return errors.Errorf("Function %v expects 1 argument. But got: %v", aggName,
@@ -263,6 +337,14 @@ func evalMathTree(mNode *mathTree) error {
 		return processBinaryBoolean(mNode)
 	}
 
+	if isCoalesce(aggName) {
+		if len(mNode.Child) != 2 {
+			return errors.Errorf("Function %v expects 2 argument. But got: %v", aggName,
+				len(mNode.Child))
+		}
+		return processCoalesce(mNode, uids)
+	}
+
 	if isTernary(aggName) {
 		if len(mNode.Child) != 3 {
 			return errors.Errorf("Function %v expects 3 argument. But got: %v", aggName,
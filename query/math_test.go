@@ -378,6 +378,46 @@ func TestProcessBinary(t *testing.T) {
 	}
 }
 
+func TestProcessDecimal(t *testing.T) {
+	dec := func(s string) types.Val {
+		d, err := types.ParseDecimal(s)
+		require.NoError(t, err)
+		return types.Val{Tid: types.DecimalID, Value: d}
+	}
+	tests := []struct {
+		fn   string
+		a, b types.Val
+		out  string
+	}{
+		{fn: "+", a: dec("0.10"), b: dec("0.20"), out: "0.30"},
+		{fn: "-", a: dec("19.99"), b: dec("20"), out: "-0.01"},
+		{fn: "*", a: dec("19.99"), b: dec("3"), out: "59.97"},
+		{fn: "/", a: dec("10.00"), b: dec("3"), out: "3.33"},
+		{fn: "%", a: dec("10.50"), b: dec("3"), out: "1.50"},
+		// Ints and floats are converted to decimals.
+		{fn: "+", a: dec("19.99"), b: types.Val{Tid: types.IntID, Value: int64(1)}, out: "20.99"},
+		{fn: "*", a: dec("19.99"), b: types.Val{Tid: types.FloatID, Value: 1.1}, out: "21.989"},
+		{fn: "max", a: dec("19.99"), b: dec("19.9"), out: "19.99"},
+	}
+	for _, tc := range tests {
+		tree := &mathTree{Fn: tc.fn, Child: []*mathTree{{Const: tc.a}, {Const: tc.b}}}
+		require.NoError(t, processBinary(tree))
+		require.Equal(t, types.DecimalID, tree.Const.Tid, tc.fn)
+		require.Equal(t, tc.out, tree.Const.Value.(types.Decimal).String(), tc.fn)
+	}
+
+	tree := &mathTree{Fn: "/", Child: []*mathTree{{Const: dec("1.00")}, {Const: dec("0")}}}
+	require.EqualError(t, processBinary(tree), ErrorDivisionByZero.Error())
+
+	tree = &mathTree{Fn: "sqrt", Child: []*mathTree{{Const: dec("6.25")}}}
+	require.NoError(t, processUnary(tree))
+	require.Equal(t, types.Val{Tid: types.FloatID, Value: 2.5}, tree.Const)
+
+	tree = &mathTree{Fn: "floor", Child: []*mathTree{{Const: dec("-1.5")}}}
+	require.NoError(t, processUnary(tree))
+	require.Equal(t, "-2", tree.Const.Value.(types.Decimal).String())
+}
+
 func TestProcessUnary(t *testing.T) {
 	tests := []struct {
 		in  *mathTree
@@ -587,4 +627,121 @@ func TestProcessTernary(t *testing.T) {
 	}
 }
 
+func TestProcessCoalesce(t *testing.T) {
+	tree := &mathTree{
+		Fn: "coalesce",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{
+				1: {Tid: types.IntID, Value: int64(10)},
+				2: {Tid: types.IntID, Value: int64(20)},
+			}},
+			{Const: types.Val{Tid: types.IntID, Value: int64(0)}},
+		}}
+	require.NoError(t, processCoalesce(tree, []uint64{1, 2, 3}))
+	require.Equal(t, map[uint64]types.Val{
+		1: {Tid: types.IntID, Value: int64(10)},
+		2: {Tid: types.IntID, Value: int64(20)},
+		3: {Tid: types.IntID, Value: int64(0)},
+	}, tree.Val)
+
+	// An int default is converted to float for a float variable.
+	tree = &mathTree{
+		Fn: "coalesce",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{1: {Tid: types.FloatID, Value: 1.5}}},
+			{Const: types.Val{Tid: types.IntID, Value: int64(2)}},
+		}}
+	require.NoError(t, processCoalesce(tree, []uint64{1, 2}))
+	require.Equal(t, map[uint64]types.Val{
+		1: {Tid: types.FloatID, Value: 1.5},
+		2: {Tid: types.FloatID, Value: 2.0},
+	}, tree.Val)
+
+	// Falls back to the values of another variable.
+	tree = &mathTree{
+		Fn: "coalesce",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{1: {Tid: types.IntID, Value: int64(1)}}},
+			{Val: map[uint64]types.Val{
+				1: {Tid: types.IntID, Value: int64(5)},
+				2: {Tid: types.IntID, Value: int64(6)},
+			}},
+		}}
+	require.NoError(t, processCoalesce(tree, []uint64{1, 2, 3}))
+	require.Equal(t, map[uint64]types.Val{
+		1: {Tid: types.IntID, Value: int64(1)},
+		2: {Tid: types.IntID, Value: int64(6)},
+	}, tree.Val)
+
+	// No values at all, the default is used as a constant.
+	tree = &mathTree{
+		Fn: "coalesce",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{}},
+			{Const: types.Val{Tid: types.IntID, Value: int64(7)}},
+		}}
+	require.NoError(t, processCoalesce(tree, nil))
+	require.Equal(t, types.Val{Tid: types.IntID, Value: int64(7)}, tree.Const)
+
+	// A numeric default can't be used for a string variable.
+	tree = &mathTree{
+		Fn: "coalesce",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{1: {Tid: types.StringID, Value: "a"}}},
+			{Const: types.Val{Tid: types.IntID, Value: int64(0)}},
+		}}
+	require.Error(t, processCoalesce(tree, []uint64{1, 2}))
+}
+
+func TestProcessCoercion(t *testing.T) {
+	str := func(s string) types.Val { return types.Val{Tid: types.StringID, Value: s} }
+	tree := &mathTree{
+		Fn: "toint",
+		Child: []*mathTree{{Val: map[uint64]types.Val{
+			1: str("12"),
+			2: str(" 7 "),
+			3: str("3.9"),
+			4: str("n/a"),
+			5: str("1,000"),
+			6: {Tid: types.FloatID, Value: -2.5},
+			7: {Tid: types.BoolID, Value: true},
+		}}}}
+	require.NoError(t, evalMathTree(tree, nil))
+	require.Equal(t, map[uint64]types.Val{
+		1: {Tid: types.IntID, Value: int64(12)},
+		2: {Tid: types.IntID, Value: int64(7)},
+		3: {Tid: types.IntID, Value: int64(3)},
+		6: {Tid: types.IntID, Value: int64(-2)},
+		7: {Tid: types.IntID, Value: int64(1)},
+	}, tree.Val)
+
+	tree = &mathTree{
+		Fn: "tofloat",
+		Child: []*mathTree{{Val: map[uint64]types.Val{
+			1: str("1.5e3"),
+			2: {Tid: types.IntID, Value: int64(2)},
+			3: str(""),
+		}}}}
+	require.NoError(t, evalMathTree(tree, nil))
+	require.Equal(t, map[uint64]types.Val{
+		1: {Tid: types.FloatID, Value: 1500.0},
+		2: {Tid: types.FloatID, Value: 2.0},
+	}, tree.Val)
+
+	tree = &mathTree{
+		Fn: "tostring",
+		Child: []*mathTree{{Val: map[uint64]types.Val{
+			1: {Tid: types.IntID, Value: int64(42)},
+			2: {Tid: types.FloatID, Value: 0.5},
+		}}}}
+	require.NoError(t, evalMathTree(tree, nil))
+	require.Equal(t, map[uint64]types.Val{1: str("42"), 2: str("0.5")}, tree.Val)
+
+	// Constants are part of the query, so a constant which can't be parsed is an error.
+	tree = &mathTree{Fn: "toint", Child: []*mathTree{{Const: str("abc")}}}
+	require.Error(t, evalMathTree(tree, nil))
+	tree = &mathTree{Fn: "toint", Child: []*mathTree{{Const: str("1e30")}}}
+	require.Error(t, evalMathTree(tree, nil))
+}
+
 func TestEvalMathTree(t *testing.T) {}
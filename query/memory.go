@@ -0,0 +1,183 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/pkg/errors"
+)
+
+// ErrServerBusy is returned when a query is rejected because the queries being processed are
+// using more memory than the query-memory-mb limit.
+var ErrServerBusy = errors.New("Server busy: queries are using too much memory. Please retry later")
+
+// memoryChargeBatch is the amount of memory an account collects before charging it to the
+// tracker, so that the tracker isn't locked for every allocation.
+const memoryChargeBatch = 1 << 20
+
+// memoryTracker keeps track of the memory used by the queries being processed. The limit is a
+// soft one: once it is crossed, only the namespaces using more than their fair share of it (the
+// limit divided by the number of namespaces with queries in flight) get their queries rejected.
+// This way a tenant running expensive queries can't starve the others.
+type memoryTracker struct {
+	sync.Mutex
+	used      int64
+	nsUsed    map[uint64]int64
+	nsQueries map[uint64]int
+}
+
+var queryMemory = &memoryTracker{
+	nsUsed:    make(map[uint64]int64),
+	nsQueries: make(map[uint64]int),
+}
+
+// overLimit returns whether charging n bytes to the namespace should be rejected. It must be
+// called with the lock held.
+func (t *memoryTracker) overLimit(ns uint64, n int64) bool {
+	limit := x.Config.LimitQueryMemory
+	if limit <= 0 || t.used+n <= limit {
+		return false
+	}
+	return t.nsUsed[ns]+n > limit/int64(len(t.nsQueries))
+}
+
+func (t *memoryTracker) start(ns uint64) error {
+	t.Lock()
+	defer t.Unlock()
+	t.nsQueries[ns]++
+	// Under memory pressure, new queries are only let in for the namespaces which are within
+	// their fair share.
+	if t.overLimit(ns, 0) {
+		t.finish(ns, 0)
+		return ErrServerBusy
+	}
+	return nil
+}
+
+func (t *memoryTracker) charge(ns uint64, n int64) error {
+	t.Lock()
+	defer t.Unlock()
+	if t.overLimit(ns, n) {
+		return ErrServerBusy
+	}
+	t.used += n
+	t.nsUsed[ns] += n
+	return nil
+}
+
+// finish releases the memory charged by a query. It must be called with the lock held.
+func (t *memoryTracker) finish(ns uint64, charged int64) {
+	t.used -= charged
+	t.nsUsed[ns] -= charged
+	if t.nsQueries[ns]--; t.nsQueries[ns] == 0 {
+		delete(t.nsQueries, ns)
+		delete(t.nsUsed, ns)
+	}
+}
+
+// memoryAccount keeps track of the memory used by a single request, for uid lists, values and
+// the encoded response.
+type memoryAccount struct {
+	sync.Mutex
+	ns uint64
+	// charged is the memory already charged to the tracker and pending is the memory allocated
+	// since, which will be charged once it reaches memoryChargeBatch.
+	charged int64
+	pending int64
+}
+
+// WithMemoryAccount returns a context that keeps track of the memory used by the request, along
+// with the function to call once the request is done. It returns ErrServerBusy if the request
+// should be rejected because of memory pressure. It does nothing when there is no limit.
+func WithMemoryAccount(ctx context.Context) (context.Context, func(), error) {
+	if x.Config.LimitQueryMemory <= 0 {
+		return ctx, func() {}, nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return ctx, nil, err
+	}
+	if err := queryMemory.start(ns); err != nil {
+		return ctx, nil, err
+	}
+	acc := &memoryAccount{ns: ns}
+	release := func() {
+		acc.Lock()
+		defer acc.Unlock()
+		queryMemory.Lock()
+		defer queryMemory.Unlock()
+		queryMemory.finish(ns, acc.charged)
+		acc.charged, acc.pending = 0, 0
+	}
+	return context.WithValue(ctx, memoryAccountKey, acc), release, nil
+}
+
+func memoryAccountFromContext(ctx context.Context) *memoryAccount {
+	acc, _ := ctx.Value(memoryAccountKey).(*memoryAccount)
+	return acc
+}
+
+// add adds n bytes to the memory used by the request. It returns ErrServerBusy if the request
+// is using too much memory.
+func (a *memoryAccount) add(n int64) error {
+	if a == nil {
+		return nil
+	}
+	a.Lock()
+	defer a.Unlock()
+	a.pending += n
+	if a.pending < memoryChargeBatch {
+		return nil
+	}
+	return a.flush()
+}
+
+// check returns ErrServerBusy if the request is using too much memory. It is meant to be called
+// before doing an expensive allocation, as the memory used by it can't be known in advance.
+func (a *memoryAccount) check() error {
+	if a == nil {
+		return nil
+	}
+	a.Lock()
+	defer a.Unlock()
+	return a.flush()
+}
+
+// flush charges the pending memory to the tracker. It must be called with the lock held.
+func (a *memoryAccount) flush() error {
+	if err := queryMemory.charge(a.ns, a.pending); err != nil {
+		return err
+	}
+	a.charged += a.pending
+	a.pending = 0
+	return nil
+}
+
+// resultMemory returns an estimate of the memory used by the result of a task.
+func resultMemory(r *pb.Result) int64 {
+	// The size of the encoded result is a good estimate for values and facets. Uids take 8 bytes
+	// each in memory, but are encoded in less than that.
+	n := int64(r.Size())
+	for _, l := range r.UidMatrix {
+		n += int64(8 * len(l.GetUids()))
+	}
+	return n
+}
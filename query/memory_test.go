@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/dgraph/x"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryAccounting(t *testing.T) {
+	defer func(limit int64) { x.Config.LimitQueryMemory = limit }(x.Config.LimitQueryMemory)
+	x.Config.LimitQueryMemory = 4 << 20
+
+	start := func(ns uint64) (*memoryAccount, func(), error) {
+		ctx, release, err := WithMemoryAccount(x.AttachNamespace(context.Background(), ns))
+		if err != nil {
+			return nil, nil, err
+		}
+		return memoryAccountFromContext(ctx), release, nil
+	}
+
+	a, releaseA, err := start(0)
+	require.NoError(t, err)
+	require.NoError(t, a.add(3<<20))
+	b, releaseB, err := start(1)
+	require.NoError(t, err)
+	defer releaseB()
+	require.NoError(t, b.add(1<<20))
+
+	// Both namespaces get half of the limit once it is crossed. Namespace 0 is already using more
+	// than that, so its queries get rejected while the ones of namespace 1 can go on.
+	require.Equal(t, ErrServerBusy, a.add(2<<20))
+	require.NoError(t, b.add(1<<20))
+	_, _, err = start(0)
+	require.Equal(t, ErrServerBusy, err)
+	_, releaseC, err := start(2)
+	require.NoError(t, err)
+	releaseC()
+
+	// Memory below memoryChargeBatch is only charged on check, which rejects the query as
+	// namespace 1 would go over its share.
+	require.NoError(t, b.add(1<<10))
+	require.Equal(t, int64(5<<20), queryMemory.used)
+	require.Equal(t, ErrServerBusy, b.check())
+
+	releaseA()
+	require.Equal(t, int64(2<<20), queryMemory.used)
+	require.NoError(t, b.check())
+	require.Equal(t, int64(2<<20+1<<10), queryMemory.used)
+	_, releaseD, err := start(0)
+	require.NoError(t, err)
+	releaseD()
+}
+
+func TestMemoryAccountingDisabled(t *testing.T) {
+	defer func(limit int64) { x.Config.LimitQueryMemory = limit }(x.Config.LimitQueryMemory)
+	x.Config.LimitQueryMemory = 0
+
+	ctx, release, err := WithMemoryAccount(context.Background())
+	require.NoError(t, err)
+	defer release()
+	acc := memoryAccountFromContext(ctx)
+	require.Nil(t, acc)
+	require.NoError(t, acc.add(1<<40))
+	require.NoError(t, acc.check())
+}
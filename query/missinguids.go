@@ -0,0 +1,117 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// MaxReportedMissingUids is the maximum number of uids reported by MissingUids. The other ones
+// are only counted.
+const MaxReportedMissingUids = 1000
+
+// MissingUids holds the uids given to the uid function of a query which don't have any data,
+// e.g. uid(0xdead) if no predicate of the namespace has a value or an edge for 0xdead. They are
+// looked for once the query has run when the context of the request holds a MissingUids with
+// the key StrictUidsKey, which allows the clients to detect stale references.
+type MissingUids struct {
+	// Uids holds the first MaxReportedMissingUids missing uids, sorted.
+	Uids []uint64
+	// Total is the number of missing uids.
+	Total int
+}
+
+// String returns the missing uids in hex, separated by commas.
+func (m *MissingUids) String() string {
+	uids := make([]string, 0, len(m.Uids))
+	for _, uid := range m.Uids {
+		uids = append(uids, fmt.Sprintf("%#x", uid))
+	}
+	return strings.Join(uids, ",")
+}
+
+// find looks for the uids of the query which are missing at the read ts of the query. The check
+// reads at the same ts as the query, so for a best-effort query, a uid written after the ts the
+// replica has caught up to is reported as missing.
+func (m *MissingUids) find(ctx context.Context, req *Request) error {
+	seen := make(map[uint64]struct{})
+	var uids []uint64
+	add := func(list []uint64) {
+		for _, uid := range list {
+			if _, ok := seen[uid]; !ok {
+				seen[uid] = struct{}{}
+				uids = append(uids, uid)
+			}
+		}
+	}
+	for _, gq := range req.GqlQuery.Query {
+		requestedUids(gq, add)
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	missing, err := worker.MissingUids(ctx, ns, uids, req.ReadTs)
+	if err != nil {
+		return err
+	}
+	m.Total = len(missing)
+	if len(missing) > MaxReportedMissingUids {
+		missing = missing[:MaxReportedMissingUids]
+	}
+	m.Uids = missing
+	return nil
+}
+
+// requestedUids calls add with the uids given to the uid function in a block, at root or in
+// a filter, and in its children.
+func requestedUids(gq *gql.GraphQuery, add func([]uint64)) {
+	if gq == nil {
+		return
+	}
+	if isUidFnWithoutVar(gq.Func) {
+		add(gq.UID)
+	}
+	var walk func(ft *gql.FilterTree)
+	walk = func(ft *gql.FilterTree) {
+		if ft == nil {
+			return
+		}
+		if isUidFnWithoutVar(ft.Func) {
+			add(ft.Func.UID)
+		}
+		for _, child := range ft.Child {
+			walk(child)
+		}
+	}
+	walk(gq.Filter)
+	for _, child := range gq.Children {
+		requestedUids(child, add)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+)
+
+func TestRequestedUids(t *testing.T) {
+	res, err := gql.Parse(gql.Request{Str: `{
+		q(func: uid(0x1, 0x2)) @filter(uid(0x3) OR uid(0x1)) {
+			friend @filter(uid(0x4)) {
+				name
+			}
+		}
+		var(func: has(name)) {
+			v as uid
+		}
+		r(func: uid(v)) {
+			uid
+		}
+	}`})
+	require.NoError(t, err)
+
+	var uids []uint64
+	for _, gq := range res.Query {
+		requestedUids(gq, func(list []uint64) { uids = append(uids, list...) })
+	}
+	require.Equal(t, []uint64{0x1, 0x2, 0x3, 0x1, 0x4}, uids)
+}
+
+func TestMissingUidsString(t *testing.T) {
+	mu := &MissingUids{Uids: []uint64{0x1, 0xdead}, Total: 2}
+	require.Equal(t, "0x1,0xdead", mu.String())
+}
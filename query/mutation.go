@@ -18,12 +18,14 @@ package query
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	otrace "go.opencensus.io/trace"
 
 	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/dgraph/chunker"
 	"github.com/dgraph-io/dgraph/gql"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/types/facets"
@@ -36,6 +38,9 @@ import (
 // ApplyMutations performs the required edge expansions and forwards the results to the
 // worker to perform the mutations.
 func ApplyMutations(ctx context.Context, m *pb.Mutations) (*api.TxnContext, error) {
+	if err := addCascadeDeletes(ctx, m); err != nil {
+		return nil, err
+	}
 	// In expandEdges, for non * type prredicates, we prepend the namespace directly and for
 	// * type predicates, we fetch the predicates and prepend the namespace.
 	edges, err := expandEdges(ctx, m)
@@ -57,6 +62,10 @@ func ApplyMutations(ctx context.Context, m *pb.Mutations) (*api.TxnContext, erro
 	return tctx, err
 }
 
+// starTypesBatchSize is the number of nodes whose types are fetched by a single task when
+// expanding the S * * deletions.
+const starTypesBatchSize = 10000
+
 func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, error) {
 	edges := make([]*pb.DirectedEdge, 0, 2*len(m.Edges))
 	namespace, err := x.ExtractNamespace(ctx)
@@ -64,6 +73,10 @@ func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, erro
 		return nil, errors.Wrapf(err, "While expanding edges")
 	}
 	isGalaxyQuery := x.IsGalaxyOperation(ctx)
+	nodeTypes, err := getStarNodeTypes(ctx, m, namespace, isGalaxyQuery)
+	if err != nil {
+		return nil, errors.Wrapf(err, "While expanding edges")
+	}
 
 	// Reset the namespace to the original.
 	defer func(ns uint64) {
@@ -71,7 +84,8 @@ func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, erro
 	}(namespace)
 
 	for _, edge := range m.Edges {
-		x.AssertTrue(edge.Op == pb.DirectedEdge_DEL || edge.Op == pb.DirectedEdge_SET)
+		x.AssertTrue(edge.Op == pb.DirectedEdge_DEL || edge.Op == pb.DirectedEdge_SET ||
+			edge.Op == pb.DirectedEdge_INCR)
 		if isGalaxyQuery {
 			// The caller should make sure that the directed edges contain the namespace we want
 			// to insert into. Now, attach the namespace in the context, so that further query
@@ -84,13 +98,7 @@ func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, erro
 		if edge.Attr != x.Star {
 			preds = []string{x.NamespaceAttr(namespace, edge.Attr)}
 		} else {
-			sg := &SubGraph{}
-			sg.DestUIDs = &pb.List{Uids: []uint64{edge.GetEntity()}}
-			sg.ReadTs = m.StartTs
-			types, err := getNodeTypes(ctx, sg)
-			if err != nil {
-				return nil, err
-			}
+			types := nodeTypes[namespace][edge.GetEntity()]
 			preds = append(preds, getPredicatesFromTypes(namespace, types)...)
 			preds = append(preds, x.StarAllPredicates(namespace)...)
 			// AllowedPreds are used only with ACL. Do not delete all predicates but
@@ -125,6 +133,76 @@ func expandEdges(ctx context.Context, m *pb.Mutations) ([]*pb.DirectedEdge, erro
 	return edges, nil
 }
 
+// getStarNodeTypes returns the types of the subjects of the S * * deletions of the mutation, by
+// namespace and then by uid. An upsert deleting uid(u) * * deletes every node matched by its
+// query, so the types are fetched in batches rather than with a task for each node.
+func getStarNodeTypes(ctx context.Context, m *pb.Mutations, namespace uint64,
+	isGalaxyQuery bool) (map[uint64]map[uint64][]string, error) {
+
+	uidsByNs := make(map[uint64][]uint64)
+	for _, edge := range m.Edges {
+		if edge.Attr != x.Star {
+			continue
+		}
+		ns := namespace
+		if isGalaxyQuery {
+			ns = edge.GetNamespace()
+		}
+		uidsByNs[ns] = append(uidsByNs[ns], edge.GetEntity())
+	}
+
+	res := make(map[uint64]map[uint64][]string, len(uidsByNs))
+	for ns, uids := range uidsByNs {
+		sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+		// The same node can be deleted by several edges, e.g. with uid(u) * * and uid(v) * *.
+		uniq := uids[:0]
+		for i, uid := range uids {
+			if i == 0 || uid != uids[i-1] {
+				uniq = append(uniq, uid)
+			}
+		}
+
+		types, err := fetchNodeTypes(x.AttachNamespace(ctx, ns), uniq, m.StartTs)
+		if err != nil {
+			return nil, err
+		}
+		res[ns] = types
+	}
+	return res, nil
+}
+
+// fetchNodeTypes returns the types of the given nodes, which must be sorted, in the namespace of
+// the context.
+func fetchNodeTypes(ctx context.Context, uids []uint64,
+	readTs uint64) (map[uint64][]string, error) {
+
+	types := make(map[uint64][]string, len(uids))
+	for start := 0; start < len(uids); start += starTypesBatchSize {
+		end := start + starTypesBatchSize
+		if end > len(uids) {
+			end = len(uids)
+		}
+		batch := uids[start:end]
+		taskQuery, err := createTaskQuery(ctx, &SubGraph{
+			Attr:    "dgraph.type",
+			SrcUIDs: &pb.List{Uids: batch},
+			ReadTs:  readTs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result, err := worker.ProcessTaskOverNetwork(ctx, taskQuery)
+		if err != nil {
+			return nil, err
+		}
+		// The value matrix has a list of types for each of the uids, in the same order.
+		for i, vals := range result.ValueMatrix {
+			types[batch[i]] = getPredsFromVals([]*pb.ValueList{vals})
+		}
+	}
+	return types, nil
+}
+
 func verifyUid(ctx context.Context, uid uint64) error {
 	if uid <= worker.MaxLeaseId() {
 		return nil
@@ -153,12 +231,35 @@ func verifyUid(ctx context.Context, uid uint64) error {
 
 // AssignUids tries to assign unique ids to each identity in the subjects and objects in the
 // format of _:xxx. An identity, e.g. _:a, will only be assigned one uid regardless how many times
-// it shows up in the subjects or objects
-func AssignUids(ctx context.Context, gmuList []*gql.Mutation) (map[string]uint64, error) {
+// it shows up in the subjects or objects. A key node, e.g. _:(email="a@b.com"), is assigned the
+// uid of the node having its key, as seen by the transaction with the given start ts, if there is
+// one. readCommittedTs is the ts the transaction reads at in read-committed mode, or 0.
+func AssignUids(ctx context.Context, gmuList []*gql.Mutation,
+	startTs, readCommittedTs uint64) (map[string]uint64, error) {
+
 	newUids := make(map[string]uint64)
+	// The key nodes which are only deleted from, they aren't created if they aren't found.
+	delKeyNodes := make(map[string]struct{})
 	num := &pb.Num{}
 	var err error
 	for _, gmu := range gmuList {
+		for _, nqs := range [][]*api.NQuad{gmu.Set, gmu.Incr} {
+			keyNQs, err := chunker.FillKeyNodes(nqs)
+			if err != nil {
+				return newUids, err
+			}
+			gmu.Set = append(gmu.Set, keyNQs...)
+		}
+		if err := chunker.CanonicalKeyNodes(gmu.Del); err != nil {
+			return newUids, err
+		}
+		for _, nq := range gmu.Del {
+			for _, id := range []string{nq.Subject, nq.ObjectId} {
+				if chunker.IsKeyNode(id) {
+					delKeyNodes[id] = struct{}{}
+				}
+			}
+		}
 		for _, nq := range gmu.Set {
 			// We dont want to assign uids to these.
 			if nq.Subject == x.Star && nq.ObjectValue.GetDefaultVal() == x.Star {
@@ -190,9 +291,48 @@ func AssignUids(ctx context.Context, gmuList []*gql.Mutation) (map[string]uint64
 				}
 			}
 		}
+		// The increments of a new node start from zero.
+		for _, nq := range gmu.Incr {
+			var uid uint64
+			if strings.HasPrefix(nq.Subject, "_:") {
+				newUids[nq.Subject] = 0
+			} else if uid, err = gql.ParseUid(nq.Subject); err != nil {
+				return newUids, err
+			}
+			if err = verifyUid(ctx, uid); err != nil {
+				return newUids, err
+			}
+		}
 	}
 
-	num.Val = uint64(len(newUids))
+	for name := range delKeyNodes {
+		if _, ok := newUids[name]; ok {
+			delete(delKeyNodes, name)
+			continue
+		}
+		newUids[name] = 0
+	}
+	if err := resolveKeyNodes(ctx, newUids, startTs, readCommittedTs); err != nil {
+		return newUids, err
+	}
+	// There is nothing to delete from the key nodes which don't exist.
+	for name := range delKeyNodes {
+		if newUids[name] == 0 {
+			delete(newUids, name)
+		} else {
+			delete(delKeyNodes, name)
+		}
+	}
+	if len(delKeyNodes) > 0 {
+		for _, gmu := range gmuList {
+			gmu.Del = dropKeyNodes(gmu.Del, delKeyNodes)
+		}
+	}
+	for _, uid := range newUids {
+		if uid == 0 {
+			num.Val++
+		}
+	}
 	num.Type = pb.Num_UID
 	if int(num.Val) > 0 {
 		var res *pb.AssignedIds
@@ -203,7 +343,10 @@ func AssignUids(ctx context.Context, gmuList []*gql.Mutation) (map[string]uint64
 		}
 		curId := res.StartId
 		// assign generated ones now
-		for k := range newUids {
+		for k, uid := range newUids {
+			if uid != 0 {
+				continue
+			}
 			x.AssertTruef(curId != 0 && curId <= res.EndId, "not enough uids generated")
 			newUids[k] = curId
 			curId++
@@ -212,6 +355,50 @@ func AssignUids(ctx context.Context, gmuList []*gql.Mutation) (map[string]uint64
 	return newUids, nil
 }
 
+// resolveKeyNodes sets the uids of the key nodes found by the transaction in newUids.
+func resolveKeyNodes(ctx context.Context, newUids map[string]uint64,
+	startTs, readCommittedTs uint64) error {
+	keyNodes := make(map[string]chunker.KeyValue)
+	for name := range newUids {
+		if !chunker.IsKeyNode(name) {
+			continue
+		}
+		key, _, err := chunker.ParseKeyNode(name)
+		if err != nil {
+			return err
+		}
+		keyNodes[name] = key
+	}
+	if len(keyNodes) == 0 {
+		return nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return err
+	}
+	found, err := worker.ResolveKeyNodes(ctx, ns, keyNodes, startTs, readCommittedTs)
+	if err != nil {
+		return err
+	}
+	for name, uid := range found {
+		newUids[name] = uid
+	}
+	return nil
+}
+
+// dropKeyNodes returns the N-Quads which don't refer to any of the key nodes.
+func dropKeyNodes(nqs []*api.NQuad, keyNodes map[string]struct{}) []*api.NQuad {
+	kept := nqs[:0]
+	for _, nq := range nqs {
+		_, subject := keyNodes[nq.Subject]
+		_, object := keyNodes[nq.ObjectId]
+		if !subject && !object {
+			kept = append(kept, nq)
+		}
+	}
+	return kept
+}
+
 // ToDirectedEdges converts the gql.Mutation input into a set of directed edges.
 func ToDirectedEdges(gmuList []*gql.Mutation, newUids map[string]uint64) (
 	edges []*pb.DirectedEdge, err error) {
@@ -258,6 +445,11 @@ func ToDirectedEdges(gmuList []*gql.Mutation, newUids map[string]uint64) (
 				return edges, err
 			}
 		}
+		for _, nq := range gmu.Incr {
+			if err := parse(nq, pb.DirectedEdge_INCR); err != nil {
+				return edges, err
+			}
+		}
 	}
 
 	return edges, nil
@@ -0,0 +1,134 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// maxFloatPrecision is the largest number of decimals which can be asked with floatPrecision.
+const maxFloatPrecision = 17
+
+// valueFormat holds the formatting of the values of a block, set by the datetimeFormat, timezone
+// and floatPrecision arguments at root. The values are written with their default format,
+// RFC3339 for the datetimes and six decimals for the floats, if the block doesn't have a
+// valueFormat.
+type valueFormat struct {
+	// datetime is the Go layout the datetimes are written with, e.g. "2006-01-02".
+	datetime string
+	// location is the timezone the datetimes are converted to. They are written in the timezone
+	// they were stored with if it's nil.
+	location *time.Location
+	// floatPrecision is the number of decimals of the floats, they keep the default format if
+	// it's negative.
+	floatPrecision int
+}
+
+// parseValueFormat returns the format set by the arguments of a root block, or nil if it
+// doesn't set any.
+func parseValueFormat(args map[string]string) (*valueFormat, error) {
+	layout, hasLayout := args["datetimeFormat"]
+	tz, hasTz := args["timezone"]
+	precision, hasPrecision := args["floatPrecision"]
+	if !hasLayout && !hasTz && !hasPrecision {
+		return nil, nil
+	}
+
+	f := &valueFormat{floatPrecision: -1}
+	if hasLayout {
+		layout = unquoteArg(layout)
+		// A layout without any element of a time would write the same string for every value.
+		if layout == "" || (time.Time{}).Format(layout) == layout {
+			return nil, errors.Errorf("datetimeFormat should be a Go time layout like "+
+				"2006-01-02T15:04:05Z07:00, got: %q", layout)
+		}
+		f.datetime = layout
+	}
+	if hasTz {
+		loc, err := time.LoadLocation(unquoteArg(tz))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing the timezone %s", tz)
+		}
+		f.location = loc
+	}
+	if hasPrecision {
+		p, err := strconv.Atoi(precision)
+		if err != nil || p < 0 || p > maxFloatPrecision {
+			return nil, errors.Errorf("floatPrecision should be an integer between 0 and %d, "+
+				"got: %s", maxFloatPrecision, precision)
+		}
+		f.floatPrecision = p
+	}
+	return f, nil
+}
+
+// unquoteArg removes the quotes around the string value of an argument, which are kept by the
+// parser. The values of the GraphQL variables aren't quoted.
+func unquoteArg(v string) string {
+	if uq, err := strconv.Unquote(v); err == nil {
+		return uq
+	}
+	return v
+}
+
+// encode returns the value encoded in JSON, or MessagePack if msgpack is set, if the format
+// applies to it. The datetimes are written as strings, which are only read back as datetimes by
+// Dgraph if the layout is one of the ones it parses, like RFC3339 or 2006-01-02.
+func (f *valueFormat) encode(v types.Val, msgpack bool) (bs []byte, ok bool, err error) {
+	switch v.Tid {
+	case types.DateTimeID:
+		t, isTime := v.Value.(time.Time)
+		if !isTime || (f.datetime == "" && f.location == nil) {
+			return nil, false, nil
+		}
+		if f.location != nil {
+			t = t.In(f.location)
+		}
+		layout := f.datetime
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		s := t.Format(layout)
+		if msgpack {
+			return appendMsgpackString(nil, s), true, nil
+		}
+		return stringJsonMarshal(s), true, nil
+
+	case types.FloatID:
+		if f.floatPrecision < 0 {
+			return nil, false, nil
+		}
+		fl, isFloat := v.Value.(float64)
+		if !isFloat || math.IsInf(fl, 0) || math.IsNaN(fl) {
+			return nil, true, errors.New("Unsupported floating point number in float field")
+		}
+		s := strconv.FormatFloat(fl, 'f', f.floatPrecision, 64)
+		if msgpack {
+			// MessagePack floats are binary, the value is rounded instead.
+			rounded, err := strconv.ParseFloat(s, 64)
+			return appendMsgpackFloat(nil, rounded), true, err
+		}
+		return []byte(s), true, nil
+	}
+	return nil, false, nil
+}
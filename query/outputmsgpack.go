@@ -0,0 +1,395 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
+	"github.com/twpayne/go-geom/encoding/geojson"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The results of a query can be encoded in MessagePack instead of JSON, with the same structure.
+// The fastJson tree is built as usual, except that the scalar values are stored already encoded
+// in MessagePack, which avoids the escaping and the formatting of numbers done for JSON.
+//
+// The values are mapped as follows:
+//   - int, float and bool values use the corresponding MessagePack types. Floats keep all of their
+//     precision, unlike JSON where they are written with six decimals.
+//   - string, uid, password and datetime values are strings. Datetimes are in RFC 3339 format, as
+//     in JSON.
+//   - decimal values are strings, so that they aren't rounded by the clients.
+//   - binary values use the bin type.
+//   - geo values are maps holding their GeoJSON representation.
+// Facets and language tags are keys of the maps as in JSON, e.g. "name@en" or "friend|since".
+
+// WantsMsgpack returns true if the results of the query should be encoded in MessagePack.
+func WantsMsgpack(ctx context.Context) bool {
+	m, _ := ctx.Value(MsgpackKey).(bool)
+	return m
+}
+
+// ToMsgpack converts the list of subgraph into a MessagePack response.
+func ToMsgpack(ctx context.Context, l *Latency, sgl []*SubGraph) ([]byte, error) {
+	data, err := resultRoot(sgl).toMsgpack(ctx, l)
+	if err != nil {
+		glog.Errorf("while running ToMsgpack: %v\n", err)
+	}
+	return data, errors.Wrapf(err, "while running ToMsgpack")
+}
+
+func (sg *SubGraph) toMsgpack(ctx context.Context, l *Latency) ([]byte, error) {
+	encodingStart := time.Now()
+	defer func() {
+		l.Json = time.Since(encodingStart)
+	}()
+
+	enc := newEncoder()
+	enc.msgpack = true
+	enc.mem = memoryAccountFromContext(ctx)
+	defer func() {
+		arenaPool.Put(enc.arena)
+		enc.alloc.Release()
+	}()
+	if renames := fieldRenamesFromContext(ctx); len(renames) > 0 {
+		if err := renames.check(sg); err != nil {
+			return nil, err
+		}
+		enc.setFieldRenames(renames)
+	}
+
+	n := enc.newNode(enc.idForAttr("_root_"))
+	for _, sg := range sg.Children {
+		if err := processNodeUids(n, enc, sg); err != nil {
+			return nil, err
+		}
+	}
+	enc.fixOrder(n)
+
+	if enc.children(n) == nil {
+		x.Check2(enc.buf.Write(appendMsgpackMapLen(nil, 0)))
+	} else if err := enc.encodeMsgpack(n); err != nil {
+		return nil, err
+	}
+	if uint64(enc.buf.Len()) > maxEncodedSize {
+		return nil, fmt.Errorf("while writing to buffer. Encoded response size: %d"+
+			" is bigger than threshold: %d", enc.buf.Len(), maxEncodedSize)
+	}
+	return enc.buf.Bytes(), nil
+}
+
+// encodeMsgpack is the MessagePack counterpart of encode. The children of a node with the same
+// attribute are consecutive, and become a single key holding an array.
+func (enc *encoder) encodeMsgpack(fj fastJsonNode) error {
+	child := enc.children(fj)
+	// This is a scalar value.
+	if child == nil {
+		offset := uint32(fj.meta & setBytes4321)
+		data, err := enc.arena.get(offset)
+		if err != nil {
+			return err
+		}
+		if (fj.meta & uidNodeBit) > 0 {
+			uid := binary.BigEndian.Uint64(data)
+			data = appendMsgpackString(nil, "0x"+strconv.FormatUint(uid, 16))
+		}
+		_, err = enc.buf.Write(data)
+		return err
+	}
+
+	keys := 0
+	for cur := child; cur != nil; cur = cur.next {
+		if cur.next == nil || enc.getAttr(cur) != enc.getAttr(cur.next) {
+			keys++
+		}
+	}
+	var hdr [9]byte
+	x.Check2(enc.buf.Write(appendMsgpackMapLen(hdr[:0], keys)))
+
+	for child != nil {
+		attr := enc.getAttr(child)
+		last, count, size := child, 1, 0
+		for last.next != nil && enc.getAttr(last.next) == attr {
+			last = last.next
+			count++
+		}
+		for cur := child; ; cur = cur.next {
+			if !enc.isEmptyNode(cur) {
+				size++
+			}
+			if cur == last {
+				break
+			}
+		}
+
+		x.Check2(enc.buf.Write(appendMsgpackString(hdr[:0], enc.attrForID(attr))))
+		switch {
+		case count > 1 || enc.getList(child):
+			x.Check2(enc.buf.Write(appendMsgpackArrayLen(hdr[:0], size)))
+		case size == 0:
+			x.Check2(enc.buf.Write([]byte{msgpackNil}))
+		}
+		for cur := child; ; cur = cur.next {
+			if !enc.isEmptyNode(cur) {
+				if err := enc.encodeMsgpack(cur); err != nil {
+					return err
+				}
+			}
+			if cur == last {
+				break
+			}
+		}
+		child = last.next
+	}
+	return nil
+}
+
+// isEmptyNode returns true if the node has neither children nor a value, e.g. the node added for
+// a block without results. It is encoded as nothing in JSON, so that the block is an empty list.
+func (enc *encoder) isEmptyNode(fj fastJsonNode) bool {
+	return fj.child == nil && fj.meta&(setBytes4321|uidNodeBit) == 0
+}
+
+func valToMsgpack(v types.Val) ([]byte, error) {
+	switch v.Tid {
+	case types.StringID, types.DefaultID:
+		switch str := v.Value.(type) {
+		case string:
+			return appendMsgpackString(nil, str), nil
+		case []byte:
+			return appendMsgpackString(nil, string(str)), nil
+		default:
+			return appendMsgpackString(nil, fmt.Sprint(str)), nil
+		}
+	case types.BinaryID:
+		return appendMsgpackBinary(nil, v.Value.([]byte)), nil
+	case types.IntID:
+		switch num := v.Value.(type) {
+		case int64:
+			return appendMsgpackInt(nil, num), nil
+		case int:
+			return appendMsgpackInt(nil, int64(num)), nil
+		default:
+			return nil, errors.Errorf("Unsupported int value: %v", v.Value)
+		}
+	case types.FloatID:
+		f, fOk := v.Value.(float64)
+		// These aren't returned in JSON either.
+		if !fOk || math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, errors.New("Unsupported floating point number in float field")
+		}
+		return appendMsgpackFloat(nil, f), nil
+	case types.DecimalID:
+		return appendMsgpackString(nil, v.Value.(types.Decimal).String()), nil
+	case types.BoolID:
+		return appendMsgpackBool(nil, v.Value.(bool)), nil
+	case types.DateTimeID:
+		return appendMsgpackString(nil, v.Value.(time.Time).Format(time.RFC3339Nano)), nil
+	case types.GeoID:
+		js, err := geojson.Marshal(v.Value.(geom.T))
+		if err != nil {
+			return nil, err
+		}
+		return JSONToMsgpack(js)
+	case types.UidID:
+		return appendMsgpackString(nil, fmt.Sprintf("%#x", v.Value)), nil
+	case types.PasswordID:
+		return appendMsgpackString(nil, v.Value.(string)), nil
+	default:
+		return nil, errors.New("Unsupported types.Val.Tid")
+	}
+}
+
+// JSONToMsgpack converts a JSON document to MessagePack. It is used for the parts of a response
+// which are only available in JSON, like the extensions or the result of a schema query. The
+// keys of the objects are sorted.
+func JSONToMsgpack(js []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, errors.Wrapf(err, "while converting JSON to MessagePack")
+	}
+	return appendMsgpackValue(nil, v)
+}
+
+// MsgpackResponse returns the MessagePack equivalent of {"data": data, "extensions": ext}, where
+// data and ext are already encoded. The data is nil if empty, e.g. for an empty query.
+func MsgpackResponse(data, ext []byte) []byte {
+	out := appendMsgpackMapLen(make([]byte, 0, len(data)+len(ext)+20), 2)
+	out = appendMsgpackString(out, "data")
+	if len(data) == 0 {
+		out = append(out, msgpackNil)
+	}
+	out = append(out, data...)
+	out = appendMsgpackString(out, "extensions")
+	return append(out, ext...)
+}
+
+func appendMsgpackValue(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, msgpackNil), nil
+	case bool:
+		return appendMsgpackBool(b, v), nil
+	case string:
+		return appendMsgpackString(b, v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return appendMsgpackInt(b, i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendMsgpackFloat(b, f), nil
+	case []interface{}:
+		b = appendMsgpackArrayLen(b, len(v))
+		for _, e := range v {
+			var err error
+			if b, err = appendMsgpackValue(b, e); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b = appendMsgpackMapLen(b, len(v))
+		for _, k := range keys {
+			b = appendMsgpackString(b, k)
+			var err error
+			if b, err = appendMsgpackValue(b, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, errors.Errorf("Unsupported JSON value of type %T", v)
+}
+
+const msgpackNil = 0xc0
+
+func appendMsgpackBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 0xc3)
+	}
+	return append(b, 0xc2)
+}
+
+func appendMsgpackInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= math.MaxInt8:
+		return append(b, byte(i))
+	case i < 0 && i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		return append(b, 0xd1, byte(i>>8), byte(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		b = append(b, 0xd2)
+		return appendUint32(b, uint32(i))
+	}
+	b = append(b, 0xd3)
+	return appendUint64(b, uint64(i))
+}
+
+func appendMsgpackFloat(b []byte, f float64) []byte {
+	b = append(b, 0xcb)
+	return appendUint64(b, math.Float64bits(f))
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xda, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xdb)
+		b = appendUint32(b, uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpackBinary(b []byte, v []byte) []byte {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		b = append(b, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xc5, byte(n>>8), byte(n))
+	default:
+		b = append(b, 0xc6)
+		b = appendUint32(b, uint32(n))
+	}
+	return append(b, v...)
+}
+
+func appendMsgpackArrayLen(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xdc, byte(n>>8), byte(n))
+	}
+	b = append(b, 0xdd)
+	return appendUint32(b, uint32(n))
+}
+
+func appendMsgpackMapLen(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, 0xde, byte(n>>8), byte(n))
+	}
+	b = append(b, 0xdf)
+	return appendUint32(b, uint32(n))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var tmp [4]byte
+	binary.BigEndian.PutUint32(tmp[:], v)
+	return append(b, tmp[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var tmp [8]byte
+	binary.BigEndian.PutUint64(tmp[:], v)
+	return append(b, tmp[:]...)
+}
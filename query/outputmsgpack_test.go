@@ -0,0 +1,166 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	geom "github.com/twpayne/go-geom"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func msgpackToJSON(t testing.TB, b []byte) string {
+	var js bytes.Buffer
+	rest, err := msgp.UnmarshalAsJSON(&js, b)
+	require.NoError(t, err)
+	require.Empty(t, rest)
+	return js.String()
+}
+
+func TestValToMsgpack(t *testing.T) {
+	tests := []struct {
+		val      types.Val
+		expected string
+	}{
+		{types.Val{Tid: types.StringID, Value: "a \"quoted\" <string>"},
+			`"a \"quoted\" <string>"`},
+		{types.Val{Tid: types.DefaultID, Value: "default"}, `"default"`},
+		{types.Val{Tid: types.IntID, Value: int64(-100000)}, `-100000`},
+		{types.Val{Tid: types.FloatID, Value: 1.234567891}, `1.234567891`},
+		{types.Val{Tid: types.BoolID, Value: true}, `true`},
+		{types.Val{Tid: types.DateTimeID, Value: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+			`"2006-01-02T15:04:05Z"`},
+		{types.Val{Tid: types.UidID, Value: uint64(0x1a)}, `"0x1a"`},
+		{types.Val{Tid: types.GeoID, Value: geom.NewPoint(geom.XY).MustSetCoords(
+			geom.Coord{-122.5, 37})}, `{"type": "Point", "coordinates": [-122.5, 37]}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.val.Tid.Name(), func(t *testing.T) {
+			b, err := valToMsgpack(tc.val)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.expected, msgpackToJSON(t, b))
+		})
+	}
+
+	// Decimals are strings, so that they aren't rounded.
+	d, err := types.Convert(types.Val{Tid: types.StringID, Value: "1234567890.123456789"},
+		types.DecimalID)
+	require.NoError(t, err)
+	b, err := valToMsgpack(d)
+	require.NoError(t, err)
+	s, _, err := msgp.ReadStringBytes(b)
+	require.NoError(t, err)
+	require.Equal(t, "1234567890.123456789", s)
+
+	// Binary values are raw bytes.
+	b, err = valToMsgpack(types.Val{Tid: types.BinaryID, Value: []byte{0, 1, 0xff}})
+	require.NoError(t, err)
+	raw, _, err := msgp.ReadBytesBytes(b, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 0xff}, raw)
+
+	_, err = valToMsgpack(types.Val{Tid: types.FloatID, Value: math.Inf(1)})
+	require.Error(t, err)
+}
+
+func TestAppendMsgpackInt(t *testing.T) {
+	for _, i := range []int64{0, 1, 127, 128, 255, 256, math.MaxInt16, math.MaxInt32,
+		math.MaxInt32 + 1, math.MaxInt64, -1, -32, -33, -128, -129, math.MinInt16,
+		math.MinInt32, math.MinInt32 - 1, math.MinInt64} {
+		got, rest, err := msgp.ReadInt64Bytes(appendMsgpackInt(nil, i))
+		require.NoError(t, err)
+		require.Empty(t, rest)
+		require.Equal(t, i, got)
+	}
+}
+
+// buildResultTree builds a tree like the ones of the query results, with a list of nodes holding
+// scalar values, a facet and a uid.
+func buildResultTree(t testing.TB, enc *encoder, num int) fastJsonNode {
+	root := enc.newNode(enc.idForAttr("_root_"))
+	for i := 0; i < num; i++ {
+		n := enc.newNode(enc.idForAttr("me"))
+		require.NoError(t, enc.SetUID(n, uint64(i+1), enc.uidAttr))
+		require.NoError(t, enc.AddValue(n, enc.idForAttr("name"),
+			types.Val{Tid: types.StringID, Value: fmt.Sprintf("name \"%d\"", i)}))
+		require.NoError(t, enc.AddValue(n, enc.idForAttr("age"),
+			types.Val{Tid: types.IntID, Value: int64(i)}))
+		require.NoError(t, enc.AddValue(n, enc.idForAttr("score"),
+			types.Val{Tid: types.FloatID, Value: float64(i) / 4}))
+		require.NoError(t, enc.AddValue(n, enc.idForAttr("name|since"),
+			types.Val{Tid: types.DateTimeID, Value: time.Unix(int64(i), 0).UTC()}))
+		enc.AddListChild(root, n)
+	}
+	enc.AddListChild(root, enc.newNode(enc.idForAttr("empty")))
+	enc.fixOrder(root)
+	return root
+}
+
+func TestEncodeMsgpack(t *testing.T) {
+	jsonEnc := newEncoder()
+	root := buildResultTree(t, jsonEnc, 20)
+	require.NoError(t, jsonEnc.encode(root))
+
+	msgpackEnc := newEncoder()
+	msgpackEnc.msgpack = true
+	root = buildResultTree(t, msgpackEnc, 20)
+	require.NoError(t, msgpackEnc.encodeMsgpack(root))
+
+	require.JSONEq(t, jsonEnc.buf.String(), msgpackToJSON(t, msgpackEnc.buf.Bytes()))
+}
+
+func TestMsgpackResponse(t *testing.T) {
+	ext, err := JSONToMsgpack([]byte(`{"txn": {"start_ts": 10}, "metrics": null}`))
+	require.NoError(t, err)
+	data := appendMsgpackMapLen(nil, 0)
+	require.JSONEq(t, `{"data": {}, "extensions": {"txn": {"start_ts": 10}, "metrics": null}}`,
+		msgpackToJSON(t, MsgpackResponse(data, ext)))
+	require.JSONEq(t, `{"data": null, "extensions": {"txn": {"start_ts": 10}, "metrics": null}}`,
+		msgpackToJSON(t, MsgpackResponse(nil, ext)))
+}
+
+// BenchmarkEncodeResult compares the encoding of the results in JSON and MessagePack. The sizes of
+// the responses are reported as bytes/op.
+func BenchmarkEncodeResult(b *testing.B) {
+	for _, format := range []string{"json", "msgpack"} {
+		b.Run(format, func(b *testing.B) {
+			b.ReportAllocs()
+			var size int
+			for i := 0; i < b.N; i++ {
+				enc := newEncoder()
+				enc.msgpack = format == "msgpack"
+				root := buildResultTree(b, enc, 10000)
+				if enc.msgpack {
+					require.NoError(b, enc.encodeMsgpack(root))
+				} else {
+					require.NoError(b, enc.encode(root))
+				}
+				size = enc.buf.Len()
+				arenaPool.Put(enc.arena)
+				enc.alloc.Release()
+			}
+			b.ReportMetric(float64(size), "bytes/op")
+		})
+	}
+}
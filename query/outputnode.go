@@ -22,6 +22,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"strconv"
 	"strings"
@@ -50,6 +51,27 @@ import (
 // ToJson converts the list of subgraph into a JSON response by calling toFastJSON.
 func ToJson(ctx context.Context, l *Latency, sgl []*SubGraph, field gqlSchema.Field) ([]byte,
 	error) {
+	data, err := resultRoot(sgl).toFastJSON(ctx, l, field, nil)
+
+	// don't log or wrap GraphQL errors
+	if x.IsGqlErrorList(err) {
+		return data, err
+	}
+	if err != nil {
+		glog.Errorf("while running ToJson: %v\n", err)
+	}
+	return data, errors.Wrapf(err, "while running ToJson")
+}
+
+// WriteJson writes the JSON response of the list of subgraphs to w as it is encoded, for the
+// results which are too large to be held in memory twice, or sent back in a gRPC reply.
+func WriteJson(ctx context.Context, l *Latency, sgl []*SubGraph, w io.Writer) error {
+	_, err := resultRoot(sgl).toFastJSON(ctx, l, nil, w)
+	return errors.Wrapf(err, "while running WriteJson")
+}
+
+// resultRoot returns a subgraph holding the blocks of the query which are part of the response.
+func resultRoot(sgl []*SubGraph) *SubGraph {
 	sgr := &SubGraph{}
 	for _, sg := range sgl {
 		if sg.Params.Alias == "var" || sg.Params.Alias == "shortest" {
@@ -60,16 +82,7 @@ func ToJson(ctx context.Context, l *Latency, sgl []*SubGraph, field gqlSchema.Fi
 		}
 		sgr.Children = append(sgr.Children, sg)
 	}
-	data, err := sgr.toFastJSON(ctx, l, field)
-
-	// don't log or wrap GraphQL errors
-	if x.IsGqlErrorList(err) {
-		return data, err
-	}
-	if err != nil {
-		glog.Errorf("while running ToJson: %v\n", err)
-	}
-	return data, errors.Wrapf(err, "while running ToJson")
+	return sgr
 }
 
 // We are capping maxEncoded size to 4GB, as grpc encoding fails
@@ -104,6 +117,24 @@ type encoder struct {
 
 	// buf is the buffer which stores the JSON encoded response
 	buf *bytes.Buffer
+	// out is written the content of buf whenever it gets larger than flushSize, if the response
+	// is streamed by WriteJson.
+	out io.Writer
+
+	// mem is the memory account of the request, and charged is the estimated response size
+	// already added to it.
+	mem     *memoryAccount
+	charged uint64
+
+	// msgpack is set if the scalar values are encoded in MessagePack instead of JSON.
+	msgpack bool
+
+	// format is the formatting of the values of the root block being encoded, nil if it doesn't
+	// set any.
+	format *valueFormat
+
+	// renames maps the names of the fields to the names they are output under.
+	renames FieldRenames
 }
 
 type node struct {
@@ -215,7 +246,7 @@ func (enc *encoder) idForAttr(attr string) uint16 {
 		return id
 	}
 
-	enc.idSlice = append(enc.idSlice, attr)
+	enc.idSlice = append(enc.idSlice, enc.renames.outputName(attr))
 	enc.attrMap[attr] = uint16(len(enc.idSlice) - 1) // TODO(Ashish): check for overflow.
 	return uint16(len(enc.idSlice) - 1)
 }
@@ -335,10 +366,17 @@ func (enc *encoder) setScalarVal(fj fastJsonNode, sv []byte) error {
 
 	// Also increase curSize.
 	enc.curSize += uint64(len(sv))
-	if size := uint64(enc.alloc.Size()) + enc.curSize; size > maxEncodedSize {
+	size := uint64(enc.alloc.Size()) + enc.curSize
+	if size > maxEncodedSize {
 		return fmt.Errorf("estimated response size: %d is bigger than threshold: %d",
 			size, maxEncodedSize)
 	}
+	if size > enc.charged {
+		if err := enc.mem.add(int64(size - enc.charged)); err != nil {
+			return err
+		}
+		enc.charged = size
+	}
 	return nil
 }
 
@@ -482,7 +520,19 @@ func (enc *encoder) AddValue(fj fastJsonNode, attr uint16, v types.Val) error {
 }
 
 func (enc *encoder) AddListValue(fj fastJsonNode, attr uint16, v types.Val, list bool) error {
-	bs, err := valToBytes(v)
+	var bs []byte
+	var err error
+	formatted := false
+	if enc.format != nil {
+		bs, formatted, err = enc.format.encode(v, enc.msgpack)
+	}
+	switch {
+	case formatted:
+	case enc.msgpack:
+		bs, err = valToMsgpack(v)
+	default:
+		bs, err = valToBytes(v)
+	}
 	if err != nil {
 		return nil // Ignore this.
 	}
@@ -647,6 +697,10 @@ func valToBytes(v types.Val) ([]byte, error) {
 			return json.Marshal(str)
 		}
 	case types.BinaryID:
+		// Binary values are base64 encoded, as JSON can't hold raw bytes.
+		if x.Config.BinaryBase64 {
+			return []byte(`"` + types.EncodeBinary(v.Value.([]byte)) + `"`), nil
+		}
 		return []byte(fmt.Sprintf("%q", v.Value)), nil
 	case types.IntID:
 		// In types.Convert(), we always convert to int64 for IntID type. fmt.Sprintf is slow
@@ -670,6 +724,9 @@ func valToBytes(v types.Val) ([]byte, error) {
 		}
 
 		return []byte(fmt.Sprintf("%f", f)), nil
+	case types.DecimalID:
+		// Decimals are written with all their digits, so that they aren't rounded.
+		return []byte(v.Value.(types.Decimal).String()), nil
 	case types.BoolID:
 		if v.Value.(bool) {
 			return boolTrue, nil
@@ -716,14 +773,16 @@ func (enc *encoder) attachFacets(fj fastJsonNode, fieldName string, isList bool,
 		if err != nil {
 			return err
 		}
+		// The values of a list facet are returned as a JSON array.
+		listFacet := facets.IsList(f)
 
 		if !isList {
-			if err := enc.AddValue(fj, enc.idForAttr(fName), fVal); err != nil {
+			if err := enc.AddListValue(fj, enc.idForAttr(fName), fVal, listFacet); err != nil {
 				return err
 			}
 		} else {
 			facetNode := enc.newNode(enc.idForAttr(fName))
-			err := enc.AddValue(facetNode, idxFieldID, fVal)
+			err := enc.AddListValue(facetNode, idxFieldID, fVal, listFacet)
 			if err != nil {
 				return err
 			}
@@ -736,6 +795,43 @@ func (enc *encoder) attachFacets(fj fastJsonNode, fieldName string, isList bool,
 	return nil
 }
 
+// attachNestedFacets adds the facets as an object under the given key, for nestFacets. For a
+// list of values, it holds an object of facets for each value, keyed by the index of the value
+// as for the flat facets.
+func (enc *encoder) attachNestedFacets(fj fastJsonNode, key string, isList bool,
+	fList []*api.Facet, facetIdx int) error {
+
+	if len(fList) == 0 {
+		return nil
+	}
+	facetsNode := enc.newNode(enc.idForAttr(key))
+	parent := facetsNode
+	if isList {
+		parent = enc.newNode(enc.idForAttr(strconv.Itoa(facetIdx)))
+	}
+	for _, f := range fList {
+		fName := f.Key
+		if f.Alias != "" {
+			fName = f.Alias
+		}
+		fVal, err := facets.ValFor(f)
+		if err != nil {
+			return err
+		}
+		err = enc.AddListValue(parent, enc.idForAttr(fName), fVal, facets.IsList(f))
+		if err != nil {
+			return err
+		}
+	}
+	if isList {
+		enc.AddMapChild(facetsNode, parent)
+	}
+	// The facets object is kept as a value of the node by @normalize.
+	enc.setFacetsParent(facetsNode)
+	enc.AddMapChild(fj, facetsNode)
+	return nil
+}
+
 func (enc *encoder) encode(fj fastJsonNode) error {
 	child := enc.children(fj)
 	// This is a scalar value.
@@ -809,6 +905,21 @@ func (enc *encoder) encode(fj fastJsonNode) error {
 		return err
 	}
 
+	return enc.flush(flushSize)
+}
+
+// flushSize is the size of the encoded response written out at once when it's streamed.
+const flushSize = 1 << 20
+
+// flush writes the encoded response to out if it is streamed and buf holds at least size bytes.
+func (enc *encoder) flush(size int) error {
+	if enc.out == nil || enc.buf.Len() < size {
+		return nil
+	}
+	if _, err := enc.out.Write(enc.buf.Bytes()); err != nil {
+		return errors.Wrapf(err, "while writing the response")
+	}
+	enc.buf.Reset()
 	return nil
 }
 
@@ -1058,7 +1169,23 @@ func (sg *SubGraph) handleCountUIDNodes(enc *encoder, n fastJsonNode, count int)
 	return addedNewChild, nil
 }
 
+// addTotalCount adds the number of results of the block before pagination, as requested by the
+// @totalcount directive, e.g. {"me": [{"totalCount": 1234}, ...]}.
+func (sg *SubGraph) addTotalCount(enc *encoder, fj fastJsonNode) error {
+	c := types.ValueForType(types.IntID)
+	c.Value = int64(sg.totalCount)
+
+	n := enc.newNode(enc.idForAttr(sg.fieldName()))
+	if err := enc.AddValue(n, enc.idForAttr("totalCount"), c); err != nil {
+		return err
+	}
+	enc.AddListChild(fj, n)
+	return nil
+}
+
 func processNodeUids(fj fastJsonNode, enc *encoder, sg *SubGraph) error {
+	// The values of the block, along with the ones of its children, use its format.
+	enc.format = sg.Params.format
 	if sg.Params.IsEmpty {
 		return sg.addAggregations(enc, fj)
 	}
@@ -1075,6 +1202,12 @@ func processNodeUids(fj fastJsonNode, enc *encoder, sg *SubGraph) error {
 	if err != nil {
 		return err
 	}
+	if sg.Params.TotalCount {
+		if err := sg.addTotalCount(enc, fj); err != nil {
+			return err
+		}
+		hasChild = true
+	}
 	if sg.Params.IsGroupBy {
 		if len(sg.GroupbyRes) == 0 {
 			return errors.Errorf("Expected GroupbyRes to have length > 0.")
@@ -1140,21 +1273,41 @@ type Extensions struct {
 	Latency *api.Latency    `json:"server_latency,omitempty"`
 	Txn     *api.TxnContext `json:"txn,omitempty"`
 	Metrics *api.Metrics    `json:"metrics,omitempty"`
+	// MissingUids holds the uids of the uid function which don't have any data, with
+	// strict-uids.
+	MissingUids []string `json:"missing_uids,omitempty"`
+	// MissingUidsTotal is the number of missing uids, only the first ones are listed.
+	MissingUidsTotal int `json:"missing_uids_total,omitempty"`
+	// Truncated holds the root blocks whose results were limited by --limit default-first.
+	Truncated []string `json:"truncated,omitempty"`
+	// IncompleteGroups holds the groups which didn't reply within the group timeout, the
+	// results of their predicates are missing.
+	IncompleteGroups []uint32 `json:"incomplete_groups,omitempty"`
 }
 
-func (sg *SubGraph) toFastJSON(ctx context.Context, l *Latency, field gqlSchema.Field) ([]byte,
-	error) {
+// toFastJSON encodes the response of the subgraph. It is written to out as it is encoded, if out
+// isn't nil.
+func (sg *SubGraph) toFastJSON(ctx context.Context, l *Latency, field gqlSchema.Field,
+	out io.Writer) ([]byte, error) {
 	encodingStart := time.Now()
 	defer func() {
 		l.Json = time.Since(encodingStart)
 	}()
 
 	enc := newEncoder()
+	enc.mem = memoryAccountFromContext(ctx)
 	defer func() {
 		// Put encoder's arena back to arena pool.
 		arenaPool.Put(enc.arena)
 		enc.alloc.Release()
 	}()
+	// The fields of GraphQL responses are named by the GraphQL query, they aren't renamed.
+	if renames := fieldRenamesFromContext(ctx); len(renames) > 0 && field == nil {
+		if err := renames.check(sg); err != nil {
+			return nil, err
+		}
+		enc.setFieldRenames(renames)
+	}
 
 	var err error
 	n := enc.newNode(enc.idForAttr("_root_"))
@@ -1176,8 +1329,15 @@ func (sg *SubGraph) toFastJSON(ctx context.Context, l *Latency, field gqlSchema.
 		// if there were any GraphQL errors, we need to propagate them back to GraphQL layer along
 		// with the data. So, don't return here if we get an error.
 		err = sg.toGraphqlJSON(newGraphQLEncoder(ctx, enc), n, field)
-	} else if err = sg.toDqlJSON(enc, n); err != nil {
-		return nil, err
+	} else {
+		enc.out = out
+		if err = sg.toDqlJSON(enc, n); err != nil {
+			return nil, err
+		}
+		if enc.out != nil {
+			// The response was written out, and isn't limited by the size of a gRPC reply.
+			return nil, enc.flush(0)
+		}
 	}
 
 	// Return error if encoded buffer size exceeds than a threshold size.
@@ -1260,6 +1420,10 @@ func (sg *SubGraph) aggWithVarFieldName() string {
 	fieldName := fmt.Sprintf("val(%v)", sg.Params.Var)
 	if len(sg.Params.NeedsVar) > 0 {
 		fieldName = fmt.Sprintf("val(%v)", sg.Params.NeedsVar[0].Name)
+		if sg.SrcFunc != nil && sg.SrcFunc.Name == "avg" && len(sg.Params.NeedsVar) > 1 {
+			// The weights of a weighted avg.
+			fieldName += fmt.Sprintf(", val(%v)", sg.Params.NeedsVar[1].Name)
+		}
 		if sg.SrcFunc != nil {
 			fieldName = fmt.Sprintf("%s(%v)", sg.SrcFunc.Name, fieldName)
 		}
@@ -1291,6 +1455,24 @@ func (sg *SubGraph) addCheckPwd(enc *encoder, vals []*pb.TaskValue, dst fastJson
 	return enc.AddValue(dst, enc.idForAttr(fieldName), c)
 }
 
+// addFuncValue adds the value computed by a function like distance or relevance, named after the
+// function and the predicate unless the function has an alias.
+func (sg *SubGraph) addFuncValue(enc *encoder, vals []*pb.TaskValue, dst fastJsonNode) error {
+	if len(vals) == 0 {
+		return nil
+	}
+	sv, err := convertWithBestEffort(vals[0], sg.Attr)
+	if err != nil {
+		return err
+	}
+
+	fieldName := sg.Params.Alias
+	if fieldName == "" {
+		fieldName = fmt.Sprintf("%s(%s)", sg.SrcFunc.Name, sg.Attr)
+	}
+	return enc.AddValue(dst, enc.idForAttr(fieldName), sv)
+}
+
 func alreadySeen(parentIds []uint64, uid uint64) bool {
 	for _, id := range parentIds {
 		if id == uid {
@@ -1321,7 +1503,7 @@ func (sg *SubGraph) preTraverse(enc *encoder, uid uint64, dst fastJsonNode) erro
 	var invalidUids map[uint64]bool
 	// We go through all predicate children of the subprotos.
 	for _, pc := range sg.Children {
-		if pc.Params.IgnoreResult {
+		if pc.Params.IgnoreResult || !pc.isIncluded(uid) {
 			continue
 		}
 		if pc.IsInternal() {
@@ -1373,6 +1555,11 @@ func (sg *SubGraph) preTraverse(enc *encoder, uid uint64, dst fastJsonNode) erro
 				return err
 			}
 
+		case pc.SrcFunc != nil && (pc.SrcFunc.Name == "distance" || pc.SrcFunc.Name == "relevance"):
+			if err := pc.addFuncValue(enc, pc.valueMatrix[idx].Values, dst); err != nil {
+				return err
+			}
+
 		case idx < len(pc.uidMatrix) && len(pc.uidMatrix[idx].Uids) > 0:
 			var fcsList []*pb.Facets
 			if pc.Params.Facet != nil {
@@ -1418,7 +1605,13 @@ func (sg *SubGraph) preTraverse(enc *encoder, uid uint64, dst fastJsonNode) erro
 					// Add facets nodes.
 					if pc.Params.Facet != nil && len(fcsList) > childIdx {
 						fs := fcsList[childIdx].Facets
-						if err := enc.attachFacets(uc, fieldName, false, fs, childIdx); err != nil {
+						var err error
+						if pc.Params.NestFacets {
+							err = enc.attachNestedFacets(uc, "@facets", false, fs, childIdx)
+						} else {
+							err = enc.attachFacets(uc, fieldName, false, fs, childIdx)
+						}
+						if err != nil {
 							return err
 						}
 					}
@@ -1505,7 +1698,14 @@ func (sg *SubGraph) preTraverse(enc *encoder, uid uint64, dst fastJsonNode) erro
 			if len(pc.facetsMatrix) > idx && len(pc.facetsMatrix[idx].FacetsList) > 0 {
 				// In case of Value we have only one Facets.
 				for i, fcts := range pc.facetsMatrix[idx].FacetsList {
-					if err := enc.attachFacets(dst, fieldName, pc.List, fcts.Facets, i); err != nil {
+					var err error
+					if pc.Params.NestFacets {
+						err = enc.attachNestedFacets(dst, fieldName+"@facets", pc.List,
+							fcts.Facets, i)
+					} else {
+						err = enc.attachFacets(dst, fieldName, pc.List, fcts.Facets, i)
+					}
+					if err != nil {
 						return err
 					}
 				}
@@ -289,6 +289,10 @@ func (genc *graphQLEncoder) encode(encInp encodeInput) bool {
 				// handles null writing for case 2
 				child = genc.completeAggregateChildren(cur, curSelection,
 					append(encInp.parentPath, curSelection.ResponseName()), true)
+			} else if curSelection.IsConnectionField() {
+				// a connection without any nodes is written with no edges, it is never null
+				genc.completeConnection(nil, curSelection,
+					append(encInp.parentPath, curSelection.ResponseName()))
 			} else {
 				// handles null writing for case 1
 				if nullWritten = writeGraphQLNull(curSelection, genc.buf,
@@ -320,7 +324,12 @@ func (genc *graphQLEncoder) encode(encInp encodeInput) bool {
 			//    current fastJson node == list type
 			//    => This is not a mismatch between the GraphQL and DQL schema and should be
 			//       handled appropriately.
-			if curSelectionIsDgList && genc.getList(cur) {
+			// The same goes for connection fields, whose data is the list of their nodes.
+			if curSelection.IsConnectionField() {
+				// handles special case of connection fields
+				child = genc.completeConnection(cur, curSelection,
+					append(encInp.parentPath, curSelection.ResponseName()))
+			} else if curSelectionIsDgList && genc.getList(cur) {
 				// handles case 1
 				itemPos := genc.buf.Len()
 				// List items which are scalars will never have null as a value returned
@@ -497,6 +506,9 @@ func (genc *graphQLEncoder) encode(encInp encodeInput) bool {
 			encInp.parentPath) {
 			// do nothing, value for field has already been written.
 			// If the value weren't written, the next else would write null.
+		} else if curSelection.IsConnectionField() {
+			genc.completeConnection(nil, curSelection,
+				append(encInp.parentPath, curSelection.ResponseName()))
 		} else {
 			if !writeGraphQLNull(curSelection, genc.buf, genc.buf.Len()) {
 				genc.errs = append(genc.errs, curSelection.GqlErrorf(append(encInp.parentPath,
@@ -746,7 +758,7 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 	parentNodeHeads []fastJsonNode, wg *sync.WaitGroup) {
 	defer wg.Done() // signal when this goroutine finishes execution
 
-	fconf, err := childField.CustomHTTPConfig()
+	fconf, err := childField.CustomHTTPConfig(genc.ctx)
 	if err != nil {
 		genc.errCh <- x.GqlErrorList{childField.GqlErrorf(nil, err.Error())}
 		return
@@ -887,8 +899,7 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 							childField.GetObjectName())}
 						return
 					}
-					body = gqlSchema.SubstituteVarsInBody(fconf.Template,
-						uniqueParents[idx].(map[string]interface{}))
+					body = fconf.SubstituteVarsInBody(uniqueParents[idx].(map[string]interface{}))
 				}
 
 				// Step-3 & 4: Make the request to external HTTP endpoint using the URL and
@@ -939,7 +950,7 @@ func (genc *graphQLEncoder) resolveCustomField(childField gqlSchema.Field,
 			}
 		} else {
 			for i := range uniqueParents {
-				uniqueParents[i] = gqlSchema.SubstituteVarsInBody(fconf.Template,
+				uniqueParents[i] = fconf.SubstituteVarsInBody(
 					uniqueParents[i].(map[string]interface{}))
 			}
 			if childField.HasLambdaDirective() {
@@ -1205,6 +1216,148 @@ func (genc *graphQLEncoder) completeAggregateChildren(fj fastJsonNode,
 	return fj
 }
 
+// completeConnection writes the result of a connection field, given the fastJson nodes of the
+// list field it is generated for, which start at fj. fj is nil if there aren't any nodes. It
+// returns the fastJson node after the nodes of the list field. For example:
+//	{
+//	  "edges": [
+//	    {
+//	      "node": { "title": "GraphQL" },
+//	      "cursor": "MHgy"
+//	    }
+//	  ],
+//	  "pageInfo": { "hasNextPage": true, "endCursor": "MHgy" }
+//	}
+func (genc *graphQLEncoder) completeConnection(fj fastJsonNode, field gqlSchema.Field,
+	fieldPath []interface{}) fastJsonNode {
+	var nodes []fastJsonNode
+	if fj != nil {
+		attrId := genc.getAttr(fj)
+		for ; fj != nil && genc.getAttr(fj) == attrId; fj = fj.next {
+			nodes = append(nodes, fj)
+		}
+	}
+	// One more node than asked for is queried, to know whether there is a next page.
+	hasNextPage := false
+	if first, ok := field.ArgValue("first").(int64); ok && first >= 0 &&
+		int64(len(nodes)) > first {
+		nodes = nodes[:first]
+		hasNextPage = true
+	}
+	// The cursor of a node is made from its uid, which is queried as dgraph.uid.
+	cursors := make([]string, len(nodes))
+	uidAttrId := genc.idForAttr("dgraph.uid")
+	for i, node := range nodes {
+		for c := genc.children(node); c != nil; c = c.next {
+			if genc.getAttr(c) != uidAttrId {
+				continue
+			}
+			if val, err := genc.getScalarVal(c); err == nil {
+				cursors[i] = gqlSchema.ConnectionCursor(toString(val))
+			}
+			break
+		}
+	}
+
+	comma := ""
+	x.Check2(genc.buf.WriteRune('{'))
+	for _, f := range field.SelectionSet() {
+		if f.Skip() || !f.Include() {
+			continue
+		}
+		x.Check2(genc.buf.WriteString(comma))
+		comma = ","
+		f.CompleteAlias(genc.buf)
+
+		switch f.Name() {
+		case gqlSchema.Typename:
+			x.Check2(genc.buf.Write(getTypename(f, nil)))
+		case "edges":
+			genc.completeConnectionEdges(nodes, cursors, f, append(fieldPath, f.ResponseName()))
+		case "pageInfo":
+			genc.completePageInfo(f, cursors, hasNextPage)
+		}
+	}
+	x.Check2(genc.buf.WriteRune('}'))
+
+	return fj
+}
+
+// completeConnectionEdges writes the edges of a connection, given its nodes and their cursors.
+func (genc *graphQLEncoder) completeConnectionEdges(nodes []fastJsonNode, cursors []string,
+	edges gqlSchema.Field, edgesPath []interface{}) {
+	x.Check2(genc.buf.WriteRune('['))
+	for i, node := range nodes {
+		if i > 0 {
+			x.Check2(genc.buf.WriteRune(','))
+		}
+		comma := ""
+		x.Check2(genc.buf.WriteRune('{'))
+		for _, f := range edges.SelectionSet() {
+			if f.Skip() || !f.Include() {
+				continue
+			}
+			x.Check2(genc.buf.WriteString(comma))
+			comma = ","
+			f.CompleteAlias(genc.buf)
+
+			switch f.Name() {
+			case gqlSchema.Typename:
+				x.Check2(genc.buf.Write(getTypename(f, nil)))
+			case "cursor":
+				x.Check2(genc.buf.WriteString(strconv.Quote(cursors[i])))
+			case "node":
+				// The node of an edge is nullable, so it becomes null if it can't be written.
+				keyEndPos := genc.buf.Len()
+				if !genc.encode(encodeInput{
+					parentField: f,
+					parentPath:  append(edgesPath, i, f.ResponseName()),
+					fj:          node,
+					fjIsRoot:    false,
+					childSelSet: f.SelectionSet(),
+				}) {
+					genc.buf.Truncate(keyEndPos)
+					x.Check2(genc.buf.Write(gqlSchema.JsonNull))
+				}
+			}
+		}
+		x.Check2(genc.buf.WriteRune('}'))
+	}
+	x.Check2(genc.buf.WriteRune(']'))
+}
+
+// completePageInfo writes the page info of a connection. The connections can only be paginated
+// forward, so there is never a previous page.
+func (genc *graphQLEncoder) completePageInfo(pageInfo gqlSchema.Field, cursors []string,
+	hasNextPage bool) {
+	comma := ""
+	x.Check2(genc.buf.WriteRune('{'))
+	for _, f := range pageInfo.SelectionSet() {
+		if f.Skip() || !f.Include() {
+			continue
+		}
+		x.Check2(genc.buf.WriteString(comma))
+		comma = ","
+		f.CompleteAlias(genc.buf)
+
+		switch {
+		case f.Name() == gqlSchema.Typename:
+			x.Check2(genc.buf.Write(getTypename(f, nil)))
+		case f.Name() == "hasNextPage":
+			x.Check2(genc.buf.WriteString(strconv.FormatBool(hasNextPage)))
+		case f.Name() == "hasPreviousPage":
+			x.Check2(genc.buf.WriteString("false"))
+		case len(cursors) == 0:
+			x.Check2(genc.buf.Write(gqlSchema.JsonNull))
+		case f.Name() == "startCursor":
+			x.Check2(genc.buf.WriteString(strconv.Quote(cursors[0])))
+		case f.Name() == "endCursor":
+			x.Check2(genc.buf.WriteString(strconv.Quote(cursors[len(cursors)-1])))
+		}
+	}
+	x.Check2(genc.buf.WriteRune('}'))
+}
+
 // completeGeoObject builds a json GraphQL result object for the underlying geo type.
 // Currently, it supports Point, Polygon and MultiPolygon.
 func completeGeoObject(path []interface{}, field gqlSchema.Field, val map[string]interface{},
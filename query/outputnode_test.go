@@ -146,6 +146,26 @@ func TestStringJsonMarshal(t *testing.T) {
 	}
 }
 
+func TestValToBytesBinary(t *testing.T) {
+	defer func(enabled bool) { x.Config.BinaryBase64 = enabled }(x.Config.BinaryBase64)
+	val := types.Val{Tid: types.BinaryID, Value: []byte("a\x00b")}
+
+	// Without binary-base64, the value is quoted as before.
+	x.Config.BinaryBase64 = false
+	b, err := valToBytes(val)
+	require.NoError(t, err)
+	require.Equal(t, `"a\x00b"`, string(b))
+
+	// With binary-base64, the value is a base64 JSON string.
+	x.Config.BinaryBase64 = true
+	b, err = valToBytes(types.Val{Tid: types.BinaryID, Value: []byte{0, 1, 0xff}})
+	require.NoError(t, err)
+	require.Equal(t, `"AAH/"`, string(b))
+	var raw []byte
+	require.NoError(t, json.Unmarshal(b, &raw))
+	require.Equal(t, []byte{0, 1, 0xff}, raw)
+}
+
 func TestFastJsonNode(t *testing.T) {
 	attrId := uint16(20)
 	scalarVal := bytes.Repeat([]byte("a"), 160)
@@ -89,7 +89,7 @@ func (b *rdfBuilder) rdfForSubgraph(sg *SubGraph) error {
 	}
 
 	for i, uid := range sg.SrcUIDs.Uids {
-		if sg.Params.IgnoreResult {
+		if sg.Params.IgnoreResult || !sg.isIncluded(uid) {
 			// Skip ignored values.
 			continue
 		}
@@ -192,7 +192,7 @@ func getObjectVal(v types.Val) ([]byte, error) {
 		return buildTriple(outputval), nil
 	case types.IntID:
 		return quotedNumber(outputval), nil
-	case types.FloatID:
+	case types.FloatID, types.DecimalID:
 		return quotedNumber(outputval), nil
 	case types.GeoID:
 		return nil, errors.New("Geo id is not supported in rdf output")
@@ -226,6 +226,10 @@ func validateSubGraphForRDF(sg *SubGraph) error {
 	if sg.SrcFunc != nil && sg.SrcFunc.Name == "checkpwd" {
 		return errors.New("chkpwd function is not supported in the rdf output format")
 	}
+	if sg.SrcFunc != nil && (sg.SrcFunc.Name == "distance" || sg.SrcFunc.Name == "relevance") {
+		return errors.Errorf("%s function is not supported in the rdf output format",
+			sg.SrcFunc.Name)
+	}
 	if sg.Params.Facet != nil && !sg.Params.ExpandAll {
 		return errors.New("facets are not supported in the rdf output format")
 	}
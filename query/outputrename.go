@@ -0,0 +1,96 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The fields of a DQL response can be renamed with a mapping given along with the query, e.g.
+// {"dgraph.type": "__typename"}, for clients expecting some field names. Every field of the
+// response with a mapped name is output under the new name, in all the blocks, including the
+// fields with a language or a facet, e.g. "name@en" or "friend|since". The names are the ones
+// of the response, so an alias is renamed too if it is mapped, and a reverse edge is renamed by
+// mapping its name with the tilde, e.g. "~friend". As JSON objects can't have duplicate keys, the
+// query fails if two fields of a block end up with the same name.
+
+// FieldRenames maps the names of the fields of a response to the names they are output under.
+type FieldRenames map[string]string
+
+// ParseFieldRenames parses a mapping of field names given as a JSON object.
+func ParseFieldRenames(s string) (FieldRenames, error) {
+	var r FieldRenames
+	if err := json.Unmarshal([]byte(s), &r); err != nil {
+		return nil, errors.Wrapf(err, "while parsing the field renames %q", s)
+	}
+	for from, to := range r {
+		if from == "" || to == "" {
+			return nil, errors.Errorf("Field renames can't use an empty name, got %q: %q",
+				from, to)
+		}
+	}
+	return r, nil
+}
+
+func fieldRenamesFromContext(ctx context.Context) FieldRenames {
+	r, _ := ctx.Value(FieldRenamesKey).(FieldRenames)
+	return r
+}
+
+// outputName returns the name the field is output under.
+func (r FieldRenames) outputName(name string) string {
+	if to, ok := r[name]; ok {
+		return to
+	}
+	// The language and the facet are only separated from the predicate in the field name.
+	if i := strings.IndexAny(name, "@"+x.FacetDelimeter); i > 0 {
+		if to, ok := r[name[:i]]; ok {
+			return to + name[i:]
+		}
+	}
+	return name
+}
+
+// check returns an error if two fields of a block would be output under the same name.
+func (r FieldRenames) check(sg *SubGraph) error {
+	names := make(map[string]string, len(sg.Children))
+	for _, child := range sg.Children {
+		name := child.fieldName()
+		out := r.outputName(name)
+		if prev, ok := names[out]; ok && prev != name {
+			return errors.Errorf("Fields %q and %q can't both be output as %q", prev, name, out)
+		}
+		names[out] = name
+		if err := r.check(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFieldRenames makes the encoder output the fields under their renamed names. It needs to be
+// called before the fields other than uid are added.
+func (enc *encoder) setFieldRenames(r FieldRenames) {
+	enc.renames = r
+	enc.idSlice[enc.uidAttr] = r.outputName("uid")
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/testutil"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func TestParseFieldRenames(t *testing.T) {
+	r, err := ParseFieldRenames(`{"dgraph.type": "__typename", "~friend": "friendOf"}`)
+	require.NoError(t, err)
+	require.Equal(t, FieldRenames{"dgraph.type": "__typename", "~friend": "friendOf"}, r)
+
+	_, err = ParseFieldRenames(`{"name": ""}`)
+	require.Error(t, err)
+	_, err = ParseFieldRenames(`["name"]`)
+	require.Error(t, err)
+}
+
+func TestFieldRenamesOutputName(t *testing.T) {
+	r := FieldRenames{"dgraph.type": "__typename", "friend": "friends", "~friend": "friendOf"}
+	tests := map[string]string{
+		"dgraph.type":   "__typename",
+		"friend":        "friends",
+		"~friend":       "friendOf",
+		"friend|since":  "friends|since",
+		"friend@en":     "friends@en",
+		"name":          "name",
+		"count(friend)": "count(friend)",
+	}
+	for name, out := range tests {
+		require.Equal(t, out, r.outputName(name), name)
+	}
+}
+
+func TestFieldRenamesCollision(t *testing.T) {
+	sg := &SubGraph{Children: []*SubGraph{{
+		Params: params{Alias: "me"},
+		Children: []*SubGraph{
+			{Attr: "dgraph.type"},
+			{Attr: "name", Params: params{Alias: "__typename"}},
+		},
+	}}}
+	require.Error(t, FieldRenames{"dgraph.type": "__typename"}.check(sg))
+	require.NoError(t, FieldRenames{"dgraph.type": "kind"}.check(sg))
+}
+
+func TestEncodeFieldRenames(t *testing.T) {
+	enc := newEncoder()
+	enc.setFieldRenames(FieldRenames{"dgraph.type": "__typename", "uid": "id"})
+
+	root := enc.newNode(0)
+	person := enc.newNode(enc.idForAttr("person"))
+	require.NoError(t, enc.AddValue(person, enc.uidAttr, types.Val{Tid: types.UidID,
+		Value: uint64(1)}))
+	require.NoError(t, enc.AddValue(person, enc.idForAttr("dgraph.type"),
+		types.Val{Tid: types.StringID, Value: "Person"}))
+	enc.AddListChild(root, person)
+
+	require.NoError(t, enc.encode(root))
+	testutil.CompareJSON(t, `{"person": [{"id": "0x1", "__typename": "Person"}]}`,
+		enc.buf.String())
+}
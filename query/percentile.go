@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+const percentileAboveFn = "percentile_above"
+
+// percentileValue returns the value as a float, so that values of all the numeric types can be
+// ranked together.
+func percentileValue(v types.Val) (float64, error) {
+	switch v.Tid {
+	case types.IntID:
+		return float64(v.Value.(int64)), nil
+	case types.FloatID:
+		return v.Value.(float64), nil
+	case types.DecimalID:
+		return v.Value.(types.Decimal).Float64(), nil
+	}
+	return 0, errors.Errorf("Function %s only supports int, float and decimal values. Got: %s",
+		percentileAboveFn, v.Tid.Name())
+}
+
+// percentileThreshold returns the smallest value in the top (100 - p) percent of vals. The
+// threshold is found using quickselect, which takes linear time on average, so that large sets
+// don't need to be sorted. The order of vals is changed.
+func percentileThreshold(vals []float64, p float64) (float64, bool) {
+	// The number of values below the threshold. The small epsilon keeps 90% of 10 values at 9.
+	k := int(math.Ceil(p*float64(len(vals))/100 - 1e-9))
+	if k < 0 {
+		k = 0
+	}
+	if k >= len(vals) {
+		return 0, false
+	}
+
+	lo, hi := 0, len(vals)-1
+	for lo < hi {
+		// Use the median of three as the pivot, so that sorted input doesn't take quadratic time.
+		mid := lo + (hi-lo)/2
+		if vals[mid] < vals[lo] {
+			vals[mid], vals[lo] = vals[lo], vals[mid]
+		}
+		if vals[hi] < vals[lo] {
+			vals[hi], vals[lo] = vals[lo], vals[hi]
+		}
+		if vals[hi] < vals[mid] {
+			vals[hi], vals[mid] = vals[mid], vals[hi]
+		}
+		pivot := vals[mid]
+
+		i, j := lo, hi
+		for i <= j {
+			for vals[i] < pivot {
+				i++
+			}
+			for vals[j] > pivot {
+				j--
+			}
+			if i <= j {
+				vals[i], vals[j] = vals[j], vals[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return vals[k], true
+		}
+	}
+	return vals[k], true
+}
+
+// applyPercentileFunc evaluates percentile_above(val(x), p). It keeps the uids whose value is in
+// the top (100 - p) percent of the values of the variable for the uids being filtered, e.g.
+// percentile_above(val(score), 90) keeps the top 10%. All the uids with the same value as the
+// threshold are kept, so the result can have more uids than the percentile when there are ties.
+func (sg *SubGraph) applyPercentileFunc() error {
+	if !sg.SrcFunc.IsValueVar {
+		return errors.Errorf("Function %s only supports value variables, e.g. "+
+			"%s(val(score), 90)", percentileAboveFn, percentileAboveFn)
+	}
+	if len(sg.SrcFunc.Args) != 1 {
+		return errors.Errorf("Function %s expects a single percentile argument",
+			percentileAboveFn)
+	}
+	p, err := strconv.ParseFloat(sg.SrcFunc.Args[0].Value, 64)
+	if err != nil || p < 0 || p > 100 {
+		return errors.Errorf("Invalid percentile %q for function %s. It must be between 0 and 100",
+			sg.SrcFunc.Args[0].Value, percentileAboveFn)
+	}
+
+	// The distribution is computed over the uids being filtered, or over all the uids of the
+	// variable when used at root.
+	uids := make([]uint64, 0, len(sg.Params.UidToVal))
+	if sg.SrcUIDs != nil {
+		for _, uid := range sg.SrcUIDs.Uids {
+			if _, ok := sg.Params.UidToVal[uid]; ok {
+				uids = append(uids, uid)
+			}
+		}
+	} else {
+		for uid := range sg.Params.UidToVal {
+			uids = append(uids, uid)
+		}
+		sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	}
+
+	vals := make([]float64, len(uids))
+	for i, uid := range uids {
+		if vals[i], err = percentileValue(sg.Params.UidToVal[uid]); err != nil {
+			return err
+		}
+	}
+	// percentileThreshold reorders the values, so select on a copy.
+	threshold, ok := percentileThreshold(append(vals[:0:0], vals...), p)
+
+	result := &pb.List{}
+	if ok {
+		for i, uid := range uids {
+			if vals[i] >= threshold {
+				result.Uids = append(result.Uids, uid)
+			}
+		}
+	}
+	sg.DestUIDs = result
+	if sg.SrcUIDs == nil {
+		sg.uidMatrix = []*pb.List{sg.DestUIDs}
+	}
+	return nil
+}
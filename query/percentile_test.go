@@ -0,0 +1,104 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+)
+
+func TestPercentileThreshold(t *testing.T) {
+	vals := []float64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5}
+	th, ok := percentileThreshold(append(vals[:0:0], vals...), 90)
+	require.True(t, ok)
+	require.Equal(t, 10.0, th)
+	th, ok = percentileThreshold(append(vals[:0:0], vals...), 0)
+	require.True(t, ok)
+	require.Equal(t, 1.0, th)
+	_, ok = percentileThreshold(append(vals[:0:0], vals...), 100)
+	require.False(t, ok)
+	_, ok = percentileThreshold(nil, 50)
+	require.False(t, ok)
+
+	// Compare with sorting for random input with many duplicates.
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 200; n++ {
+		vals := make([]float64, n)
+		for i := range vals {
+			vals[i] = float64(r.Intn(20))
+		}
+		sorted := append(vals[:0:0], vals...)
+		sort.Float64s(sorted)
+		for _, p := range []float64{0, 10, 33.3, 50, 90, 99} {
+			th, ok := percentileThreshold(append(vals[:0:0], vals...), p)
+			k := 0
+			for float64(k) < p*float64(n)/100-1e-9 {
+				k++
+			}
+			require.Equal(t, k < n, ok, "n=%d p=%v", n, p)
+			if ok {
+				require.Equal(t, sorted[k], th, "n=%d p=%v", n, p)
+			}
+		}
+	}
+}
+
+func TestApplyPercentileFunc(t *testing.T) {
+	newSg := func(arg string, src []uint64) *SubGraph {
+		sg := &SubGraph{
+			SrcFunc: &Function{Name: percentileAboveFn, IsValueVar: true,
+				Args: []gql.Arg{{Value: arg}}},
+			Params: params{UidToVal: map[uint64]types.Val{
+				1: {Tid: types.IntID, Value: int64(15)},
+				2: {Tid: types.IntID, Value: int64(15)},
+				3: {Tid: types.IntID, Value: int64(17)},
+				4: {Tid: types.IntID, Value: int64(19)},
+			}},
+		}
+		if src != nil {
+			sg.SrcUIDs = &pb.List{Uids: src}
+		}
+		return sg
+	}
+
+	sg := newSg("75", nil)
+	require.NoError(t, sg.applyPercentileFunc())
+	require.Equal(t, []uint64{4}, sg.DestUIDs.Uids)
+
+	// Ties at the threshold are kept.
+	sg = newSg("25", nil)
+	require.NoError(t, sg.applyPercentileFunc())
+	require.Equal(t, []uint64{1, 2, 3, 4}, sg.DestUIDs.Uids)
+
+	// As a filter, the distribution is computed over the uids being filtered.
+	sg = newSg("50", []uint64{1, 2, 3, 5})
+	require.NoError(t, sg.applyPercentileFunc())
+	require.Equal(t, []uint64{3}, sg.DestUIDs.Uids)
+
+	require.Error(t, newSg("101", nil).applyPercentileFunc())
+	require.Error(t, newSg("abc", nil).applyPercentileFunc())
+	sg = newSg("50", nil)
+	sg.Params.UidToVal[5] = types.Val{Tid: types.StringID, Value: "a"}
+	require.Error(t, sg.applyPercentileFunc())
+}
@@ -162,6 +162,39 @@ type params struct {
 	Cascade *CascadeArgs
 	// IgnoreReflex is true if the @ignorereflex directive is specified.
 	IgnoreReflex bool
+	// TotalCount is true if the @totalcount directive is specified.
+	TotalCount bool
+	// Include stores the arguments passed to the @include or @skip directive.
+	Include *gql.IncludeArgs
+	// Dedup stores the arguments passed to the @dedup directive.
+	Dedup *gql.DedupArgs
+	// Intersect stores the arguments passed to the @intersect directive. It is set on the
+	// SubGraph of the filter of the block.
+	Intersect *gql.IntersectArgs
+	// DefaultFirst is true if the results of a root block are limited by --limit default-first,
+	// as no pagination is specified for it. One more result is fetched to know if there are more.
+	DefaultFirst bool
+	// ProjectType is true if the "projectType" argument is set. The predicates of the types of
+	// each node are then returned for it, without being listed in the query.
+	ProjectType bool
+	// NestFacets is true if the "nestFacets" argument is set at root. The facets are then
+	// returned as an object under the @facets key instead of as pred|facet keys.
+	NestFacets bool
+	// IncludeDeleted is true if the "includeDeleted" argument is set at root. The nodes of the
+	// @softDelete types marked as deleted are then returned.
+	IncludeDeleted bool
+	// softDelete holds the filter leaving out the nodes marked as deleted, it is nil if no type
+	// of the namespace is declared with @softDelete or if IncludeDeleted is set.
+	softDelete *softDelete
+	// format holds the formatting of the values of a root block set by the "datetimeFormat",
+	// "timezone" and "floatPrecision" arguments, it is nil if none of them is set.
+	format *valueFormat
+	// Sample is the value of the "sample" argument at root. The results of the root are then a
+	// random sample of this many of the nodes matching the function and the filters.
+	Sample int
+	// SampleSeed is the value of the "seed" argument, or a random seed if it isn't set. The same
+	// seed gives the same sample of the same data.
+	SampleSeed int64
 
 	// ShortestPathArgs contains the from and to functions to execute a shortest path query.
 	ShortestPathArgs gql.ShortestPathArgs
@@ -184,6 +217,9 @@ type params struct {
 	IsInternal bool
 	// IgnoreResult is true if the node results are to be ignored.
 	IgnoreResult bool
+	// Split is the separator of split(attr, sep) in @groupby, the values of the attribute are
+	// then grouped by each of their tokens.
+	Split string
 	// Expand holds the argument passed to the expand function.
 	Expand string
 
@@ -191,6 +227,8 @@ type params struct {
 	IsGroupBy bool // True if @groupby is specified.
 	// GroupbyAttrs holds the list of attributes to group by.
 	GroupbyAttrs []gql.GroupByAttr
+	// GroupbyPage holds the order and the pagination of the groups, if given.
+	GroupbyPage *gql.GroupByPage
 
 	// ParentIds is a stack that is maintained and passed down to children.
 	ParentIds []uint64
@@ -225,6 +263,9 @@ type Function struct {
 	IsCount    bool      // gt(count(friends),0)
 	IsValueVar bool      // eq(val(s), 10)
 	IsLenVar   bool      // eq(len(s), 10)
+	Coerce     string    // gt(toint(val(s)), 10)
+	Split      string    // eq(split(val(s), ","), "a")
+	Limit      int       // has(name)@limit(1000)
 }
 
 // SubGraph is the way to represent data. It contains both the request parameters and the response.
@@ -254,6 +295,7 @@ type Function struct {
 type SubGraph struct {
 	ReadTs      uint64
 	Cache       int
+	TxnStartTs  uint64
 	Attr        string
 	UnknownAttr bool
 	// read only parameters which are populated before the execution of the query and are used to
@@ -298,6 +340,18 @@ type SubGraph struct {
 	List     bool // whether predicate is of list type
 
 	pathMeta *pathMetadata
+
+	// included stores the uids of the parent level for which this SubGraph is part of the
+	// output. It is only populated if the @include or @skip directive is specified, or for the
+	// predicates added by projectType, which are only returned for the nodes of their types.
+	included map[uint64]struct{}
+
+	// totalCount is the number of results of a root block before pagination. It is only
+	// populated if the @totalcount directive is specified.
+	totalCount int
+
+	// truncated is true if some results of a root block were left out by --limit default-first.
+	truncated bool
 }
 
 func (sg *SubGraph) recurse(set func(sg *SubGraph)) {
@@ -310,6 +364,112 @@ func (sg *SubGraph) recurse(set func(sg *SubGraph)) {
 	}
 }
 
+// populateIncludes evaluates the condition of the @include and @skip directives specified in the
+// children of this SubGraph, once all the variables have been populated.
+func (sg *SubGraph) populateIncludes(doneVars map[string]varValue) error {
+	for _, child := range sg.Children {
+		if inc := child.Params.Include; inc != nil {
+			child.included = make(map[uint64]struct{})
+			vals := doneVars[inc.Var].Vals
+			for _, uid := range child.SrcUIDs.GetUids() {
+				if inc.UidVar {
+					// The condition holds for the uids of the variable.
+					if found := algo.IndexOf(doneVars[inc.Var].Uids, uid) >= 0; found != inc.Skip {
+						child.included[uid] = struct{}{}
+					}
+					continue
+				}
+				// The condition is false for uids for which the variable doesn't have a value.
+				cond := false
+				if v, ok := vals[uid]; ok && v.Value != nil {
+					b, ok := v.Value.(bool)
+					if v.Tid != types.BoolID || !ok {
+						return errors.Errorf("Variable %s used in @include or @skip should be of "+
+							"type bool, got %s", inc.Var, v.Tid.Name())
+					}
+					cond = b
+				}
+				if cond != inc.Skip {
+					child.included[uid] = struct{}{}
+				}
+			}
+		}
+		if err := child.populateIncludes(doneVars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isIncluded returns whether the SubGraph is part of the output for the given uid of its parent.
+func (sg *SubGraph) isIncluded(uid uint64) bool {
+	if sg.Params.Include == nil && sg.included == nil {
+		return true
+	}
+	_, ok := sg.included[uid]
+	return ok
+}
+
+// populateDedup removes the results of this SubGraph and of its children whose key was already
+// seen, as specified by the @dedup directive. The key is either the uid or the value of the
+// variable for the uid, and results without a value are kept. The lists are visited in the order
+// of the uids of the parent, and each list in its sort order, so the first result is kept.
+func (sg *SubGraph) populateDedup(doneVars map[string]varValue) {
+	if dd := sg.Params.Dedup; dd != nil && len(sg.uidMatrix) > 0 {
+		vals := doneVars[dd.Var].Vals
+		seen := make(map[string]struct{})
+		kept := make(map[uint64]struct{})
+		keep := func(uid uint64) bool {
+			key := strconv.FormatUint(uid, 10)
+			if dd.Var != "" {
+				v, ok := vals[uid]
+				if !ok || v.Value == nil {
+					return true
+				}
+				key = fmt.Sprintf("%d/%v", v.Tid, v.Value)
+			}
+			if _, ok := seen[key]; ok {
+				return false
+			}
+			seen[key] = struct{}{}
+			return true
+		}
+
+		for i, l := range sg.uidMatrix {
+			hasFacets := i < len(sg.facetsMatrix) &&
+				len(sg.facetsMatrix[i].FacetsList) == len(l.Uids)
+			uids := make([]uint64, 0, len(l.Uids))
+			var fl []*pb.Facets
+			for j, uid := range l.Uids {
+				if !keep(uid) {
+					continue
+				}
+				uids = append(uids, uid)
+				kept[uid] = struct{}{}
+				if hasFacets {
+					fl = append(fl, sg.facetsMatrix[i].FacetsList[j])
+				}
+			}
+			// The lists are replaced instead of being updated as they can be shared, e.g. the
+			// list of the root is also its DestUIDs, which are the SrcUIDs of its children.
+			sg.uidMatrix[i] = &pb.List{Uids: uids}
+			if hasFacets {
+				sg.facetsMatrix[i] = &pb.FacetsList{FacetsList: fl}
+			}
+		}
+		dest := make([]uint64, 0, len(kept))
+		for _, uid := range sg.DestUIDs.GetUids() {
+			if _, ok := kept[uid]; ok {
+				dest = append(dest, uid)
+			}
+		}
+		sg.DestUIDs = &pb.List{Uids: dest}
+	}
+	for _, child := range sg.Children {
+		child.populateDedup(doneVars)
+	}
+}
+
 // IsGroupBy returns whether this subgraph is part of a groupBy query.
 func (sg *SubGraph) IsGroupBy() bool {
 	return sg.Params.IsGroupBy
@@ -331,6 +491,9 @@ func (sg *SubGraph) createSrcFunction(gf *gql.Function) {
 		IsCount:    gf.IsCount,
 		IsValueVar: gf.IsValueVar,
 		IsLenVar:   gf.IsLenVar,
+		Coerce:     gf.Coerce,
+		Split:      gf.Split,
+		Limit:      gf.Limit,
 	}
 
 	// type function is just an alias for eq(type, "dgraph.type").
@@ -342,6 +505,11 @@ func (sg *SubGraph) createSrcFunction(gf *gql.Function) {
 		sg.SrcFunc.IsLenVar = false
 		return
 	}
+	// in_set reads the members of a uid set.
+	if gf.Name == inSetFn {
+		sg.Attr = uidSetMembers
+		return
+	}
 
 	if gf.Lang != "" {
 		sg.Params.Langs = append(sg.Params.Langs, gf.Lang)
@@ -488,9 +656,53 @@ func filterCopy(sg *SubGraph, ft *gql.FilterTree) error {
 		}
 		sg.Filters = append(sg.Filters, child)
 	}
+	if attr := negatedEqAttr(ft); attr != "" {
+		// The nodes which don't have the predicate aren't returned by not eq on an indexed
+		// predicate, so the filter is run along with has, see the not case of ProcessGraph. The
+		// has filter is dropped there if the predicate isn't indexed.
+		has := &SubGraph{Attr: attr}
+		has.createSrcFunction(&gql.Function{Name: "has", Attr: attr})
+		sg.Filters = append(sg.Filters, has)
+	}
 	return nil
 }
 
+// isFilterOf returns true if the subgraph is one of the filters of parent.
+func (sg *SubGraph) isFilterOf(parent *SubGraph) bool {
+	if parent == nil {
+		return false
+	}
+	for _, filter := range parent.Filters {
+		if filter == sg {
+			return true
+		}
+	}
+	return false
+}
+
+// isIndexed returns true if the predicate of the subgraph is indexed.
+func (sg *SubGraph) isIndexed(ctx context.Context) bool {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return false
+	}
+	return schema.State().IsIndexed(ctx, x.NamespaceAttr(ns, sg.Attr))
+}
+
+// negatedEqAttr returns the predicate of ft if it is a not eq filter on a predicate, e.g.
+// not eq(status, "active"), and an empty string otherwise.
+func negatedEqAttr(ft *gql.FilterTree) string {
+	if ft.Op != "not" || len(ft.Child) != 1 || ft.Child[0].Func == nil {
+		return ""
+	}
+	f := ft.Child[0].Func
+	if f.Name != "eq" || f.Attr == "" || f.Attr == "uid" || f.IsCount || f.IsValueVar ||
+		f.IsLenVar || len(f.NeedsVar) > 0 {
+		return ""
+	}
+	return f.Attr
+}
+
 func uniqueKey(gchild *gql.GraphQuery) string {
 	key := gchild.Attr
 	if gchild.Func != nil {
@@ -505,6 +717,9 @@ func uniqueKey(gchild *gql.GraphQuery) string {
 		case len(gchild.NeedsVar) > 0:
 			// For var(s)
 			key = fmt.Sprintf("val(%v)", gchild.NeedsVar[0].Name)
+			if len(gchild.NeedsVar) > 1 {
+				key += fmt.Sprintf("val(%v)", gchild.NeedsVar[1].Name)
+			}
 		}
 
 		// Could be min(var(x)) && max(var(x))
@@ -561,15 +776,22 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 			FacetVar:     gchild.FacetVar,
 			GetUid:       sg.Params.GetUid,
 			IgnoreReflex: sg.Params.IgnoreReflex,
+			NestFacets:   sg.Params.NestFacets,
+			softDelete:   sg.Params.softDelete,
+			// The predicates expanded at any level are restricted by ACL.
+			AllowedPreds: sg.Params.AllowedPreds,
 			Langs:        gchild.Langs,
 			NeedsVar:     append(gchild.NeedsVar[:0:0], gchild.NeedsVar...),
 			Normalize:    gchild.Normalize || sg.Params.Normalize,
 			Order:        gchild.Order,
 			Var:          gchild.Var,
 			GroupbyAttrs: gchild.GroupbyAttrs,
+			GroupbyPage:  gchild.GroupbyPage,
 			IsGroupBy:    gchild.IsGroupby,
 			IsInternal:   gchild.IsInternal,
 			Cascade:      &CascadeArgs{},
+			Include:      gchild.Include,
+			Dedup:        gchild.Dedup,
 		}
 
 		// Inherit from the parent.
@@ -620,7 +842,8 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 		}
 
 		if gchild.Func != nil &&
-			(gchild.Func.IsAggregator() || gchild.Func.IsPasswordVerifier()) {
+			(gchild.Func.IsAggregator() || gchild.Func.IsPasswordVerifier() ||
+				gchild.Func.IsGeoDistance() || gchild.Func.IsRelevance()) {
 			if len(gchild.Children) != 0 {
 				return errors.Errorf("Node with %q cant have child attr", gchild.Func.Name)
 			}
@@ -644,8 +867,14 @@ func treeCopy(gq *gql.GraphQuery, sg *SubGraph) error {
 			if err := filterCopy(dstf, gchild.Filter); err != nil {
 				return err
 			}
+			dstf.Params.Intersect = gchild.Intersect
 			dst.Filters = append(dst.Filters, dstf)
 		}
+		if !gchild.IsInternal && args.softDelete.appliesTo(gchild.Attr) {
+			if err := dst.addSoftDeleteFilter(); err != nil {
+				return err
+			}
+		}
 
 		if gchild.FacetsFilter != nil {
 			facetsFilter, err := toFacetsFilter(gchild.FacetsFilter)
@@ -741,6 +970,50 @@ func (args *params) fill(gq *gql.GraphQuery) error {
 		}
 		args.Count = int(first)
 	}
+	if v, ok := gq.Args["projectType"]; ok {
+		project, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Errorf("projectType should be true or false, got: %s", v)
+		}
+		args.ProjectType = project
+	}
+	if v, ok := gq.Args["nestFacets"]; ok {
+		nest, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Errorf("nestFacets should be true or false, got: %s", v)
+		}
+		args.NestFacets = nest
+	}
+	if v, ok := gq.Args["includeDeleted"]; ok {
+		include, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.Errorf("includeDeleted should be true or false, got: %s", v)
+		}
+		args.IncludeDeleted = include
+	}
+	format, err := parseValueFormat(gq.Args)
+	if err != nil {
+		return err
+	}
+	args.format = format
+	if v, ok := gq.Args["sample"]; ok {
+		sample, err := strconv.ParseInt(v, 0, 32)
+		if err != nil || sample <= 0 {
+			return errors.Errorf("sample should be a positive integer, got: %s", v)
+		}
+		args.Sample = int(sample)
+		args.SampleSeed = time.Now().UnixNano()
+	}
+	if v, ok := gq.Args["seed"]; ok {
+		if args.Sample == 0 {
+			return errors.Errorf("seed can only be used along with sample")
+		}
+		seed, err := strconv.ParseInt(v, 0, 64)
+		if err != nil {
+			return errors.Errorf("seed should be an integer, got: %s", v)
+		}
+		args.SampleSeed = seed
+	}
 	return nil
 }
 
@@ -763,6 +1036,18 @@ type ContextKey int
 const (
 	// DebugKey is the key used to toggle debug mode.
 	DebugKey ContextKey = iota
+	// MsgpackKey is the key used to request the results of a query in MessagePack.
+	MsgpackKey
+	// FieldRenamesKey is the key used to rename the fields of the response, see FieldRenames.
+	FieldRenamesKey
+	// StrictUidsKey is the key used to report the uids of the uid function which don't have
+	// any data, see MissingUids.
+	StrictUidsKey
+	// TruncatedKey is the key used to report the root blocks limited by --limit default-first,
+	// see Truncated.
+	TruncatedKey
+	// memoryAccountKey is the key used to store the memoryAccount of the request.
+	memoryAccountKey
 )
 
 func isDebug(ctx context.Context) bool {
@@ -805,6 +1090,7 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		GetUid:           isDebug(ctx),
 		IgnoreReflex:     gq.IgnoreReflex,
 		IsEmpty:          gq.IsEmpty,
+		TotalCount:       gq.TotalCount,
 		Langs:            gq.Langs,
 		NeedsVar:         append(gq.NeedsVar[:0:0], gq.NeedsVar...),
 		Normalize:        gq.Normalize,
@@ -815,8 +1101,10 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		ShortestPathArgs: gq.ShortestPathArgs,
 		Var:              gq.Var,
 		GroupbyAttrs:     gq.GroupbyAttrs,
+		GroupbyPage:      gq.GroupbyPage,
 		IsGroupBy:        gq.IsGroupby,
 		AllowedPreds:     gq.AllowedPreds,
+		Dedup:            gq.Dedup,
 	}
 
 	// Remove pagination arguments from the query if @cascade is mentioned since
@@ -833,6 +1121,10 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 	if err := args.fill(gq); err != nil {
 		return nil, errors.Wrapf(err, "while filling args")
 	}
+	if ns, err := x.ExtractNamespace(ctx); err == nil && !args.IncludeDeleted &&
+		gq.Alias != "shortest" {
+		args.softDelete = newSoftDelete(ns)
+	}
 
 	sg := &SubGraph{Params: args}
 
@@ -865,8 +1157,14 @@ func newGraph(ctx context.Context, gq *gql.GraphQuery) (*SubGraph, error) {
 		if err := filterCopy(sgf, gq.Filter); err != nil {
 			return nil, errors.Wrapf(err, "while copying filter")
 		}
+		sgf.Params.Intersect = gq.Intersect
 		sg.Filters = append(sg.Filters, sgf)
 	}
+	if !gq.IsEmpty {
+		if err := sg.addSoftDeleteFilter(); err != nil {
+			return nil, err
+		}
+	}
 	if gq.FacetsFilter != nil {
 		facetsFilter, err := toFacetsFilter(gq.FacetsFilter)
 		if err != nil {
@@ -937,15 +1235,21 @@ func createTaskQuery(ctx context.Context, sg *SubGraph) (*pb.Query, error) {
 
 	// first is to limit how many results we want.
 	first, offset := calculatePaginationParams(sg)
+	afterUid := sg.Params.AfterUID
+	if sg.isOrdered() {
+		// The results after the uid are picked once they are ordered.
+		afterUid = 0
+	}
 
 	out := &pb.Query{
 		ReadTs:       sg.ReadTs,
 		Cache:        int32(sg.Cache),
+		TxnStartTs:   sg.TxnStartTs,
 		Attr:         x.NamespaceAttr(namespace, attr),
 		Langs:        sg.Params.Langs,
 		Reverse:      reverse,
 		SrcFunc:      srcFunc,
-		AfterUid:     sg.Params.AfterUID,
+		AfterUid:     afterUid,
 		DoCount:      len(sg.Filters) == 0 && sg.Params.DoCount,
 		FacetParam:   sg.Params.Facet,
 		FacetsFilter: sg.facetsFilter,
@@ -953,6 +1257,17 @@ func createTaskQuery(ctx context.Context, sg *SubGraph) (*pb.Query, error) {
 		First:        first,
 		Offset:       offset,
 	}
+	if sg.Params.Sample > 0 && len(sg.Filters) == 0 && sg.SrcFunc != nil &&
+		!sg.Params.TotalCount {
+		// The worker only sends back the sample of the uids of the function, has doesn't even
+		// keep the others while iterating over the predicate. The sample is taken again in
+		// ProcessGraph, which keeps all the uids of a sample.
+		out.Sample = int32(sg.Params.Sample)
+		out.SampleSeed = sg.Params.SampleSeed
+	}
+	if sg.SrcFunc != nil {
+		out.FuncLimit = int32(sg.SrcFunc.Limit)
+	}
 
 	if sg.SrcUIDs != nil {
 		out.UidList = sg.SrcUIDs
@@ -973,15 +1288,17 @@ func calculatePaginationParams(sg *SubGraph) (int32, int32) {
 	//     father
 	//   }
 	// }
-	// - No Ordering (We need all the results to do the sorting)
+	// - No Ordering (We need all the results to do the sorting), including ordering by facets
 	// {
 	//   q(func: has(name), first:1, orderasc: name) {
 	//     name
+	//     friend @facets(orderdesc: weight) (first: 1) { name }
 	//   }
 	// }
 	// - should not be one of those function which fetches some results and then do further
 	// processing to narrow down the result. For example: allofterm will fetch the index postings
 	// for each term and then do an intersection.
+	// - No @totalcount (We need all the results to count them)
 	// TODO: Look into how we can optimize queries involving these functions.
 
 	shouldExclude := false
@@ -994,7 +1311,9 @@ func calculatePaginationParams(sg *SubGraph) (int32, int32) {
 		}
 	}
 
-	if len(sg.Filters) == 0 && len(sg.Params.Order) == 0 && !shouldExclude {
+	// - No sample (The pagination applies to the sample)
+	if len(sg.Filters) == 0 && len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 &&
+		!shouldExclude && !sg.Params.TotalCount && sg.Params.Sample == 0 {
 		if sg.Params.Count != 0 {
 			return int32(sg.Params.Count), int32(sg.Params.Offset)
 		}
@@ -1013,6 +1332,15 @@ type varValue struct {
 	// strList stores the valueMatrix corresponding to a predicate and is later used in
 	// expand(val(x)) query.
 	strList []*pb.ValueList
+	// edgeVals stores the values of a facet variable for each edge having the facet, as Vals
+	// holds a single value per node. They are aggregated at the root of a query.
+	edgeVals []edgeVal
+}
+
+// edgeVal is the value of a facet of the edge from the node src to the node dst.
+type edgeVal struct {
+	src, dst uint64
+	val      types.Val
 }
 
 func evalLevelAgg(
@@ -1025,16 +1353,28 @@ func evalLevelAgg(
 	}
 
 	needsVar := sg.Params.NeedsVar[0].Name
+	var weightVar string
+	if len(sg.Params.NeedsVar) > 1 {
+		weightVar = sg.Params.NeedsVar[1].Name
+	}
 	if parent.Params.IsEmpty {
+		if weightVar != "" {
+			return evalWeightedAvg(doneVars[needsVar], doneVars[weightVar])
+		}
 		// The aggregated value doesn't really belong to a uid, we put it in UidToVal map
 		// corresponding to uid 0 to avoid defining another field in SubGraph.
-		vals := doneVars[needsVar].Vals
-
 		ag := aggregator{
 			name: sg.SrcFunc.Name,
 		}
-		for _, val := range vals {
-			ag.Apply(val)
+		if vv := doneVars[needsVar]; len(vv.edgeVals) > 0 {
+			// A facet variable is aggregated over the edges, as for the sibling nodes below.
+			for _, ev := range vv.edgeVals {
+				ag.Apply(ev.val)
+			}
+		} else {
+			for _, val := range vv.Vals {
+				ag.Apply(val)
+			}
 		}
 		v, err := ag.Value()
 		if err != nil && err != ErrEmptyVal {
@@ -1048,25 +1388,32 @@ func evalLevelAgg(
 	}
 
 	var relSG *SubGraph
+	var facetKey string
 	for _, ch := range parent.Children {
 		if sg == ch {
 			continue
 		}
-		for _, v := range ch.Params.FacetVar {
+		for key, v := range ch.Params.FacetVar {
 			if v == needsVar {
-				relSG = ch
+				relSG, facetKey = ch, key
 			}
 		}
 		for _, cch := range ch.Children {
 			// Find the sibling node whose child has the required variable.
 			if cch.Params.Var == needsVar {
-				relSG = ch
+				relSG, facetKey = ch, ""
 			}
 		}
 	}
 	if relSG == nil {
 		return nil, errors.Errorf("Invalid variable aggregation. Check the levels.")
 	}
+	if weightVar != "" {
+		return evalLevelWeightedAvg(doneVars, relSG, facetKey, needsVar, weightVar)
+	}
+	if facetKey != "" {
+		return evalFacetAgg(relSG, facetKey, sg.SrcFunc.Name)
+	}
 
 	vals := doneVars[needsVar].Vals
 	mp = make(map[uint64]types.Val)
@@ -1091,6 +1438,150 @@ func evalLevelAgg(
 	return mp, nil
 }
 
+// evalFacetAgg aggregates the values of the facet key over the edges of each node of sg. The
+// value of a facet variable for a node is the sum of the facets of the edges pointing to it, which
+// would be counted for every node pointing to it, so the facets of the edges are read instead.
+// The edges without the facet aren't aggregated, so they don't count towards an average.
+func evalFacetAgg(sg *SubGraph, key, fn string) (map[uint64]types.Val, error) {
+	mp := make(map[uint64]types.Val)
+	for i := range sg.uidMatrix {
+		if i >= len(sg.facetsMatrix) {
+			break
+		}
+		ag := aggregator{
+			name: fn,
+		}
+		for _, fs := range sg.facetsMatrix[i].FacetsList {
+			for _, f := range fs.Facets {
+				if f.Key != key {
+					continue
+				}
+				val, err := facets.ValFor(f)
+				if err != nil {
+					return nil, err
+				}
+				ag.Apply(val)
+			}
+		}
+		v, err := ag.Value()
+		if err != nil && err != ErrEmptyVal {
+			return nil, err
+		}
+		if v.Value != nil {
+			mp[sg.SrcUIDs.Uids[i]] = v
+		}
+	}
+	return mp, nil
+}
+
+// evalWeightedAvg computes the average of the values of vv weighted by the values of wv, over all
+// the edges for facet variables and over all the nodes otherwise. The edges and nodes missing
+// either value are left out.
+func evalWeightedAvg(vv, wv varValue) (map[uint64]types.Val, error) {
+	var wa weightedAvg
+	if len(vv.edgeVals) > 0 || len(wv.edgeVals) > 0 {
+		weights := make(map[[2]uint64]types.Val, len(wv.edgeVals))
+		for _, ev := range wv.edgeVals {
+			weights[[2]uint64{ev.src, ev.dst}] = ev.val
+		}
+		for _, ev := range vv.edgeVals {
+			if w, ok := weights[[2]uint64{ev.src, ev.dst}]; ok {
+				if err := wa.apply(ev.val, w); err != nil {
+					return nil, err
+				}
+			}
+		}
+	} else {
+		for uid, val := range vv.Vals {
+			if w, ok := wv.Vals[uid]; ok {
+				if err := wa.apply(val, w); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	v, err := wa.value()
+	if err == ErrEmptyVal {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[uint64]types.Val{0: v}, nil
+}
+
+// evalLevelWeightedAvg computes the weighted average of needsVar for each node of sg, over the
+// edges of the node if they have the facet key, and over the children of the node otherwise. The
+// weights have to be at the same level as the values.
+func evalLevelWeightedAvg(doneVars map[string]varValue, sg *SubGraph,
+	key, needsVar, weightVar string) (map[uint64]types.Val, error) {
+	var weightKey string
+	for k, v := range sg.Params.FacetVar {
+		if v == weightVar {
+			weightKey = k
+		}
+	}
+	if (key == "") != (weightKey == "") {
+		return nil, errors.Errorf("The weights %s of avg should be a variable of the same"+
+			" level as %s", weightVar, needsVar)
+	}
+
+	mp := make(map[uint64]types.Val)
+	for i, list := range sg.uidMatrix {
+		var wa weightedAvg
+		if key != "" {
+			if i >= len(sg.facetsMatrix) {
+				break
+			}
+			for _, fs := range sg.facetsMatrix[i].FacetsList {
+				var v, w types.Val
+				var ok, wok bool
+				for _, f := range fs.Facets {
+					if f.Key != key && f.Key != weightKey {
+						continue
+					}
+					val, err := facets.ValFor(f)
+					if err != nil {
+						return nil, err
+					}
+					if f.Key == key {
+						v, ok = val, true
+					} else {
+						w, wok = val, true
+					}
+				}
+				if !ok || !wok {
+					continue
+				}
+				if err := wa.apply(v, w); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			vals, weights := doneVars[needsVar].Vals, doneVars[weightVar].Vals
+			for _, uid := range list.Uids {
+				v, ok := vals[uid]
+				w, wok := weights[uid]
+				if !ok || !wok {
+					continue
+				}
+				if err := wa.apply(v, w); err != nil {
+					return nil, err
+				}
+			}
+		}
+		v, err := wa.value()
+		if err == ErrEmptyVal {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		mp[sg.SrcUIDs.Uids[i]] = v
+	}
+	return mp, nil
+}
+
 func (mt *mathTree) extractVarNodes() []*mathTree {
 	var nodeList []*mathTree
 	for _, ch := range mt.Child {
@@ -1136,8 +1627,9 @@ func (fromNode *varValue) transformTo(toPath []*SubGraph) (map[uint64]types.Val,
 			if !ok || curVal.Value == nil {
 				continue
 			}
-			if curVal.Tid != types.IntID && curVal.Tid != types.FloatID {
-				return nil, errors.Errorf("Encountered non int/float type for summing")
+			if curVal.Tid != types.IntID && curVal.Tid != types.FloatID &&
+				curVal.Tid != types.DecimalID {
+				return nil, errors.Errorf("Encountered non int/float/decimal type for summing")
 			}
 			for j := 0; j < len(ul.Uids); j++ {
 				dstUid := ul.Uids[j]
@@ -1159,6 +1651,9 @@ func (fromNode *varValue) transformTo(toPath []*SubGraph) (map[uint64]types.Val,
 // transformVars transforms all the variables to the variable at the lowest level
 func (sg *SubGraph) transformVars(doneVars map[string]varValue, path []*SubGraph) error {
 	mNode := sg.MathExp
+	if err := checkCoalesceVars(mNode, doneVars); err != nil {
+		return err
+	}
 	mvarList := mNode.extractVarNodes()
 	for i := 0; i < len(mvarList); i++ {
 		mt := mvarList[i]
@@ -1180,6 +1675,26 @@ func (sg *SubGraph) transformVars(doneVars map[string]varValue, path []*SubGraph
 	return nil
 }
 
+// checkCoalesceVars makes sure that the arguments of coalesce are value variables. A uid variable
+// doesn't have a value for any uid, so coalescing it would always pick the default.
+func checkCoalesceVars(mNode *mathTree, doneVars map[string]varValue) error {
+	if mNode == nil {
+		return nil
+	}
+	for _, child := range mNode.Child {
+		if isCoalesce(mNode.Fn) && child.Var != "" {
+			if v := doneVars[child.Var]; v.Uids != nil && len(v.Vals) == 0 {
+				return errors.Errorf("Function %s expects a value variable, but %s is a uid "+
+					"variable", mNode.Fn, child.Var)
+			}
+		}
+		if err := checkCoalesceVars(child, doneVars); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (sg *SubGraph) valueVarAggregation(doneVars map[string]varValue, path []*SubGraph,
 	parent *SubGraph) error {
 	if !sg.IsInternal() && !sg.IsGroupBy() && !sg.Params.IsEmpty {
@@ -1215,7 +1730,11 @@ func (sg *SubGraph) valueVarAggregation(doneVars map[string]varValue, path []*Su
 			return err
 		}
 
-		err = evalMathTree(sg.MathExp)
+		rangeOver := sg.SrcUIDs
+		if parent == nil {
+			rangeOver = sg.DestUIDs
+		}
+		err = evalMathTree(sg.MathExp, rangeOver.GetUids())
 		if err != nil {
 			return err
 		}
@@ -1250,10 +1769,6 @@ func (sg *SubGraph) valueVarAggregation(doneVars map[string]varValue, path []*Su
 		case sg.MathExp.Const.Value != nil:
 			// Assign the const for all the srcUids.
 			mp := make(map[uint64]types.Val)
-			rangeOver := sg.SrcUIDs
-			if parent == nil {
-				rangeOver = sg.DestUIDs
-			}
 			if rangeOver == nil {
 				it := doneVars[sg.Params.Var]
 				it.Vals = mp
@@ -1609,21 +2124,20 @@ func (sg *SubGraph) populateFacetVars(doneVars map[string]varValue, sgPath []*Su
 				if !ok {
 					continue
 				}
-				if pVal, ok := doneVars[fvar].Vals[uid]; !ok {
-					fVal, err := facets.ValFor(f)
-					if err != nil {
-						return err
-					}
+				nVal, err := facets.ValFor(f)
+				if err != nil {
+					return err
+				}
+				vv := doneVars[fvar]
+				vv.edgeVals = append(vv.edgeVals,
+					edgeVal{src: sg.SrcUIDs.Uids[i], dst: uid, val: nVal})
+				doneVars[fvar] = vv
 
-					doneVars[fvar].Vals[uid] = fVal
+				if pVal, ok := vv.Vals[uid]; !ok {
+					vv.Vals[uid] = nVal
 				} else {
 					// If the value is int/float we add them up. Else we throw an error as
 					// many to one maps are not allowed for other types.
-					nVal, err := facets.ValFor(f)
-					if err != nil {
-						return err
-					}
-
 					if nVal.Tid != types.IntID && nVal.Tid != types.FloatID {
 						return errors.Errorf("Repeated id with non int/float value for " +
 							"facet var encountered.")
@@ -1635,7 +2149,7 @@ func (sg *SubGraph) populateFacetVars(doneVars map[string]varValue, sgPath []*Su
 					if err != nil {
 						continue
 					}
-					doneVars[fvar].Vals[uid] = fVal
+					vv.Vals[uid] = fVal
 				}
 			}
 		}
@@ -1739,6 +2253,20 @@ func (sg *SubGraph) fillVars(mp map[string]varValue) error {
 			}
 			sg.SrcFunc.Args = srcFuncArgs
 
+		case v.Typ == gql.UidVar && sg.SrcFunc != nil && sg.SrcFunc.Name == reachableFn:
+			// The variable is replaced by its uids in the seeds of reachable(pred, uid(x), depth).
+			args := sg.SrcFunc.Args[:0:0]
+			for _, arg := range sg.SrcFunc.Args {
+				if arg.Value != v.Name {
+					args = append(args, arg)
+					continue
+				}
+				for _, uid := range l.Uids.GetUids() {
+					args = append(args, gql.Arg{Value: strconv.FormatUint(uid, 10)})
+				}
+			}
+			sg.SrcFunc.Args = args
+
 		case (v.Typ == gql.AnyVar || v.Typ == gql.UidVar) && l.Uids != nil:
 			lists = append(lists, l.Uids)
 
@@ -1825,7 +2353,11 @@ func (sg *SubGraph) replaceVarInFunc() error {
 // The function filters uids corresponding to the variable which satisfy the inequality and stores
 // the filtered uids in DestUIDs.
 func (sg *SubGraph) applyIneqFunc() error {
-	if len(sg.Params.UidToVal) == 0 {
+	uidToVal := sg.Params.UidToVal
+	if sg.SrcFunc.Coerce != "" {
+		uidToVal = coerceVals(sg.SrcFunc.Coerce, uidToVal)
+	}
+	if len(uidToVal) == 0 {
 		// Expected a valid value map. But got empty.
 		// Don't return error, return empty - issue #2610
 		return nil
@@ -1835,29 +2367,46 @@ func (sg *SubGraph) applyIneqFunc() error {
 	// Find out the type of value using the first value in the map and try to convert the function
 	// argument to that type to make sure we can compare them. If we can't return an error.
 	var typ types.TypeID
-	for _, v := range sg.Params.UidToVal {
+	for _, v := range uidToVal {
 		typ = v.Tid
 		break
 	}
+	if sg.SrcFunc.Split != "" {
+		typ = types.StringID
+	}
 	val := sg.SrcFunc.Args[0].Value
 	src := types.Val{Tid: types.StringID, Value: []byte(val)}
 	dst, err := types.Convert(src, typ)
 	if err != nil {
 		return errors.Errorf("Invalid argment %v. Comparing with different type", val)
 	}
+	matches := func(v types.Val) bool {
+		return types.CompareVals(sg.SrcFunc.Name, v, dst)
+	}
+	if sg.SrcFunc.Split != "" {
+		// A uid matches if any of the tokens of its value does.
+		matches = func(v types.Val) bool {
+			for _, tok := range splitVal(v, sg.SrcFunc.Split) {
+				if types.CompareVals(sg.SrcFunc.Name, tok, dst) {
+					return true
+				}
+			}
+			return false
+		}
+	}
 
 	if sg.SrcUIDs != nil {
 		// This means its a filter.
 		for _, uid := range sg.SrcUIDs.Uids {
-			curVal, ok := sg.Params.UidToVal[uid]
-			if ok && types.CompareVals(sg.SrcFunc.Name, curVal, dst) {
+			curVal, ok := uidToVal[uid]
+			if ok && matches(curVal) {
 				sg.DestUIDs.Uids = append(sg.DestUIDs.Uids, uid)
 			}
 		}
 	} else {
 		// This means it's a function at root as SrcUIDs is nil
-		for uid, curVal := range sg.Params.UidToVal {
-			if types.CompareVals(sg.SrcFunc.Name, curVal, dst) {
+		for uid, curVal := range uidToVal {
+			if matches(curVal) {
 				sg.DestUIDs.Uids = append(sg.DestUIDs.Uids, uid)
 			}
 		}
@@ -1957,23 +2506,7 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				break
 			}
 
-			preds = getPredicatesFromTypes(namespace, typeNames)
-			// We check if enterprise is enabled and only
-			// restrict preds to allowed preds if ACL is turned on.
-			if worker.EnterpriseEnabled() && sg.Params.AllowedPreds != nil {
-				// Take intersection of both the predicate lists
-				intersectPreds := make([]string, 0)
-				hashMap := make(map[string]bool)
-				for _, allowedPred := range sg.Params.AllowedPreds {
-					hashMap[allowedPred] = true
-				}
-				for _, pred := range preds {
-					if _, found := hashMap[pred]; found {
-						intersectPreds = append(intersectPreds, pred)
-					}
-				}
-				preds = intersectPreds
-			}
+			preds = sg.allowedPreds(getPredicatesFromTypes(namespace, typeNames))
 
 		default:
 			if len(child.ExpandPreds) > 0 {
@@ -2022,6 +2555,11 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 				recursiveCopy(s, cf)
 				temp.Filters = append(temp.Filters, s)
 			}
+			if temp.Params.softDelete.appliesTo(temp.Attr) {
+				if err := temp.addSoftDeleteFilter(); err != nil {
+					return out, err
+				}
+			}
 
 			// Go through each child, create a copy and attach to temp.Children.
 			for _, cc := range child.Children {
@@ -2042,6 +2580,101 @@ func expandSubgraph(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
 	return out, nil
 }
 
+// allowedPreds restricts preds to the predicates allowed by ACL, if it is turned on.
+func (sg *SubGraph) allowedPreds(preds []string) []string {
+	if !worker.EnterpriseEnabled() || sg.Params.AllowedPreds == nil {
+		return preds
+	}
+	// Take intersection of both the predicate lists
+	intersectPreds := make([]string, 0)
+	hashMap := make(map[string]bool)
+	for _, allowedPred := range sg.Params.AllowedPreds {
+		hashMap[allowedPred] = true
+	}
+	for _, pred := range preds {
+		if _, found := hashMap[pred]; found {
+			intersectPreds = append(intersectPreds, pred)
+		}
+	}
+	return intersectPreds
+}
+
+// projectTypes adds a child for each predicate of the types of the nodes of sg, for the
+// projectType argument. Unlike expand(_all_), which returns the predicates of all the types
+// found at the level for every node, a predicate is only returned for the nodes which have a
+// type declaring it, so a node with several types gets the union of their predicates. The uid
+// predicates return the uids they point to. The predicates already queried are left as they are.
+func projectTypes(ctx context.Context, sg *SubGraph) ([]*SubGraph, error) {
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "While projecting types")
+	}
+	typesMatrix, err := nodeTypesMatrix(ctx, sg)
+	if err != nil {
+		return nil, err
+	}
+
+	// The nodes of each predicate, and the predicates in the order they are first found.
+	nodes := make(map[string]map[uint64]struct{})
+	var preds []string
+	for i, vals := range typesMatrix {
+		if i >= len(sg.DestUIDs.Uids) {
+			break
+		}
+		typeNames := getPredsFromVals([]*pb.ValueList{vals})
+		for _, pred := range sg.allowedPreds(getPredicatesFromTypes(namespace, typeNames)) {
+			if _, ok := nodes[pred]; !ok {
+				nodes[pred] = make(map[uint64]struct{})
+				preds = append(preds, pred)
+			}
+			nodes[pred][sg.DestUIDs.Uids[i]] = struct{}{}
+		}
+	}
+
+	out := sg.Children
+	for _, pred := range preds {
+		child := &SubGraph{
+			ReadTs: sg.ReadTs,
+			Attr:   x.ParseAttr(pred),
+			Params: params{
+				Cascade:      &CascadeArgs{},
+				GetUid:       sg.Params.GetUid,
+				IgnoreReflex: sg.Params.IgnoreReflex,
+				NestFacets:   sg.Params.NestFacets,
+				Normalize:    sg.Params.Normalize,
+				AllowedPreds: sg.Params.AllowedPreds,
+			},
+			included: nodes[pred],
+		}
+		if hasSimilarChild(sg, child) {
+			continue
+		}
+		// The reverse predicates of a type are uid predicates as well.
+		isUid := strings.HasPrefix(child.Attr, "~")
+		if typ, err := schema.State().TypeOf(pred); err == nil && typ == types.UidID {
+			isUid = true
+		}
+		if isUid {
+			child.Children = []*SubGraph{{
+				ReadTs: sg.ReadTs,
+				Attr:   "uid",
+				Params: params{Cascade: &CascadeArgs{}, GetUid: sg.Params.GetUid},
+			}}
+		}
+		out = append(out, child)
+	}
+	return out, nil
+}
+
+func hasSimilarChild(sg, child *SubGraph) bool {
+	for _, ch := range sg.Children {
+		if ch.isSimilar(child) {
+			return true
+		}
+	}
+	return false
+}
+
 // ProcessGraph processes the SubGraph instance accumulating result for the query
 // from different instances. Note: taskQuery is nil for root node.
 func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
@@ -2082,7 +2715,7 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				return sg.DestUIDs.Uids[i] < sg.DestUIDs.Uids[j]
 			})
 		}
-		if sg.Params.AfterUID > 0 {
+		if sg.Params.AfterUID > 0 && !sg.isOrdered() {
 			i := sort.Search(len(sg.DestUIDs.Uids), func(i int) bool { return sg.DestUIDs.Uids[i] > sg.Params.AfterUID })
 			sg.DestUIDs.Uids = sg.DestUIDs.Uids[i:]
 		}
@@ -2122,6 +2755,27 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				rch <- err
 				return
 			}
+		case sg.SrcFunc != nil && sg.SrcFunc.Name == percentileAboveFn:
+			// The percentile is computed from the value variable, so there is no task to run.
+			err = sg.applyPercentileFunc()
+			if parent != nil || err != nil {
+				rch <- err
+				return
+			}
+		case sg.SrcFunc != nil && sg.SrcFunc.Name == reachableFn:
+			// The traversal runs a task for each level.
+			err = sg.applyReachableFunc(ctx)
+			if parent != nil || err != nil {
+				rch <- err
+				return
+			}
+		case sg.SrcFunc != nil && sg.SrcFunc.Name == inSetFn:
+			// The set is found by its name before its members are read.
+			err = sg.applyInSetFunc(ctx)
+			if parent != nil || err != nil {
+				rch <- err
+				return
+			}
 		case isInequalityFn && sg.SrcFunc.IsLenVar:
 			// Safe to access 0th element here because if no variable was given, parser would throw
 			// an error.
@@ -2141,21 +2795,51 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 				sg.DestUIDs.Uids = nil
 			}
 		default:
+			// The size of the result isn't known before running the task, so stop here if the
+			// request is already using too much memory.
+			mem := memoryAccountFromContext(ctx)
+			if err := mem.check(); err != nil {
+				rch <- err
+				return
+			}
 			taskQuery, err := createTaskQuery(ctx, sg)
 			if err != nil {
 				rch <- err
 				return
 			}
-			result, err := worker.ProcessTaskOverNetwork(ctx, taskQuery)
+			var result *pb.Result
+			remote := federatedRemoteOf(sg.Attr)
+			switch {
+			case remote != nil && sg.SrcFunc != nil:
+				result, err = sg.processFederatedFunc(ctx, taskQuery, remote, parent == nil)
+			case remote != nil && parent != nil:
+				result, err = sg.processFederated(ctx, taskQuery, remote)
+			default:
+				result, err = worker.ProcessTaskOverNetwork(ctx, taskQuery)
+			}
 			switch {
 			case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
 				sg.UnknownAttr = true
 				// Create an empty result because the code below depends on it.
 				result = &pb.Result{}
+			case err == worker.ErrGroupTimedOut && sg.isFilterOf(parent):
+				// An empty result would be wrong for a filter, the nodes would be left out by AND
+				// and kept by NOT.
+				rch <- errors.Wrapf(err, "while running the filter on predicate %s",
+					x.ParseAttr(sg.Attr))
+				return
+			case err == worker.ErrGroupTimedOut:
+				// The query asked for partial results, the groups which timed out are returned
+				// in the extensions or the headers of the response.
+				result = &pb.Result{}
 			case err != nil:
 				rch <- err
 				return
 			}
+			if err := mem.add(resultMemory(result)); err != nil {
+				rch <- err
+				return
+			}
 
 			sg.uidMatrix = result.UidMatrix
 			sg.valueMatrix = result.ValueMatrix
@@ -2182,6 +2866,12 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 			if parent == nil {
 				// I'm root. We reach here if root had a function.
 
+				// A function like anyofterms returns a list per token, each capped by the worker,
+				// so the limit is applied again once they are merged.
+				if sg.SrcFunc != nil && sg.SrcFunc.Limit > 0 &&
+					len(sg.DestUIDs.Uids) > sg.SrcFunc.Limit {
+					sg.DestUIDs = &pb.List{Uids: sg.DestUIDs.Uids[:sg.SrcFunc.Limit]}
+				}
 				if len(sg.Params.Cascade.Fields) >= 0 {
 					// DesitUIDs for this level becomes the sourceUIDs for the next level. In updateUidMatrix with cascade,
 					// we end up modifying the first list from the uidMatrix which ends up modifying the srcUids of the next level.
@@ -2198,33 +2888,36 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	if sg.FilterOp == "not" && len(sg.Filters) == 2 && !sg.Filters[1].isIndexed(ctx) {
+		// Without an index, not eq compares the values of the nodes which don't have the
+		// predicate too, and returns them.
+		sg.Filters = sg.Filters[:1]
+	}
+
 	// Run filters if any.
 	if len(sg.Filters) > 0 {
-		// Run all filters in parallel.
-		filterChan := make(chan error, len(sg.Filters))
-		for _, filter := range sg.Filters {
-			isUidFuncWithoutVar := filter.SrcFunc != nil && filter.SrcFunc.Name == "uid" &&
-				len(filter.Params.NeedsVar) == 0
-			// For uid function filter, no need for processing. User already gave us the
-			// list. Lets just update DestUIDs.
-			if isUidFuncWithoutVar {
-				filter.DestUIDs = filter.SrcUIDs
-				filterChan <- nil
-				continue
+		// The filters given in the order of @intersect are run first, one after the other, on the
+		// uids matched by the previous ones.
+		ordered, others, hashIntersect := sg.intersectHint()
+		srcUIDs := sg.DestUIDs
+		for _, filter := range ordered {
+			filterChan := make(chan error, 1)
+			sg.runFilter(ctx, filter, srcUIDs, filterChan)
+			if err = <-filterChan; err != nil {
+				rch <- err
+				return
 			}
+			srcUIDs = algo.IntersectSorted([]*pb.List{srcUIDs, filter.DestUIDs})
+		}
 
-			filter.SrcUIDs = sg.DestUIDs
-			if len(filter.SrcUIDs.Uids) == 0 {
-				filterChan <- nil
-				continue
-			}
-			// Passing the pointer is okay since the filter only reads.
-			filter.Params.ParentVars = sg.Params.ParentVars // Pass to the child.
-			go ProcessGraph(ctx, filter, sg, filterChan)
+		// Run the other filters in parallel.
+		filterChan := make(chan error, len(others))
+		for _, filter := range others {
+			sg.runFilter(ctx, filter, srcUIDs, filterChan)
 		}
 
 		var filterErr error
-		for range sg.Filters {
+		for range others {
 			if err = <-filterChan; err != nil {
 				// Store error in a variable and wait for all filters to run
 				// before returning. Else tracing causes crashes.
@@ -2246,9 +2939,17 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		switch {
 		case sg.FilterOp == "or":
 			sg.DestUIDs = algo.MergeSorted(lists)
+		case sg.FilterOp == "not" && len(sg.Filters) == 2:
+			// not eq on an indexed predicate returns the nodes which have the predicate, with a
+			// value that isn't equal: the results of has minus the results of eq, which uses the
+			// index.
+			has := algo.IntersectSorted([]*pb.List{sg.DestUIDs, sg.Filters[1].DestUIDs})
+			sg.DestUIDs = algo.Difference(has, sg.Filters[0].DestUIDs)
 		case sg.FilterOp == "not":
 			x.AssertTrue(len(sg.Filters) == 1)
 			sg.DestUIDs = algo.Difference(sg.DestUIDs, sg.Filters[0].DestUIDs)
+		case sg.FilterOp == "and" && hashIntersect:
+			sg.DestUIDs = algo.IntersectHash(lists)
 		case sg.FilterOp == "and":
 			sg.DestUIDs = algo.IntersectSorted(lists)
 		default:
@@ -2263,10 +2964,24 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	if parent == nil && sg.Params.TotalCount {
+		// The total is counted after the filters, but before pagination.
+		sg.totalCount = len(sg.DestUIDs.GetUids())
+	}
+	if parent == nil && sg.Params.Sample > 0 {
+		// The total count is the number of nodes the sample is taken from.
+		sampler := algo.NewUidSampler(sg.Params.Sample, sg.Params.SampleSeed)
+		for _, uid := range sg.DestUIDs.GetUids() {
+			sampler.Add(uid)
+		}
+		sg.DestUIDs = &pb.List{Uids: sampler.Uids()}
+	}
+
 	if len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
 		// for `has` function when there is no filtering and ordering, we fetch
 		// correct paginated results so no need to apply pagination here.
-		if !(len(sg.Filters) == 0 && sg.SrcFunc != nil && sg.SrcFunc.Name == "has") {
+		if !(len(sg.Filters) == 0 && sg.SrcFunc != nil && sg.SrcFunc.Name == "has" &&
+			!sg.Params.TotalCount && sg.Params.Sample == 0) {
 			// There is no ordering. Just apply pagination and return.
 			if err = sg.applyPagination(ctx); err != nil {
 				rch <- err
@@ -2284,6 +2999,10 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		}
 	}
 
+	if parent == nil && sg.Params.DefaultFirst {
+		sg.truncateToDefaultFirst()
+	}
+
 	// Here we consider handling count with filtering. We do this after
 	// pagination because otherwise, we need to do the count with pagination
 	// taken into account. For example, a PL might have only 50 entries but the
@@ -2307,6 +3026,12 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 		rch <- err
 		return
 	}
+	if sg.Params.ProjectType && len(sg.DestUIDs.GetUids()) > 0 {
+		if sg.Children, err = projectTypes(ctx, sg); err != nil {
+			rch <- err
+			return
+		}
+	}
 
 	if sg.IsGroupBy() {
 		// Add the attrs required by groupby nodes
@@ -2319,6 +3044,7 @@ func ProcessGraph(ctx context.Context, sg, parent *SubGraph, rch chan error) {
 					Alias:        it.Alias,
 					IgnoreResult: true,
 					Langs:        it.Langs,
+					Split:        it.Split,
 				},
 			})
 		}
@@ -2398,12 +3124,95 @@ func (sg *SubGraph) applyPagination(ctx context.Context) error {
 	return nil
 }
 
+// isOrdered returns true if the results are ordered by a predicate, a facet or a variable
+// instead of by uid.
+func (sg *SubGraph) isOrdered() bool {
+	return len(sg.Params.Order) > 0 || len(sg.Params.FacetsOrder) > 0
+}
+
+// runFilter runs the filter on srcUIDs, and sends its error to filterChan once it is done.
+func (sg *SubGraph) runFilter(ctx context.Context, filter *SubGraph, srcUIDs *pb.List,
+	filterChan chan error) {
+	isUidFuncWithoutVar := filter.SrcFunc != nil && filter.SrcFunc.Name == "uid" &&
+		len(filter.Params.NeedsVar) == 0
+	// For uid function filter, no need for processing. User already gave us the
+	// list. Lets just update DestUIDs.
+	if isUidFuncWithoutVar {
+		filter.DestUIDs = filter.SrcUIDs
+		filterChan <- nil
+		return
+	}
+
+	filter.SrcUIDs = srcUIDs
+	if len(filter.SrcUIDs.Uids) == 0 {
+		filter.DestUIDs = &pb.List{}
+		filterChan <- nil
+		return
+	}
+	// Passing the pointer is okay since the filter only reads.
+	filter.Params.ParentVars = sg.Params.ParentVars // Pass to the child.
+	go ProcessGraph(ctx, filter, sg, filterChan)
+}
+
+// intersectHint returns the filters to run one after the other in the order of @intersect, the
+// ones to run in parallel after them, and whether their results are intersected with a hash set.
+// The hint only applies to filters joined by AND, the parts of it which can't be applied are
+// ignored with a warning as the results are the same without them.
+func (sg *SubGraph) intersectHint() ([]*SubGraph, []*SubGraph, bool) {
+	hint := sg.Params.Intersect
+	if hint == nil {
+		return nil, sg.Filters, false
+	}
+	if sg.FilterOp != "and" {
+		glog.Warningf("Ignoring @intersect as it only applies to filters joined by AND")
+		return nil, sg.Filters, false
+	}
+
+	var hashIntersect bool
+	switch hint.Method {
+	case "", "merge":
+	case "hash":
+		hashIntersect = true
+	default:
+		glog.Warningf("Ignoring unknown method %q of @intersect, expected merge or hash",
+			hint.Method)
+	}
+
+	var ordered []*SubGraph
+	used := make(map[*SubGraph]bool)
+	for _, pred := range hint.Order {
+		var found bool
+		for _, filter := range sg.Filters {
+			if !used[filter] && filter.Attr == pred && filter.SrcFunc != nil {
+				ordered = append(ordered, filter)
+				used[filter] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			glog.Warningf("Ignoring %q in the order of @intersect as no function of the filter "+
+				"uses it", pred)
+		}
+	}
+	others := make([]*SubGraph, 0, len(sg.Filters)-len(ordered))
+	for _, filter := range sg.Filters {
+		if !used[filter] {
+			others = append(others, filter)
+		}
+	}
+	return ordered, others, hashIntersect
+}
+
 // applyOrderAndPagination orders each posting list by a given attribute
 // before applying pagination.
 func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 	if len(sg.Params.Order) == 0 && len(sg.Params.FacetsOrder) == 0 {
 		return nil
 	}
+	if sg.Params.AfterUID > 0 {
+		return sg.applyOrderAfterUid(ctx)
+	}
 
 	sg.updateUidMatrix()
 
@@ -2433,6 +3242,9 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrapf(err, "While ordering and paginating")
 	}
+	if remote := federatedRemoteOf(sg.Params.Order[0].Attr); remote != nil {
+		return sg.sortFederated(ctx, ns, remote)
+	}
 	order := sg.createOrderForTask(ns)
 	sortMsg := &pb.SortMessage{
 		Order:     order,
@@ -2469,14 +3281,138 @@ func (sg *SubGraph) applyOrderAndPagination(ctx context.Context) error {
 	return nil
 }
 
+// applyOrderAfterUid orders the lists like applyOrderAndPagination, and then keeps the results
+// which follow the after uid in the order, so that the last uid of a page can be given as after
+// to get the next page. For the orders by predicates, the cursor is compared by the values of the
+// after uid, then by uid, as the equal values are ordered by uid: it doesn't have to be in the
+// list, e.g. if it was deleted since the previous page. For the other orders, the lists which
+// don't contain the after uid are empty, as they don't have a position to start from.
+func (sg *SubGraph) applyOrderAfterUid(ctx context.Context) error {
+	count, offset, after := sg.Params.Count, sg.Params.Offset, sg.Params.AfterUID
+	defer func() {
+		sg.Params.Count, sg.Params.Offset, sg.Params.AfterUID = count, offset, after
+	}()
+	// The whole lists are ordered, the pagination is applied from the after uid.
+	sg.Params.Count, sg.Params.Offset, sg.Params.AfterUID = math.MaxInt32, 0, 0
+	if err := sg.applyOrderAndPagination(ctx); err != nil {
+		return err
+	}
+	if count == 0 {
+		// Only retrieve up to 1000 results by default, as for the other orders.
+		count = 1000
+	}
+
+	follows, err := sg.afterCursor(ctx, after)
+	if err != nil {
+		return err
+	}
+	for i, ul := range sg.uidMatrix {
+		start := len(ul.Uids)
+		for j, uid := range ul.Uids {
+			if follows == nil && uid == after {
+				start = j + 1
+				break
+			}
+			if follows != nil && follows(uid) {
+				start = j
+				break
+			}
+		}
+		from, to := x.PageRange(count, offset, len(ul.Uids)-start)
+		from, to = from+start, to+start
+		if i < len(sg.facetsMatrix) && len(sg.facetsMatrix[i].FacetsList) == len(ul.Uids) {
+			sg.facetsMatrix[i].FacetsList = sg.facetsMatrix[i].FacetsList[from:to]
+		}
+		ul.Uids = ul.Uids[from:to]
+	}
+	sg.updateDestUids()
+	return nil
+}
+
+// afterCursor returns a function telling whether a uid follows the after uid in the order by
+// predicates of the subgraph, by comparing their values. It returns nil for the other orders.
+func (sg *SubGraph) afterCursor(ctx context.Context, after uint64) (func(uint64) bool, error) {
+	if len(sg.Params.Order) == 0 || len(sg.Params.FacetsOrder) > 0 {
+		return nil, nil
+	}
+	for _, o := range sg.Params.Order {
+		if o.Count || o.Reverse {
+			return nil, nil
+		}
+		for _, it := range sg.Params.NeedsVar {
+			if it.Name == o.Attr && it.Typ == gql.ValueVar {
+				return nil, nil
+			}
+		}
+	}
+
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "While paginating after %#x", after)
+	}
+	uids := algo.MergeSorted(append([]*pb.List{{Uids: []uint64{after}}}, sg.uidMatrix...))
+	// vals holds the value of each order for the uids which have one.
+	vals := make([]map[uint64]types.Val, len(sg.Params.Order))
+	for i, o := range sg.Params.Order {
+		res, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+			Attr:    x.NamespaceAttr(ns, o.Attr),
+			Langs:   o.Langs,
+			UidList: uids,
+			ReadTs:  sg.ReadTs,
+		})
+		switch {
+		case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
+			res = &pb.Result{}
+		case err != nil:
+			return nil, err
+		}
+		vals[i] = make(map[uint64]types.Val, len(uids.Uids))
+		for j, uid := range uids.Uids {
+			if j >= len(res.ValueMatrix) || len(res.ValueMatrix[j].Values) == 0 {
+				continue
+			}
+			v, err := convertWithBestEffort(res.ValueMatrix[j].Values[0], o.Attr)
+			if err != nil {
+				return nil, err
+			}
+			vals[i][uid] = v
+		}
+	}
+
+	return func(uid uint64) bool {
+		for i, o := range sg.Params.Order {
+			a, aok := vals[i][after]
+			b, bok := vals[i][uid]
+			switch {
+			case !aok && !bok:
+				continue
+			case !aok:
+				// The uids without a value come after the others.
+				return false
+			case !bok:
+				return true
+			case types.CompareVals("eq", a, b):
+				continue
+			}
+			if o.Desc {
+				return types.CompareVals("lt", b, a)
+			}
+			return types.CompareVals("lt", a, b)
+		}
+		return uid > after
+	}, nil
+}
+
 // createOrderForTask creates namespaced aware order for the task.
 func (sg *SubGraph) createOrderForTask(ns uint64) []*pb.Order {
 	out := []*pb.Order{}
 	for _, o := range sg.Params.Order {
 		oc := &pb.Order{
-			Attr:  x.NamespaceAttr(ns, o.Attr),
-			Desc:  o.Desc,
-			Langs: o.Langs,
+			Attr:    x.NamespaceAttr(ns, o.Attr),
+			Desc:    o.Desc,
+			Langs:   o.Langs,
+			Count:   o.Count,
+			Reverse: o.Reverse,
 		}
 		out = append(out, oc)
 	}
@@ -2538,11 +3474,15 @@ func (sg *SubGraph) sortAndPaginateUsingFacet(ctx context.Context) error {
 			// we can break out of below loop.
 			remainingFacets := len(orderbyKeys)
 			// TODO: We are searching sequentially, explore if binary search is useful here.
-			for _, it := range f.Facets {
+			for k, it := range f.Facets {
 				idx, ok := orderbyKeys[it.Key]
 				if !ok {
 					continue
 				}
+				// List facets are ordered by their first value.
+				if k > 0 && f.Facets[k-1].Key == it.Key {
+					continue
+				}
 
 				fVal, err := facets.ValFor(it)
 				if err != nil {
@@ -2593,27 +3533,34 @@ func (sg *SubGraph) sortAndPaginateUsingVar(ctx context.Context) error {
 	if sg.Params.UidToVal == nil {
 		return errors.Errorf("Variable: [%s] used before definition.", sg.Params.Order[0].Attr)
 	}
+	return sg.sortByValues(sg.Params.UidToVal, sg.Params.Order[0].Desc)
+}
 
+// sortByValues orders the lists of the uid matrix by the given values, and paginates them.
+func (sg *SubGraph) sortByValues(uidToVal map[uint64]types.Val, desc bool) error {
+	// The variable can be computed in any other block, so it usually doesn't have a value for
+	// every uid. The uids without a value are returned after the others, as for the predicates
+	// which a node doesn't have. The uids with equal values, as the uids without a value, are
+	// kept in the order of their uids, so that the pages are consistent.
 	for i := 0; i < len(sg.uidMatrix); i++ {
 		ul := sg.uidMatrix[i]
 		uids := make([]uint64, 0, len(ul.Uids))
 		values := make([][]types.Val, 0, len(ul.Uids))
+		var missing []uint64
 		for _, uid := range ul.Uids {
-			v, ok := sg.Params.UidToVal[uid]
+			v, ok := uidToVal[uid]
 			if !ok {
-				// We skip the UIDs which don't have a value.
+				missing = append(missing, uid)
 				continue
 			}
 			values = append(values, []types.Val{v})
 			uids = append(uids, uid)
 		}
-		if len(values) == 0 {
-			continue
-		}
-		if err := types.Sort(values, &uids, []bool{sg.Params.Order[0].Desc}, ""); err != nil {
+		err := types.SortStable(values, &uids, []bool{desc}, "")
+		if err != nil {
 			return err
 		}
-		sg.uidMatrix[i].Uids = uids
+		sg.uidMatrix[i].Uids = append(uids, missing...)
 	}
 
 	if sg.Params.Count != 0 || sg.Params.Offset != 0 {
@@ -2633,7 +3580,8 @@ func (sg *SubGraph) sortAndPaginateUsingVar(ctx context.Context) error {
 func isValidArg(a string) bool {
 	switch a {
 	case "numpaths", "from", "to", "orderasc", "orderdesc", "first", "offset", "after", "depth",
-		"minweight", "maxweight":
+		"minweight", "maxweight", "projectType", "nestFacets", "includeDeleted", "datetimeFormat",
+		"timezone", "floatPrecision", "sample", "seed":
 		return true
 	}
 	return false
@@ -2643,7 +3591,8 @@ func isValidArg(a string) bool {
 func isValidFuncName(f string) bool {
 	switch f {
 	case "anyofterms", "allofterms", "val", "regexp", "anyoftext", "alloftext",
-		"has", "uid", "uid_in", "anyof", "allof", "type", "match":
+		"has", "uid", "uid_in", "anyof", "allof", "type", "match", percentileAboveFn,
+		reachableFn, inSetFn:
 		return true
 	}
 	return isInequalityFn(f) || types.IsGeoFunc(f)
@@ -2670,6 +3619,15 @@ func isUidFnWithoutVar(f *gql.Function) bool {
 }
 
 func getNodeTypes(ctx context.Context, sg *SubGraph) ([]string, error) {
+	typesMatrix, err := nodeTypesMatrix(ctx, sg)
+	if err != nil {
+		return nil, err
+	}
+	return getPredsFromVals(typesMatrix), nil
+}
+
+// nodeTypesMatrix returns the types of each of the DestUIDs of sg.
+func nodeTypesMatrix(ctx context.Context, sg *SubGraph) ([]*pb.ValueList, error) {
 	temp := &SubGraph{
 		Attr:    "dgraph.type",
 		SrcUIDs: sg.DestUIDs,
@@ -2683,7 +3641,7 @@ func getNodeTypes(ctx context.Context, sg *SubGraph) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	return getPredsFromVals(result.ValueMatrix), nil
+	return result.ValueMatrix, nil
 }
 
 // getPredicatesFromTypes returns the list of preds contained in the given types.
@@ -2742,10 +3700,17 @@ type Request struct {
 	Cache    int    // 0 represents use txn cache, 1 represents not to use cache.
 	Latency  *Latency
 	GqlQuery *gql.Result
+	// TxnStartTs is the start ts of a transaction in read-committed mode, whose mutations are
+	// seen by the query along with the data committed up to ReadTs.
+	TxnStartTs uint64
 
 	Subgraphs []*SubGraph
 
 	Vars map[string]varValue
+
+	// Upsert is true for the query of an upsert block. Its results aren't limited by
+	// --limit default-first, as the mutations apply to all of them.
+	Upsert bool
 }
 
 // ProcessQuery processes query part of the request (without mutations).
@@ -2773,9 +3738,11 @@ func (req *Request) ProcessQuery(ctx context.Context) (err error) {
 		if err != nil {
 			return errors.Wrapf(err, "while converting to subgraph")
 		}
+		req.applyDefaultFirst(sg, gq, len(req.GqlQuery.QueryVars[i].Defines) > 0)
 		sg.recurse(func(sg *SubGraph) {
 			sg.ReadTs = req.ReadTs
 			sg.Cache = req.Cache
+			sg.TxnStartTs = req.TxnStartTs
 		})
 		span.Annotate(nil, "Query parsed")
 		req.Subgraphs = append(req.Subgraphs, sg)
@@ -2879,6 +3846,11 @@ func (req *Request) ProcessQuery(ctx context.Context) (err error) {
 			}
 			// first time at the root here.
 
+			// With @cascade, the total needs to leave out the nodes removed by it.
+			if len(sg.Params.Cascade.Fields) > 0 && sg.Params.TotalCount {
+				sg.totalCount = len(sg.DestUIDs.GetUids())
+			}
+
 			// Apply pagination at the root after @cascade.
 			if len(sg.Params.Cascade.Fields) > 0 && (sg.Params.Cascade.First != 0 || sg.Params.Cascade.Offset != 0) {
 				sg.updateUidMatrix()
@@ -2900,6 +3872,15 @@ func (req *Request) ProcessQuery(ctx context.Context) (err error) {
 			return errors.Errorf("Query couldn't be executed")
 		}
 	}
+
+	// The conditions of @include and @skip and the keys of @dedup can depend on variables defined
+	// anywhere in the query, so they are evaluated after all the blocks have been processed.
+	for _, sg := range req.Subgraphs {
+		if err := sg.populateIncludes(req.Vars); err != nil {
+			return err
+		}
+		sg.populateDedup(req.Vars)
+	}
 	req.Latency.Processing += time.Since(execStart)
 
 	// If we had a shortestPath SG, append it to the result.
@@ -2914,6 +3895,7 @@ type ExecutionResult struct {
 	Subgraphs  []*SubGraph
 	SchemaNode []*pb.SchemaNode
 	Types      []*pb.TypeUpdate
+	History    *pb.HistoryResult
 	Metrics    map[string]uint64
 }
 
@@ -2924,11 +3906,23 @@ func (req *Request) Process(ctx context.Context) (er ExecutionResult, err error)
 		return er, err
 	}
 	er.Subgraphs = req.Subgraphs
+	if mu, ok := ctx.Value(StrictUidsKey).(*MissingUids); ok {
+		if err := mu.find(ctx, req); err != nil {
+			return er, err
+		}
+	}
 	// calculate metrics.
 	metrics := make(map[string]uint64)
 	for _, sg := range er.Subgraphs {
 		calculateMetrics(sg, metrics)
 	}
+	if tr, ok := ctx.Value(TruncatedKey).(*Truncated); ok {
+		for _, sg := range er.Subgraphs {
+			if sg.truncated {
+				tr.Blocks = append(tr.Blocks, sg.Params.Alias)
+			}
+		}
+	}
 	er.Metrics = metrics
 	namespace, err := x.ExtractNamespace(ctx)
 	if err != nil {
@@ -2947,6 +3941,13 @@ func (req *Request) Process(ctx context.Context) (er ExecutionResult, err error)
 			return er, errors.Wrapf(err, "while fetching types")
 		}
 	}
+	if h := req.GqlQuery.History; h != nil {
+		h.Attr = x.NamespaceAttr(namespace, h.Attr)
+		h.ReadTs = req.ReadTs
+		if er.History, err = worker.GetHistoryOverNetwork(ctx, h); err != nil {
+			return er, errors.Wrapf(err, "while fetching history")
+		}
+	}
 
 	if !x.IsGalaxyOperation(ctx) {
 		// Filter the schema nodes for the given namespace.
@@ -594,6 +594,100 @@ func TestLevelBasedFacetVarAggSum(t *testing.T) {
 	}`, js)
 }
 
+func TestLevelBasedFacetVarAggAvg(t *testing.T) {
+	// 0x3ea is pointed to by both 0x3e8 and 0x3e9, and the edge of 0x3eb to 0x3e9 doesn't have
+	// the facet.
+	query := `
+		{
+			friend(func: uid(1000, 1001, 1003)) {
+				path @facets(w as weight)
+				avgw: avg(val(w))
+			}
+			total() {
+				avg(val(w))
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+		  "friend": [
+			{
+			  "path": [
+				{"uid": "0x3e9", "path|weight": 0.1},
+				{"uid": "0x3ea", "path|weight": 0.7}
+			  ],
+			  "avgw": 0.4
+			},
+			{
+			  "path": [
+				{"uid": "0x3ea", "path|weight": 0.1},
+				{"uid": "0x3eb", "path|weight": 1.5}
+			  ],
+			  "avgw": 0.8
+			},
+			{
+			  "path": [
+				{"uid": "0x3e9"}
+			  ]
+			}
+		  ],
+		  "total": [
+			{"avg(val(w))": 0.6}
+		  ]
+		}
+	}`, js)
+}
+
+func TestLevelBasedFacetVarWeightedAvg(t *testing.T) {
+	// The rating of 0x7003 has no weight, so it's left out.
+	require.NoError(t, addTriplesToCluster(`
+		<0x7000> <wa_rated> <0x7001> (score = 4, weight = 3) .
+		<0x7000> <wa_rated> <0x7002> (score = 2, weight = 1) .
+		<0x7000> <wa_rated> <0x7003> (score = 5) .
+		<0x7004> <wa_rated> <0x7001> (score = 1, weight = 4) .
+	`))
+	defer dropPredicate("wa_rated")
+
+	query := `
+		{
+			rater(func: uid(0x7000, 0x7004)) {
+				wa_rated @facets(s as score, w as weight)
+				avgs: avg(val(s), val(w))
+			}
+			total() {
+				avg(val(s), val(w))
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+		  "rater": [
+			{
+			  "wa_rated": [
+				{"uid": "0x7001", "wa_rated|score": 4, "wa_rated|weight": 3},
+				{"uid": "0x7002", "wa_rated|score": 2, "wa_rated|weight": 1},
+				{"uid": "0x7003", "wa_rated|score": 5}
+			  ],
+			  "avgs": 3.5
+			},
+			{
+			  "wa_rated": [
+				{"uid": "0x7001", "wa_rated|score": 1, "wa_rated|weight": 4}
+			  ],
+			  "avgs": 1
+			}
+		  ],
+		  "total": [
+			{"avg(val(s), val(w))": 2.25}
+		  ]
+		}
+	}`, js)
+}
+
 func TestLevelBasedFacetVarSum(t *testing.T) {
 	query := `
 		{
@@ -911,7 +1005,7 @@ func TestQueryVarValAggSince(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"AgeOrder":[{"name":"Rick Grimes","val(a)":"1910-01-02T00:00:00Z"},{"name":"Michonne","val(a)":"1910-01-01T00:00:00Z"},{"name":"Andrea","val(a)":"1901-01-15T00:00:00Z"}]}}`,
+		`{"data": {"AgeOrder":[{"name":"Rick Grimes","val(a)":"1910-01-02T00:00:00Z"},{"name":"Michonne","val(a)":"1910-01-01T00:00:00Z"},{"name":"Andrea","val(a)":"1901-01-15T00:00:00Z"},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -948,7 +1042,7 @@ func TestQueryVarValAggNestedFuncConst(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"MaxMe":[{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(p)":25.000000,"val(s)":38},{"name":"Andrea","val(a)":19,"val(n)":15,"val(p)":29.000000,"val(s)":15},{"name":"Michonne","val(a)":38,"val(n)":15,"val(p)":52.000000,"val(s)":19}],"MinMe":[{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(q)":-21660.000000,"val(s)":38},{"name":"Michonne","val(a)":38,"val(n)":15,"val(q)":-10830.000000,"val(s)":19},{"name":"Andrea","val(a)":19,"val(n)":15,"val(q)":-4275.000000,"val(s)":15}]}}`,
+		`{"data": {"MaxMe":[{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(p)":25.000000,"val(s)":38},{"name":"Andrea","val(a)":19,"val(n)":15,"val(p)":29.000000,"val(s)":15},{"name":"Michonne","val(a)":38,"val(n)":15,"val(p)":52.000000,"val(s)":19},{"name":"Andrea With no friends"}],"MinMe":[{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(q)":-21660.000000,"val(s)":38},{"name":"Michonne","val(a)":38,"val(n)":15,"val(q)":-10830.000000,"val(s)":19},{"name":"Andrea","val(a)":19,"val(n)":15,"val(q)":-4275.000000,"val(s)":15},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -985,7 +1079,105 @@ func TestQueryVarValAggNestedFuncMinMaxVars(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"MinMe":[{"name":"Michonne","val(a)":38,"val(n)":15,"val(q)":15,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(q)":15,"val(s)":38},{"name":"Andrea","val(a)":19,"val(n)":15,"val(q)":15,"val(s)":15}],"MaxMe":[{"name":"Andrea","val(a)":19,"val(n)":15,"val(p)":19,"val(s)":15},{"name":"Michonne","val(a)":38,"val(n)":15,"val(p)":38,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(p)":38,"val(s)":38}]}}`,
+		`{"data": {"MinMe":[{"name":"Michonne","val(a)":38,"val(n)":15,"val(q)":15,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(q)":15,"val(s)":38},{"name":"Andrea","val(a)":19,"val(n)":15,"val(q)":15,"val(s)":15},{"name":"Andrea With no friends"}],"MaxMe":[{"name":"Andrea","val(a)":19,"val(n)":15,"val(p)":19,"val(s)":15},{"name":"Michonne","val(a)":38,"val(n)":15,"val(p)":38,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(n)":38,"val(p)":38,"val(s)":38},{"name":"Andrea With no friends"}]}}`,
+		js)
+}
+
+func TestQueryIncludeDirective(t *testing.T) {
+	query := `
+		{
+			var(func: uid(1, 23)) {
+				a as age
+				adult as math(a > 20)
+			}
+
+			me(func: uid(1, 23, 31)) {
+				name
+				friend(first: 1) @include(if: val(adult)) {
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne","friend":[{"name":"Rick Grimes"}]},{"name":"Rick Grimes"},{"name":"Andrea"}]}}`,
+		js)
+}
+
+func TestQuerySkipDirective(t *testing.T) {
+	query := `
+		{
+			var(func: uid(1, 23)) {
+				a as age
+				adult as math(a > 20)
+			}
+
+			me(func: uid(1, 23, 31)) {
+				name
+				friend(first: 1) @skip(if: val(adult)) {
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne"},{"name":"Rick Grimes","friend":[{"name":"Michonne"}]},{"name":"Andrea","friend":[{"name":"Glenn Rhee"}]}]}}`,
+		js)
+}
+
+func TestQueryIncludeDirectiveNotBool(t *testing.T) {
+	query := `
+		{
+			var(func: uid(1, 23)) {
+				a as age
+			}
+
+			me(func: uid(1, 23)) {
+				name
+				friend @include(if: val(a)) {
+					name
+				}
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "should be of type bool")
+}
+
+func TestQueryDedupByUid(t *testing.T) {
+	// Glenn Rhee is a friend of both Michonne and Andrea, only the first one is kept.
+	query := `
+		{
+			me(func: uid(1, 31)) {
+				name
+				friend @dedup(by: uid) {
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Michonne","friend":[{"name":"Rick Grimes"},{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},{"name":"Andrea"}]},{"name":"Andrea"}]}}`,
+		js)
+}
+
+func TestQueryDedupByValue(t *testing.T) {
+	// Glenn Rhee and Rick Grimes have the same age, the first one by name is kept.
+	query := `
+		{
+			me(func: uid(23, 24, 25, 31), orderasc: name) @dedup(by: val(a)) {
+				name
+				a as age
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"name":"Andrea","age":19},{"name":"Daryl Dixon","age":17},{"name":"Glenn Rhee","age":15}]}}`,
 		js)
 }
 
@@ -1019,7 +1211,7 @@ func TestQueryVarValAggNestedFuncConditional(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"ExpMe":[{"name":"Michonne","val(a)":38,"val(condExp)":1.000000,"val(n)":15},{"name":"Rick Grimes","val(a)":15,"val(condExp)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condExp)":1.000000,"val(n)":15}],"LogMe":[{"name":"Michonne","val(a)":38,"val(condLog)":1.682606,"val(n)":15},{"name":"Andrea","val(a)":19,"val(condLog)":1.682606,"val(n)":15},{"name":"Rick Grimes","val(a)":15,"val(condLog)":2.260159,"val(n)":38}]}}`,
+		`{"data": {"ExpMe":[{"name":"Michonne","val(a)":38,"val(condExp)":1.000000,"val(n)":15},{"name":"Rick Grimes","val(a)":15,"val(condExp)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condExp)":1.000000,"val(n)":15},{"name":"Andrea With no friends"}],"LogMe":[{"name":"Michonne","val(a)":38,"val(condLog)":1.682606,"val(n)":15},{"name":"Andrea","val(a)":19,"val(condLog)":1.682606,"val(n)":15},{"name":"Rick Grimes","val(a)":15,"val(condLog)":2.260159,"val(n)":38},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1053,7 +1245,7 @@ func TestQueryVarValAggNestedFuncConditional2(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"ExpMe":[{"name":"Rick Grimes","val(a)":15,"val(condExp)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condExp)":1.000000,"val(n)":15},{"name":"Michonne","val(a)":38,"val(condExp)":5.477226,"val(n)":15}],"LogMe":[{"name":"Rick Grimes","val(a)":15,"val(condLog)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condLog)":1.000000,"val(n)":15},{"name":"Michonne","val(a)":38,"val(condLog)":7.500000,"val(n)":15}]}}`,
+		`{"data": {"ExpMe":[{"name":"Rick Grimes","val(a)":15,"val(condExp)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condExp)":1.000000,"val(n)":15},{"name":"Michonne","val(a)":38,"val(condExp)":5.477226,"val(n)":15},{"name":"Andrea With no friends"}],"LogMe":[{"name":"Rick Grimes","val(a)":15,"val(condLog)":1.000000,"val(n)":38},{"name":"Andrea","val(a)":19,"val(condLog)":1.000000,"val(n)":15},{"name":"Michonne","val(a)":38,"val(condLog)":7.500000,"val(n)":15},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1090,7 +1282,7 @@ func TestQueryVarValAggNestedFuncUnary(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"ExpMe":[{"name":"Rick Grimes","val(a)":15,"val(combiExp)":16.000000,"val(n)":38,"val(s)":38},{"name":"Andrea","val(a)":19,"val(combiExp)":20.000000,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combiExp)":92.598150,"val(n)":15,"val(s)":19}],"LogMe":[{"name":"Rick Grimes","val(a)":15,"val(combiLog)":-179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000,"val(n)":38,"val(s)":38},{"name":"Andrea","val(a)":19,"val(combiLog)":-179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combiLog)":39.386294,"val(n)":15,"val(s)":19}]}}`,
+		`{"data": {"ExpMe":[{"name":"Rick Grimes","val(a)":15,"val(combiExp)":16.000000,"val(n)":38,"val(s)":38},{"name":"Andrea","val(a)":19,"val(combiExp)":20.000000,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combiExp)":92.598150,"val(n)":15,"val(s)":19},{"name":"Andrea With no friends"}],"LogMe":[{"name":"Rick Grimes","val(a)":15,"val(combiLog)":-179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000,"val(n)":38,"val(s)":38},{"name":"Andrea","val(a)":19,"val(combiLog)":-179769313486231570814527423731704356798070567525844996598917476803157260780028538760589558632766878171540458953514382464234321326889464182768467546703537516986049910576551282076245490090389328944075868508455133942304583236903222948165808559332123348274797826204144723168738177180919299881250404026184124858368.000000,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combiLog)":39.386294,"val(n)":15,"val(s)":19},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1118,7 +1310,7 @@ func TestQueryVarValAggNestedFunc(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"me":[{"name":"Andrea","val(a)":19,"val(combi)":244,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combi)":323,"val(n)":15,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(combi)":1459,"val(n)":38,"val(s)":38}]}}`,
+		`{"data": {"me":[{"name":"Andrea","val(a)":19,"val(combi)":244,"val(n)":15,"val(s)":15},{"name":"Michonne","val(a)":38,"val(combi)":323,"val(n)":15,"val(s)":19},{"name":"Rick Grimes","val(a)":15,"val(combi)":1459,"val(n)":38,"val(s)":38},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1144,7 +1336,7 @@ func TestQueryVarValAggMinMaxSelf(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"me":[{"name":"Andrea","val(s)":15,"val(sum)":49},{"name":"Michonne","val(s)":19,"val(sum)":72},{"name":"Rick Grimes","val(s)":38,"val(sum)":91}]}}`,
+		`{"data": {"me":[{"name":"Andrea","val(s)":15,"val(sum)":49},{"name":"Michonne","val(s)":19,"val(sum)":72},{"name":"Rick Grimes","val(s)":38,"val(sum)":91},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1169,7 +1361,7 @@ func TestQueryVarValAggMinMax(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"me":[{"name":"Rick Grimes","val(n)":38,"val(s)":38},{"name":"Michonne","val(n)":15,"val(s)":19},{"name":"Andrea","val(n)":15,"val(s)":15}]}}`,
+		`{"data": {"me":[{"name":"Rick Grimes","val(n)":38,"val(s)":38},{"name":"Michonne","val(n)":15,"val(s)":19},{"name":"Andrea","val(n)":15,"val(s)":15},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1194,7 +1386,7 @@ func TestQueryVarValAggMinMaxAlias(t *testing.T) {
 	`
 	js := processQueryNoErr(t, query)
 	require.JSONEq(t,
-		`{"data": {"me":[{"name":"Rick Grimes","MinAge":38,"MaxAge":38},{"name":"Michonne","MinAge":15,"MaxAge":19},{"name":"Andrea","MinAge":15,"MaxAge":15}]}}`,
+		`{"data": {"me":[{"name":"Rick Grimes","MinAge":38,"MaxAge":38},{"name":"Michonne","MinAge":15,"MaxAge":19},{"name":"Andrea","MinAge":15,"MaxAge":15},{"name":"Andrea With no friends"}]}}`,
 		js)
 }
 
@@ -1473,6 +1665,54 @@ func TestGroupByRoot(t *testing.T) {
 		js)
 }
 
+func TestGroupByRootOrderAndPage(t *testing.T) {
+	// The groups with the same count keep their default order, so the pages don't overlap.
+	query := `
+	{
+		me(func: uid(1, 23, 24, 25, 31)) @groupby(age, orderdesc: count, first: 2) {
+				count(uid)
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"@groupby":[{"age":15,"count":2},{"age":17,"count":1}]}]}}`, js)
+
+	query = `
+	{
+		me(func: uid(1, 23, 24, 25, 31)) @groupby(age, orderdesc: count, first: 2, offset: 2) {
+				count(uid)
+		}
+	}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"@groupby":[{"age":19,"count":1},{"age":38,"count":1}]}]}}`, js)
+
+	query = `
+	{
+		me(func: uid(1, 23, 24, 25, 31)) @groupby(age, orderdesc: age, offset: 1) {
+				count(uid)
+		}
+	}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"@groupby":[{"age":19,"count":1},{"age":17,"count":1},`+
+			`{"age":15,"count":2}]}]}}`, js)
+
+	query = `
+	{
+		me(func: uid(1, 23, 24, 25, 31)) @groupby(age, orderasc: name) {
+				count(uid)
+		}
+	}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cannot order the groups of groupby by name")
+}
+
 func TestGroupByRootEmpty(t *testing.T) {
 	// Predicate agent doesn't exist.
 	query := `
@@ -2105,6 +2345,59 @@ func TestVarInIneq2(t *testing.T) {
 	require.JSONEq(t, `{"data": {"me":[{"name":"Andrea"}]}}`, js)
 }
 
+func TestPercentileAbove(t *testing.T) {
+	query := `
+	{
+		var(func: uid(1)) {
+			f as friend {
+				a as age
+			}
+		}
+
+		me(func: uid(f)) @filter(percentile_above(val(a), 50)) {
+			name
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Daryl Dixon"},{"name":"Andrea"}]}}`, js)
+}
+
+func TestPercentileAboveAtRoot(t *testing.T) {
+	// Rick Grimes and Glenn Rhee have the same age, so both are kept.
+	query := `
+	{
+		var(func: uid(1)) {
+			friend {
+				a as age
+			}
+		}
+
+		me(func: percentile_above(val(a), 25)) {
+			name
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Rick Grimes"},{"name":"Glenn Rhee"},
+		{"name":"Daryl Dixon"},{"name":"Andrea"}]}}`, js)
+}
+
+func TestPercentileAboveWithPredicate(t *testing.T) {
+	query := `
+	{
+		me(func: uid(1)) {
+			friend @filter(percentile_above(age, 50)) {
+				name
+			}
+		}
+	}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only supports value variables")
+}
+
 func TestVarInIneq3(t *testing.T) {
 
 	query := `
@@ -3177,6 +3470,52 @@ func TestBetweenString(t *testing.T) {
 	}
 }
 
+func TestSetMembershipFunctions(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		result string
+	}{
+		{
+			`anyof matches the nodes having one of the values`,
+			`{me(func: anyof(pet_name, ["mahi", "little master"])) {uid}}`,
+			`{"data":{"me":[{"uid":"0x4e20"},{"uid":"0x4e21"}]}}`,
+		},
+		{
+			`allof matches the nodes having all the values`,
+			`{me(func: allof(pet_name, ["master blaster", "little master"])) {uid}}`,
+			`{"data":{"me":[{"uid":"0x4e20"}]}}`,
+		},
+		{
+			`allof with values of different nodes`,
+			`{me(func: allof(pet_name, ["little master", "mahi"])) {uid}}`,
+			`{"data":{"me":[]}}`,
+		},
+		{
+			`the exact index is case sensitive`,
+			`{me(func: anyof(pet_name, ["MAHI"])) {uid}}`,
+			`{"data":{"me":[]}}`,
+		},
+		{
+			`an empty list matches no node`,
+			`{me(func: anyof(pet_name, [])) {uid}}`,
+			`{"data":{"me":[]}}`,
+		},
+		{
+			`in a filter`,
+			`{me(func: uid(20000, 20001)) @filter(allof(pet_name, ["mahi", "ms"])) {uid}}`,
+			`{"data":{"me":[{"uid":"0x4e21"}]}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			js := processQueryNoErr(t, tc.query)
+			require.JSONEq(t, tc.result, js)
+		})
+	}
+}
+
 func TestBetweenFloat(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -60,6 +60,35 @@ func TestSchemaBlock3(t *testing.T) {
 	require.JSONEq(t, `{"data":{"schema":[{"predicate":"age","type":"int","index":true,"tokenizer":["int"]}]}}`, js)
 }
 
+func TestSchemaBlockGroup(t *testing.T) {
+	query := `
+		schema(pred: [name, age]) {
+			type
+			group
+		}
+	`
+	js := processQueryNoErr(t, query)
+	var res struct {
+		Data struct {
+			Schema []struct {
+				Predicate string `json:"predicate"`
+				Type      string `json:"type"`
+				Group     uint32 `json:"group"`
+			} `json:"schema"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(js), &res))
+	require.Len(t, res.Data.Schema, 2)
+	for _, node := range res.Data.Schema {
+		// The predicates can be served by any group of the cluster.
+		require.NotZero(t, node.Group, "predicate %s", node.Predicate)
+	}
+
+	// The group is only returned when it's asked for.
+	js = processQueryNoErr(t, `schema(pred: age) { type }`)
+	require.JSONEq(t, `{"data":{"schema":[{"predicate":"age","type":"int"}]}}`, js)
+}
+
 func TestSchemaBlock4(t *testing.T) {
 	query := `
 		schema(pred: [age, genre, random]) {
@@ -719,6 +748,85 @@ func TestHasFuncAtRootWithAfterOnUIDsOtherThanRoot(t *testing.T) {
 	require.JSONEq(t, `{"data": {"me":[{"uid":"0x1","friend":[{"uid": "0x17"},{"uid": "0x18"}]},{"uid": "0x1f","friend": [{"uid": "0x18"}]}]}}`, js)
 }
 
+func TestAfterNested(t *testing.T) {
+	query := `
+	{
+		me(func: uid(0x1)) {
+			page: friend(first: 2, after: 0x17) {
+				uid
+			}
+			next: friend(first: 2, after: 0x19) {
+				uid
+			}
+		}
+		rev(func: uid(0x18)) {
+			~friend(first: 1, after: 0x1) {
+				uid
+			}
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"me":[{"page":[{"uid":"0x18"},{"uid":"0x19"}],"next":[{"uid":"0x1f"},{"uid":"0x65"}]}],
+		"rev":[{"~friend":[{"uid":"0x1f"}]}]}}`, js)
+}
+
+func TestAfterWithOrder(t *testing.T) {
+	// With an order, the results are the ones following the after uid in that order.
+	query := `
+	{
+		me(func: uid(1, 23, 24, 25, 31), orderasc: name, first: 2, after: 0x19) {
+			name
+		}
+		friends(func: uid(0x1, 0x1f)) {
+			name
+			friend(orderasc: name, first: 2, after: 0x19) {
+				name
+			}
+			next: friend(orderasc: name, first: 1, after: 0x18) {
+				name
+			}
+		}
+		rev(func: uid(0x18)) {
+			~friend(orderdesc: name, after: 0x1) {
+				name
+			}
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"me":[{"name":"Glenn Rhee"},{"name":"Michonne"}],
+		"friends":[
+			{"name":"Michonne","friend":[{"name":"Glenn Rhee"},{"name":"Rick Grimes"}],
+				"next":[{"name":"Rick Grimes"}]},
+			{"name":"Andrea","friend":[{"name":"Glenn Rhee"}]}],
+		"rev":[{"~friend":[{"name":"Andrea"}]}]}}`, js)
+}
+
+func TestAfterWithOrderByValue(t *testing.T) {
+	// The after uid doesn't have to be in the results, the cursor is its name: "Daryl Dixon".
+	query := `
+	{
+		asc(func: uid(1, 23, 24, 31), orderasc: name, after: 0x19) {
+			name
+		}
+		desc(func: uid(1, 23, 24, 31), orderdesc: name, after: 0x19) {
+			name
+		}
+		page(func: uid(1, 23, 24, 31), orderasc: name, first: 1, after: 0x19) {
+			name
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"asc":[{"name":"Glenn Rhee"},{"name":"Michonne"},{"name":"Rick Grimes"}],
+		"desc":[{"name":"Andrea"}],
+		"page":[{"name":"Glenn Rhee"}]}}`, js)
+}
+
 func TestHasFuncAtRootFilter(t *testing.T) {
 
 	query := `
@@ -1707,6 +1815,42 @@ func TestMathCeil2(t *testing.T) {
 	require.JSONEq(t, `{"data": {"me":[{"ceilAge":14.000000}]}}`, js)
 }
 
+func TestMathStringSimilarity(t *testing.T) {
+	query := `
+	{
+		var(func: uid(0x1, 0x2, 0x3, 0x4)) {
+			n as name
+			s as math(jarowinkler(n, "MARGRET"))
+		}
+
+		me(func: uid(s), orderdesc: val(s)) {
+			name
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Margaret"},{"name":"Michonne"},
+		{"name":"King Lear"},{"name":"Leonard"}]}}`, js)
+}
+
+func TestMathStringSimilarityWrongType(t *testing.T) {
+	query := `
+	{
+		var(func: uid(0x1)) {
+			a as age
+			s as math(cosine(a, "john"))
+		}
+
+		me(func: uid(s)) {
+			val(s)
+		}
+	}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Wrong types")
+}
+
 func TestUidAttr(t *testing.T) {
 	tests := []struct {
 		in, out, failure string
@@ -2294,6 +2438,67 @@ func TestMultiSortWithNulls(t *testing.T) {
 	}
 }
 
+func TestMultiSortPaginateWithNullsInFirstOrder(t *testing.T) {
+	// Sorted by pred2 asc and pred1 desc, the full result is:
+	// nameG, nameD, nameA, nameH, nameE, nameB, nameI, nameJ, nameF, nameC
+	// where nameJ, nameF and nameC don't have a value for pred2.
+	tests := []struct {
+		offset int32
+		first  int32
+		result string
+	}{
+		{0, 2, `{"data": {"me":[
+			{"pname":"nameG","pred1":"C", "pred2":"I"},
+			{"pname":"nameD","pred1":"B", "pred2":"I"}]}}`,
+		},
+		{4, 3, `{"data": {"me":[
+			{"pname":"nameE","pred1":"B", "pred2":"J"},
+			{"pname":"nameB","pred1":"A", "pred2":"J"},
+			{"pname":"nameI","pred1":"C", "pred2":"K"}]}}`,
+		},
+		{6, 3, `{"data": {"me":[
+			{"pname":"nameI","pred1":"C", "pred2":"K"},
+			{"pname":"nameJ","pred1":"C"},
+			{"pname":"nameF","pred1":"B"}]}}`,
+		},
+		{8, 2, `{"data": {"me":[
+			{"pname":"nameF","pred1":"B"},
+			{"pname":"nameC","pred1":"A"}]}}`,
+		},
+		{9, 5, `{"data": {"me":[
+			{"pname":"nameC","pred1":"A"}]}}`,
+		},
+	}
+
+	makeQuery := func(offset, first int32, index bool) string {
+		pred1 := "pred1"
+		pred2 := "pred2"
+		if index {
+			pred1 = "index-pred1"
+			pred2 = "index-pred2"
+		}
+		query := fmt.Sprintf(`{
+			me(func: uid(61, 62, 63, 64, 65, 66, 67, 68, 69, 70), orderasc: %s,
+				orderdesc: %s, offset: %d, first: %d) {
+				pname
+				pred1: %s
+				pred2: %s
+			}
+		}`, pred2, pred1, offset, first, pred1, pred2)
+		return processQueryNoErr(t, query)
+	}
+
+	for _, tc := range tests {
+		actual := makeQuery(tc.offset, tc.first, true)
+		require.JSONEqf(t, tc.result, actual, "Failed with index, offset: %d, first: %d",
+			tc.offset, tc.first)
+
+		actual = makeQuery(tc.offset, tc.first, false)
+		require.JSONEqf(t, tc.result, actual, "Failed without index, offset: %d, first: %d",
+			tc.offset, tc.first)
+	}
+}
+
 func TestMultiSortPaginateWithOffset(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -18,6 +18,7 @@ package query
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -719,6 +720,33 @@ func TestCountReverseFunc(t *testing.T) {
 		js)
 }
 
+func TestOrderByReverseCount(t *testing.T) {
+	query := `
+		{
+			desc(func: uid(1, 23, 24, 25, 31), orderdesc: count(~friend), first: 2) {
+				uid
+			}
+			asc(func: uid(2, 24, 25), orderasc: count(~friend)) {
+				uid
+			}
+			nodesWithoutEdgesLast(func: uid(2, 24, 25), orderdesc: count(~friend)) {
+				uid
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"desc": [{"uid": "0x18"}, {"uid": "0x1"}],
+		"asc": [{"uid": "0x19"}, {"uid": "0x18"}, {"uid": "0x2"}],
+		"nodesWithoutEdgesLast": [{"uid": "0x18"}, {"uid": "0x19"}, {"uid": "0x2"}]
+	}}`, js)
+
+	query = `{me(func: uid(1), orderdesc: count(~school)) {uid}}`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Need @reverse directive in schema for attr: school")
+}
+
 func TestCountReverseFilter(t *testing.T) {
 
 	query := `
@@ -735,6 +763,180 @@ func TestCountReverseFilter(t *testing.T) {
 		js)
 }
 
+func TestCountReverseFuncMatchesVarFilter(t *testing.T) {
+	// Filtering on the count of reverse edges at root should give the same result as computing
+	// the count in a var block and filtering on it.
+	atRoot := `
+		{
+			me(func: gt(count(~friend), 0)) {
+				uid
+			}
+		}
+	`
+	withVar := `
+		{
+			var(func: has(friend)) {
+				f as friend
+			}
+			var(func: uid(f)) {
+				c as count(~friend)
+			}
+			me(func: uid(c)) @filter(gt(val(c), 0)) {
+				uid
+			}
+		}
+	`
+	js := processQueryNoErr(t, atRoot)
+	require.JSONEq(t, js, processQueryNoErr(t, withVar))
+
+	js = processQueryNoErr(t, `
+		{
+			me(func: gt(count(~friend), 1)) {
+				name
+			}
+		}
+	`)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Glenn Rhee"}]}}`, js)
+}
+
+func TestCountReverseFuncWithoutReverse(t *testing.T) {
+	query := `
+		{
+			me(func: gt(count(~school), 1)) {
+				name
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Need @reverse directive in schema for attr: school")
+}
+
+func TestZeroCountReverseFilter(t *testing.T) {
+	// count(~friend) is zero for King Lear and Andrea With no friends, who aren't anyone's friend.
+	// Zero counts aren't in the count index, so this needs to work from the reverse edges.
+	query := `
+		{
+			me(func: anyofterms(name, "Michonne Rick Andrea King")) @filter(eq(count(~friend), 0)) {
+				name
+				count(~friend)
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[
+		{"name":"King Lear","count(~friend)":0},
+		{"name":"Andrea With no friends","count(~friend)":0}]}}`, js)
+
+	query = `
+		{
+			me(func: anyofterms(name, "Michonne Rick Andrea King")) @filter(not has(~friend)) {
+				name
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"King Lear"},{"name":"Andrea With no friends"}]}}`,
+		js)
+
+	query = `
+		{
+			me(func: anyofterms(name, "Michonne Rick Andrea King")) @filter(le(count(~friend), 0)
+				or between(count(~friend), 2, 3)) {
+				name
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"King Lear"},{"name":"Andrea With no friends"}]}}`,
+		js)
+}
+
+func TestZeroCountReverseFilterNested(t *testing.T) {
+	query := `
+		{
+			me(func: uid(64)) {
+				~best_friend @filter(eq(count(~friend), 0)) {
+					name
+				}
+				withFriend: ~best_friend @filter(has(~friend)) {
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"~best_friend":[
+		{"name":"King Lear"},{"name":"Margaret"},{"name":"Leonard"}]}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(1)) {
+				friend @filter(not has(~friend) or eq(count(~friend), 0)) {
+					name
+				}
+				count: count(friend @filter(gt(count(~friend), 0)))
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"count":5}]}}`, js)
+}
+
+func TestZeroCountFilterUnknownPredicate(t *testing.T) {
+	// A predicate without data has a count of zero for every node.
+	query := `
+		{
+			me(func: uid(1, 23)) @filter(eq(count(no_such_predicate), 0)) {
+				name
+			}
+			none(func: uid(1, 23)) @filter(gt(count(no_such_predicate), 0)) {
+				name
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Michonne"},{"name":"Rick Grimes"}],"none":[]}}`,
+		js)
+
+	query = `
+		{
+			me(func: uid(1, 23)) @filter(eq(count(~no_such_predicate), 0)) {
+				name
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate no_such_predicate doesn't have reverse edge")
+}
+
+func TestZeroCountReverseFilterWithoutReverse(t *testing.T) {
+	query := `
+		{
+			me(func: uid(1, 23)) @filter(eq(count(~school), 0)) {
+				name
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate school doesn't have reverse edge")
+}
+
+func TestZeroCountReverseFuncAtRoot(t *testing.T) {
+	query := `
+		{
+			me(func: eq(count(~friend), 0)) {
+				name
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Zero counts can be matched in a filter")
+}
+
 func TestCountReverse(t *testing.T) {
 
 	query := `
@@ -2686,6 +2888,132 @@ func TestIntersectsPolygon2(t *testing.T) {
 	require.JSONEq(t, expected, js)
 }
 
+func TestDistanceFilterAndSort(t *testing.T) {
+	query := `{
+		var(func: has(geometry)) {
+			d as distance(geometry, [-122.25, 37.55])
+		}
+
+		me(func: uid(d), orderasc: val(d)) @filter(lt(val(d), 5000)) {
+			name
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	expected := `{"data": {"me":[{"name":"SF Bay area"},{"name":"San Carlos"},
+		{"name":"San Carlos Airport"}]}}`
+	require.JSONEq(t, expected, js)
+}
+
+func TestDistanceInsidePolygon(t *testing.T) {
+	query := `{
+		me(func: uid(5104, 5105)) {
+			name
+			distance(geometry, [-122.082506, 37.4249518])
+			dist: distance(geometry, [-122.082506, 37.4249518])
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	expected := `{"data": {"me":[
+		{"name":"SF Bay area", "distance(geometry)": 0, "dist": 0},
+		{"name":"Mountain View", "distance(geometry)": 0, "dist": 0}]}}`
+	require.JSONEq(t, expected, js)
+}
+
+func TestDistanceNotGeo(t *testing.T) {
+	query := `{
+		me(func: uid(5104)) {
+			distance(name, [-122.082506, 37.4249518])
+		}
+	}`
+
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "distance fn can only be used on attr: [name]")
+}
+
+func TestRelevanceOrder(t *testing.T) {
+	query := `{
+		var(func: anyoftext(synopsis, "graph search")) {
+			s as relevance(synopsis, "graph search")
+		}
+
+		me(func: uid(s), orderdesc: val(s)) {
+			uid
+		}
+	}`
+
+	// The value of 5402 has "graph" three times, but is longer and doesn't have "search".
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x1519"},{"uid":"0x151a"},{"uid":"0x151c"}]}}`, js)
+}
+
+func TestRelevanceMultipleFields(t *testing.T) {
+	query := `{
+		var(func: anyoftext(synopsis, "graph search")) {
+			s as relevance(synopsis, "graph search")
+			h as relevance(headline, "graph search")
+			total as math(s + 2 * h)
+		}
+
+		me(func: uid(total), orderdesc: val(total)) {
+			uid
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x151c"},{"uid":"0x1519"},{"uid":"0x151a"}]}}`, js)
+}
+
+func TestRelevanceNoMatch(t *testing.T) {
+	query := `{
+		me(func: uid(5402, 5403)) {
+			uid
+			score: relevance(synopsis, "relational")
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x151a"},
+		{"uid":"0x151b", "score":1.428781004303808}]}}`, js)
+}
+
+func TestRelevanceLanguages(t *testing.T) {
+	query := `{
+		me(func: uid(5405, 5406)) {
+			uid
+			score: relevance(blurb, "graph search")
+		}
+	}`
+
+	// Each value of 5405 is scored on its own, so the value in french doesn't change the score
+	// of the one in english.
+	var resp struct {
+		Data struct {
+			Me []struct {
+				Score float64 `json:"score"`
+			} `json:"me"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(processQueryNoErr(t, query)), &resp))
+	require.Len(t, resp.Data.Me, 2)
+	require.Greater(t, resp.Data.Me[0].Score, 0.0)
+	require.Equal(t, resp.Data.Me[1].Score, resp.Data.Me[0].Score)
+}
+
+func TestRelevanceNotIndexed(t *testing.T) {
+	query := `{
+		me(func: uid(61)) {
+			relevance(tweet-c, "citizen")
+		}
+	}`
+
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "relevance fn requires @index(bm25) on attr: [tweet-c]")
+}
+
 func TestNotExistObject(t *testing.T) {
 
 	// we haven't set genre(type:uid) for 0x01, should just be ignored
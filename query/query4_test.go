@@ -1561,3 +1561,611 @@ func TestNumUids(t *testing.T) {
 	require.Equal(t, metrics.NumUids["name"], uint64(16))
 	require.Equal(t, metrics.NumUids["_total"], uint64(26))
 }
+
+func TestTotalCount(t *testing.T) {
+	// has() without filters is paginated while reading the index, which can't be done when
+	// the total is needed.
+	query := `
+		{
+			me(func: has(friend), first: 1) @totalcount {
+				uid
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"totalCount":3},{"uid":"0x1"}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(1, 23, 24, 25, 31), first: 1, offset: 1) @filter(eq(alive, true))
+				@totalcount {
+				name
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"totalCount":2},{"name":"Rick Grimes"}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(1, 23, 24, 25, 31), first: 1) @totalcount @cascade {
+				name
+				alive
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t,
+		`{"data": {"me":[{"totalCount":4},{"name":"Michonne","alive":true}]}}`, js)
+}
+
+func TestTotalCountNoResults(t *testing.T) {
+	query := `
+		{
+			me(func: uid(1), first: 1) @filter(eq(alive, false)) @totalcount {
+				name
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"totalCount":0}]}}`, js)
+}
+
+func TestCoercionFuncs(t *testing.T) {
+	s := testSchema + "\n legacy_score: string .\n"
+	setSchema(s)
+	defer setSchema(testSchema)
+	triples := `
+		<1> <legacy_score> "12" .
+		<23> <legacy_score> " 7 " .
+		<24> <legacy_score> "3.5" .
+		<25> <legacy_score> "n/a" .
+		<31> <legacy_score> "100" .
+	`
+	require.NoError(t, addTriplesToCluster(triples))
+	defer deleteTriplesInCluster(triples)
+
+	// Compared as strings, "100" would be less than "12" and "7".
+	query := `
+		{
+			var(func: has(legacy_score)) {
+				s as legacy_score
+			}
+			me(func: uid(s)) @filter(gt(toint(val(s)), 5)) {
+				name
+			}
+			root(func: lt(toFloat(val(s)), 10), orderasc: name) {
+				name
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"me":[{"name":"Michonne"},{"name":"Rick Grimes"},{"name":"Andrea"}],
+		"root":[{"name":"Glenn Rhee"},{"name":"Rick Grimes"}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(1, 24, 25)) {
+				s as legacy_score
+				double: math(tofloat(s) * 2)
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[
+		{"legacy_score":"12","double":24.0},
+		{"legacy_score":"3.5","double":7.0},
+		{"legacy_score":"n/a"}]}}`, js)
+}
+
+func TestSplitFunc(t *testing.T) {
+	s := testSchema + "\n legacy_tags: string .\n"
+	setSchema(s)
+	defer setSchema(testSchema)
+	triples := `
+		<1> <legacy_tags> "red, green" (since=2006-01-02T15:04:05) .
+		<23> <legacy_tags> " green,," .
+		<24> <legacy_tags> "red,red" .
+		<25> <legacy_tags> "red" .
+		<31> <legacy_tags> "blue" .
+	`
+	require.NoError(t, addTriplesToCluster(triples))
+	defer deleteTriplesInCluster(triples)
+
+	query := `
+		{
+			me(func: has(legacy_tags)) @groupby(split(legacy_tags, ",")) {
+				count(uid)
+			}
+			aliased(func: has(legacy_tags)) @groupby(tag: split(legacy_tags, ","), age) {
+				count(uid)
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"me":[{"@groupby":[
+			{"legacy_tags":"blue","count":1},
+			{"legacy_tags":"green","count":2},
+			{"legacy_tags":"red","count":3}]}],
+		"aliased":[{"@groupby":[
+			{"tag":"blue","age":19,"count":1},
+			{"tag":"green","age":15,"count":1},
+			{"tag":"green","age":38,"count":1},
+			{"tag":"red","age":15,"count":1},
+			{"tag":"red","age":17,"count":1},
+			{"tag":"red","age":38,"count":1}]}]}}`, js)
+
+	query = `
+		{
+			var(func: has(legacy_tags)) {
+				t as legacy_tags
+			}
+			me(func: uid(t)) @filter(eq(split(val(t), ","), "green")) {
+				name
+				legacy_tags @facets
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[
+		{"name":"Michonne","legacy_tags":"red, green",
+			"legacy_tags|since":"2006-01-02T15:04:05Z"},
+		{"name":"Rick Grimes","legacy_tags":" green,,"}]}}`, js)
+}
+
+func TestReachableFunc(t *testing.T) {
+	query := `
+		{
+			me(func: reachable(newfriend, 0x1f5, 2)) {
+				newname
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"newname":"P1"},{"newname":"P2"},{"newname":"P3"},
+		{"newname":"P5"},{"newname":"P6"},{"newname":"P7"},{"newname":"P8"}]}}`, js)
+
+	// The cycles between the nodes are only followed once.
+	query = `
+		{
+			me(func: reachable(connects, 51, 5)) {
+				uid
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x33"},{"uid":"0x34"},{"uid":"0x35"},
+		{"uid":"0x36"},{"uid":"0x37"}]}}`, js)
+
+	query = `
+		{
+			seed as var(func: eq(newname, "P2"))
+			me(func: reachable(newfriend, uid(seed), 1)) {
+				newname
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"newname":"P2"},{"newname":"P5"},{"newname":"P6"}]}}`,
+		js)
+
+	query = `
+		{
+			me(func: has(newname)) @filter(reachable(newfriend, 0x1f5, 1)) {
+				newname
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"newname":"P1"},{"newname":"P2"},{"newname":"P3"}]}}`,
+		js)
+}
+
+func TestReachableFuncInvalidDepth(t *testing.T) {
+	query := `
+		{
+			me(func: reachable(newfriend, 0x1f5, 0)) {
+				newname
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid maximum depth")
+}
+
+func TestProjectType(t *testing.T) {
+	// 0xca is both a CarModel and an Object, it gets the predicates of both types. The owner
+	// predicate is already queried, so it isn't projected.
+	query := `
+		{
+			me(func: uid(0xca), projectType: true) {
+				uid
+				owner {
+					owner_name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0xca","owner":[{"owner_name":"Owner of Prius"}],
+		"name":"Car","make":"Toyota","model":"Prius","year":2009}]}}`, js)
+
+	// The uid predicates, including the reverse predicates of the type, return the uids they
+	// point to, unless projectType is set for them too.
+	query = `
+		{
+			me(func: uid(0xc9), projectType: true) {
+				previous_model(projectType: true)
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"make":"Ford","model":"Focus","year":2009,
+		"previous_model":{"make":"Ford","model":"Focus","year":2008,
+		"~previous_model":[{"uid":"0xc9"}]}}]}}`, js)
+}
+
+func TestProjectTypeInvalidValue(t *testing.T) {
+	query := `
+		{
+			me(func: uid(0xca), projectType: yes) {
+				uid
+			}
+		}
+	`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "projectType should be true or false, got: yes")
+}
+
+func TestNotEqFilter(t *testing.T) {
+	// 0x18 doesn't have the alive predicate, so it isn't returned by not eq.
+	query := `
+		{
+			me(func: uid(0x1, 0x17, 0x18, 0x19, 0x1f)) @filter(not eq(alive, true)) {
+				name
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Daryl Dixon"},{"name":"Andrea"}]}}`, js)
+
+	query = `
+		{
+			me(func: uid(0x1, 0x17, 0x18, 0x19, 0x1f))
+				@filter(not eq(alive, true) or not has(alive)) {
+				name
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"name":"Glenn Rhee"},{"name":"Daryl Dixon"},
+		{"name":"Andrea"}]}}`, js)
+
+	// A node of a list predicate is excluded if any of its values is equal.
+	query = `
+		{
+			me(func: uid(0x3d, 0x4e20, 0x4e21)) @filter(not eq(pet_name, "mahi")) {
+				uid
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x4e20"}]}}`, js)
+
+	// Without an index, the nodes which don't have the predicate are returned.
+	query = `
+		{
+			me(func: uid(0x1, 0x2, 0x3, 0x4, 0x5)) @filter(not eq(noindex_alive, true)) {
+				uid
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me":[{"uid":"0x2"},{"uid":"0x3"},{"uid":"0x5"}]}}`, js)
+}
+
+func TestOrderByValueVarFromOtherBlock(t *testing.T) {
+	query := `
+		{
+			var(func: uid(1, 23, 24)) {
+				s as math(age * 2)
+			}
+
+			desc(func: uid(1, 23, 24, 25, 31), orderdesc: val(s)) {
+				name
+				val(s)
+			}
+
+			page(func: uid(1, 23, 24, 25, 31), orderasc: val(s), first: 2, offset: 2) {
+				name
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"desc": [
+				{"name": "Michonne", "val(s)": 76},
+				{"name": "Rick Grimes", "val(s)": 30},
+				{"name": "Glenn Rhee", "val(s)": 30},
+				{"name": "Daryl Dixon"},
+				{"name": "Andrea"}
+			],
+			"page": [
+				{"name": "Michonne"},
+				{"name": "Daryl Dixon"}
+			]
+		}
+	}`, js)
+}
+
+func TestIntersectHint(t *testing.T) {
+	expected := `
+	{
+		"data": {
+			"me": [{
+				"friend": [
+					{"name": "Rick Grimes"},
+					{"name": "Glenn Rhee"}
+				]
+			}]
+		}
+	}`
+	// The hint only changes how the filters are run, the results are the same with invalid
+	// hints, which are ignored.
+	for _, hint := range []string{
+		"",
+		"@intersect(order: [alias, age])",
+		"@intersect(order: [age], method: hash)",
+		"@intersect(method: merge)",
+		"@intersect(order: [unknown, alias], method: unknown)",
+	} {
+		query := `
+		{
+			me(func: uid(1)) {
+				friend @filter(lt(age, 17) AND anyofterms(alias, "Alice Matt")) ` + hint + ` {
+					name
+				}
+			}
+		}
+		`
+		js := processQueryNoErr(t, query)
+		require.JSONEq(t, expected, js, hint)
+	}
+
+	query := `
+	{
+		me(func: anyofterms(alias, "Alice Matt")) @filter(lt(age, 17) AND has(name))
+			@intersect(order: [age, name], method: hash) {
+			name
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"me": [{"name": "Rick Grimes"}, {"name": "Glenn Rhee"}]}}`, js)
+}
+
+func TestSoftDelete(t *testing.T) {
+	setSchema(testSchema + `
+		sd_title: string .
+		sd_deleted: bool .
+		sd_posts: [uid] @reverse @count .
+		type SoftPost @softDelete(on: sd_deleted) {
+			sd_title
+			sd_deleted
+		}
+		type SoftUser @softDelete(on: sd_deleted) {
+			sd_deleted
+			sd_posts
+		}
+	`)
+	defer func() {
+		for _, typ := range []string{"SoftPost", "SoftUser"} {
+			require.NoError(t, client.Alter(context.Background(), &api.Operation{
+				DropOp: api.Operation_TYPE, DropValue: typ}))
+		}
+		for _, pred := range []string{"sd_title", "sd_deleted", "sd_posts"} {
+			dropPredicate(pred)
+		}
+	}()
+
+	// 0x5003 is marked as deleted, but isn't of a @softDelete type. The user 0x5004, which is
+	// deleted, points to a post which isn't.
+	require.NoError(t, addTriplesToCluster(`
+		<0x5000> <dgraph.type> "SoftUser" .
+		<0x5000> <sd_posts> <0x5001> .
+		<0x5000> <sd_posts> <0x5002> .
+		<0x5000> <sd_posts> <0x5003> .
+		<0x5001> <dgraph.type> "SoftPost" .
+		<0x5001> <sd_title> "kept" .
+		<0x5002> <dgraph.type> "SoftPost" .
+		<0x5002> <sd_title> "deleted" .
+		<0x5002> <sd_deleted> "true" .
+		<0x5003> <sd_title> "untyped" .
+		<0x5003> <sd_deleted> "true" .
+		<0x5004> <dgraph.type> "SoftUser" .
+		<0x5004> <sd_deleted> "true" .
+		<0x5004> <sd_posts> <0x5001> .
+	`))
+
+	query := `
+	{
+		posts(func: has(sd_title)) {
+			sd_title
+		}
+		user(func: uid(0x5000, 0x5004)) {
+			uid
+			count(sd_posts)
+			sd_posts {
+				sd_title
+			}
+		}
+		reverse(func: uid(0x5001)) {
+			count(~sd_posts)
+			~sd_posts {
+				uid
+			}
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"posts": [{"sd_title": "kept"}, {"sd_title": "untyped"}],
+			"user": [{
+				"uid": "0x5000",
+				"count(sd_posts)": 2,
+				"sd_posts": [{"sd_title": "kept"}, {"sd_title": "untyped"}]
+			}],
+			"reverse": [{"count(~sd_posts)": 1, "~sd_posts": [{"uid": "0x5000"}]}]
+		}
+	}`, js)
+
+	query = `
+	{
+		posts(func: has(sd_title), includeDeleted: true) {
+			sd_title
+		}
+		user(func: uid(0x5000), includeDeleted: true) {
+			count(sd_posts)
+		}
+	}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"posts": [{"sd_title": "kept"}, {"sd_title": "deleted"}, {"sd_title": "untyped"}],
+			"user": [{"count(sd_posts)": 3}]
+		}
+	}`, js)
+
+	_, err := processQuery(context.Background(), t,
+		`{ q(func: has(sd_title), includeDeleted: maybe) { sd_title } }`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "includeDeleted should be true or false")
+}
+
+func TestSampleAtRoot(t *testing.T) {
+	query := `
+	{
+		has(func: has(name), sample: 3, seed: 7) {
+			count(uid)
+		}
+		fewer(func: uid(1, 23), sample: 5) {
+			uid
+		}
+		filtered(func: uid(1, 23, 24, 25, 31), sample: 2) @filter(uid(23, 24, 25)) {
+			count(uid)
+		}
+		index(func: eq(alive, true), sample: 1) {
+			count(uid)
+		}
+		tokens(func: eq(alive, true, false), sample: 3) {
+			count(uid)
+		}
+		all(func: eq(alive, true), sample: 5) {
+			uid
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"has": [{"count": 3}],
+		"fewer": [{"uid": "0x1"}, {"uid": "0x17"}],
+		"filtered": [{"count": 2}],
+		"index": [{"count": 1}],
+		"tokens": [{"count": 3}],
+		"all": [{"uid": "0x1"}, {"uid": "0x17"}]
+	}}`, js)
+
+	// The same seed gives the same sample, whether it's taken by the worker or not.
+	for _, q := range []string{
+		`{me(func: has(name), sample: 5, seed: 42) {uid}}`,
+		`{me(func: eq(alive, true, false), sample: 2, seed: 42) {uid}}`,
+		`{me(func: eq(alive, false), sample: 1, seed: 42) {uid}}`,
+	} {
+		require.Equal(t, processQueryNoErr(t, q), processQueryNoErr(t, q))
+	}
+
+	_, err := processQuery(context.Background(), t, `{me(func: has(name), seed: 42) {uid}}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "seed can only be used along with sample")
+}
+
+func TestFuncLimitAtRoot(t *testing.T) {
+	query := `
+	{
+		limited(func: has(name)@limit(3)) {
+			count(uid)
+		}
+		offset(func: has(name)@limit(3), offset: 1) {
+			count(uid)
+		}
+		index(func: anyofterms(name, "Michonne Rick Andrea")@limit(2)) {
+			count(uid)
+		}
+	}`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {
+		"limited": [{"count": 3}],
+		"offset": [{"count": 2}],
+		"index": [{"count": 2}]
+	}}`, js)
+
+	// The lowest uids are kept, as with first.
+	require.Equal(t, processQueryNoErr(t, `{me(func: has(name), first: 4) {uid}}`),
+		processQueryNoErr(t, `{me(func: has(name)@limit(4)) {uid}}`))
+
+	// With several tokens the limit applies to the merged uids, not to those of each token.
+	require.Equal(t,
+		processQueryNoErr(t, `{me(func: anyofterms(name, "Michonne Rick Andrea"), first: 2) {uid}}`),
+		processQueryNoErr(t, `{me(func: anyofterms(name, "Michonne Rick Andrea")@limit(2)) {uid}}`))
+}
+
+func TestValueFormat(t *testing.T) {
+	query := `
+	{
+		me(func: uid(1), datetimeFormat: "2006-01-02", floatPrecision: 1) {
+			dob
+			survival_rate
+		}
+		tz(func: uid(1), datetimeFormat: "2006-01-02 15:04 -0700", timezone: "America/New_York") {
+			dob
+			survival_rate
+		}
+		default(func: uid(1)) {
+			dob
+			survival_rate
+		}
+	}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"me": [{"dob": "1910-01-01", "survival_rate": 99.0}],
+			"tz": [{"dob": "1909-12-31 19:00 -0500", "survival_rate": 98.99}],
+			"default": [{"dob": "1910-01-01T00:00:00Z", "survival_rate": 98.99}]
+		}
+	}`, js)
+
+	for _, tc := range []struct {
+		arg string
+		err string
+	}{
+		{`datetimeFormat: "date"`, "datetimeFormat should be a Go time layout"},
+		{`timezone: "Mars/Olympus"`, "while parsing the timezone"},
+		{`floatPrecision: 40`, "floatPrecision should be an integer between 0 and 17"},
+	} {
+		_, err := processQuery(context.Background(), t,
+			`{ q(func: uid(1), `+tc.arg+`) { dob } }`)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
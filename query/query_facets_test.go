@@ -17,6 +17,7 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -99,6 +100,10 @@ func populateClusterWithFacets() error {
 	triples += fmt.Sprintf("<34> <friend> <31> %s .\n", friendFacets8)
 	triples += fmt.Sprintf("<34> <friend> <25> %s .\n", friendFacets9)
 
+	triples += "<12001> <owner> <12002> (tags=[\"work\", \"college\"], rating=[3, 5]) .\n"
+	triples += "<12001> <owner> <12003> (tags=\"home\", rating=[4, 1]) .\n"
+	triples += "<12004> <owner> <12005> (tags=[\"solo\"], rating=2) .\n"
+
 	err := addTriplesToCluster(triples)
 
 	// Mark the setup as done so that the next tests do not have to perform it.
@@ -581,6 +586,113 @@ func TestFacetsMultipleOrderbyMissingFacets(t *testing.T) {
 	`, js)
 }
 
+// The edges have to be sorted by facet before they are paginated, otherwise the page would be
+// taken from the edges sorted by uid.
+func TestOrderFacetsWithPagination(t *testing.T) {
+	populateClusterWithFacets()
+	query := `
+		{
+			me(func: uid(1)) {
+				friend @facets(orderdesc: since) (first: 2) {
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+		{
+		    "data": {
+		        "me": [
+		            {
+		                "friend": [
+		                    {
+		                        "name": "Daryl Dixon",
+		                        "friend|since": "2007-05-02T15:04:05Z"
+		                    },
+		                    {
+		                        "name": "Rick Grimes",
+		                        "friend|since": "2006-01-02T15:04:05Z"
+		                    }
+		                ]
+		            }
+		        ]
+		    }
+		}
+	`, js)
+
+	query = `
+		{
+			me(func: uid(1)) {
+				friend @facets(orderdesc: since) (first: 2, offset: 1) {
+					name
+				}
+			}
+		}
+	`
+	js = processQueryNoErr(t, query)
+	require.JSONEq(t, `
+		{
+		    "data": {
+		        "me": [
+		            {
+		                "friend": [
+		                    {
+		                        "name": "Rick Grimes",
+		                        "friend|since": "2006-01-02T15:04:05Z"
+		                    },
+		                    {
+		                        "name": "Andrea",
+		                        "friend|since": "2006-01-02T15:04:05Z"
+		                    }
+		                ]
+		            }
+		        ]
+		    }
+		}
+	`, js)
+}
+
+// Edges which don't have the facet are sorted to the end, also when paginating.
+func TestOrderFacetsMissingWithPagination(t *testing.T) {
+	populateClusterWithFacets()
+	query := `
+		{
+			me(func: uid(1)) {
+				friend @facets(orderdesc: age) (first: 3) {
+					uid
+					name
+				}
+			}
+		}
+	`
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+		{
+		    "data": {
+		        "me": [
+		            {
+		                "friend": [
+		                    {
+		                        "uid": "0x65",
+		                        "friend|age": 33
+		                    },
+		                    {
+		                        "uid": "0x17",
+		                        "name": "Rick Grimes"
+		                    },
+		                    {
+		                        "uid": "0x18",
+		                        "name": "Glenn Rhee"
+		                    }
+		                ]
+		            }
+		        ]
+		    }
+		}
+	`, js)
+}
+
 func TestRetrieveFacetsAsVars(t *testing.T) {
 	populateClusterWithFacets()
 	// to see how friend @facets are positioned in output.
@@ -2432,3 +2544,166 @@ func TestCountFacetsFilteringScalarListPredicate(t *testing.T) {
 		}
 	}`, js)
 }
+
+func TestFacetsList(t *testing.T) {
+	populateClusterWithFacets()
+
+	query := `{
+		q(func: uid(12001)) {
+			owner @facets(tags, rating) {
+				uid
+			}
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"q": [
+				{
+					"owner": [
+						{
+							"uid": "0x2ee2",
+							"owner|tags": ["work", "college"],
+							"owner|rating": [3, 5]
+						},
+						{
+							"uid": "0x2ee3",
+							"owner|tags": "home",
+							"owner|rating": [4, 1]
+						}
+					]
+				}
+			]
+		}
+	}`, js)
+}
+
+func TestFacetsListSingleValue(t *testing.T) {
+	populateClusterWithFacets()
+
+	// A list of a single value is returned as a list.
+	query := `{
+		q(func: uid(12004)) {
+			owner @facets(tags, rating) @facets(eq(tags, "solo")) {
+				uid
+			}
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `{"data": {"q": [{"owner": [
+		{"uid": "0x2ee5", "owner|tags": ["solo"], "owner|rating": 2}
+	]}]}}`, js)
+}
+
+func TestFacetsListFilter(t *testing.T) {
+	populateClusterWithFacets()
+
+	tests := []struct {
+		filter string
+		result string
+	}{
+		{`eq(tags, "college")`, `[{"uid": "0x2ee2"}]`},
+		{`eq(tags, "home")`, `[{"uid": "0x2ee3"}]`},
+		{`anyofterms(tags, "work home")`, `[{"uid": "0x2ee2"}, {"uid": "0x2ee3"}]`},
+		{`not eq(tags, "work")`, `[{"uid": "0x2ee3"}]`},
+		{`ge(rating, 5)`, `[{"uid": "0x2ee2"}]`},
+		{`lt(rating, 2)`, `[{"uid": "0x2ee3"}]`},
+	}
+	for _, tc := range tests {
+		query := fmt.Sprintf(`{
+			q(func: uid(12001)) {
+				owner @facets(%s) {
+					uid
+				}
+			}
+		}`, tc.filter)
+
+		js := processQueryNoErr(t, query)
+		require.JSONEq(t, fmt.Sprintf(`{"data": {"q": [{"owner": %s}]}}`, tc.result), js,
+			tc.filter)
+	}
+}
+
+func TestFacetsListOrder(t *testing.T) {
+	populateClusterWithFacets()
+
+	// List facets are ordered by their first value.
+	query := `{
+		q(func: uid(12001)) {
+			owner @facets(orderdesc: rating) {
+				uid
+			}
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"q": [
+				{
+					"owner": [
+						{"uid": "0x2ee3", "owner|rating": [4, 1]},
+						{"uid": "0x2ee2", "owner|rating": [3, 5]}
+					]
+				}
+			]
+		}
+	}`, js)
+}
+
+func TestNestFacets(t *testing.T) {
+	populateClusterWithFacets()
+
+	query := `{
+		q(func: uid(1), nestFacets: true) {
+			name @facets
+			alt_name @facets
+			friend @facets(since) @filter(uid(23, 24)) {
+				name
+			}
+		}
+	}`
+
+	js := processQueryNoErr(t, query)
+	require.JSONEq(t, `
+	{
+		"data": {
+			"q": [
+				{
+					"name": "Michonne",
+					"name@facets": {"origin": "french", "dummy": true},
+					"alt_name": ["Michelle", "Michelin"],
+					"alt_name@facets": {
+						"0": {"origin": "french", "dummy": true},
+						"1": {"origin": "spanish", "dummy": false, "isNick": true}
+					},
+					"friend": [
+						{
+							"name": "Rick Grimes",
+							"@facets": {"since": "2006-01-02T15:04:05Z"}
+						},
+						{
+							"name": "Glenn Rhee",
+							"@facets": {"since": "2004-05-02T15:04:05Z"}
+						}
+					]
+				}
+			]
+		}
+	}`, js)
+}
+
+func TestNestFacetsInvalidValue(t *testing.T) {
+	query := `{
+		q(func: uid(1), nestFacets: yes) {
+			name @facets
+		}
+	}`
+	_, err := processQuery(context.Background(), t, query)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "nestFacets should be true or false, got: yes")
+}
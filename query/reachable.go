@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const reachableFn = "reachable"
+
+// reachableArgs returns the seed uids and the maximum depth of reachable(pred, seeds..., depth).
+// The seeds are either uids, or the uids of a variable, which have been set as arguments by
+// fillVars. A variable without uids doesn't leave any seed.
+func (sg *SubGraph) reachableArgs() ([]uint64, uint64, error) {
+	args := sg.SrcFunc.Args
+	if len(args) == 0 || (len(args) == 1 && len(sg.Params.NeedsVar) == 0) {
+		return nil, 0, errors.Errorf("Function %s expects a predicate, the seed uids and a "+
+			"maximum depth, e.g. %s(friend, 0x1, 5)", reachableFn, reachableFn)
+	}
+	depth, err := strconv.ParseUint(args[len(args)-1].Value, 10, 64)
+	if err != nil || depth == 0 {
+		return nil, 0, errors.Errorf("Invalid maximum depth %q for function %s. It must be a "+
+			"positive integer", args[len(args)-1].Value, reachableFn)
+	}
+	seeds := make([]uint64, 0, len(args)-1)
+	for _, arg := range args[:len(args)-1] {
+		uid, err := strconv.ParseUint(arg.Value, 0, 64)
+		if err != nil || uid == 0 {
+			return nil, 0, errors.Errorf("Invalid seed uid %q for function %s", arg.Value,
+				reachableFn)
+		}
+		seeds = append(seeds, uid)
+	}
+	sort.Slice(seeds, func(i, j int) bool { return seeds[i] < seeds[j] })
+	uniq := seeds[:0]
+	for i, uid := range seeds {
+		if i == 0 || uid != seeds[i-1] {
+			uniq = append(uniq, uid)
+		}
+	}
+	return uniq, depth, nil
+}
+
+// applyReachableFunc evaluates reachable(pred, seeds..., depth). It returns the uids which can be
+// reached from the seeds by following edges of pred at most depth times, including the seeds,
+// each of them once. The graph is traversed one level at a time and only the uids which weren't
+// reached yet are expanded, so cycles are only visited once. The traversal stops with an error
+// once more uids than the query-edge limit have been reached. In a filter, the uids being
+// filtered are intersected with the reachable ones.
+func (sg *SubGraph) applyReachableFunc(ctx context.Context) error {
+	seeds, depth, err := sg.reachableArgs()
+	if err != nil {
+		return err
+	}
+	namespace, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "While evaluating function %s", reachableFn)
+	}
+	attr := sg.Attr
+	reverse := strings.HasPrefix(attr, "~")
+	attr = strings.TrimPrefix(attr, "~")
+
+	mem := memoryAccountFromContext(ctx)
+	reached := &pb.List{Uids: seeds}
+	frontier := &pb.List{Uids: seeds}
+	for level := uint64(0); level < depth && len(frontier.Uids) > 0; level++ {
+		if err := mem.check(); err != nil {
+			return err
+		}
+		res, err := worker.ProcessTaskOverNetwork(ctx, &pb.Query{
+			Attr:       x.NamespaceAttr(namespace, attr),
+			Reverse:    reverse,
+			UidList:    frontier,
+			ReadTs:     sg.ReadTs,
+			Cache:      int32(sg.Cache),
+			TxnStartTs: sg.TxnStartTs,
+		})
+		switch {
+		case err != nil && strings.Contains(err.Error(), worker.ErrNonExistentTabletMessage):
+			// The predicate doesn't have any edge.
+			res = &pb.Result{}
+		case err != nil:
+			return err
+		}
+		if err := mem.add(resultMemory(res)); err != nil {
+			return err
+		}
+
+		next := algo.Difference(algo.MergeSorted(res.UidMatrix), reached)
+		reached = algo.MergeSorted([]*pb.List{reached, next})
+		if uint64(len(reached.Uids)) > x.Config.LimitQueryEdge {
+			return errors.Errorf("Exceeded query edge limit = %v. Found more than %v uids "+
+				"reachable with function %s.", x.Config.LimitQueryEdge, x.Config.LimitQueryEdge,
+				reachableFn)
+		}
+		frontier = next
+	}
+
+	if sg.SrcUIDs != nil {
+		sg.DestUIDs = algo.IntersectSorted([]*pb.List{sg.SrcUIDs, reached})
+		return nil
+	}
+	sg.DestUIDs = reached
+	sg.uidMatrix = []*pb.List{sg.DestUIDs}
+	return nil
+}
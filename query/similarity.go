@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"math"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeForSimilarity lowercases the string and normalizes it using NFKC, the same way the
+// term and fulltext tokenizers do. This way, "Ｊｏｈｎ" and "john" are considered the same.
+func normalizeForSimilarity(s string) []rune {
+	return []rune(norm.NFKC.String(strings.ToLower(s)))
+}
+
+// jaroSimilarity returns the Jaro similarity of a and b, between 0 (no similarity) and 1 (the
+// strings are the same).
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	// Characters are only considered matching if they aren't farther apart than this.
+	window := len(a)
+	if len(b) > window {
+		window = len(b)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	aMatched, bMatched := make([]bool, len(a)), make([]bool, len(b))
+	matches := 0
+	for i := range a {
+		lo, hi := i-window, i+window+1
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(b) {
+			hi = len(b)
+		}
+		for j := lo; j < hi; j++ {
+			if !bMatched[j] && a[i] == b[j] {
+				aMatched[i], bMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Count the matching characters which are in a different order.
+	transpositions, j := 0, 0
+	for i := range a {
+		if !aMatched[i] {
+			continue
+		}
+		for !bMatched[j] {
+			j++
+		}
+		if a[i] != b[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// jaroWinklerSimilarity returns the Jaro-Winkler similarity of a and b. It is the Jaro
+// similarity boosted for strings which have a common prefix of up to 4 characters, which works
+// well for names.
+func jaroWinklerSimilarity(a, b []rune) float64 {
+	sim := jaroSimilarity(a, b)
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && prefix < 4 && a[prefix] == b[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*0.1*(1-sim)
+}
+
+// bigrams returns the count of each pair of adjacent characters in s. Strings with a single
+// character are their own bigram, so that they can still be compared.
+func bigrams(s []rune) map[string]int {
+	grams := make(map[string]int)
+	if len(s) == 1 {
+		grams[string(s)]++
+	}
+	for i := 0; i+1 < len(s); i++ {
+		grams[string(s[i:i+2])]++
+	}
+	return grams
+}
+
+// cosineSimilarity returns the cosine similarity of the bigram vectors of a and b, between 0
+// and 1. Unlike Jaro-Winkler, it doesn't depend on the order of the words in the strings.
+func cosineSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	ga, gb := bigrams(a), bigrams(b)
+	var dot, na, nb float64
+	for g, ca := range ga {
+		dot += float64(ca * gb[g])
+		na += float64(ca * ca)
+	}
+	for _, cb := range gb {
+		nb += float64(cb * cb)
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
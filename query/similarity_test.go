@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b                      string
+		jaro, jaroWinkler, cosine float64
+	}{
+		{a: "martha", b: "marhta", jaro: 0.9444, jaroWinkler: 0.9611, cosine: 0.4},
+		{a: "dixon", b: "dicksonx", jaro: 0.7667, jaroWinkler: 0.8133, cosine: 0.378},
+		{a: "john smith", b: "smith john", jaro: 0.5333, jaroWinkler: 0.5333, cosine: 0.7778},
+		{a: "abc", b: "xyz"},
+		{a: "a", b: ""},
+		{a: "", b: "", jaro: 1, jaroWinkler: 1, cosine: 1},
+		// Strings are compared after lowercasing and unicode normalization.
+		{a: "Ｊｏｈｎ", b: "john", jaro: 1, jaroWinkler: 1, cosine: 1},
+	}
+	for _, tc := range tests {
+		a, b := normalizeForSimilarity(tc.a), normalizeForSimilarity(tc.b)
+		require.InDelta(t, tc.jaro, jaroSimilarity(a, b), 0.0001, "%s %s", tc.a, tc.b)
+		require.InDelta(t, tc.jaroWinkler, jaroWinklerSimilarity(a, b), 0.0001, "%s %s",
+			tc.a, tc.b)
+		require.InDelta(t, tc.cosine, cosineSimilarity(a, b), 0.0001, "%s %s", tc.a, tc.b)
+	}
+}
+
+func TestProcessSimilarity(t *testing.T) {
+	tree := &mathTree{
+		Fn: "jarowinkler",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{
+				1: {Tid: types.StringID, Value: "Margaret"},
+				2: {Tid: types.DefaultID, Value: "Leonard"},
+			}},
+			{Const: types.Val{Tid: types.StringID, Value: "margret"}},
+		}}
+	require.NoError(t, processBinary(tree))
+	require.Equal(t, types.FloatID, tree.Val[1].Tid)
+	require.InDelta(t, 0.975, tree.Val[1].Value.(float64), 0.0001)
+	require.InDelta(t, 0.4286, tree.Val[2].Value.(float64), 0.0001)
+
+	tree = &mathTree{
+		Fn: "cosine",
+		Child: []*mathTree{
+			{Val: map[uint64]types.Val{1: {Tid: types.IntID, Value: int64(1)}}},
+			{Const: types.Val{Tid: types.StringID, Value: "john"}},
+		}}
+	require.Error(t, processBinary(tree))
+}
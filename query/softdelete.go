@@ -0,0 +1,106 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The nodes of a type declared with @softDelete(on: pred) are marked as deleted by setting pred
+// to true. They are left out of the results of queries, at root and through uid predicates,
+// including reverse ones, as if they didn't exist: they aren't counted by count(pred) either.
+// They are only returned if the includeDeleted argument is set at root.
+type softDelete struct {
+	ns uint64
+	// filter is the filter matching the nodes which aren't deleted:
+	// NOT ((eq(pred1, true) AND (type(Type1) OR type(Type2))) OR (eq(pred2, true) AND ...))
+	filter *gql.FilterTree
+}
+
+// newSoftDelete returns the filter leaving out the nodes marked as deleted in the namespace, or
+// nil if no type of the namespace is declared with @softDelete.
+func newSoftDelete(ns uint64) *softDelete {
+	typePreds := schema.State().SoftDeleteTypes(ns)
+	if len(typePreds) == 0 {
+		return nil
+	}
+	predTypes := make(map[string][]string)
+	for typ, pred := range typePreds {
+		predTypes[pred] = append(predTypes[pred], typ)
+	}
+	preds := make([]string, 0, len(predTypes))
+	for pred := range predTypes {
+		preds = append(preds, pred)
+	}
+	sort.Strings(preds)
+
+	deleted := &gql.FilterTree{Op: "or"}
+	for _, pred := range preds {
+		typeNames := predTypes[pred]
+		sort.Strings(typeNames)
+		ofType := &gql.FilterTree{Op: "or"}
+		for _, typ := range typeNames {
+			ofType.Child = append(ofType.Child, &gql.FilterTree{
+				Func: &gql.Function{Name: "type", Args: []gql.Arg{{Value: typ}}},
+			})
+		}
+		marked := &gql.FilterTree{
+			Func: &gql.Function{Name: "eq", Attr: pred, Args: []gql.Arg{{Value: "true"}}},
+		}
+		deleted.Child = append(deleted.Child, &gql.FilterTree{
+			Op:    "and",
+			Child: []*gql.FilterTree{marked, ofType},
+		})
+	}
+	return &softDelete{
+		ns:     ns,
+		filter: &gql.FilterTree{Op: "not", Child: []*gql.FilterTree{deleted}},
+	}
+}
+
+// appliesTo returns true if the nodes reached through attr have to be filtered, i.e. if attr is
+// a uid predicate.
+func (sd *softDelete) appliesTo(attr string) bool {
+	if sd == nil || attr == "" {
+		return false
+	}
+	if strings.HasPrefix(attr, "~") {
+		return true
+	}
+	typ, err := schema.State().TypeOf(x.NamespaceAttr(sd.ns, attr))
+	return err == nil && typ == types.UidID
+}
+
+// addSoftDeleteFilter adds the filter leaving out the nodes marked as deleted to the filters of
+// the SubGraph. It is intersected with the results of the other filters.
+func (sg *SubGraph) addSoftDeleteFilter() error {
+	if sg.Params.softDelete == nil {
+		return nil
+	}
+	filter := &SubGraph{}
+	if err := filterCopy(filter, sg.Params.softDelete.filter); err != nil {
+		return err
+	}
+	sg.Filters = append(sg.Filters, filter)
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"strings"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// splitVal splits a value holding several values separated by sep, as stored by data which
+// wasn't modelled with a list predicate, e.g. "red, green,blue". The tokens are trimmed of
+// spaces, and the empty and repeated ones are left out, so that a uid is found once in the group
+// of a token with @groupby(split(attr, ",")). Values which aren't strings are converted to
+// strings first.
+func splitVal(v types.Val, sep string) []types.Val {
+	str := types.Val{Tid: types.StringID}
+	if err := types.Marshal(v, &str); err != nil {
+		return nil
+	}
+	parts := strings.Split(str.Value.(string), sep)
+	toks := make([]types.Val, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if _, ok := seen[p]; ok || p == "" {
+			continue
+		}
+		seen[p] = struct{}{}
+		toks = append(toks, types.Val{Tid: types.StringID, Value: p})
+	}
+	return toks
+}
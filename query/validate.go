@@ -0,0 +1,269 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/worker"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// minSuggestionSimilarity is the Jaro-Winkler similarity above which a predicate of the schema is
+// suggested for a predicate which isn't found.
+const minSuggestionSimilarity = 0.8
+
+// ValidateQuery parses a DQL query and checks it against the schema of the namespace of the
+// request, without running it. It returns the problems found: parsing errors, including the use
+// of undefined variables, predicates which aren't in the schema and functions used on predicates
+// which don't have the type or the index they need. The error is only set if the schema couldn't
+// be read.
+func ValidateQuery(ctx context.Context, req gql.Request) ([]string, error) {
+	res, err := gql.Parse(req)
+	if err != nil {
+		return []string{err.Error()}, nil
+	}
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := worker.GetSchemaOverNetwork(ctx, &pb.SchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	v := newQueryValidator(ns, nodes)
+	for _, gq := range res.Query {
+		v.validateBlock(gq, true)
+	}
+	return v.problems, nil
+}
+
+// queryValidator holds the schema a query is validated against, and the problems found.
+type queryValidator struct {
+	schema   map[string]*pb.SchemaNode
+	problems []string
+	seen     map[string]struct{}
+}
+
+func newQueryValidator(ns uint64, nodes []*pb.SchemaNode) *queryValidator {
+	v := &queryValidator{
+		schema: make(map[string]*pb.SchemaNode),
+		seen:   make(map[string]struct{}),
+	}
+	for _, node := range nodes {
+		if pns, pred := x.ParseNamespaceAttr(node.Predicate); pns == ns {
+			// The predicates are reported without the namespace.
+			node.Predicate = pred
+			v.schema[pred] = node
+		}
+	}
+	return v
+}
+
+func (v *queryValidator) addProblem(format string, args ...interface{}) {
+	// A predicate is usually used several times in a query, it's only reported once.
+	p := fmt.Sprintf(format, args...)
+	if _, ok := v.seen[p]; ok {
+		return
+	}
+	v.seen[p] = struct{}{}
+	v.problems = append(v.problems, p)
+}
+
+func (v *queryValidator) validateBlock(gq *gql.GraphQuery, root bool) {
+	if !root && !gq.IsInternal && gq.Expand == "" {
+		if node := v.predicate(gq.Attr); node != nil && len(gq.Langs) > 0 && !node.Lang {
+			v.addProblem("predicate '%s' needs @lang to be queried with a language", node.Predicate)
+		}
+		if gq.Func != nil {
+			v.validateFieldFunc(gq.Func, gq.Attr)
+		}
+	}
+	if root && gq.Func != nil {
+		v.validateFunc(gq.Func, true)
+	}
+	v.validateFilter(gq.Filter)
+
+	for _, o := range gq.Order {
+		if !gq.UsesValueVar(o.Attr) {
+			v.predicate(o.Attr)
+		}
+	}
+	for _, attr := range gq.GroupbyAttrs {
+		v.predicate(attr.Attr)
+	}
+	for _, child := range gq.Children {
+		v.validateBlock(child, false)
+	}
+}
+
+func (v *queryValidator) validateFilter(ft *gql.FilterTree) {
+	if ft == nil {
+		return
+	}
+	if ft.Func != nil {
+		v.validateFunc(ft.Func, false)
+	}
+	for _, child := range ft.Child {
+		v.validateFilter(child)
+	}
+}
+
+// validateFieldFunc checks the functions which are used as a field of a block, like
+// checkpwd(password, "secret"). The aggregation functions only need the predicate to exist.
+func (v *queryValidator) validateFieldFunc(f *gql.Function, attr string) {
+	var typ types.TypeID
+	switch f.Name {
+	case "relevance":
+		if node := v.predicate(attr); node != nil {
+			v.requireTokenizer(f.Name, node, "bm25")
+		}
+		return
+	case "checkpwd":
+		typ = types.PasswordID
+	case "distance":
+		typ = types.GeoID
+	default:
+		return
+	}
+	if node := v.predicate(attr); node != nil && node.Type != typ.Name() {
+		v.addProblem("%s requires a predicate of type %s, '%s' is of type %s", f.Name, typ.Name(),
+			node.Predicate, node.Type)
+	}
+}
+
+// validateFunc checks that the predicate of a function at root or in a filter has the type and
+// the index the function needs. The index is needed for every function at root, as the uids are
+// found from it, but only for some of them in filters.
+func (v *queryValidator) validateFunc(f *gql.Function, root bool) {
+	name := strings.ToLower(f.Name)
+	switch {
+	case !isValidFuncName(name):
+		v.addProblem("function '%s' not found", f.Name)
+		return
+	case name == "uid" || name == "type" || f.IsValueVar || f.IsLenVar || f.Attr == "":
+		return
+	}
+	node := v.predicate(f.Attr)
+	if node == nil {
+		return
+	}
+	pred := node.Predicate
+	if f.IsCount {
+		if root && !node.Count {
+			v.addProblem("count(%s) at root requires @count on predicate '%s'", f.Attr, pred)
+		}
+		return
+	}
+
+	switch name {
+	case "has", "uid_in", reachableFn:
+	case "anyofterms", "allofterms":
+		v.requireTokenizer(name, node, "term")
+	case "anyoftext", "alloftext":
+		v.requireTokenizer(name, node, "fulltext")
+	case "regexp", "match":
+		v.requireTokenizer(name, node, "trigram")
+	case "anyof", "allof":
+		switch {
+		case len(f.Args) == 0:
+		case f.Args[0].Value != "":
+			v.requireTokenizer(name, node, f.Args[0].Value)
+		case !hasTokenizer(node, "exact") && !hasTokenizer(node, "term"):
+			// A list of values, e.g. anyof(tags, ["a", "b"]).
+			v.addProblem("%s requires @index(exact) or @index(term) on predicate '%s'", name,
+				node.Predicate)
+		}
+	default:
+		switch {
+		case types.IsGeoFunc(name):
+			v.requireTokenizer(name, node, "geo")
+		case !root:
+			// The other functions compare the values in filters.
+		case !node.Index:
+			v.addProblem("%s at root requires an index on predicate '%s'", name, pred)
+		case name != "eq" && node.Type == types.StringID.Name():
+			// Only the exact index can be iterated in order.
+			v.requireTokenizer(name, node, "exact")
+		}
+	}
+}
+
+func (v *queryValidator) requireTokenizer(fn string, node *pb.SchemaNode, tokenizer string) {
+	if hasTokenizer(node, tokenizer) {
+		return
+	}
+	v.addProblem("%s requires @index(%s) on predicate '%s'", fn, tokenizer, node.Predicate)
+}
+
+func hasTokenizer(node *pb.SchemaNode, tokenizer string) bool {
+	for _, t := range node.Tokenizer {
+		if t == tokenizer {
+			return true
+		}
+	}
+	return false
+}
+
+// predicate returns the schema of the predicate of attr, or nil if it isn't in the schema or attr
+// isn't a predicate. A problem is added if the predicate isn't found, or if attr is a reverse
+// predicate without @reverse.
+func (v *queryValidator) predicate(attr string) *pb.SchemaNode {
+	if attr == "" || attr == "uid" || attr == "val" || attr == "expand" {
+		return nil
+	}
+	pred := strings.TrimPrefix(attr, "~")
+	node, ok := v.schema[pred]
+	if !ok {
+		if s := v.suggest(pred); s != "" {
+			v.addProblem("predicate '%s' not found; did you mean '%s'?", pred, s)
+		} else {
+			v.addProblem("predicate '%s' not found", pred)
+		}
+		return nil
+	}
+	if pred != attr && !node.Reverse {
+		v.addProblem("predicate '%s' needs @reverse to query %s", pred, attr)
+	}
+	return node
+}
+
+// suggest returns the predicate of the schema which looks the most like pred.
+func (v *queryValidator) suggest(pred string) string {
+	preds := make([]string, 0, len(v.schema))
+	for p := range v.schema {
+		preds = append(preds, p)
+	}
+	// Sorted so that the suggestion is the same for predicates as similar.
+	sort.Strings(preds)
+
+	var best string
+	bestSim := minSuggestionSimilarity
+	a := normalizeForSimilarity(pred)
+	for _, p := range preds {
+		if sim := jaroWinklerSimilarity(a, normalizeForSimilarity(p)); sim > bestSim {
+			best, bestSim = p, sim
+		}
+	}
+	return best
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/gql"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func validateAgainstTestSchema(t *testing.T, q string) []string {
+	nodes := []*pb.SchemaNode{
+		{Predicate: "name", Type: "string", Index: true, Tokenizer: []string{"term", "exact"},
+			Lang: true},
+		{Predicate: "bio", Type: "string"},
+		{Predicate: "age", Type: "int", Index: true, Tokenizer: []string{"int"}},
+		{Predicate: "friend", Type: "uid", Reverse: true, Count: true, List: true},
+		{Predicate: "owner", Type: "uid"},
+		{Predicate: "password", Type: "password"},
+		{Predicate: "loc", Type: "geo", Index: true, Tokenizer: []string{"geo"}},
+	}
+	for _, n := range nodes {
+		n.Predicate = x.GalaxyAttr(n.Predicate)
+	}
+	// Predicates of the other namespaces aren't part of the schema.
+	nodes = append(nodes, &pb.SchemaNode{Predicate: x.NamespaceAttr(2, "nickname"), Type: "string"})
+
+	res, err := gql.Parse(gql.Request{Str: q})
+	require.NoError(t, err)
+	v := newQueryValidator(x.GalaxyNamespace, nodes)
+	for _, gq := range res.Query {
+		v.validateBlock(gq, true)
+	}
+	return v.problems
+}
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		problems []string
+	}{
+		{"valid", `{
+			me(func: anyofterms(name, "alice"), orderasc: age) @filter(gt(age, 20)) {
+				name@en
+				bio
+				count(friend)
+				~friend(first: 2) {
+					uid
+					checkpwd(password, "secret")
+				}
+				a as age
+				double: math(a * 2)
+			}
+			old(func: ge(age, 60)) @filter(eq(bio, "x") OR has(friend)) {
+				expand(_all_)
+			}
+			near(func: near(loc, [1, 2], 10)) {
+				distance(loc, [1, 2])
+			}
+			popular(func: gt(count(friend), 10)) {
+				uid
+			}
+			sorted(func: uid(a), orderdesc: val(a)) {
+				val(a)
+			}
+		}`, nil},
+		{"missing predicates", `{
+			me(func: has(nam)) {
+				frend {
+					nickname
+				}
+				nam
+			}
+		}`, []string{
+			"predicate 'nam' not found; did you mean 'name'?",
+			"predicate 'frend' not found; did you mean 'friend'?",
+			"predicate 'nickname' not found",
+		}},
+		{"missing indexes", `{
+			me(func: regexp(bio, /^a/)) @filter(alloftext(name, "a") AND anyofterms(bio, "b")) {
+				uid
+			}
+			old(func: ge(bio, "b")) {
+				uid
+			}
+			named(func: lt(name, "b")) {
+				uid
+			}
+			owned(func: gt(count(owner), 1)) {
+				~owner
+				name@fr
+				bio@en
+				relevance(bio, "b")
+			}
+		}`, []string{
+			"regexp requires @index(trigram) on predicate 'bio'",
+			"alloftext requires @index(fulltext) on predicate 'name'",
+			"anyofterms requires @index(term) on predicate 'bio'",
+			"ge at root requires an index on predicate 'bio'",
+			"count(owner) at root requires @count on predicate 'owner'",
+			"predicate 'owner' needs @reverse to query ~owner",
+			"predicate 'bio' needs @lang to be queried with a language",
+			"relevance requires @index(bm25) on predicate 'bio'",
+		}},
+		{"wrong types", `{
+			me(func: has(name)) {
+				checkpwd(bio, "secret")
+				distance(name, [1, 2])
+			}
+		}`, []string{
+			"checkpwd requires a predicate of type password, 'bio' is of type string",
+			"distance requires a predicate of type geo, 'name' is of type string",
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.problems, validateAgainstTestSchema(t, tc.query))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// SetAliases sets the aliases of a predicate, which could be served by another group. The
+// aliases hold the names without a namespace, they are in the namespace of pred. No aliases
+// removes the ones the predicate had.
+func (s *state) SetAliases(pred string, aliases []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.setAliases(pred, aliases)
+}
+
+func (s *state) setAliases(pred string, aliases []string) {
+	for alias, target := range s.aliases {
+		if target == pred {
+			delete(s.aliases, alias)
+		}
+	}
+	ns := x.ParseNamespace(pred)
+	for _, alias := range aliases {
+		s.aliases[x.NamespaceAttr(ns, alias)] = pred
+	}
+}
+
+// PredicateAliases returns the aliases of a predicate served by this alpha.
+func (s *state) PredicateAliases(pred string) []string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetAliases()
+}
+
+// HasAliases returns true if any predicate known to this alpha has an alias.
+func (s *state) HasAliases() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.aliases) > 0
+}
+
+// ResolveAlias returns the predicate the given alias stands for. Both are namespaced.
+func (s *state) ResolveAlias(alias string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	pred, ok := s.aliases[alias]
+	return pred, ok
+}
+
+// Aliases returns a copy of the aliases of all the predicates known to this alpha, mapped to
+// the predicate they stand for.
+func (s *state) Aliases() map[string]string {
+	s.RLock()
+	defer s.RUnlock()
+	out := make(map[string]string, len(s.aliases))
+	for alias, pred := range s.aliases {
+		out[alias] = pred
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CascadeDelete holds the @cascadeDelete directive of a type.
+type CascadeDelete struct {
+	// Via is the predicate followed to the nodes to delete, without the namespace.
+	Via string
+	// Force deletes the nodes which are also referenced by nodes which aren't deleted.
+	Force bool
+}
+
+// CascadeDeleteTypes returns the types of the namespace declared with @cascadeDelete. The names
+// are returned without the namespace.
+func (s *state) CascadeDeleteTypes(ns uint64) map[string]CascadeDelete {
+	if s == nil {
+		return nil
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	var out map[string]CascadeDelete
+	for name, typ := range s.types {
+		if typ.CascadeDelete == "" || x.ParseNamespace(name) != ns {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]CascadeDelete)
+		}
+		out[x.ParseAttr(name)] = CascadeDelete{Via: typ.CascadeDelete, Force: typ.CascadeDeleteForce}
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// IsDerivedLiteral returns true if the term of a derivation is a string literal rather than the
+// name of a source predicate.
+func IsDerivedLiteral(term string) bool {
+	return strings.HasPrefix(term, `"`)
+}
+
+// DerivedLiteral returns the value of a string literal term of a derivation.
+func DerivedLiteral(term string) string {
+	lit, err := strconv.Unquote(term)
+	x.Check(err) // Literals are quoted when the schema is parsed.
+	return lit
+}
+
+// DerivedSources returns the source predicates of the derivation of pred. The terms hold the
+// names of the sources without a namespace, the sources are in the namespace of pred.
+func DerivedSources(pred string, terms []string) []string {
+	ns := x.ParseNamespace(pred)
+	var sources []string
+	for _, term := range terms {
+		if !IsDerivedLiteral(term) {
+			sources = append(sources, x.NamespaceAttr(ns, term))
+		}
+	}
+	return sources
+}
+
+// DerivationOrder returns the derived predicates in the order in which they need to be computed,
+// so that a predicate derived from another derived predicate comes after it. An error is
+// returned if the derivations have a cycle.
+func DerivationOrder(derived map[string][]string) ([]string, error) {
+	preds := make([]string, 0, len(derived))
+	for pred := range derived {
+		preds = append(preds, pred)
+	}
+	sort.Strings(preds)
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(derived))
+	order := make([]string, 0, len(derived))
+	var visit func(pred string, path []string) error
+	visit = func(pred string, path []string) error {
+		switch state[pred] {
+		case visited:
+			return nil
+		case visiting:
+			var names []string
+			for _, p := range append(path, pred) {
+				names = append(names, x.ParseAttr(p))
+			}
+			return errors.Errorf("Derived predicates can't have cycles. Found: %s",
+				strings.Join(names, " -> "))
+		}
+		state[pred] = visiting
+		for _, src := range DerivedSources(pred, derived[pred]) {
+			if _, ok := derived[src]; !ok {
+				continue
+			}
+			if err := visit(src, append(path, pred)); err != nil {
+				return err
+			}
+		}
+		state[pred] = visited
+		order = append(order, pred)
+		return nil
+	}
+	for _, pred := range preds {
+		if err := visit(pred, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// SetDerivation sets the derivation of a derived predicate, which could be served by another
+// group. An empty derivation removes it.
+func (s *state) SetDerivation(pred string, terms []string) {
+	s.Lock()
+	defer s.Unlock()
+	s.setDerivation(pred, terms)
+}
+
+func (s *state) setDerivation(pred string, terms []string) {
+	if len(terms) == 0 {
+		delete(s.derived, pred)
+		return
+	}
+	s.derived[pred] = terms
+}
+
+// Derivation returns the terms of the derivation of the given predicate.
+func (s *state) Derivation(pred string) ([]string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	terms, ok := s.derived[pred]
+	return terms, ok
+}
+
+// Derivations returns a copy of the derivations of all the derived predicates known to this
+// alpha.
+func (s *state) Derivations() map[string][]string {
+	s.RLock()
+	defer s.RUnlock()
+	out := make(map[string][]string, len(s.derived))
+	for pred, terms := range s.derived {
+		out[pred] = terms
+	}
+	return out
+}
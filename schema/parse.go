@@ -18,6 +18,7 @@ package schema
 
 import (
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -62,13 +63,24 @@ func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeI
 			return next.Errorf("Cannot reverse for non-UID type")
 		}
 		schema.Directive = pb.SchemaUpdate_REVERSE
+	case "undirected":
+		if t != types.UidID || !schema.List {
+			return next.Errorf("@undirected directive can only be specified for [uid] type."+
+				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
+		}
+		schema.Undirected = true
 	case "index":
+		if t == types.BinaryID {
+			return next.Errorf("Predicates of type binary can't be indexed. Got @index for "+
+				"attr: [%v]", x.ParseAttr(schema.Predicate))
+		}
 		tokenizer, err := parseIndexDirective(it, schema.Predicate, t)
 		if err != nil {
 			return err
 		}
 		schema.Directive = pb.SchemaUpdate_INDEX
 		schema.Tokenizer = tokenizer
+		schema.TokenizerVersions = tok.PluginVersions(tokenizer)
 	case "count":
 		schema.Count = true
 	case "upsert":
@@ -81,6 +93,40 @@ func parseDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate, t types.TypeI
 				" Got: [%v] for attr: [%v]", t.Name(), schema.Predicate)
 		}
 		schema.Lang = true
+	case "derived":
+		terms, err := parseDerivedDirective(it, schema, t)
+		if err != nil {
+			return err
+		}
+		schema.Derived = terms
+	case "pattern":
+		pattern, err := parsePatternDirective(it, schema, t)
+		if err != nil {
+			return err
+		}
+		schema.Pattern = pattern
+	case "alias":
+		aliases, err := parseAliasDirective(it, schema)
+		if err != nil {
+			return err
+		}
+		schema.Aliases = aliases
+	case "cardinality":
+		if t != types.UidID {
+			return next.Errorf("@cardinality directive can only be specified for uid type."+
+				" Got: [%v] for attr: [%v]", t.Name(), x.ParseAttr(schema.Predicate))
+		}
+		if err := parseCardinalityDirective(it, schema); err != nil {
+			return err
+		}
+	case "key":
+		if t != types.StringID || schema.List {
+			return next.Errorf("@key directive can only be specified for string type."+
+				" Got: [%v] for attr: [%v]", t.Name(), x.ParseAttr(schema.Predicate))
+		}
+		// The concurrent transactions creating nodes with the same key conflict as with @upsert.
+		schema.Key = true
+		schema.Upsert = true
 	default:
 		return next.Errorf("Invalid index specification")
 	}
@@ -123,6 +169,11 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 		return nil, next.Errorf("Missing Type")
 	}
 	typ := strings.ToLower(next.Val)
+	// An enum is a string predicate whose values are declared along with it.
+	isEnum := typ == "enum"
+	if isEnum {
+		typ = types.StringID.Name()
+	}
 	// We ignore the case for types.
 	t, ok := types.TypeForName(typ)
 	if !ok {
@@ -138,6 +189,40 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 	// Check for index / reverse.
 	it.Next()
 	next = it.Item()
+	if isEnum {
+		values, err := parseEnumValues(it, predicate)
+		if err != nil {
+			return nil, err
+		}
+		schema.EnumValues = values
+		it.Next()
+		next = it.Item()
+	}
+	// Decimals can have the number of digits after the decimal point, like decimal(2). Without
+	// it, the values keep the digits they are given, so the scale can't be 0.
+	if next.Typ == itemLeftRound {
+		if t != types.DecimalID {
+			return nil, next.Errorf("Scale can only be given for type decimal, not %s", t.Name())
+		}
+		if !it.Next() {
+			return nil, next.Errorf("Invalid ending while trying to parse schema.")
+		}
+		next = it.Item()
+		if next.Typ != itemNumber {
+			return nil, next.Errorf("Expected a number for the scale of decimal, got: %s", next.Val)
+		}
+		scale, err := strconv.ParseUint(next.Val, 10, 32)
+		if err != nil || scale == 0 || scale > types.MaxDecimalScale {
+			return nil, next.Errorf("Scale of decimal must be between 1 and %d, got: %s",
+				types.MaxDecimalScale, next.Val)
+		}
+		schema.DecimalScale = uint32(scale)
+		if !it.Next() || it.Item().Typ != itemRightRound {
+			return nil, next.Errorf("Unclosed ( while parsing scale of decimal for: %s", predicate)
+		}
+		it.Next()
+		next = it.Item()
+	}
 	if schema.List {
 		if next.Typ != itemRightSquare {
 			return nil, next.Errorf("Unclosed [ while parsing schema for: %s", predicate)
@@ -157,6 +242,32 @@ func parseScalarPair(it *lex.ItemIterator, predicate string, ns uint64) (*pb.Sch
 		}
 		next = it.Item()
 	}
+	if isEnum {
+		// The values of an enum are indexed as with @index(exact), the only index it can have.
+		switch {
+		case schema.Directive != pb.SchemaUpdate_INDEX:
+			schema.Directive = pb.SchemaUpdate_INDEX
+			schema.Tokenizer = []string{"exact"}
+		case len(schema.Tokenizer) != 1 || schema.Tokenizer[0] != "exact":
+			return nil, next.Errorf("An enum can only have @index(exact), got @index(%s) for "+
+				"attr: [%v]", strings.Join(schema.Tokenizer, ","), predicate)
+		}
+		if schema.Lang {
+			return nil, next.Errorf("@lang directive can't be used with an enum for attr: [%v]",
+				predicate)
+		}
+	}
+	// An undirected edge is its own reverse, so having both doesn't make sense.
+	if schema.Undirected && schema.Directive == pb.SchemaUpdate_REVERSE {
+		return nil, next.Errorf("@undirected and @reverse can't be used together for attr: [%v]",
+			predicate)
+	}
+	if err := CheckCardinality(schema); err != nil {
+		return nil, next.Errorf("%v", err)
+	}
+	if err := CheckKey(schema); err != nil {
+		return nil, next.Errorf("%v", err)
+	}
 
 	if next.Typ != itemDot {
 		return nil, next.Errorf("Invalid ending")
@@ -178,6 +289,7 @@ func parseIndexDirective(it *lex.ItemIterator, predicate string,
 	typ types.TypeID) ([]string, error) {
 	var tokenizers []string
 	var seen = make(map[string]bool)
+	var seenExprBase = make(map[string]bool)
 	var seenSortableTok bool
 
 	if typ == types.UidID || typ == types.DefaultID || typ == types.PasswordID {
@@ -216,11 +328,44 @@ func parseIndexDirective(it *lex.ItemIterator, predicate string,
 		if !expectArg {
 			return tokenizers, next.Errorf("Expected a comma but got: %v", next)
 		}
-		// Look for custom tokenizer.
-		tokenizer, has := tok.GetTokenizer(strings.ToLower(next.Val))
+		name := strings.ToLower(next.Val)
+		// Plugin tokenizers can be declared as custom:"name".
+		isCustom := false
+		if name == "expr" {
+			if peek, err := it.Peek(1); err == nil && peek[0].Typ == itemColon {
+				exprName, err := parseIndexExpr(it, predicate)
+				if err != nil {
+					return tokenizers, err
+				}
+				name = exprName
+			}
+		}
+		if name == "custom" {
+			if peek, err := it.Peek(1); err == nil && peek[0].Typ == itemColon {
+				it.Next()
+				if !it.Next() || it.Item().Typ != itemQuotedText {
+					return tokenizers, it.Item().Errorf("Expected the name of a custom tokenizer "+
+						"in quotes after custom:, but got: %v", it.Item().Val)
+				}
+				unquoted, err := strconv.Unquote(it.Item().Val)
+				if err != nil {
+					return tokenizers, it.Item().Errorf("Invalid custom tokenizer name %s",
+						it.Item().Val)
+				}
+				name, isCustom = strings.ToLower(unquoted), true
+			}
+		}
+		tokenizer, has := tok.GetTokenizer(name)
 		if !has {
+			if isCustom {
+				return tokenizers, next.Errorf("Custom tokenizer %s isn't loaded. Load its "+
+					"plugin using --custom_tokenizers", name)
+			}
 			return tokenizers, next.Errorf("Invalid tokenizer %s", next.Val)
 		}
+		if isCustom && !tok.IsCustomTokenizer(name) {
+			return tokenizers, next.Errorf("Tokenizer %s isn't a custom tokenizer", name)
+		}
 		tokenizerType, ok := types.TypeForName(tokenizer.Type())
 		x.AssertTrue(ok) // Type is validated during tokenizer loading.
 		if tokenizerType != typ {
@@ -232,6 +377,14 @@ func parseIndexDirective(it *lex.ItemIterator, predicate string,
 			return tokenizers, next.Errorf("Duplicate tokenizers defined for pred %v",
 				predicate)
 		}
+		if et, ok := tokenizer.(tok.ExprTokenizer); ok {
+			// The expression indexes of a type share the identifier of their tokens.
+			if seenExprBase[et.Base().Name()] {
+				return tokenizers, next.Errorf("More than one expression index of type %s "+
+					"for pred %v", et.Base().Name(), predicate)
+			}
+			seenExprBase[et.Base().Name()] = true
+		}
 		if tokenizer.IsSortable() {
 			if seenSortableTok {
 				return nil, next.Errorf("More than one sortable index encountered for: %v",
@@ -246,6 +399,381 @@ func parseIndexDirective(it *lex.ItemIterator, predicate string,
 	return tokenizers, nil
 }
 
+// parseIndexExpr works on expr: "lower(email)", type: hash in @index, and returns the name of the
+// tokenizer of the expression index.
+func parseIndexExpr(it *lex.ItemIterator, predicate string) (string, error) {
+	it.Next()
+	if !it.Next() || it.Item().Typ != itemQuotedText {
+		return "", it.Item().Errorf("Expected an expression in quotes after expr:, but got: %v",
+			it.Item().Val)
+	}
+	expr, err := strconv.Unquote(it.Item().Val)
+	if err != nil {
+		return "", it.Item().Errorf("Invalid index expression %s", it.Item().Val)
+	}
+	var base string
+	if it.Next() && it.Item().Typ == itemComma && it.Next() &&
+		strings.ToLower(it.Item().Val) == "type" && it.Next() && it.Item().Typ == itemColon &&
+		it.Next() && it.Item().Typ == itemText {
+		base = strings.ToLower(it.Item().Val)
+	} else {
+		return "", it.Item().Errorf("Expected type: after the expression of an index, e.g. "+
+			"expr: %q, type: hash", expr)
+	}
+	t, err := tok.NewExprTokenizer(expr, x.ParseAttr(predicate), base)
+	if err != nil {
+		return "", it.Item().Errorf("%s", err.Error())
+	}
+	return t.Name(), nil
+}
+
+// parseDerivedDirective works on @derived(firstName + " " + lastName). The names of the source
+// predicates are kept as they are, and string literals are kept quoted.
+func parseDerivedDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate,
+	typ types.TypeID) ([]string, error) {
+	attr := x.ParseAttr(schema.Predicate)
+	if typ != types.StringID || schema.List {
+		return nil, it.Item().Errorf("@derived directive can only be specified for string type."+
+			" Got: [%v] for attr: [%v]", typ.Name(), attr)
+	}
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return nil, it.Item().Errorf("Expected ( after @derived for attr: [%v]", attr)
+	}
+
+	var terms []string
+	expectTerm := true
+	for {
+		if !it.Next() {
+			return nil, it.Item().Errorf("Unclosed ( while parsing @derived for attr: [%v]", attr)
+		}
+		next := it.Item()
+		switch {
+		case next.Typ == itemRightRound || next.Typ == itemPlus:
+			if expectTerm {
+				return nil, next.Errorf("Expected a predicate or a string in @derived for "+
+					"attr: [%v], but got: %v", attr, next.Val)
+			}
+			if next.Typ == itemRightRound {
+				return terms, nil
+			}
+			expectTerm = true
+		case !expectTerm:
+			return nil, next.Errorf("Expected + or ) in @derived for attr: [%v], but got: %v",
+				attr, next.Val)
+		case next.Typ == itemText:
+			if next.Val == attr {
+				return nil, next.Errorf("Predicate %s can't be derived from itself", attr)
+			}
+			terms = append(terms, next.Val)
+			expectTerm = false
+		case next.Typ == itemQuotedText:
+			lit, err := strconv.Unquote(next.Val)
+			if err != nil {
+				return nil, next.Errorf("Invalid string %s in @derived for attr: [%v]",
+					next.Val, attr)
+			}
+			terms = append(terms, strconv.Quote(lit))
+			expectTerm = false
+		default:
+			return nil, next.Errorf("Expected a predicate or a string in @derived for "+
+				"attr: [%v], but got: %v", attr, next.Val)
+		}
+	}
+}
+
+// parsePatternDirective works on @pattern("^[^@]+@[^@]+$"). The pattern is compiled here so
+// that an invalid regular expression is rejected with the schema.
+func parsePatternDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate,
+	typ types.TypeID) (string, error) {
+	attr := x.ParseAttr(schema.Predicate)
+	if typ != types.StringID {
+		return "", it.Item().Errorf("@pattern directive can only be specified for string type."+
+			" Got: [%v] for attr: [%v]", typ.Name(), attr)
+	}
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return "", it.Item().Errorf("Expected ( after @pattern for attr: [%v]", attr)
+	}
+	if !it.Next() || it.Item().Typ != itemQuotedText {
+		return "", it.Item().Errorf("Expected a quoted regular expression in @pattern for "+
+			"attr: [%v]", attr)
+	}
+	next := it.Item()
+	pattern, err := strconv.Unquote(next.Val)
+	if err != nil {
+		return "", next.Errorf("Invalid string %s in @pattern for attr: [%v]", next.Val, attr)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", next.Errorf("Invalid regular expression in @pattern for attr: [%v]: %v",
+			attr, err)
+	}
+	if !it.Next() || it.Item().Typ != itemRightRound {
+		return "", it.Item().Errorf("Expected ) after the pattern in @pattern for attr: [%v]",
+			attr)
+	}
+	return pattern, nil
+}
+
+// parseAliasDirective works on @alias(oldName, old_name). The aliases are other names of the
+// predicate, which are resolved to it in queries and mutations.
+func parseAliasDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate) ([]string, error) {
+	attr := x.ParseAttr(schema.Predicate)
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return nil, it.Item().Errorf("Expected ( after @alias for attr: [%v]", attr)
+	}
+
+	var aliases []string
+	expectAlias := true
+	for {
+		if !it.Next() {
+			return nil, it.Item().Errorf("Unclosed ( while parsing @alias for attr: [%v]", attr)
+		}
+		next := it.Item()
+		switch {
+		case next.Typ == itemRightRound || next.Typ == itemComma:
+			if expectAlias {
+				return nil, next.Errorf("Expected a predicate name in @alias for attr: [%v], "+
+					"but got: %v", attr, next.Val)
+			}
+			if next.Typ == itemRightRound {
+				return aliases, nil
+			}
+			expectAlias = true
+		case !expectAlias:
+			return nil, next.Errorf("Expected , or ) in @alias for attr: [%v], but got: %v",
+				attr, next.Val)
+		case next.Typ == itemText:
+			alias := next.Val
+			switch {
+			case alias == attr:
+				return nil, next.Errorf("Predicate %s can't be an alias of itself", attr)
+			case x.IsReservedPredicate(x.GalaxyAttr(alias)):
+				return nil, next.Errorf("Reserved predicate %s can't be an alias of %s", alias,
+					attr)
+			}
+			aliases = append(aliases, alias)
+			expectAlias = false
+		default:
+			return nil, next.Errorf("Expected a predicate name in @alias for attr: [%v], "+
+				"but got: %v", attr, next.Val)
+		}
+	}
+}
+
+// The cardinalities of the uid edges of a predicate given by @cardinality.
+const (
+	// CardinalityOne allows a node to have a single edge of the predicate, which is the
+	// behavior of uid predicates. The edges are replaced, as without @cardinality, or rejected.
+	CardinalityOne = "one"
+	// CardinalityOneToOne also allows a single edge pointing to each node.
+	CardinalityOneToOne = "one-to-one"
+	// CardinalityOneToMany allows a node to have many edges, but a single edge pointing to each
+	// node, e.g. for the employees of a company.
+	CardinalityOneToMany = "one-to-many"
+)
+
+// parseCardinalityDirective works on @cardinality(one-to-one) or @cardinality(one, reject). The
+// edges breaking the cardinality are replaced, unless reject is given.
+func parseCardinalityDirective(it *lex.ItemIterator, schema *pb.SchemaUpdate) error {
+	attr := x.ParseAttr(schema.Predicate)
+	if !it.Next() || it.Item().Typ != itemLeftRound {
+		return it.Item().Errorf("Expected ( after @cardinality for attr: [%v]", attr)
+	}
+	if !it.Next() || it.Item().Typ != itemText {
+		return it.Item().Errorf("Expected one, one-to-one or one-to-many in @cardinality for "+
+			"attr: [%v]", attr)
+	}
+	next := it.Item()
+	switch next.Val {
+	case CardinalityOne, CardinalityOneToOne, CardinalityOneToMany:
+		schema.Cardinality = next.Val
+	default:
+		return next.Errorf("Invalid cardinality %s for attr: [%v]. Expected one, one-to-one or "+
+			"one-to-many", next.Val, attr)
+	}
+
+	if !it.Next() {
+		return next.Errorf("Unclosed ( while parsing @cardinality for attr: [%v]", attr)
+	}
+	if it.Item().Typ == itemComma {
+		if !it.Next() || it.Item().Typ != itemText || it.Item().Val != "reject" {
+			return it.Item().Errorf("Expected reject after , in @cardinality for attr: [%v]",
+				attr)
+		}
+		schema.CardinalityReject = true
+		it.Next()
+	}
+	if it.Item().Typ != itemRightRound {
+		return it.Item().Errorf("Expected ) in @cardinality for attr: [%v]", attr)
+	}
+	return nil
+}
+
+// CheckCardinality verifies that the cardinality of the predicate can be enforced with its
+// other directives. The edges pointing to a node are found from the reverse edges, so @reverse
+// is needed for the cardinalities limiting them.
+func CheckCardinality(su *pb.SchemaUpdate) error {
+	attr := x.ParseAttr(su.Predicate)
+	switch su.Cardinality {
+	case "":
+		return nil
+	case CardinalityOne, CardinalityOneToOne:
+		if su.ValueType != pb.Posting_UID || su.List {
+			return errors.Errorf("@cardinality(%s) can only be specified for uid type, "+
+				"not [uid], for attr: [%v]", su.Cardinality, attr)
+		}
+	case CardinalityOneToMany:
+		if su.ValueType != pb.Posting_UID || !su.List {
+			return errors.Errorf("@cardinality(%s) can only be specified for [uid] type for "+
+				"attr: [%v]", su.Cardinality, attr)
+		}
+	default:
+		return errors.Errorf("Invalid cardinality %s for attr: [%v]", su.Cardinality, attr)
+	}
+	if su.Cardinality != CardinalityOne && su.Directive != pb.SchemaUpdate_REVERSE {
+		return errors.Errorf("@cardinality(%s) requires @reverse for attr: [%v]",
+			su.Cardinality, attr)
+	}
+	return nil
+}
+
+// CheckKey verifies that the nodes can be looked up by the values of a @key predicate, which
+// needs an index giving the nodes having a value.
+func CheckKey(su *pb.SchemaUpdate) error {
+	if !su.Key {
+		return nil
+	}
+	attr := x.ParseAttr(su.Predicate)
+	switch {
+	case su.ValueType != pb.Posting_STRING || su.List:
+		return errors.Errorf("@key can only be specified for string type for attr: [%v]", attr)
+	case su.Lang:
+		return errors.Errorf("@key and @lang can't be used together for attr: [%v]", attr)
+	case !su.Upsert:
+		return errors.Errorf("@key requires @upsert for attr: [%v]", attr)
+	}
+	for _, name := range su.Tokenizer {
+		if name == "exact" || name == "hash" {
+			return nil
+		}
+	}
+	return errors.Errorf("@key requires @index(exact) or @index(hash) for attr: [%v]", attr)
+}
+
+// checkAliases verifies that the aliases are neither predicates nor aliases of another predicate
+// in the schema. The predicates which aren't in the schema are verified when it's applied.
+func checkAliases(updates []*pb.SchemaUpdate) error {
+	preds := make(map[string]struct{}, len(updates))
+	for _, su := range updates {
+		preds[su.Predicate] = struct{}{}
+	}
+	aliases := make(map[string]string)
+	for _, su := range updates {
+		ns := x.ParseNamespace(su.Predicate)
+		for _, alias := range su.Aliases {
+			nsAlias := x.NamespaceAttr(ns, alias)
+			if _, ok := preds[nsAlias]; ok {
+				return errors.Errorf("Alias %s of predicate %s is already a predicate", alias,
+					x.ParseAttr(su.Predicate))
+			}
+			if other, ok := aliases[nsAlias]; ok && other != su.Predicate {
+				return errors.Errorf("Alias %s can't be used by both predicates %s and %s", alias,
+					x.ParseAttr(other), x.ParseAttr(su.Predicate))
+			}
+			aliases[nsAlias] = su.Predicate
+		}
+	}
+	return nil
+}
+
+// parseEnumValues works on the values of an enum, like ("active", "inactive", "banned"). The
+// iterator is on the item following enum.
+func parseEnumValues(it *lex.ItemIterator, predicate string) ([]string, error) {
+	if it.Item().Typ != itemLeftRound {
+		return nil, it.Item().Errorf("Expected ( after enum for attr: [%v]", predicate)
+	}
+	var values []string
+	seen := make(map[string]struct{})
+	for {
+		if !it.Next() || it.Item().Typ != itemQuotedText {
+			return nil, it.Item().Errorf("Expected a quoted value in enum for attr: [%v]",
+				predicate)
+		}
+		next := it.Item()
+		val, err := strconv.Unquote(next.Val)
+		if err != nil {
+			return nil, next.Errorf("Invalid string %s in enum for attr: [%v]", next.Val,
+				predicate)
+		}
+		if _, ok := seen[val]; ok {
+			return nil, next.Errorf("Duplicate value %q in enum for attr: [%v]", val, predicate)
+		}
+		seen[val] = struct{}{}
+		values = append(values, val)
+
+		if !it.Next() {
+			return nil, next.Errorf("Invalid ending while trying to parse schema.")
+		}
+		switch it.Item().Typ {
+		case itemComma:
+		case itemRightRound:
+			return values, nil
+		default:
+			return nil, it.Item().Errorf("Expected , or ) after a value of enum for attr: [%v]",
+				predicate)
+		}
+	}
+}
+
+// checkDerivations verifies the derived predicates against the other predicates in the schema.
+// The sources which aren't in the schema are verified when it's applied.
+func checkDerivations(updates []*pb.SchemaUpdate) error {
+	preds := make(map[string]*pb.SchemaUpdate, len(updates))
+	derived := make(map[string][]string)
+	for _, su := range updates {
+		preds[su.Predicate] = su
+		if len(su.Derived) > 0 {
+			derived[su.Predicate] = su.Derived
+		}
+	}
+	for pred, terms := range derived {
+		if x.IsReservedPredicate(pred) {
+			return errors.Errorf("Reserved predicate %s can't be derived", x.ParseAttr(pred))
+		}
+		if len(DerivedSources(pred, terms)) == 0 {
+			return errors.Errorf("Derived predicate %s must have at least one source predicate",
+				x.ParseAttr(pred))
+		}
+		for _, src := range DerivedSources(pred, terms) {
+			if x.IsReservedPredicate(src) {
+				return errors.Errorf("Reserved predicate %s can't be used by derived predicate %s",
+					x.ParseAttr(src), x.ParseAttr(pred))
+			}
+			if su, ok := preds[src]; ok {
+				if err := CheckDerivedSource(pred, su); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	_, err := DerivationOrder(derived)
+	return err
+}
+
+// CheckDerivedSource returns an error if the predicate can't be a source of the derived
+// predicate. Only scalar values of the same node can be used in a derivation.
+func CheckDerivedSource(derived string, src *pb.SchemaUpdate) error {
+	typ := types.TypeID(src.ValueType)
+	if typ != types.UidID && typ != types.PasswordID && !src.List {
+		return nil
+	}
+	name := typ.Name()
+	if src.List {
+		name = "[" + name + "]"
+	}
+	return errors.Errorf("Derived predicate %s can only use scalar predicates, but %s is of "+
+		"type %s", x.ParseAttr(derived), x.ParseAttr(src.Predicate), name)
+}
+
 // resolveTokenizers resolves default tokenizers and verifies tokenizers definitions.
 func resolveTokenizers(updates []*pb.SchemaUpdate) error {
 	for _, schema := range updates {
@@ -312,6 +840,24 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 	typeUpdate := &pb.TypeUpdate{TypeName: x.NamespaceAttr(ns, it.Item().Val)}
 
 	it.Next()
+	for it.Item().Typ == itemAt {
+		next, err := it.Peek(1)
+		if err != nil {
+			return nil, it.Item().Errorf("Invalid ending after @ in type declaration")
+		}
+		switch next[0].Val {
+		case "cascadeDelete":
+			if typeUpdate.CascadeDelete, typeUpdate.CascadeDeleteForce, err =
+				parseCascadeDelete(it); err != nil {
+				return nil, err
+			}
+		default:
+			if typeUpdate.SoftDelete, err = parseSoftDelete(it); err != nil {
+				return nil, err
+			}
+		}
+		it.Next()
+	}
 	if it.Item().Typ != itemLeftCurl {
 		return nil, it.Item().Errorf("Expected {. Got %v", it.Item().Val)
 	}
@@ -338,6 +884,18 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 
 				fieldSet[field.GetPredicate()] = struct{}{}
 			}
+			if sd := typeUpdate.SoftDelete; sd != "" {
+				if _, ok := fieldSet[x.NamespaceAttr(ns, sd)]; !ok {
+					return nil, it.Item().Errorf("Predicate %s of @softDelete should be a field "+
+						"of type %s", sd, x.ParseAttr(typeUpdate.TypeName))
+				}
+			}
+			if cd := typeUpdate.CascadeDelete; cd != "" {
+				if _, ok := fieldSet[x.NamespaceAttr(ns, cd)]; !ok {
+					return nil, it.Item().Errorf("Predicate %s of @cascadeDelete should be a "+
+						"field of type %s", cd, x.ParseAttr(typeUpdate.TypeName))
+				}
+			}
 
 			typeUpdate.Fields = fields
 			return typeUpdate, nil
@@ -356,6 +914,79 @@ func parseTypeDeclaration(it *lex.ItemIterator, ns uint64) (*pb.TypeUpdate, erro
 	return nil, errors.Errorf("Shouldn't reach here.")
 }
 
+// parseSoftDelete parses the @softDelete(on: pred) directive of a type, and returns the
+// predicate. The iterator is on the @ and ends on the right round bracket.
+func parseSoftDelete(it *lex.ItemIterator) (string, error) {
+	expect := func(typ lex.ItemType, val string) error {
+		it.Next()
+		if item := it.Item(); item.Typ != typ || (val != "" && item.Val != val) {
+			return item.Errorf("Expected @softDelete(on: <predicate>) after type name. Got %v",
+				item.Val)
+		}
+		return nil
+	}
+	for _, e := range []struct {
+		typ lex.ItemType
+		val string
+	}{{itemText, "softDelete"}, {itemLeftRound, ""}, {itemText, "on"}, {itemColon, ""},
+		{itemText, ""}} {
+		if err := expect(e.typ, e.val); err != nil {
+			return "", err
+		}
+	}
+	pred := it.Item().Val
+	if err := expect(itemRightRound, ""); err != nil {
+		return "", err
+	}
+	return pred, nil
+}
+
+// parseCascadeDelete parses the @cascadeDelete(via: pred) or @cascadeDelete(via: pred, force:
+// true) directive of a type, and returns the predicate and the value of force. The iterator is
+// on the @ and ends on the right round bracket.
+func parseCascadeDelete(it *lex.ItemIterator) (string, bool, error) {
+	expect := func(typ lex.ItemType, val string) error {
+		it.Next()
+		if item := it.Item(); item.Typ != typ || (val != "" && item.Val != val) {
+			return item.Errorf("Expected @cascadeDelete(via: <predicate>) after type name. "+
+				"Got %v", item.Val)
+		}
+		return nil
+	}
+	for _, e := range []struct {
+		typ lex.ItemType
+		val string
+	}{{itemText, "cascadeDelete"}, {itemLeftRound, ""}, {itemText, "via"}, {itemColon, ""},
+		{itemText, ""}} {
+		if err := expect(e.typ, e.val); err != nil {
+			return "", false, err
+		}
+	}
+	pred := it.Item().Val
+
+	var force bool
+	if next, ok := it.PeekOne(); ok && next.Typ == itemComma {
+		it.Next()
+		for _, e := range []struct {
+			typ lex.ItemType
+			val string
+		}{{itemText, "force"}, {itemColon, ""}, {itemText, ""}} {
+			if err := expect(e.typ, e.val); err != nil {
+				return "", false, err
+			}
+		}
+		var err error
+		if force, err = strconv.ParseBool(it.Item().Val); err != nil {
+			return "", false, it.Item().Errorf("Expected true or false for force of "+
+				"@cascadeDelete. Got %v", it.Item().Val)
+		}
+	}
+	if err := expect(itemRightRound, ""); err != nil {
+		return "", false, err
+	}
+	return pred, force, nil
+}
+
 func parseTypeField(it *lex.ItemIterator, typeName string, ns uint64) (*pb.SchemaUpdate, error) {
 	field := &pb.SchemaUpdate{Predicate: x.NamespaceAttr(ns, it.Item().Val)}
 	var list bool
@@ -450,7 +1081,8 @@ func isTypeDeclaration(item lex.Item, it *lex.ItemIterator) bool {
 	case nextItems[0].Typ != itemText:
 		return false
 
-	case nextItems[1].Typ != itemLeftCurl:
+	// The name of the type can be followed by its directives, e.g. @softDelete.
+	case nextItems[1].Typ != itemLeftCurl && nextItems[1].Typ != itemAt:
 		return false
 	}
 
@@ -503,6 +1135,12 @@ func parse(s string, namespace uint64) (*ParsedSchema, error) {
 			if err := resolveTokenizers(result.Preds); err != nil {
 				return nil, errors.Wrapf(err, "failed to enrich schema")
 			}
+			if err := checkDerivations(result.Preds); err != nil {
+				return nil, err
+			}
+			if err := checkAliases(result.Preds); err != nil {
+				return nil, err
+			}
 			return &result, nil
 
 		case itemText:
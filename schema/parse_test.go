@@ -26,6 +26,7 @@ import (
 
 	"github.com/dgraph-io/badger/v3"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 )
@@ -197,6 +198,324 @@ func TestSchemaIndexCustom(t *testing.T) {
 	require.Equal(t, "int", State().Tokenizer(context.Background(), x.GalaxyAttr("age"))[0].Name())
 }
 
+type skuTokenizer struct{}
+
+func (skuTokenizer) Name() string     { return "sku" }
+func (skuTokenizer) Type() string     { return "string" }
+func (skuTokenizer) Identifier() byte { return 0xfe }
+func (skuTokenizer) Version() string  { return "2" }
+func (skuTokenizer) Tokens(v interface{}) ([]string, error) {
+	return []string{v.(string)}, nil
+}
+
+func TestSchemaIndexCustomPlugin(t *testing.T) {
+	if !tok.IsCustomTokenizer("sku") {
+		require.NoError(t, tok.RegisterPlugin(skuTokenizer{}))
+	}
+
+	reset()
+	result, err := Parse(`sku: string @index(exact, custom:"sku") .`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 1)
+	require.Equal(t, []string{"exact", "sku"}, result.Preds[0].Tokenizer)
+	require.Equal(t, []string{"sku=2"}, result.Preds[0].TokenizerVersions)
+
+	// The tokenizer can still be used by its name.
+	reset()
+	result, err = Parse(`sku: string @index(sku) .`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sku"}, result.Preds[0].Tokenizer)
+	require.Equal(t, []string{"sku=2"}, result.Preds[0].TokenizerVersions)
+
+	reset()
+	_, err = Parse(`sku: string @index(custom:"missing") .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Custom tokenizer missing isn't loaded")
+
+	reset()
+	_, err = Parse(`sku: string @index(custom:"exact") .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "isn't a custom tokenizer")
+
+	reset()
+	_, err = Parse(`sku: string @index(custom:sku) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "in quotes after custom:")
+}
+
+func TestSchemaIndexExpr(t *testing.T) {
+	reset()
+	result, err := Parse(`email: string @index(exact, expr: "LOWER( trim(email))", type: hash) ` +
+		`@upsert .`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 1)
+	require.Equal(t, []string{"exact", "expr:lower(trim(email)):hash"},
+		result.Preds[0].Tokenizer)
+
+	reset()
+	_, err = Parse(`email: string @index(expr: "lower(name)", type: hash) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be applied to email")
+	require.Contains(t, err.Error(), "Index a @derived predicate")
+
+	// An index computed from several predicates is the one of a derived predicate.
+	reset()
+	result, err = Parse(`full: string @index(expr: "lower(full)", type: hash) ` +
+		`@derived(first + " " + last) .`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"expr:lower(full):hash"}, result.Preds[0].Tokenizer)
+	require.Equal(t, []string{"first", `" "`, "last"}, result.Preds[0].Derived)
+
+	reset()
+	_, err = Parse(`email: string @index(expr: "reverse(email)", type: hash) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Unknown function reverse")
+
+	reset()
+	_, err = Parse(`email: string @index(expr: "lower(email)", type: term) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can only have the type exact or hash")
+
+	reset()
+	_, err = Parse(`email: string @index(expr: "lower(email)") .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected type: after the expression")
+
+	reset()
+	_, err = Parse(`email: string @index(expr: "lower(email)", type: hash, ` +
+		`expr: "upper(email)", type: hash) .`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "More than one expression index of type hash")
+
+	reset()
+	_, err = Parse(`age: int @index(expr: "lower(age)", type: hash) .`)
+	require.Error(t, err)
+}
+
+func TestSchemaDerived(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		firstName: string .
+		lastName: string .
+		fullName: string @index(exact) @derived(firstName + " " + lastName) .
+		greeting: string @derived("Hello, " + fullName + "!") .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 4)
+	require.Equal(t, []string{"firstName", `" "`, "lastName"}, result.Preds[2].Derived)
+	require.Equal(t, []string{"exact"}, result.Preds[2].Tokenizer)
+	require.Equal(t, []string{`"Hello, "`, "fullName", `"!"`}, result.Preds[3].Derived)
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`age: int @derived(name) .`, "@derived directive can only be specified for string type"},
+		{`names: [string] @derived(name) .`, "can only be specified for string type"},
+		{`name: string @derived .`, "Expected ( after @derived"},
+		{`name: string @derived() .`, "Expected a predicate or a string"},
+		{`name: string @derived(first +) .`, "Expected a predicate or a string"},
+		{`name: string @derived(first last) .`, "Expected + or )"},
+		{`name: string @derived(name + "x") .`, "can't be derived from itself"},
+		{`name: string @derived("x") .`, "must have at least one source predicate"},
+		{`name: string @derived(<dgraph.type>) .`, "Reserved predicate dgraph.type can't be used"},
+		{"friend: [uid] .\nname: string @derived(friend) .", "can only use scalar predicates"},
+		{"tags: [string] .\nname: string @derived(tags) .", "but tags is of type [string]"},
+		{"a: string @derived(b) .\nb: string @derived(a) .", "Derived predicates can't have cycles"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestSchemaPattern(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		email: string @index(exact) @pattern("^[^@]+@[^@]+$") .
+		tags: [string] @pattern("^[a-z]+$") .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 2)
+	require.Equal(t, "^[^@]+@[^@]+$", result.Preds[0].Pattern)
+	require.Equal(t, []string{"exact"}, result.Preds[0].Tokenizer)
+	require.Equal(t, "^[a-z]+$", result.Preds[1].Pattern)
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`age: int @pattern("^[0-9]+$") .`, "@pattern directive can only be specified for string"},
+		{`email: string @pattern .`, "Expected ( after @pattern"},
+		{`email: string @pattern(email) .`, "Expected a quoted regular expression"},
+		{`email: string @pattern("a" "b") .`, "Expected ) after the pattern"},
+		{`email: string @pattern("a(b") .`, "Invalid regular expression in @pattern"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestSchemaAlias(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		fullName: string @index(term) @alias(name, <full_name>) .
+		friend: [uid] @reverse @alias(knows) .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 2)
+	require.Equal(t, []string{"name", "full_name"}, result.Preds[0].Aliases)
+	require.Equal(t, []string{"term"}, result.Preds[0].Tokenizer)
+	require.Equal(t, []string{"knows"}, result.Preds[1].Aliases)
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`name: string @alias .`, "Expected ( after @alias"},
+		{`name: string @alias() .`, "Expected a predicate name in @alias"},
+		{`name: string @alias(a b) .`, "Expected , or ) in @alias"},
+		{`name: string @alias(a,) .`, "Expected a predicate name in @alias"},
+		{`name: string @alias(name) .`, "Predicate name can't be an alias of itself"},
+		{`name: string @alias(dgraph.type) .`, "Reserved predicate dgraph.type can't be an alias"},
+		{`name: string @alias(title) .
+		  title: string .`, "Alias title of predicate name is already a predicate"},
+		{`name: string @alias(title) .
+		  label: string @alias(title) .`, "Alias title can't be used by both predicates"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestSchemaEnum(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		status: enum("active", "inactive", "banned") .
+		roles: [enum("admin","dev")] @index(exact) @upsert .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 2)
+	require.Equal(t, pb.Posting_STRING, result.Preds[0].ValueType)
+	require.Equal(t, []string{"active", "inactive", "banned"}, result.Preds[0].EnumValues)
+	// An enum is indexed as with @index(exact).
+	require.Equal(t, pb.SchemaUpdate_INDEX, result.Preds[0].Directive)
+	require.Equal(t, []string{"exact"}, result.Preds[0].Tokenizer)
+	require.True(t, result.Preds[1].List)
+	require.Equal(t, []string{"admin", "dev"}, result.Preds[1].EnumValues)
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`status: enum .`, "Expected ( after enum"},
+		{`status: enum() .`, "Expected a quoted value in enum"},
+		{`status: enum("a" "b") .`, "Expected , or ) after a value of enum"},
+		{`status: enum("a", "a") .`, `Duplicate value "a" in enum`},
+		{`status: enum("a") @index(term) .`, "An enum can only have @index(exact)"},
+		{`status: enum("a") @lang .`, "@lang directive can't be used with an enum"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestSchemaCardinality(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		worksAt: uid @cardinality(one) .
+		spouse: uid @cardinality(one-to-one, reject) @reverse .
+		employees: [uid] @reverse @cardinality(one-to-many) .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 3)
+	require.Equal(t, CardinalityOne, result.Preds[0].Cardinality)
+	require.False(t, result.Preds[0].CardinalityReject)
+	require.Equal(t, CardinalityOneToOne, result.Preds[1].Cardinality)
+	require.True(t, result.Preds[1].CardinalityReject)
+	require.Equal(t, CardinalityOneToMany, result.Preds[2].Cardinality)
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`name: string @cardinality(one) .`, "can only be specified for uid type"},
+		{`worksAt: uid @cardinality .`, "Expected ( after @cardinality"},
+		{`worksAt: uid @cardinality(two) .`, "Invalid cardinality two"},
+		{`worksAt: uid @cardinality(one, replace) .`, "Expected reject after ,"},
+		{`worksAt: uid @cardinality(one reject) .`, "Expected ) in @cardinality"},
+		{`worksAt: [uid] @cardinality(one) .`, "@cardinality(one) can only be specified for uid"},
+		{`spouse: uid @cardinality(one-to-one) .`, "@cardinality(one-to-one) requires @reverse"},
+		{`employees: uid @reverse @cardinality(one-to-many) .`,
+			"@cardinality(one-to-many) can only be specified for [uid] type"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestSchemaKey(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		email: string @index(exact) @key .
+		tenant: string @index(hash) @upsert @key .
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Preds, 2)
+	for _, su := range result.Preds {
+		require.True(t, su.Key)
+		require.True(t, su.Upsert)
+	}
+
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{`age: int @index(int) @key .`, "@key directive can only be specified for string type"},
+		{`emails: [string] @index(exact) @key .`, "can only be specified for string type"},
+		{`email: string @key .`, "@key requires @index(exact) or @index(hash)"},
+		{`email: string @index(term) @key .`, "@key requires @index(exact) or @index(hash)"},
+		{`email: string @index(exact) @lang @key .`, "@key and @lang can't be used together"},
+	}
+	for _, test := range tests {
+		reset()
+		_, err := Parse(test.schema)
+		require.Error(t, err, test.schema)
+		require.Contains(t, err.Error(), test.err, test.schema)
+	}
+}
+
+func TestDerivationOrder(t *testing.T) {
+	derived := map[string][]string{
+		x.GalaxyAttr("greeting"): {`"Hello, "`, "fullName"},
+		x.GalaxyAttr("fullName"): {"firstName", `" "`, "lastName"},
+		x.GalaxyAttr("initials"): {"firstName"},
+	}
+	order, err := DerivationOrder(derived)
+	require.NoError(t, err)
+	require.Equal(t, []string{x.GalaxyAttr("fullName"), x.GalaxyAttr("greeting"),
+		x.GalaxyAttr("initials")}, order)
+
+	derived[x.GalaxyAttr("fullName")] = []string{"greeting"}
+	_, err = DerivationOrder(derived)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "fullName -> greeting -> fullName")
+}
+
 func TestParse(t *testing.T) {
 	reset()
 	_, err := Parse("age:int @index . name:string")
@@ -232,6 +551,17 @@ func TestParse4_NoError(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestParseBinary(t *testing.T) {
+	reset()
+	result, err := Parse("thumbnail: bytes .")
+	require.NoError(t, err)
+	require.Equal(t, pb.Posting_BINARY, result.Preds[0].ValueType)
+
+	_, err = Parse("thumbnail: binary @index(exact) .")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicates of type binary can't be indexed")
+}
+
 func TestParse5_Error(t *testing.T) {
 	reset()
 	result, err := Parse("value:default @index .")
@@ -326,6 +656,88 @@ func TestParseScalarListError3(t *testing.T) {
 	require.Contains(t, err.Error(), "Unsupported type for list: [bool]")
 }
 
+func TestParseDecimal(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		price: decimal(2) @index(decimal) .
+		rates: [decimal(4)] .
+		amount: decimal .
+	`)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(result.Preds))
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:    x.GalaxyAttr("price"),
+		ValueType:    pb.Posting_DECIMAL,
+		Directive:    pb.SchemaUpdate_INDEX,
+		Tokenizer:    []string{"decimal"},
+		DecimalScale: 2,
+	}, result.Preds[0])
+
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:    x.GalaxyAttr("rates"),
+		ValueType:    pb.Posting_DECIMAL,
+		List:         true,
+		DecimalScale: 4,
+	}, result.Preds[1])
+
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate: x.GalaxyAttr("amount"),
+		ValueType: pb.Posting_DECIMAL,
+	}, result.Preds[2])
+}
+
+func TestParseDecimalError(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{"price: float(2) .", "Scale can only be given for type decimal, not float"},
+		{"price: decimal(39) .", "Scale of decimal must be between 1 and 38, got: 39"},
+		{"price: decimal(0) .", "Scale of decimal must be between 1 and 38, got: 0"},
+		{"price: decimal(abc) .", "Expected a number for the scale of decimal"},
+		{"price: decimal(2 .", "Unclosed ( while parsing scale of decimal for: price"},
+	}
+	for _, tc := range tests {
+		reset()
+		_, err := Parse(tc.schema)
+		require.Error(t, err, tc.schema)
+		require.Contains(t, err.Error(), tc.err, tc.schema)
+	}
+}
+
+func TestParseUndirected(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		buddy: [uid] @undirected @count .
+	`)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(result.Preds))
+	require.EqualValues(t, &pb.SchemaUpdate{
+		Predicate:  x.GalaxyAttr("buddy"),
+		ValueType:  pb.Posting_UID,
+		List:       true,
+		Count:      true,
+		Undirected: true,
+	}, result.Preds[0])
+}
+
+func TestParseUndirectedError(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{"buddy: uid @undirected .", "@undirected directive can only be specified for [uid] type"},
+		{"buddy: [string] @undirected .", "@undirected directive can only be specified for [uid]"},
+		{"buddy: [uid] @undirected @reverse .", "@undirected and @reverse can't be used together"},
+	}
+	for _, tc := range tests {
+		reset()
+		_, err := Parse(tc.schema)
+		require.Error(t, err, tc.schema)
+		require.Contains(t, err.Error(), tc.err, tc.schema)
+	}
+}
+
 func TestParseUidList(t *testing.T) {
 	reset()
 	result, err := Parse(`
@@ -482,6 +894,80 @@ func TestParseTypeDuplicateFields(t *testing.T) {
 	require.Contains(t, err.Error(), "Duplicate fields with name: name")
 }
 
+func TestParseTypeSoftDelete(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		type Post @softDelete(on: deleted) {
+			title
+			deleted
+		}
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Types, 1)
+	require.Equal(t, "deleted", result.Types[0].SoftDelete)
+	require.Len(t, result.Types[0].Fields, 2)
+
+	_, err = Parse(`
+		type Post @softDelete(on: deleted) {
+			title
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate deleted of @softDelete should be a field of type Post")
+
+	_, err = Parse(`
+		type Post @softDelete(deleted) {
+			deleted
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected @softDelete(on: <predicate>) after type name")
+}
+
+func TestParseTypeCascadeDelete(t *testing.T) {
+	reset()
+	result, err := Parse(`
+		type User @cascadeDelete(via: owns) {
+			name
+			owns
+		}
+		type Folder @cascadeDelete(via: files, force: true) {
+			files
+		}
+	`)
+	require.NoError(t, err)
+	require.Len(t, result.Types, 2)
+	require.Equal(t, "owns", result.Types[0].CascadeDelete)
+	require.False(t, result.Types[0].CascadeDeleteForce)
+	require.Len(t, result.Types[0].Fields, 2)
+	require.Equal(t, "files", result.Types[1].CascadeDelete)
+	require.True(t, result.Types[1].CascadeDeleteForce)
+
+	_, err = Parse(`
+		type User @cascadeDelete(via: owns) {
+			name
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate owns of @cascadeDelete should be a field of type User")
+
+	_, err = Parse(`
+		type User @cascadeDelete(owns) {
+			owns
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected @cascadeDelete(via: <predicate>) after type name")
+
+	_, err = Parse(`
+		type User @cascadeDelete(via: owns, force: maybe) {
+			owns
+		}
+	`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected true or false for force of @cascadeDelete")
+}
+
 func TestOldTypeFormat(t *testing.T) {
 	reset()
 	result, err := Parse(`
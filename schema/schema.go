@@ -62,6 +62,8 @@ func (s *state) init() {
 	s.types = make(map[string]*pb.TypeUpdate)
 	s.elog = trace.NewEventLog("Dgraph", "Schema")
 	s.mutSchema = make(map[string]*pb.SchemaUpdate)
+	s.derived = make(map[string][]string)
+	s.aliases = make(map[string]string)
 }
 
 type state struct {
@@ -72,6 +74,12 @@ type state struct {
 	elog      trace.EventLog
 	// mutSchema holds the schema update that is being applied in the background.
 	mutSchema map[string]*pb.SchemaUpdate
+	// derived holds the derivations of the derived predicates in the cluster, including the ones
+	// served by other groups, so that they can be computed when their sources are mutated.
+	derived map[string][]string
+	// aliases maps the aliases of the predicates in the cluster to the predicate they stand for,
+	// so that they can be resolved by any alpha receiving a query or a mutation.
+	aliases map[string]string
 }
 
 // State returns the struct holding the current schema.
@@ -94,6 +102,14 @@ func (s *state) DeleteAll() {
 	for pred := range s.mutSchema {
 		delete(s.mutSchema, pred)
 	}
+
+	for pred := range s.derived {
+		delete(s.derived, pred)
+	}
+
+	for alias := range s.aliases {
+		delete(s.aliases, alias)
+	}
 }
 
 // Delete updates the schema in memory and disk
@@ -113,6 +129,8 @@ func (s *state) Delete(attr string, ts uint64) error {
 
 	delete(s.predicate, attr)
 	delete(s.mutSchema, attr)
+	delete(s.derived, attr)
+	s.setAliases(attr, nil)
 	return nil
 }
 
@@ -169,6 +187,16 @@ func (s *state) DeletePredsForNs(delNs uint64) {
 			delete(s.mutSchema, pred)
 		}
 	}
+	for pred := range s.derived {
+		if x.ParseNamespace(pred) == delNs {
+			delete(s.derived, pred)
+		}
+	}
+	for alias := range s.aliases {
+		if x.ParseNamespace(alias) == delNs {
+			delete(s.aliases, alias)
+		}
+	}
 	for typ := range s.types {
 		ns := x.ParseNamespace(typ)
 		if ns == delNs {
@@ -204,6 +232,8 @@ func (s *state) Set(pred string, schema *pb.SchemaUpdate) {
 	s.Lock()
 	defer s.Unlock()
 	s.predicate[pred] = schema
+	s.setDerivation(pred, schema.Derived)
+	s.setAliases(pred, schema.Aliases)
 	s.elog.Printf(logUpdate(schema, pred))
 }
 
@@ -404,6 +434,22 @@ func (s *state) IsReversed(ctx context.Context, pred string) bool {
 	return false
 }
 
+// IsUndirected returns whether the edges of the predicate are stored in both directions or not.
+func (s *state) IsUndirected(ctx context.Context, pred string) bool {
+	isWrite, _ := ctx.Value(isWrite).(bool)
+	s.RLock()
+	defer s.RUnlock()
+	if isWrite {
+		if schema, ok := s.mutSchema[pred]; ok && schema.Undirected {
+			return true
+		}
+	}
+	if schema, ok := s.predicate[pred]; ok {
+		return schema.Undirected
+	}
+	return false
+}
+
 // HasCount returns whether we want to mantain a count index for the given predicate or not.
 func (s *state) HasCount(ctx context.Context, pred string) bool {
 	isWrite, _ := ctx.Value(isWrite).(bool)
@@ -454,6 +500,36 @@ func (s *state) HasNoConflict(pred string) bool {
 	return s.predicate[pred].GetNoConflict()
 }
 
+// Pattern returns the regular expression the values of the predicate must match, if any.
+func (s *state) Pattern(pred string) string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetPattern()
+}
+
+// EnumValues returns the values of the predicate if it is an enum, nil otherwise.
+func (s *state) EnumValues(pred string) []string {
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetEnumValues()
+}
+
+// Cardinality returns the cardinality of the uid edges of the predicate, if any, and whether the
+// edges breaking it are rejected rather than replaced.
+func (s *state) Cardinality(pred string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	su := s.predicate[pred]
+	return su.GetCardinality(), su.GetCardinalityReject()
+}
+
+// IsKey returns whether the values of the predicate identify the nodes.
+func (s *state) IsKey(pred string) bool {
+	s.RLock()
+	defer s.RUnlock()
+	return s.predicate[pred].GetKey()
+}
+
 // IndexingInProgress checks whether indexing is going on for a given predicate.
 func (s *state) IndexingInProgress() bool {
 	s.RLock()
@@ -618,6 +694,22 @@ func initialTypesInternal(namespace uint64, all bool) []*pb.TypeUpdate {
 					ValueType: pb.Posting_STRING,
 				},
 			},
+		}, &pb.TypeUpdate{
+			TypeName: "dgraph.uidset",
+			Fields: []*pb.SchemaUpdate{
+				{
+					Predicate: "dgraph.uidset.name",
+					ValueType: pb.Posting_STRING,
+				},
+				{
+					Predicate: "dgraph.uidset.members",
+					ValueType: pb.Posting_UID,
+				},
+				{
+					Predicate: "dgraph.uidset.expiry",
+					ValueType: pb.Posting_DATETIME,
+				},
+			},
 		})
 
 	if all || x.WorkerConfig.AclEnabled {
@@ -720,6 +812,19 @@ func initialSchemaInternal(namespace uint64, all bool) []*pb.SchemaUpdate {
 			ValueType: pb.Posting_STRING,
 			Directive: pb.SchemaUpdate_INDEX,
 			Tokenizer: []string{"sha256"},
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.uidset.name",
+			ValueType: pb.Posting_STRING,
+			Directive: pb.SchemaUpdate_INDEX,
+			Tokenizer: []string{"exact"},
+			Upsert:    true,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.uidset.members",
+			ValueType: pb.Posting_UID,
+			List:      true,
+		}, &pb.SchemaUpdate{
+			Predicate: "dgraph.uidset.expiry",
+			ValueType: pb.Posting_DATETIME,
 		})
 
 	if all || x.WorkerConfig.AclEnabled {
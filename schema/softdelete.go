@@ -0,0 +1,43 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package schema
+
+import (
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// SoftDeleteTypes returns the types of the namespace declared with @softDelete, mapped to the
+// predicate marking their nodes as deleted. The names are returned without the namespace.
+func (s *state) SoftDeleteTypes(ns uint64) map[string]string {
+	if s == nil {
+		return nil
+	}
+
+	s.RLock()
+	defer s.RUnlock()
+	var out map[string]string
+	for name, typ := range s.types {
+		if typ.SoftDelete == "" || x.ParseNamespace(name) != ns {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[x.ParseAttr(name)] = typ.SoftDelete
+	}
+	return out
+}
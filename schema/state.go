@@ -36,6 +36,8 @@ const (
 	itemLeftSquare
 	itemRightSquare
 	itemExclamationMark
+	itemQuotedText
+	itemPlus
 )
 
 func lexText(l *lex.Lexer) lex.StateFn {
@@ -79,6 +81,13 @@ Loop:
 			l.Emit(itemRightSquare)
 		case r == '!':
 			l.Emit(itemExclamationMark)
+		case r == '+':
+			l.Emit(itemPlus)
+		case r == '"':
+			if err := l.LexQuotedString(); err != nil {
+				return l.Errorf("Invalid schema: %v", err)
+			}
+			l.Emit(itemQuotedText)
 		case r == '_':
 			// Predicates can start with _.
 			return lexWord
@@ -229,8 +229,9 @@ func TestBackupFilesystem(t *testing.T) {
 	// Check the predicates and types in the schema are as expected.
 	// TODO: refactor tests so that minio and filesystem tests share most of their logic.
 	preds := []string{"dgraph.graphql.schema", "name", "dgraph.graphql.xid", "dgraph.type",
-		"movie", "dgraph.graphql.p_query", "dgraph.drop.op"}
-	types := []string{"Node", "dgraph.graphql", "dgraph.graphql.persisted_query"}
+		"movie", "dgraph.graphql.p_query", "dgraph.drop.op", "dgraph.uidset.name",
+		"dgraph.uidset.members", "dgraph.uidset.expiry"}
+	types := []string{"Node", "dgraph.graphql", "dgraph.graphql.persisted_query", "dgraph.uidset"}
 	testutil.CheckSchema(t, preds, types)
 
 	verifyUids := func(count int) {
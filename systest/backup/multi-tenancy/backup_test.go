@@ -117,9 +117,10 @@ func TestBackupMultiTenancy(t *testing.T) {
 
 	preds := []string{"dgraph.graphql.schema", "name", "dgraph.graphql.xid", "dgraph.type", "movie",
 		"dgraph.graphql.p_query", "dgraph.drop.op", "dgraph.xid", "dgraph.acl.rule",
-		"dgraph.password", "dgraph.user.group", "dgraph.rule.predicate", "dgraph.rule.permission"}
+		"dgraph.password", "dgraph.user.group", "dgraph.rule.predicate", "dgraph.rule.permission",
+		"dgraph.uidset.name", "dgraph.uidset.members", "dgraph.uidset.expiry"}
 	preds = append(preds, preds...)
-	types := []string{"Node", "dgraph.graphql", "dgraph.graphql.persisted_query",
+	types := []string{"Node", "dgraph.graphql", "dgraph.graphql.persisted_query", "dgraph.uidset",
 		"dgraph.type.Rule", "dgraph.type.User", "dgraph.type.Group"} // ACL
 	types = append(types, types...)
 	testutil.CheckSchema(t, preds, types)
@@ -83,7 +83,10 @@ var expectedSchema = `[0x0] <movie>:string .` + " " + `
 [0x0] <dgraph.type>:[string] @index(exact) .` + " " + `
 [0x0] <dgraph.drop.op>:string .` + " " + `
 [0x0] <dgraph.graphql.xid>:string @index(exact) @upsert .` + " " + `
+[0x0] <dgraph.uidset.name>:string @index(exact) @upsert .` + " " + `
+[0x0] <dgraph.uidset.expiry>:datetime .` + " " + `
 [0x0] <dgraph.graphql.schema>:string .` + " " + `
+[0x0] <dgraph.uidset.members>:[uid] .` + " " + `
 [0x0] <dgraph.graphql.p_query>:string @index(sha256) .` + " " + `
 [0x0] type <Node> {
 	movie
@@ -95,6 +98,11 @@ var expectedSchema = `[0x0] <movie>:string .` + " " + `
 [0x0] type <dgraph.graphql.persisted_query> {
 	dgraph.graphql.p_query
 }
+[0x0] type <dgraph.uidset> {
+	dgraph.uidset.name
+	dgraph.uidset.members
+	dgraph.uidset.expiry
+}
 `
 var moviesData = `<_:x1> <movie> "BIRDS MAN OR (THE UNEXPECTED VIRTUE OF IGNORANCE)" .
 	<_:x2> <movie> "Spotlight" .
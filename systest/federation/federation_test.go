@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package federation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/testutil"
+)
+
+func setup(t *testing.T, alpha, schema, rdf string) *dgo.Dgraph {
+	dg, err := testutil.DgraphClient(testutil.ContainerAddr(alpha, 9080))
+	require.NoError(t, err)
+	testutil.DropAll(t, dg)
+	require.NoError(t, dg.Alter(context.Background(), &api.Operation{Schema: schema}))
+	_, err = dg.NewTxn().Mutate(context.Background(), &api.Mutation{
+		SetNquads: []byte(rdf),
+		CommitNow: true,
+	})
+	require.NoError(t, err)
+	return dg
+}
+
+func TestFederation(t *testing.T) {
+	local := setup(t, "alpha1", `
+		sku: string @index(exact) .
+		name: string @index(term) .
+	`, `
+		_:a <sku> "a" .
+		_:a <name> "red chair" .
+		_:b <sku> "b" .
+		_:b <name> "blue chair" .
+		_:c <sku> "c" .
+		_:c <name> "red table" .
+		_:d <name> "green table" .
+	`)
+	// The uids of the remote nodes don't match the local ones, the remote cluster also has a node
+	// which isn't known locally.
+	setup(t, "alpha2", `
+		sku: string @index(exact) .
+		price: int @index(int) .
+		stock: string @index(exact) .
+	`, `
+		_:x <sku> "x" .
+		_:x <price> "1" .
+		_:c <sku> "c" .
+		_:c <price> "30" .
+		_:c <stock> "out" .
+		_:a <sku> "a" .
+		_:a <price> "10" .
+		_:a <stock> "in" .
+		_:b <sku> "b" .
+		_:b <price> "20" .
+		_:b <stock> "in" .
+	`)
+
+	for _, tc := range []struct {
+		name, query, resp string
+	}{
+		{"values", `{q(func: anyofterms(name, "red"), orderasc: sku) { sku price stock }}`,
+			`{"q": [{"sku": "a", "price": 10, "stock": "in"},
+				{"sku": "c", "price": 30, "stock": "out"}]}`},
+		{"root function", `{q(func: ge(price, 20), orderasc: sku) { sku name }}`,
+			`{"q": [{"sku": "b", "name": "blue chair"}, {"sku": "c", "name": "red table"}]}`},
+		{"filter", `{q(func: anyofterms(name, "chair table"), orderasc: sku)
+			@filter(eq(stock, "in") and lt(price, 15)) { sku }}`,
+			`{"q": [{"sku": "a"}]}`},
+		{"negated filter", `{q(func: anyofterms(name, "chair table"), orderasc: name)
+			@filter(not le(price, 20)) { name }}`,
+			`{"q": [{"name": "green table"}, {"name": "red table"}]}`},
+		// The node without a price comes last.
+		{"order", `{q(func: has(name), orderdesc: price) { name }}`,
+			`{"q": [{"name": "red table"}, {"name": "blue chair"}, {"name": "red chair"},
+				{"name": "green table"}]}`},
+		{"order and pagination", `{q(func: has(sku), orderasc: price, first: 2) { sku price }}`,
+			`{"q": [{"sku": "a", "price": 10}, {"sku": "b", "price": 20}]}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := local.NewReadOnlyTxn().Query(context.Background(), tc.query)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.resp, string(resp.Json))
+		})
+	}
+
+	// The counts are of the remote nodes, which can't be matched with the local ones.
+	_, err := local.NewReadOnlyTxn().Query(context.Background(),
+		`{q(func: has(sku)) @filter(gt(count(price), 0)) { sku }}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't use a count or a variable")
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -552,6 +553,79 @@ func TestTokenExpired(t *testing.T) {
 	require.Contains(t, err.Error(), "Only guardian of galaxy is allowed to do this operation")
 }
 
+func TestImportBundle(t *testing.T) {
+	prepare(t)
+	galaxyToken := testutil.Login(t,
+		&testutil.LoginParams{UserID: "groot", Passwd: "password", Namespace: x.GalaxyNamespace})
+
+	// Export the data and the ACL of a namespace with a user alice.
+	ns1, err := testutil.CreateNamespaceWithRetry(t, galaxyToken)
+	require.NoError(t, err)
+	dc := testutil.DgClientWithLogin(t, "groot", "password", ns1)
+	testutil.AddData(t, dc)
+	token1 := testutil.Login(t,
+		&testutil.LoginParams{UserID: "groot", Passwd: "password", Namespace: ns1})
+	testutil.CreateUser(t, token1, "alice", "newpassword")
+
+	resp := testutil.MakeGQLRequestWithAccessJwt(t, &testutil.GraphQLParams{
+		Query: `mutation {
+			export(input: {format: "rdf", bundle: true, includeAcl: true}) {
+				response { code }
+				exportedFiles
+			}
+		}`,
+	}, token1.AccessJwt)
+	resp.RequireNoGraphQLErrors(t)
+	var exported struct {
+		Export struct {
+			ExportedFiles []string
+		}
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &exported))
+	require.Len(t, exported.Export.ExportedFiles, 1)
+
+	// The bundle must be in the export directory.
+	ns2, err := testutil.CreateNamespaceWithRetry(t, galaxyToken)
+	require.NoError(t, err)
+	token2 := testutil.Login(t,
+		&testutil.LoginParams{UserID: "groot", Passwd: "password", Namespace: ns2})
+	importBundle := `mutation importBundle($file: String!) {
+		import(input: {file: $file, includeAcl: true}) {
+			response { code }
+		}
+	}`
+	resp = testutil.MakeGQLRequestWithAccessJwt(t, &testutil.GraphQLParams{
+		Query:     importBundle,
+		Variables: map[string]interface{}{"file": "../" + exported.Export.ExportedFiles[0]},
+	}, token2.AccessJwt)
+	require.Len(t, resp.Errors, 1)
+	require.Contains(t, resp.Errors[0].Message, "must be in the export directory")
+
+	// Import the bundle into another namespace, the data and alice are restored.
+	resp = testutil.MakeGQLRequestWithAccessJwt(t, &testutil.GraphQLParams{
+		Query:     importBundle,
+		Variables: map[string]interface{}{"file": exported.Export.ExportedFiles[0]},
+	}, token2.AccessJwt)
+	resp.RequireNoGraphQLErrors(t)
+
+	query := `{ me(func: has(name)) { nickname name } }`
+	dc = testutil.DgClientWithLogin(t, "groot", "password", ns2)
+	testutil.CompareJSON(t, `{"me": [{"name":"guy1","nickname":"RG"},
+		{"name": "guy2", "nickname":"RG2"}]}`, string(testutil.QueryData(t, dc, query)))
+	dc = testutil.DgClientWithLogin(t, "alice", "newpassword", ns2)
+	testutil.CompareJSON(t, `{}`, string(testutil.QueryData(t, dc, query)))
+
+	// The user running the import keeps its access, its existing login still works and it's
+	// still a guardian of its namespace.
+	resp = testutil.MakeGQLRequestWithAccessJwt(t, &testutil.GraphQLParams{
+		Query: `{ getCurrentUser { name groups { name } } }`,
+	}, token2.AccessJwt)
+	resp.RequireNoGraphQLErrors(t)
+	testutil.CompareJSON(t, `{"getCurrentUser": {"name": "groot",
+		"groups": [{"name": "guardians"}]}}`, string(resp.Data))
+	testutil.CreateUser(t, token2, "bob", "newpassword")
+}
+
 func TestMain(m *testing.M) {
 	fmt.Printf("Using adminEndpoint : %s for multi-tenancy test.\n", testutil.AdminUrl())
 	os.Exit(m.Run())
@@ -399,6 +399,15 @@ func SchemaQueryTestPredicate1(t *testing.T, c *dgo.Dgraph) {
 	  {
         "predicate": "dgraph.graphql.xid"
 	  },
+	  {
+        "predicate": "dgraph.uidset.expiry"
+	  },
+	  {
+        "predicate": "dgraph.uidset.members"
+	  },
+	  {
+        "predicate": "dgraph.uidset.name"
+	  },
       {
         "predicate": "dgraph.user.group"
       },
@@ -96,7 +96,7 @@ func WaitForRestore(t *testing.T, dg *dgo.Dgraph) {
 		if err == nil {
 			numSuccess++
 		} else {
-			require.Contains(t, err.Error(), "the server is in draining mode")
+			require.Contains(t, err.Error(), "a restore is in progress on the server")
 			numSuccess = 0
 		}
 
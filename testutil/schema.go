@@ -39,7 +39,10 @@ const (
 {"predicate":"dgraph.drop.op", "type": "string"},
 {"predicate":"dgraph.graphql.p_query","type":"string","index":true,"tokenizer":["sha256"]},
 {"predicate":"dgraph.graphql.schema", "type": "string"},
-{"predicate":"dgraph.graphql.xid","type":"string","index":true,"tokenizer":["exact"],"upsert":true}
+{"predicate":"dgraph.graphql.xid","type":"string","index":true,"tokenizer":["exact"],"upsert":true},
+{"predicate":"dgraph.uidset.expiry","type":"datetime"},
+{"predicate":"dgraph.uidset.members","type":"uid","list":true},
+{"predicate":"dgraph.uidset.name","type":"string","index":true,"tokenizer":["exact"],"upsert":true}
 `
 	aclTypes = `
 {
@@ -60,6 +63,10 @@ const (
 },{
 	"fields": [{"name": "dgraph.graphql.p_query"}],
 	"name": "dgraph.graphql.persisted_query"
+},{
+	"fields": [{"name": "dgraph.uidset.expiry"},{"name": "dgraph.uidset.members"},
+		{"name": "dgraph.uidset.name"}],
+	"name": "dgraph.uidset"
 }
 `
 )
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// BM25Tokenizer generates the tokens used to rank the values by relevance with BM25, along with
+// the fulltext tokenizer which finds them. A value has a token for each of its terms, holding the
+// number of times the term appears in it, and a token holding its number of terms. The term
+// frequencies, the number of values having a term and the lengths of the values can then be read
+// from the index.
+type BM25Tokenizer struct{ lang string }
+
+func (t BM25Tokenizer) Name() string { return "bm25" }
+func (t BM25Tokenizer) Type() string { return "string" }
+func (t BM25Tokenizer) Tokens(v interface{}) ([]string, error) {
+	str, ok := v.(string)
+	if !ok || str == "" {
+		return []string{}, nil
+	}
+	terms := FullTextTerms(str, t.lang)
+	if len(terms) == 0 {
+		return []string{}, nil
+	}
+	freqs := make(map[string]int)
+	for _, term := range terms {
+		freqs[term]++
+	}
+	tokens := make([]string, 0, len(freqs)+1)
+	for term, freq := range freqs {
+		tokens = append(tokens, term+string(rune(IdentDelimiter))+strconv.Itoa(freq))
+	}
+	sort.Strings(tokens)
+	// The terms can't start with the delimiter.
+	return append(tokens, string(rune(IdentDelimiter))+strconv.Itoa(len(terms))), nil
+}
+func (t BM25Tokenizer) Identifier() byte { return IdentBM25 }
+func (t BM25Tokenizer) IsSortable() bool { return false }
+func (t BM25Tokenizer) IsLossy() bool    { return true }
+
+// FullTextTerms returns the terms of str for the fulltext tokenizer, in order and including the
+// repeated ones.
+func FullTextTerms(str, lang string) []string {
+	tokens := fulltextTokens(str, lang)
+	terms := make([]string, 0, len(tokens))
+	for i := range tokens {
+		terms = append(terms, string(tokens[i].Term))
+	}
+	return terms
+}
+
+// BM25TermPrefix returns the prefix of the index tokens of a term. The term is followed by the
+// delimiter, so that the prefix doesn't match the tokens of the terms it is a prefix of.
+func BM25TermPrefix(term string) string {
+	return encodeToken(term+string(rune(IdentDelimiter)), IdentBM25)
+}
+
+// BM25LengthPrefix returns the prefix of the index tokens of the lengths of the values.
+func BM25LengthPrefix() string {
+	return encodeToken(string(rune(IdentDelimiter)), IdentBM25)
+}
+
+// ParseBM25Count returns the number held by a token of the BM25 tokenizer: the frequency of its
+// term or the length of a value.
+func ParseBM25Count(token string) (int, error) {
+	idx := strings.LastIndexByte(token, IdentDelimiter)
+	if idx < 0 {
+		return 0, errors.Errorf("Invalid bm25 token %q", token)
+	}
+	n, err := strconv.Atoi(token[idx+1:])
+	if err != nil {
+		return 0, errors.Wrapf(err, "while parsing bm25 token %q", token)
+	}
+	return n, nil
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBM25Tokenizer(t *testing.T) {
+	tokenizer, has := GetTokenizer("bm25")
+	require.True(t, has)
+
+	tokens, err := BuildTokens("The quick fox jumps over the lazy fox",
+		GetTokenizerForLang(tokenizer, "en"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		BM25TermPrefix("fox") + "2",
+		BM25TermPrefix("jump") + "1",
+		BM25TermPrefix("lazi") + "1",
+		BM25TermPrefix("quick") + "1",
+		// The stop words aren't counted in the length.
+		BM25LengthPrefix() + "5",
+	}, tokens)
+
+	for _, token := range tokens[:4] {
+		require.Equal(t, byte(IdentBM25), token[0])
+	}
+	n, err := ParseBM25Count(tokens[0])
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	n, err = ParseBM25Count(tokens[4])
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+
+	tokens, err = BuildTokens("the", GetTokenizerForLang(tokenizer, "en"))
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+}
+
+func TestBM25TermPrefix(t *testing.T) {
+	// The prefix of a term doesn't match the tokens of the longer terms.
+	tokenizer := BM25Tokenizer{}
+	tokens, err := BuildTokens("catalog", tokenizer)
+	require.NoError(t, err)
+	require.NotContains(t, tokens[0], BM25TermPrefix("cat"))
+	require.Contains(t, tokens[0], BM25TermPrefix("catalog"))
+
+	_, err = ParseBM25Count("cat")
+	require.Error(t, err)
+}
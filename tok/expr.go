@@ -0,0 +1,172 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// An expression index indexes the result of an expression on the values of a string predicate
+// instead of the values themselves, e.g. @index(expr: "lower(email)", type: hash) indexes the
+// lowercased emails with the hash tokenizer. The expression is a composition of the functions
+// in exprFuncs applied to the predicate. The index is used by anyof and allof given the
+// expression instead of a tokenizer, e.g. anyof(email, "lower(email)", "Alice@Example.com")
+// matches "alice@example.com". eq never uses it, so it keeps comparing the values as they are.
+// With @upsert, the transactions setting values with the same result conflict, as for the index
+// of values.
+//
+// An index computed from several predicates is declared on a @derived predicate, e.g.
+// fullName: string @derived(firstName + " " + lastName) @index(expr: "lower(fullName)",
+// type: hash). The derived value, and so its index, is recomputed by the mutations changing any
+// of the sources.
+//
+// The tokenizer is identified by its name, expr:<expression>:<type>, so a predicate can have one
+// expression index of each type, and changing the expression rebuilds the index.
+
+var exprFuncs = map[string]func(string) string{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+}
+
+// exprBases are the tokenizers an expression index can use, along with the identifiers of the
+// expression indexes using them.
+var exprBases = map[string]byte{
+	"exact": IdentExprExact,
+	"hash":  IdentExprHash,
+}
+
+const exprTokenizerPrefix = "expr:"
+
+// ExprTokenizer tokenizes the result of an expression on string values with another tokenizer.
+type ExprTokenizer struct {
+	expr string
+	// fns are the functions of the expression, from the innermost one.
+	fns  []func(string) string
+	base Tokenizer
+}
+
+func (t ExprTokenizer) Name() string {
+	return exprTokenizerPrefix + t.expr + ":" + t.base.Name()
+}
+func (t ExprTokenizer) Type() string { return "string" }
+func (t ExprTokenizer) Tokens(v interface{}) ([]string, error) {
+	str, ok := v.(string)
+	if !ok {
+		return nil, errors.Errorf("Expression index only supported for string types")
+	}
+	return t.base.Tokens(t.Apply(str))
+}
+func (t ExprTokenizer) Identifier() byte { return exprBases[t.base.Name()] }
+func (t ExprTokenizer) IsSortable() bool { return false }
+
+// IsLossy is true as the value can't be known from the result of the expression.
+func (t ExprTokenizer) IsLossy() bool { return true }
+
+// Expr returns the expression of the index, e.g. lower(email).
+func (t ExprTokenizer) Expr() string { return t.expr }
+
+// Base returns the tokenizer the results of the expression are tokenized with.
+func (t ExprTokenizer) Base() Tokenizer { return t.base }
+
+// Apply returns the result of the expression on a value.
+func (t ExprTokenizer) Apply(s string) string {
+	for _, fn := range t.fns {
+		s = fn(s)
+	}
+	return s
+}
+
+// NewExprTokenizer returns the tokenizer of an expression index on the predicate attr, given
+// without its namespace. The expression needs to apply functions to attr, e.g. lower(trim(email)).
+func NewExprTokenizer(expr, attr, base string) (ExprTokenizer, error) {
+	var t ExprTokenizer
+	if _, ok := exprBases[base]; !ok {
+		return t, errors.Errorf("Expression indexes can only have the type exact or hash, got %s",
+			base)
+	}
+	t.base, _ = GetTokenizer(base)
+
+	rest := strings.ReplaceAll(expr, " ", "")
+	var names []string
+	for {
+		i := strings.IndexByte(rest, '(')
+		if i < 0 {
+			break
+		}
+		if !strings.HasSuffix(rest, ")") {
+			return t, errors.Errorf("Unbalanced parentheses in index expression %q", expr)
+		}
+		names = append(names, strings.ToLower(rest[:i]))
+		rest = rest[i+1 : len(rest)-1]
+	}
+	if rest != attr {
+		return t, errors.Errorf("Index expression %q of predicate %s must be applied to %s. "+
+			"Index a @derived predicate to combine several predicates", expr, attr, attr)
+	}
+	if len(names) == 0 {
+		return t, errors.Errorf("Index expression %q doesn't apply any function, use "+
+			"@index(%s) instead", expr, base)
+	}
+	for i := len(names) - 1; i >= 0; i-- {
+		fn, ok := exprFuncs[names[i]]
+		if !ok {
+			return t, errors.Errorf("Unknown function %s in index expression %q", names[i], expr)
+		}
+		t.fns = append(t.fns, fn)
+	}
+	t.expr = strings.Join(names, "(") + "(" + attr + strings.Repeat(")", len(names))
+	return t, nil
+}
+
+// exprTokenizers caches the tokenizers of the expression indexes by name.
+var exprTokenizers sync.Map
+
+// getExprTokenizer returns the tokenizer of an expression index from its name.
+func getExprTokenizer(name string) (Tokenizer, bool) {
+	if t, ok := exprTokenizers.Load(name); ok {
+		return t.(Tokenizer), true
+	}
+	expr, base, ok := ParseExprTokenizerName(name)
+	if !ok {
+		return nil, false
+	}
+	i := strings.LastIndexByte(expr, '(')
+	t, err := NewExprTokenizer(expr, strings.TrimRight(expr[i+1:], ")"), base)
+	if err != nil {
+		return nil, false
+	}
+	exprTokenizers.Store(name, t)
+	return t, true
+}
+
+// ParseExprTokenizerName returns the expression and the type of an expression index from the
+// name of its tokenizer. ok is false if the name isn't the one of an expression index.
+func ParseExprTokenizerName(name string) (expr, base string, ok bool) {
+	if !strings.HasPrefix(name, exprTokenizerPrefix) {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(name, exprTokenizerPrefix)
+	i := strings.LastIndexByte(rest, ':')
+	if i < 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExprTokenizer(t *testing.T) {
+	et, err := NewExprTokenizer("lower(trim(email))", "email", "exact")
+	require.NoError(t, err)
+	require.Equal(t, "expr:lower(trim(email)):exact", et.Name())
+	require.Equal(t, "alice@example.com", et.Apply("  Alice@Example.COM "))
+
+	tokens, err := BuildTokens(" Alice@Example.com", et)
+	require.NoError(t, err)
+	same, err := BuildTokens("alice@example.com", et)
+	require.NoError(t, err)
+	require.Equal(t, same, tokens)
+	require.Equal(t, byte(IdentExprExact), tokens[0][0])
+
+	// The tokenizer is found from its name, as stored in the schema.
+	found, ok := GetTokenizer(et.Name())
+	require.True(t, ok)
+	require.Equal(t, et.Name(), found.Name())
+	require.Equal(t, byte(IdentExprExact), found.Identifier())
+
+	expr, base, ok := ParseExprTokenizerName("expr:upper(name):hash")
+	require.True(t, ok)
+	require.Equal(t, "upper(name)", expr)
+	require.Equal(t, "hash", base)
+	_, _, ok = ParseExprTokenizerName("hash")
+	require.False(t, ok)
+
+	_, ok = GetTokenizer("expr:shout(name):hash")
+	require.False(t, ok)
+	_, err = NewExprTokenizer("lower(email", "email", "hash")
+	require.Error(t, err)
+	_, err = NewExprTokenizer("email", "email", "hash")
+	require.Error(t, err)
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"plugin"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+)
+
+// PluginAPIVersion is the version of the tokenizer plugin API supported by this release. Plugins
+// can export a PluginAPIVersion function returning the version they were written for, so that
+// incompatible plugins are rejected when they are loaded rather than failing later.
+//
+// Plugins don't need to import Dgraph. The symbols looked up in a plugin are:
+//
+//	func PluginAPIVersion() int      // optional, defaults to 1
+//	func Tokenizer() interface{}     // a single PluginTokenizer, or
+//	func Tokenizers() []interface{}  // several PluginTokenizers
+//
+// A tokenizer can also implement Version() string. The version is stored with the indexes using
+// the tokenizer, and they are rebuilt when a plugin with a different version is loaded.
+const PluginAPIVersion = 1
+
+// versioned is implemented by plugin tokenizers which report their version.
+type versioned interface {
+	Version() string
+}
+
+// RegisterPlugin registers a plugin tokenizer, so that it can be used in @index directives. It
+// can be used to register tokenizers compiled into the binary, instead of loading them from a
+// plugin file.
+func RegisterPlugin(t PluginTokenizer) error {
+	if t == nil {
+		return errors.New("custom tokenizer can't be nil")
+	}
+	name := t.Name()
+	if name == "" || strings.ContainsAny(name, " ,()\"") {
+		return errors.Errorf("invalid custom tokenizer name %q", name)
+	}
+	if strings.ToLower(name) != name {
+		return errors.Errorf("custom tokenizer name %q must be lowercase", name)
+	}
+	if id := t.Identifier(); id < IdentCustom {
+		return errors.Errorf("custom tokenizer %s identifier byte must be >= %#x, but was %#x",
+			name, IdentCustom, id)
+	}
+	if _, ok := tokenizers[name]; ok {
+		return errors.Errorf("duplicate tokenizer %s", name)
+	}
+	if other, ok := GetTokenizerByID(t.Identifier()); ok {
+		return errors.Errorf("custom tokenizer %s uses identifier %#x, which is already used by "+
+			"tokenizer %s", name, t.Identifier(), other.Name())
+	}
+	if _, ok := types.TypeForName(t.Type()); !ok {
+		return errors.Errorf("invalid type %q for custom tokenizer %s", t.Type(), name)
+	}
+	tokenizers[name] = CustomTokenizer{PluginTokenizer: t}
+	glog.Infof("Registered custom tokenizer %s (identifier %#x, version %q)", name,
+		t.Identifier(), PluginVersion(name))
+	return nil
+}
+
+// LoadCustomTokenizer reads the tokenizer plugin in the given file and registers the tokenizers
+// in it.
+func LoadCustomTokenizer(soFile string) error {
+	glog.Infof("Loading custom tokenizer from %q", soFile)
+	pl, err := plugin.Open(soFile)
+	if err != nil {
+		// Plugins built with a different Go version or different versions of shared packages
+		// fail here with a message which doesn't say much on its own.
+		return errors.Wrapf(err, "could not open custom tokenizer plugin %s. Plugins must be "+
+			"built with the same Go version as Dgraph, using go build -buildmode=plugin", soFile)
+	}
+
+	if symb, err := pl.Lookup("PluginAPIVersion"); err == nil {
+		fn, ok := symb.(func() int)
+		if !ok {
+			return errors.Errorf("PluginAPIVersion in custom tokenizer plugin %s must be a "+
+				"func() int, but is %T", soFile, symb)
+		}
+		if v := fn(); v != PluginAPIVersion {
+			return errors.Errorf("custom tokenizer plugin %s was built for plugin API version %d, "+
+				"but this Dgraph release supports version %d", soFile, v, PluginAPIVersion)
+		}
+	}
+
+	var values []interface{}
+	if symb, err := pl.Lookup("Tokenizers"); err == nil {
+		fn, ok := symb.(func() []interface{})
+		if !ok {
+			return errors.Errorf("Tokenizers in custom tokenizer plugin %s must be a "+
+				"func() []interface{}, but is %T", soFile, symb)
+		}
+		values = fn()
+	} else {
+		symb, err := pl.Lookup("Tokenizer")
+		if err != nil {
+			return errors.Wrapf(err, `could not find symbol "Tokenizer" or "Tokenizers" in `+
+				"custom tokenizer plugin %s", soFile)
+		}
+		fn, ok := symb.(func() interface{})
+		if !ok {
+			return errors.Errorf("Tokenizer in custom tokenizer plugin %s must be a "+
+				"func() interface{}, but is %T", soFile, symb)
+		}
+		values = []interface{}{fn()}
+	}
+
+	for _, v := range values {
+		t, ok := v.(PluginTokenizer)
+		if !ok {
+			return errors.Errorf("%T in custom tokenizer plugin %s doesn't implement the "+
+				"Name, Type, Tokens and Identifier methods", v, soFile)
+		}
+		if err := RegisterPlugin(t); err != nil {
+			return errors.Wrapf(err, "while loading custom tokenizer plugin %s", soFile)
+		}
+	}
+	return nil
+}
+
+// IsCustomTokenizer returns true if the tokenizer was registered by a plugin.
+func IsCustomTokenizer(name string) bool {
+	t, ok := GetTokenizer(name)
+	return ok && t.Identifier() >= IdentCustom
+}
+
+// PluginVersion returns the version reported by the given plugin tokenizer, or an empty string
+// if the tokenizer doesn't report one.
+func PluginVersion(name string) string {
+	t, ok := GetTokenizer(name)
+	if !ok {
+		return ""
+	}
+	ct, ok := t.(CustomTokenizer)
+	if !ok {
+		return ""
+	}
+	if v, ok := ct.PluginTokenizer.(versioned); ok {
+		return v.Version()
+	}
+	return ""
+}
+
+// PluginVersions returns the versions of the plugin tokenizers among the given tokenizers, as
+// name=version. Tokenizers which don't report a version are left out.
+func PluginVersions(names []string) []string {
+	var versions []string
+	for _, name := range names {
+		if v := PluginVersion(name); v != "" {
+			versions = append(versions, name+"="+v)
+		}
+	}
+	return versions
+}
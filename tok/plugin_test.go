@@ -0,0 +1,80 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tok
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPlugin struct {
+	name    string
+	typ     string
+	id      byte
+	version string
+}
+
+func (p testPlugin) Name() string     { return p.name }
+func (p testPlugin) Type() string     { return p.typ }
+func (p testPlugin) Identifier() byte { return p.id }
+func (p testPlugin) Version() string  { return p.version }
+func (p testPlugin) Tokens(v interface{}) ([]string, error) {
+	return []string{strings.ToUpper(v.(string))}, nil
+}
+
+func TestRegisterPlugin(t *testing.T) {
+	require.NoError(t, RegisterPlugin(testPlugin{name: "sku", typ: "string", id: 0xf0,
+		version: "1.2"}))
+	require.True(t, IsCustomTokenizer("sku"))
+	require.False(t, IsCustomTokenizer("exact"))
+	require.Equal(t, "1.2", PluginVersion("sku"))
+	require.Equal(t, []string{"sku=1.2"}, PluginVersions([]string{"exact", "sku"}))
+
+	tokenizer, ok := GetTokenizer("sku")
+	require.True(t, ok)
+	tokens, err := BuildTokens("ab-1", tokenizer)
+	require.NoError(t, err)
+	require.Equal(t, []string{encodeToken("AB-1", 0xf0)}, tokens)
+
+	tests := []struct {
+		plugin testPlugin
+		err    string
+	}{
+		{testPlugin{name: "sku", typ: "string", id: 0xf1}, "duplicate tokenizer sku"},
+		{testPlugin{name: "exact", typ: "string", id: 0xf1}, "duplicate tokenizer exact"},
+		{testPlugin{name: "other", typ: "string", id: 0xf0}, "already used by tokenizer sku"},
+		{testPlugin{name: "other", typ: "string", id: 0x10}, "identifier byte must be >= 0x80"},
+		{testPlugin{name: "Other", typ: "string", id: 0xf1}, "must be lowercase"},
+		{testPlugin{name: "", typ: "string", id: 0xf1}, "invalid custom tokenizer name"},
+		{testPlugin{name: "a b", typ: "string", id: 0xf1}, "invalid custom tokenizer name"},
+		{testPlugin{name: "other", typ: "text", id: 0xf1}, `invalid type "text"`},
+	}
+	for _, tc := range tests {
+		err := RegisterPlugin(tc.plugin)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+	require.Error(t, RegisterPlugin(nil))
+}
+
+func TestLoadCustomTokenizerMissingFile(t *testing.T) {
+	err := LoadCustomTokenizer("/does/not/exist.so")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "same Go version")
+}
@@ -18,11 +18,11 @@ package tok
 
 import (
 	"encoding/binary"
-	"plugin"
+	"math"
+	"math/big"
 	"strings"
 	"time"
 
-	"github.com/golang/glog"
 	geom "github.com/twpayne/go-geom"
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/text/collate"
@@ -53,6 +53,10 @@ const (
 	IdentTrigram   = 0xA
 	IdentHash      = 0xB
 	IdentSha       = 0xC
+	IdentDecimal   = 0xD
+	IdentExprExact = 0xE
+	IdentExprHash  = 0xF
+	IdentBM25      = 0x10
 	IdentCustom    = 0x80
 	IdentDelimiter = 0x1f // ASCII 31 - Unit seperator
 )
@@ -90,6 +94,7 @@ func init() {
 	registerTokenizer(GeoTokenizer{})
 	registerTokenizer(IntTokenizer{})
 	registerTokenizer(FloatTokenizer{})
+	registerTokenizer(DecimalTokenizer{})
 	registerTokenizer(YearTokenizer{})
 	registerTokenizer(HourTokenizer{})
 	registerTokenizer(MonthTokenizer{})
@@ -100,6 +105,7 @@ func init() {
 	registerTokenizer(HashTokenizer{})
 	registerTokenizer(TermTokenizer{})
 	registerTokenizer(FullTextTokenizer{})
+	registerTokenizer(BM25Tokenizer{})
 	registerTokenizer(Sha256Tokenizer{})
 	setupBleve()
 }
@@ -118,25 +124,6 @@ func BuildTokens(val interface{}, t Tokenizer) ([]string, error) {
 	return tokens, nil
 }
 
-// LoadCustomTokenizer reads and loads a custom tokenizer from the given file.
-func LoadCustomTokenizer(soFile string) {
-	glog.Infof("Loading custom tokenizer from %q", soFile)
-	pl, err := plugin.Open(soFile)
-	x.Checkf(err, "could not open custom tokenizer plugin file")
-	symb, err := pl.Lookup("Tokenizer")
-	x.Checkf(err, `could not find symbol "Tokenizer" while loading custom tokenizer: %v`, err)
-
-	// Let any type assertion panics occur, since they will contain a message
-	// telling the user what went wrong. Otherwise it's hard to capture this
-	// information to pass on to the user.
-	tokenizer := symb.(func() interface{})().(PluginTokenizer)
-
-	id := tokenizer.Identifier()
-	x.AssertTruef(id >= IdentCustom,
-		"custom tokenizer identifier byte must be >= 0x80, but was %#x", id)
-	registerTokenizer(CustomTokenizer{PluginTokenizer: tokenizer})
-}
-
 // GetTokenizerByID tries to find a tokenizer by id in the registered list.
 // Returns the tokenizer and true if found, otherwise nil and false.
 func GetTokenizerByID(id byte) (Tokenizer, bool) {
@@ -151,6 +138,9 @@ func GetTokenizerByID(id byte) (Tokenizer, bool) {
 // GetTokenizer returns tokenizer given unique name.
 func GetTokenizer(name string) (Tokenizer, bool) {
 	t, found := tokenizers[name]
+	if !found {
+		return getExprTokenizer(name)
+	}
 	return t, found
 }
 
@@ -211,6 +201,26 @@ func (t FloatTokenizer) Identifier() byte { return IdentFloat }
 func (t FloatTokenizer) IsSortable() bool { return true }
 func (t FloatTokenizer) IsLossy() bool    { return true }
 
+// DecimalTokenizer generates tokens from decimal data. Like the float tokens, these are the
+// integer part of the value, so the exact value is compared after the index lookup.
+type DecimalTokenizer struct{}
+
+func (t DecimalTokenizer) Name() string { return "decimal" }
+func (t DecimalTokenizer) Type() string { return "decimal" }
+func (t DecimalTokenizer) Tokens(v interface{}) ([]string, error) {
+	i := v.(types.Decimal).Trunc()
+	switch {
+	case i.Cmp(big.NewInt(math.MaxInt64)) > 0:
+		return []string{encodeInt(math.MaxInt64)}, nil
+	case i.Cmp(big.NewInt(math.MinInt64)) < 0:
+		return []string{encodeInt(math.MinInt64)}, nil
+	}
+	return []string{encodeInt(i.Int64())}, nil
+}
+func (t DecimalTokenizer) Identifier() byte { return IdentDecimal }
+func (t DecimalTokenizer) IsSortable() bool { return true }
+func (t DecimalTokenizer) IsLossy() bool    { return true }
+
 // YearTokenizer generates year tokens from datetime data.
 type YearTokenizer struct{}
 
@@ -364,15 +374,7 @@ func (t FullTextTokenizer) Tokens(v interface{}) ([]string, error) {
 	if !ok || str == "" {
 		return []string{}, nil
 	}
-	lang := LangBase(t.lang)
-	// pass 1 - lowercase and normalize input
-	tokens := fulltextAnalyzer.Analyze([]byte(str))
-	// pass 2 - filter stop words
-	tokens = filterStopwords(lang, tokens)
-	// pass 3 - filter stems
-	tokens = filterStemmers(lang, tokens)
-	// finally, return the terms.
-	return uniqueTerms(tokens), nil
+	return uniqueTerms(fulltextTokens(str, t.lang)), nil
 }
 func (t FullTextTokenizer) Identifier() byte { return IdentFullText }
 func (t FullTextTokenizer) IsSortable() bool { return false }
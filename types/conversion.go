@@ -18,6 +18,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
 	"math"
@@ -91,6 +92,12 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				*res = w
 			case PasswordID:
 				*res = string(data)
+			case DecimalID:
+				var d Decimal
+				if err := d.UnmarshalBinary(data); err != nil {
+					return to, err
+				}
+				*res = d
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -144,6 +151,12 @@ func Convert(from Val, toID TypeID) (Val, error) {
 					return to, err
 				}
 				*res = p
+			case DecimalID:
+				d, err := ParseDecimal(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = d
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -169,6 +182,8 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				*res = strconv.FormatInt(vc, 10)
 			case DateTimeID:
 				*res = time.Unix(vc, 0).UTC()
+			case DecimalID:
+				*res = DecimalFromInt(vc)
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -202,6 +217,12 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				fracSecs := vc - float64(secs)
 				nsecs := int64(fracSecs * nanoSecondsInSec)
 				*res = time.Unix(secs, nsecs).UTC()
+			case DecimalID:
+				d, err := DecimalFromFloat(vc)
+				if err != nil {
+					return to, err
+				}
+				*res = d
 			default:
 				return to, cantConvert(fromID, toID)
 			}
@@ -304,6 +325,37 @@ func Convert(from Val, toID TypeID) (Val, error) {
 				return to, cantConvert(fromID, toID)
 			}
 		}
+	case DecimalID:
+		{
+			var vc Decimal
+			if err := vc.UnmarshalBinary(data); err != nil {
+				return to, err
+			}
+			switch toID {
+			case DecimalID:
+				*res = vc
+			case BinaryID:
+				r, err := vc.MarshalBinary()
+				if err != nil {
+					return to, err
+				}
+				*res = r
+			case StringID, DefaultID:
+				*res = vc.String()
+			case IntID:
+				i, err := vc.Int64()
+				if err != nil {
+					return to, err
+				}
+				*res = i
+			case FloatID:
+				*res = vc.Float64()
+			case BoolID:
+				*res = vc.Sign() != 0
+			default:
+				return to, cantConvert(fromID, toID)
+			}
+		}
 	default:
 		return to, cantConvert(fromID, toID)
 	}
@@ -435,6 +487,20 @@ func Marshal(from Val, to *Val) error {
 		default:
 			return cantConvert(fromID, toID)
 		}
+	case DecimalID:
+		vc := val.(Decimal)
+		switch toID {
+		case StringID, DefaultID:
+			*res = vc.String()
+		case BinaryID:
+			r, err := vc.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			*res = r
+		default:
+			return cantConvert(fromID, toID)
+		}
 	default:
 		return cantConvert(fromID, toID)
 	}
@@ -503,6 +569,13 @@ func ObjectValue(id TypeID, value interface{}) (*api.Value, error) {
 			return def, errors.Errorf("Expected value of type password. Got : %v", value)
 		}
 		return &api.Value{Val: &api.Value_PasswordVal{PasswordVal: v}}, nil
+	case DecimalID:
+		// api.Value has no decimal, so it is sent as text and converted back as per the schema.
+		var v Decimal
+		if v, ok = value.(Decimal); !ok {
+			return def, errors.Errorf("Expected value of type decimal. Got : %v", value)
+		}
+		return &api.Value{Val: &api.Value_DefaultVal{DefaultVal: v.String()}}, nil
 	default:
 		return def, errors.Errorf("ObjectValue not available for: %v", id)
 	}
@@ -516,6 +589,21 @@ func toBinary(id TypeID, b interface{}) ([]byte, error) {
 	return p1.Value.([]byte), nil
 }
 
+// DecodeBinary decodes a binary value given as text, as in JSON or in RDF with
+// xs:base64Binary, which is base64 encoded. Binary values are stored decoded.
+func DecodeBinary(s string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid base64 for binary value")
+	}
+	return b, nil
+}
+
+// EncodeBinary returns the base64 text of a binary value, for the outputs which are text.
+func EncodeBinary(b []byte) string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
 func cantConvert(from TypeID, to TypeID) error {
 	return errors.Errorf("Cannot convert %s to type %s", from.Name(), to.Name())
 }
@@ -537,6 +625,12 @@ func (v Val) MarshalJSON() ([]byte, error) {
 		return json.Marshal(v.Safe().(string))
 	case PasswordID:
 		return json.Marshal(v.Value.(string))
+	case BinaryID:
+		// Encoded as base64.
+		return json.Marshal(v.Value.([]byte))
+	case DecimalID:
+		// Written as a JSON number with all its digits, so that it isn't rounded to a float.
+		return []byte(v.Value.(Decimal).String()), nil
 	}
 	return nil, errors.Errorf("Invalid type for MarshalJSON: %v", v.Tid)
 }
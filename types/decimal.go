@@ -0,0 +1,328 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxDecimalScale is the maximum number of digits allowed after the decimal point.
+	MaxDecimalScale = 38
+	// MaxDecimalPrecision is the maximum number of significant digits of a parsed decimal.
+	MaxDecimalPrecision = 76
+)
+
+var (
+	decimalRegex = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+	bigTen       = big.NewInt(10)
+)
+
+// Decimal is a fixed-point number. Its value is Unscaled * 10^-Scale, so 19.99 is stored as
+// Unscaled 1999 with Scale 2. Decimals are stored and compared exactly.
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    uint32
+}
+
+func pow10(n uint32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// ParseDecimal parses a decimal number like 19.99, -0.5 or 1.5e3. The scale of the result is
+// the number of digits after the decimal point in the input. It returns an error if the result
+// has more than MaxDecimalScale digits after the decimal point or MaxDecimalPrecision digits.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if !decimalRegex.MatchString(s) {
+		return Decimal{}, errors.Errorf("Invalid decimal value: %q", s)
+	}
+
+	var exp int64
+	if idx := strings.IndexAny(s, "eE"); idx >= 0 {
+		var err error
+		if exp, err = strconv.ParseInt(s[idx+1:], 10, 32); err != nil {
+			return Decimal{}, errors.Errorf("Invalid exponent in decimal value: %q", s)
+		}
+		s = s[:idx]
+	}
+	digits := s
+	var frac string
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		digits, frac = s[:idx], s[idx+1:]
+	}
+
+	scale := int64(len(frac)) - exp
+	if scale > MaxDecimalScale {
+		return Decimal{}, errors.Errorf("Decimal value %q has more than %d digits after the "+
+			"decimal point", s, MaxDecimalScale)
+	}
+	// Check the number of digits of the result before scaling it, so that a large exponent
+	// can't make us build a huge number. Zero has no significant digits at any exponent.
+	precision := int64(len(strings.TrimLeft(digits+frac, "0")))
+	if precision > 0 && scale < 0 {
+		precision -= scale
+	}
+	if precision > MaxDecimalPrecision {
+		return Decimal{}, errors.Errorf("Decimal value %q has more than %d digits", s,
+			MaxDecimalPrecision)
+	}
+
+	unscaled, ok := new(big.Int).SetString(digits+frac, 10)
+	if !ok {
+		return Decimal{}, errors.Errorf("Invalid decimal value: %q", s)
+	}
+	if scale < 0 {
+		if unscaled.Sign() != 0 {
+			unscaled.Mul(unscaled, pow10(uint32(-scale)))
+		}
+		scale = 0
+	}
+	return Decimal{Unscaled: unscaled, Scale: uint32(scale)}, nil
+}
+
+// DecimalFromInt returns the decimal for the given integer.
+func DecimalFromInt(i int64) Decimal {
+	return Decimal{Unscaled: big.NewInt(i)}
+}
+
+// DecimalFromFloat returns the decimal with the shortest representation which converts back to
+// the given float.
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, errors.Errorf("Can't convert %v to decimal", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.Unscaled == nil {
+		return new(big.Int)
+	}
+	return d.Unscaled
+}
+
+// String returns the decimal with exactly Scale digits after the decimal point.
+func (d Decimal) String() string {
+	u := d.unscaled()
+	s := new(big.Int).Abs(u).String()
+	if d.Scale > 0 {
+		if pad := int(d.Scale) + 1 - len(s); pad > 0 {
+			s = strings.Repeat("0", pad) + s
+		}
+		s = s[:len(s)-int(d.Scale)] + "." + s[len(s)-int(d.Scale):]
+	}
+	if u.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Rescale returns the decimal with the given scale. It returns an error if the decimal has more
+// digits after the decimal point than the scale allows, as those digits would be lost.
+func (d Decimal) Rescale(scale uint32) (Decimal, error) {
+	if scale > MaxDecimalScale {
+		return Decimal{}, errors.Errorf("Decimal scale %d is more than %d", scale, MaxDecimalScale)
+	}
+	u := d.unscaled()
+	switch {
+	case scale == d.Scale:
+		return Decimal{Unscaled: new(big.Int).Set(u), Scale: scale}, nil
+	case scale > d.Scale:
+		return Decimal{Unscaled: new(big.Int).Mul(u, pow10(scale-d.Scale)), Scale: scale}, nil
+	}
+	q, r := new(big.Int).QuoRem(u, pow10(d.Scale-scale), new(big.Int))
+	if r.Sign() != 0 {
+		return Decimal{}, errors.Errorf("Decimal value %s has more than %d digits after the "+
+			"decimal point", d, scale)
+	}
+	return Decimal{Unscaled: q, Scale: scale}, nil
+}
+
+// Round returns the decimal rounded to the given scale. Halfway values are rounded to the even
+// neighbour, so that the rounding errors don't add up over many values.
+func (d Decimal) Round(scale uint32) Decimal {
+	u := d.unscaled()
+	if scale >= d.Scale {
+		return Decimal{Unscaled: new(big.Int).Mul(u, pow10(scale-d.Scale)), Scale: scale}
+	}
+	div := pow10(d.Scale - scale)
+	q, r := new(big.Int).QuoRem(u, div, new(big.Int))
+	// Compare twice the remainder with the divisor to find if it is past the halfway point.
+	cmp := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(div)
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if u.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{Unscaled: q, Scale: scale}
+}
+
+// align returns the unscaled values of a and b at the larger of their scales.
+func align(a, b Decimal) (*big.Int, *big.Int, uint32) {
+	au, bu := a.unscaled(), b.unscaled()
+	switch {
+	case a.Scale > b.Scale:
+		return au, new(big.Int).Mul(bu, pow10(a.Scale-b.Scale)), a.Scale
+	case b.Scale > a.Scale:
+		return new(big.Int).Mul(au, pow10(b.Scale-a.Scale)), bu, b.Scale
+	}
+	return au, bu, a.Scale
+}
+
+// Cmp compares a and b and returns -1, 0 or +1.
+func (d Decimal) Cmp(b Decimal) int {
+	au, bu, _ := align(d, b)
+	return au.Cmp(bu)
+}
+
+// Add returns d + b.
+func (d Decimal) Add(b Decimal) Decimal {
+	au, bu, scale := align(d, b)
+	return Decimal{Unscaled: new(big.Int).Add(au, bu), Scale: scale}
+}
+
+// Sub returns d - b.
+func (d Decimal) Sub(b Decimal) Decimal {
+	au, bu, scale := align(d, b)
+	return Decimal{Unscaled: new(big.Int).Sub(au, bu), Scale: scale}
+}
+
+// Mul returns d * b. The scale of the result is the sum of the scales, so that no digits are
+// lost. It is capped at MaxDecimalScale.
+func (d Decimal) Mul(b Decimal) Decimal {
+	res := Decimal{Unscaled: new(big.Int).Mul(d.unscaled(), b.unscaled()), Scale: d.Scale + b.Scale}
+	if res.Scale > MaxDecimalScale {
+		res = res.Round(MaxDecimalScale)
+	}
+	return res
+}
+
+// Quo returns d / b rounded to the given scale. It returns an error if b is zero.
+func (d Decimal) Quo(b Decimal, scale uint32) (Decimal, error) {
+	if b.unscaled().Sign() == 0 {
+		return Decimal{}, errors.New("Division by zero")
+	}
+	// d / b = (du * 10^bs) / (bu * 10^ds), computed at the given scale.
+	num := new(big.Int).Mul(d.unscaled(), pow10(b.Scale+scale))
+	den := new(big.Int).Mul(b.unscaled(), pow10(d.Scale))
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	// Round half to even, as in Round.
+	cmp := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(new(big.Int).Abs(den))
+	if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{Unscaled: q, Scale: scale}, nil
+}
+
+// Rem returns the remainder of d / b, with the sign of d like the % operator. It returns an
+// error if b is zero.
+func (d Decimal) Rem(b Decimal) (Decimal, error) {
+	if b.unscaled().Sign() == 0 {
+		return Decimal{}, errors.New("Division by zero")
+	}
+	au, bu, scale := align(d, b)
+	return Decimal{Unscaled: new(big.Int).Rem(au, bu), Scale: scale}, nil
+}
+
+// Floor returns the largest integer which isn't more than d.
+func (d Decimal) Floor() Decimal {
+	q, r := new(big.Int).QuoRem(d.unscaled(), pow10(d.Scale), new(big.Int))
+	if r.Sign() < 0 {
+		q.Sub(q, big.NewInt(1))
+	}
+	return Decimal{Unscaled: q}
+}
+
+// Ceil returns the smallest integer which isn't less than d.
+func (d Decimal) Ceil() Decimal {
+	q, r := new(big.Int).QuoRem(d.unscaled(), pow10(d.Scale), new(big.Int))
+	if r.Sign() > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return Decimal{Unscaled: q}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{Unscaled: new(big.Int).Neg(d.unscaled()), Scale: d.Scale}
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+// Trunc returns the integer part of d, dropping the digits after the decimal point.
+func (d Decimal) Trunc() *big.Int {
+	return new(big.Int).Quo(d.unscaled(), pow10(d.Scale))
+}
+
+// Float64 returns the float closest to d.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.unscaled(), pow10(d.Scale)).Float64()
+	return f
+}
+
+// Int64 returns the integer part of d. It returns an error if it doesn't fit in an int64.
+func (d Decimal) Int64() (int64, error) {
+	t := d.Trunc()
+	if !t.IsInt64() {
+		return 0, errors.Errorf("Decimal %s out of int64 range", d)
+	}
+	return t.Int64(), nil
+}
+
+// MarshalBinary encodes the decimal as its scale followed by the sign and the big-endian bytes
+// of the absolute unscaled value.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	u := d.unscaled()
+	abs := new(big.Int).Abs(u).Bytes()
+	b := make([]byte, 0, 2+len(abs))
+	b = append(b, byte(d.Scale))
+	if u.Sign() < 0 {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	return append(b, abs...), nil
+}
+
+// UnmarshalBinary decodes the decimal encoded by MarshalBinary.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] > MaxDecimalScale || data[1] > 1 {
+		return errors.Errorf("Invalid data for decimal %v", data)
+	}
+	u := new(big.Int).SetBytes(data[2:])
+	if data[1] == 1 {
+		u.Neg(u)
+	}
+	d.Unscaled, d.Scale = u, uint32(data[0])
+	return nil
+}
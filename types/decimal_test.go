@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecimal(t *testing.T, s string) Decimal {
+	d, err := ParseDecimal(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"19.99", "19.99"},
+		{"-0.5", "-0.5"},
+		{".25", "0.25"},
+		{"+7", "7"},
+		{"5.", "5"},
+		{"1.5e3", "1500"},
+		{"1.25E-1", "0.125"},
+		{"0.000", "0.000"},
+		{"12345678901234567890.123456789", "12345678901234567890.123456789"},
+		{"1e75", "1" + strings.Repeat("0", 75)},
+		{"0e2000000000", "0"},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.out, mustDecimal(t, tc.in).String(), tc.in)
+	}
+
+	for _, in := range []string{"", "abc", "1/3", "1.2.3", "--1", "1e", "NaN"} {
+		_, err := ParseDecimal(in)
+		require.Error(t, err, in)
+	}
+
+	// Values with too many digits are rejected before they are built.
+	for _, in := range []string{"1e76", "1e2000000000", "-1e2000000000", "1e-2000000000",
+		"-1.5e-2000000000", strings.Repeat("9", 77)} {
+		_, err := ParseDecimal(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	d, err := mustDecimal(t, "19.9").Rescale(2)
+	require.NoError(t, err)
+	require.Equal(t, "19.90", d.String())
+
+	d, err = mustDecimal(t, "19.900").Rescale(2)
+	require.NoError(t, err)
+	require.Equal(t, "19.90", d.String())
+
+	_, err = mustDecimal(t, "19.999").Rescale(2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "more than 2 digits after the decimal point")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := mustDecimal(t, "0.1"), mustDecimal(t, "0.2")
+	require.Equal(t, "0.3", a.Add(b).String())
+	require.Equal(t, 0, a.Add(b).Cmp(mustDecimal(t, "0.30")))
+	require.Equal(t, "-0.1", a.Sub(b).String())
+	require.Equal(t, "0.02", a.Mul(b).String())
+
+	q, err := mustDecimal(t, "10.00").Quo(mustDecimal(t, "3"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "3.33", q.String())
+	// Halfway values are rounded to even.
+	q, err = mustDecimal(t, "0.125").Quo(mustDecimal(t, "1"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "0.12", q.String())
+	q, err = mustDecimal(t, "-0.135").Quo(mustDecimal(t, "1"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "-0.14", q.String())
+	_, err = a.Quo(Decimal{}, 2)
+	require.Error(t, err)
+
+	r, err := mustDecimal(t, "-7.5").Rem(mustDecimal(t, "2"))
+	require.NoError(t, err)
+	require.Equal(t, "-1.5", r.String())
+
+	require.Equal(t, "-2", mustDecimal(t, "-1.5").Floor().String())
+	require.Equal(t, "-1", mustDecimal(t, "-1.5").Ceil().String())
+	require.Equal(t, "2", mustDecimal(t, "1.01").Ceil().String())
+}
+
+func TestDecimalConversion(t *testing.T) {
+	d := mustDecimal(t, "-19.99")
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+
+	v, err := Convert(Val{Tid: DecimalID, Value: b}, DecimalID)
+	require.NoError(t, err)
+	require.Equal(t, "-19.99", v.Value.(Decimal).String())
+
+	v, err = Convert(Val{Tid: DecimalID, Value: b}, StringID)
+	require.NoError(t, err)
+	require.Equal(t, "-19.99", v.Value)
+
+	v, err = Convert(Val{Tid: StringID, Value: []byte("19.99")}, DecimalID)
+	require.NoError(t, err)
+	require.Equal(t, "19.99", v.Value.(Decimal).String())
+
+	// Floats are converted using their shortest representation.
+	fb := Val{Tid: BinaryID}
+	require.NoError(t, Marshal(Val{Tid: FloatID, Value: 0.1}, &fb))
+	v, err = Convert(Val{Tid: FloatID, Value: fb.Value}, DecimalID)
+	require.NoError(t, err)
+	require.Equal(t, "0.1", v.Value.(Decimal).String())
+
+	js, err := Val{Tid: DecimalID, Value: mustDecimal(t, "12345678901234567.89")}.MarshalJSON()
+	require.NoError(t, err)
+	require.Equal(t, "12345678901234567.89", string(js))
+}
+
+func TestSortDecimals(t *testing.T) {
+	list := getInput(t, DecimalID, []string{"19.99", "-1", "19.9", "100", "0.001"})
+	ul := getUIDList(5)
+	require.NoError(t, Sort(list, &ul.Uids, []bool{false}, ""))
+	require.EqualValues(t, []uint64{200, 500, 300, 100, 400}, ul.Uids)
+	require.EqualValues(t, []string{"-1", "0.001", "19.9", "19.99", "100"},
+		toString(t, list, DecimalID))
+}
@@ -29,19 +29,47 @@ import (
 	"github.com/pkg/errors"
 )
 
-// SortAndValidate sorts And validates the facets.
+// listToken is the first token of the values of a list facet. api.Facet has no field to tell a
+// list of a single value from a scalar facet, and the term tokens don't start with 0x00, so it
+// doesn't match any term of the anyofterms and allofterms functions.
+const listToken = "\x00list"
+
+// MarkList marks the facets as the values of a list facet.
+func MarkList(fs []*api.Facet) {
+	for _, f := range fs {
+		if !IsList(f) {
+			f.Tokens = append([]string{listToken}, f.Tokens...)
+		}
+	}
+}
+
+// IsList returns true if the facet is a value of a list facet.
+func IsList(f *api.Facet) bool {
+	return len(f.Tokens) > 0 && f.Tokens[0] == listToken
+}
+
+// SortAndValidate sorts And validates the facets. A list facet is stored as consecutive facets
+// with the same key, one for each value in the list. The sort is stable, so that the values of a
+// list keep their order.
 func SortAndValidate(fs []*api.Facet) error {
 	if len(fs) == 0 {
 		return nil
 	}
-	sort.Slice(fs, func(i, j int) bool {
+	sort.SliceStable(fs, func(i, j int) bool {
 		return fs[i].Key < fs[j].Key
 	})
 	for i := 1; i < len(fs); i++ {
-		if fs[i-1].Key == fs[i].Key {
+		if fs[i-1].Key != fs[i].Key {
+			continue
+		}
+		if !IsList(fs[i-1]) || !IsList(fs[i]) {
 			return errors.Errorf("Repeated keys are not allowed in facets. But got %s",
 				fs[i].Key)
 		}
+		if fs[i-1].ValType != fs[i].ValType {
+			return errors.Errorf("All the values of list facet %s must be of the same type",
+				fs[i].Key)
+		}
 	}
 	return nil
 }
@@ -67,11 +95,17 @@ func CopyFacets(fcs []*api.Facet, param *pb.FacetParams) (fs []*api.Facet) {
 			if !param.AllKeys {
 				fcopy.Alias = param.Param[kidx].Alias
 			}
+			if IsList(f) {
+				fcopy.Tokens = []string{listToken}
+			}
 			fcopy.Value = make([]byte, len(f.Value))
 			copy(fcopy.Value, f.Value)
 			fs = append(fs, fcopy)
-			kidx++
 			fidx++
+			// Copy all the values of a list facet before moving on to the next key.
+			if fidx == numFacets || fcs[fidx].Key != f.Key {
+				kidx++
+			}
 		case f.Key > param.Param[kidx].Key:
 			kidx++
 		default:
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package facets
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestSortAndValidateList(t *testing.T) {
+	facet := func(key, val string) *api.Facet {
+		f, err := FacetFor(key, val)
+		require.NoError(t, err)
+		return f
+	}
+
+	tags := []*api.Facet{facet("tags", `"work"`), facet("tags", `"college"`)}
+	MarkList(tags)
+	fs := append([]*api.Facet{facet("since", "12")}, tags...)
+	require.NoError(t, SortAndValidate(fs))
+	require.Equal(t, "since", fs[0].Key)
+	require.Equal(t, "work", string(fs[1].Value))
+	require.Equal(t, "college", string(fs[2].Value))
+	require.False(t, IsList(fs[0]))
+	require.True(t, IsList(fs[1]))
+
+	// Marking the facets twice doesn't add the list token again.
+	MarkList(tags)
+	require.Equal(t, []string{listToken, "\001work"}, tags[0].Tokens)
+
+	// The same key can't be given twice, unless both are values of a list.
+	err := SortAndValidate([]*api.Facet{facet("tags", `"work"`), facet("tags", `"college"`)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Repeated keys are not allowed in facets. But got tags")
+	err = SortAndValidate([]*api.Facet{tags[0], facet("tags", `"home"`)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Repeated keys are not allowed in facets. But got tags")
+
+	ints := []*api.Facet{facet("tags", "1")}
+	MarkList(ints)
+	err = SortAndValidate([]*api.Facet{tags[0], ints[0]})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "All the values of list facet tags must be of the same type")
+}
+
+func TestCopyFacetsList(t *testing.T) {
+	tags, err := FacetFor("tags", `"work"`)
+	require.NoError(t, err)
+	MarkList([]*api.Facet{tags})
+	since, err := FacetFor("since", "12")
+	require.NoError(t, err)
+
+	// A list of a single value is still a list in the copy.
+	fs := CopyFacets([]*api.Facet{since, tags}, &pb.FacetParams{AllKeys: true})
+	require.Len(t, fs, 2)
+	require.False(t, IsList(fs[0]))
+	require.True(t, IsList(fs[1]))
+}
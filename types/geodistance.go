@@ -0,0 +1,144 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
+)
+
+// geoShape holds a geometry as s2 points and loops. As for the geo index, only the outer loop
+// of a polygon is used, so the holes of a polygon are considered part of it.
+type geoShape struct {
+	points []s2.Point
+	loops  []*s2.Loop
+}
+
+func shapeFromGeom(g geom.T) (*geoShape, error) {
+	var s geoShape
+	switch v := g.(type) {
+	case *geom.Point:
+		s.points = append(s.points, pointFromPoint(v))
+	case *geom.Polygon:
+		l, err := loopFromPolygon(v)
+		if err != nil {
+			return nil, err
+		}
+		s.loops = append(s.loops, l)
+	case *geom.MultiPolygon:
+		for i := 0; i < v.NumPolygons(); i++ {
+			l, err := loopFromPolygon(v.Polygon(i))
+			if err != nil {
+				return nil, err
+			}
+			s.loops = append(s.loops, l)
+		}
+	default:
+		return nil, errors.Errorf("Cannot compute the distance to a geometry of type %T", v)
+	}
+	return &s, nil
+}
+
+// ParseGeoReference parses the geometry given as an argument of a geo function, either as
+// coordinates, e.g. [-122.4, 37.7], or as geojson.
+func ParseGeoReference(str string) (geom.T, error) {
+	return convertToGeom(str)
+}
+
+// GeoDistance returns the geodesic distance in meters between two geometries, which is the
+// distance between their nearest points. The distance is zero if the geometries intersect, e.g.
+// for a point inside a polygon.
+func GeoDistance(a, b geom.T) (float64, error) {
+	sa, err := shapeFromGeom(a)
+	if err != nil {
+		return 0, err
+	}
+	sb, err := shapeFromGeom(b)
+	if err != nil {
+		return 0, err
+	}
+	if sa.overlaps(sb) || sb.overlaps(sa) {
+		return 0, nil
+	}
+
+	dist := infAngle
+	for _, p := range sa.points {
+		for _, q := range sb.points {
+			dist = minAngle(dist, p.Distance(q))
+		}
+	}
+	for _, pair := range [][2]*geoShape{{sa, sb}, {sb, sa}} {
+		from, to := pair[0], pair[1]
+		for _, l := range to.loops {
+			for _, p := range from.points {
+				dist = minAngle(dist, distanceToLoop(p, l))
+			}
+			for _, fl := range from.loops {
+				for _, p := range fl.Vertices() {
+					dist = minAngle(dist, distanceToLoop(p, l))
+				}
+			}
+		}
+	}
+	if dist == infAngle {
+		return math.Inf(1), nil
+	}
+	return float64(EarthDistance(dist)), nil
+}
+
+// overlaps returns true if a loop of s contains a point of o, or if the edges of a loop of s
+// cross the ones of a loop of o.
+func (s *geoShape) overlaps(o *geoShape) bool {
+	for _, l := range s.loops {
+		for _, p := range o.points {
+			if l.ContainsPoint(p) {
+				return true
+			}
+		}
+		for _, ol := range o.loops {
+			if l.ContainsPoint(ol.Vertex(0)) || edgesCrossPoints(l, ol.Vertices()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// distanceToLoop returns the distance between a point outside of a loop and the boundary of
+// the loop.
+func distanceToLoop(p s2.Point, l *s2.Loop) s1.Angle {
+	dist := infAngle
+	n := l.NumVertices()
+	for i := 0; i < n; i++ {
+		dist = minAngle(dist, s2.DistanceFromSegment(p, l.Vertex(i), l.Vertex((i+1)%n)))
+	}
+	return dist
+}
+
+// infAngle is larger than any distance.
+var infAngle = s1.Angle(math.Inf(1))
+
+func minAngle(a, b s1.Angle) s1.Angle {
+	if b < a {
+		return b
+	}
+	return a
+}
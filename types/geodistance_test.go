@@ -0,0 +1,71 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twpayne/go-geom"
+)
+
+func TestGeoDistance(t *testing.T) {
+	// The length of one degree of a great circle.
+	const degree = 111194.93
+
+	square := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+	})
+	below := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0, -3}, {1, -3}, {1, -2}, {0, -2}, {0, -3}},
+	})
+	overlapping := geom.NewPolygon(geom.XY).MustSetCoords([][]geom.Coord{
+		{{0.5, 0.5}, {2, 0.5}, {2, 2}, {0.5, 2}, {0.5, 0.5}},
+	})
+	point := func(lng, lat float64) *geom.Point {
+		return geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{lng, lat})
+	}
+
+	tests := []struct {
+		name string
+		a, b geom.T
+		dist float64
+	}{
+		{"same point", point(0, 0), point(0, 0), 0},
+		{"points", point(0, 0), point(1, 0), degree},
+		{"point inside polygon", point(0.5, 0.5), square, 0},
+		{"point outside polygon", point(0.5, -1), square, degree},
+		{"polygon and point", square, point(0.5, -1), degree},
+		{"polygons", square, below, 2 * degree},
+		{"overlapping polygons", square, overlapping, 0},
+		{"multipolygon", point(3, 0), geom.NewMultiPolygon(geom.XY).MustSetCoords(
+			[][][]geom.Coord{square.Coords(), below.Coords()}), 2 * degree},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dist, err := GeoDistance(tc.a, tc.b)
+			require.NoError(t, err)
+			require.InDelta(t, tc.dist, dist, 0.01)
+		})
+	}
+}
+
+func TestGeoDistanceUnsupportedType(t *testing.T) {
+	line := geom.NewLineString(geom.XY).MustSetCoords([]geom.Coord{{0, 0}, {1, 1}})
+	_, err := GeoDistance(line, geom.NewPoint(geom.XY).MustSetCoords(geom.Coord{0, 0}))
+	require.Error(t, err)
+}
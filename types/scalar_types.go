@@ -55,6 +55,8 @@ const (
 	PasswordID = TypeID(pb.Posting_PASSWORD)
 	// StringID represents the string type.
 	StringID = TypeID(pb.Posting_STRING)
+	// DecimalID represents the fixed-point decimal number type.
+	DecimalID = TypeID(pb.Posting_DECIMAL)
 	// UndefinedID represents the undefined type.
 	UndefinedID = TypeID(100)
 )
@@ -62,6 +64,7 @@ const (
 var typeNameMap = map[string]TypeID{
 	"default":  DefaultID,
 	"binary":   BinaryID,
+	"bytes":    BinaryID, // alias of binary
 	"int":      IntID,
 	"float":    FloatID,
 	"bool":     BoolID,
@@ -70,6 +73,7 @@ var typeNameMap = map[string]TypeID{
 	"uid":      UidID,
 	"string":   StringID,
 	"password": PasswordID,
+	"decimal":  DecimalID,
 }
 
 // TypeID represents the type of the data.
@@ -103,6 +107,8 @@ func (t TypeID) Name() string {
 		return "string"
 	case PasswordID:
 		return "password"
+	case DecimalID:
+		return "decimal"
 	}
 	return ""
 }
@@ -141,7 +147,7 @@ func (t TypeID) IsScalar() bool {
 
 // IsNumber returns whether the type is a number type.
 func (t TypeID) IsNumber() bool {
-	return t == IntID || t == FloatID
+	return t == IntID || t == FloatID || t == DecimalID
 }
 
 // ValueForType returns the zero value for a type id
@@ -187,6 +193,10 @@ func ValueForType(id TypeID) Val {
 		var p string
 		return Val{PasswordID, p}
 
+	case DecimalID:
+		var d Decimal
+		return Val{DecimalID, &d}
+
 	default:
 		return Val{}
 	}
@@ -60,9 +60,9 @@ func (s byValue) Less(i, j int) bool {
 	}
 	for vidx := range first {
 		// Null values are appended at the end of the sort result for both ascending and descending.
-		// If both first and second has nil values, then maintain the order by UID.
+		// If both first and second has nil values, then look at the next value to decide.
 		if first[vidx].Value == nil && second[vidx].Value == nil {
-			return s.desc[vidx]
+			continue
 		}
 
 		if first[vidx].Value == nil {
@@ -85,13 +85,15 @@ func (s byValue) Less(i, j int) bool {
 		}
 		return less
 	}
-	return false
+	// All the values are equal, maintain the order by UID. This keeps the sort order
+	// deterministic, which is needed to paginate consistently over equal values.
+	return (*s.ul)[i] < (*s.ul)[j]
 }
 
 // IsSortable returns true, if tid is sortable. Otherwise it returns false.
 func IsSortable(tid TypeID) bool {
 	switch tid {
-	case DateTimeID, IntID, FloatID, StringID, DefaultID:
+	case DateTimeID, IntID, FloatID, StringID, DefaultID, DecimalID:
 		return true
 	default:
 		return false
@@ -101,6 +103,18 @@ func IsSortable(tid TypeID) bool {
 // SortWithFacet sorts the given array in-place and considers the given facets to calculate
 // the proper ordering.
 func SortWithFacet(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang string) error {
+	return sortValues(v, ul, l, desc, lang, false)
+}
+
+// SortStable sorts the given array in-place like Sort, keeping the order of the uids which have
+// equal values.
+func SortStable(v [][]Val, ul *[]uint64, desc []bool, lang string) error {
+	return sortValues(v, ul, nil, desc, lang, true)
+}
+
+func sortValues(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang string,
+	stable bool) error {
+
 	if len(v) == 0 || len(v[0]) == 0 {
 		return nil
 	}
@@ -122,7 +136,11 @@ func SortWithFacet(v [][]Val, ul *[]uint64, l []*pb.Facets, desc []bool, lang st
 
 	b := sortBase{v, desc, ul, l, cl}
 	toBeSorted := byValue{b}
-	sort.Sort(toBeSorted)
+	if stable {
+		sort.Stable(toBeSorted)
+	} else {
+		sort.Sort(toBeSorted)
+	}
 	return nil
 }
 
@@ -138,7 +156,7 @@ func Less(a, b Val) (bool, error) {
 	}
 	typ := a.Tid
 	switch typ {
-	case DateTimeID, UidID, IntID, FloatID, StringID, DefaultID:
+	case DateTimeID, UidID, IntID, FloatID, StringID, DefaultID, DecimalID:
 		// Don't do anything, we can sort values of this type.
 	default:
 		return false, errors.Errorf("Compare not supported for type: %v", a.Tid)
@@ -157,6 +175,8 @@ func less(a, b Val, cl *collate.Collator) bool {
 		return (a.Value.(int64)) < (b.Value.(int64))
 	case FloatID:
 		return (a.Value.(float64)) < (b.Value.(float64))
+	case DecimalID:
+		return a.Value.(Decimal).Cmp(b.Value.(Decimal)) < 0
 	case UidID:
 		return (a.Value.(uint64) < b.Value.(uint64))
 	case StringID, DefaultID:
@@ -194,7 +214,7 @@ func Equal(a, b Val) (bool, error) {
 	}
 	typ := a.Tid
 	switch typ {
-	case DateTimeID, IntID, FloatID, StringID, DefaultID, BoolID:
+	case DateTimeID, IntID, FloatID, StringID, DefaultID, BoolID, DecimalID:
 		// Don't do anything, we can sort values of this type.
 	default:
 		return false, errors.Errorf("Equal not supported for type: %v", a.Tid)
@@ -227,6 +247,10 @@ func equal(a, b Val) bool {
 		aVal, aOk := a.Value.(bool)
 		bVal, bOk := b.Value.(bool)
 		return aOk && bOk && aVal == bVal
+	case DecimalID:
+		aVal, aOk := a.Value.(Decimal)
+		bVal, bOk := b.Value.(Decimal)
+		return aOk && bOk && aVal.Cmp(bVal) == 0
 	}
 	return false
 }
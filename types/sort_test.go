@@ -204,3 +204,37 @@ func TestSortMismatchedTypes(t *testing.T) {
 	require.True(t, idx21 < idx33)
 	require.True(t, idx33 < idx55)
 }
+
+func TestSortMultipleKeys(t *testing.T) {
+	str := func(s string) Val { return Val{Tid: StringID, Value: s} }
+	num := func(i int64) Val { return Val{Tid: IntID, Value: i} }
+	null := Val{Tid: IntID}
+
+	getList := func() [][]Val {
+		return [][]Val{
+			{str("b"), num(1)},
+			{str("a"), num(2)},
+			{str("a"), null},
+			{str("b"), num(1)},
+			{{Tid: StringID}, num(5)},
+			{str("a"), num(1)},
+			{{Tid: StringID}, num(3)},
+		}
+	}
+
+	// Null values are sorted at the end and ties are broken by the UID.
+	list := getList()
+	ul := getUIDList(7)
+	require.NoError(t, Sort(list, &ul.Uids, []bool{false, false}, ""))
+	require.Equal(t, []uint64{600, 200, 300, 100, 400, 700, 500}, ul.Uids)
+
+	list = getList()
+	ul = getUIDList(7)
+	require.NoError(t, Sort(list, &ul.Uids, []bool{false, true}, ""))
+	require.Equal(t, []uint64{200, 600, 300, 100, 400, 500, 700}, ul.Uids)
+
+	list = getList()
+	ul = getUIDList(7)
+	require.NoError(t, Sort(list, &ul.Uids, []bool{true, true}, ""))
+	require.Equal(t, []uint64{100, 400, 200, 600, 300, 500, 700}, ul.Uids)
+}
@@ -26,12 +26,14 @@ func couldApplyAggregatorOn(agrtr string, typ types.TypeID) bool {
 	case "min", "max":
 		return (typ == types.IntID ||
 			typ == types.FloatID ||
+			typ == types.DecimalID ||
 			typ == types.DateTimeID ||
 			typ == types.StringID ||
 			typ == types.DefaultID)
 	case "sum", "avg":
 		return (typ == types.IntID ||
-			typ == types.FloatID)
+			typ == types.FloatID ||
+			typ == types.DecimalID)
 	default:
 		return false
 	}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Aliases are declared in the schema as newName: string @alias(oldName) . and are resolved to
+// the predicate by the alpha receiving a query or a mutation, before it's authorized and
+// processed, so that the data and the indexes are only kept under the predicate. The alias and
+// the predicate can be served by different groups, so the aliases of all the predicates are
+// kept by every alpha, see schema.State().Aliases().
+
+var aliasesLoaded struct {
+	sync.Mutex
+	done bool
+}
+
+// LoadAliases fetches the aliases of the predicates served by the other groups. It's only done
+// once, as the changes made later are sent to all the groups along with the schema updates.
+func LoadAliases(ctx context.Context) error {
+	aliasesLoaded.Lock()
+	defer aliasesLoaded.Unlock()
+	if aliasesLoaded.done {
+		return nil
+	}
+
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Fields: []string{"aliases"}})
+	if err != nil {
+		return errors.Wrapf(err, "while loading the aliases of the predicates")
+	}
+	known := aliasedPredicates(schema.State().Aliases())
+	for _, node := range nodes {
+		if len(node.Aliases) == 0 {
+			continue
+		}
+		if _, ok := known[node.Predicate]; !ok {
+			schema.State().SetAliases(node.Predicate, node.Aliases)
+		}
+	}
+	aliasesLoaded.done = true
+	return nil
+}
+
+// checkAliases verifies the aliases of a schema update against the predicates and the aliases
+// of the cluster, and sets the aliases which need to be sent to all the groups. The aliases of
+// the predicates dropped by the mutation are removed as well.
+func checkAliases(ctx context.Context, m *pb.Mutations) error {
+	var dropped []string
+	for _, edge := range m.Edges {
+		if edge.Entity == 0 && bytes.Equal(edge.Value, []byte(x.Star)) {
+			dropped = append(dropped, edge.Attr)
+		}
+	}
+	if len(m.Schema) == 0 && len(dropped) == 0 {
+		return nil
+	}
+	if err := LoadAliases(ctx); err != nil {
+		return err
+	}
+
+	aliases := schema.State().Aliases()
+	hasAliases := aliasedPredicates(aliases)
+	for _, attr := range dropped {
+		if _, ok := hasAliases[attr]; ok {
+			m.Aliases = append(m.Aliases, &pb.SchemaUpdate{Predicate: attr})
+		}
+	}
+
+	var added []string
+	for _, su := range m.Schema {
+		if pred, ok := aliases[su.Predicate]; ok {
+			return errors.Errorf("Predicate %s can't be defined as it's an alias of predicate %s",
+				x.ParseAttr(su.Predicate), x.ParseAttr(pred))
+		}
+		_, had := hasAliases[su.Predicate]
+		if !had && len(su.Aliases) == 0 {
+			continue
+		}
+		m.Aliases = append(m.Aliases,
+			&pb.SchemaUpdate{Predicate: su.Predicate, Aliases: su.Aliases})
+
+		ns := x.ParseNamespace(su.Predicate)
+		for _, alias := range su.Aliases {
+			alias = x.NamespaceAttr(ns, alias)
+			if pred, ok := aliases[alias]; ok && pred != su.Predicate {
+				return errors.Errorf("Alias %s is already used by predicate %s",
+					x.ParseAttr(alias), x.ParseAttr(pred))
+			}
+			if _, ok := aliases[alias]; !ok {
+				added = append(added, alias)
+			}
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	// The new aliases can't stand for a predicate which already exists, as its data would be
+	// hidden by the alias.
+	sort.Strings(added)
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Predicates: added,
+		Fields: []string{"type"}})
+	if err != nil {
+		return err
+	}
+	if len(nodes) > 0 {
+		return errors.Errorf("Alias %s is already a predicate, it needs to be dropped first",
+			x.ParseAttr(nodes[0].Predicate))
+	}
+	return nil
+}
+
+// aliasedPredicates returns the predicates which have aliases.
+func aliasedPredicates(aliases map[string]string) map[string]struct{} {
+	preds := make(map[string]struct{}, len(aliases))
+	for _, pred := range aliases {
+		preds[pred] = struct{}{}
+	}
+	return preds
+}
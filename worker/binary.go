@@ -0,0 +1,42 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// checkBinarySize returns an error if the edge sets a value of a binary predicate larger than
+// allowed by --limit "binary-value-kb". The type is the one of the predicate in the schema, or the
+// one of the value if the predicate has no schema yet, as it's then given the type of the value.
+// It's called once the value has been decoded from base64, before the mutation is proposed, so
+// that the replicas don't depend on the limit when they apply it.
+func checkBinarySize(edge *pb.DirectedEdge, typ types.TypeID) error {
+	limit := x.Config.LimitBinaryValue
+	if limit <= 0 || edge.Op != pb.DirectedEdge_SET || typ != types.BinaryID {
+		return nil
+	}
+	if size := int64(len(edge.Value)); size > limit {
+		return errors.Errorf("Value of binary predicate %q is %d bytes, larger than the limit of "+
+			"%d bytes set by binary-value-kb", x.ParseAttr(edge.Attr), size, limit)
+	}
+	return nil
+}
@@ -0,0 +1,635 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+
+	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/ee/enc"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// bundleVersion is the version of the bundle layout. It must be increased whenever the
+	// layout changes in a way that older releases can't import.
+	bundleVersion = 1
+	// bundleManifest is the name of the manifest file inside a bundle. It is always the first
+	// file in the archive.
+	bundleManifest = "manifest.json"
+	// bundleBatchSize is the number of N-Quads sent in each mutation while importing a bundle.
+	bundleBatchSize = 1000
+)
+
+var releaseRe = regexp.MustCompile(`^v(\d+)\.(\d+)`)
+
+// assignBundleUids leases the uids of the imported nodes, it's replaced in tests.
+var assignBundleUids = AssignUidsOverNetwork
+
+// BundleManifest describes the contents of a bundle created by an export with bundle set.
+type BundleManifest struct {
+	Version       int      `json:"version"`
+	DgraphVersion string   `json:"dgraph_version"`
+	ReadTs        uint64   `json:"read_ts"`
+	Format        string   `json:"format"`
+	Namespace     uint64   `json:"namespace"`
+	IncludesAcl   bool     `json:"includes_acl"`
+	Encrypted     bool     `json:"encrypted"`
+	Files         []string `json:"files"`
+}
+
+// bundleDir returns the local directory the export files were written to.
+func bundleDir(in *pb.ExportRequest) string {
+	if strings.HasPrefix(in.Destination, "/") {
+		return in.Destination
+	}
+	return x.WorkerConfig.ExportPath
+}
+
+// writeBundle packs the files written by the export of each group into a single tar archive,
+// along with a manifest. The original files are removed once the archive has been written.
+// It returns the path of the archive relative to the export directory.
+func writeBundle(in *pb.ExportRequest, readTs uint64, files ExportedFiles) (string, error) {
+	dir := bundleDir(in)
+	manifest := &BundleManifest{
+		Version:       bundleVersion,
+		DgraphVersion: x.Version(),
+		ReadTs:        readTs,
+		Format:        in.Format,
+		Namespace:     in.Namespace,
+		IncludesAcl:   in.IncludeAcl,
+		Encrypted:     x.WorkerConfig.EncryptionKey != nil,
+	}
+	for _, f := range files {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			return "", errors.Wrapf(err, "while reading exported file %s. All the Alphas must "+
+				"export to a shared directory to create a bundle", f)
+		}
+		manifest.Files = append(manifest.Files, filepath.Base(f))
+	}
+	mdata, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("dgraph.r%d.bundle.tar", readTs)
+	fd, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+
+	tw := tar.NewWriter(fd)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: bundleManifest,
+		Mode: 0600,
+		Size: int64(len(mdata)),
+	}); err != nil {
+		return "", err
+	}
+	if _, err := tw.Write(mdata); err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if err := addToTar(tw, filepath.Join(dir, f)); err != nil {
+			return "", errors.Wrapf(err, "while adding %s to bundle", f)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := fd.Sync(); err != nil {
+		return "", err
+	}
+
+	// The files now live in the bundle. Remove them along with the directories they were in.
+	for _, f := range files {
+		path := filepath.Join(dir, f)
+		if err := os.Remove(path); err != nil {
+			glog.Warningf("Unable to remove exported file %s: %v", path, err)
+		}
+		// Remove only fails for non-empty directories, which are kept.
+		_ = os.Remove(filepath.Dir(path))
+	}
+	glog.Infof("Export bundle written to %s", filepath.Join(dir, name))
+	return name, nil
+}
+
+func addToTar(tw *tar.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    filepath.Base(path),
+		Mode:    0600,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Bundle is an export bundle which has been extracted for importing.
+type Bundle struct {
+	Manifest BundleManifest
+	dir      string
+}
+
+// BundlePath returns the path of the bundle file to import, which must be in the export
+// directory. A relative file, like the name returned by the export, is relative to it.
+func BundlePath(file string) (string, error) {
+	dir, err := filepath.Abs(x.WorkerConfig.ExportPath)
+	if err != nil {
+		return "", err
+	}
+	path := file
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	path = filepath.Clean(path)
+	if rel, err := filepath.Rel(dir, path); err != nil || rel == "." ||
+		rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("Bundle %s must be in the export directory %s", file, dir)
+	}
+	return path, nil
+}
+
+// OpenBundle extracts the bundle at the given path and validates its manifest. Close must be
+// called once the bundle is no longer needed.
+func OpenBundle(path string) (*Bundle, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "while opening bundle")
+	}
+	defer fd.Close()
+
+	dir, err := ioutil.TempDir(x.WorkerConfig.TmpDir, "import")
+	if err != nil {
+		return nil, err
+	}
+	b := &Bundle{dir: dir}
+	if err := b.extract(fd); err != nil {
+		b.Close()
+		return nil, err
+	}
+	if err := b.validate(); err != nil {
+		b.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Bundle) extract(r io.Reader) error {
+	tr := tar.NewReader(r)
+	sawManifest := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "while reading bundle")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		// Only use the base name, so that entries can't be written outside of the directory.
+		name := filepath.Base(hdr.Name)
+		if name == bundleManifest {
+			sawManifest = true
+			if err := json.NewDecoder(tr).Decode(&b.Manifest); err != nil {
+				return errors.Wrapf(err, "while reading bundle manifest")
+			}
+			continue
+		}
+		f, err := os.OpenFile(filepath.Join(b.dir, name), os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, tr)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return errors.Wrapf(err, "while extracting %s from bundle", name)
+		}
+	}
+	if !sawManifest {
+		return errors.Errorf("Bundle doesn't have a manifest. Is it an export bundle?")
+	}
+	return nil
+}
+
+// parseRelease returns the major and minor release numbers of a version like v21.03.2.
+func parseRelease(version string) (int, int, bool) {
+	m := releaseRe.FindStringSubmatch(version)
+	if m == nil {
+		return 0, 0, false
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return major, minor, true
+}
+
+// checkBundleVersion returns an error if a bundle created by the given Dgraph version can't be
+// imported by this release.
+func checkBundleVersion(bundle string) error {
+	bmajor, bminor, ok := parseRelease(bundle)
+	if !ok {
+		glog.Warningf("Unable to find the release which created the bundle: %q", bundle)
+		return nil
+	}
+	major, minor, ok := parseRelease(x.Version())
+	if !ok {
+		glog.Warningf("Unable to find the release of this Alpha: %q", x.Version())
+		return nil
+	}
+	if bmajor > major || (bmajor == major && bminor > minor) {
+		return errors.Errorf("Bundle was created by Dgraph %s, which is newer than this "+
+			"Alpha's version %s", bundle, x.Version())
+	}
+	return nil
+}
+
+func (b *Bundle) validate() error {
+	m := &b.Manifest
+	if m.Version < 1 || m.Version > bundleVersion {
+		return errors.Errorf("Unsupported bundle version %d. This Alpha supports versions up "+
+			"to %d", m.Version, bundleVersion)
+	}
+	if err := checkBundleVersion(m.DgraphVersion); err != nil {
+		return err
+	}
+	if m.Encrypted && x.WorkerConfig.EncryptionKey == nil {
+		return errors.Errorf("Bundle is encrypted, but no encryption key is set for this Alpha")
+	}
+	if m.Namespace == math.MaxUint64 {
+		return errors.Errorf("Bundles with all the namespaces can't be imported")
+	}
+	if _, ok := exportFormats[m.Format]; !ok {
+		return errors.Errorf("Unknown bundle data format: %q", m.Format)
+	}
+	for _, f := range m.Files {
+		if _, err := os.Stat(filepath.Join(b.dir, f)); err != nil {
+			return errors.Errorf("File %s listed in the bundle manifest is missing", f)
+		}
+	}
+	return nil
+}
+
+// filesWithSuffix returns the bundle files ending with the given suffix, in manifest order.
+func (b *Bundle) filesWithSuffix(suffix string) []string {
+	var files []string
+	for _, f := range b.Manifest.Files {
+		if strings.HasSuffix(f, suffix) {
+			files = append(files, filepath.Join(b.dir, f))
+		}
+	}
+	return files
+}
+
+func (b *Bundle) readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := enc.GetReader(x.WorkerConfig.EncryptionKey, f)
+	if err != nil {
+		return nil, err
+	}
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+	return ioutil.ReadAll(gzr)
+}
+
+// Schema returns the schema and types of all the groups in the bundle.
+func (b *Bundle) Schema() (string, error) {
+	var buf bytes.Buffer
+	for _, f := range b.filesWithSuffix(".schema.gz") {
+		data, err := b.readFile(f)
+		if err != nil {
+			return "", errors.Wrapf(err, "while reading schema from bundle")
+		}
+		buf.Write(data)
+	}
+	return buf.String(), nil
+}
+
+// GraphQLSchema returns the GraphQL schema in the bundle, or an empty string if there is none.
+func (b *Bundle) GraphQLSchema() (string, error) {
+	for _, f := range b.filesWithSuffix(".gql_schema.gz") {
+		data, err := b.readFile(f)
+		if err != nil {
+			return "", errors.Wrapf(err, "while reading GraphQL schema from bundle")
+		}
+		var schemas []x.ExportedGQLSchema
+		if err := json.Unmarshal(data, &schemas); err != nil {
+			return "", errors.Wrapf(err, "while parsing GraphQL schema from bundle")
+		}
+		for _, s := range schemas {
+			if s.Schema != "" {
+				return s.Schema, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// uidMapper maps the uids in the bundle to new uids leased from Zero, so that the data doesn't
+// collide with uids already in use by the cluster.
+type uidMapper struct {
+	uids map[uint64]uint64
+}
+
+func (m *uidMapper) mapUids(ctx context.Context, nqs []*api.NQuad) error {
+	var missing []uint64
+	add := func(s string) error {
+		if s == "" {
+			return nil
+		}
+		uid, err := strconv.ParseUint(s, 0, 64)
+		if err != nil {
+			return errors.Errorf("Invalid uid %q in bundle", s)
+		}
+		if _, ok := m.uids[uid]; !ok {
+			m.uids[uid] = 0
+			missing = append(missing, uid)
+		}
+		return nil
+	}
+	for _, nq := range nqs {
+		if err := add(nq.Subject); err != nil {
+			return err
+		}
+		if err := add(nq.ObjectId); err != nil {
+			return err
+		}
+	}
+	if len(missing) > 0 {
+		assigned, err := assignBundleUids(ctx, &pb.Num{Val: uint64(len(missing))})
+		if err != nil {
+			return errors.Wrapf(err, "while leasing uids for import")
+		}
+		next := assigned.StartId
+		for _, uid := range missing {
+			x.AssertTrue(next <= assigned.EndId)
+			m.uids[uid] = next
+			next++
+		}
+	}
+	for _, nq := range nqs {
+		nq.Subject = m.remap(nq.Subject)
+		nq.ObjectId = m.remap(nq.ObjectId)
+	}
+	return nil
+}
+
+func (m *uidMapper) remap(s string) string {
+	if s == "" {
+		return s
+	}
+	uid, _ := strconv.ParseUint(s, 0, 64)
+	return fmt.Sprintf("%#x", m.uids[uid])
+}
+
+// isAclType returns true if the type is used for the nodes storing the ACL users, groups and
+// rules.
+func isAclType(typ string) bool {
+	switch typ {
+	case "dgraph.type.User", "dgraph.type.Group", "dgraph.type.Rule":
+		return true
+	}
+	return false
+}
+
+// isAclNQuad returns true if the N-Quad stores ACL data, including the types of the ACL nodes.
+func isAclNQuad(nq *api.NQuad) bool {
+	if x.IsAclPredicate(nq.Predicate) {
+		return true
+	}
+	if nq.Predicate != "dgraph.type" || nq.ObjectValue == nil {
+		return false
+	}
+	var typ string
+	switch v := nq.ObjectValue.Val.(type) {
+	case *api.Value_StrVal:
+		typ = v.StrVal
+	case *api.Value_DefaultVal:
+		typ = v.DefaultVal
+	}
+	return isAclType(typ)
+}
+
+// AclNode identifies an ACL user or group by its type and name.
+type AclNode struct {
+	Type string
+	Xid  string
+}
+
+// aclNodes returns the uids of the ACL users and groups in the bundle matching the nodes of keep,
+// mapped to the uid of the matching node, along with the uids of the rules of the groups.
+func (b *Bundle) aclNodes(ctx context.Context, keep map[AclNode]uint64) (
+	map[uint64]uint64, map[uint64]struct{}, error) {
+
+	xids := make(map[string]string)
+	types := make(map[string]string)
+	rules := make(map[string][]string)
+	err := b.readData(ctx, func(nqs []*api.NQuad) error {
+		for _, nq := range nqs {
+			switch {
+			case nq.Predicate == "dgraph.xid":
+				xids[nq.Subject] = nq.ObjectValue.GetStrVal() + nq.ObjectValue.GetDefaultVal()
+			case nq.Predicate == "dgraph.acl.rule":
+				rules[nq.Subject] = append(rules[nq.Subject], nq.ObjectId)
+			case isAclNQuad(nq) && nq.Predicate == "dgraph.type":
+				types[nq.Subject] = nq.ObjectValue.GetStrVal() + nq.ObjectValue.GetDefaultVal()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	parse := func(s string) uint64 {
+		uid, _ := strconv.ParseUint(s, 0, 64)
+		return uid
+	}
+	matched := make(map[uint64]uint64)
+	skipped := make(map[uint64]struct{})
+	for subj, typ := range types {
+		uid, ok := keep[AclNode{Type: typ, Xid: xids[subj]}]
+		if !ok {
+			continue
+		}
+		matched[parse(subj)] = uid
+		for _, rule := range rules[subj] {
+			skipped[parse(rule)] = struct{}{}
+		}
+	}
+	return matched, skipped, nil
+}
+
+// LoadData parses the data in the bundle and calls fn with batches of N-Quads. The uids in the
+// bundle are replaced by new ones, and the ACL data is skipped unless includeAcl is set. The ACL
+// users and groups in the bundle which match a node of keep aren't loaded, along with the rules of
+// the groups, and the edges to them point to the node of keep instead.
+func (b *Bundle) LoadData(ctx context.Context, includeAcl bool, keep map[AclNode]uint64,
+	fn func([]*api.NQuad) error) error {
+
+	mapper := &uidMapper{uids: make(map[uint64]uint64)}
+	skipped := make(map[uint64]struct{})
+	if includeAcl && len(keep) > 0 {
+		matched, rules, err := b.aclNodes(ctx, keep)
+		if err != nil {
+			return err
+		}
+		for uid, to := range matched {
+			mapper.uids[uid] = to
+			skipped[uid] = struct{}{}
+		}
+		for uid := range rules {
+			skipped[uid] = struct{}{}
+		}
+	}
+
+	return b.readData(ctx, func(nqs []*api.NQuad) error {
+		var batch []*api.NQuad
+		for _, nq := range nqs {
+			if !includeAcl && isAclNQuad(nq) {
+				continue
+			}
+			if uid, err := strconv.ParseUint(nq.Subject, 0, 64); err == nil {
+				if _, ok := skipped[uid]; ok {
+					continue
+				}
+			}
+			batch = append(batch, nq)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := mapper.mapUids(ctx, batch); err != nil {
+			return err
+		}
+		return fn(batch)
+	})
+}
+
+// readData parses the data files in the bundle and calls fn with the batches of N-Quads as they
+// are in the bundle.
+func (b *Bundle) readData(ctx context.Context, fn func([]*api.NQuad) error) error {
+	format := chunker.RdfFormat
+	if b.Manifest.Format == "json" {
+		format = chunker.JsonFormat
+	}
+	for _, f := range b.filesWithSuffix(exportFormats[b.Manifest.Format].ext + ".gz") {
+		if err := b.readDataFile(ctx, f, format, fn); err != nil {
+			return errors.Wrapf(err, "while importing %s", filepath.Base(f))
+		}
+	}
+	return nil
+}
+
+func (b *Bundle) readDataFile(ctx context.Context, path string, format chunker.InputFormat,
+	fn func([]*api.NQuad) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := enc.GetReader(x.WorkerConfig.EncryptionKey, f)
+	if err != nil {
+		return err
+	}
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+	rd := bufio.NewReader(gzr)
+
+	ck := chunker.NewChunker(format, bundleBatchSize)
+	errCh := make(chan error, 1)
+	go func() {
+		var rerr error
+		for nqs := range ck.NQuads().Ch() {
+			if rerr != nil {
+				// Keep draining the channel, so that the parser doesn't block.
+				continue
+			}
+			rerr = fn(nqs)
+		}
+		errCh <- rerr
+	}()
+
+	var perr error
+	for {
+		chunkBuf, err := ck.Chunk(rd)
+		if err != nil && err != io.EOF {
+			perr = err
+			break
+		}
+		if perr = ck.Parse(chunkBuf); perr != nil || err == io.EOF {
+			break
+		}
+	}
+	ck.NQuads().Flush()
+	if err := <-errCh; err != nil {
+		return err
+	}
+	return perr
+}
+
+// Close removes the extracted bundle.
+func (b *Bundle) Close() {
+	if err := os.RemoveAll(b.dir); err != nil {
+		glog.Warningf("Unable to remove extracted bundle at %s: %v", b.dir, err)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// A uid predicate with @cardinality(one) or @cardinality(one-to-one) has a single edge per node,
+// and a predicate with @cardinality(one-to-one) or @cardinality(one-to-many) a single edge
+// pointing to each node. When an edge is set, the edges it breaks the cardinality with are
+// deleted in the same transaction, or the mutation fails if the cardinality has reject.
+//
+// The edges pointing to a node are read from its reverse edges. As the reverse edges don't have
+// conflict keys, a conflict on the reverse key of the target is added, so that two concurrent
+// transactions pointing to the same node can't both commit.
+
+func isTargetCardinality(card string) bool {
+	return card == schema.CardinalityOneToOne || card == schema.CardinalityOneToMany
+}
+
+// enforceCardinality is called by runMutation before the edge is added to plist, the data list
+// of its node.
+func enforceCardinality(ctx context.Context, edge *pb.DirectedEdge, su *pb.SchemaUpdate,
+	plist *posting.List, txn *posting.Txn) error {
+
+	card := su.GetCardinality()
+	if card == "" || edge.Op != pb.DirectedEdge_SET || edge.ValueId == 0 {
+		return nil
+	}
+	attr := x.ParseAttr(edge.Attr)
+	reject := su.GetCardinalityReject()
+	if card != schema.CardinalityOneToMany && reject {
+		// Without reject, the edge of the node is replaced as for any uid predicate.
+		uids, err := plist.Uids(posting.ListOptions{ReadTs: txn.StartTs})
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids.Uids {
+			if uid != edge.ValueId {
+				return errors.Errorf("Node %#x already has an edge %s to %#x, which can't be "+
+					"replaced as the cardinality of %s is %s with reject", edge.Entity, attr, uid,
+					attr, card)
+			}
+		}
+	}
+	if !isTargetCardinality(card) {
+		return nil
+	}
+
+	key := x.ReverseKey(edge.Attr, edge.ValueId)
+	txn.AddKeyConflict(key)
+	rlist, err := txn.Get(key)
+	if err != nil {
+		return err
+	}
+	sources, err := rlist.Uids(posting.ListOptions{ReadTs: txn.StartTs})
+	if err != nil {
+		return err
+	}
+	for _, src := range sources.Uids {
+		if src == edge.Entity {
+			continue
+		}
+		if reject {
+			return errors.Errorf("Node %#x already has an edge %s from %#x, so %#x can't point "+
+				"to it as the cardinality of %s is %s with reject", edge.ValueId, attr, src,
+				edge.Entity, attr, card)
+		}
+		dataList, err := txn.Get(x.DataKey(edge.Attr, src))
+		if err != nil {
+			return err
+		}
+		del := &pb.DirectedEdge{
+			Entity:    src,
+			Attr:      edge.Attr,
+			ValueId:   edge.ValueId,
+			ValueType: pb.Posting_UID,
+			Op:        pb.DirectedEdge_DEL,
+		}
+		if err := dataList.AddMutationWithIndex(ctx, del, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkCardinalityEdges returns an error if the edges of a mutation break the cardinality of
+// their predicate between themselves. The edges of a mutation are applied concurrently, so which
+// of them would be kept isn't defined.
+func checkCardinalityEdges(ctx context.Context, edges []*pb.DirectedEdge) error {
+	type nodeAttr struct {
+		attr string
+		uid  uint64
+	}
+	targets := make(map[nodeAttr]uint64)
+	sources := make(map[nodeAttr]uint64)
+	for _, edge := range edges {
+		if edge.Op != pb.DirectedEdge_SET || edge.ValueId == 0 {
+			continue
+		}
+		su, ok := schema.State().Get(ctx, edge.Attr)
+		if !ok || su.GetCardinality() == "" {
+			continue
+		}
+		attr := x.ParseAttr(edge.Attr)
+		if card := su.GetCardinality(); isTargetCardinality(card) {
+			target := nodeAttr{attr: edge.Attr, uid: edge.ValueId}
+			if src, ok := sources[target]; ok && src != edge.Entity {
+				return errors.Errorf("Edges %s of nodes %#x and %#x both point to %#x, but the "+
+					"cardinality of %s is %s", attr, src, edge.Entity, edge.ValueId, attr, card)
+			}
+			sources[target] = edge.Entity
+		}
+		if su.GetCardinality() != schema.CardinalityOneToMany && su.GetCardinalityReject() {
+			src := nodeAttr{attr: edge.Attr, uid: edge.Entity}
+			if uid, ok := targets[src]; ok && uid != edge.ValueId {
+				return errors.Errorf("Node %#x is given two edges %s, to %#x and %#x, but the "+
+					"cardinality of %s is %s with reject", edge.Entity, attr, uid, edge.ValueId,
+					attr, su.GetCardinality())
+			}
+			targets[src] = edge.ValueId
+		}
+	}
+	return nil
+}
+
+// checkCardinalityChange returns an error if the schema update limits the edges pointing to a
+// node while a node already has several of them. The other cardinality changes don't need to
+// read the data: a uid predicate already has a single edge per node, and a [uid] predicate with
+// data can't become a uid one.
+func checkCardinalityChange(s *pb.SchemaUpdate) error {
+	if !isTargetCardinality(s.Cardinality) {
+		return nil
+	}
+	if old, _ := schema.State().Cardinality(s.Predicate); isTargetCardinality(old) {
+		return nil
+	}
+	if _, err := schema.State().TypeOf(s.Predicate); err != nil {
+		// There is no data yet.
+		return nil
+	}
+
+	target, srcs, err := sharedTarget(s.Predicate, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	if target != 0 {
+		attr := x.ParseAttr(s.Predicate)
+		return errors.Errorf("Schema change not allowed to @cardinality(%s): edges %s of nodes "+
+			"%#x and %#x both point to %#x. Delete one of them first.", s.Cardinality, attr,
+			srcs[0], srcs[1], target)
+	}
+	return nil
+}
+
+// sharedTarget returns a node which edges of the predicate of two nodes, also returned, point
+// to at readTs. It returns 0 if there isn't any. The data keys are read rather than the reverse
+// ones, as @reverse can be added along with @cardinality, and the source of every target is
+// kept in memory, which is fine for a schema change.
+func sharedTarget(attr string, readTs uint64) (uint64, [2]uint64, error) {
+	var target uint64
+	var srcs [2]uint64
+	sources := make(map[uint64]uint64)
+	err := iterateDataKeys(attr, readTs, func(node uint64, pl *posting.List) error {
+		uids, err := pl.Uids(posting.ListOptions{ReadTs: readTs})
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids.Uids {
+			if src, ok := sources[uid]; ok && src != node {
+				target, srcs = uid, [2]uint64{src, node}
+				return posting.ErrStopIteration
+			}
+			sources[uid] = node
+		}
+		return nil
+	})
+	return target, srcs, err
+}
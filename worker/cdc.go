@@ -21,6 +21,7 @@ package worker
 import (
 	"math"
 
+	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 )
 
@@ -47,6 +48,11 @@ func (cdc *CDC) updateCDCState(state *pb.CDCState) {
 	return
 }
 
+func (cdc *CDC) recordOldValues(index uint64, txn *posting.Txn,
+	edges []*pb.DirectedEdge) error {
+	return nil
+}
+
 func (cd *CDC) Close() {
 	return
 }
@@ -16,7 +16,10 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -24,6 +27,7 @@ import (
 
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
@@ -53,6 +57,13 @@ type CDC struct {
 	sink             Sink
 	closer           *z.Closer
 	pendingTxnEvents map[uint64][]CDCEvent
+	// predicates are the only predicates for which the events are sent, if set.
+	predicates map[string]struct{}
+	// oldValuePreds are the predicates for which the mutation events carry the value before the
+	// mutation. oldValues has these values, read while applying the mutations, keyed by
+	// the Raft index of the proposal and then by oldValueKey.
+	oldValuePreds map[string]struct{}
+	oldValues     map[uint64]map[string]interface{}
 
 	// dont use mutex, use atomic for the following.
 
@@ -79,9 +90,28 @@ func newCDC() *CDC {
 		closer:           z.NewCloser(1),
 		pendingTxnEvents: make(map[uint64][]CDCEvent),
 	}
+	cdc.predicates = parsePredicateList(cdcFlag.GetString("predicates"))
+	cdc.oldValuePreds = parsePredicateList(cdcFlag.GetString("old-values"))
+	if len(cdc.oldValuePreds) > 0 {
+		cdc.oldValues = make(map[uint64]map[string]interface{})
+	}
 	return cdc
 }
 
+func parsePredicateList(list string) map[string]struct{} {
+	var preds map[string]struct{}
+	for _, pred := range strings.Split(list, ",") {
+		if pred = strings.TrimSpace(pred); pred == "" {
+			continue
+		}
+		if preds == nil {
+			preds = make(map[string]struct{})
+		}
+		preds[pred] = struct{}{}
+	}
+	return preds
+}
+
 func (cdc *CDC) getSeenIndex() uint64 {
 	if cdc == nil {
 		return math.MaxUint64
@@ -127,6 +157,161 @@ func (cdc *CDC) hasPending(attr string) bool {
 	return false
 }
 
+// filterEvents drops the events of the predicates which are not to be sent. Drop all, drop data
+// and drop type events are always sent.
+func (cdc *CDC) filterEvents(events []CDCEvent) []CDCEvent {
+	if len(cdc.predicates) == 0 {
+		return events
+	}
+	filtered := events[:0]
+	for _, e := range events {
+		var attr string
+		switch ev := e.Event.(type) {
+		case *MutationEvent:
+			attr = ev.Attr
+		case *DropEvent:
+			if ev.Operation != OpDropPred {
+				filtered = append(filtered, e)
+				continue
+			}
+			attr = ev.Pred
+		}
+		if _, ok := cdc.predicates[attr]; ok {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+func oldValueKey(edge *pb.DirectedEdge) string {
+	return fmt.Sprintf("%s|%d|%s", edge.Attr, edge.Entity, edge.Lang)
+}
+
+// recordOldValues reads the values of the edges as of the start ts of the transaction, before
+// they are mutated. It only reads the predicates listed in the old-values option, as these reads
+// add to the latency of applying the mutations. The values are only kept on the leader, which is
+// the one sending the events.
+func (cdc *CDC) recordOldValues(index uint64, txn *posting.Txn,
+	edges []*pb.DirectedEdge) error {
+	if cdc == nil || len(cdc.oldValuePreds) == 0 || !groups().Node.AmLeader() {
+		return nil
+	}
+
+	var vals map[string]interface{}
+	for _, edge := range edges {
+		if _, ok := cdc.oldValuePreds[x.ParseAttr(edge.Attr)]; !ok {
+			continue
+		}
+		key := oldValueKey(edge)
+		if _, ok := vals[key]; ok {
+			continue
+		}
+		val, err := readOldValue(txn, edge)
+		if err != nil {
+			return errors.Wrapf(err, "while reading old value for CDC")
+		}
+		if vals == nil {
+			vals = make(map[string]interface{})
+		}
+		vals[key] = val
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	cdc.Lock()
+	defer cdc.Unlock()
+	cdc.oldValues[index] = vals
+	return nil
+}
+
+func readOldValue(txn *posting.Txn, edge *pb.DirectedEdge) (interface{}, error) {
+	l, err := txn.Get(x.DataKey(edge.Attr, edge.Entity))
+	if err != nil {
+		return nil, err
+	}
+	readTs := txn.StartTs
+
+	if typ, err := schema.State().TypeOf(edge.Attr); err == nil && typ == types.UidID {
+		uids, err := l.Uids(posting.ListOptions{ReadTs: readTs})
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(uids.Uids) == 0:
+			return nil, nil
+		case !schema.State().IsList(edge.Attr):
+			return uids.Uids[0], nil
+		}
+		return uids.Uids, nil
+	}
+
+	var vals []types.Val
+	switch {
+	case schema.State().IsList(edge.Attr):
+		vals, err = l.AllValues(readTs)
+	case edge.Lang != "":
+		var val types.Val
+		if val, err = l.ValueForTag(readTs, edge.Lang); err == nil {
+			vals = append(vals, val)
+		}
+	default:
+		var val types.Val
+		if val, err = l.Value(readTs); err == nil {
+			vals = append(vals, val)
+		}
+	}
+	switch {
+	case err == posting.ErrNoValue:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	res := make([]interface{}, 0, len(vals))
+	for _, v := range vals {
+		if v.Tid == types.PasswordID {
+			res = append(res, "****")
+			continue
+		}
+		src := types.Val{Tid: types.BinaryID, Value: v.Value}
+		cv, err := types.Convert(src, v.Tid)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, cv.Value)
+	}
+	switch {
+	case len(res) == 0:
+		return nil, nil
+	case !schema.State().IsList(edge.Attr):
+		return res[0], nil
+	}
+	return res, nil
+}
+
+// takeOldValues returns the old values recorded for the proposal at the given index. The values
+// recorded for the earlier indices are dropped, as those entries have been seen.
+func (cdc *CDC) takeOldValues(index uint64) map[string]interface{} {
+	cdc.Lock()
+	defer cdc.Unlock()
+	vals := cdc.oldValues[index]
+	for idx := range cdc.oldValues {
+		if idx <= index {
+			delete(cdc.oldValues, idx)
+		}
+	}
+	return vals
+}
+
+func (cdc *CDC) resetOldValues() {
+	if len(cdc.oldValuePreds) == 0 {
+		return
+	}
+	cdc.Lock()
+	defer cdc.Unlock()
+	cdc.oldValues = make(map[uint64]map[string]interface{})
+}
+
 func (cdc *CDC) addToPending(ts uint64, events []CDCEvent) {
 	if cdc == nil {
 		return
@@ -229,7 +414,11 @@ func (cdc *CDC) processCDCEvents() {
 			return
 		}
 		if proposal.Mutations != nil {
-			events := toCDCEvent(entry.Index, proposal.Mutations)
+			var oldValues map[string]interface{}
+			if len(cdc.oldValuePreds) > 0 {
+				oldValues = cdc.takeOldValues(entry.Index)
+			}
+			events := cdc.filterEvents(toCDCEvent(entry.Index, proposal.Mutations, oldValues))
 			if len(events) == 0 {
 				return
 			}
@@ -331,6 +520,10 @@ func (cdc *CDC) processCDCEvents() {
 				if err := sendEvents(); err != nil {
 					glog.Errorf("unable to send events %+v", err)
 				}
+			} else {
+				// Only the leader reads the old values, drop the ones read before
+				// the leadership was lost.
+				cdc.resetOldValues()
 			}
 		case <-proposalTick.C:
 			// The leader would propose the max sentTs over to the group.
@@ -364,12 +557,72 @@ type EventMeta struct {
 	CommitTs  uint64 `json:"commit_ts"`
 }
 
+// newValue returns the value of the predicate of the edge once the edge is applied to the old
+// value. It is the whole list for a list predicate.
+func newValue(old interface{}, edge *pb.DirectedEdge, val interface{}) interface{} {
+	del := edge.Op == pb.DirectedEdge_DEL
+	switch {
+	case del && bytes.Equal(edge.Value, []byte(x.Star)):
+		return nil
+	case !schema.State().IsList(edge.Attr) && del:
+		return nil
+	case !schema.State().IsList(edge.Attr):
+		return val
+	}
+
+	if uid, ok := val.(uint64); ok && posting.TypeID(edge) == types.UidID {
+		uids, _ := old.([]uint64)
+		res := make([]uint64, 0, len(uids)+1)
+		found := false
+		for _, u := range uids {
+			if u == uid {
+				found = true
+				if del {
+					continue
+				}
+			}
+			res = append(res, u)
+		}
+		if !del && !found {
+			res = append(res, uid)
+			sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+		}
+		if len(res) == 0 {
+			return nil
+		}
+		return res
+	}
+
+	vals, _ := old.([]interface{})
+	res := make([]interface{}, 0, len(vals)+1)
+	found := false
+	for _, v := range vals {
+		if reflect.DeepEqual(v, val) {
+			found = true
+			if del {
+				continue
+			}
+		}
+		res = append(res, v)
+	}
+	if !del && !found {
+		res = append(res, val)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
 type MutationEvent struct {
 	Operation string      `json:"operation"`
 	Uid       uint64      `json:"uid"`
 	Attr      string      `json:"attr"`
 	Value     interface{} `json:"value"`
 	ValueType string      `json:"value_type"`
+	// Old and New are only set for the predicates listed in the old-values option of CDC.
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
 }
 
 type DropEvent struct {
@@ -384,7 +637,12 @@ const (
 	OpDropPred        = "predicate"
 )
 
-func toCDCEvent(index uint64, mutation *pb.Mutations) []CDCEvent {
+// toCDCEvent converts the mutations into CDC events. The mutation events of the edges which have an
+// entry in oldValues carry the old value and the new value, which are the whole list for a list
+// predicate. They are computed one edge after the other, so that the edges of the same node and
+// predicate follow each other. The new value is left out once there is none, e.g. for deletions.
+func toCDCEvent(index uint64, mutation *pb.Mutations,
+	oldValues map[string]interface{}) []CDCEvent {
 	// todo(Aman): we are skipping schema updates for now. Fix this later.
 	if len(mutation.Schema) > 0 || len(mutation.Types) > 0 {
 		return nil
@@ -417,6 +675,10 @@ func toCDCEvent(index uint64, mutation *pb.Mutations) []CDCEvent {
 		}
 	}
 
+	current := make(map[string]interface{}, len(oldValues))
+	for key, val := range oldValues {
+		current[key] = val
+	}
 	cdcEvents := make([]CDCEvent, 0)
 	for _, edge := range mutation.Edges {
 		if x.IsReservedPredicate(edge.Attr) {
@@ -455,19 +717,32 @@ func toCDCEvent(index uint64, mutation *pb.Mutations) []CDCEvent {
 				glog.Errorf("error while converting value %v", err)
 			}
 		}
+		me := &MutationEvent{
+			Operation: strings.ToLower(edge.Op.String()),
+			Uid:       edge.Entity,
+			Attr:      attr,
+			Value:     val,
+			ValueType: posting.TypeID(edge).Name(),
+		}
+		key := oldValueKey(edge)
+		if old, ok := current[key]; ok {
+			me.Old = old
+			if edge.Op == pb.DirectedEdge_INCR {
+				// The value of an increment is the amount added, the new value isn't known here,
+				// and neither are the values of the next edges.
+				delete(current, key)
+			} else {
+				me.New = newValue(old, edge, val)
+				current[key] = me.New
+			}
+		}
 		cdcEvents = append(cdcEvents, CDCEvent{
 			Meta: &EventMeta{
 				RaftIndex: index,
 				Namespace: ns,
 			},
-			Type: EventTypeMutation,
-			Event: &MutationEvent{
-				Operation: strings.ToLower(edge.Op.String()),
-				Uid:       edge.Entity,
-				Attr:      attr,
-				Value:     val,
-				ValueType: posting.TypeID(edge).Name(),
-			},
+			Type:  EventTypeMutation,
+			Event: me,
 		})
 	}
 
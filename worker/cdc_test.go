@@ -0,0 +1,151 @@
+// +build !oss
+
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const cdcTestSchema = `
+	cdc_name: string .
+	cdc_tags: [string] .
+	cdc_owner: uid .
+	cdc_friends: [uid] .
+`
+
+func TestReadOldValue(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(cdcTestSchema), 1))
+	name, tags := x.GalaxyAttr("cdc_name"), x.GalaxyAttr("cdc_tags")
+	owner, friends := x.GalaxyAttr("cdc_owner"), x.GalaxyAttr("cdc_friends")
+	// The other tests, like the ones of the exports, read the whole store.
+	defer func() {
+		for _, attr := range []string{name, tags, owner, friends} {
+			require.NoError(t, pstore.DropPrefix(x.PredicatePrefix(attr)))
+		}
+	}()
+	valEdge := func(attr, val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Entity: 1, Attr: attr, Value: []byte(val),
+			ValueType: pb.Posting_STRING}
+	}
+	uidEdge := func(attr string, uid uint64) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Entity: 1, Attr: attr, ValueId: uid, ValueType: pb.Posting_UID}
+	}
+	addEdge(t, valEdge(name, "a"), getOrCreate(x.DataKey(name, 1)))
+	addEdge(t, valEdge(tags, "x"), getOrCreate(x.DataKey(tags, 1)))
+	addEdge(t, valEdge(tags, "y"), getOrCreate(x.DataKey(tags, 1)))
+	addEdge(t, uidEdge(owner, 5), getOrCreate(x.DataKey(owner, 1)))
+	addEdge(t, uidEdge(friends, 6), getOrCreate(x.DataKey(friends, 1)))
+	addEdge(t, uidEdge(friends, 7), getOrCreate(x.DataKey(friends, 1)))
+
+	txn := posting.Oracle().RegisterStartTs(timestamp())
+	read := func(edge *pb.DirectedEdge) interface{} {
+		val, err := readOldValue(txn, edge)
+		require.NoError(t, err)
+		return val
+	}
+	// The old value of a list is the whole list, not only the value of the edge.
+	require.Equal(t, "a", read(valEdge(name, "b")))
+	require.ElementsMatch(t, []interface{}{"x", "y"}, read(valEdge(tags, "z")))
+	require.Equal(t, uint64(5), read(uidEdge(owner, 8)))
+	require.Equal(t, []uint64{6, 7}, read(uidEdge(friends, 8)))
+
+	// A node without a value has no old value.
+	edge := valEdge(tags, "z")
+	edge.Entity = 2
+	require.Nil(t, read(edge))
+}
+
+func TestCDCEventOldValues(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(cdcTestSchema), 1))
+	name, tags := x.GalaxyAttr("cdc_name"), x.GalaxyAttr("cdc_tags")
+	friends := x.GalaxyAttr("cdc_friends")
+	edges := []*pb.DirectedEdge{
+		{Entity: 1, Attr: name, Value: []byte("b"), Op: pb.DirectedEdge_SET},
+		{Entity: 1, Attr: tags, Value: []byte("z"), Op: pb.DirectedEdge_SET},
+		{Entity: 1, Attr: tags, Value: []byte("x"), Op: pb.DirectedEdge_DEL},
+		{Entity: 1, Attr: friends, ValueId: 8, ValueType: pb.Posting_UID,
+			Op: pb.DirectedEdge_SET},
+		{Entity: 1, Attr: friends, ValueId: 6, ValueType: pb.Posting_UID,
+			Op: pb.DirectedEdge_DEL},
+		{Entity: 2, Attr: name, Value: []byte("c"), Op: pb.DirectedEdge_DEL},
+		{Entity: 3, Attr: tags, Value: []byte(x.Star), Op: pb.DirectedEdge_DEL},
+	}
+	oldValues := make(map[string]interface{})
+	for _, edge := range edges {
+		oldValues[oldValueKey(edge)] = nil
+	}
+	oldValues[oldValueKey(edges[0])] = "a"
+	oldValues[oldValueKey(edges[1])] = []interface{}{"x", "y"}
+	oldValues[oldValueKey(edges[3])] = []uint64{6, 7}
+	oldValues[oldValueKey(edges[5])] = "c"
+	oldValues[oldValueKey(edges[6])] = []interface{}{"t"}
+
+	events := toCDCEvent(1, &pb.Mutations{Edges: edges}, oldValues)
+	require.Len(t, events, len(edges))
+	for i, tc := range []struct {
+		old, new interface{}
+	}{
+		{"a", "b"},
+		// The edges of the same list are applied one after the other.
+		{[]interface{}{"x", "y"}, []interface{}{"x", "y", "z"}},
+		{[]interface{}{"x", "y", "z"}, []interface{}{"y", "z"}},
+		{[]uint64{6, 7}, []uint64{6, 7, 8}},
+		{[]uint64{6, 7, 8}, []uint64{7, 8}},
+		// A deletion has no new value.
+		{"c", nil},
+		{[]interface{}{"t"}, nil},
+	} {
+		me := events[i].Event.(*MutationEvent)
+		require.Equal(t, tc.old, me.Old, "event %d", i)
+		require.Equal(t, tc.new, me.New, "event %d", i)
+	}
+
+	// Without old values, the events don't carry any.
+	events = toCDCEvent(1, &pb.Mutations{Edges: edges[:1]}, nil)
+	me := events[0].Event.(*MutationEvent)
+	require.Nil(t, me.Old)
+	require.Nil(t, me.New)
+}
+
+func TestFilterEvents(t *testing.T) {
+	events := func() []CDCEvent {
+		return []CDCEvent{
+			{Type: EventTypeMutation, Event: &MutationEvent{Attr: "order"}},
+			{Type: EventTypeMutation, Event: &MutationEvent{Attr: "status"}},
+			{Type: EventTypeMutation, Event: &MutationEvent{Attr: "name"}},
+			{Type: EventTypeDrop, Event: &DropEvent{Operation: OpDropPred, Pred: "order"}},
+			{Type: EventTypeDrop, Event: &DropEvent{Operation: OpDropPred, Pred: "name"}},
+			{Type: EventTypeDrop, Event: &DropEvent{Operation: "all"}},
+			{Type: EventTypeDrop, Event: &DropEvent{Operation: "data"}},
+			{Type: EventTypeDrop, Event: &DropEvent{Operation: "type", Type: "Person"}},
+		}
+	}
+
+	// Without predicates, all the events are sent.
+	cdc := &CDC{}
+	require.Equal(t, events(), cdc.filterEvents(events()))
+
+	// Only the events of the predicates are sent, along with the drops which aren't of a predicate.
+	cdc.predicates = map[string]struct{}{"order": {}, "status": {}}
+	all := events()
+	require.Equal(t, []CDCEvent{all[0], all[1], all[3], all[5], all[6], all[7]},
+		cdc.filterEvents(events()))
+	require.Empty(t, cdc.filterEvents(events()[2:3]))
+}
@@ -38,3 +38,12 @@ func evalCompare(cmp string, lv, rv int64) bool {
 	x.Panic(errors.New("EvalCompare: unreachable"))
 	return false
 }
+
+// evalCountCompare compares a count with the thresholds of a count function. The between
+// function has two thresholds, the others have one.
+func evalCountCompare(cmp string, count int64, thresholds []int64) bool {
+	if cmp == between {
+		return count >= thresholds[0] && count <= thresholds[1]
+	}
+	return evalCompare(cmp, count, thresholds[0])
+}
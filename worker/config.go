@@ -32,6 +32,17 @@ const (
 	StrictMutations
 )
 
+const (
+	// CoerceTypeMismatch is the mode converting the values stored with another type than the one
+	// of their predicate in the schema, and failing the query if a value can't be converted.
+	CoerceTypeMismatch int = iota
+	// SkipTypeMismatch is the mode converting the values stored with another type, and leaving
+	// out the ones which can't be converted.
+	SkipTypeMismatch
+	// ErrorTypeMismatch is the mode failing the query on a value stored with another type.
+	ErrorTypeMismatch
+)
+
 // Options contains options for the Dgraph server.
 type Options struct {
 	// PostingDir is the path to the directory storing the postings..
@@ -40,6 +51,9 @@ type Options struct {
 	WALDir string
 	// MutationsMode is the mode used to handle mutation requests.
 	MutationsMode int
+	// TypeMismatchMode is the mode used to read the values stored with another type than the one
+	// of their predicate in the schema.
+	TypeMismatchMode int
 	// AuthToken is the token to be passed for Alter HTTP requests.
 	AuthToken string
 
@@ -0,0 +1,518 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// Derived predicates are declared in the schema as @derived(firstName + " " + lastName), and are
+// computed when the mutation is received, before its edges are sent to the groups serving them.
+// The derived predicate and its sources can be served by different groups, so the derivations
+// of all the derived predicates are kept by every alpha, see schema.State().Derivations().
+//
+// The values of the sources are taken from the mutation if it sets or deletes them, and read at
+// the start ts of the transaction otherwise. The derived predicate is deleted if any of its
+// sources doesn't have a value. Values are only computed from the untagged values of the
+// sources. Exports leave out the values of derived predicates, which are computed again when the
+// data is loaded with the live loader, but not with the bulk loader.
+
+const derivedComputeBatch = 1000
+
+type derivedCtxKey int
+
+// isDerivedCompute is set in the context of the mutations computing the derived predicates of
+// the existing data, which are allowed to set them.
+const isDerivedCompute derivedCtxKey = iota
+
+var derivationsLoaded struct {
+	sync.Mutex
+	done bool
+}
+
+// loadDerivations fetches the derivations of the derived predicates served by the other groups.
+// It's only done once, as the changes made later are sent to all the groups along with the
+// schema updates.
+func loadDerivations(ctx context.Context) error {
+	derivationsLoaded.Lock()
+	defer derivationsLoaded.Unlock()
+	if derivationsLoaded.done {
+		return nil
+	}
+
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{Fields: []string{"derived"}})
+	if err != nil {
+		return errors.Wrapf(err, "while loading the derived predicates")
+	}
+	for _, node := range nodes {
+		if len(node.Derived) == 0 {
+			continue
+		}
+		if _, ok := schema.State().Derivation(node.Predicate); !ok {
+			schema.State().SetDerivation(node.Predicate, node.Derived)
+		}
+	}
+	derivationsLoaded.done = true
+	return nil
+}
+
+// checkDerivations verifies the derived predicates of a schema update against the schema of
+// their sources, and sets the derivations which need to be sent to all the groups.
+func checkDerivations(ctx context.Context, m *pb.Mutations) error {
+	if len(m.Schema) == 0 {
+		return nil
+	}
+	if err := loadDerivations(ctx); err != nil {
+		return err
+	}
+
+	derived := schema.State().Derivations()
+	updates := make(map[string]*pb.SchemaUpdate, len(m.Schema))
+	for _, su := range m.Schema {
+		updates[su.Predicate] = su
+		if _, ok := derived[su.Predicate]; !ok && len(su.Derived) == 0 {
+			continue
+		}
+		m.Derivations = append(m.Derivations,
+			&pb.SchemaUpdate{Predicate: su.Predicate, Derived: su.Derived})
+		if len(su.Derived) > 0 {
+			derived[su.Predicate] = su.Derived
+		} else {
+			delete(derived, su.Predicate)
+		}
+	}
+	if len(m.Derivations) == 0 {
+		return nil
+	}
+	if _, err := schema.DerivationOrder(derived); err != nil {
+		return err
+	}
+
+	// The sources which aren't in the update are fetched from the groups serving them.
+	remote := make(map[string]string)
+	for pred, terms := range derived {
+		_, changed := updates[pred]
+		for _, src := range schema.DerivedSources(pred, terms) {
+			if su, ok := updates[src]; ok {
+				if err := schema.CheckDerivedSource(pred, su); err != nil {
+					return err
+				}
+			} else if changed {
+				remote[src] = pred
+			}
+		}
+	}
+	if len(remote) == 0 {
+		return nil
+	}
+	preds := make([]string, 0, len(remote))
+	for src := range remote {
+		preds = append(preds, src)
+	}
+	sort.Strings(preds)
+	nodes, err := GetSchemaOverNetwork(ctx, &pb.SchemaRequest{
+		Predicates: preds,
+		Fields:     []string{"type", "list"},
+	})
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		typ, ok := types.TypeForName(node.Type)
+		if !ok {
+			continue
+		}
+		su := &pb.SchemaUpdate{Predicate: node.Predicate, ValueType: typ.Enum(), List: node.List}
+		if err := schema.CheckDerivedSource(remote[node.Predicate], su); err != nil {
+			return err
+		}
+		delete(remote, node.Predicate)
+	}
+	for _, src := range preds {
+		if pred, ok := remote[src]; ok {
+			return errors.Errorf("Predicate %s used by derived predicate %s isn't defined in "+
+				"the schema", x.ParseAttr(src), x.ParseAttr(pred))
+		}
+	}
+	return nil
+}
+
+// derivedSource holds a value of a source predicate set or deleted by a mutation.
+type derivedSource struct {
+	val string
+	// deleted is true if the value is deleted. If only is set, the value is only deleted if it
+	// is the current value.
+	deleted bool
+	only    *string
+}
+
+// addDerivedEdges adds the edges setting the derived predicates of the nodes whose sources are
+// set or deleted by the mutation.
+func addDerivedEdges(ctx context.Context, m *pb.Mutations) error {
+	if len(m.Edges) == 0 {
+		return nil
+	}
+	if err := loadDerivations(ctx); err != nil {
+		return err
+	}
+	derived := schema.State().Derivations()
+	if len(derived) == 0 {
+		return nil
+	}
+
+	// Only the values of the sources and the derived predicates themselves are needed.
+	relevant := make(map[string]struct{})
+	for pred, terms := range derived {
+		relevant[pred] = struct{}{}
+		for _, src := range schema.DerivedSources(pred, terms) {
+			relevant[src] = struct{}{}
+		}
+	}
+
+	compute, _ := ctx.Value(isDerivedCompute).(bool)
+	touched := make(map[string]map[uint64]derivedSource)
+	for _, edge := range m.Edges {
+		if edge.Entity == 0 && bytes.Equal(edge.Value, []byte(x.Star)) {
+			if err := checkDerivedDrop(m, derived, edge.Attr); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := relevant[edge.Attr]; !ok {
+			continue
+		}
+		isDelAll := edge.Op == pb.DirectedEdge_DEL && bytes.Equal(edge.Value, []byte(x.Star))
+		if _, ok := derived[edge.Attr]; ok && !compute && !isDelAll {
+			return errors.Errorf("Predicate %s is derived and can't be mutated directly",
+				x.ParseAttr(edge.Attr))
+		}
+		if edge.Op == pb.DirectedEdge_INCR {
+			return errors.Errorf("Predicate %s is a source of a derived predicate and can't be "+
+				"incremented", x.ParseAttr(edge.Attr))
+		}
+		if edge.Lang != "" || edge.ValueId != 0 {
+			continue
+		}
+
+		var src derivedSource
+		switch {
+		case isDelAll:
+			src.deleted = true
+		case edge.Op == pb.DirectedEdge_DEL:
+			val, err := derivedString(types.TypeID(edge.ValueType), edge.Value)
+			if err != nil {
+				return err
+			}
+			src.deleted, src.only = true, &val
+		default:
+			val, err := derivedString(types.TypeID(edge.ValueType), edge.Value)
+			if err != nil {
+				return err
+			}
+			src.val = val
+		}
+		if touched[edge.Attr] == nil {
+			touched[edge.Attr] = make(map[uint64]derivedSource)
+		}
+		touched[edge.Attr][edge.Entity] = src
+	}
+
+	order, err := schema.DerivationOrder(derived)
+	if err != nil {
+		return err
+	}
+	for _, pred := range order {
+		sources := schema.DerivedSources(pred, derived[pred])
+		uidSet := make(map[uint64]struct{})
+		for _, src := range sources {
+			for uid := range touched[src] {
+				uidSet[uid] = struct{}{}
+			}
+		}
+		if len(uidSet) == 0 {
+			continue
+		}
+		uids := make([]uint64, 0, len(uidSet))
+		for uid := range uidSet {
+			uids = append(uids, uid)
+		}
+		sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+
+		vals := make(map[string]map[uint64]string, len(sources))
+		for _, src := range sources {
+			srcVals, err := derivedSourceValues(ctx, src, uids, touched[src], m.StartTs)
+			if err != nil {
+				return err
+			}
+			vals[src] = srcVals
+		}
+		if touched[pred] == nil {
+			touched[pred] = make(map[uint64]derivedSource)
+		}
+		for _, uid := range uids {
+			val, ok := derivedValue(pred, derived[pred], vals, uid)
+			touched[pred][uid] = derivedSource{val: val, deleted: !ok}
+			m.Edges = append(m.Edges, derivedEdge(uid, pred, val, ok))
+		}
+	}
+	return nil
+}
+
+// derivedEdge returns the edge setting the value of the derived predicate, or deleting it if
+// the value couldn't be computed.
+func derivedEdge(uid uint64, pred, val string, ok bool) *pb.DirectedEdge {
+	if !ok {
+		return &pb.DirectedEdge{
+			Entity:    uid,
+			Attr:      pred,
+			Value:     []byte(x.Star),
+			ValueType: pb.Posting_DEFAULT,
+			Op:        pb.DirectedEdge_DEL,
+		}
+	}
+	return &pb.DirectedEdge{
+		Entity:    uid,
+		Attr:      pred,
+		Value:     []byte(val),
+		ValueType: pb.Posting_STRING,
+		Op:        pb.DirectedEdge_SET,
+	}
+}
+
+// checkDerivedDrop makes sure that the predicates used by derived predicates aren't dropped,
+// and removes the derivation of a derived predicate from all the groups when it's dropped.
+func checkDerivedDrop(m *pb.Mutations, derived map[string][]string, attr string) error {
+	for pred, terms := range derived {
+		for _, src := range schema.DerivedSources(pred, terms) {
+			if src == attr {
+				return errors.Errorf("Predicate %s can't be dropped as it's used by derived "+
+					"predicate %s", x.ParseAttr(attr), x.ParseAttr(pred))
+			}
+		}
+	}
+	if _, ok := derived[attr]; ok {
+		m.Derivations = append(m.Derivations, &pb.SchemaUpdate{Predicate: attr})
+	}
+	return nil
+}
+
+// derivedSourceValues returns the values of the source predicate for the given uids, taking the
+// values set or deleted by the mutation over the ones read at readTs.
+func derivedSourceValues(ctx context.Context, attr string, uids []uint64,
+	touched map[uint64]derivedSource, readTs uint64) (map[uint64]string, error) {
+	vals := make(map[uint64]string, len(uids))
+	var read []uint64
+	for _, uid := range uids {
+		src, ok := touched[uid]
+		switch {
+		case !ok || src.only != nil:
+			read = append(read, uid)
+		case !src.deleted:
+			vals[uid] = src.val
+		}
+	}
+	if len(read) == 0 {
+		return vals, nil
+	}
+
+	stored, err := readDerivedSource(ctx, attr, read, readTs)
+	if err != nil {
+		return nil, err
+	}
+	for uid, val := range stored {
+		if src, ok := touched[uid]; ok && src.only != nil && *src.only == val {
+			continue
+		}
+		vals[uid] = val
+	}
+	return vals, nil
+}
+
+// readDerivedSource reads the values of the predicate for the given sorted uids.
+func readDerivedSource(ctx context.Context, attr string, uids []uint64,
+	readTs uint64) (map[uint64]string, error) {
+	res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+		Attr:    attr,
+		UidList: &pb.List{Uids: uids},
+		ReadTs:  readTs,
+	})
+	switch {
+	case err == errNonExistentTablet:
+		// Nothing has been stored for the predicate yet.
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "while reading %s for derived predicates", x.ParseAttr(attr))
+	}
+
+	vals := make(map[uint64]string, len(uids))
+	for i, vl := range res.GetValueMatrix() {
+		if i >= len(uids) || len(vl.GetValues()) == 0 {
+			continue
+		}
+		v := vl.Values[0]
+		val, err := derivedString(types.TypeID(v.ValType), v.Val)
+		if err != nil {
+			return nil, err
+		}
+		vals[uids[i]] = val
+	}
+	return vals, nil
+}
+
+// derivedString returns the value as a string, so that it can be used in a derivation.
+func derivedString(tid types.TypeID, data []byte) (string, error) {
+	val, err := types.Convert(types.Val{Tid: tid, Value: data}, types.StringID)
+	if err != nil {
+		return "", err
+	}
+	return val.Value.(string), nil
+}
+
+// derivedValue computes the value of the derived predicate for the uid. It returns false if any
+// of the sources doesn't have a value.
+func derivedValue(pred string, terms []string, vals map[string]map[uint64]string,
+	uid uint64) (string, bool) {
+	ns := x.ParseNamespace(pred)
+	var sb strings.Builder
+	for _, term := range terms {
+		if schema.IsDerivedLiteral(term) {
+			sb.WriteString(schema.DerivedLiteral(term))
+			continue
+		}
+		val, ok := vals[x.NamespaceAttr(ns, term)][uid]
+		if !ok {
+			return "", false
+		}
+		sb.WriteString(val)
+	}
+	return sb.String(), true
+}
+
+// ChangedDerivations returns the derived predicates whose derivation is added or changed by the
+// schema updates.
+func ChangedDerivations(ctx context.Context, updates []*pb.SchemaUpdate) ([]string, error) {
+	if err := loadDerivations(ctx); err != nil {
+		return nil, err
+	}
+	var changed []string
+	for _, su := range updates {
+		if len(su.Derived) == 0 {
+			continue
+		}
+		terms, ok := schema.State().Derivation(su.Predicate)
+		if !ok || strings.Join(terms, "\x00") != strings.Join(su.Derived, "\x00") {
+			changed = append(changed, su.Predicate)
+		}
+	}
+	return changed, nil
+}
+
+// ComputeDerived computes the given derived predicates for the existing data. It's used when a
+// derivation is added or changed, as the values are otherwise only computed when the sources
+// are mutated. The values are written in batches, each in its own transaction, and the
+// predicates derived from them are computed along with them.
+func ComputeDerived(ctx context.Context, preds []string) error {
+	derived := schema.State().Derivations()
+	order, err := schema.DerivationOrder(derived)
+	if err != nil {
+		return err
+	}
+	want := make(map[string]struct{}, len(preds))
+	for _, pred := range preds {
+		want[pred] = struct{}{}
+	}
+
+	ctx = context.WithValue(ctx, isDerivedCompute, true)
+	for _, pred := range order {
+		if _, ok := want[pred]; !ok {
+			continue
+		}
+		glog.Infof("Computing derived predicate %s", x.ParseAttr(pred))
+		if err := computeDerived(ctx, pred, derived[pred]); err != nil {
+			return errors.Wrapf(err, "while computing derived predicate %s", x.ParseAttr(pred))
+		}
+	}
+	return nil
+}
+
+func computeDerived(ctx context.Context, pred string, terms []string) error {
+	sources := schema.DerivedSources(pred, terms)
+	readTs := State.GetTimestamp(true)
+
+	// The nodes having the derived predicate are included, so that the values of the nodes not
+	// having all the sources anymore are deleted.
+	var lists []*pb.List
+	for _, attr := range append(sources, pred) {
+		res, err := ProcessTaskOverNetwork(ctx, &pb.Query{
+			Attr:    attr,
+			SrcFunc: &pb.SrcFunction{Name: "has"},
+			ReadTs:  readTs,
+		})
+		switch {
+		case err == errNonExistentTablet:
+			continue
+		case err != nil:
+			return err
+		}
+		lists = append(lists, res.GetUidMatrix()...)
+	}
+	uids := algo.MergeSorted(lists).GetUids()
+
+	for start := 0; start < len(uids); start += derivedComputeBatch {
+		end := start + derivedComputeBatch
+		if end > len(uids) {
+			end = len(uids)
+		}
+		batch := uids[start:end]
+
+		m := &pb.Mutations{StartTs: State.GetTimestamp(false)}
+		vals := make(map[string]map[uint64]string, len(sources))
+		for _, src := range sources {
+			stored, err := readDerivedSource(ctx, src, batch, m.StartTs)
+			if err != nil {
+				return err
+			}
+			vals[src] = stored
+		}
+		for _, uid := range batch {
+			val, ok := derivedValue(pred, terms, vals, uid)
+			m.Edges = append(m.Edges, derivedEdge(uid, pred, val, ok))
+		}
+
+		tctx, err := MutateOverNetwork(ctx, m)
+		if err != nil {
+			return err
+		}
+		if _, err := CommitOverNetwork(ctx, tctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -80,6 +80,8 @@ type node struct {
 	elog        trace.EventLog
 
 	ex *executor
+	// batcher coalesces the mutation proposals, it is nil if mutation-batch-window isn't set.
+	batcher *proposalBatcher
 }
 
 type op int
@@ -263,6 +265,114 @@ func GetOngoingTasks() []string {
 	return tasks
 }
 
+// RaftStatus captures the Raft replication state of this Alpha for its group.
+type RaftStatus struct {
+	Group uint32 `json:"group"`
+	Id    uint64 `json:"id"`
+	// Role is one of leader, follower, candidate or pre-candidate.
+	Role string `json:"role"`
+	// Leader is the Raft id of the current group leader. It is zero while an election is
+	// in progress, in which case HasLeader is false.
+	Leader    uint64 `json:"leader"`
+	HasLeader bool   `json:"has_leader"`
+	Term      uint64 `json:"term"`
+	// Committed is the commit index of the leader, while Applied is the highest index applied by
+	// this node. Lag is the difference between the two. Without a leader, Committed is the commit
+	// index known by this node.
+	Committed     uint64 `json:"committed"`
+	Applied       uint64 `json:"applied"`
+	Lag           uint64 `json:"lag"`
+	SnapshotIndex uint64 `json:"snapshot_index"`
+	// Peers contains the replication lag of each peer as seen by the leader. It is only populated
+	// when this node is the leader.
+	Peers []RaftPeerStatus `json:"peers,omitempty"`
+}
+
+// RaftPeerStatus is the replication progress of a peer, as tracked by the group leader.
+type RaftPeerStatus struct {
+	Id      uint64 `json:"id"`
+	Match   uint64 `json:"match"`
+	Lag     uint64 `json:"lag"`
+	Active  bool   `json:"active"`
+	Learner bool   `json:"learner"`
+}
+
+func raftRole(st raft.StateType) string {
+	switch st {
+	case raft.StateLeader:
+		return "leader"
+	case raft.StateCandidate:
+		return "candidate"
+	case raft.StatePreCandidate:
+		return "pre-candidate"
+	default:
+		return "follower"
+	}
+}
+
+// GetRaftStatus returns the Raft replication state of this Alpha. It returns an error if the
+// Raft node has not been started yet, or if the commit index of the leader can't be read.
+func GetRaftStatus(ctx context.Context) (*RaftStatus, error) {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return nil, conn.ErrNoNode
+	}
+	st := n.Raft().Status()
+	applied := n.Applied.DoneUntil()
+	// The commit index of a follower trails the one of the leader, so it is read from the leader.
+	committed := st.Commit
+	if st.Lead != raft.None && st.RaftState != raft.StateLeader {
+		var err error
+		if committed, err = n.ReadIndex(ctx); err != nil {
+			return nil, errors.Wrapf(err, "while reading the commit index of the leader")
+		}
+	}
+	snap, err := n.Store.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	rs := raftStatus(n.Id, st, committed, applied)
+	rs.Group = n.gid
+	rs.SnapshotIndex = snap.Metadata.Index
+	return rs, nil
+}
+
+// raftStatus returns the replication state of the node id, given its Raft status, the commit
+// index of the leader and the index applied by the node.
+func raftStatus(id uint64, st raft.Status, committed, applied uint64) *RaftStatus {
+	rs := &RaftStatus{
+		Id:        id,
+		Role:      raftRole(st.RaftState),
+		Leader:    st.Lead,
+		HasLeader: st.Lead != raft.None,
+		Term:      st.Term,
+		Committed: committed,
+		Applied:   applied,
+	}
+	if committed > applied {
+		rs.Lag = committed - applied
+	}
+
+	// The progress of the peers is only tracked by the leader, whose commit index is st.Commit.
+	for pid, pr := range st.Progress {
+		if pid == id {
+			continue
+		}
+		ps := RaftPeerStatus{
+			Id:      pid,
+			Match:   pr.Match,
+			Active:  pr.RecentActive,
+			Learner: pr.IsLearner,
+		}
+		if st.Commit > pr.Match {
+			ps.Lag = st.Commit - pr.Match
+		}
+		rs.Peers = append(rs.Peers, ps)
+	}
+	sort.Slice(rs.Peers, func(i, j int) bool { return rs.Peers[i].Id < rs.Peers[j].Id })
+	return rs
+}
+
 // Now that we apply txn updates via Raft, waiting based on Txn timestamps is
 // sufficient. We don't need to wait for proposals to be applied.
 
@@ -295,6 +405,12 @@ func newNode(store *raftwal.DiskStorage, gid uint32, id uint64, myAddr string) *
 	if x.WorkerConfig.LudicrousEnabled {
 		n.ex = newExecutor(&m.Applied, int(x.WorkerConfig.Ludicrous.GetInt64("concurrency")))
 	}
+	if window := x.WorkerConfig.Raft.GetDuration("mutation-batch-window"); window > 0 {
+		n.batcher = newProposalBatcher(window,
+			func(ctx context.Context, entries []raftpb.Entry) error {
+				return stepProposals(ctx, n.Raft(), entries)
+			})
+	}
 	return n
 }
 
@@ -326,6 +442,14 @@ func (n *node) applyConfChange(e raftpb.Entry) {
 
 var errHasPendingTxns = errors.New("Pending transactions found. Please retry operation")
 
+// dropEpoch is the number of drop all, drop data and namespace deletions applied by this alpha.
+var dropEpoch uint64
+
+// DropEpoch returns dropEpoch. Data cached out of the posting lists is stale once it changes.
+func DropEpoch() uint64 {
+	return atomic.LoadUint64(&dropEpoch)
+}
+
 // We must not wait here. Previously, we used to block until we have aborted the
 // transactions. We're now applying all updates serially, so blocking for one
 // operation is not an option.
@@ -351,6 +475,9 @@ func detectPendingTxns(attr string) error {
 func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr error) {
 	span := otrace.FromContext(ctx)
 
+	if op := proposal.Mutations.DropOp; op == pb.Mutations_DATA || op == pb.Mutations_ALL {
+		defer atomic.AddUint64(&dropEpoch, 1)
+	}
 	if proposal.Mutations.DropOp == pb.Mutations_DATA {
 		// Ensures nothing get written to disk due to commit proposals.
 		posting.Oracle().ResetTxns()
@@ -404,6 +531,20 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 		return errors.New("StartTs must be provided")
 	}
 
+	// Derivations and aliases come along with the schema updates and the drop of predicates, or
+	// on their own for the groups which don't serve any of the predicates.
+	for _, d := range proposal.Mutations.Derivations {
+		schema.State().SetDerivation(d.Predicate, d.Derived)
+	}
+	for _, a := range proposal.Mutations.Aliases {
+		schema.State().SetAliases(a.Predicate, a.Aliases)
+	}
+	if len(proposal.Mutations.Derivations)+len(proposal.Mutations.Aliases) > 0 &&
+		len(proposal.Mutations.Schema) == 0 && len(proposal.Mutations.Types) == 0 &&
+		len(proposal.Mutations.Edges) == 0 {
+		return nil
+	}
+
 	if len(proposal.Mutations.Schema) > 0 || len(proposal.Mutations.Types) > 0 {
 		// MaxAssigned would ensure that everything that's committed up until this point
 		// would be picked up in building indexes. Any uncommitted txns would be cancelled
@@ -463,6 +604,10 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 				span.Annotatef(nil, "Found pending transactions. Retry later.")
 				return err
 			}
+			// DropPrefix() blocks the writes on Badger, as for the schema mutations below.
+			if x.WorkerConfig.LudicrousEnabled {
+				n.ex.waitForActiveMutations()
+			}
 			span.Annotatef(nil, "Deleting predicate: %s", edge.Attr)
 			return posting.DeletePredicate(ctx, edge.Attr, proposal.StartTs)
 		}
@@ -501,6 +646,9 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	}
 
 	m := proposal.Mutations
+	if m.AppendDuplicates {
+		ctx = posting.WithAppendDuplicates(ctx)
+	}
 
 	// It is possible that the user gives us multiple versions of the same edge, one with no facets
 	// and another with facets. In that case, use stable sort to maintain the ordering given to us
@@ -521,7 +669,12 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 		return nil
 	}
 
-	txn := posting.Oracle().RegisterStartTs(m.StartTs)
+	var txn *posting.Txn
+	if m.ReadCommittedTs != 0 {
+		txn = posting.Oracle().RegisterReadCommitted(m.StartTs, m.ReadCommittedTs)
+	} else {
+		txn = posting.Oracle().RegisterStartTs(m.StartTs)
+	}
 	if txn.ShouldAbort() {
 		span.Annotatef(nil, "Txn %d should abort.", m.StartTs)
 		return x.ErrConflict
@@ -529,6 +682,10 @@ func (n *node) applyMutations(ctx context.Context, proposal *pb.Proposal) (rerr
 	// Discard the posting lists from cache to release memory at the end.
 	defer txn.Update()
 
+	if err := n.cdcTracker.recordOldValues(proposal.Index, txn, m.Edges); err != nil {
+		return err
+	}
+
 	process := func(edges []*pb.DirectedEdge) error {
 		var retries int
 		for _, edge := range edges {
@@ -652,13 +809,23 @@ func (n *node) applyCommitted(proposal *pb.Proposal, key uint64) error {
 			}
 			glog.Warningf("Error while calling CreateSnapshot: %v. Retrying...", err)
 		}
-		// We can now discard all invalid versions of keys below this ts.
-		pstore.SetDiscardTs(snap.ReadTs)
+		// We can now discard all invalid versions of keys below this ts, except the ones which
+		// still fall within the MVCC retention window.
+		pstore.SetDiscardTs(retention.update(snap.ReadTs, time.Now()))
 		return nil
 	case proposal.Restore != nil:
-		// Enable draining mode for the duration of the restore processing.
+		// Enable draining mode for the duration of the restore processing. The requests are
+		// rejected with an error telling that the restore is in progress, including the ones
+		// of the other Alphas for the predicates of this group, which are sent to ServeTask.
+		// They are allowed again once the indexes of the restored predicates are ready, as
+		// checked by handleRestoreProposal. The predicates of the groups which are done
+		// restoring can already be queried.
 		x.UpdateDrainingMode(true)
-		defer x.UpdateDrainingMode(false)
+		x.UpdateRestoreMode(true)
+		defer func() {
+			x.UpdateRestoreMode(false)
+			x.UpdateDrainingMode(false)
+		}()
 
 		var err error
 		var closer *z.Closer
@@ -685,6 +852,7 @@ func (n *node) applyCommitted(proposal *pb.Proposal, key uint64) error {
 	case proposal.DeleteNs != nil:
 		x.AssertTrue(proposal.DeleteNs.Namespace != x.GalaxyNamespace)
 		n.elog.Printf("Deleting namespace: %d", proposal.DeleteNs.Namespace)
+		defer atomic.AddUint64(&dropEpoch, 1)
 		return posting.DeleteNamespace(proposal.DeleteNs.Namespace)
 
 	case proposal.CdcState != nil:
@@ -920,6 +1088,21 @@ func (n *node) Snapshot() (*pb.Snapshot, error) {
 	return res, nil
 }
 
+// MinReadTs returns the lowest timestamp at which a consistent snapshot can be read from the group
+// served by this Alpha. Badger is allowed to discard the versions below the discard ts, which is
+// the ReadTs of the last Raft snapshot unless a MVCC retention window is set. Reads at a lower
+// timestamp could miss data.
+func MinReadTs() (uint64, error) {
+	if ts := retention.getDiscardTs(); ts > 0 {
+		return ts, nil
+	}
+	snap, err := groups().Node.Snapshot()
+	if err != nil {
+		return 0, err
+	}
+	return snap.GetReadTs(), nil
+}
+
 func (n *node) retrieveSnapshot(snap pb.Snapshot) error {
 	closer, err := n.startTask(opSnapshot)
 	if err != nil {
@@ -986,8 +1169,33 @@ func (n *node) proposeCDCState(ts uint64) error {
 	return n.Raft().Propose(n.ctx, data)
 }
 
+// replicatingIndex returns the lowest index matched by the followers which are still catching up
+// via the Raft log. A snapshot beyond that index would delete the entries they need from the WAL,
+// forcing a full snapshot transfer instead. Followers lagging more than snapshot-after-entries
+// behind the commit index of the leader are not waited for, so that a slow follower can't make
+// the WAL grow unbounded.
+func (n *node) replicatingIndex(applied uint64) uint64 {
+	maxLag := x.WorkerConfig.Raft.GetUint64("snapshot-after-entries")
+	return replicatingIndex(n.Id, n.Raft().Status(), applied, maxLag)
+}
+
+func replicatingIndex(id uint64, st raft.Status, applied, maxLag uint64) uint64 {
+	idx := applied
+	// The progress of the followers is only tracked by the leader, whose commit index is st.Commit.
+	for pid, pr := range st.Progress {
+		if pid == id || !pr.RecentActive || pr.State == raft.ProgressStateSnapshot {
+			continue
+		}
+		if pr.Match < idx && (pr.Match >= st.Commit || st.Commit-pr.Match <= maxLag) {
+			idx = pr.Match
+		}
+	}
+	return idx
+}
+
 func (n *node) proposeSnapshot() error {
-	lastIdx := x.Min(n.Applied.DoneUntil(), n.cdcTracker.getSeenIndex())
+	applied := n.Applied.DoneUntil()
+	lastIdx := x.Min(n.replicatingIndex(applied), n.cdcTracker.getSeenIndex())
 	// We can't rely upon the Raft entries to determine the minPendingStart,
 	// because there are many cases during mutations where we don't commit or
 	// abort the transaction. This might happen due to an early error thrown.
@@ -1068,6 +1276,7 @@ func (n *node) checkpointAndClose(done chan struct{}) {
 	x.AssertTruef(snapshotAfterEntries > 10, "raft.snapshot-after must be a number greater than 10")
 
 	snapshotFrequency := x.WorkerConfig.Raft.GetDuration("snapshot-after-duration")
+	maxWalFiles := int(x.WorkerConfig.Raft.GetInt64("snapshot-max-wal-files"))
 
 	for {
 		select {
@@ -1093,7 +1302,8 @@ func (n *node) checkpointAndClose(done chan struct{}) {
 
 				// If we don't have a snapshot, or if there are too many log files in Raft,
 				// calculate a new snapshot.
-				calculate := raft.IsEmptySnap(snap) || n.Store.NumLogFiles() > 4
+				tooManyFiles := maxWalFiles > 0 && n.Store.NumLogFiles() > maxWalFiles
+				calculate := raft.IsEmptySnap(snap) || tooManyFiles
 
 				// Only take snapshot if both snapshotFrequency and
 				// snapshotAfterEntries requirements are met. If set to 0,
@@ -1122,6 +1332,13 @@ func (n *node) checkpointAndClose(done chan struct{}) {
 				// We use disk based storage for Raft. So, we're not too concerned about
 				// snapshotting.  We just need to do enough, so that we don't have a huge backlog of
 				// entries to process on a restart.
+				// A backup streams data at its read timestamp. Hold off on snapshots (which move
+				// the discard timestamp forward) until it is done, unless the WAL has already
+				// grown beyond its bounds.
+				if calculate && !tooManyFiles && n.isRunningTask(opBackup) {
+					glog.V(2).Infof("Deferring snapshot as a backup is in progress")
+					calculate = false
+				}
 				if calculate {
 					// We can set discardN argument to zero, because we already know that calculate
 					// would be true if either we absolutely needed to calculate the snapshot,
@@ -1874,6 +2091,10 @@ func (n *node) InitAndStartNode() {
 	}
 	go n.processTabletSizes()
 	go n.processApplyCh()
+	if n.batcher != nil {
+		n.closer.AddRunning(1)
+		go n.batcher.run(n.closer)
+	}
 	go n.BatchAndSendMessages()
 	go n.monitorRaftMetrics()
 	go n.cdcTracker.processCDCEvents()
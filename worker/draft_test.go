@@ -26,6 +26,7 @@ import (
 	"github.com/dgraph-io/dgraph/raftwal"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 )
 
@@ -114,3 +115,55 @@ func TestCalculateSnapshot(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, snap)
 }
+
+func TestRaftStatus(t *testing.T) {
+	// A follower whose own commit index trails the one of the leader.
+	var st raft.Status
+	st.RaftState = raft.StateFollower
+	st.Lead = 2
+	st.Term = 3
+	st.Commit = 90
+	rs := raftStatus(1, st, 100, 80)
+	require.Equal(t, &RaftStatus{Id: 1, Role: "follower", Leader: 2, HasLeader: true, Term: 3,
+		Committed: 100, Applied: 80, Lag: 20}, rs)
+
+	// An election is in progress.
+	st.RaftState = raft.StateCandidate
+	st.Lead = raft.None
+	rs = raftStatus(1, st, st.Commit, 95)
+	require.False(t, rs.HasLeader)
+	require.Equal(t, "candidate", rs.Role)
+	require.Equal(t, uint64(0), rs.Lag)
+
+	// The leader reports the lag of its peers.
+	st.RaftState = raft.StateLeader
+	st.Lead = 1
+	st.Commit = 100
+	st.Progress = map[uint64]raft.Progress{
+		1: {Match: 100},
+		3: {Match: 70, RecentActive: true},
+		2: {Match: 100, IsLearner: true},
+	}
+	rs = raftStatus(1, st, st.Commit, 100)
+	require.Equal(t, uint64(0), rs.Lag)
+	require.Equal(t, []RaftPeerStatus{
+		{Id: 2, Match: 100, Learner: true},
+		{Id: 3, Match: 70, Lag: 30, Active: true},
+	}, rs.Peers)
+}
+
+func TestReplicatingIndex(t *testing.T) {
+	var st raft.Status
+	st.Commit = 100
+	st.Progress = map[uint64]raft.Progress{
+		1: {Match: 100, RecentActive: true},
+		2: {Match: 60, RecentActive: true},
+		3: {Match: 40, RecentActive: false},
+		4: {Match: 20, RecentActive: true, State: raft.ProgressStateSnapshot},
+	}
+	// The active followers catching up via the log are waited for.
+	require.Equal(t, uint64(60), replicatingIndex(1, st, 90, 50))
+	// The lag is against the commit index of the leader, not the applied index.
+	require.Equal(t, uint64(90), replicatingIndex(1, st, 90, 30))
+	require.Equal(t, uint64(50), replicatingIndex(1, st, 50, 50))
+}
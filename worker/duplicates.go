@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+const (
+	// DuplicatesDedup applies a set nquad given several times in a mutation once. It's the
+	// default.
+	DuplicatesDedup = "dedup"
+	// DuplicatesError fails a mutation which sets the same nquad several times.
+	DuplicatesError = "error"
+	// DuplicatesAppend keeps every occurrence of a value set several times to a list predicate,
+	// by this mutation or the previous ones, each with its own facets. A deletion of the value
+	// deletes all of them. A uid list still holds each uid once, as the postings of an edge are
+	// found by the uid.
+	DuplicatesAppend = "append"
+)
+
+// ParseDuplicates parses how the duplicate nquads of a mutation are handled.
+func ParseDuplicates(mode string) (string, error) {
+	switch mode {
+	case "", DuplicatesDedup:
+		return DuplicatesDedup, nil
+	case DuplicatesError, DuplicatesAppend:
+		return mode, nil
+	default:
+		return "", errors.Errorf("Invalid value %q for duplicates, expected %s, %s or %s", mode,
+			DuplicatesDedup, DuplicatesError, DuplicatesAppend)
+	}
+}
+
+// CheckDuplicateEdges returns an error for the first edge set more than once by the mutation. The
+// facets aren't compared: an edge set twice with different facets is a duplicate, as only the
+// facets of one of them would be kept.
+func CheckDuplicateEdges(edges []*pb.DirectedEdge) error {
+	type edgeKey struct {
+		entity  uint64
+		attr    string
+		lang    string
+		valueID uint64
+		value   string
+	}
+	seen := make(map[edgeKey]struct{}, len(edges))
+	for _, edge := range edges {
+		if edge.Op != pb.DirectedEdge_SET {
+			continue
+		}
+		key := edgeKey{edge.Entity, edge.Attr, edge.Lang, edge.ValueId, string(edge.Value)}
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			continue
+		}
+		object := fmt.Sprintf("%q", edge.Value)
+		if edge.ValueType == pb.Posting_UID {
+			object = fmt.Sprintf("<%#x>", edge.ValueId)
+		}
+		return errors.Errorf("The mutation sets <%#x> <%s> %s more than once, which isn't "+
+			"allowed with duplicates=%s", edge.Entity, x.ParseAttr(edge.Attr), object,
+			DuplicatesError)
+	}
+	return nil
+}
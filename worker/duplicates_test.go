@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCheckDuplicateEdges(t *testing.T) {
+	friend := x.GalaxyAttr("friend")
+	name := x.GalaxyAttr("name")
+	edges := []*pb.DirectedEdge{
+		{Entity: 1, Attr: friend, ValueId: 2, ValueType: pb.Posting_UID},
+		{Entity: 1, Attr: friend, ValueId: 3, ValueType: pb.Posting_UID},
+		{Entity: 1, Attr: name, Value: []byte("a")},
+		{Entity: 1, Attr: name, Value: []byte("a"), Lang: "en"},
+		// Deleting an edge which is also set isn't a duplicate.
+		{Entity: 1, Attr: friend, ValueId: 2, ValueType: pb.Posting_UID,
+			Op: pb.DirectedEdge_DEL},
+	}
+	require.NoError(t, CheckDuplicateEdges(edges))
+
+	// The facets don't distinguish the edges.
+	dup := append(edges, &pb.DirectedEdge{Entity: 1, Attr: friend, ValueId: 3,
+		ValueType: pb.Posting_UID, Facets: []*api.Facet{{Key: "since"}}})
+	err := CheckDuplicateEdges(dup)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "<0x1> <friend> <0x3> more than once")
+
+	err = CheckDuplicateEdges(append(edges, &pb.DirectedEdge{Entity: 1, Attr: name,
+		Value: []byte("a")}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `<0x1> <name> "a" more than once`)
+}
+
+func TestParseDuplicates(t *testing.T) {
+	for in, out := range map[string]string{"": DuplicatesDedup, "dedup": DuplicatesDedup,
+		"error": DuplicatesError, "append": DuplicatesAppend} {
+		mode, err := ParseDuplicates(in)
+		require.NoError(t, err)
+		require.Equal(t, out, mode)
+	}
+	_, err := ParseDuplicates("keep")
+	require.Error(t, err)
+}
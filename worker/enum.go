@@ -0,0 +1,175 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CheckEnum returns an error if the value set by the edge isn't one of the values of the enum of
+// the predicate. As CheckPattern, it is called once the value has been converted to the type of
+// the schema.
+func CheckEnum(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	values := su.GetEnumValues()
+	if len(values) == 0 || edge.Op != pb.DirectedEdge_SET ||
+		types.TypeID(edge.ValueType) != types.StringID {
+		return nil
+	}
+	for _, v := range values {
+		if v == string(edge.Value) {
+			return nil
+		}
+	}
+	return errors.Errorf("Value %q for predicate %q isn't one of the values of its enum: %s",
+		edge.Value, x.ParseAttr(edge.Attr), quoteEnumValues(values))
+}
+
+func quoteEnumValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// checkEnumChange returns an error if the schema update makes the predicate an enum while some
+// of its values aren't in the enum, which happens when values are removed from an enum, or when
+// a string predicate having data becomes an enum. Adding values to an enum is always allowed.
+func checkEnumChange(s *pb.SchemaUpdate) error {
+	if len(s.EnumValues) == 0 {
+		return nil
+	}
+	allowed := make(map[string]struct{}, len(s.EnumValues))
+	for _, v := range s.EnumValues {
+		allowed[v] = struct{}{}
+	}
+	if old := schema.State().EnumValues(s.Predicate); len(old) > 0 {
+		removed := false
+		for _, v := range old {
+			if _, ok := allowed[v]; !ok {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			return nil
+		}
+	} else if _, err := schema.State().TypeOf(s.Predicate); err != nil {
+		// There is no data yet.
+		return nil
+	}
+
+	val, found, err := valueNotIn(s.Predicate, allowed, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	if found {
+		return errors.Errorf("Schema change not allowed: value %q of predicate %s isn't one of "+
+			"the values of its enum. Delete or update the nodes using it first.", val,
+			x.ParseAttr(s.Predicate))
+	}
+	return nil
+}
+
+// valueNotIn returns a value of the predicate at readTs which isn't in allowed, and whether
+// there is one. The values are compared as strings. Every data key of the predicate has to be
+// read, which is fine for a schema change.
+func valueNotIn(attr string, allowed map[string]struct{}, readTs uint64) (string, bool,
+	error) {
+
+	var val string
+	var found bool
+	err := iterateDataKeys(attr, readTs, func(_ uint64, pl *posting.List) error {
+		vals, err := pl.AllValues(readTs)
+		if err != nil {
+			return err
+		}
+		for _, v := range vals {
+			sv, err := types.Convert(v, types.StringID)
+			if err != nil {
+				return err
+			}
+			str := sv.Value.(string)
+			if _, ok := allowed[str]; !ok {
+				val, found = str, true
+				return posting.ErrStopIteration
+			}
+		}
+		return nil
+	})
+	return val, found, err
+}
+
+// iterateDataKeys calls fn with the uid and the posting list of every node which has data of the
+// predicate at readTs. It stops at the first error returned by fn, and returns it unless it is
+// posting.ErrStopIteration.
+func iterateDataKeys(attr string, readTs uint64,
+	fn func(uid uint64, pl *posting.List) error) error {
+
+	pk := x.ParsedKey{Attr: attr}
+	iterOpt := badger.DefaultIteratorOptions
+	iterOpt.AllVersions = true
+	iterOpt.Prefix = pk.DataPrefix()
+
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+	it := txn.NewIterator(iterOpt)
+	defer it.Close()
+
+	var prevKey []byte
+	for it.Rewind(); it.Valid(); {
+		item := it.Item()
+		if bytes.Equal(item.Key(), prevKey) {
+			it.Next()
+			continue
+		}
+		prevKey = append(prevKey[:0], item.Key()...)
+		if item.UserMeta()&posting.BitEmptyPosting > 0 {
+			it.Next()
+			continue
+		}
+		key := item.KeyCopy(nil)
+		parsed, err := x.Parse(key)
+		if err != nil {
+			return err
+		}
+		// ReadPostingList advances the iterator past the versions of the key.
+		pl, err := posting.ReadPostingList(key, it)
+		if err != nil {
+			return err
+		}
+		if err := fn(parsed.Uid, pl); err != nil {
+			if err == posting.ErrStopIteration {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -121,8 +121,9 @@ func generateConflictKeys(ctx context.Context, p *subMutation) map[uint64]struct
 		hasCount := schema.State().HasCount(ctx, edge.Attr)
 		tokenizers := schema.State().Tokenizer(ctx, edge.Attr)
 		isReverse := schema.State().IsReversed(ctx, edge.Attr)
+		isUndirected := schema.State().IsUndirected(ctx, edge.Attr)
 
-		if hasCount || isReverse {
+		if hasCount || isReverse || isUndirected {
 			keys[0] = struct{}{}
 		}
 
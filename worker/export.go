@@ -29,6 +29,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -45,6 +46,8 @@ import (
 	"github.com/dgraph-io/dgraph/ee/enc"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/types/facets"
 	"github.com/dgraph-io/dgraph/x"
@@ -92,6 +95,7 @@ var rdfTypeMap = map[types.TypeID]string{
 	types.GeoID:      "geo:geojson",
 	types.BinaryID:   "xs:base64Binary",
 	types.PasswordID: "xs:password",
+	types.DecimalID:  "xs:decimal",
 }
 
 // UIDs like 0x1 look weird but 64-bit ones like 0x0000000000000001 are too long.
@@ -100,6 +104,10 @@ var uidFmtStrJson = "\"%#x\""
 
 // valToStr converts a posting value to a string.
 func valToStr(v types.Val) (string, error) {
+	// Binary values are exported in base64, as for xs:base64Binary.
+	if v.Tid == types.BinaryID && x.Config.BinaryBase64 {
+		return types.EncodeBinary(v.Value.([]byte)), nil
+	}
 	v2, err := types.Convert(v, types.StringID)
 	if err != nil {
 		return "", errors.Wrapf(err, "while converting %v to string", v2.Value)
@@ -124,6 +132,43 @@ func facetToString(fct *api.Facet) (string, error) {
 	return v2.Value.(string), nil
 }
 
+// exportedFacet holds the values of a facet converted to strings for exports. List facets have
+// more than one value.
+type exportedFacet struct {
+	key  string
+	vals []string
+	list bool
+}
+
+// exportFacets converts the facets of a posting to strings. The values of a list facet are stored
+// as consecutive facets with the same key, and are grouped together. String values are escaped
+// if quote returns true for their type.
+func exportFacets(pfacets []*api.Facet, quote func(types.TypeID) bool) []exportedFacet {
+	var out []exportedFacet
+	for _, fct := range pfacets {
+		str, err := facetToString(fct)
+		if err != nil {
+			glog.Errorf("Ignoring error: %+v", err)
+			continue
+		}
+		tid, err := facets.TypeIDFor(fct)
+		if err != nil {
+			glog.Errorf("Error getting type id from facet %#v: %v", fct, err)
+			continue
+		}
+		if quote(tid) {
+			str = escapedString(str)
+		}
+		if len(out) > 0 && out[len(out)-1].key == fct.Key {
+			out[len(out)-1].vals = append(out[len(out)-1].vals, str)
+			continue
+		}
+		out = append(out, exportedFacet{key: fct.Key, vals: []string{str},
+			list: facets.IsList(fct)})
+	}
+	return out
+}
+
 // escapedString converts a string into an escaped string for exports.
 func escapedString(str string) string {
 	// We use the Marshal function in the JSON package for all export formats
@@ -144,26 +189,14 @@ func (e *exporter) toJSON() (*bpb.KVList, error) {
 	// Leaving it simple for now.
 
 	writeFacets := func(pfacets []*api.Facet) error {
-		for _, fct := range pfacets {
-			fmt.Fprintf(bp, `,"%s|%s":`, e.attr, fct.Key)
-
-			str, err := facetToString(fct)
-			if err != nil {
-				glog.Errorf("Ignoring error: %+v", err)
-				return nil
-			}
-
-			tid, err := facets.TypeIDFor(fct)
-			if err != nil {
-				glog.Errorf("Error getting type id from facet %#v: %v", fct, err)
-				continue
-			}
-
-			if !tid.IsNumber() {
-				str = escapedString(str)
+		quote := func(tid types.TypeID) bool { return !tid.IsNumber() }
+		for _, fct := range exportFacets(pfacets, quote) {
+			fmt.Fprintf(bp, `,"%s|%s":`, e.attr, fct.key)
+			if fct.list {
+				fmt.Fprintf(bp, "[%s]", strings.Join(fct.vals, ","))
+			} else {
+				fmt.Fprint(bp, fct.vals[0])
 			}
-
-			fmt.Fprint(bp, str)
 		}
 		return nil
 	}
@@ -253,30 +286,19 @@ func (e *exporter) toRDF() (*bpb.KVList, error) {
 		fmt.Fprintf(bp, " <%#x>", e.namespace)
 
 		// Facets.
-		if len(p.Facets) != 0 {
+		quote := func(tid types.TypeID) bool { return tid == types.StringID }
+		if fcts := exportFacets(p.Facets, quote); len(fcts) != 0 {
 			fmt.Fprint(bp, " (")
-			for i, fct := range p.Facets {
+			for i, fct := range fcts {
 				if i != 0 {
 					fmt.Fprint(bp, ",")
 				}
-				fmt.Fprint(bp, fct.Key+"=")
-
-				str, err := facetToString(fct)
-				if err != nil {
-					glog.Errorf("Ignoring error: %+v", err)
-					return nil
-				}
-
-				tid, err := facets.TypeIDFor(fct)
-				if err != nil {
-					glog.Errorf("Error getting type id from facet %#v: %v", fct, err)
-					continue
-				}
-
-				if tid == types.StringID {
-					str = escapedString(str)
+				fmt.Fprint(bp, fct.key+"=")
+				if fct.list {
+					fmt.Fprintf(bp, "[%s]", strings.Join(fct.vals, ","))
+				} else {
+					fmt.Fprint(bp, fct.vals[0])
 				}
-				fmt.Fprint(bp, str)
 			}
 			fmt.Fprint(bp, ")")
 		}
@@ -305,7 +327,20 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 	if update.GetList() {
 		x.Check2(buf.WriteRune('['))
 	}
-	x.Check2(buf.WriteString(types.TypeID(update.GetValueType()).Name()))
+	if values := update.GetEnumValues(); len(values) > 0 {
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, strconv.Quote(v))
+		}
+		x.Check2(buf.WriteString("enum("))
+		x.Check2(buf.WriteString(strings.Join(quoted, ",")))
+		x.Check2(buf.WriteRune(')'))
+	} else {
+		x.Check2(buf.WriteString(types.TypeID(update.GetValueType()).Name()))
+	}
+	if update.GetDecimalScale() > 0 {
+		x.Check2(buf.WriteString(fmt.Sprintf("(%d)", update.GetDecimalScale())))
+	}
 	if update.GetList() {
 		x.Check2(buf.WriteRune(']'))
 	}
@@ -314,7 +349,14 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 		x.Check2(buf.WriteString(" @reverse"))
 	case update.GetDirective() == pb.SchemaUpdate_INDEX && len(update.GetTokenizer()) > 0:
 		x.Check2(buf.WriteString(" @index("))
-		x.Check2(buf.WriteString(strings.Join(update.GetTokenizer(), ",")))
+		tokenizers := make([]string, 0, len(update.GetTokenizer()))
+		for _, name := range update.GetTokenizer() {
+			if expr, base, ok := tok.ParseExprTokenizerName(name); ok {
+				name = fmt.Sprintf("expr: %q, type: %s", expr, base)
+			}
+			tokenizers = append(tokenizers, name)
+		}
+		x.Check2(buf.WriteString(strings.Join(tokenizers, ",")))
 		x.Check2(buf.WriteRune(')'))
 	}
 	if update.GetCount() {
@@ -326,6 +368,46 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 	if update.GetUpsert() {
 		x.Check2(buf.WriteString(" @upsert"))
 	}
+	if update.GetKey() {
+		x.Check2(buf.WriteString(" @key"))
+	}
+	if update.GetUndirected() {
+		x.Check2(buf.WriteString(" @undirected"))
+	}
+	if len(update.GetDerived()) > 0 {
+		terms := make([]string, 0, len(update.GetDerived()))
+		for _, term := range update.GetDerived() {
+			if !schema.IsDerivedLiteral(term) {
+				term = "<" + term + ">"
+			}
+			terms = append(terms, term)
+		}
+		x.Check2(buf.WriteString(" @derived("))
+		x.Check2(buf.WriteString(strings.Join(terms, " + ")))
+		x.Check2(buf.WriteRune(')'))
+	}
+	if update.GetPattern() != "" {
+		x.Check2(buf.WriteString(" @pattern("))
+		x.Check2(buf.WriteString(strconv.Quote(update.GetPattern())))
+		x.Check2(buf.WriteRune(')'))
+	}
+	if len(update.GetAliases()) > 0 {
+		aliases := make([]string, 0, len(update.GetAliases()))
+		for _, alias := range update.GetAliases() {
+			aliases = append(aliases, "<"+alias+">")
+		}
+		x.Check2(buf.WriteString(" @alias("))
+		x.Check2(buf.WriteString(strings.Join(aliases, ", ")))
+		x.Check2(buf.WriteRune(')'))
+	}
+	if update.GetCardinality() != "" {
+		x.Check2(buf.WriteString(" @cardinality("))
+		x.Check2(buf.WriteString(update.GetCardinality()))
+		if update.GetCardinalityReject() {
+			x.Check2(buf.WriteString(", reject"))
+		}
+		x.Check2(buf.WriteRune(')'))
+	}
 	x.Check2(buf.WriteString(" . \n"))
 	//TODO(Naman): We don't need the version anymore.
 	return &bpb.KV{
@@ -334,10 +416,33 @@ func toSchema(attr string, update *pb.SchemaUpdate) *bpb.KV {
 	}
 }
 
+// SchemaString returns the schema of a predicate as it's written in an export, which can be
+// applied with an alter.
+func SchemaString(update *pb.SchemaUpdate) string {
+	return string(toSchema(update.Predicate, update).Value)
+}
+
+// TypeString returns the definition of a type as it's written in an export, which can be applied
+// with an alter.
+func TypeString(update *pb.TypeUpdate) string {
+	return string(toType(update.TypeName, *update).Value)
+}
+
 func toType(attr string, update pb.TypeUpdate) *bpb.KV {
 	var buf bytes.Buffer
 	ns, attr := x.ParseNamespaceAttr(attr)
-	x.Check2(buf.WriteString(fmt.Sprintf("[%#x] type <%s> {\n", ns, attr)))
+	x.Check2(buf.WriteString(fmt.Sprintf("[%#x] type <%s> ", ns, attr)))
+	if update.SoftDelete != "" {
+		x.Check2(buf.WriteString(fmt.Sprintf("@softDelete(on: <%s>) ", update.SoftDelete)))
+	}
+	if update.CascadeDelete != "" {
+		x.Check2(buf.WriteString(fmt.Sprintf("@cascadeDelete(via: <%s>", update.CascadeDelete)))
+		if update.CascadeDeleteForce {
+			x.Check2(buf.WriteString(", force: true"))
+		}
+		x.Check2(buf.WriteString(") "))
+	}
+	x.Check2(buf.WriteString("{\n"))
 	for _, field := range update.Fields {
 		x.Check2(buf.WriteString(fieldToString(field)))
 	}
@@ -597,6 +702,9 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 		return nil, err
 	}
 
+	// Bundles leave out the ACL data, unless asked to include it.
+	skipAcl := in.Bundle && !in.IncludeAcl
+
 	// This stream exports only the data and the graphQL schema.
 	stream := db.NewStreamAt(in.ReadTs)
 	stream.Prefix = []byte{x.DefaultPrefix}
@@ -628,6 +736,13 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 		if pk.Attr == "_predicate_" {
 			return false
 		}
+		if skipAcl && x.IsAclPredicate(x.ParseAttr(pk.Attr)) {
+			return false
+		}
+		// The values of derived predicates are computed again when the data is loaded back.
+		if _, ok := schema.State().Derivation(pk.Attr); ok {
+			return false
+		}
 
 		if !skipZero {
 			if servesTablet, err := groups().ServesTablet(pk.Attr); err != nil || !servesTablet {
@@ -657,6 +772,8 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 			// Ignore this predicate.
 		case e.attr == "dgraph.graphql.p_query":
 			// Ignore this predicate.
+		case strings.HasPrefix(e.attr, "dgraph.uidset."):
+			// The uid sets point to the uids of this cluster, which aren't kept by an import.
 		case pk.IsData() && e.attr == "dgraph.graphql.schema":
 			// Export the graphql schema.
 			pl, err := posting.ReadPostingList(key, itr)
@@ -712,7 +829,7 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 			}
 
 			// The GraphQL layer will create a node of type "dgraph.graphql". That entry
-			// should not be exported.
+			// should not be exported, nor the type of the uid sets.
 			if e.attr == "dgraph.type" {
 				vals, err := e.pl.AllValues(in.ReadTs)
 				if err != nil {
@@ -723,7 +840,10 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 					if !ok {
 						return nil, errors.Errorf("cannot read value of dgraph.type entry")
 					}
-					if string(val) == "dgraph.graphql" {
+					if string(val) == "dgraph.graphql" || string(val) == "dgraph.uidset" {
+						return nil, nil
+					}
+					if skipAcl && isAclType(string(val)) {
 						return nil, nil
 					}
 				}
@@ -821,6 +941,10 @@ func exportInternal(ctx context.Context, in *pb.ExportRequest, db *badger.DB,
 				return err
 			}
 
+			if name := x.ParseAttr(pk.Attr); skipAcl && (x.IsAclPredicate(name) || isAclType(name)) {
+				continue
+			}
+
 			var kv *bpb.KV
 			switch prefix {
 			case x.ByteSchema:
@@ -927,11 +1051,12 @@ func handleExportOverNetwork(ctx context.Context, in *pb.ExportRequest) (Exporte
 
 	glog.Infof("Sending export request to group: %d, addr: %s\n", in.GroupId, pl.Addr)
 	c := pb.NewWorkerClient(pl.Get())
-	_, err := c.Export(ctx, in)
+	resp, err := c.Export(ctx, in)
 	if err != nil {
 		glog.Errorf("Export error received from group: %d. Error: %v\n", in.GroupId, err)
+		return nil, err
 	}
-	return nil, err
+	return resp.GetFiles(), nil
 }
 
 // ExportOverNetwork sends export requests to all the known groups.
@@ -941,6 +1066,15 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 		glog.Errorf("Rejecting export request due to health check error: %v\n", err)
 		return nil, err
 	}
+	if input.Bundle {
+		if strings.HasPrefix(input.Destination, "minio://") ||
+			strings.HasPrefix(input.Destination, "s3://") {
+			return nil, errors.Errorf("Bundles can only be exported to a local directory")
+		}
+		if input.Namespace == math.MaxUint64 {
+			return nil, errors.Errorf("Bundles can only be exported for a single namespace")
+		}
+	}
 	// Get ReadTs from zero and wait for stream to catch up.
 	ts, err := Timestamps(ctx, &pb.Num{ReadOnly: true})
 	if err != nil {
@@ -973,6 +1107,9 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 				SecretKey:    input.SecretKey,
 				SessionToken: input.SessionToken,
 				Anonymous:    input.Anonymous,
+
+				Bundle:     input.Bundle,
+				IncludeAcl: input.IncludeAcl,
 			}
 			files, err := handleExportOverNetwork(ctx, req)
 			ch <- filesAndError{files, err}
@@ -990,6 +1127,16 @@ func ExportOverNetwork(ctx context.Context, input *pb.ExportRequest) (ExportedFi
 		allFiles = append(allFiles, pair.ExportedFiles...)
 	}
 
+	if input.Bundle {
+		name, err := writeBundle(input, readTs, allFiles)
+		if err != nil {
+			rerr := errors.Wrapf(err, "Export failed at readTs %d", readTs)
+			glog.Errorln(rerr)
+			return nil, rerr
+		}
+		allFiles = ExportedFiles{name}
+	}
+
 	glog.Infof("Export at readTs %d DONE", readTs)
 	return allFiles, nil
 }
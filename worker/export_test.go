@@ -17,6 +17,7 @@
 package worker
 
 import (
+	"archive/tar"
 	"bufio"
 	"bytes"
 	"compress/gzip"
@@ -28,6 +29,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -400,12 +402,324 @@ func TestExportJson(t *testing.T) {
 	checkExportGqlSchema(t, gqlSchema)
 }
 
+func TestExportBundle(t *testing.T) {
+	initTestExport(t, `name: string @index(exact) .`)
+
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	x.WorkerConfig.ExportPath = bdir
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	req := &pb.ExportRequest{ReadTs: readTs, GroupId: 1, Format: "rdf",
+		Namespace: x.GalaxyNamespace, Bundle: true}
+	files, err := export(context.Background(), req)
+	require.NoError(t, err)
+	name, err := writeBundle(req, readTs, files)
+	require.NoError(t, err)
+
+	// Only the bundle is left in the export directory.
+	entries, err := ioutil.ReadDir(bdir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, name, entries[0].Name())
+
+	b, err := OpenBundle(filepath.Join(bdir, name))
+	require.NoError(t, err)
+	defer b.Close()
+	require.Equal(t, bundleVersion, b.Manifest.Version)
+	require.Equal(t, readTs, b.Manifest.ReadTs)
+	require.Len(t, b.Manifest.Files, 3)
+
+	sch, err := b.Schema()
+	require.NoError(t, err)
+	result, err := schema.Parse(sch)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result.Preds))
+	require.Equal(t, 1, len(result.Types))
+
+	gqlSch, err := b.GraphQLSchema()
+	require.NoError(t, err)
+	require.Equal(t, gqlSchema, gqlSch)
+}
+
+func TestOpenBundleErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeManifest := func(m BundleManifest) string {
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		path := filepath.Join(dir, "bundle.tar")
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		tw := tar.NewWriter(f)
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: bundleManifest, Mode: 0600,
+			Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		require.NoError(t, f.Close())
+		return path
+	}
+
+	tests := []struct {
+		manifest BundleManifest
+		err      string
+	}{
+		{BundleManifest{Version: bundleVersion + 1, Format: "rdf"}, "Unsupported bundle version"},
+		{BundleManifest{Version: bundleVersion, Format: "rdf", Encrypted: true}, "encrypted"},
+		{BundleManifest{Version: bundleVersion, Format: "rdf", Namespace: math.MaxUint64},
+			"all the namespaces"},
+		{BundleManifest{Version: bundleVersion, Format: "rdf", Files: []string{"g01.rdf.gz"}},
+			"missing"},
+	}
+	for _, tc := range tests {
+		_, err := OpenBundle(writeManifest(tc.manifest))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
+
+// fakeBundleUids leases uids from 1000 for the imported nodes, instead of asking Zero.
+func fakeBundleUids() func() {
+	next := uint64(1000)
+	assignBundleUids = func(_ context.Context, num *pb.Num) (*pb.AssignedIds, error) {
+		ids := &pb.AssignedIds{StartId: next, EndId: next + num.Val - 1}
+		next += num.Val
+		return ids, nil
+	}
+	return func() { assignBundleUids = AssignUidsOverNetwork }
+}
+
+func TestBundlePath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	x.WorkerConfig.ExportPath = dir
+
+	path, err := BundlePath("dgraph.r10.bundle.tar")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "dgraph.r10.bundle.tar"), path)
+	path, err = BundlePath(filepath.Join(dir, "sub", "dgraph.r10.bundle.tar"))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "sub", "dgraph.r10.bundle.tar"), path)
+
+	for _, file := range []string{"", ".", "../dgraph.r10.bundle.tar", "sub/../../other",
+		"/etc/passwd", dir + "-other/dgraph.r10.bundle.tar"} {
+		_, err := BundlePath(file)
+		require.Error(t, err, file)
+		require.Contains(t, err.Error(), "must be in the export directory", file)
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	initTestExport(t, `name: string @index(exact) .`)
+	defer fakeBundleUids()()
+
+	bdir, err := ioutil.TempDir("", "export")
+	require.NoError(t, err)
+	defer os.RemoveAll(bdir)
+
+	x.WorkerConfig.ExportPath = bdir
+	readTs := timestamp()
+	posting.Oracle().ProcessDelta(&pb.OracleDelta{MaxAssigned: readTs})
+	req := &pb.ExportRequest{ReadTs: readTs, GroupId: 1, Format: "rdf",
+		Namespace: x.GalaxyNamespace, Bundle: true}
+	files, err := export(context.Background(), req)
+	require.NoError(t, err)
+	name, err := writeBundle(req, readTs, files)
+	require.NoError(t, err)
+
+	path, err := BundlePath(name)
+	require.NoError(t, err)
+	b, err := OpenBundle(path)
+	require.NoError(t, err)
+	defer b.Close()
+
+	var nqs []*api.NQuad
+	require.NoError(t, b.LoadData(context.Background(), false, nil,
+		func(batch []*api.NQuad) error {
+			nqs = append(nqs, batch...)
+			return nil
+		}))
+
+	names := make(map[string]string)
+	var friends []*api.NQuad
+	for _, nq := range nqs {
+		// All the nodes get new uids.
+		uid, err := strconv.ParseUint(nq.Subject, 0, 64)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, uid, uint64(1000))
+		switch nq.Predicate {
+		case "name":
+			names[nq.ObjectValue.GetDefaultVal()] = nq.Subject
+		case "friend":
+			friends = append(friends, nq)
+		}
+	}
+	require.Len(t, friends, 4)
+	withFacets := 0
+	for _, nq := range friends {
+		require.Equal(t, friends[0].ObjectId, nq.ObjectId)
+		if len(nq.Facets) > 0 {
+			require.Len(t, nq.Facets, 5)
+			withFacets++
+		}
+	}
+	require.Equal(t, 1, withFacets)
+	require.Equal(t, friends[0].ObjectId, names[""])
+	require.Contains(t, names, "First Line\nSecondLine")
+	require.NotContains(t, names, "node_to_delete")
+	require.NotContains(t, names, "ns2")
+}
+
+// writeTestBundle writes a bundle with the given RDF data to dir, and returns its path.
+func writeTestBundle(t *testing.T, dir, rdf string) string {
+	var data bytes.Buffer
+	gw := gzip.NewWriter(&data)
+	_, err := gw.Write([]byte(rdf))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	mdata, err := json.Marshal(BundleManifest{Version: bundleVersion, Format: "rdf",
+		IncludesAcl: true, Files: []string{"g01.rdf.gz"}})
+	require.NoError(t, err)
+	path := filepath.Join(dir, "bundle.tar")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	tw := tar.NewWriter(f)
+	for _, entry := range []struct {
+		name string
+		data []byte
+	}{{bundleManifest, mdata}, {"g01.rdf.gz", data.Bytes()}} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: entry.name, Mode: 0600,
+			Size: int64(len(entry.data))}))
+		_, err = tw.Write(entry.data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+	return path
+}
+
+func TestBundleKeepsImporterAcl(t *testing.T) {
+	defer fakeBundleUids()()
+	dir, err := ioutil.TempDir("", "bundle")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := OpenBundle(writeTestBundle(t, dir, `
+		<0x1> <dgraph.xid> "groot" .
+		<0x1> <dgraph.type> "dgraph.type.User" .
+		<0x1> <dgraph.password> "secret" .
+		<0x1> <dgraph.user.group> <0x2> .
+		<0x2> <dgraph.xid> "guardians" .
+		<0x2> <dgraph.type> "dgraph.type.Group" .
+		<0x2> <dgraph.acl.rule> <0x3> .
+		<0x3> <dgraph.type> "dgraph.type.Rule" .
+		<0x3> <dgraph.rule.predicate> "name" .
+		<0x4> <dgraph.xid> "alice" .
+		<0x4> <dgraph.type> "dgraph.type.User" .
+		<0x4> <dgraph.user.group> <0x2> .
+		<0x4> <dgraph.user.group> <0x5> .
+		<0x5> <dgraph.xid> "dev" .
+		<0x5> <dgraph.type> "dgraph.type.Group" .
+		<0x6> <name> "a" .
+	`))
+	require.NoError(t, err)
+	defer b.Close()
+
+	load := func(includeAcl bool, keep map[AclNode]uint64) []*api.NQuad {
+		var nqs []*api.NQuad
+		require.NoError(t, b.LoadData(context.Background(), includeAcl, keep,
+			func(batch []*api.NQuad) error {
+				nqs = append(nqs, batch...)
+				return nil
+			}))
+		return nqs
+	}
+
+	// Without the ACL data, only the data is loaded.
+	nqs := load(false, nil)
+	require.Len(t, nqs, 1)
+	require.Equal(t, "name", nqs[0].Predicate)
+
+	// The user running the import and its group are kept, the edges to the group of the bundle
+	// point to the kept group instead.
+	keep := map[AclNode]uint64{
+		{Type: "dgraph.type.User", Xid: "groot"}:      0x100,
+		{Type: "dgraph.type.Group", Xid: "guardians"}: 0x200,
+	}
+	nqs = load(true, keep)
+	var groups []string
+	for _, nq := range nqs {
+		require.NotEqual(t, "0x100", nq.Subject)
+		require.NotEqual(t, "0x200", nq.Subject)
+		require.NotEqual(t, "dgraph.rule.predicate", nq.Predicate)
+		require.NotEqual(t, "dgraph.password", nq.Predicate)
+		switch xid := nq.ObjectValue.GetDefaultVal(); {
+		case nq.Predicate == "dgraph.xid":
+			require.Contains(t, []string{"alice", "dev"}, xid)
+		case nq.Predicate == "dgraph.user.group":
+			groups = append(groups, nq.ObjectId)
+		}
+	}
+	require.Len(t, groups, 2)
+	require.Contains(t, groups, "0x200")
+	require.Len(t, nqs, 7)
+}
+
+func TestBundleRelease(t *testing.T) {
+	major, minor, ok := parseRelease("v21.03.2")
+	require.True(t, ok)
+	require.Equal(t, 21, major)
+	require.Equal(t, 3, minor)
+	_, _, ok = parseRelease("dev")
+	require.False(t, ok)
+}
+
 const exportRequest = `mutation export($format: String!) {
 	export(input: {format: $format}) {
 		response { code }
 	}
 }`
 
+func TestExportListFacets(t *testing.T) {
+	facetsFor := func(key string, list bool, vals ...string) []*api.Facet {
+		var fcts []*api.Facet
+		for _, val := range vals {
+			fct, err := facets.FacetFor(key, val)
+			require.NoError(t, err)
+			fcts = append(fcts, fct)
+		}
+		if list {
+			facets.MarkList(fcts)
+		}
+		return fcts
+	}
+	fcts := append(facetsFor("tags", true, `"work"`, `"college"`),
+		facetsFor("since", false, "2006")...)
+	fcts = append(fcts, facetsFor("ids", true, "7")...)
+	require.NoError(t, facets.SortAndValidate(fcts))
+
+	quote := func(tid types.TypeID) bool { return tid == types.StringID }
+	require.Equal(t, []exportedFacet{
+		{key: "ids", vals: []string{"7"}, list: true},
+		{key: "since", vals: []string{"2006"}},
+		{key: "tags", vals: []string{`"work"`, `"college"`}, list: true},
+	}, exportFacets(fcts, quote))
+
+	// The exported list can be parsed again.
+	nq, err := chunker.ParseRDF(
+		`<0x1> <friend> <0x2> (ids=[7],since=2006,tags=["work","college"]) .`, &lex.Lexer{})
+	require.NoError(t, err)
+	require.NoError(t, facets.SortAndValidate(nq.Facets))
+	require.Equal(t, fcts, nq.Facets)
+}
+
 func TestExportFormat(t *testing.T) {
 	tmpdir, err := ioutil.TempDir("", "export")
 	require.NoError(t, err)
@@ -568,6 +882,98 @@ func TestToSchema(t *testing.T) {
 			},
 			expected: "[0x0] <data.base>:string @lang . \n",
 		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("fullName"),
+				schema: pb.SchemaUpdate{
+					Predicate: x.GalaxyAttr("fullName"),
+					ValueType: pb.Posting_STRING,
+					Directive: pb.SchemaUpdate_INDEX,
+					Tokenizer: []string{"exact"},
+					Derived:   []string{"firstName", `" "`, "lastName"},
+				},
+			},
+			expected: "[0x0] <fullName>:string @index(exact) " +
+				"@derived(<firstName> + \" \" + <lastName>) . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("email"),
+				schema: pb.SchemaUpdate{
+					Predicate: x.GalaxyAttr("email"),
+					ValueType: pb.Posting_STRING,
+					Pattern:   `^[^@]+@[^@"]+$`,
+				},
+			},
+			expected: "[0x0] <email>:string @pattern(\"^[^@]+@[^@\\\"]+$\") . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("email"),
+				schema: pb.SchemaUpdate{
+					Predicate: x.GalaxyAttr("email"),
+					ValueType: pb.Posting_STRING,
+					Directive: pb.SchemaUpdate_INDEX,
+					Tokenizer: []string{"exact", "expr:lower(email):hash"},
+					Upsert:    true,
+				},
+			},
+			expected: "[0x0] <email>:string @index(exact,expr: \"lower(email)\", type: hash) " +
+				"@upsert . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("fullName"),
+				schema: pb.SchemaUpdate{
+					Predicate: x.GalaxyAttr("fullName"),
+					ValueType: pb.Posting_STRING,
+					Directive: pb.SchemaUpdate_INDEX,
+					Tokenizer: []string{"term"},
+					Aliases:   []string{"name", "full_name"},
+				},
+			},
+			expected: "[0x0] <fullName>:string @index(term) @alias(<name>, <full_name>) . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("spouse"),
+				schema: pb.SchemaUpdate{
+					Predicate:         x.GalaxyAttr("spouse"),
+					ValueType:         pb.Posting_UID,
+					Directive:         pb.SchemaUpdate_REVERSE,
+					Cardinality:       "one-to-one",
+					CardinalityReject: true,
+				},
+			},
+			expected: "[0x0] <spouse>:uid @reverse @cardinality(one-to-one, reject) . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("email"),
+				schema: pb.SchemaUpdate{
+					Predicate: x.GalaxyAttr("email"),
+					ValueType: pb.Posting_STRING,
+					Directive: pb.SchemaUpdate_INDEX,
+					Tokenizer: []string{"exact"},
+					Upsert:    true,
+					Key:       true,
+				},
+			},
+			expected: "[0x0] <email>:string @index(exact) @upsert @key . \n",
+		},
+		{
+			skv: &skv{
+				attr: x.GalaxyAttr("status"),
+				schema: pb.SchemaUpdate{
+					Predicate:  x.GalaxyAttr("status"),
+					ValueType:  pb.Posting_STRING,
+					Directive:  pb.SchemaUpdate_INDEX,
+					Tokenizer:  []string{"exact"},
+					EnumValues: []string{"active", "banned"},
+				},
+			},
+			expected: "[0x0] <status>:enum(\"active\",\"banned\") @index(exact) . \n",
+		},
 	}
 	for _, testCase := range testCases {
 		kv := toSchema(testCase.skv.attr, &testCase.skv.schema)
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/raftwal"
 	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
 	"github.com/golang/glog"
@@ -109,6 +111,7 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 		GroupId: x.WorkerConfig.ProposedGroupId,
 		Addr:    x.WorkerConfig.MyAddr,
 		Learner: x.WorkerConfig.Raft.GetBool("learner"),
+		Region:  x.WorkerConfig.Region,
 	}
 	if m.GroupId > 0 {
 		m.ForceGroupId = true
@@ -161,6 +164,7 @@ func StartRaftNodes(walStore *raftwal.DiskStorage, bindall bool) {
 	gr.informZeroAboutTablets()
 	gr.applyInitialSchema()
 	gr.applyInitialTypes()
+	go gr.rebuildOutdatedIndexes()
 
 	x.UpdateHealthStatus(true)
 	glog.Infof("Server is ready")
@@ -206,6 +210,67 @@ func (g *groupi) applyInitialTypes() {
 	}
 }
 
+// rebuildOutdatedIndexes rebuilds the indexes which were built by a different version of a
+// plugin tokenizer than the one loaded, by proposing the schema of their predicates again with
+// the loaded versions. It retries until the rebuild is proposed, e.g. once the group has a leader
+// or once the maintenance window allows the reindex.
+func (g *groupi) rebuildOutdatedIndexes() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		updates := outdatedIndexes(g.Ctx())
+		if len(updates) == 0 {
+			return
+		}
+		err := CheckHeavyOp("Reindex")
+		if err == nil {
+			_, err = MutateOverNetwork(g.Ctx(), &pb.Mutations{
+				StartTs: State.GetTimestamp(false),
+				Schema:  updates,
+			})
+		}
+		if err == nil {
+			glog.Infof("Rebuilding the indexes of %d predicates with the loaded tokenizer "+
+				"versions", len(updates))
+			return
+		}
+		glog.Errorf("Error while rebuilding the indexes built with other tokenizer versions, "+
+			"will retry: %v", err)
+
+		select {
+		case <-ticker.C:
+		case <-g.closer.HasBeenClosed():
+			return
+		}
+	}
+}
+
+// outdatedIndexes returns the schema of the predicates whose index was built by a different
+// version of a plugin tokenizer than the one loaded, updated with the loaded versions.
+func outdatedIndexes(ctx context.Context) []*pb.SchemaUpdate {
+	preds := schema.State().Predicates()
+	sort.Strings(preds)
+	var updates []*pb.SchemaUpdate
+	for _, pred := range preds {
+		su, ok := schema.State().Get(ctx, pred)
+		if !ok || len(su.Tokenizer) == 0 {
+			continue
+		}
+		// Both lists are in the order of the tokenizers of the predicate.
+		loaded := tok.PluginVersions(su.Tokenizer)
+		if strings.Join(loaded, ",") == strings.Join(su.TokenizerVersions, ",") {
+			continue
+		}
+		_, attr := x.ParseNamespaceAttr(pred)
+		glog.Warningf("The index of predicate %s was built with tokenizer versions %v, but "+
+			"versions %v are loaded. Rebuilding it.", attr, su.TokenizerVersions, loaded)
+		su.TokenizerVersions = loaded
+		updates = append(updates, &su)
+	}
+	return updates
+}
+
 func (g *groupi) applyInitialSchema() {
 	if g.groupId() != 1 {
 		return
@@ -587,14 +652,21 @@ func (g *groupi) AnyTwoServers(gid uint32) []string {
 	if !has {
 		return []string{}
 	}
-	var res []string
+	// The members in the same region as this alpha come first, so that the requests don't
+	// leave the region unless the group isn't served in it, or as backup requests.
+	var local, remote []string
 	for _, m := range group.Members {
 		// map iteration gives us members in no particular order.
-		res = append(res, m.Addr)
-		if len(res) >= 2 {
-			break
+		if x.WorkerConfig.Region != "" && m.Region == x.WorkerConfig.Region {
+			local = append(local, m.Addr)
+		} else {
+			remote = append(remote, m.Addr)
 		}
 	}
+	res := append(local, remote...)
+	if len(res) > 2 {
+		res = res[:2]
+	}
 	return res
 }
 
@@ -757,6 +829,7 @@ func (g *groupi) doSendMembership(tablets map[string]*pb.Tablet) error {
 		Addr:       x.WorkerConfig.MyAddr,
 		Leader:     leader,
 		LastUpdate: uint64(time.Now().Unix()),
+		Region:     x.WorkerConfig.Region,
 	}
 	group := &pb.Group{
 		Members: make(map[uint64]*pb.Member),
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestAnyTwoServersPrefersRegion(t *testing.T) {
+	defer func(region string) { x.WorkerConfig.Region = region }(x.WorkerConfig.Region)
+
+	g := &groupi{state: &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Members: map[uint64]*pb.Member{
+			1: {Id: 1, Addr: "east1:7080", Region: "us-east"},
+			2: {Id: 2, Addr: "west1:7080", Region: "us-west"},
+			3: {Id: 3, Addr: "west2:7080", Region: "us-west"},
+		}},
+		2: {Members: map[uint64]*pb.Member{
+			4: {Id: 4, Addr: "west3:7080", Region: "us-west"},
+		}},
+	}}}
+
+	x.WorkerConfig.Region = "us-east"
+	for i := 0; i < 10; i++ {
+		addrs := g.AnyTwoServers(1)
+		require.Len(t, addrs, 2)
+		// The replica of the same region comes first, one of the others is the backup.
+		require.Equal(t, "east1:7080", addrs[0])
+		require.Contains(t, []string{"west1:7080", "west2:7080"}, addrs[1])
+	}
+	// Without a replica in the region, the requests go to the other regions.
+	require.Equal(t, []string{"west3:7080"}, g.AnyTwoServers(2))
+	require.Empty(t, g.AnyTwoServers(3))
+
+	x.WorkerConfig.Region = "us-west"
+	for i := 0; i < 10; i++ {
+		require.ElementsMatch(t, []string{"west1:7080", "west2:7080"}, g.AnyTwoServers(1))
+	}
+}
+
+type skuTokenizer struct{}
+
+func (skuTokenizer) Name() string     { return "sku" }
+func (skuTokenizer) Type() string     { return "string" }
+func (skuTokenizer) Identifier() byte { return 0xfe }
+func (skuTokenizer) Version() string  { return "2" }
+func (skuTokenizer) Tokens(v interface{}) ([]string, error) {
+	return []string{v.(string)}, nil
+}
+
+func TestOutdatedIndexes(t *testing.T) {
+	if !tok.IsCustomTokenizer("sku") {
+		require.NoError(t, tok.RegisterPlugin(skuTokenizer{}))
+	}
+	require.NoError(t, schema.ParseBytes([]byte(`
+		sku: string @index(exact, sku) .
+		sku_name: string @index(exact) .
+	`), 1))
+	ctx := context.Background()
+	require.Empty(t, outdatedIndexes(ctx))
+
+	// The index of sku was built by another version of the tokenizer.
+	sku := x.GalaxyAttr("sku")
+	su, ok := schema.State().Get(ctx, sku)
+	require.True(t, ok)
+	su.TokenizerVersions = []string{"sku=1"}
+	schema.State().Set(sku, &su)
+
+	updates := outdatedIndexes(ctx)
+	require.Len(t, updates, 1)
+	require.Equal(t, sku, updates[0].Predicate)
+	require.Equal(t, []string{"exact", "sku"}, updates[0].Tokenizer)
+	require.Equal(t, []string{"sku=2"}, updates[0].TokenizerVersions)
+	// The schema of the state is left as it is until the update is applied.
+	su, _ = schema.State().Get(ctx, sku)
+	require.Equal(t, []string{"sku=1"}, su.TokenizerVersions)
+}
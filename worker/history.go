@@ -0,0 +1,186 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/pkg/errors"
+	otrace "go.opencensus.io/trace"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// GetHistoryOverNetwork returns the values a predicate of a node had after each commit which
+// changed them, up to the read ts of the request. The history is read from the versions kept by
+// Badger, so it only goes back to the oldest version which hasn't been garbage collected yet.
+func GetHistoryOverNetwork(ctx context.Context,
+	req *pb.HistoryRequest) (*pb.HistoryResult, error) {
+
+	gid, err := groups().BelongsToReadOnly(req.Attr, req.ReadTs)
+	switch {
+	case err != nil:
+		return nil, err
+	case gid == 0:
+		// The predicate doesn't have any data.
+		return &pb.HistoryResult{}, nil
+	}
+
+	if groups().ServesGroup(gid) {
+		return getHistory(ctx, req)
+	}
+	result, err := processWithBackupRequest(ctx, gid,
+		func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+			return c.History(ctx, req)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*pb.HistoryResult), nil
+}
+
+// History returns the history of a predicate of a node served by this group.
+func (w *grpcWorker) History(ctx context.Context,
+	req *pb.HistoryRequest) (*pb.HistoryResult, error) {
+
+	ctx, span := otrace.StartSpan(ctx, "worker.History")
+	defer span.End()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err := x.HealthCheck(); err != nil {
+		return nil, err
+	}
+	return getHistory(ctx, req)
+}
+
+func getHistory(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResult, error) {
+	if err := posting.Oracle().WaitForTs(ctx, req.ReadTs); err != nil {
+		return nil, err
+	}
+	gid, err := groups().BelongsToReadOnly(req.Attr, req.ReadTs)
+	switch {
+	case err != nil:
+		return nil, err
+	case gid == 0:
+		return &pb.HistoryResult{}, nil
+	case gid != groups().groupId():
+		return nil, errUnservedTablet
+	}
+
+	su, _ := schema.State().Get(ctx, req.Attr)
+	typ := types.TypeID(su.ValueType)
+	if typ == types.PasswordID {
+		return nil, errors.Errorf("The history of password predicate %s can't be queried",
+			x.ParseAttr(req.Attr))
+	}
+
+	key := x.DataKey(req.Attr, req.Uid)
+	versions, err := keyVersions(key, req.ReadTs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.HistoryResult{List: su.List}
+	var last []byte
+	// The versions are from the newest to the oldest.
+	for i := len(versions) - 1; i >= 0; i-- {
+		postings, err := postingsAt(key, versions[i], typ == types.UidID)
+		if err != nil {
+			return nil, err
+		}
+		// A commit which didn't change the values, e.g. deleting a value which wasn't there,
+		// isn't part of the history.
+		pl := &pb.PostingList{Postings: postings}
+		data, err := pl.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		if len(res.Versions) > 0 && bytes.Equal(data, last) {
+			continue
+		}
+		last = data
+		pl.CommitTs = versions[i]
+		res.Versions = append(res.Versions, pl)
+	}
+	return res, nil
+}
+
+// keyVersions returns the versions of the key at or below readTs, from the newest to the oldest.
+// The values of the key at the versions which are still there can be read, the others have been
+// garbage collected, or are replaced by a rollup at the same version.
+func keyVersions(key []byte, readTs uint64) ([]uint64, error) {
+	txn := pstore.NewTransactionAt(readTs, false)
+	defer txn.Discard()
+
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	iterOpts.PrefetchValues = false
+	itr := txn.NewKeyIterator(key, iterOpts)
+	defer itr.Close()
+
+	var versions []uint64
+	for itr.Seek(key); itr.Valid(); itr.Next() {
+		item := itr.Item()
+		if item.IsDeletedOrExpired() {
+			// The older versions were deleted, e.g. when the predicate was dropped.
+			break
+		}
+		versions = append(versions, item.Version())
+	}
+	return versions, nil
+}
+
+// postingsAt returns the postings of the key at the given version. Only the uids are kept for a
+// uid predicate, and the values and language tags for the other ones.
+func postingsAt(key []byte, version uint64, uids bool) ([]*pb.Posting, error) {
+	txn := pstore.NewTransactionAt(version, false)
+	defer txn.Discard()
+
+	iterOpts := badger.DefaultIteratorOptions
+	iterOpts.AllVersions = true
+	iterOpts.PrefetchValues = false
+	itr := txn.NewKeyIterator(key, iterOpts)
+	defer itr.Close()
+	itr.Seek(key)
+	l, err := posting.ReadPostingList(key, itr)
+	if err != nil {
+		return nil, err
+	}
+
+	var postings []*pb.Posting
+	err = l.Iterate(version, 0, func(p *pb.Posting) error {
+		if uids {
+			postings = append(postings, &pb.Posting{Uid: p.Uid, ValType: pb.Posting_UID})
+		} else {
+			postings = append(postings, &pb.Posting{
+				Value:   p.Value,
+				ValType: p.ValType,
+				LangTag: p.LangTag,
+			})
+		}
+		return nil
+	})
+	return postings, err
+}
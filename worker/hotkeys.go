@@ -0,0 +1,272 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The accesses to the predicates and to their keys by the queries and the mutations are sampled
+// into count-min sketches, one per minute over the last hour, to find the hot keys. Only one in
+// hotKeySampleRate accesses is recorded, and at most hotKeyMaxSampledUids uids of the uids a query
+// task reads, so that the overhead stays low. The memory used is bounded by the size of the
+// sketches and of the candidates kept for each of them.
+const (
+	hotKeySampleRate     = 16
+	hotKeyMaxSampledUids = 8
+	hotKeyBucketLength   = time.Minute
+	hotKeyBuckets        = 60
+	hotKeySketchDepth    = 4
+	hotKeySketchWidth    = 1024
+	hotKeyCandidates     = 256
+
+	// MaxHotKeysWindow is the longest window the hot keys can be looked up over.
+	MaxHotKeysWindow = hotKeyBuckets * hotKeyBucketLength
+)
+
+// HotKey is a predicate, or a key of a predicate if Uid is set, along with an estimate of the
+// number of times it was accessed.
+type HotKey struct {
+	Attr  string
+	Uid   uint64
+	Count uint64
+}
+
+// countMinSketch estimates the number of times a key was added. The estimate is never lower than
+// the real count, and is higher by at most a small fraction of the total count with a high
+// probability.
+type countMinSketch struct {
+	rows [hotKeySketchDepth][hotKeySketchWidth]uint32
+}
+
+func sketchHashes(key string) [hotKeySketchDepth]uint32 {
+	// The hashes of the rows are derived from two hashes of the key, as in Kirsch-Mitzenmacher.
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	h1, h2 := uint32(sum), uint32(sum>>32)|1
+	var res [hotKeySketchDepth]uint32
+	for i := range res {
+		res[i] = (h1 + uint32(i)*h2) % hotKeySketchWidth
+	}
+	return res
+}
+
+func (s *countMinSketch) add(key string, n uint32) uint32 {
+	est := ^uint32(0)
+	for i, col := range sketchHashes(key) {
+		c := s.rows[i][col]
+		if c+n >= c {
+			c += n
+		}
+		s.rows[i][col] = c
+		if c < est {
+			est = c
+		}
+	}
+	return est
+}
+
+func (s *countMinSketch) estimate(key string) uint32 {
+	est := ^uint32(0)
+	for i, col := range sketchHashes(key) {
+		if c := s.rows[i][col]; c < est {
+			est = c
+		}
+	}
+	return est
+}
+
+// hotKeyBucket holds the accesses of one minute. The candidates are the keys with the highest
+// estimates seen in the minute, which are the ones which can be reported.
+type hotKeyBucket struct {
+	start      time.Time
+	sketch     countMinSketch
+	candidates map[string]uint32
+}
+
+func (b *hotKeyBucket) add(key string, n uint32) {
+	est := b.sketch.add(key, n)
+	if _, ok := b.candidates[key]; ok || len(b.candidates) < hotKeyCandidates {
+		b.candidates[key] = est
+		return
+	}
+	minKey, minEst := "", ^uint32(0)
+	for k, e := range b.candidates {
+		if e < minEst {
+			minKey, minEst = k, e
+		}
+	}
+	if est > minEst {
+		delete(b.candidates, minKey)
+		b.candidates[key] = est
+	}
+}
+
+type hotKeyTracker struct {
+	sync.Mutex
+	accesses uint64
+	buckets  [hotKeyBuckets]*hotKeyBucket
+}
+
+var hotKeys = &hotKeyTracker{}
+
+// The keys of the sketches are a 'p' followed by the predicate for a predicate, and a 'k'
+// followed by the uid and the predicate for a key of a predicate.
+func hotPredicateKey(attr string) string {
+	return "p" + attr
+}
+
+func hotUidKey(attr string, uid uint64) string {
+	b := make([]byte, 9, 9+len(attr))
+	b[0] = 'k'
+	binary.BigEndian.PutUint64(b[1:], uid)
+	return string(append(b, attr...))
+}
+
+func parseHotKey(key string) HotKey {
+	if key[0] == 'k' {
+		return HotKey{Attr: key[9:], Uid: binary.BigEndian.Uint64([]byte(key[1:9]))}
+	}
+	return HotKey{Attr: key[1:]}
+}
+
+// accessWeight returns the number of accesses a sample stands for.
+func accessWeight(n int) uint32 {
+	if w := n * hotKeySampleRate; w < 1<<30 {
+		return uint32(w)
+	}
+	return 1 << 30
+}
+
+// recordAccess samples an access to attr reading or writing the given uids. The uids can be
+// empty, e.g. for a function at root.
+func recordAccess(attr string, uids []uint64) {
+	hotKeys.record(time.Now(), attr, uids)
+}
+
+func (t *hotKeyTracker) record(now time.Time, attr string, uids []uint64) {
+	if atomic.AddUint64(&t.accesses, 1)%hotKeySampleRate != 0 {
+		return
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	b := t.bucket(now)
+	n := len(uids)
+	if n == 0 {
+		n = 1
+	}
+	b.add(hotPredicateKey(attr), accessWeight(n))
+	if len(uids) == 0 {
+		return
+	}
+	// Evenly spaced uids stand for all of them, each one weighing for the uids it stands for.
+	step := (len(uids) + hotKeyMaxSampledUids - 1) / hotKeyMaxSampledUids
+	for i := 0; i < len(uids); i += step {
+		b.add(hotUidKey(attr, uids[i]), accessWeight(step))
+	}
+}
+
+// bucket returns the bucket of the minute of now, replacing the bucket of the same slot if it is
+// older.
+func (t *hotKeyTracker) bucket(now time.Time) *hotKeyBucket {
+	start := now.Truncate(hotKeyBucketLength)
+	slot := (start.Unix() / int64(hotKeyBucketLength/time.Second)) % hotKeyBuckets
+	b := t.buckets[slot]
+	if b == nil || !b.start.Equal(start) {
+		b = &hotKeyBucket{start: start, candidates: make(map[string]uint32)}
+		t.buckets[slot] = b
+	}
+	return b
+}
+
+// HotKeys returns the predicates and the keys of predicates accessed the most on this Alpha over
+// the last window, at most limit of each, along with the estimated number of accesses. The window
+// is rounded up to the minute. Only the predicates of the given namespace are returned, unless
+// ns is the galaxy namespace.
+func HotKeys(ns uint64, window time.Duration, limit int) (preds, keys []HotKey) {
+	return hotKeys.top(time.Now(), ns, window, limit)
+}
+
+func (t *hotKeyTracker) top(now time.Time, ns uint64, window time.Duration,
+	limit int) ([]HotKey, []HotKey) {
+
+	t.Lock()
+	defer t.Unlock()
+	from := now.Truncate(hotKeyBucketLength).Add(-window + hotKeyBucketLength)
+	var buckets []*hotKeyBucket
+	for _, b := range t.buckets {
+		if b != nil && !b.start.Before(from) && !b.start.After(now) {
+			buckets = append(buckets, b)
+		}
+	}
+
+	// A key which is a candidate of a bucket is counted in all the buckets of the window, as it
+	// may have been accessed in a bucket without being one of its candidates.
+	counts := make(map[string]uint64)
+	for _, b := range buckets {
+		for key := range b.candidates {
+			if _, ok := counts[key]; ok {
+				continue
+			}
+			if ns != x.GalaxyNamespace && x.ParseNamespace(parseHotKey(key).Attr) != ns {
+				continue
+			}
+			var count uint64
+			for _, o := range buckets {
+				count += uint64(o.sketch.estimate(key))
+			}
+			counts[key] = count
+		}
+	}
+
+	var preds, keys []HotKey
+	for key, count := range counts {
+		hk := parseHotKey(key)
+		hk.Count = count
+		if key[0] == 'p' {
+			preds = append(preds, hk)
+		} else {
+			keys = append(keys, hk)
+		}
+	}
+	return topHotKeys(preds, limit), topHotKeys(keys, limit)
+}
+
+func topHotKeys(hks []HotKey, limit int) []HotKey {
+	sort.Slice(hks, func(i, j int) bool {
+		if hks[i].Count != hks[j].Count {
+			return hks[i].Count > hks[j].Count
+		}
+		if hks[i].Attr != hks[j].Attr {
+			return hks[i].Attr < hks[j].Attr
+		}
+		return hks[i].Uid < hks[j].Uid
+	})
+	if len(hks) > limit {
+		hks = hks[:limit]
+	}
+	return hks
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestHotKeys(t *testing.T) {
+	tr := &hotKeyTracker{}
+	now := time.Date(2021, 6, 1, 12, 0, 30, 0, time.UTC)
+	name := x.GalaxyAttr("name")
+	age := x.GalaxyAttr("age")
+	other := x.NamespaceAttr(1, "name")
+
+	record := func(at time.Time, attr string, uids []uint64, times int) {
+		for i := 0; i < times*hotKeySampleRate; i++ {
+			tr.record(at, attr, uids)
+		}
+	}
+	record(now, name, []uint64{1}, 100)
+	record(now, age, []uint64{2}, 10)
+	record(now, other, []uint64{3}, 50)
+	// Out of a 5m window.
+	record(now.Add(-10*time.Minute), age, []uint64{2}, 1000)
+
+	preds, keys := tr.top(now, x.GalaxyNamespace, 5*time.Minute, 2)
+	require.Equal(t, []HotKey{
+		{Attr: name, Count: 100 * hotKeySampleRate},
+		{Attr: other, Count: 50 * hotKeySampleRate},
+	}, preds)
+	require.Equal(t, []HotKey{
+		{Attr: name, Uid: 1, Count: 100 * hotKeySampleRate},
+		{Attr: other, Uid: 3, Count: 50 * hotKeySampleRate},
+	}, keys)
+
+	preds, _ = tr.top(now, 1, 5*time.Minute, 10)
+	require.Equal(t, []HotKey{{Attr: other, Count: 50 * hotKeySampleRate}}, preds)
+
+	preds, _ = tr.top(now, x.GalaxyNamespace, time.Hour, 1)
+	require.Equal(t, []HotKey{{Attr: age, Count: 1010 * hotKeySampleRate}}, preds)
+}
+
+func TestHotKeysBounded(t *testing.T) {
+	tr := &hotKeyTracker{}
+	now := time.Now()
+	attr := x.GalaxyAttr("name")
+	// Every uid is sampled once, which is more uids than the candidates kept.
+	for uid := uint64(1); uid <= 10*hotKeyCandidates*hotKeySampleRate; uid++ {
+		tr.record(now, attr, []uint64{uid})
+	}
+	for i := 0; i < 100*hotKeySampleRate; i++ {
+		tr.record(now, attr, []uint64{42})
+	}
+	require.LessOrEqual(t, len(tr.bucket(now).candidates), hotKeyCandidates)
+
+	_, keys := tr.top(now, x.GalaxyNamespace, time.Minute, 1)
+	require.Len(t, keys, 1)
+	require.Equal(t, uint64(42), keys[0].Uid, "%+v", keys)
+}
+
+func TestHotKeysSampledUids(t *testing.T) {
+	tr := &hotKeyTracker{}
+	now := time.Now()
+	attr := x.GalaxyAttr("friend")
+	uids := make([]uint64, 1000)
+	for i := range uids {
+		uids[i] = uint64(i + 1)
+	}
+	for i := 0; i < hotKeySampleRate; i++ {
+		tr.record(now, attr, uids)
+	}
+	preds, keys := tr.top(now, x.GalaxyNamespace, time.Minute, 100)
+	require.Equal(t, []HotKey{{Attr: attr, Count: 1000 * hotKeySampleRate}}, preds)
+	require.LessOrEqual(t, len(keys), hotKeyMaxSampledUids)
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/dgraph-io/badger/v3"
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// ImportKeyValues receives the posting lists built by the bulk loader with --live-import, for
+// the predicates served by this group. Unlike the keys of a predicate move, the posting lists are
+// deltas which are merged with the stored ones, so that e.g. the index keys of the imported nodes
+// are added to the index of the existing nodes. The keys are proposed in batches and each batch
+// is applied before the next one is read, so a slow group slows the bulk loader down.
+func (w *grpcWorker) ImportKeyValues(stream pb.Worker_ImportKeyValuesServer) error {
+	n := groups().Node
+	if !n.AmLeader() {
+		return errors.Errorf("ImportKeyValues failed: Not the leader of group")
+	}
+	ctx := stream.Context()
+	c := &importChecker{
+		txn:    pstore.NewTransactionAt(math.MaxUint64, false),
+		served: make(map[string]bool),
+	}
+	defer c.txn.Discard()
+
+	proposal := &pb.Proposal{}
+	count, size := 0, 0
+	propose := func() error {
+		if len(proposal.Kv) == 0 {
+			return nil
+		}
+		if err := n.proposeAndWait(ctx, proposal); err != nil {
+			return err
+		}
+		proposal = &pb.Proposal{}
+		size = 0
+		return nil
+	}
+	for {
+		kvBuf, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			glog.Errorf("Imported %d keys. Error in loop: %v\n", count, err)
+			return err
+		}
+		buf := z.NewBufferSlice(kvBuf.Data)
+		err = buf.SliceIterate(func(s []byte) error {
+			kv := &bpb.KV{}
+			if err := kv.Unmarshal(s); err != nil {
+				return err
+			}
+			if err := c.check(kv); err != nil {
+				return err
+			}
+			proposal.Kv = append(proposal.Kv, kv)
+			count++
+			size += len(kv.Key) + len(kv.Value)
+			if size >= 32<<20 { // 32 MB
+				return propose()
+			}
+			return nil
+		})
+		if err != nil {
+			glog.Errorf("Imported %d keys. Error: %v\n", count, err)
+			return err
+		}
+	}
+	if err := propose(); err != nil {
+		return err
+	}
+	glog.Infof("Imported %d keys\n", count)
+	return stream.SendAndClose(&api.Payload{Data: []byte(fmt.Sprintf("%d", count))})
+}
+
+// importChecker checks the keys received by ImportKeyValues before they are proposed.
+type importChecker struct {
+	txn *badger.Txn
+	// served caches whether the predicates of the keys are served by this group.
+	served map[string]bool
+}
+
+// check returns an error if the key isn't a delta of a predicate served by this group, or if it
+// would overwrite the data of an existing node. The bulk loader assigns new uids to the nodes it
+// imports, so the data keys should never exist. The index, reverse and count keys are merged.
+func (c *importChecker) check(kv *bpb.KV) error {
+	pk, err := x.Parse(kv.Key)
+	if err != nil {
+		return errors.Wrapf(err, "while parsing the imported key %x", kv.Key)
+	}
+	attr := x.ParseAttr(pk.Attr)
+	if pk.IsSchema() || pk.IsType() {
+		return errors.Errorf("The schema of %s can't be imported, it has to be altered", attr)
+	}
+	if len(kv.UserMeta) == 0 || kv.UserMeta[0] != posting.BitDeltaPosting {
+		return errors.Errorf("The imported keys of predicate %s should be deltas", attr)
+	}
+
+	served, ok := c.served[pk.Attr]
+	if !ok {
+		if served, err = groups().ServesTablet(pk.Attr); err != nil {
+			return err
+		}
+		c.served[pk.Attr] = served
+	}
+	if !served {
+		return errors.Errorf("Predicate %s isn't served by group %d", attr, groups().groupId())
+	}
+
+	if !pk.IsData() {
+		return nil
+	}
+	switch _, err := c.txn.Get(kv.Key); {
+	case err == nil:
+		return errors.Errorf("Node %#x already has data for predicate %s, only new nodes can be "+
+			"imported", pk.Uid, attr)
+	case err != badger.ErrKeyNotFound:
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"math"
+	"testing"
+
+	bpb "github.com/dgraph-io/badger/v3/pb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestImportChecker(t *testing.T) {
+	name := x.GalaxyAttr("name")
+	const existing, imported = 1 << 40, 1<<40 + 1
+	addEdge(t, &pb.DirectedEdge{Entity: existing, Attr: name, Value: []byte("a")},
+		getOrCreate(x.DataKey(name, existing)))
+
+	c := &importChecker{
+		txn:    pstore.NewTransactionAt(math.MaxUint64, false),
+		served: make(map[string]bool),
+	}
+	defer c.txn.Discard()
+	delta := func(key []byte) *bpb.KV {
+		return &bpb.KV{Key: key, UserMeta: []byte{posting.BitDeltaPosting}}
+	}
+
+	require.NoError(t, c.check(delta(x.DataKey(name, imported))))
+	// The index of the imported nodes is merged with the one of the existing nodes.
+	require.NoError(t, c.check(delta(x.IndexKey(name, "a"))))
+	require.True(t, c.served[name])
+
+	err := c.check(delta(x.DataKey(name, existing)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Node 0x10000000000 already has data for predicate name")
+
+	err = c.check(&bpb.KV{Key: x.DataKey(name, imported),
+		UserMeta: []byte{posting.BitCompletePosting}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "The imported keys of predicate name should be deltas")
+
+	err = c.check(delta(x.SchemaKey(name)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "The schema of name can't be imported")
+
+	notServed := x.GalaxyAttr("friend_not_served")
+	err = c.check(delta(x.DataKey(notServed, imported)))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate friend_not_served isn't served by group 1")
+	require.False(t, c.served[notServed])
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// CheckIncrement returns an error if the edge is an increment of a predicate which can't be
+// incremented. The increments are only resolved when the value is read, so the predicate must be
+// a single int or float without any index, as the index would need the value when it's written.
+// It's called before the value is converted to the type of the schema, as a float increment of
+// an int predicate would be truncated.
+func CheckIncrement(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	if edge.Op != pb.DirectedEdge_INCR {
+		return nil
+	}
+	attr := x.ParseAttr(edge.Attr)
+	switch typ := types.TypeID(su.GetValueType()); {
+	case typ != types.IntID && typ != types.FloatID:
+		return errors.Errorf("Predicate %q is of type %s, only int and float predicates can be "+
+			"incremented", attr, typ.Name())
+	case typ == types.IntID && types.TypeID(edge.ValueType) == types.FloatID:
+		return errors.Errorf("Predicate %q is of type int, it can't be incremented by a float",
+			attr)
+	case su.GetList():
+		return errors.Errorf("Predicate %q is a list, it can't be incremented", attr)
+	case len(su.GetTokenizer()) > 0 || su.GetCount() || su.GetUpsert():
+		return errors.Errorf("Predicate %q has an index, it can't be incremented", attr)
+	case edge.Lang != "":
+		return errors.Errorf("Predicate %q can't be incremented with a language", attr)
+	}
+	return nil
+}
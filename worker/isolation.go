@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"github.com/pkg/errors"
+)
+
+// A transaction runs with snapshot isolation by default: all of its queries read the data
+// committed before its start ts, and it is aborted at commit if another transaction committed a
+// change to a key it modified after its start ts. The requests of a transaction can instead ask
+// for read-committed isolation, e.g. with the isolation parameter of /query and /mutate, which
+// trades the isolation for fewer aborts:
+// - each query reads the data committed when it runs, along with the mutations of the
+//   transaction, instead of the data committed before the start of the transaction.
+// - the mutations read the data, e.g. to update the indexes, when the first mutation of the
+//   transaction is applied by a group, so the transaction is only aborted if another one commits
+//   a change to the same keys after that. A long transaction which queries first and mutates at
+//   the end is then rarely aborted.
+// The guarantees are weaker than with snapshot isolation. A transaction can see different results
+// for the same query (non-repeatable reads), and the nodes matched by a query (phantoms). A
+// change it makes based on a query can overwrite a change committed after the query ran (lost
+// update). Every request of the transaction should use the same isolation, as a query in
+// snapshot isolation doesn't see the mutations done in read-committed mode.
+//
+// The conflict keys of the transactions in read-committed mode carry the ts their mutations read
+// the data at, see x.ConflictKey, which the Zeros of the previous releases can't parse: they skip
+// the keys, so the transactions are never aborted. When upgrading, the Zeros must be upgraded
+// before the Alphas, and read-committed isolation must only be used once they all are. The keys of
+// the transactions in snapshot isolation are unchanged, so they don't depend on the order.
+
+const (
+	// IsolationSnapshot runs the requests of a transaction with snapshot isolation. It's the
+	// default.
+	IsolationSnapshot = "snapshot"
+	// IsolationReadCommitted runs the requests of a transaction with read-committed isolation.
+	IsolationReadCommitted = "read-committed"
+)
+
+// ParseIsolation parses the isolation of the requests of a transaction.
+func ParseIsolation(mode string) (string, error) {
+	switch mode {
+	case "", IsolationSnapshot:
+		return IsolationSnapshot, nil
+	case IsolationReadCommitted:
+		return IsolationReadCommitted, nil
+	default:
+		return "", errors.Errorf("Invalid value %q for isolation, expected %s or %s", mode,
+			IsolationSnapshot, IsolationReadCommitted)
+	}
+}
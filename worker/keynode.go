@@ -0,0 +1,219 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/algo"
+	"github.com/dgraph-io/dgraph/chunker"
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The values of a @key predicate identify the nodes: a key blank node, like _:(email="a@b.com"),
+// is the node having the value if there is one, and a new node otherwise, which gets the value.
+// As the values of each @key predicate are unique, a key node is given a single one of them.
+// Loading the same data again then updates the same nodes. Two transactions creating a node for
+// the same key conflict, as @key implies @upsert, so a single node is created. A value of a @key
+// predicate can't be set on a node while another node has it.
+
+// ResolveKeyNodes returns the uids of the key nodes, given with their keys, which are found by
+// the transaction with the given start ts, its own mutations included. readCommittedTs is the ts
+// it reads the committed data at in read-committed mode, or 0. The key nodes which aren't found
+// are left out, they need new uids.
+func ResolveKeyNodes(ctx context.Context, ns uint64, nodes map[string]chunker.KeyValue,
+	startTs, readCommittedTs uint64) (map[string]uint64, error) {
+
+	preds := make(map[string]struct{})
+	for _, k := range nodes {
+		preds[x.NamespaceAttr(ns, k.Predicate)] = struct{}{}
+	}
+	req := &pb.SchemaRequest{Fields: []string{"key"}}
+	for pred := range preds {
+		req.Predicates = append(req.Predicates, pred)
+	}
+	sort.Strings(req.Predicates)
+	// The schema of a predicate is only known to the group serving it.
+	schemaNodes, err := GetSchemaOverNetwork(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range schemaNodes {
+		if node.Key {
+			delete(preds, node.Predicate)
+		}
+	}
+	for _, pred := range req.Predicates {
+		if _, ok := preds[pred]; ok {
+			return nil, errors.Errorf("Predicate %s of a key node must have @key in the schema",
+				x.ParseAttr(pred))
+		}
+	}
+
+	uids := make(map[string]uint64)
+	for name, key := range nodes {
+		uid, err := keyNodeUid(ctx, ns, key, startTs, readCommittedTs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while resolving key node %s", name)
+		}
+		if uid != 0 {
+			uids[name] = uid
+		}
+	}
+	return uids, nil
+}
+
+// keyNodeUid returns the uid of the node having the value of the key, or 0 if there is none. The
+// query reads the cache of the transaction, so that the nodes created by its previous mutations
+// are found.
+func keyNodeUid(ctx context.Context, ns uint64, key chunker.KeyValue,
+	startTs, readCommittedTs uint64) (uint64, error) {
+
+	q := &pb.Query{
+		Attr:    x.NamespaceAttr(ns, key.Predicate),
+		SrcFunc: &pb.SrcFunction{Name: "eq", Args: []string{key.Value}},
+		ReadTs:  startTs,
+		Cache:   UseTxnCache,
+	}
+	if readCommittedTs != 0 {
+		q.ReadTs, q.TxnStartTs = readCommittedTs, startTs
+	}
+	res, err := ProcessTaskOverNetwork(ctx, q)
+	switch {
+	case err == errNonExistentTablet:
+		// There is no data yet.
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+	found := algo.MergeSorted(res.UidMatrix).Uids
+	switch len(found) {
+	case 0:
+		return 0, nil
+	case 1:
+		return found[0], nil
+	default:
+		return 0, errors.Errorf("Nodes %#x and %#x both have %s %q", found[0], found[1],
+			key.Predicate, key.Value)
+	}
+}
+
+// checkKeyCollision returns an error if the value of a @key predicate set by the edge is the
+// value of another node. The concurrent transactions setting the value conflict on its index
+// key, as with @upsert.
+func checkKeyCollision(edge *pb.DirectedEdge, su *pb.SchemaUpdate, txn *posting.Txn) error {
+	if !su.GetKey() || edge.Op != pb.DirectedEdge_SET {
+		return nil
+	}
+	src := types.Val{Tid: types.TypeID(edge.ValueType), Value: edge.Value}
+	val, err := types.Convert(src, types.StringID)
+	if err != nil {
+		return err
+	}
+	for _, name := range su.GetTokenizer() {
+		if name != "exact" && name != "hash" {
+			continue
+		}
+		tokenizer, ok := tok.GetTokenizer(name)
+		if !ok {
+			continue
+		}
+		tokens, err := tok.BuildTokens(val.Value, tokenizer)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			pl, err := txn.Get(x.IndexKey(edge.Attr, token))
+			if err != nil {
+				return err
+			}
+			uids, err := pl.Uids(posting.ListOptions{ReadTs: txn.StartTs})
+			if err != nil {
+				return err
+			}
+			for _, uid := range uids.Uids {
+				if uid != edge.Entity {
+					return errors.Errorf("Value %q of key predicate %s for node %#x is already "+
+						"the value of node %#x", val.Value, x.ParseAttr(edge.Attr), edge.Entity,
+						uid)
+				}
+			}
+		}
+		// A single index is enough to find the other nodes.
+		return nil
+	}
+	return nil
+}
+
+// checkKeyChange returns an error if the schema update makes a predicate a key while several
+// nodes have the same value.
+func checkKeyChange(s *pb.SchemaUpdate) error {
+	if !s.Key || schema.State().IsKey(s.Predicate) {
+		return nil
+	}
+	if _, err := schema.State().TypeOf(s.Predicate); err != nil {
+		// There is no data yet.
+		return nil
+	}
+	val, uids, err := sharedValue(s.Predicate, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	if uids[0] != 0 {
+		return errors.Errorf("Schema change not allowed to @key: nodes %#x and %#x both have "+
+			"value %q of predicate %s. Update one of them first.", uids[0], uids[1], val,
+			x.ParseAttr(s.Predicate))
+	}
+	return nil
+}
+
+// sharedValue returns a value of the predicate which two nodes, also returned, have at readTs.
+// The nodes are 0 if there isn't any. As for sharedTarget, the data keys are read.
+func sharedValue(attr string, readTs uint64) (string, [2]uint64, error) {
+	var val string
+	var uids [2]uint64
+	nodes := make(map[string]uint64)
+	err := iterateDataKeys(attr, readTs, func(node uint64, pl *posting.List) error {
+		vals, err := pl.AllValues(readTs)
+		if err != nil {
+			return err
+		}
+		for _, v := range vals {
+			sv, err := types.Convert(v, types.StringID)
+			if err != nil {
+				return err
+			}
+			str := sv.Value.(string)
+			if uid, ok := nodes[str]; ok && uid != node {
+				val, uids = str, [2]uint64{uid, node}
+				return posting.ErrStopIteration
+			}
+			nodes[str] = node
+		}
+		return nil
+	})
+	return val, uids, err
+}
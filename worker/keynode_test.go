@@ -0,0 +1,206 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/testutil"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCheckKeyCollision(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		email: string @index(exact) @upsert @key .
+	`), 1))
+	email := x.GalaxyAttr("email")
+	edge := func(uid uint64, val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Entity:    uid,
+			Attr:      email,
+			Value:     []byte(val),
+			ValueType: pb.Posting_STRING,
+			Op:        pb.DirectedEdge_SET,
+		}
+	}
+	run := func(edge *pb.DirectedEdge) error {
+		startTs := timestamp()
+		txn := posting.Oracle().RegisterStartTs(startTs)
+		if err := runMutation(context.Background(), edge, txn); err != nil {
+			return err
+		}
+		txn.Update()
+		writer := posting.NewTxnWriter(pstore)
+		require.NoError(t, txn.CommitToDisk(writer, commitTs(startTs)))
+		require.NoError(t, writer.Flush())
+		return nil
+	}
+
+	require.NoError(t, run(edge(1, "a@b.com")))
+	// The node can be given its own value again.
+	require.NoError(t, run(edge(1, "a@b.com")))
+	require.NoError(t, run(edge(2, "c@d.com")))
+
+	err := run(edge(2, "a@b.com"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		`Value "a@b.com" of key predicate email for node 0x2 is already the value of node 0x1`)
+
+	// The deletions aren't checked.
+	del := edge(2, "a@b.com")
+	del.Op = pb.DirectedEdge_DEL
+	require.NoError(t, run(del))
+}
+
+func TestCheckKeyChange(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		nick: string @index(exact) .
+	`), 1))
+	nick := x.GalaxyAttr("nick")
+	edge := func(uid uint64, val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Entity:    uid,
+			Attr:      nick,
+			Value:     []byte(val),
+			ValueType: pb.Posting_STRING,
+		}
+	}
+	update := &pb.SchemaUpdate{
+		Predicate: nick,
+		ValueType: pb.Posting_STRING,
+		Tokenizer: []string{"exact"},
+		Upsert:    true,
+		Key:       true,
+	}
+
+	addEdge(t, edge(1, "x"), getOrCreate(x.DataKey(nick, 1)))
+	addEdge(t, edge(2, "y"), getOrCreate(x.DataKey(nick, 2)))
+	val, uids, err := sharedValue(nick, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, "", val)
+	require.Equal(t, [2]uint64{}, uids)
+	require.NoError(t, checkKeyChange(update))
+
+	addEdge(t, edge(3, "x"), getOrCreate(x.DataKey(nick, 3)))
+	val, uids, err = sharedValue(nick, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, "x", val)
+	require.Equal(t, [2]uint64{1, 3}, uids)
+	err = checkKeyChange(update)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `nodes 0x1 and 0x3 both have value "x" of predicate nick`)
+
+	// The value of 0x3 is no longer shared once it's deleted.
+	delEdge(t, edge(3, "x"), getOrCreate(x.DataKey(nick, 3)))
+	_, uids, err = sharedValue(nick, math.MaxUint64)
+	require.NoError(t, err)
+	require.Equal(t, [2]uint64{}, uids)
+	require.NoError(t, checkKeyChange(update))
+}
+
+func initKeyNodeTest(t *testing.T) *dgo.Dgraph {
+	dg, err := testutil.DgraphClient(testutil.SockAddr)
+	require.NoError(t, err)
+	testutil.DropAll(t, dg)
+	require.NoError(t, dg.Alter(context.Background(), &api.Operation{Schema: `
+		email: string @index(exact) @upsert @key .
+		name: string @index(exact) .
+	`}))
+	return dg
+}
+
+func TestKeyNodeLoads(t *testing.T) {
+	dg := initKeyNodeTest(t)
+	ctx := context.Background()
+	load := func(txn *dgo.Txn, rdf string) map[string]string {
+		resp, err := txn.Mutate(ctx, &api.Mutation{SetNquads: []byte(rdf), CommitNow: true})
+		require.NoError(t, err)
+		return resp.Uids
+	}
+
+	// Loading the same data again updates the same node, however the key node is written.
+	uids := load(dg.NewTxn(), `_:(email="a@b.com") <name> "a" .`)
+	uid := uids[`(email="a@b.com")`]
+	require.NotEmpty(t, uid)
+	uids = load(dg.NewTxn(), `_:( <email> = "a@b.com" ) <name> "b" .`)
+	require.Equal(t, uid, uids[`(email="a@b.com")`])
+
+	resp, err := dg.NewReadOnlyTxn().Query(ctx, `{q(func: eq(email, "a@b.com")) { uid name }}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"uid": "`+uid+`", "name": "b"}]}`, string(resp.Json))
+
+	// The node created by a previous mutation of the transaction is found before the commit.
+	txn := dg.NewTxn()
+	resp, err = txn.Mutate(ctx, &api.Mutation{SetNquads: []byte(`_:(email="c@d.com") <name> "c" .`)})
+	require.NoError(t, err)
+	uid = resp.Uids[`(email="c@d.com")`]
+	require.NotEmpty(t, uid)
+	require.Equal(t, uid, load(txn, `_:(email="c@d.com") <name> "d" .`)[`(email="c@d.com")`])
+
+	// A key node is given to deletions, and only the existing ones are deleted from.
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{CommitNow: true, DelNquads: []byte(`
+		_:(email="a@b.com") <name> * .
+		_:(email="none@b.com") <name> * .
+	`)})
+	require.NoError(t, err)
+	resp, err = dg.NewReadOnlyTxn().Query(ctx, `{
+		a(func: eq(email, "a@b.com")) { name }
+		none(func: eq(email, "none@b.com")) { uid }
+	}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a": [], "none": []}`, string(resp.Json))
+
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{CommitNow: true,
+		SetNquads: []byte(`_:(email="a@b.com", name="a") <name> "x" .`)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "a node is identified by the value of a single @key predicate")
+
+	_, err = dg.NewTxn().Mutate(ctx, &api.Mutation{CommitNow: true,
+		SetNquads: []byte(`_:(name="a") <email> "e@f.com" .`)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Predicate name of a key node must have @key in the schema")
+}
+
+func TestKeyNodeConcurrentCreate(t *testing.T) {
+	dg := initKeyNodeTest(t)
+	ctx := context.Background()
+	mu := &api.Mutation{SetNquads: []byte(`_:(email="e@f.com") <name> "e" .`)}
+
+	// Neither transaction sees the node of the other, the second commit is aborted.
+	txn1, txn2 := dg.NewTxn(), dg.NewTxn()
+	resp1, err := txn1.Mutate(ctx, mu)
+	require.NoError(t, err)
+	resp2, err := txn2.Mutate(ctx, mu)
+	require.NoError(t, err)
+	require.NotEqual(t, resp1.Uids[`(email="e@f.com")`], resp2.Uids[`(email="e@f.com")`])
+	require.NoError(t, txn1.Commit(ctx))
+	require.Equal(t, dgo.ErrAborted, txn2.Commit(ctx))
+
+	resp, err := dg.NewReadOnlyTxn().Query(ctx, `{q(func: eq(email, "e@f.com")) { uid }}`)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"q": [{"uid": "`+resp1.Uids[`(email="e@f.com")`]+`"}]}`,
+		string(resp.Json))
+}
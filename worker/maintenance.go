@@ -0,0 +1,139 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+// The maintenance policy restricts the heavy operations, i.e. exports, backups and the schema
+// changes which rebuild an index, to a window of the day, so that they aren't run by accident
+// during peak hours. It is set with the config mutation of the admin API and stored in the
+// membership state of Zero, so that it applies to all the alphas and is kept across restarts. The
+// operations which are already running when the window closes are left to finish.
+
+// timeNow is replaced in the tests.
+var timeNow = time.Now
+
+// opsWindow is a window of the day in UTC, as the durations since midnight of its start and its
+// end. The window goes past midnight if end is before start, e.g. for 22:00-06:00.
+type opsWindow struct {
+	start, end time.Duration
+}
+
+func parseOpsWindow(s string) (*opsWindow, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("The window of the heavy operations should be like "+
+			"22:00-06:00, got: %q", s)
+	}
+	var w [2]time.Duration
+	for i, part := range parts {
+		t, err := time.Parse("15:04", strings.TrimSpace(part))
+		if err != nil {
+			return nil, errors.Wrapf(err, "while parsing the window of the heavy operations %q", s)
+		}
+		w[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if w[0] == w[1] {
+		return nil, errors.Errorf("The window of the heavy operations %q is empty", s)
+	}
+	return &opsWindow{start: w[0], end: w[1]}, nil
+}
+
+func (w *opsWindow) contains(t time.Time) bool {
+	t = t.UTC()
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if w.start < w.end {
+		return d >= w.start && d < w.end
+	}
+	return d >= w.start || d < w.end
+}
+
+func (w *opsWindow) String() string {
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return format(w.start) + "-" + format(w.end)
+}
+
+// UpdateHeavyOpsPolicy updates the maintenance policy of the cluster. The window is the window of
+// the day, in UTC, during which the heavy operations are allowed, e.g. "22:00-06:00", an empty
+// window allowing them at any time. The override allows them outside of the window if it's set.
+// The current values are kept for the ones which are nil.
+func UpdateHeavyOpsPolicy(ctx context.Context, window *string, override *bool) error {
+	policy := &pb.MaintenancePolicy{}
+	policy.Window, policy.Override = HeavyOpsPolicy()
+	if window != nil {
+		policy.Window = *window
+		if policy.Window != "" {
+			w, err := parseOpsWindow(policy.Window)
+			if err != nil {
+				return err
+			}
+			policy.Window = w.String()
+		}
+	}
+	if override != nil {
+		policy.Override = *override
+	}
+
+	glog.Infof("Updating the maintenance policy to %+v", policy)
+	if _, err := UpdateMaintenanceOverNetwork(ctx, policy); err != nil {
+		return errors.Wrapf(err, "while updating the maintenance policy")
+	}
+	// The policy is applied by this alpha as soon as the mutation returns, the others get it with
+	// the next update of the membership state.
+	return UpdateMembershipState(ctx)
+}
+
+// HeavyOpsPolicy returns the window of the heavy operations, empty if they are allowed at any
+// time, and whether the override is set.
+func HeavyOpsPolicy() (string, bool) {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	policy := g.state.GetMaintenance()
+	return policy.GetWindow(), policy.GetOverride()
+}
+
+// CheckHeavyOp returns an error if the heavy operation op, e.g. "Export", can't be started now
+// because of the maintenance policy.
+func CheckHeavyOp(op string) error {
+	window, override := HeavyOpsPolicy()
+	if window == "" || override {
+		return nil
+	}
+	w, err := parseOpsWindow(window)
+	if err != nil {
+		return errors.Wrapf(err, "while checking the maintenance policy of %s", op)
+	}
+	if w.contains(timeNow()) {
+		return nil
+	}
+	return errors.Errorf("%s operation not permitted during peak hours. The heavy operations "+
+		"are allowed between %s UTC, or with heavyOpsOverride", op, w)
+}
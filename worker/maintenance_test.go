@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+)
+
+func TestHeavyOpsWindow(t *testing.T) {
+	// The policy is read from the membership state, as it's received from Zero.
+	setPolicy := func(window string, override bool) {
+		gr.Lock()
+		defer gr.Unlock()
+		gr.state = &pb.MembershipState{
+			Maintenance: &pb.MaintenancePolicy{Window: window, Override: override},
+		}
+	}
+	state := gr.state
+	defer func() {
+		timeNow = time.Now
+		gr.Lock()
+		gr.state = state
+		gr.Unlock()
+	}()
+	at := func(hour, min int) {
+		timeNow = func() time.Time { return time.Date(2021, 6, 1, hour, min, 0, 0, time.UTC) }
+	}
+
+	at(12, 0)
+	require.NoError(t, CheckHeavyOp("Export"))
+
+	setPolicy("22:00-06:00", false)
+	window, override := HeavyOpsPolicy()
+	require.Equal(t, "22:00-06:00", window)
+	require.False(t, override)
+
+	err := CheckHeavyOp("Export")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Export operation not permitted during peak hours")
+	for _, hm := range [][2]int{{22, 0}, {23, 59}, {0, 0}, {5, 59}} {
+		at(hm[0], hm[1])
+		require.NoError(t, CheckHeavyOp("Export"), "at %02d:%02d", hm[0], hm[1])
+	}
+	at(6, 0)
+	require.Error(t, CheckHeavyOp("Backup"))
+
+	setPolicy("22:00-06:00", true)
+	require.NoError(t, CheckHeavyOp("Backup"))
+
+	setPolicy("01:30-04:00", false)
+	at(1, 29)
+	require.Error(t, CheckHeavyOp("Reindex"))
+	at(1, 30)
+	require.NoError(t, CheckHeavyOp("Reindex"))
+
+	// The invalid windows are rejected before they're sent to Zero.
+	for _, w := range []string{"22:00", "25:00-01:00", "02:00-02:00"} {
+		w := w
+		require.Error(t, UpdateHeavyOpsPolicy(context.Background(), &w, nil), w)
+	}
+	window, _ = HeavyOpsPolicy()
+	require.Equal(t, "01:30-04:00", window)
+}
@@ -70,7 +70,7 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 	// We shouldn't check whether this Alpha serves this predicate or not. Membership information
 	// isn't consistent across the entire cluster. We should just apply whatever is given to us.
 	su, ok := schema.State().Get(ctx, edge.Attr)
-	if edge.Op == pb.DirectedEdge_SET {
+	if edge.Op == pb.DirectedEdge_SET || edge.Op == pb.DirectedEdge_INCR {
 		if !ok {
 			return errors.Errorf("runMutation: Unable to find schema for %s", edge.Attr)
 		}
@@ -79,10 +79,14 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 	if isDeletePredicateEdge(edge) {
 		return errors.New("We should never reach here")
 	}
+	recordAccess(edge.Attr, []uint64{edge.Entity})
 
 	// Once mutation comes via raft we do best effort conversion
 	// Type check is done before proposing mutation, in case schema is not
 	// present, some invalid entries might be written initially
+	if err := CheckIncrement(edge, &su); err != nil {
+		return err
+	}
 	if err := ValidateAndConvert(edge, &su); err != nil {
 		return err
 	}
@@ -124,6 +128,12 @@ func runMutation(ctx context.Context, edge *pb.DirectedEdge, txn *posting.Txn) e
 	if err != nil {
 		return err
 	}
+	if err := enforceCardinality(ctx, edge, &su, plist, txn); err != nil {
+		return err
+	}
+	if err := checkKeyCollision(edge, &su, txn); err != nil {
+		return err
+	}
 	return plist.AddMutationWithIndex(ctx, edge, txn)
 }
 
@@ -420,6 +430,22 @@ func checkSchema(s *pb.SchemaUpdate) error {
 			x.ParseAttr(s.Predicate))
 	}
 
+	if s.Undirected && (typ != types.UidID || !s.List) {
+		return errors.Errorf("@undirected is only allowed on predicate of type [uid] on predicate %s",
+			x.ParseAttr(s.Predicate))
+	}
+	if s.Undirected && s.Directive == pb.SchemaUpdate_REVERSE {
+		return errors.Errorf("@undirected and @reverse can't be used together on predicate %s",
+			x.ParseAttr(s.Predicate))
+	}
+
+	if err := schema.CheckCardinality(s); err != nil {
+		return err
+	}
+	if err := schema.CheckKey(s); err != nil {
+		return err
+	}
+
 	// If schema update has upsert directive, it should have index directive.
 	if s.Upsert && len(s.Tokenizer) == 0 {
 		return errors.Errorf("Index tokenizer is mandatory for: [%s] when specifying @upsert directive",
@@ -456,6 +482,23 @@ func checkSchema(s *pb.SchemaUpdate) error {
 				" while there is data for pred: %s", x.ParseAttr(s.Predicate))
 		}
 	}
+
+	if err := checkEnumChange(s); err != nil {
+		return err
+	}
+	if err := checkCardinalityChange(s); err != nil {
+		return err
+	}
+	if err := checkKeyChange(s); err != nil {
+		return err
+	}
+
+	// The existing edges only have one direction, so they can't be made undirected.
+	if s.Undirected && !schema.State().IsUndirected(context.Background(), s.Predicate) &&
+		hasEdges(s.Predicate, math.MaxUint64) {
+		return errors.Errorf("Schema change not allowed to @undirected without deleting pred: %s",
+			x.ParseAttr(s.Predicate))
+	}
 	return nil
 }
 
@@ -504,10 +547,29 @@ func ValidateAndConvert(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
 	)
 
 	src := types.Val{Tid: types.TypeID(edge.ValueType), Value: edge.Value}
+	// Binary values given as text, as in JSON, are base64 encoded.
+	if schemaType == types.BinaryID && x.Config.BinaryBase64 &&
+		(src.Tid == types.StringID || src.Tid == types.DefaultID) {
+		b, err := types.DecodeBinary(string(edge.Value))
+		if err != nil {
+			return errors.Wrapf(err, "Input for predicate %q of type binary",
+				x.ParseAttr(edge.Attr))
+		}
+		src = types.Val{Tid: types.BinaryID, Value: b}
+	}
 	// check compatibility of schema type and storage type
 	if dst, err = types.Convert(src, schemaType); err != nil {
 		return err
 	}
+	// Decimals are stored with the scale from the schema, and digits after that are rejected
+	// instead of being rounded off. Without a scale, they are stored as given.
+	if schemaType == types.DecimalID && su.GetDecimalScale() > 0 {
+		d, err := dst.Value.(types.Decimal).Rescale(su.GetDecimalScale())
+		if err != nil {
+			return errors.Wrapf(err, "Input for predicate %q", x.ParseAttr(edge.Attr))
+		}
+		dst.Value = d
+	}
 
 	// convert to schema type
 	b := types.ValueForType(types.BinaryID)
@@ -677,6 +739,32 @@ func populateMutationMap(src *pb.Mutations) (map[uint32]*pb.Mutations, error) {
 		}
 	}
 
+	// Derivations are sent to all groups as well, since the derived predicates are computed
+	// wherever their sources are mutated.
+	if len(src.Derivations) > 0 {
+		for _, gid := range groups().KnownGroups() {
+			mu := mm[gid]
+			if mu == nil {
+				mu = &pb.Mutations{GroupId: gid}
+				mm[gid] = mu
+			}
+			mu.Derivations = src.Derivations
+		}
+	}
+
+	// Aliases are sent to all groups, since they are resolved wherever the queries and the
+	// mutations are received.
+	if len(src.Aliases) > 0 {
+		for _, gid := range groups().KnownGroups() {
+			mu := mm[gid]
+			if mu == nil {
+				mu = &pb.Mutations{GroupId: gid}
+				mm[gid] = mu
+			}
+			mu.Aliases = src.Aliases
+		}
+	}
+
 	return mm, nil
 }
 
@@ -695,6 +783,24 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 	if err := verifyTypes(ctx, m); err != nil {
 		return tctx, err
 	}
+	if err := checkDerivations(ctx, m); err != nil {
+		return tctx, err
+	}
+	if err := checkAliases(ctx, m); err != nil {
+		return tctx, err
+	}
+	if err := addDerivedEdges(ctx, m); err != nil {
+		return tctx, err
+	}
+	if err := checkNewPredicates(m); err != nil {
+		return tctx, err
+	}
+	if err := checkTargets(ctx, m); err != nil {
+		return tctx, err
+	}
+	if err := checkStorageQuota(m); err != nil {
+		return tctx, err
+	}
 	mutationMap, err := populateMutationMap(m)
 	if err != nil {
 		return tctx, err
@@ -708,6 +814,8 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 			return tctx, errNonExistentTablet
 		}
 		mu.StartTs = m.StartTs
+		mu.ReadCommittedTs = m.ReadCommittedTs
+		mu.AppendDuplicates = m.AppendDuplicates
 		go proposeOrSend(ctx, gid, mu, resCh)
 	}
 
@@ -728,6 +836,50 @@ func MutateOverNetwork(ctx context.Context, m *pb.Mutations) (*api.TxnContext, e
 	return tctx, e
 }
 
+// checkNewPredicates rejects the mutation if it would create more predicates than allowed by
+// --limit "predicates-per-mutation". It has to be done before the tablets of the mutation are
+// served, as that creates the predicates. Zero is only asked about the predicates which aren't
+// known by this alpha, until the limit is crossed.
+func checkNewPredicates(m *pb.Mutations) error {
+	limit := x.Config.LimitPredicatesPerMutation
+	if limit <= 0 {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var unknown []string
+	g := groups()
+	g.RLock()
+	for _, edge := range m.Edges {
+		if _, ok := seen[edge.Attr]; ok {
+			continue
+		}
+		seen[edge.Attr] = struct{}{}
+		if _, ok := g.tablets[edge.Attr]; !ok {
+			unknown = append(unknown, edge.Attr)
+		}
+	}
+	g.RUnlock()
+	if len(unknown) <= limit {
+		return nil
+	}
+
+	var numNew int
+	for _, attr := range unknown {
+		gid, err := g.BelongsToReadOnly(attr, 0)
+		if err != nil {
+			return err
+		}
+		if gid != 0 {
+			continue
+		}
+		if numNew++; numNew > limit {
+			return errors.Errorf("Mutation would create more than %d new predicates, the limit "+
+				"set by predicates-per-mutation", limit)
+		}
+	}
+	return nil
+}
+
 func verifyTypes(ctx context.Context, m *pb.Mutations) error {
 	// Create a set of all the predicates included in this schema request.
 	reqPredSet := make(map[string]struct{}, len(m.Schema))
@@ -828,6 +980,14 @@ func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error)
 		clientDiscard = true
 	}
 
+	// If a target of the mutations of the txn still doesn't exist, abort the txn.
+	var targetErr error
+	if !tc.Aborted && x.Config.RequireExistingTargets {
+		if targetErr = checkPendingTargets(tc.StartTs); targetErr != nil {
+			tc.Aborted = true
+		}
+	}
+
 	pl := groups().Leader(0)
 	if pl == nil {
 		return 0, conn.ErrNoConnection
@@ -854,6 +1014,9 @@ func CommitOverNetwork(ctx context.Context, tc *api.TxnContext) (uint64, error)
 			// The server aborted the txn (not the client)
 			ostats.Record(ctx, x.TxnAborts.M(1))
 		}
+		if targetErr != nil {
+			return 0, targetErr
+		}
 		return 0, dgo.ErrAborted
 	}
 	ostats.Record(ctx, x.TxnCommits.M(1))
@@ -875,7 +1038,9 @@ func (w *grpcWorker) proposeAndWait(ctx context.Context, txnCtx *api.TxnContext,
 	// might be wrong because we might be missing out a commit which has updated the value. This
 	// wait here ensures that the proposal would only be registered after seeing txn status of all
 	// pending transactions. Thus, the ordering would be correct.
-	if err := posting.Oracle().WaitForTs(ctx, m.StartTs); err != nil {
+	// The mutations of a transaction in read-committed mode can read the data later than its start
+	// ts.
+	if err := posting.Oracle().WaitForTs(ctx, x.Max(m.StartTs, m.ReadCommittedTs)); err != nil {
 		return err
 	}
 
@@ -17,8 +17,11 @@
 package worker
 
 import (
+	"context"
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -110,6 +113,275 @@ func TestValidateEdgeTypeError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestValidateAndConvertBinary(t *testing.T) {
+	defer func(enabled bool) { x.Config.BinaryBase64 = enabled }(x.Config.BinaryBase64)
+	su := &pb.SchemaUpdate{ValueType: pb.Posting_BINARY}
+	// Without binary-base64, a value given as text is stored as is.
+	x.Config.BinaryBase64 = false
+	edge := &pb.DirectedEdge{
+		Value:     []byte("AAH/"),
+		ValueType: pb.Posting_DEFAULT,
+		Attr:      x.GalaxyAttr("thumbnail"),
+	}
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, []byte("AAH/"), edge.Value)
+
+	// With it, a value given as text, as in JSON, is decoded from base64.
+	x.Config.BinaryBase64 = true
+	edge = &pb.DirectedEdge{
+		Value:     []byte("AAH/"),
+		ValueType: pb.Posting_DEFAULT,
+		Attr:      x.GalaxyAttr("thumbnail"),
+	}
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, pb.Posting_BINARY, edge.ValueType)
+	require.Equal(t, []byte{0, 1, 0xff}, edge.Value)
+
+	// A value given as bytes is stored as is.
+	edge = &pb.DirectedEdge{
+		Value:     []byte("AAH/"),
+		ValueType: pb.Posting_BINARY,
+		Attr:      x.GalaxyAttr("thumbnail"),
+	}
+	require.NoError(t, ValidateAndConvert(edge, su))
+	require.Equal(t, []byte("AAH/"), edge.Value)
+
+	err := ValidateAndConvert(&pb.DirectedEdge{
+		Value:     []byte("not base64!"),
+		ValueType: pb.Posting_STRING,
+		Attr:      x.GalaxyAttr("thumbnail"),
+	}, su)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Input for predicate "thumbnail" of type binary`)
+}
+
+func TestValidateAndConvertDecimal(t *testing.T) {
+	convert := func(su *pb.SchemaUpdate, val string) (string, error) {
+		edge := &pb.DirectedEdge{
+			Value:     []byte(val),
+			ValueType: pb.Posting_DEFAULT,
+			Attr:      x.GalaxyAttr("price"),
+		}
+		if err := ValidateAndConvert(edge, su); err != nil {
+			return "", err
+		}
+		require.Equal(t, pb.Posting_DECIMAL, edge.ValueType)
+		var d types.Decimal
+		require.NoError(t, d.UnmarshalBinary(edge.Value))
+		return d.String(), nil
+	}
+
+	// Without a scale, the digits after the decimal point are kept as given.
+	su := &pb.SchemaUpdate{ValueType: pb.Posting_DECIMAL}
+	for in, out := range map[string]string{"19.99": "19.99", "0.125": "0.125", "-3.5": "-3.5",
+		"7": "7", "1.50": "1.50"} {
+		d, err := convert(su, in)
+		require.NoError(t, err, in)
+		require.Equal(t, out, d, in)
+	}
+
+	// With a scale, the values are padded to it, and more digits are rejected.
+	su.DecimalScale = 2
+	for in, out := range map[string]string{"19.99": "19.99", "19.9": "19.90", "7": "7.00"} {
+		d, err := convert(su, in)
+		require.NoError(t, err, in)
+		require.Equal(t, out, d, in)
+	}
+	_, err := convert(su, "19.999")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Input for predicate "price"`)
+	require.Contains(t, err.Error(), "more than 2 digits after the decimal point")
+}
+
+func TestCheckBinarySize(t *testing.T) {
+	defer func(limit int64) { x.Config.LimitBinaryValue = limit }(x.Config.LimitBinaryValue)
+	x.Config.LimitBinaryValue = 4
+
+	edge := func(size int) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Value:     make([]byte, size),
+			ValueType: pb.Posting_BINARY,
+			Attr:      x.GalaxyAttr("thumbnail"),
+		}
+	}
+	require.NoError(t, checkBinarySize(edge(4), types.BinaryID))
+	err := checkBinarySize(edge(5), types.BinaryID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value of binary predicate "thumbnail" is 5 bytes`)
+
+	// The other types aren't limited.
+	require.NoError(t, checkBinarySize(edge(5), types.StringID))
+	x.Config.LimitBinaryValue = 0
+	require.NoError(t, checkBinarySize(edge(5), types.BinaryID))
+}
+
+func TestCheckPattern(t *testing.T) {
+	su := &pb.SchemaUpdate{
+		ValueType: pb.Posting_STRING,
+		Pattern:   "^[^@]+@[^@]+$",
+	}
+	edge := func(val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Value:     []byte(val),
+			ValueType: pb.Posting_STRING,
+			Attr:      x.GalaxyAttr("email"),
+		}
+	}
+
+	require.NoError(t, CheckPattern(edge("alice@dgraph.io"), su))
+	err := CheckPattern(edge("alice"), su)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value "alice" for predicate "email" doesn't match the `+
+		`pattern "^[^@]+@[^@]+$"`)
+
+	// Deleting a value doesn't need to match the pattern.
+	del := edge("alice")
+	del.Op = pb.DirectedEdge_DEL
+	require.NoError(t, CheckPattern(del, su))
+
+	require.NoError(t, CheckPattern(edge("alice"), &pb.SchemaUpdate{ValueType: pb.Posting_STRING}))
+}
+
+func TestCheckEnum(t *testing.T) {
+	su := &pb.SchemaUpdate{
+		ValueType:  pb.Posting_STRING,
+		EnumValues: []string{"active", "inactive", "banned"},
+	}
+	edge := func(val string) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Value:     []byte(val),
+			ValueType: pb.Posting_STRING,
+			Attr:      x.GalaxyAttr("status"),
+		}
+	}
+
+	require.NoError(t, CheckEnum(edge("banned"), su))
+	err := CheckEnum(edge("deleted"), su)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Value "deleted" for predicate "status" isn't one of the `+
+		`values of its enum: "active", "inactive", "banned"`)
+
+	del := edge("deleted")
+	del.Op = pb.DirectedEdge_DEL
+	require.NoError(t, CheckEnum(del, su))
+}
+
+func TestEnforceCardinality(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		spouse: uid @reverse @cardinality(one-to-one) .
+		ceo: uid @reverse @cardinality(one-to-one, reject) .
+	`), 1))
+	spouse, ceo := x.GalaxyAttr("spouse"), x.GalaxyAttr("ceo")
+	edge := func(attr string, src, dst uint64) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Entity:    src,
+			Attr:      attr,
+			ValueId:   dst,
+			ValueType: pb.Posting_UID,
+			Op:        pb.DirectedEdge_SET,
+		}
+	}
+	run := func(edge *pb.DirectedEdge) error {
+		startTs := timestamp()
+		txn := posting.Oracle().RegisterStartTs(startTs)
+		if err := runMutation(context.Background(), edge, txn); err != nil {
+			return err
+		}
+		txn.Update()
+		writer := posting.NewTxnWriter(pstore)
+		require.NoError(t, txn.CommitToDisk(writer, commitTs(startTs)))
+		require.NoError(t, writer.Flush())
+		return nil
+	}
+	uids := func(key []byte) []uint64 {
+		res, err := getOrCreate(key).Uids(posting.ListOptions{ReadTs: math.MaxUint64})
+		require.NoError(t, err)
+		return res.Uids
+	}
+
+	require.NoError(t, run(edge(spouse, 1, 2)))
+	// The edge of 0x1 is replaced by the one of 0x3.
+	require.NoError(t, run(edge(spouse, 3, 2)))
+	require.Empty(t, uids(x.DataKey(spouse, 1)))
+	require.Equal(t, []uint64{3}, uids(x.ReverseKey(spouse, 2)))
+
+	require.NoError(t, run(edge(ceo, 10, 20)))
+	err := run(edge(ceo, 11, 20))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Node 0x14 already has an edge ceo from 0xa")
+	err = run(edge(ceo, 10, 21))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Node 0xa already has an edge ceo to 0x14")
+	require.Equal(t, []uint64{10}, uids(x.ReverseKey(ceo, 20)))
+}
+
+func TestCheckCardinalityEdges(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte(`
+		employees: [uid] @reverse @cardinality(one-to-many) .
+		ceo: uid @reverse @cardinality(one, reject) .
+	`), 1))
+	employees, ceo := x.GalaxyAttr("employees"), x.GalaxyAttr("ceo")
+	edges := []*pb.DirectedEdge{
+		{Entity: 1, Attr: employees, ValueId: 3, Op: pb.DirectedEdge_SET},
+		{Entity: 1, Attr: employees, ValueId: 4, Op: pb.DirectedEdge_SET},
+		{Entity: 2, Attr: employees, ValueId: 5, Op: pb.DirectedEdge_SET},
+		{Entity: 2, Attr: employees, ValueId: 3, Op: pb.DirectedEdge_DEL},
+		{Entity: 1, Attr: ceo, ValueId: 3, Op: pb.DirectedEdge_SET},
+		{Entity: 2, Attr: ceo, ValueId: 3, Op: pb.DirectedEdge_SET},
+	}
+	ctx := context.Background()
+	require.NoError(t, checkCardinalityEdges(ctx, edges))
+
+	err := checkCardinalityEdges(ctx, append(edges,
+		&pb.DirectedEdge{Entity: 2, Attr: employees, ValueId: 4, Op: pb.DirectedEdge_SET}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Edges employees of nodes 0x1 and 0x2 both point to 0x4")
+
+	err = checkCardinalityEdges(ctx, append(edges,
+		&pb.DirectedEdge{Entity: 1, Attr: ceo, ValueId: 4, Op: pb.DirectedEdge_SET}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Node 0x1 is given two edges ceo, to 0x3 and 0x4")
+}
+
+func TestCheckIncrement(t *testing.T) {
+	edge := func(typ pb.Posting_ValType) *pb.DirectedEdge {
+		return &pb.DirectedEdge{
+			Value:     []byte{1, 0, 0, 0, 0, 0, 0, 0},
+			ValueType: typ,
+			Attr:      x.GalaxyAttr("views"),
+			Op:        pb.DirectedEdge_INCR,
+		}
+	}
+
+	require.NoError(t, CheckIncrement(edge(pb.Posting_INT), &pb.SchemaUpdate{
+		ValueType: pb.Posting_INT}))
+	require.NoError(t, CheckIncrement(edge(pb.Posting_INT), &pb.SchemaUpdate{
+		ValueType: pb.Posting_FLOAT}))
+	for _, tc := range []struct {
+		typ pb.Posting_ValType
+		su  *pb.SchemaUpdate
+		err string
+	}{
+		{pb.Posting_FLOAT, &pb.SchemaUpdate{ValueType: pb.Posting_INT},
+			`Predicate "views" is of type int, it can't be incremented by a float`},
+		{pb.Posting_INT, &pb.SchemaUpdate{ValueType: pb.Posting_STRING},
+			`Predicate "views" is of type string, only int and float predicates can be`},
+		{pb.Posting_INT, &pb.SchemaUpdate{ValueType: pb.Posting_INT, List: true},
+			`Predicate "views" is a list, it can't be incremented`},
+		{pb.Posting_INT, &pb.SchemaUpdate{ValueType: pb.Posting_INT, Tokenizer: []string{"int"}},
+			`Predicate "views" has an index, it can't be incremented`},
+	} {
+		err := CheckIncrement(edge(tc.typ), tc.su)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+
+	// The other edges are left to the usual checks.
+	set := edge(pb.Posting_STRING)
+	set.Op = pb.DirectedEdge_SET
+	require.NoError(t, CheckIncrement(set, &pb.SchemaUpdate{ValueType: pb.Posting_STRING}))
+}
+
 func TestPopulateMutationMap(t *testing.T) {
 	edges := []*pb.DirectedEdge{{
 		Value: []byte("set edge"),
@@ -261,3 +533,56 @@ func TestTypeSanityCheck(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Field in type definition cannot have tokenizers")
 }
+
+func TestEdgeTargets(t *testing.T) {
+	friend := x.GalaxyAttr("friend")
+	edges := []*pb.DirectedEdge{
+		// 0x2 is created by the same mutation.
+		{Entity: 1, Attr: friend, ValueId: 2, ValueType: pb.Posting_UID, Op: pb.DirectedEdge_SET},
+		{Entity: 2, Attr: x.GalaxyAttr("name"), Value: []byte("b"), Op: pb.DirectedEdge_SET},
+		{Entity: 1, Attr: friend, ValueId: 3, ValueType: pb.Posting_UID, Op: pb.DirectedEdge_SET},
+		{Entity: 2, Attr: friend, ValueId: 3, ValueType: pb.Posting_UID, Op: pb.DirectedEdge_SET},
+		// Deleting an edge to a missing node is fine.
+		{Entity: 1, Attr: friend, ValueId: 4, ValueType: pb.Posting_UID, Op: pb.DirectedEdge_DEL},
+	}
+	require.Equal(t, map[uint64]string{3: friend}, edgeTargets(edges))
+}
+
+func TestPendingTargets(t *testing.T) {
+	// The targets of the transactions older than this are dropped.
+	abortOlderThan := x.WorkerConfig.AbortOlderThan
+	defer func() { x.WorkerConfig.AbortOlderThan = abortOlderThan }()
+	x.WorkerConfig.AbortOlderThan = time.Minute
+	friend := x.GalaxyAttr("friend")
+	addPendingTargets(10, map[uint64]string{3: friend, 4: friend})
+	addPendingTargets(11, map[uint64]string{5: friend})
+
+	// A later mutation of the transaction creates 0x3, but deleting from 0x4 doesn't create it.
+	resolvePendingTargets(10, []*pb.DirectedEdge{
+		{Entity: 3, Attr: x.GalaxyAttr("name"), Value: []byte("c"), Op: pb.DirectedEdge_SET},
+		{Entity: 4, Attr: x.GalaxyAttr("name"), Value: []byte("d"), Op: pb.DirectedEdge_DEL},
+		{Entity: 5, Attr: x.GalaxyAttr("name"), Value: []byte("e"), Op: pb.DirectedEdge_SET},
+	})
+	err := checkPendingTargets(10)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Edge friend points to uid 0x4, which doesn't have any data")
+	// The targets are dropped with the transaction.
+	require.NoError(t, checkPendingTargets(10))
+
+	// The targets of the other transactions aren't resolved by its mutations.
+	require.Error(t, checkPendingTargets(11))
+	resolvePendingTargets(12, []*pb.DirectedEdge{
+		{Entity: 5, Attr: x.GalaxyAttr("name"), Value: []byte("e"), Op: pb.DirectedEdge_SET},
+	})
+	require.NoError(t, checkPendingTargets(12))
+}
+
+func TestCheckTargetsDisabled(t *testing.T) {
+	// Without the limit, the targets aren't looked for.
+	require.False(t, x.Config.RequireExistingTargets)
+	require.NoError(t, checkTargets(WithCommitNow(context.Background()), &pb.Mutations{
+		StartTs: 20,
+		Edges: []*pb.DirectedEdge{{Entity: 1, Attr: x.GalaxyAttr("friend"), ValueId: 0xdead,
+			ValueType: pb.Posting_UID, Op: pb.DirectedEdge_SET}},
+	}))
+}
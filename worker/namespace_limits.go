@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The namespaces of a shared cluster can be limited with --limit "namespace-query-rate",
+// "namespace-mutation-rate" and "namespace-storage-mb". The rates are the number of requests
+// per second a namespace can send to an alpha, each alpha counts the requests it receives. The
+// storage quota is checked against the on-disk size of the predicates of the namespace, which
+// the groups report to Zero periodically, so a namespace can go somewhat over its quota before
+// its writes are blocked. The galaxy namespace isn't limited, neither are the requests which
+// don't have a namespace, as the internal ones.
+
+var nsLimits struct {
+	queries   *x.RateLimiter
+	mutations *x.RateLimiter
+}
+
+// InitNamespaceLimits sets up the rate limiters of the namespaces from --limit. The limiters
+// are refilled every second until the closer is signaled.
+func InitNamespaceLimits(closer *z.Closer) {
+	if rate := x.Config.LimitNamespaceQueryRate; rate > 0 {
+		nsLimits.queries = x.NewRateLimiter(rate, time.Second, closer)
+	}
+	if rate := x.Config.LimitNamespaceMutationRate; rate > 0 {
+		nsLimits.mutations = x.NewRateLimiter(rate, time.Second, closer)
+	}
+}
+
+// limitedNamespace returns the namespace of the request, and false if it isn't limited.
+func limitedNamespace(ctx context.Context) (uint64, bool) {
+	ns, err := x.ExtractNamespace(ctx)
+	if err != nil || ns == x.GalaxyNamespace {
+		return 0, false
+	}
+	return ns, true
+}
+
+// CheckNamespaceQueryRate returns an error if the namespace of the request has already sent
+// namespace-query-rate queries in the last second.
+func CheckNamespaceQueryRate(ctx context.Context) error {
+	return checkNamespaceRate(ctx, nsLimits.queries, "query", x.Config.LimitNamespaceQueryRate)
+}
+
+// CheckNamespaceMutationRate returns an error if the namespace of the request has already sent
+// namespace-mutation-rate mutations in the last second.
+func CheckNamespaceMutationRate(ctx context.Context) error {
+	return checkNamespaceRate(ctx, nsLimits.mutations, "mutation",
+		x.Config.LimitNamespaceMutationRate)
+}
+
+func checkNamespaceRate(ctx context.Context, limiter *x.RateLimiter, kind string,
+	rate int64) error {
+
+	if limiter == nil {
+		return nil
+	}
+	ns, ok := limitedNamespace(ctx)
+	if !ok || limiter.Allow(ns, 1) {
+		return nil
+	}
+	return errors.Errorf("Namespace %#x exceeded its %s rate of %d per second. Please retry "+
+		"after some time.", ns, kind, rate)
+}
+
+// checkStorageQuota rejects the mutations which set data in a namespace using more than
+// namespace-storage-mb on disk. Deletions are still allowed, so that a namespace over its quota
+// can free some space.
+func checkStorageQuota(m *pb.Mutations) error {
+	quota := x.Config.LimitNamespaceStorage
+	if quota <= 0 {
+		return nil
+	}
+	namespaces := make(map[uint64]struct{})
+	for _, edge := range m.Edges {
+		if edge.Op == pb.DirectedEdge_DEL {
+			continue
+		}
+		if ns := x.ParseNamespace(edge.Attr); ns != x.GalaxyNamespace {
+			namespaces[ns] = struct{}{}
+		}
+	}
+	for ns := range namespaces {
+		if size := namespaceDiskBytes(ns); size > quota {
+			return errors.Errorf("Namespace %#x uses %d MB on disk, which exceeds its storage "+
+				"quota of %d MB. Only deletions are allowed until some space is freed.",
+				ns, size>>20, quota>>20)
+		}
+	}
+	return nil
+}
+
+// namespaceDiskBytes returns the on-disk size of the predicates of the given namespace, as last
+// reported by the groups serving them.
+func namespaceDiskBytes(ns uint64) int64 {
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	if g.state == nil {
+		return 0
+	}
+	var size int64
+	for _, group := range g.state.Groups {
+		for pred, tablet := range group.Tablets {
+			if x.ParseNamespace(pred) == ns {
+				size += tablet.GetOnDiskBytes()
+			}
+		}
+	}
+	return size
+}
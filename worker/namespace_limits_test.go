@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCheckNamespaceRate(t *testing.T) {
+	closer := z.NewCloser(0)
+	defer closer.SignalAndWait()
+	limiter := x.NewRateLimiter(2, time.Hour, closer)
+
+	ctx := x.AttachNamespace(context.Background(), 2)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, checkNamespaceRate(ctx, limiter, "query", 2))
+	}
+	err := checkNamespaceRate(ctx, limiter, "query", 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Namespace 0x2 exceeded its query rate of 2 per second")
+
+	// The other namespaces have their own tokens, and the galaxy one isn't limited.
+	require.NoError(t, checkNamespaceRate(x.AttachNamespace(context.Background(), 3), limiter,
+		"query", 2))
+	galaxy := x.AttachNamespace(context.Background(), x.GalaxyNamespace)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, checkNamespaceRate(galaxy, limiter, "query", 2))
+	}
+	require.NoError(t, checkNamespaceRate(context.Background(), limiter, "query", 2))
+}
+
+func TestCheckStorageQuota(t *testing.T) {
+	g := groups()
+	g.Lock()
+	oldState := g.state
+	g.state = &pb.MembershipState{Groups: map[uint32]*pb.Group{
+		1: {Tablets: map[string]*pb.Tablet{
+			x.NamespaceAttr(2, "name"): {OnDiskBytes: 3 << 20},
+			x.NamespaceAttr(2, "age"):  {OnDiskBytes: 2 << 20},
+			x.NamespaceAttr(3, "name"): {OnDiskBytes: 1 << 20},
+			x.GalaxyAttr("name"):       {OnDiskBytes: 100 << 20},
+		}},
+	}}
+	g.Unlock()
+	oldQuota := x.Config.LimitNamespaceStorage
+	x.Config.LimitNamespaceStorage = 4 << 20
+	defer func() {
+		g.Lock()
+		g.state = oldState
+		g.Unlock()
+		x.Config.LimitNamespaceStorage = oldQuota
+	}()
+
+	set := func(attr string) *pb.Mutations {
+		return &pb.Mutations{Edges: []*pb.DirectedEdge{{Entity: 1, Attr: attr,
+			Value: []byte("a")}}}
+	}
+	err := checkStorageQuota(set(x.NamespaceAttr(2, "name")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Namespace 0x2 uses 5 MB on disk, which exceeds its "+
+		"storage quota of 4 MB")
+	require.NoError(t, checkStorageQuota(set(x.NamespaceAttr(3, "name"))))
+	require.NoError(t, checkStorageQuota(set(x.GalaxyAttr("name"))))
+
+	// The namespace over its quota can still delete some data.
+	require.NoError(t, checkStorageQuota(&pb.Mutations{Edges: []*pb.DirectedEdge{{Entity: 1,
+		Attr: x.NamespaceAttr(2, "name"), Value: []byte("a"), Op: pb.DirectedEdge_DEL}}}))
+}
@@ -15,11 +15,13 @@ package worker
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/url"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/dgraph-io/badger/v3"
 	"github.com/golang/glog"
 
 	"github.com/dgraph-io/dgraph/conn"
@@ -267,6 +269,11 @@ func handleRestoreProposal(ctx context.Context, req *pb.RestoreRequest, pidx uin
 	if err := schema.LoadFromDb(); err != nil {
 		return errors.Wrapf(err, "cannot load schema after restore")
 	}
+	// The requests are rejected until the indexes are ready, so that they don't return the
+	// results of incomplete indexes.
+	if err := rebuildMissingIndexes(ctx, preds, req.RestoreTs); err != nil {
+		return errors.Wrapf(err, "cannot rebuild the indexes after restore")
+	}
 
 	ResetAclCache()
 	// Propose a snapshot immediately after all the work is done to prevent the restore
@@ -292,6 +299,66 @@ func handleRestoreProposal(ctx context.Context, req *pb.RestoreRequest, pidx uin
 	return nil
 }
 
+// rebuildMissingIndexes rebuilds the indexes of the restored predicates which are missing, e.g.
+// if the backup was taken while they were being built in the background.
+func rebuildMissingIndexes(ctx context.Context, preds []string, ts uint64) error {
+	for _, pred := range preds {
+		su, ok := schema.State().Get(ctx, pred)
+		if !ok || !indexesMissing(pred, &su) {
+			continue
+		}
+		glog.Infof("Rebuilding the indexes of restored predicate %s", x.ParseAttr(pred))
+		rb := posting.IndexRebuild{Attr: pred, StartTs: ts, CurrentSchema: &su}
+		if err := rb.DropIndexes(ctx); err != nil {
+			return err
+		}
+		if err := rb.BuildIndexes(ctx); err != nil {
+			return errors.Wrapf(err, "while rebuilding the indexes of %s", x.ParseAttr(pred))
+		}
+	}
+	return nil
+}
+
+// indexesMissing returns true if the predicate has data but no keys for one of the indexes of
+// its schema.
+func indexesMissing(pred string, su *pb.SchemaUpdate) bool {
+	pk := x.ParsedKey{Attr: pred}
+	var prefixes [][]byte
+	switch su.Directive {
+	case pb.SchemaUpdate_INDEX:
+		prefixes = append(prefixes, pk.IndexPrefix())
+	case pb.SchemaUpdate_REVERSE:
+		prefixes = append(prefixes, pk.ReversePrefix())
+	}
+	if su.Count {
+		prefixes = append(prefixes, pk.CountPrefix(false))
+	}
+	if len(prefixes) == 0 {
+		return false
+	}
+
+	txn := pstore.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	hasKeys := func(prefix []byte) bool {
+		iterOpt := badger.DefaultIteratorOptions
+		iterOpt.PrefetchValues = false
+		iterOpt.Prefix = prefix
+		it := txn.NewIterator(iterOpt)
+		defer it.Close()
+		it.Rewind()
+		return it.Valid()
+	}
+	if !hasKeys(pk.DataPrefix()) {
+		return false
+	}
+	for _, prefix := range prefixes {
+		if !hasKeys(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // create a config object from the request for use with enc package.
 func getEncConfig(req *pb.RestoreRequest) (*viper.Viper, error) {
 	config := viper.New()
@@ -0,0 +1,56 @@
+// +build !oss
+
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Dgraph Community License (the "License"); you
+ * may not use this file except in compliance with the License. You
+ * may obtain a copy of the License at
+ *
+ *     https://github.com/dgraph-io/dgraph/blob/master/licenses/DCL.txt
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestIndexesMissing(t *testing.T) {
+	attr := x.GalaxyAttr("restored")
+	su := &pb.SchemaUpdate{
+		Predicate: attr,
+		ValueType: pb.Posting_STRING,
+		Directive: pb.SchemaUpdate_INDEX,
+		Tokenizer: []string{"exact"},
+	}
+	// Without data, there is nothing to rebuild.
+	require.False(t, indexesMissing(attr, su))
+
+	// The data was restored without its index.
+	txn := pstore.NewTransactionAt(1, true)
+	pl := &pb.PostingList{Postings: []*pb.Posting{{Uid: 1, Value: []byte("a")}}}
+	val, err := pl.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, txn.Set(x.DataKey(attr, 1), val))
+	require.NoError(t, txn.CommitAt(1, nil))
+	txn.Discard()
+	require.True(t, indexesMissing(attr, su))
+	// The schema without index needs none.
+	require.False(t, indexesMissing(attr, &pb.SchemaUpdate{Predicate: attr}))
+
+	txn = pstore.NewTransactionAt(2, true)
+	require.NoError(t, txn.Set(x.IndexKey(attr, "a"), val))
+	require.NoError(t, txn.CommitAt(2, nil))
+	txn.Discard()
+	require.False(t, indexesMissing(attr, su))
+
+	// The count index is checked too.
+	su.Count = true
+	require.True(t, indexesMissing(attr, su))
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrGroupTimedOut is returned by ProcessTaskOverNetwork when the group serving the predicate
+// didn't reply within the group timeout of the query.
+var ErrGroupTimedOut = errors.New("The group serving the predicate didn't reply within the " +
+	"group timeout")
+
+type partialResultsKey struct{}
+
+// PartialResults records the groups which didn't reply within the group timeout of a query. Their
+// predicates are then missing from the results, which are incomplete: a node could be missing, or
+// a filter on the predicate could have kept nodes it should have removed.
+type PartialResults struct {
+	timeout time.Duration
+
+	mu     sync.Mutex
+	groups map[uint32]struct{}
+}
+
+// WithGroupTimeout returns a context for a query which gets the results of the predicates served
+// by another group only if the group replies within timeout.
+func WithGroupTimeout(ctx context.Context, timeout time.Duration) (context.Context,
+	*PartialResults) {
+
+	p := &PartialResults{timeout: timeout, groups: make(map[uint32]struct{})}
+	return context.WithValue(ctx, partialResultsKey{}, p), p
+}
+
+// PartialResultsOf returns the partial results of the query, or nil if it has no group timeout.
+func PartialResultsOf(ctx context.Context) *PartialResults {
+	p, _ := ctx.Value(partialResultsKey{}).(*PartialResults)
+	return p
+}
+
+// Timeout returns the group timeout of the query.
+func (p *PartialResults) Timeout() time.Duration {
+	return p.timeout
+}
+
+// TimedOutGroups returns the groups which didn't reply within the group timeout.
+func (p *PartialResults) TimedOutGroups() []uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	gids := make([]uint32, 0, len(p.groups))
+	for gid := range p.groups {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	return gids
+}
+
+// processWithGroupTimeout runs f against the group gid, with the group timeout of the query if
+// it has one. ErrGroupTimedOut is returned if f didn't return within the timeout.
+func processWithGroupTimeout(ctx context.Context, gid uint32,
+	f func(context.Context) (interface{}, error)) (interface{}, error) {
+
+	p := PartialResultsOf(ctx)
+	if p == nil {
+		return f(ctx)
+	}
+	fctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	reply, err := f(fctx)
+	// The query itself could have been cancelled, or have reached its own deadline.
+	if err != nil && ctx.Err() == nil && fctx.Err() == context.DeadlineExceeded {
+		p.mu.Lock()
+		p.groups[gid] = struct{}{}
+		p.mu.Unlock()
+		return nil, ErrGroupTimedOut
+	}
+	return reply, err
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessWithGroupTimeout(t *testing.T) {
+	slow := func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	fast := func(ctx context.Context) (interface{}, error) {
+		return "reply", nil
+	}
+
+	require.Nil(t, PartialResultsOf(context.Background()))
+	ctx, partial := WithGroupTimeout(context.Background(), 10*time.Millisecond)
+	require.Equal(t, partial, PartialResultsOf(ctx))
+	reply, err := processWithGroupTimeout(ctx, 1, fast)
+	require.NoError(t, err)
+	require.Equal(t, "reply", reply)
+	_, err = processWithGroupTimeout(ctx, 2, slow)
+	require.Equal(t, ErrGroupTimedOut, err)
+	require.Equal(t, []uint32{2}, partial.TimedOutGroups())
+
+	// The query being cancelled isn't a group timing out.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = processWithGroupTimeout(cctx, 3, slow)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, []uint32{2}, partial.TimedOutGroups())
+
+	// Without a group timeout, the request waits for the group.
+	qctx, qcancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer qcancel()
+	_, err = processWithGroupTimeout(qctx, 2, slow)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
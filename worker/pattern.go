@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// patterns caches the compiled regular expressions of the @pattern directives, so that they
+// aren't compiled again for every value written.
+var patterns sync.Map
+
+func compiledPattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// CheckPattern returns an error if the value set by the edge doesn't match the @pattern of the
+// predicate. It is called once the value has been converted to the type of the schema by
+// ValidateAndConvert. For a list predicate, every value is set by its own edge, so each of them
+// is checked.
+func CheckPattern(edge *pb.DirectedEdge, su *pb.SchemaUpdate) error {
+	if su.GetPattern() == "" || edge.Op != pb.DirectedEdge_SET ||
+		types.TypeID(edge.ValueType) != types.StringID {
+		return nil
+	}
+	re, err := compiledPattern(su.GetPattern())
+	if err != nil {
+		return errors.Wrapf(err, "while compiling the pattern of predicate %q",
+			x.ParseAttr(edge.Attr))
+	}
+	if !re.Match(edge.Value) {
+		return errors.Errorf("Value %q for predicate %q doesn't match the pattern %q",
+			edge.Value, x.ParseAttr(edge.Attr), su.GetPattern())
+	}
+	return nil
+}
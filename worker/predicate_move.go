@@ -56,6 +56,11 @@ func populateKeyValues(ctx context.Context, kvs []*bpb.KV) error {
 	if err := writer.Flush(); err != nil {
 		return err
 	}
+	// The keys imported with ImportKeyValues are merged with the stored lists, which could be
+	// cached.
+	for _, kv := range kvs {
+		posting.RemoveCacheFor(kv.Key)
+	}
 	pk, err := x.Parse(kvs[0].Key)
 	if err != nil {
 		return errors.Errorf("while parsing KV: %+v, got error: %v", kvs[0], err)
@@ -26,6 +26,7 @@ import (
 	"github.com/dgraph-io/dgraph/conn"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
+	"github.com/dgraph-io/dgraph/types"
 	"github.com/dgraph-io/dgraph/x"
 	"github.com/dgraph-io/ristretto/z"
 
@@ -52,7 +53,11 @@ var limiter rateLimiter
 type rateLimiter struct {
 	iou int
 	max int
-	c   *sync.Cond
+	// wait is how long incr waits for the pending proposals to go below max before rejecting
+	// the proposal with errUnableToServe, set with --raft "pending-proposals-wait". It waits
+	// until the context is done if wait is zero.
+	wait time.Duration
+	c    *sync.Cond
 }
 
 // Instead of using the time/rate package, we use this simple one, because that
@@ -78,6 +83,13 @@ func (rl *rateLimiter) incr(ctx context.Context, retry int) error {
 	// channel do its natural rate limiting.
 	weight := 1 << uint(retry) // Use an exponentially increasing weight.
 	c := rl.c
+	var deadline time.Time
+	if rl.wait > 0 {
+		// The waiters are woken up when the wait is over, without waiting for the next bleed.
+		deadline = time.Now().Add(rl.wait)
+		t := time.AfterFunc(rl.wait, c.Broadcast)
+		defer t.Stop()
+	}
 	c.L.Lock()
 
 	for {
@@ -86,6 +98,11 @@ func (rl *rateLimiter) incr(ctx context.Context, retry int) error {
 			c.L.Unlock()
 			return nil
 		}
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			// Reject the proposal quickly, the client can retry the mutation later.
+			c.L.Unlock()
+			return errUnableToServe
+		}
 		c.Wait()
 		// We woke up after some time. Let's check if the context is done.
 		select {
@@ -150,6 +167,10 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 	// Set this to disable retrying mechanism, and using the user-specified
 	// timeout.
 	var noTimeout bool
+	// Only the proposals of mutations without schema updates or drops are batched.
+	batch := n.batcher != nil && proposal.Mutations != nil &&
+		len(proposal.Mutations.Schema) == 0 && len(proposal.Mutations.Types) == 0 &&
+		proposal.Mutations.DropOp == pb.Mutations_NONE
 
 	checkTablet := func(pred string) error {
 		tablet, err := groups().Tablet(pred)
@@ -184,11 +205,25 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 						"types/predicates.",
 						x.ParseAttr(edge.Attr))
 				}
+				if err := checkBinarySize(edge, types.TypeID(edge.ValueType)); err != nil {
+					return err
+				}
 				continue
+			} else if err := CheckIncrement(edge, &su); err != nil {
+				return err
 			} else if err := ValidateAndConvert(edge, &su); err != nil {
 				return err
+			} else if err := CheckPattern(edge, &su); err != nil {
+				return err
+			} else if err := CheckEnum(edge, &su); err != nil {
+				return err
+			} else if err := checkBinarySize(edge, types.TypeID(su.ValueType)); err != nil {
+				return err
 			}
 		}
+		if err := checkCardinalityEdges(ctx, proposal.Mutations.Edges); err != nil {
+			return err
+		}
 
 		for _, schema := range proposal.Mutations.Schema {
 			if err := checkTablet(schema.Predicate); err != nil {
@@ -233,7 +268,12 @@ func (n *node) proposeAndWait(ctx context.Context, proposal *pb.Proposal) (perr
 
 		span.Annotatef(nil, "Proposing with key: %d. Timeout: %v", key, timeout)
 
-		if err = n.Raft().Propose(cctx, data); err != nil {
+		if batch {
+			err = n.batcher.propose(cctx, data)
+		} else {
+			err = n.Raft().Propose(cctx, data)
+		}
+		if err != nil {
 			return errors.Wrapf(err, "While proposing")
 		}
 
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgraph-io/ristretto/z"
+	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+// maxBatchedProposals is the maximum number of proposals sent in a single Raft message.
+const maxBatchedProposals = 1000
+
+// proposalBatcher coalesces the mutation proposals made within a window, set with --raft
+// "mutation-batch-window", into a single Raft message, which reduces the per-proposal overhead
+// of Raft under a high write rate, at the cost of up to the window of latency for every mutation,
+// including the ones with CommitNow. The commits themselves are proposed by Zero and aren't
+// delayed. The proposals are sent in the order they were received, and each one is still a
+// separate entry of the Raft log, so they are applied and retried individually.
+type proposalBatcher struct {
+	window time.Duration
+	max    int
+	ch     chan *batchedProposal
+	// step sends the entries of a batch to Raft.
+	step func(ctx context.Context, entries []raftpb.Entry) error
+}
+
+type batchedProposal struct {
+	data  []byte
+	errCh chan error
+}
+
+func newProposalBatcher(window time.Duration,
+	step func(ctx context.Context, entries []raftpb.Entry) error) *proposalBatcher {
+	return &proposalBatcher{
+		window: window,
+		max:    maxBatchedProposals,
+		ch:     make(chan *batchedProposal, maxBatchedProposals),
+		step:   step,
+	}
+}
+
+// stepProposals sends the entries of a batch to Raft in a single message. Unlike Propose, Step
+// doesn't wait for Raft to take the proposals, so it doesn't return ErrProposalDropped when Raft
+// drops them, e.g. while there is no leader or the leadership is being transferred. These cases
+// are checked first, and the proposals are rejected with ErrProposalDropped as Propose would. A
+// proposal which is still dropped is retried by proposeAndWait once its timeout is reached.
+func stepProposals(ctx context.Context, rn raft.Node, entries []raftpb.Entry) error {
+	if st := rn.Status(); st.Lead == raft.None || st.LeadTransferee != raft.None {
+		return raft.ErrProposalDropped
+	}
+	return rn.Step(ctx, raftpb.Message{Type: raftpb.MsgProp, Entries: entries})
+}
+
+// propose queues data to be sent with the next batch, and waits until the batch is sent to Raft.
+// As for raft.Node.Propose, this doesn't wait for the proposal to be committed.
+func (b *proposalBatcher) propose(ctx context.Context, data []byte) error {
+	// The channel is buffered, so that the batcher doesn't block on a proposal which is done.
+	p := &batchedProposal{data: data, errCh: make(chan error, 1)}
+	select {
+	case b.ch <- p:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-p.errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run sends the proposals to Raft in batches, until the closer is signalled. A batch is sent
+// once the window has passed since its first proposal was received, or once it is full.
+func (b *proposalBatcher) run(closer *z.Closer) {
+	defer closer.Done()
+
+	batch := make([]*batchedProposal, 0, b.max)
+	for {
+		select {
+		case p := <-b.ch:
+			batch = append(batch[:0], p)
+		case <-closer.HasBeenClosed():
+			return
+		}
+
+		timer := time.NewTimer(b.window)
+	collect:
+		for len(batch) < b.max {
+			select {
+			case p := <-b.ch:
+				batch = append(batch, p)
+			case <-timer.C:
+				break collect
+			case <-closer.HasBeenClosed():
+				timer.Stop()
+				return
+			}
+		}
+		timer.Stop()
+
+		entries := make([]raftpb.Entry, len(batch))
+		for i, p := range batch {
+			entries[i] = raftpb.Entry{Data: p.data}
+		}
+		err := b.step(closer.Ctx(), entries)
+		for _, p := range batch {
+			p.errCh <- err
+		}
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/raft"
+	"go.etcd.io/etcd/raft/raftpb"
+)
+
+// stepRecorder records the batches sent to Raft by a proposalBatcher. Each step sleeps for cost,
+// to emulate the per-message overhead of Raft.
+type stepRecorder struct {
+	sync.Mutex
+	batches [][]raftpb.Entry
+	cost    time.Duration
+	err     error
+}
+
+func (r *stepRecorder) step(ctx context.Context, entries []raftpb.Entry) error {
+	time.Sleep(r.cost)
+	r.Lock()
+	defer r.Unlock()
+	r.batches = append(r.batches, entries)
+	return r.err
+}
+
+func (r *stepRecorder) numEntries() int {
+	r.Lock()
+	defer r.Unlock()
+	var n int
+	for _, b := range r.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func startBatcher(window time.Duration, r *stepRecorder) (*proposalBatcher, *z.Closer) {
+	b := newProposalBatcher(window, r.step)
+	closer := z.NewCloser(1)
+	go b.run(closer)
+	return b, closer
+}
+
+func proposalData(i int) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(i))
+	return buf[:]
+}
+
+func TestProposalBatcher(t *testing.T) {
+	r := &stepRecorder{}
+	b, closer := startBatcher(50*time.Millisecond, r)
+	defer closer.SignalAndWait()
+
+	const num = 100
+	var wg sync.WaitGroup
+	for i := 0; i < num; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, b.propose(context.Background(), proposalData(i)))
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, num, r.numEntries())
+	require.Less(t, len(r.batches), num)
+}
+
+func TestProposalBatcherOrder(t *testing.T) {
+	r := &stepRecorder{}
+	b, closer := startBatcher(5*time.Millisecond, r)
+	defer closer.SignalAndWait()
+
+	// The proposals are made one after the other, by a single goroutine, but without waiting
+	// for the previous one to be sent.
+	const num = 50
+	errCh := make(chan error, num)
+	for i := 0; i < num; i++ {
+		p := &batchedProposal{data: proposalData(i), errCh: errCh}
+		b.ch <- p
+	}
+	for i := 0; i < num; i++ {
+		require.NoError(t, <-errCh)
+	}
+
+	var got []int
+	r.Lock()
+	for _, batch := range r.batches {
+		for _, e := range batch {
+			got = append(got, int(binary.BigEndian.Uint64(e.Data)))
+		}
+	}
+	r.Unlock()
+	require.Len(t, got, num)
+	for i, v := range got {
+		require.Equal(t, i, v)
+	}
+}
+
+func TestProposalBatcherMax(t *testing.T) {
+	r := &stepRecorder{}
+	b := newProposalBatcher(time.Hour, r.step)
+	b.max = 10
+	closer := z.NewCloser(1)
+	go b.run(closer)
+	defer closer.SignalAndWait()
+
+	// The batches are sent once full, without waiting for the window.
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			require.NoError(t, b.propose(context.Background(), proposalData(i)))
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, r.batches, 3)
+	for _, batch := range r.batches {
+		require.Len(t, batch, 10)
+	}
+}
+
+func TestProposalBatcherError(t *testing.T) {
+	r := &stepRecorder{err: errors.New("proposal dropped")}
+	b, closer := startBatcher(time.Millisecond, r)
+
+	require.EqualError(t, b.propose(context.Background(), proposalData(0)), "proposal dropped")
+
+	// The proposals wait for their context once the batcher is stopped.
+	closer.SignalAndWait()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, b.propose(ctx, proposalData(1)))
+}
+
+func TestStepProposals(t *testing.T) {
+	storage := raft.NewMemoryStorage()
+	rn := raft.StartNode(&raft.Config{
+		ID:              1,
+		ElectionTick:    10,
+		HeartbeatTick:   1,
+		Storage:         storage,
+		MaxSizePerMsg:   1 << 20,
+		MaxInflightMsgs: 256,
+	}, []raft.Peer{{ID: 1}})
+	defer rn.Stop()
+	ctx := context.Background()
+	entry := []raftpb.Entry{{Data: proposalData(1)}}
+
+	// Without a leader, Raft would drop the proposals without an error.
+	require.Equal(t, raft.ErrProposalDropped, stepProposals(ctx, rn, entry))
+
+	// advance applies the updates of the node until done returns true. The configuration of the
+	// peer has to be applied for the node to campaign.
+	advance := func(done func(rd raft.Ready) bool) {
+		for rd := range rn.Ready() {
+			require.NoError(t, storage.Append(rd.Entries))
+			for _, e := range rd.CommittedEntries {
+				if e.Type == raftpb.EntryConfChange {
+					var cc raftpb.ConfChange
+					require.NoError(t, cc.Unmarshal(e.Data))
+					rn.ApplyConfChange(cc)
+				}
+			}
+			rn.Advance()
+			if done(rd) {
+				return
+			}
+		}
+	}
+	advance(func(rd raft.Ready) bool { return len(rd.CommittedEntries) > 0 })
+	require.NoError(t, rn.Campaign(ctx))
+	advance(func(rd raft.Ready) bool { return rd.SoftState != nil && rd.SoftState.Lead == 1 })
+	require.NoError(t, stepProposals(ctx, rn, entry))
+	advance(func(rd raft.Ready) bool {
+		return len(rd.Entries) > 0 && bytes.Equal(rd.Entries[len(rd.Entries)-1].Data, entry[0].Data)
+	})
+}
+
+// BenchmarkProposalBatcher measures the throughput of concurrent proposals, with the overhead
+// of a Raft message emulated by a sleep, without batching and with several windows.
+func BenchmarkProposalBatcher(b *testing.B) {
+	const stepCost = 100 * time.Microsecond
+
+	b.Run("unbatched", func(b *testing.B) {
+		r := &stepRecorder{cost: stepCost}
+		var mu sync.Mutex
+		b.SetParallelism(64)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				// As for raft.Node.Propose, the messages are stepped one at a time.
+				mu.Lock()
+				err := r.step(context.Background(), []raftpb.Entry{{Data: proposalData(0)}})
+				mu.Unlock()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	})
+
+	for _, window := range []time.Duration{time.Millisecond, 5 * time.Millisecond} {
+		b.Run(fmt.Sprintf("window=%s", window), func(b *testing.B) {
+			r := &stepRecorder{cost: stepCost}
+			batcher, closer := startBatcher(window, r)
+			defer closer.SignalAndWait()
+
+			b.SetParallelism(64)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := batcher.propose(context.Background(), proposalData(0)); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.StopTimer()
+			r.Lock()
+			numBatches := len(r.batches)
+			r.Unlock()
+			b.ReportMetric(float64(r.numEntries())/float64(numBatches), "proposals/batch")
+		})
+	}
+}
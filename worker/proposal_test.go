@@ -146,3 +146,25 @@ func BenchmarkRateLimiter(b *testing.B) {
 		}
 	}
 }
+
+func TestRateLimiterWait(t *testing.T) {
+	l := &rateLimiter{c: sync.NewCond(&sync.Mutex{}), max: 2, wait: 100 * time.Millisecond}
+	ctx := context.Background()
+	require.NoError(t, l.incr(ctx, 0))
+	require.NoError(t, l.incr(ctx, 0))
+
+	// The limiter is full, the proposal is rejected once the wait is over.
+	start := time.Now()
+	require.Equal(t, errUnableToServe, l.incr(ctx, 0))
+	require.True(t, time.Since(start) >= l.wait)
+	// With a weight of 2, a retry doesn't fit either once a slot is freed.
+	l.decr(0)
+	require.Equal(t, errUnableToServe, l.incr(ctx, 1))
+
+	// A proposal waiting for a slot gets it as soon as it's freed.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		l.decr(0)
+	}()
+	require.NoError(t, l.incr(ctx, 1))
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The results of a query can be written to a file by a task, for the results which are too large
+// to be sent back in a response. The file is written with the export storage, so to a directory
+// of the Alpha or to minio or s3, gzipped and encrypted as the exports are.
+
+// Formats of the files written by a QueryToFileRequest.
+const (
+	// QueryFileJSON writes the response of the query as it is.
+	QueryFileJSON = "json"
+	// QueryFileJSONL writes a line per node of a root block, as a JSON object with a single key,
+	// the name of the block, holding the node, e.g. {"q": {"uid": "0x1"}}. The blocks are
+	// written in the order of the response.
+	QueryFileJSONL = "jsonl"
+)
+
+// QueryToFileRequest is the request of a task writing the results of a query to a file.
+type QueryToFileRequest struct {
+	// Destination is a local directory, or a minio:// or s3:// URL. The export path is used if
+	// it is empty.
+	Destination  string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	Anonymous    bool
+	Format       string
+
+	// Run runs the query and writes its results as JSON to w, as they are encoded. The query
+	// engine isn't part of worker, so it is provided by the caller.
+	Run func(ctx context.Context, w io.Writer) error
+}
+
+// QueryFilePath returns the path, relative to the destination, of the file the task with the
+// given id writes the results of its query to.
+func QueryFilePath(taskId uint64, format string) string {
+	return filepath.Join(queryFileDir(taskId), "results."+format+".gz")
+}
+
+func queryFileDir(taskId uint64) string {
+	return fmt.Sprintf("dgraph.query.%#x", taskId)
+}
+
+// QueryFileLocation returns where the file with the results of the task is written.
+func QueryFileLocation(destination string, taskId uint64, format string) string {
+	if destination == "" {
+		destination = x.WorkerConfig.ExportPath
+	}
+	return strings.TrimSuffix(destination, "/") + "/" + QueryFilePath(taskId, format)
+}
+
+// writeQueryToFile runs the query of the task and writes its results. The files written locally
+// are removed if the task fails, along with the local copy of the files uploaded to minio or s3.
+func writeQueryToFile(ctx context.Context, taskId uint64, req *QueryToFileRequest) error {
+	in := &pb.ExportRequest{
+		Destination:  req.Destination,
+		AccessKey:    req.AccessKey,
+		SecretKey:    req.SecretKey,
+		SessionToken: req.SessionToken,
+		Anonymous:    req.Anonymous,
+	}
+	storage, err := newExportStorage(in, queryFileDir(taskId))
+	if err != nil {
+		return err
+	}
+	var localDir string
+	switch s := storage.(type) {
+	case *localExportStorage:
+		localDir = s.destination
+	case *remoteExportStorage:
+		localDir = s.les.destination
+		defer func() {
+			if err := os.RemoveAll(localDir); err != nil {
+				glog.Warningf("task %#x: couldn't remove %s: %v", taskId, localDir, err)
+			}
+		}()
+	}
+
+	err = func() error {
+		fw, err := storage.openFile("results." + req.Format + ".gz")
+		if err != nil {
+			return err
+		}
+		// The results are written to the file as the query encodes them.
+		w := newResultsWriter(fw.gw, req.Format)
+		err = req.Run(ctx, w)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			fw.Close()
+			return errors.Wrapf(err, "while running the query")
+		}
+		_, err = storage.finishWriting(fw)
+		return err
+	}()
+	if err != nil {
+		if rerr := os.RemoveAll(filepath.Join(localDir, queryFileDir(taskId))); rerr != nil {
+			glog.Warningf("task %#x: couldn't remove the results: %v", taskId, rerr)
+		}
+		return err
+	}
+	glog.Infof("task %#x: wrote the results of the query to %s", taskId,
+		QueryFileLocation(req.Destination, taskId, req.Format))
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// jsonlWriter converts the JSON response of a query written to it to JSONL, in a goroutine
+// reading it from a pipe.
+type jsonlWriter struct {
+	*io.PipeWriter
+	done chan error
+}
+
+// Close waits for the conversion to finish and returns its error.
+func (w *jsonlWriter) Close() error {
+	if err := w.PipeWriter.Close(); err != nil {
+		return err
+	}
+	return <-w.done
+}
+
+// newResultsWriter returns a writer converting the JSON response of a query written to it to
+// the given format as it is written, so that the response isn't held in memory.
+func newResultsWriter(w io.Writer, format string) io.WriteCloser {
+	if format == QueryFileJSON {
+		return nopWriteCloser{w}
+	}
+	pr, pw := io.Pipe()
+	jw := &jsonlWriter{PipeWriter: pw, done: make(chan error, 1)}
+	go func() {
+		err := writeJSONL(w, pr)
+		// Fails the writes left if the response couldn't be converted.
+		pr.CloseWithError(err)
+		jw.done <- err
+	}()
+	return jw
+}
+
+// writeJSONL reads the JSON response of a query from r, and writes its nodes to w in JSONL.
+func writeJSONL(w io.Writer, r io.Reader) error {
+	dec := json.NewDecoder(r)
+	// The numbers are written as they are in the response.
+	dec.UseNumber()
+	wrap := func(err error) error {
+		return errors.Wrapf(err, "while reading the results of the query")
+	}
+	if err := expectDelim(dec, '{'); err != nil {
+		return wrap(err)
+	}
+	var buf bytes.Buffer
+	writeNode := func(key []byte, node []byte) error {
+		buf.Reset()
+		buf.WriteByte('{')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(node)
+		buf.WriteString("}\n")
+		_, err := w.Write(buf.Bytes())
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		key, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		tok, err = dec.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		switch tok {
+		case json.Delim('['):
+			for dec.More() {
+				var node json.RawMessage
+				if err := dec.Decode(&node); err != nil {
+					return wrap(err)
+				}
+				if err := writeNode(key, node); err != nil {
+					return err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return wrap(err)
+			}
+		case json.Delim('{'):
+			return errors.Errorf("Block %s of the results isn't a list of nodes", key)
+		default:
+			// A scalar value.
+			node, err := json.Marshal(tok)
+			if err != nil {
+				return err
+			}
+			if err := writeNode(key, node); err != nil {
+				return err
+			}
+		}
+	}
+	return wrap(expectDelim(dec, '}'))
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return errors.Errorf("expected %s, got %v", delim, tok)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteQueryResults(t *testing.T) {
+	res := `{"q":[{"uid":"0x1"},{"uid":"0x2"}],"a":[{"count":2}],"s":1.50}`
+
+	var buf bytes.Buffer
+	w := newResultsWriter(&buf, QueryFileJSON)
+	_, err := w.Write([]byte(res))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, res, buf.String())
+
+	buf.Reset()
+	w = newResultsWriter(&buf, QueryFileJSONL)
+	// The response is converted as it is written.
+	for _, part := range []string{res[:10], res[10:30], res[30:]} {
+		_, err := w.Write([]byte(part))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	require.Equal(t, `{"q":{"uid":"0x1"}}
+{"q":{"uid":"0x2"}}
+{"a":{"count":2}}
+{"s":1.50}
+`, buf.String())
+
+	buf.Reset()
+	err = writeJSONL(&buf, strings.NewReader(`{"q":{"uid":"0x1"}}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `Block "q" of the results isn't a list of nodes`)
+	err = writeJSONL(&buf, strings.NewReader(`{"q":[{"uid":"0x1"}`))
+	require.Error(t, err)
+}
+
+func TestWriteQueryToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "query_file")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	req := &QueryToFileRequest{
+		Destination: dir,
+		Format:      QueryFileJSONL,
+		Run: func(ctx context.Context, w io.Writer) error {
+			for _, part := range []string{`{"q":[{"uid":"0x1"},`, `{"uid":"0x2"}]}`} {
+				if _, err := w.Write([]byte(part)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	require.NoError(t, writeQueryToFile(context.Background(), 1, req))
+	f, err := os.Open(filepath.Join(dir, QueryFilePath(1, QueryFileJSONL)))
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(gr)
+	require.NoError(t, err)
+	require.Equal(t, "{\"q\":{\"uid\":\"0x1\"}}\n{\"q\":{\"uid\":\"0x2\"}}\n", string(data))
+
+	// The results written before the query failed are removed.
+	req.Run = func(ctx context.Context, w io.Writer) error {
+		if _, err := w.Write([]byte(`{"q":[{"uid":"0x1"},`)); err != nil {
+			return err
+		}
+		return errors.New("query failed")
+	}
+	err = writeQueryToFile(context.Background(), 2, req)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "while running the query: query failed")
+	_, err = os.Stat(filepath.Join(dir, queryFileDir(2)))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestQueryFileLocation(t *testing.T) {
+	require.Equal(t, "s3://bucket/dir/dgraph.query.0x2a/results.jsonl.gz",
+		QueryFileLocation("s3://bucket/dir/", 42, QueryFileJSONL))
+}
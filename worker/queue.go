@@ -138,6 +138,7 @@ type tasks struct {
 // may have happened in that span of time. The request must be of type:
 // - *pb.BackupRequest
 // - *pb.ExportRequest
+// - *QueryToFileRequest
 func (t *tasks) Enqueue(req interface{}) (uint64, error) {
 	if t == nil {
 		return 0, fmt.Errorf("task queue hasn't been initialized yet")
@@ -171,6 +172,7 @@ func (t *tasks) Enqueue(req interface{}) (uint64, error) {
 // enqueue adds a new task to the queue. This must be of type:
 // - *pb.BackupRequest
 // - *pb.ExportRequest
+// - *QueryToFileRequest
 func (t *tasks) enqueue(req interface{}) (uint64, error) {
 	var kind TaskKind
 	switch req.(type) {
@@ -178,6 +180,8 @@ func (t *tasks) enqueue(req interface{}) (uint64, error) {
 		kind = TaskKindBackup
 	case *pb.ExportRequest:
 		kind = TaskKindExport
+	case *QueryToFileRequest:
+		kind = TaskKindQueryToFile
 	default:
 		err := fmt.Errorf("invalid TaskKind: %d", kind)
 		panic(err)
@@ -310,7 +314,7 @@ func (t *tasks) newId() uint64 {
 
 type taskRequest struct {
 	id  uint64
-	req interface{} // *pb.BackupRequest, *pb.ExportRequest
+	req interface{} // *pb.BackupRequest, *pb.ExportRequest, *QueryToFileRequest
 }
 
 // run starts a task and blocks till it completes.
@@ -326,6 +330,10 @@ func (t *taskRequest) run() error {
 			return err
 		}
 		glog.Infof("task %#x: exported files: %v", t.id, files)
+	case *QueryToFileRequest:
+		if err := writeQueryToFile(context.Background(), t.id, req); err != nil {
+			return err
+		}
 	default:
 		glog.Errorf(
 			"task %#x: received request of unknown type (%T)", t.id, reflect.TypeOf(t.req))
@@ -370,6 +378,7 @@ const (
 	// Reserve the zero value for errors.
 	TaskKindBackup TaskKind = iota + 1
 	TaskKindExport
+	TaskKindQueryToFile
 )
 
 type TaskKind uint64
@@ -380,6 +389,8 @@ func (k TaskKind) String() string {
 		return "Backup"
 	case TaskKindExport:
 		return "Export"
+	case TaskKindQueryToFile:
+		return "QueryToFile"
 	default:
 		return "Unknown"
 	}
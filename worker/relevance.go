@@ -0,0 +1,254 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/pkg/errors"
+	otrace "go.opencensus.io/trace"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/protos/pb"
+	ctask "github.com/dgraph-io/dgraph/task"
+	"github.com/dgraph-io/dgraph/tok"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// The relevance function scores the values of a predicate for full-text search terms with BM25,
+// so that the results of a search can be ranked, e.g.
+//   var(func: anyoftext(description, "graph database")) {
+//     s as relevance(description, "graph database")
+//   }
+//   me(func: uid(s), orderdesc: val(s)) { description }
+// The number of values having each term and the lengths of all the values are read from the bm25
+// index of the predicate, see tok.BM25Tokenizer. The values of the uids having the terms are then
+// read to score them. Each value is scored on its own, e.g. each language of a value with @lang,
+// and a uid gets the best score of its values. The scores of several predicates can be weighted and added
+// with math, e.g. math(2 * title_score + description_score).
+
+const (
+	// bm25K1 sets how fast the score of a term saturates as it appears more often in a value.
+	bm25K1 = 1.2
+	// bm25B sets how much the scores are normalized by the lengths of the values.
+	bm25B = 0.75
+)
+
+// handleRelevanceFunction sets the BM25 score of the value of each uid of the query which has
+// at least one of the terms. The other uids don't get any value.
+func (qs *queryState) handleRelevanceFunction(ctx context.Context, args funcArgs) error {
+	q, srcFn, out := args.q, args.srcFn, args.out
+	span := otrace.FromContext(ctx)
+	stop := x.SpanTimer(span, "handleRelevanceFunction")
+	defer stop()
+
+	uids := q.UidList.GetUids()
+	wanted := make(map[uint64]struct{}, len(uids))
+	for _, uid := range uids {
+		wanted[uid] = struct{}{}
+	}
+
+	txn := pstore.NewTransactionAt(q.ReadTs, false)
+	defer txn.Discard()
+
+	stats, err := qs.bm25Stats(txn, q)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[uint64]float64)
+	if stats.numValues > 0 {
+		// The inverse document frequency of each term, from the number of values having it, and
+		// the uids of the query having one of the terms.
+		idfs := make(map[string]float64, len(srcFn.tokens))
+		matched := make(map[uint64]struct{})
+		for _, term := range srcFn.tokens {
+			var numMatching int
+			err := qs.bm25Lists(txn, q, tok.BM25TermPrefix(term), func(_ int, pl *posting.List) error {
+				list, err := pl.Uids(posting.ListOptions{ReadTs: q.ReadTs})
+				if err != nil {
+					return err
+				}
+				numMatching += len(list.Uids)
+				for _, uid := range list.Uids {
+					if _, ok := wanted[uid]; ok {
+						matched[uid] = struct{}{}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			idfs[term] = math.Log(1 + (float64(stats.numValues-numMatching)+0.5)/
+				(float64(numMatching)+0.5))
+		}
+
+		avgLength := float64(stats.totalLength) / float64(stats.numValues)
+		for uid := range matched {
+			score, found, err := qs.bm25Score(q, uid, idfs, avgLength)
+			if err != nil {
+				return err
+			}
+			if found {
+				scores[uid] = score
+			}
+		}
+	}
+
+	for _, uid := range uids {
+		vals := &pb.ValueList{}
+		if score, ok := scores[uid]; ok {
+			vals.Values = []*pb.TaskValue{ctask.FromFloat(score)}
+		}
+		out.ValueMatrix = append(out.ValueMatrix, vals)
+		// Add an empty UID list to make later processing consistent
+		out.UidMatrix = append(out.UidMatrix, &pb.List{})
+	}
+	return nil
+}
+
+// bm25Score returns the best score of the values of the uid having one of the terms.
+func (qs *queryState) bm25Score(q *pb.Query, uid uint64, idfs map[string]float64,
+	avgLength float64) (float64, bool, error) {
+
+	pl, err := qs.cache.Get(x.DataKey(q.Attr, uid))
+	if err != nil {
+		return 0, false, err
+	}
+	var best float64
+	found := false
+	err = pl.Iterate(q.ReadTs, 0, func(p *pb.Posting) error {
+		val, err := types.Convert(types.Val{Tid: types.TypeID(p.ValType), Value: p.Value},
+			types.StringID)
+		if err != nil {
+			return err
+		}
+		// The terms are the ones of the bm25 index of the value.
+		terms := tok.FullTextTerms(val.Value.(string), string(p.LangTag))
+		freqs := make(map[string]int)
+		for _, term := range terms {
+			if _, ok := idfs[term]; ok {
+				freqs[term]++
+			}
+		}
+		if len(freqs) == 0 {
+			return nil
+		}
+
+		var score float64
+		norm := 1 - bm25B + bm25B*float64(len(terms))/avgLength
+		for term, freq := range freqs {
+			tf := float64(freq)
+			score += idfs[term] * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+		}
+		if !found || score > best {
+			best, found = score, true
+		}
+		return nil
+	})
+	return best, found, err
+}
+
+// bm25Stat holds the number of values of a predicate and their total length at a readTs.
+type bm25Stat struct {
+	readTs      uint64
+	numValues   int
+	totalLength int
+}
+
+// bm25StatCache keeps the stats of the latest readTs of each predicate, so that the lengths of
+// all the values are only read once by the queries at the same readTs.
+var bm25StatCache struct {
+	sync.Mutex
+	stats map[string]bm25Stat
+}
+
+// bm25Stats returns the number of values of the predicate and their total length. They are only
+// cached for the queries reading the committed data, without the mutations of a transaction.
+func (qs *queryState) bm25Stats(txn *badger.Txn, q *pb.Query) (bm25Stat, error) {
+	if qs.committed {
+		bm25StatCache.Lock()
+		stats, ok := bm25StatCache.stats[q.Attr]
+		bm25StatCache.Unlock()
+		if ok && stats.readTs == q.ReadTs {
+			return stats, nil
+		}
+	}
+
+	stats := bm25Stat{readTs: q.ReadTs}
+	err := qs.bm25Lists(txn, q, tok.BM25LengthPrefix(), func(length int, pl *posting.List) error {
+		n := pl.Length(q.ReadTs, 0)
+		if n < 0 {
+			return errors.Errorf("while counting the values of length %d of %s", length,
+				x.ParseAttr(q.Attr))
+		}
+		stats.numValues += n
+		stats.totalLength += length * n
+		return nil
+	})
+	if err != nil || !qs.committed {
+		return stats, err
+	}
+
+	bm25StatCache.Lock()
+	defer bm25StatCache.Unlock()
+	if bm25StatCache.stats == nil {
+		bm25StatCache.stats = make(map[string]bm25Stat)
+	}
+	// The stats of an older readTs don't replace the ones of a newer readTs.
+	if cached, ok := bm25StatCache.stats[q.Attr]; !ok || cached.readTs < stats.readTs {
+		bm25StatCache.stats[q.Attr] = stats
+	}
+	return stats, nil
+}
+
+// bm25Lists calls fn with the number held by each token of the bm25 index of the predicate
+// starting with prefix, and its posting list.
+func (qs *queryState) bm25Lists(txn *badger.Txn, q *pb.Query, prefix string,
+	fn func(n int, pl *posting.List) error) error {
+
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.PrefetchValues = false
+	itOpt.Prefix = x.IndexKey(q.Attr, prefix)
+	it := txn.NewIterator(itOpt)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		key := it.Item().KeyCopy(nil)
+		pk, err := x.Parse(key)
+		if err != nil {
+			return err
+		}
+		n, err := tok.ParseBM25Count(pk.Term)
+		if err != nil {
+			return err
+		}
+		pl, err := qs.cache.Get(key)
+		if err != nil {
+			return err
+		}
+		if err := fn(n, pl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
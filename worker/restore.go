@@ -297,7 +297,7 @@ func applyDropOperationsBeforeRestore(
 			if isOld {
 				attr = x.GalaxyAttr(operation.DropValue)
 			}
-			return db.DropPrefix(x.PredicatePrefix(attr))
+			return db.DropPrefix(x.PredicatePrefix(attr), x.PredicateSplitPrefix(attr))
 		case pb.DropOperation_NS:
 			ns, err := strconv.ParseUint(operation.DropValue, 0, 64)
 			x.Check(err)
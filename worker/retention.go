@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/dgraph/x"
+)
+
+var retention = &versionRetention{}
+
+type retentionSample struct {
+	at     time.Time
+	readTs uint64
+}
+
+// versionRetention computes the discard ts to be set in Badger, so that the versions committed
+// within the last x.WorkerConfig.MvccRetention are kept. Timestamps don't map to wall clock time,
+// so the ReadTs of every snapshot is recorded along with the time it was taken. The discard ts is
+// the ReadTs of the latest snapshot which is older than the retention window.
+type versionRetention struct {
+	sync.Mutex
+	samples   []retentionSample
+	discardTs uint64
+}
+
+// update records a snapshot taken at readTs and returns the discard ts to be used.
+func (r *versionRetention) update(readTs uint64, now time.Time) uint64 {
+	return r.updateWithWindow(readTs, now, x.WorkerConfig.MvccRetention)
+}
+
+func (r *versionRetention) updateWithWindow(readTs uint64, now time.Time,
+	window time.Duration) uint64 {
+	r.Lock()
+	defer r.Unlock()
+
+	candidate := readTs
+	if window > 0 {
+		r.samples = append(r.samples, retentionSample{at: now, readTs: readTs})
+		cutoff := now.Add(-window)
+		idx := -1
+		for i, s := range r.samples {
+			if s.at.After(cutoff) {
+				break
+			}
+			idx = i
+		}
+		candidate = 0
+		if idx >= 0 {
+			candidate = r.samples[idx].readTs
+			// The older samples can't be the discard ts anymore.
+			r.samples = r.samples[idx:]
+		}
+	}
+	// The versions below the discard ts might already be gone, so it never goes back.
+	if candidate > r.discardTs {
+		r.discardTs = candidate
+	}
+	return r.discardTs
+}
+
+func (r *versionRetention) getDiscardTs() uint64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.discardTs
+}
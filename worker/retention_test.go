@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionRetention(t *testing.T) {
+	start := time.Now()
+
+	// Without a window, the discard ts follows the snapshots.
+	r := &versionRetention{}
+	require.Equal(t, uint64(10), r.updateWithWindow(10, start, 0))
+	require.Equal(t, uint64(20), r.updateWithWindow(20, start.Add(time.Minute), 0))
+
+	r = &versionRetention{}
+	window := time.Hour
+	require.Equal(t, uint64(0), r.updateWithWindow(10, start, window))
+	require.Equal(t, uint64(0), r.updateWithWindow(20, start.Add(30*time.Minute), window))
+	// The snapshot at ts 10 is now older than the window.
+	require.Equal(t, uint64(10), r.updateWithWindow(30, start.Add(time.Hour), window))
+	require.Equal(t, uint64(20), r.updateWithWindow(40, start.Add(100*time.Minute), window))
+	require.Len(t, r.samples, 3)
+	require.Equal(t, uint64(20), r.getDiscardTs())
+
+	// A shorter window doesn't make the discard ts go back.
+	require.Equal(t, uint64(40), r.updateWithWindow(50, start.Add(2*time.Hour), time.Minute))
+	require.Equal(t, uint64(40), r.updateWithWindow(60, start.Add(2*time.Hour), window))
+}
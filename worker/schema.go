@@ -57,7 +57,8 @@ func getSchema(ctx context.Context, s *pb.SchemaRequest) (*pb.SchemaResult, erro
 		fields = s.Fields
 	} else {
 		fields = []string{"type", "index", "tokenizer", "reverse", "count", "list", "upsert",
-			"lang", "noconflict"}
+			"lang", "noconflict", "undirected", "derived", "pattern", "aliases",
+			"enum_values", "cardinality", "key"}
 	}
 
 	myGid := groups().groupId()
@@ -72,15 +73,16 @@ func getSchema(ctx context.Context, s *pb.SchemaRequest) (*pb.SchemaResult, erro
 			continue
 		}
 
-		if schemaNode := populateSchema(attr, fields); schemaNode != nil {
+		if schemaNode := populateSchema(attr, fields, gid); schemaNode != nil {
 			result.Schema = append(result.Schema, schemaNode)
 		}
 	}
 	return &result, nil
 }
 
-// populateSchema returns the information of asked fields for given attribute
-func populateSchema(attr string, fields []string) *pb.SchemaNode {
+// populateSchema returns the information of asked fields for given attribute, served by the group
+// gid.
+func populateSchema(attr string, fields []string, gid uint32) *pb.SchemaNode {
 	var schemaNode pb.SchemaNode
 	var typ types.TypeID
 	var err error
@@ -112,6 +114,23 @@ func populateSchema(attr string, fields []string) *pb.SchemaNode {
 			schemaNode.Lang = schema.State().HasLang(attr)
 		case "noconflict":
 			schemaNode.NoConflict = schema.State().HasNoConflict(attr)
+		case "undirected":
+			schemaNode.Undirected = schema.State().IsUndirected(ctx, attr)
+		case "derived":
+			schemaNode.Derived, _ = schema.State().Derivation(attr)
+		case "pattern":
+			schemaNode.Pattern = schema.State().Pattern(attr)
+		case "aliases":
+			schemaNode.Aliases = schema.State().PredicateAliases(attr)
+		case "enum_values":
+			schemaNode.EnumValues = schema.State().EnumValues(attr)
+		case "cardinality":
+			schemaNode.Cardinality, schemaNode.CardinalityReject = schema.State().Cardinality(attr)
+		case "key":
+			schemaNode.Key = schema.State().IsKey(attr)
+		case "group":
+			// The group isn't in the default fields, it has to be asked for.
+			schemaNode.Group = gid
 		default:
 			//pass
 		}
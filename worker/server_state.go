@@ -41,18 +41,25 @@ const (
 	AuditDefaults  = `compress=false; days=10; size=100; dir=; output=; encrypt-file=;`
 	BadgerDefaults = `compression=snappy; numgoroutines=8;`
 	RaftDefaults   = `learner=false; snapshot-after-entries=10000; ` +
-		`snapshot-after-duration=30m; pending-proposals=256; idx=; group=;`
+		`snapshot-after-duration=30m; snapshot-max-wal-files=4; pending-proposals=256; ` +
+		`pending-proposals-wait=0s; mutation-batch-window=0s; idx=; group=; region=;`
 	SecurityDefaults  = `token=; whitelist=;`
 	LudicrousDefaults = `enabled=false; concurrency=2000;`
 	CDCDefaults       = `file=; kafka=; sasl_user=; sasl_password=; ca_cert=; client_cert=; ` +
-		`client_key=; sasl-mechanism=PLAIN;`
+		`client_key=; sasl-mechanism=PLAIN; nats=; subject=dgraph-cdc; predicates=; old-values=;`
 	LimitDefaults = `mutations=allow; query-edge=1000000; normalize-node=10000; ` +
 		`mutations-nquad=1000000; disallow-drop=false; query-timeout=0ms; txn-abort-after=5m; ` +
-		` max-retries=-1;max-pending-queries=10000`
+		` max-retries=-1;max-pending-queries=10000; query-memory-mb=0; ` +
+		`require-existing-targets=false; grpc-max-message-mb=0; default-first=0; ` +
+		`predicates-per-mutation=0; upsert-match=0; namespace-query-rate=0; ` +
+		`namespace-mutation-rate=0; namespace-storage-mb=0; binary-value-kb=1024; ` +
+		`type-mismatch=coerce; binary-base64=false;`
 	ZeroLimitsDefaults = `uid-lease=0; refill-interval=30s; disable-admin-http=false;`
 	GraphQLDefaults    = `introspection=true; debug=false; extensions=true; poll-interval=1s; ` +
-		`lambda-url=;`
-	CacheDefaults = `size-mb=1024; percentage=0,65,35;`
+		`lambda-url=; max-depth=0; max-complexity=0; persisted-query-allowlist=false; ` +
+		`persisted-query-cache=1000; partial-mutations=false;`
+	CacheDefaults      = `size-mb=1024; percentage=0,65,35; pin-size-mb=256; pin-predicates=; no-cache-predicates=;`
+	FederationDefaults = `key=xid; timeout=5s; predicates=;`
 )
 
 // ServerState holds the state of the Dgraph server.
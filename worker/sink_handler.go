@@ -17,17 +17,23 @@
 package worker
 
 import (
+	"bufio"
 	"crypto/sha256"
 	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/golang/glog"
 	"github.com/pkg/errors"
 	"github.com/xdg/scram"
 
@@ -62,6 +68,8 @@ func GetSink(conf *z.SuperFlag) (Sink, error) {
 	switch {
 	case conf.GetString("kafka") != "":
 		return newKafkaSink(conf)
+	case conf.GetString("nats") != "":
+		return newNatsSink(conf)
 	case conf.GetPath("file") != "":
 		return newFileSink(conf)
 	}
@@ -86,32 +94,11 @@ func newKafkaSink(config *z.SuperFlag) (Sink, error) {
 	saramaConf.Producer.Return.Successes = true
 	saramaConf.Producer.Return.Errors = true
 
-	if config.GetPath("ca-cert") != "" {
-		tlsCfg := &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		}
-		var pool *x509.CertPool
-		var err error
-		if pool, err = x509.SystemCertPool(); err != nil {
-			return nil, err
-		}
-		caFile, err := ioutil.ReadFile(config.GetPath("ca-cert"))
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to read ca cert file")
-		}
-		if !pool.AppendCertsFromPEM(caFile) {
-			return nil, errors.New("not able to append certificates")
-		}
-		tlsCfg.RootCAs = pool
-		cert := config.GetPath("client-cert")
-		key := config.GetPath("client-key")
-		if cert != "" && key != "" {
-			cert, err := tls.LoadX509KeyPair(cert, key)
-			if err != nil {
-				return nil, errors.Wrap(err, "unable to load client cert and key")
-			}
-			tlsCfg.Certificates = []tls.Certificate{cert}
-		}
+	tlsCfg, err := sinkTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
 		saramaConf.Net.TLS.Enable = true
 		saramaConf.Net.TLS.Config = tlsCfg
 	}
@@ -157,6 +144,40 @@ func newKafkaSink(config *z.SuperFlag) (Sink, error) {
 	}, nil
 }
 
+// sinkTLSConfig returns the TLS config for connecting to the sink. It is nil if no CA cert is
+// provided.
+func sinkTLSConfig(config *z.SuperFlag) (*tls.Config, error) {
+	if config.GetPath("ca-cert") == "" {
+		return nil, nil
+	}
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	var pool *x509.CertPool
+	var err error
+	if pool, err = x509.SystemCertPool(); err != nil {
+		return nil, err
+	}
+	caFile, err := ioutil.ReadFile(config.GetPath("ca-cert"))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read ca cert file")
+	}
+	if !pool.AppendCertsFromPEM(caFile) {
+		return nil, errors.New("not able to append certificates")
+	}
+	tlsCfg.RootCAs = pool
+	cert := config.GetPath("client-cert")
+	key := config.GetPath("client-key")
+	if cert != "" && key != "" {
+		cert, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to load client cert and key")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	return tlsCfg, nil
+}
+
 func (k *kafkaSinkClient) Send(messages []SinkMessage) error {
 	if len(messages) == 0 {
 		return nil
@@ -177,6 +198,212 @@ func (k *kafkaSinkClient) Close() error {
 	return k.client.Close()
 }
 
+const natsTimeout = 10 * time.Second
+
+// natsSinkClient publishes the messages to a NATS subject using the NATS client protocol. Like the
+// Kafka client, it is not concurrency safe.
+type natsSinkClient struct {
+	servers []*url.URL
+	tlsCfg  *tls.Config
+	subject string
+
+	conn net.Conn
+	r    *bufio.Reader
+	w    *bufio.Writer
+}
+
+func newNatsSink(config *z.SuperFlag) (Sink, error) {
+	n := &natsSinkClient{subject: config.GetString("subject")}
+	if n.subject == "" {
+		return nil, errors.New("subject is not provided for the nats config")
+	}
+	for _, s := range strings.Split(config.GetString("nats"), ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !strings.Contains(s, "://") {
+			s = "nats://" + s
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid NATS server %q", s)
+		}
+		if u.Port() == "" {
+			u.Host = net.JoinHostPort(u.Hostname(), "4222")
+		}
+		n.servers = append(n.servers, u)
+	}
+	if len(n.servers) == 0 {
+		return nil, errors.New("servers are not provided for the nats config")
+	}
+	var err error
+	if n.tlsCfg, err = sinkTLSConfig(config); err != nil {
+		return nil, err
+	}
+	if err := n.connect(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// connect connects to the first NATS server which accepts the connection.
+func (n *natsSinkClient) connect() error {
+	var err error
+	for _, u := range n.servers {
+		if err = n.connectTo(u); err == nil {
+			return nil
+		}
+		glog.Warningf("CDC: unable to connect to NATS server %s: %v", u.Host, err)
+	}
+	return errors.Wrap(err, "unable to connect to any NATS server")
+}
+
+func (n *natsSinkClient) connectTo(u *url.URL) error {
+	conn, err := net.DialTimeout("tcp", u.Host, natsTimeout)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		conn.Close()
+		return err
+	}
+	n.conn, n.r = conn, bufio.NewReader(conn)
+
+	// The server sends its INFO as soon as we connect.
+	line, err := n.readLine()
+	if err != nil {
+		n.close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		n.close()
+		return errors.Errorf("expected INFO from NATS server, got: %s", line)
+	}
+	if n.tlsCfg != nil {
+		cfg := n.tlsCfg.Clone()
+		if cfg.ServerName == "" {
+			cfg.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			n.close()
+			return errors.Wrap(err, "TLS handshake with NATS server failed")
+		}
+		n.conn, n.r = tlsConn, bufio.NewReader(tlsConn)
+	}
+	n.w = bufio.NewWriter(n.conn)
+
+	opts := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "Dgraph",
+		"lang":     "go",
+	}
+	if u.User != nil {
+		pass, ok := u.User.Password()
+		if ok {
+			opts["user"] = u.User.Username()
+			opts["pass"] = pass
+		} else {
+			opts["auth_token"] = u.User.Username()
+		}
+	}
+	b, err := json.Marshal(opts)
+	x.Check(err)
+	fmt.Fprintf(n.w, "CONNECT %s\r\n", b)
+	// The server replies to PING only after it has accepted the CONNECT.
+	if err := n.flushAndWait(); err != nil {
+		n.close()
+		return err
+	}
+	return nil
+}
+
+func (n *natsSinkClient) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// flushAndWait sends a PING after the pending writes and waits for the PONG. The server processes
+// the messages in order, so the PONG means that all the messages sent before were accepted.
+func (n *natsSinkClient) flushAndWait() error {
+	if _, err := n.w.WriteString("PING\r\n"); err != nil {
+		return err
+	}
+	if err := n.w.Flush(); err != nil {
+		return err
+	}
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.w.WriteString("PONG\r\n"); err != nil {
+				return err
+			}
+			if err := n.w.Flush(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.Errorf("NATS server returned error: %s", strings.TrimPrefix(line, "-ERR "))
+		}
+		// +OK and INFO updates don't need any action.
+	}
+}
+
+func (n *natsSinkClient) close() {
+	if n.conn != nil {
+		_ = n.conn.Close()
+	}
+	n.conn, n.r, n.w = nil, nil, nil
+}
+
+// Send publishes the messages in order and returns after the server has accepted all of them.
+// On failure the connection is dropped, so that the next call reconnects and the messages are
+// sent again.
+func (n *natsSinkClient) Send(messages []SinkMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return err
+		}
+	}
+	// A slow server holds up the CDC job rather than letting the events pile up in memory.
+	if err := n.conn.SetDeadline(time.Now().Add(natsTimeout)); err != nil {
+		n.close()
+		return err
+	}
+	for _, m := range messages {
+		fmt.Fprintf(n.w, "PUB %s %d\r\n", n.subject, len(m.Value))
+		_, _ = n.w.Write(m.Value)
+		_, _ = n.w.WriteString("\r\n")
+	}
+	if err := n.flushAndWait(); err != nil {
+		n.close()
+		return errors.Wrap(err, "unable to publish messages to NATS")
+	}
+	return nil
+}
+
+func (n *natsSinkClient) Close() error {
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn, n.r, n.w = nil, nil, nil
+	return err
+}
+
 // this is only for testing purposes. Ideally client wouldn't want file based sink
 type fileSink struct {
 	// log writer is buffered. Do take care of that while testing
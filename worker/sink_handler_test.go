@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dgraph-io/ristretto/z"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNatsServer accepts NATS connections and records the published messages.
+type fakeNatsServer struct {
+	sync.Mutex
+	ln        net.Listener
+	published []string
+	// rejectPub makes the server reply with an error instead of a PONG after a PUB.
+	rejectPub bool
+}
+
+func newFakeNatsServer(t *testing.T) *fakeNatsServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeNatsServer{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeNatsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO {\"server_id\":\"test\"}\r\n")
+	r := bufio.NewReader(conn)
+	gotPub := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "PUB "):
+			parts := strings.Fields(line)
+			n, _ := strconv.Atoi(parts[2])
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.Lock()
+			s.published = append(s.published, parts[1]+" "+string(payload[:n]))
+			s.Unlock()
+			gotPub = true
+		case line == "PING":
+			s.Lock()
+			reject := s.rejectPub && gotPub
+			s.Unlock()
+			if reject {
+				fmt.Fprintf(conn, "-ERR 'Maximum Payload Violation'\r\n")
+				return
+			}
+			fmt.Fprintf(conn, "PONG\r\n")
+		}
+	}
+}
+
+func (s *fakeNatsServer) messages() []string {
+	s.Lock()
+	defer s.Unlock()
+	return append([]string{}, s.published...)
+}
+
+func TestNatsSink(t *testing.T) {
+	s := newFakeNatsServer(t)
+	defer s.ln.Close()
+
+	conf := z.NewSuperFlag("nats=" + s.ln.Addr().String() + "; subject=dgraph.changes").
+		MergeAndCheckDefault(CDCDefaults)
+	sink, err := GetSink(conf)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send([]SinkMessage{
+		{Key: []byte("k1"), Value: []byte(`{"a":1}`)},
+		{Key: []byte("k2"), Value: []byte(`{"b":2}`)},
+	}))
+	// Send returns only after the server has accepted the messages.
+	require.Equal(t, []string{`dgraph.changes {"a":1}`, `dgraph.changes {"b":2}`}, s.messages())
+
+	s.Lock()
+	s.rejectPub = true
+	s.Unlock()
+	err = sink.Send([]SinkMessage{{Value: []byte(`{"c":3}`)}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Maximum Payload Violation")
+
+	// The failed messages are sent again on a new connection.
+	s.Lock()
+	s.rejectPub = false
+	s.Unlock()
+	require.NoError(t, sink.Send([]SinkMessage{{Value: []byte(`{"c":3}`)}}))
+	require.Equal(t, `dgraph.changes {"c":3}`, s.messages()[len(s.messages())-1])
+}
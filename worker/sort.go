@@ -19,6 +19,7 @@ package worker
 import (
 	"context"
 	"encoding/hex"
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -327,6 +328,22 @@ BUCKETS:
 			}
 		}
 
+		if len(ts.Order) > 1 {
+			// Null nodes tie on the first sort attribute, so their relative order is decided
+			// by the rest of the sort attributes. Hence, we can't apply the offset or the count
+			// on them yet. If the page isn't full already, keep all of them and apply the
+			// remaining offset after multiSort.
+			if len(r.UidMatrix[i].Uids)-int(multiSortOffsets[i]) >= int(ts.Count) {
+				continue
+			}
+			if out[i].offset > 0 {
+				multiSortOffsets[i] += int32(out[i].offset)
+			}
+			r.UidMatrix[i].Uids = append(r.UidMatrix[i].Uids, nullNodes...)
+			values[i] = append(values[i], make([]types.Val, len(nullNodes))...)
+			continue
+		}
+
 		// Apply the offset on null nodes, if the nodes with value were not enough.
 		if out[i].offset < len(nullNodes) {
 			if out[i].offset >= 0 {
@@ -338,12 +355,6 @@ BUCKETS:
 		remainingCount := int(ts.Count) - len(r.UidMatrix[i].Uids)
 		canAppend := x.Min(uint64(remainingCount), uint64(len(nullNodes)))
 		r.UidMatrix[i].Uids = append(r.UidMatrix[i].Uids, nullNodes[:canAppend]...)
-
-		// The value list also need to contain null values for the appended uids.
-		if len(ts.Order) > 1 {
-			nullVals := make([]types.Val, canAppend)
-			values[i] = append(values[i], nullVals...)
-		}
 	}
 
 	select {
@@ -493,6 +504,9 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 				"Try flipping order and return first few elements instead.",
 			x.ParseAttr(ts.Order[0].Attr), ts.Count)
 	}
+	if ts.Order[0].Count {
+		return sortByCount(ctx, ts)
+	}
 	// TODO (pawan) - Why check only the first attribute, what if other attributes are of list type?
 	if schema.State().IsList(ts.Order[0].Attr) {
 		return nil, errors.Errorf("Sorting not supported on attr: %s of type: [scalar]",
@@ -543,6 +557,101 @@ func processSort(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error
 	return r.reply, err
 }
 
+// sortByCount sorts the uid lists by the number of edges of the predicate of the order, e.g.
+// orderdesc: count(~references). The count index is iterated from the highest count for orderdesc
+// and from the lowest one for orderasc, until the page of every list is filled. The nodes with the
+// same count are sorted by uid. The nodes without any edge aren't in the count index, so they come
+// last in both orders, like the nodes without a value when sorting by a value.
+func sortByCount(ctx context.Context, ts *pb.SortMessage) (*pb.SortResult, error) {
+	order := ts.Order[0]
+	countAttr := x.ParseAttr(order.Attr)
+	if order.Reverse {
+		countAttr = "~" + countAttr
+	}
+	if !schema.State().HasCount(ctx, order.Attr) {
+		return nil, errors.Errorf("Need @count directive in schema for attr: %s to sort by "+
+			"count(%s)", x.ParseAttr(order.Attr), countAttr)
+	}
+	// The count index of the reverse edges is only kept if the predicate has @reverse.
+	if order.Reverse && !schema.State().IsReversed(ctx, order.Attr) {
+		return nil, errors.Errorf("Need @reverse directive in schema for attr: %s to sort by "+
+			"count(%s)", x.ParseAttr(order.Attr), countAttr)
+	}
+
+	n := len(ts.UidMatrix)
+	sorted := make([]*pb.List, n)
+	seen := make([]map[uint64]struct{}, n)
+	for i := range sorted {
+		sorted[i] = &pb.List{}
+		seen[i] = make(map[uint64]struct{})
+	}
+	full := func(i int) bool {
+		return ts.Count > 0 && len(sorted[i].Uids) >= int(ts.Offset+ts.Count)
+	}
+	allFull := func() bool {
+		for i := range sorted {
+			if !full(i) {
+				return false
+			}
+		}
+		return true
+	}
+
+	txn := pstore.NewTransactionAt(ts.ReadTs, false)
+	defer txn.Discard()
+	pk := x.ParsedKey{Attr: order.Attr}
+	itOpt := badger.DefaultIteratorOptions
+	itOpt.PrefetchValues = false
+	itOpt.Reverse = order.Desc
+	itOpt.Prefix = pk.CountPrefix(order.Reverse)
+	itr := txn.NewIterator(itOpt)
+	defer itr.Close()
+
+	var seekKey []byte // Would automatically seek to the lowest count.
+	if order.Desc {
+		seekKey = x.CountKey(order.Attr, math.MaxUint32, order.Reverse)
+	}
+	for itr.Seek(seekKey); itr.Valid() && !allFull(); itr.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		// Don't put the count keys in memory.
+		pl, err := posting.GetNoStore(itr.Item().KeyCopy(nil), ts.ReadTs)
+		if err != nil {
+			return nil, err
+		}
+		for i, ul := range ts.UidMatrix {
+			if full(i) {
+				continue
+			}
+			res, err := pl.Uids(posting.ListOptions{Intersect: ul, ReadTs: ts.ReadTs})
+			if err != nil {
+				return nil, err
+			}
+			for _, uid := range res.Uids {
+				if _, ok := seen[i][uid]; !ok {
+					seen[i][uid] = struct{}{}
+					sorted[i].Uids = append(sorted[i].Uids, uid)
+				}
+			}
+		}
+	}
+
+	for i, ul := range ts.UidMatrix {
+		for _, uid := range ul.Uids {
+			if full(i) {
+				break
+			}
+			if _, ok := seen[i][uid]; !ok {
+				sorted[i].Uids = append(sorted[i].Uids, uid)
+			}
+		}
+		start, end := x.PageRange(int(ts.Count), int(ts.Offset), len(sorted[i].Uids))
+		sorted[i].Uids = sorted[i].Uids[start:end]
+	}
+	return &pb.SortResult{UidMatrix: sorted}, nil
+}
+
 func destUids(uidMatrix []*pb.List) *pb.List {
 	included := make(map[uint64]struct{})
 	for _, ul := range uidMatrix {
@@ -727,7 +836,7 @@ func paginate(ts *pb.SortMessage, dest *pb.List, vals []types.Val) (int, int, er
 	// equal values at start or the end.
 	if len(ts.Order) > 1 {
 		for start < len(vals) && start > 0 {
-			eq, err := types.Equal(vals[start], vals[start-1])
+			eq, err := sortValsEqual(vals[start], vals[start-1])
 			if err != nil {
 				return 0, 0, err
 			}
@@ -736,8 +845,8 @@ func paginate(ts *pb.SortMessage, dest *pb.List, vals []types.Val) (int, int, er
 			}
 			start--
 		}
-		for end < len(dest.Uids) {
-			eq, err := types.Equal(vals[end-1], vals[end])
+		for end > 0 && end < len(dest.Uids) {
+			eq, err := sortValsEqual(vals[end-1], vals[end])
 			if err != nil {
 				return 0, 0, err
 			}
@@ -751,6 +860,15 @@ func paginate(ts *pb.SortMessage, dest *pb.List, vals []types.Val) (int, int, er
 	return start, end, nil
 }
 
+// sortValsEqual returns true if a and b are equal for the purpose of sorting. Missing values are
+// all sorted at the end, so they are equal to each other and different from any other value.
+func sortValsEqual(a, b types.Val) (bool, error) {
+	if a.Value == nil || b.Value == nil {
+		return a.Value == nil && b.Value == nil, nil
+	}
+	return types.Equal(a, b)
+}
+
 // sortByValue fetches values and sort UIDList.
 func sortByValue(ctx context.Context, ts *pb.SortMessage, ul *pb.List,
 	typ types.TypeID) ([]types.Val, error) {
@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
 )
 
 func TestRemoveDuplicates(t *testing.T) {
@@ -54,3 +57,31 @@ func TestRemoveDuplicates(t *testing.T) {
 		require.Equal(t, set, toSet(test.setOut))
 	}
 }
+
+func TestPaginateMultiSort(t *testing.T) {
+	str := func(s string) types.Val { return types.Val{Tid: types.StringID, Value: s} }
+	// The values are sorted by the first sort attribute, with missing values at the end.
+	vals := []types.Val{str("a"), str("a"), str("b"), str("b"), str("b"), str("c"), {}, {}}
+	dest := &pb.List{Uids: []uint64{1, 2, 3, 4, 5, 6, 7, 8}}
+	order := []*pb.Order{{Attr: "name"}, {Attr: "age"}}
+
+	for _, tc := range []struct {
+		offset, count int32
+		start, end    int
+	}{
+		{offset: 0, count: 2, start: 0, end: 2},
+		{offset: 0, count: 3, start: 0, end: 5},
+		{offset: 1, count: 1, start: 0, end: 2},
+		{offset: 3, count: 2, start: 2, end: 5},
+		{offset: 5, count: 1, start: 5, end: 6},
+		{offset: 6, count: 1, start: 6, end: 8},
+		{offset: 5, count: 2, start: 5, end: 8},
+		{offset: 8, count: 2, start: 8, end: 8},
+	} {
+		ts := &pb.SortMessage{Order: order, Offset: tc.offset, Count: tc.count}
+		start, end, err := paginate(ts, dest, vals)
+		require.NoError(t, err)
+		require.Equalf(t, tc.start, start, "offset: %d count: %d", tc.offset, tc.count)
+		require.Equalf(t, tc.end, end, "offset: %d count: %d", tc.offset, tc.count)
+	}
+}
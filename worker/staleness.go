@@ -0,0 +1,72 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/dgraph-io/dgraph/posting"
+)
+
+// The commits are streamed by Zero to the leader of each group, which proposes them to the
+// group. A best effort query reads at the max assigned timestamp known by the alpha, which lags
+// behind if the alpha hasn't applied all the committed Raft entries of its group. A query with a
+// maximum staleness only reads at that timestamp if the alpha was up to date at most that long
+// ago, otherwise it gets its timestamp from Zero. The alpha is considered up to date if it has
+// applied every entry up to the commit index of the leader of its group, or if Zero didn't give a
+// timestamp ahead of its own. The commit index known by a follower lags behind the one of the
+// leader, so the leader's is asked for with a ReadIndex request. The times are measured with the
+// clock of the alpha only, so that the clocks of the nodes don't need to be in sync.
+
+// upToDateAt is the time, in unix nanoseconds, at which the alpha was last known to be up to
+// date with the commits.
+var upToDateAt int64
+
+// BoundedStalenessTs returns the timestamp at which a read-only query can read the data
+// committed up to at most maxStaleness ago.
+func BoundedStalenessTs(ctx context.Context, maxStaleness time.Duration) uint64 {
+	maxAssigned := posting.Oracle().MaxAssigned()
+	if maxAssigned > 0 {
+		// The leader is only asked for its commit index once the last check is too old.
+		if time.Since(time.Unix(0, atomic.LoadInt64(&upToDateAt))) <= maxStaleness {
+			return maxAssigned
+		}
+		if appliedLeaderIndex(ctx) {
+			atomic.StoreInt64(&upToDateAt, time.Now().UnixNano())
+			return maxAssigned
+		}
+	}
+
+	ts := State.GetTimestamp(true)
+	if ts <= posting.Oracle().MaxAssigned() {
+		atomic.StoreInt64(&upToDateAt, time.Now().UnixNano())
+	}
+	return ts
+}
+
+// appliedLeaderIndex returns true if the alpha has applied the entries up to the commit index of
+// the leader of its group.
+func appliedLeaderIndex(ctx context.Context) bool {
+	n := groups().Node
+	if n == nil || n.Raft() == nil {
+		return false
+	}
+	index, err := n.ReadIndex(ctx)
+	return err == nil && n.Applied.DoneUntil() >= index
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"math"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// StorageStats holds the estimates of the disk space used by the posting store of this alpha,
+// and of the space a value log GC or a compaction would reclaim.
+type StorageStats struct {
+	LsmSize  int64
+	VlogSize int64
+	Tables   int
+	// StaleBytes is the size of the data of the tables which is already known to be stale, and
+	// which is dropped when the tables are compacted.
+	StaleBytes uint64
+
+	// The fields below are only set if the versions have been scanned.
+	Scanned   bool
+	DiscardTs uint64
+	Keys      uint64
+	Versions  uint64
+	// DiscardableVersions are the versions below the discard ts which are shadowed by a newer
+	// complete or deleted version, and which are dropped by the compactions.
+	DiscardableVersions uint64
+	DiscardableBytes    uint64
+	// LiveVlogBytes is the size of the values stored in the value log which are still used.
+	LiveVlogBytes uint64
+}
+
+// VlogUtilization returns the fraction of the value log which is still used, or 1 if it's not
+// known.
+func (s *StorageStats) VlogUtilization() float64 {
+	if !s.Scanned || s.VlogSize <= 0 {
+		return 1
+	}
+	return math.Min(1, float64(s.LiveVlogBytes)/float64(s.VlogSize))
+}
+
+// ReclaimableBytes returns the estimated disk space a value log GC and a compaction of every
+// level would reclaim.
+func (s *StorageStats) ReclaimableBytes() uint64 {
+	reclaimable := s.StaleBytes
+	if !s.Scanned {
+		return reclaimable
+	}
+	reclaimable += s.DiscardableBytes
+	if vlog := uint64(s.VlogSize); vlog > s.LiveVlogBytes {
+		reclaimable += vlog - s.LiveVlogBytes
+	}
+	return reclaimable
+}
+
+// GetStorageStats returns the storage stats of the posting store of this alpha. The sizes are
+// read from Badger and are cheap to get. If scan is set, every version of every key is read as
+// well, without the values, to count the discardable versions and the used part of the value
+// log. The scan can take a while on a large store.
+func GetStorageStats(ctx context.Context, scan bool) (*StorageStats, error) {
+	var stats StorageStats
+	stats.LsmSize, stats.VlogSize = pstore.Size()
+	tables := pstore.Tables()
+	stats.Tables = len(tables)
+	for _, t := range tables {
+		stats.StaleBytes += uint64(t.StaleDataSize)
+	}
+	if !scan {
+		return &stats, nil
+	}
+
+	discardTs, err := MinReadTs()
+	if err != nil {
+		return nil, err
+	}
+	if err := scanVersions(ctx, pstore, discardTs, &stats); err != nil {
+		return nil, err
+	}
+	stats.Scanned, stats.DiscardTs = true, discardTs
+	return &stats, nil
+}
+
+// scanVersions counts the versions of the keys which the compactions would drop. Badger keeps
+// the newest version at or below the discard ts, and drops the older ones if it's deleted,
+// expired, or marked to discard the earlier versions, as the rollups are.
+func scanVersions(ctx context.Context, db *badger.DB, discardTs uint64,
+	stats *StorageStats) error {
+	txn := db.NewTransactionAt(math.MaxUint64, false)
+	defer txn.Discard()
+	iopt := badger.DefaultIteratorOptions
+	iopt.AllVersions = true
+	iopt.PrefetchValues = false
+	itr := txn.NewIterator(iopt)
+	defer itr.Close()
+
+	valueThreshold := db.Opts().ValueThreshold
+	var lastKey []byte
+	// shadowed is true once the versions of the current key are below a version which
+	// discards them.
+	var shadowed, kept bool
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		if stats.Versions%10000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		item := itr.Item()
+		if !bytes.Equal(item.Key(), lastKey) {
+			lastKey = item.KeyCopy(lastKey)
+			shadowed, kept = false, false
+			stats.Keys++
+		}
+		stats.Versions++
+
+		if shadowed {
+			stats.DiscardableVersions++
+			stats.DiscardableBytes += uint64(item.EstimatedSize())
+			continue
+		}
+		if item.Version() <= discardTs && !kept {
+			kept = true
+			shadowed = item.IsDeletedOrExpired() || item.DiscardEarlierVersions()
+		}
+		if item.ValueSize() >= valueThreshold {
+			stats.LiveVlogBytes += uint64(item.ValueSize())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanVersions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storagestats_")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := badger.OpenManaged(badger.DefaultOptions(dir).WithValueThreshold(64).
+		WithNumVersionsToKeep(1 << 30))
+	require.NoError(t, err)
+	defer db.Close()
+
+	write := func(key string, ts uint64, e *badger.Entry) {
+		txn := db.NewTransactionAt(ts, true)
+		defer txn.Discard()
+		require.NoError(t, txn.SetEntry(e))
+		require.NoError(t, txn.CommitAt(ts, nil))
+	}
+	// Two deltas and a rollup discarding them, then a delta above the discard ts.
+	write("a", 1, badger.NewEntry([]byte("a"), []byte("1")))
+	write("a", 2, badger.NewEntry([]byte("a"), []byte("2")))
+	write("a", 3, badger.NewEntry([]byte("a"), []byte("3")).WithDiscard())
+	write("a", 6, badger.NewEntry([]byte("a"), []byte("6")))
+	// The versions of b aren't discarded, but the ones of c are below a delete.
+	write("b", 1, badger.NewEntry([]byte("b"), []byte("1")))
+	write("b", 4, badger.NewEntry([]byte("b"), make([]byte, 100)))
+	write("c", 1, badger.NewEntry([]byte("c"), make([]byte, 100)))
+	txn := db.NewTransactionAt(2, true)
+	require.NoError(t, txn.Delete([]byte("c")))
+	require.NoError(t, txn.CommitAt(2, nil))
+
+	var stats StorageStats
+	require.NoError(t, scanVersions(context.Background(), db, 5, &stats))
+	require.Equal(t, uint64(3), stats.Keys)
+	require.Equal(t, uint64(8), stats.Versions)
+	require.Equal(t, uint64(3), stats.DiscardableVersions)
+	require.Greater(t, stats.DiscardableBytes, uint64(0))
+	// Badger estimates the size of the values in the value log from their pointers.
+	require.InDelta(t, 100, stats.LiveVlogBytes, 4)
+
+	// Nothing is discarded below the oldest version.
+	stats = StorageStats{}
+	require.NoError(t, scanVersions(context.Background(), db, 0, &stats))
+	require.Zero(t, stats.DiscardableVersions)
+	require.InDelta(t, 200, stats.LiveVlogBytes, 8)
+
+	stats = StorageStats{Scanned: true, VlogSize: 400, StaleBytes: 10, LiveVlogBytes: 100,
+		DiscardableBytes: 20}
+	require.Equal(t, 0.25, stats.VlogUtilization())
+	require.Equal(t, uint64(330), stats.ReclaimableBytes())
+}
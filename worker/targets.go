@@ -0,0 +1,267 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// With --limit "require-existing-targets=true", a uid edge can only point to a node which has
+// data, i.e. a value or an edge of any predicate. The targets are checked when the mutation is
+// received, and the mutation is rejected if one of them doesn't exist and the mutation commits
+// the transaction. Otherwise, a later mutation of the same transaction can still create them:
+// the missing targets are recorded, the ones set as subjects by the next mutations are removed,
+// and the transaction is aborted at commit if some are left. The data of the other transactions
+// isn't looked at again at commit, as the transaction reads at its start ts. The commit has to be
+// sent to the alpha which received the mutations, as the dgo clients do.
+
+// pendingTargets holds the targets of the open transactions which didn't exist when their
+// mutations were received, indexed by the start ts of the transaction.
+var pendingTargets = struct {
+	sync.Mutex
+	txns map[uint64]*txnTargets
+}{txns: make(map[uint64]*txnTargets)}
+
+type txnTargets struct {
+	// targets maps the uid of a target to the predicate of an edge pointing to it.
+	targets  map[uint64]string
+	lastSeen time.Time
+}
+
+type commitNowKey struct{}
+
+// WithCommitNow returns a context for mutations which commit their transaction, so that the
+// targets they point to can't be created by a later mutation.
+func WithCommitNow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, commitNowKey{}, true)
+}
+
+// checkTargets finds the uid targets of the mutation which don't have any data. They are
+// rejected if the mutation commits the transaction, else they are recorded to be checked when
+// the transaction commits.
+func checkTargets(ctx context.Context, m *pb.Mutations) error {
+	if !x.Config.RequireExistingTargets {
+		return nil
+	}
+	resolvePendingTargets(m.StartTs, m.Edges)
+	targets := edgeTargets(m.Edges)
+	if len(targets) == 0 {
+		return nil
+	}
+
+	missing, err := missingTargets(ctx, targets, m.StartTs)
+	if err != nil || len(missing) == 0 {
+		return err
+	}
+	if commitNow, _ := ctx.Value(commitNowKey{}).(bool); commitNow {
+		return targetsError(missing)
+	}
+	addPendingTargets(m.StartTs, missing)
+	return nil
+}
+
+// addPendingTargets records the missing targets of a mutation of the transaction.
+func addPendingTargets(startTs uint64, missing map[uint64]string) {
+	pendingTargets.Lock()
+	defer pendingTargets.Unlock()
+	now := time.Now()
+	for ts, txn := range pendingTargets.txns {
+		// The transactions which are never committed are aborted by Zero after a while.
+		if now.Sub(txn.lastSeen) > x.WorkerConfig.AbortOlderThan {
+			delete(pendingTargets.txns, ts)
+		}
+	}
+	txn, ok := pendingTargets.txns[startTs]
+	if !ok {
+		txn = &txnTargets{targets: make(map[uint64]string)}
+		pendingTargets.txns[startTs] = txn
+	}
+	txn.lastSeen = now
+	for uid, attr := range missing {
+		txn.targets[uid] = attr
+	}
+}
+
+// resolvePendingTargets removes the pending targets of the transaction which are created by the
+// edges of its mutation.
+func resolvePendingTargets(startTs uint64, edges []*pb.DirectedEdge) {
+	pendingTargets.Lock()
+	defer pendingTargets.Unlock()
+	txn, ok := pendingTargets.txns[startTs]
+	if !ok {
+		return
+	}
+	for _, edge := range edges {
+		if edge.Op == pb.DirectedEdge_SET {
+			delete(txn.targets, edge.Entity)
+		}
+	}
+	txn.lastSeen = time.Now()
+}
+
+// edgeTargets returns the uid targets of the edges set by a mutation which need to be checked,
+// along with the predicate of an edge pointing to each of them.
+func edgeTargets(edges []*pb.DirectedEdge) map[uint64]string {
+	// The subjects of the mutation exist once it is applied, which allows e.g.
+	// _:a <friend> _:b . _:b <name> "b" .
+	subjects := make(map[uint64]struct{})
+	for _, edge := range edges {
+		if edge.Op == pb.DirectedEdge_SET {
+			subjects[edge.Entity] = struct{}{}
+		}
+	}
+	targets := make(map[uint64]string)
+	for _, edge := range edges {
+		if edge.Op != pb.DirectedEdge_SET || edge.ValueType != pb.Posting_UID ||
+			edge.ValueId == 0 {
+			continue
+		}
+		if _, ok := subjects[edge.ValueId]; ok {
+			continue
+		}
+		if _, ok := targets[edge.ValueId]; !ok {
+			targets[edge.ValueId] = edge.Attr
+		}
+	}
+	return targets
+}
+
+// checkPendingTargets returns an error if some targets of the transaction which didn't exist
+// when its mutations were received weren't created by its later mutations. It is called before
+// the transaction is committed, when all of its mutations have been applied.
+func checkPendingTargets(startTs uint64) error {
+	pendingTargets.Lock()
+	txn, ok := pendingTargets.txns[startTs]
+	delete(pendingTargets.txns, startTs)
+	pendingTargets.Unlock()
+	if !ok || len(txn.targets) == 0 {
+		return nil
+	}
+	return targetsError(txn.targets)
+}
+
+// targetsError returns the error of the missing targets, for the lowest uid.
+func targetsError(missing map[uint64]string) error {
+	uids := make([]uint64, 0, len(missing))
+	for uid := range missing {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	return errors.Errorf("Edge %s points to uid %#x, which doesn't have any data. Mutations "+
+		"can only point to existing nodes when require-existing-targets is set",
+		x.ParseAttr(missing[uids[0]]), uids[0])
+}
+
+// missingTargets returns the targets which don't have any data at readTs. Every predicate of
+// the namespace needs to be looked at, so the targets found in a predicate aren't looked for in
+// the next ones. dgraph.type is looked at first, as most nodes have a type.
+func missingTargets(ctx context.Context, targets map[uint64]string,
+	readTs uint64) (map[uint64]string, error) {
+
+	missing := make(map[uint64]string, len(targets))
+	namespaces := make(map[uint64]struct{})
+	for uid, attr := range targets {
+		missing[uid] = attr
+		namespaces[x.ParseNamespace(attr)] = struct{}{}
+	}
+
+	for ns := range namespaces {
+		for _, pred := range namespacePredicates(ns) {
+			uids := make([]uint64, 0, len(missing))
+			for uid, attr := range missing {
+				if x.ParseNamespace(attr) == ns {
+					uids = append(uids, uid)
+				}
+			}
+			if len(uids) == 0 {
+				break
+			}
+			sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+
+			q := &pb.Query{
+				Attr:    pred,
+				UidList: &pb.List{Uids: uids},
+				ReadTs:  readTs,
+				SrcFunc: &pb.SrcFunction{Name: "has"},
+			}
+			res, err := ProcessTaskOverNetwork(ctx, q)
+			switch {
+			case err == errNonExistentTablet:
+				// The predicate has been dropped.
+				continue
+			case err != nil:
+				return nil, errors.Wrapf(err, "while looking for the data of the uids")
+			}
+			for _, list := range res.UidMatrix {
+				for _, uid := range list.Uids {
+					delete(missing, uid)
+				}
+			}
+		}
+	}
+	return missing, nil
+}
+
+// MissingUids returns the uids, sorted, which don't have any data in the namespace at readTs.
+func MissingUids(ctx context.Context, ns uint64, uids []uint64,
+	readTs uint64) ([]uint64, error) {
+
+	targets := make(map[uint64]string, len(uids))
+	attr := x.NamespaceAttr(ns, "dgraph.type")
+	for _, uid := range uids {
+		targets[uid] = attr
+	}
+	missing, err := missingTargets(ctx, targets, readTs)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]uint64, 0, len(missing))
+	for uid := range missing {
+		res = append(res, uid)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res, nil
+}
+
+// namespacePredicates returns the predicates of the given namespace served by any group.
+func namespacePredicates(ns uint64) []string {
+	typePred := x.NamespaceAttr(ns, "dgraph.type")
+	preds := []string{typePred}
+
+	g := groups()
+	g.RLock()
+	defer g.RUnlock()
+	if g.state == nil {
+		return preds
+	}
+	for _, group := range g.state.Groups {
+		for pred := range group.Tablets {
+			if pred != typePred && x.ParseNamespace(pred) == ns {
+				preds = append(preds, pred)
+			}
+		}
+	}
+	return preds
+}
@@ -19,6 +19,7 @@ package worker
 import (
 	"bytes"
 	"context"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -44,6 +45,7 @@ import (
 	cindex "github.com/google/codesearch/index"
 	cregexp "github.com/google/codesearch/regexp"
 	"github.com/pkg/errors"
+	geom "github.com/twpayne/go-geom"
 )
 
 func invokeNetworkRequest(ctx context.Context, addr string,
@@ -134,6 +136,8 @@ func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error
 	switch {
 	case err != nil:
 		return nil, err
+	case gid == 0 && q.SrcFunc != nil && q.SrcFunc.IsCount && q.UidList != nil:
+		return processUnservedCountFilter(ctx, q)
 	case gid == 0:
 		return nil, errNonExistentTablet
 	}
@@ -149,10 +153,13 @@ func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error
 		return processTask(ctx, q, gid)
 	}
 
-	result, err := processWithBackupRequest(ctx, gid,
-		func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
-			return c.ServeTask(ctx, q)
-		})
+	result, err := processWithGroupTimeout(ctx, gid, func(ctx context.Context) (interface{},
+		error) {
+		return processWithBackupRequest(ctx, gid,
+			func(ctx context.Context, c pb.WorkerClient) (interface{}, error) {
+				return c.ServeTask(ctx, q)
+			})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +172,28 @@ func ProcessTaskOverNetwork(ctx context.Context, q *pb.Query) (*pb.Result, error
 	return reply, nil
 }
 
+// processUnservedCountFilter evaluates a count filter, e.g. eq(count(friend), 0), on a predicate
+// which isn't served by any tablet. There is no data for the predicate, so every uid has a count
+// of zero and the filter keeps all the uids if zero matches the comparison.
+func processUnservedCountFilter(ctx context.Context, q *pb.Query) (*pb.Result, error) {
+	if q.Reverse {
+		// Without a tablet the predicate isn't in the schema, so it can't have @reverse.
+		return nil, errors.Errorf("Predicate %s doesn't have reverse edge", x.ParseAttr(q.Attr))
+	}
+	srcFn, err := parseSrcFn(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.Result{}
+	if srcFn.fnType != compareScalarFn || !evalCountCompare(srcFn.fname, 0, srcFn.threshold) {
+		return out, nil
+	}
+	for _, uid := range q.UidList.Uids {
+		out.UidMatrix = append(out.UidMatrix, &pb.List{Uids: []uint64{uid}})
+	}
+	return out, nil
+}
+
 // convertValue converts the data to the schema.State() type of predicate.
 func convertValue(attr, data string) (types.Val, error) {
 	// Parse given value and get token. There should be only one token.
@@ -220,6 +249,8 @@ const (
 	uidInFn
 	customIndexFn
 	matchFn
+	distanceFn
+	relevanceFn
 	standardFn = 100
 )
 
@@ -250,6 +281,10 @@ func parseFuncTypeHelper(name string) (FuncType, string) {
 		return aggregatorFn, f
 	case "checkpwd":
 		return passwordFn, f
+	case "distance":
+		return distanceFn, f
+	case "relevance":
+		return relevanceFn, f
 	case "regexp":
 		return regexFn, f
 	case "alloftext", "anyoftext":
@@ -304,7 +339,7 @@ type funcArgs struct {
 // The function tells us whether we want to fetch value posting lists or uid posting lists.
 func (srcFn *functionContext) needsValuePostings(typ types.TypeID) (bool, error) {
 	switch srcFn.fnType {
-	case aggregatorFn, passwordFn:
+	case aggregatorFn, passwordFn, distanceFn:
 		return true, nil
 	case compareAttrFn:
 		if len(srcFn.tokens) > 0 {
@@ -317,6 +352,9 @@ func (srcFn *functionContext) needsValuePostings(typ types.TypeID) (bool, error)
 	case uidInFn, compareScalarFn:
 		// Operate on uid postings
 		return false, nil
+	case relevanceFn:
+		// The scores are computed from the index by handleRelevanceFunction.
+		return false, nil
 	case notAFunction:
 		return typ.IsScalar(), nil
 	}
@@ -341,7 +379,7 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 	}
 
 	switch srcFn.fnType {
-	case notAFunction, aggregatorFn, passwordFn, compareAttrFn:
+	case notAFunction, aggregatorFn, passwordFn, compareAttrFn, distanceFn:
 	default:
 		return errors.Errorf("Unhandled function in handleValuePostings: %s", srcFn.fname)
 	}
@@ -354,6 +392,10 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 		return errors.Errorf("checkpwd fn can only be used on attr: [%s] with schema type "+
 			"password. Got type: %s", x.ParseAttr(q.Attr), types.TypeID(srcFn.atype).Name())
 	}
+	if srcFn.fnType == distanceFn && srcFn.atype != types.GeoID {
+		return errors.Errorf("distance fn can only be used on attr: [%s] with schema type "+
+			"geo. Got type: %s", x.ParseAttr(q.Attr), types.TypeID(srcFn.atype).Name())
+	}
 	if srcFn.n == 0 {
 		return nil
 	}
@@ -439,6 +481,13 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 			uidList := new(pb.List)
 			var vl pb.ValueList
 			for _, val := range vals {
+				keep, err := checkStoredType(q.Attr, val, srcFn.atype)
+				if err != nil {
+					return err
+				}
+				if !keep {
+					continue
+				}
 				newValue, err := convertToType(val, srcFn.atype)
 				if err != nil {
 					return err
@@ -500,6 +549,17 @@ func (qs *queryState) handleValuePostings(ctx context.Context, args funcArgs) er
 				}
 				// Add an empty UID list to make later processing consistent
 				out.UidMatrix = append(out.UidMatrix, &pb.List{})
+			case srcFn.fnType == distanceFn:
+				lastPos := len(out.ValueMatrix) - 1
+				if len(out.ValueMatrix[lastPos].Values) > 0 {
+					dist, err := geoDistance(out.ValueMatrix[lastPos].Values, srcFn.geoRef)
+					if err != nil {
+						return err
+					}
+					out.ValueMatrix[lastPos].Values = []*pb.TaskValue{ctask.FromFloat(dist)}
+				}
+				// Add an empty UID list to make later processing consistent
+				out.UidMatrix = append(out.UidMatrix, &pb.List{})
 			default:
 				out.UidMatrix = append(out.UidMatrix, uidList)
 			}
@@ -776,7 +836,7 @@ func (qs *queryState) handleUidPostings(
 					return posting.ErrTsTooOld
 				}
 				count := int64(len)
-				if evalCompare(srcFn.fname, count, srcFn.threshold[0]) {
+				if evalCountCompare(srcFn.fname, count, srcFn.threshold) {
 					tlist := &pb.List{Uids: []uint64{q.UidList.Uids[i]}}
 					out.UidMatrix = append(out.UidMatrix, tlist)
 				}
@@ -912,13 +972,18 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 	case knownGid != groups().groupId():
 		return nil, errUnservedTablet
 	}
+	recordAccess(q.Attr, q.UidList.GetUids())
 
 	var qs queryState
-	if q.Cache == UseTxnCache {
+	switch {
+	case q.Cache == UseTxnCache && q.TxnStartTs != 0:
+		qs.cache = posting.Oracle().ReadCommittedCache(q.TxnStartTs, q.ReadTs)
+	case q.Cache == UseTxnCache:
 		qs.cache = posting.Oracle().CacheAt(q.ReadTs)
 	}
 	if qs.cache == nil {
 		qs.cache = posting.NoCache(q.ReadTs)
+		qs.committed = true
 	}
 	// For now, remove the query level cache. It is causing contention for queries with high
 	// fan-out.
@@ -931,6 +996,9 @@ func processTask(ctx context.Context, q *pb.Query, gid uint32) (*pb.Result, erro
 
 type queryState struct {
 	cache *posting.LocalCache
+	// committed is true if the cache only reads the committed data, without the mutations of a
+	// transaction.
+	committed bool
 }
 
 func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint32) (
@@ -995,12 +1063,18 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 	if err != nil {
 		return nil, err
 	}
-	if needsValPostings {
+	switch {
+	case srcFn.fnType == relevanceFn:
+		span.Annotate(nil, "handleRelevanceFunction")
+		if err = qs.handleRelevanceFunction(ctx, args); err != nil {
+			return nil, err
+		}
+	case needsValPostings:
 		span.Annotate(nil, "handleValuePostings")
 		if err = qs.handleValuePostings(ctx, args); err != nil {
 			return nil, err
 		}
-	} else {
+	default:
 		span.Annotate(nil, "handleUidPostings")
 		if err = qs.handleUidPostings(ctx, args, opts); err != nil {
 			return nil, err
@@ -1061,6 +1135,28 @@ func (qs *queryState) helpProcessTask(ctx context.Context, q *pb.Query, gid uint
 		}
 	}
 
+	if q.FuncLimit > 0 && srcFn.isFuncAtRoot {
+		// The uids are sorted, so the lowest ones are kept. The lists of a function with several
+		// tokens are capped again once they are merged, in query.ProcessGraph.
+		for _, list := range out.UidMatrix {
+			if len(list.Uids) > int(q.FuncLimit) {
+				list.Uids = list.Uids[:q.FuncLimit]
+			}
+		}
+	}
+
+	// The sample of has is taken in handleHasFunction. The uids of the functions with several
+	// tokens are only sampled once they are merged in query.ProcessGraph, as a uid can be in
+	// several of the lists. The same seed gives the same sample of the same uids there.
+	if q.Sample > 0 && srcFn.isFuncAtRoot && srcFn.fnType != hasFn && len(out.UidMatrix) == 1 &&
+		len(out.ValueMatrix) == 0 {
+		sampler := algo.NewUidSampler(int(q.Sample), q.SampleSeed)
+		for _, uid := range out.UidMatrix[0].Uids {
+			sampler.Add(uid)
+		}
+		out.UidMatrix[0].Uids = sampler.Uids()
+	}
+
 	out.IntersectDest = srcFn.intersectDest
 	return out, nil
 }
@@ -1088,6 +1184,12 @@ func (qs *queryState) handleCompareScalarFunction(ctx context.Context, arg funcA
 		return errors.Errorf("Need @count directive in schema for attr: %s for fn: %s at root",
 			x.ParseAttr(attr), arg.srcFn.fname)
 	}
+	// The count index of the reverse edges is only kept if the predicate has @reverse. Without
+	// it, the function would silently match nothing.
+	if arg.q.Reverse && !schema.State().IsReversed(ctx, attr) {
+		return errors.Errorf("Need @reverse directive in schema for attr: %s for fn: %s of "+
+			"count(~%s) at root", x.ParseAttr(attr), arg.srcFn.fname, x.ParseAttr(attr))
+	}
 	counts := arg.srcFn.threshold
 	cp := countParams{
 		fn:      arg.srcFn.fname,
@@ -1268,6 +1370,10 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 						return false
 					}
 					for _, sv := range svs {
+						if _, err := checkStoredType(attr, sv, typ); err != nil {
+							filterErr = err
+							return false
+						}
 						dst, err := types.Convert(sv, typ)
 						if err == nil && compareFunc(dst) {
 							return true
@@ -1289,6 +1395,10 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 					}
 					return false
 				}
+				if _, err := checkStoredType(attr, sv, typ); err != nil {
+					filterErr = err
+					return false
+				}
 				dst, err := types.Convert(sv, typ)
 				return err == nil && compareFunc(dst)
 			case ".":
@@ -1303,6 +1413,10 @@ func (qs *queryState) handleCompareFunction(ctx context.Context, arg funcArgs) e
 					return false
 				}
 				for _, sv := range values {
+					if _, err := checkStoredType(attr, sv, typ); err != nil {
+						filterErr = err
+						return false
+					}
 					dst, err := types.Convert(sv, typ)
 					if err == nil && compareFunc(dst) {
 						return true
@@ -1590,7 +1704,7 @@ func (qs *queryState) filterStringFunction(arg funcArgs) error {
 	case customIndexFn:
 		filter.tokens = arg.srcFn.tokens
 		filter.match = defaultMatch
-		filter.tokName = arg.q.SrcFunc.Args[0]
+		filter.tokName = arg.srcFn.tokName
 		filtered = matchStrings(filtered, values, &filter)
 	case compareAttrFn:
 		// filter.ineqValue = arg.srcFn.ineqValue
@@ -1631,6 +1745,26 @@ func (qs *queryState) getValsForUID(attr, lang string, uid, ReadTs uint64) ([]ty
 	return vals, err
 }
 
+// geoDistance returns the distance in meters between ref and the nearest of the given geo
+// values.
+func geoDistance(vals []*pb.TaskValue, ref geom.T) (float64, error) {
+	min := math.Inf(1)
+	for _, tv := range vals {
+		src := types.ValueForType(types.BinaryID)
+		src.Value = tv.Val
+		gc, err := types.Convert(src, types.GeoID)
+		if err != nil {
+			return 0, err
+		}
+		dist, err := types.GeoDistance(gc.Value.(geom.T), ref)
+		if err != nil {
+			return 0, err
+		}
+		min = math.Min(min, dist)
+	}
+	return min, nil
+}
+
 func matchRegex(value types.Val, regex *cregexp.Regexp) bool {
 	return len(value.Value.(string)) > 0 && regex.MatchString(value.Value.(string), true, true) > 0
 }
@@ -1638,6 +1772,7 @@ func matchRegex(value types.Val, regex *cregexp.Regexp) bool {
 type functionContext struct {
 	tokens        []string
 	geoQuery      *types.GeoQueryData
+	geoRef        geom.T // Used by the distance function.
 	intersectDest bool
 	// eqTokens is used by compareAttr functions. It stores values corresponding to each
 	// function argument. There could be multiple arguments to `eq` function but only one for
@@ -1655,6 +1790,8 @@ type functionContext struct {
 	isFuncAtRoot   bool
 	isStringFn     bool
 	atype          types.TypeID
+	// tokName is the tokenizer of the index used by customIndexFn.
+	tokName string
 }
 
 const (
@@ -1826,6 +1963,31 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 			return nil, err
 		}
 		fc.n = len(q.UidList.Uids)
+	case distanceFn:
+		if err = ensureArgsCount(q.SrcFunc, 2); err != nil {
+			return nil, err
+		}
+		if q.UidList == nil {
+			return nil, errors.Errorf("distance fn can only be used inside a block")
+		}
+		if fc.geoRef, err = types.ParseGeoReference(q.SrcFunc.Args[0]); err != nil {
+			return nil, errors.Wrapf(err, "while parsing the arguments of distance")
+		}
+		fc.n = len(q.UidList.Uids)
+	case relevanceFn:
+		if err = ensureArgsCount(q.SrcFunc, 2); err != nil {
+			return nil, err
+		}
+		if q.UidList == nil {
+			return nil, errors.Errorf("relevance fn can only be used inside a block")
+		}
+		if !schema.State().HasTokenizer(ctx, tok.IdentBM25, attr) {
+			return nil, errors.Errorf("relevance fn requires @index(bm25) on attr: [%s]",
+				x.ParseAttr(attr))
+		}
+		// The terms are analyzed as the values, and only scored once.
+		fc.tokens = x.RemoveDuplicates(tok.FullTextTerms(q.SrcFunc.Args[0], langForFunc(q.Langs)))
+		fc.n = len(q.UidList.Uids)
 	case standardFn, fullTextSearchFn:
 		// srcfunc 0th val is func name and and [2:] are args.
 		// we tokenize the arguments of the query.
@@ -1866,11 +2028,24 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 		fc.tokens = q.SrcFunc.Args
 		fc.n = len(fc.tokens)
 	case customIndexFn:
+		// The parser sets an empty tokenizer for a list of values, e.g. anyof(tags, ["a", "b"]).
+		// An empty list matches no node.
+		if len(q.SrcFunc.Args) > 0 && q.SrcFunc.Args[0] == "" {
+			fc.tokens, fc.tokName, err = setMembershipTokens(ctx, q.Attr, q.SrcFunc.Args[1:])
+			if err != nil {
+				return nil, err
+			}
+			fc.intersectDest = needsIntersect(f)
+			fc.n = len(fc.tokens)
+			break
+		}
 		if err = ensureArgsCount(q.SrcFunc, 2); err != nil {
 			return nil, err
 		}
 		tokerName := q.SrcFunc.Args[0]
-		if !verifyCustomIndex(ctx, q.Attr, tokerName) {
+		if et, ok := exprIndexTokenizer(ctx, q.Attr, tokerName); ok {
+			tokerName = et.Name()
+		} else if !verifyCustomIndex(ctx, q.Attr, tokerName) {
 			return nil, errors.Errorf("Attribute %s is not indexed with custom tokenizer %s",
 				x.ParseAttr(q.Attr), tokerName)
 		}
@@ -1884,6 +2059,7 @@ func parseSrcFn(ctx context.Context, q *pb.Query) (*functionContext, error) {
 		}
 		fc.tokens, _ = tok.BuildTokens(valToTok.Value,
 			tok.GetTokenizerForLang(tokenizer, langForFunc(q.Langs)))
+		fc.tokName = tokerName
 		fc.intersectDest = needsIntersect(f)
 		fc.n = len(fc.tokens)
 	case regexFn:
@@ -2000,47 +2176,23 @@ func applyFacetsTree(postingFacets []*api.Facet, ftree *facetsTree) (bool, error
 		return true, nil
 	}
 	if ftree.function != nil {
-		var fc *api.Facet
-		for _, fci := range postingFacets {
-			if fci.Key == ftree.function.key {
-				fc = fci
-				break
-			}
-		}
-		if fc == nil { // facet is not there
-			return false, nil
-		}
-
-		switch ftree.function.fnType {
-		case compareAttrFn: // lt, gt, le, ge, eq
-			fVal, err := facets.ValFor(fc)
-			if err != nil {
-				return false, err
-			}
-
-			v, ok := ftree.function.typesToVal[fVal.Tid]
-			if !ok {
-				// Not found in map and hence convert it here.
-				v, err = types.Convert(ftree.function.val, fVal.Tid)
-				if err != nil {
-					// ignore facet if not of appropriate type.
-					return false, nil
+		// The values of a list facet are stored as consecutive facets with the same key. The
+		// function matches the posting if it matches any of the values.
+		found := false
+		for _, fc := range postingFacets {
+			if fc.Key != ftree.function.key {
+				if found {
+					break
 				}
+				continue
 			}
-
-			return types.CompareVals(ftree.function.name, fVal, v), nil
-
-		case standardFn: // allofterms, anyofterms
-			facetType, err := facets.TypeIDFor(fc)
-			if err != nil {
-				return false, err
-			}
-			if facetType != types.StringID {
-				return false, nil
+			found = true
+			ok, err := applyFacetFunc(fc, ftree.function)
+			if err != nil || ok {
+				return ok, err
 			}
-			return filterOnStandardFn(ftree.function.name, fc.Tokens, ftree.function.tokens)
 		}
-		return false, errors.Errorf("Fn %s not supported in facets filtering.", ftree.function.name)
+		return false, nil
 	}
 
 	res := make([]bool, 0, 2) // We can have max two children for a node.
@@ -2064,6 +2216,40 @@ func applyFacetsTree(postingFacets []*api.Facet, ftree *facetsTree) (bool, error
 	return false, errors.Errorf("Unexpected behavior in applyFacetsTree.")
 }
 
+// applyFacetFunc tells whether the facet satisfies the function.
+func applyFacetFunc(fc *api.Facet, fn *facetsFunc) (bool, error) {
+	switch fn.fnType {
+	case compareAttrFn: // lt, gt, le, ge, eq
+		fVal, err := facets.ValFor(fc)
+		if err != nil {
+			return false, err
+		}
+
+		v, ok := fn.typesToVal[fVal.Tid]
+		if !ok {
+			// Not found in map and hence convert it here.
+			v, err = types.Convert(fn.val, fVal.Tid)
+			if err != nil {
+				// ignore facet if not of appropriate type.
+				return false, nil
+			}
+		}
+
+		return types.CompareVals(fn.name, fVal, v), nil
+
+	case standardFn: // allofterms, anyofterms
+		facetType, err := facets.TypeIDFor(fc)
+		if err != nil {
+			return false, err
+		}
+		if facetType != types.StringID {
+			return false, nil
+		}
+		return filterOnStandardFn(fn.name, fc.Tokens, fn.tokens)
+	}
+	return false, errors.Errorf("Fn %s not supported in facets filtering.", fn.name)
+}
+
 // filterOnStandardFn : tells whether facet corresponding to fcTokens can be taken or not.
 // fcTokens and argTokens should be sorted.
 func filterOnStandardFn(fname string, fcTokens []string, argTokens []string) (bool, error) {
@@ -2237,7 +2423,9 @@ func (qs *queryState) evaluate(cp countParams, out *pb.Result) error {
 	}
 	if illegal {
 		return errors.Errorf("count(predicate) cannot be used to search for " +
-			"negative counts (nonsensical) or zero counts (not tracked).")
+			"negative counts (nonsensical) or zero counts (not tracked). Zero counts can be " +
+			"matched in a filter, e.g. @filter(eq(count(predicate), 0)) or " +
+			"@filter(not has(predicate)).")
 	}
 
 	countKey := x.CountKey(cp.attr, uint32(countl), cp.reverse)
@@ -2276,10 +2464,10 @@ func (qs *queryState) evaluate(cp countParams, out *pb.Result) error {
 	itr := txn.NewIterator(itOpt)
 	defer itr.Close()
 
+	var keys [][]byte
+	seen := make(map[string]struct{})
 	for itr.Seek(countKey); itr.Valid(); itr.Next() {
-		item := itr.Item()
-		var key []byte
-		key = item.KeyCopy(key)
+		key := itr.Item().KeyCopy(nil)
 		k, err := x.Parse(key)
 		if err != nil {
 			return err
@@ -2287,8 +2475,39 @@ func (qs *queryState) evaluate(cp countParams, out *pb.Result) error {
 		if cp.fn == between && int64(k.Count) > counth {
 			break
 		}
+		keys = append(keys, key)
+		seen[string(key)] = struct{}{}
+	}
+
+	// The count keys written by the transaction of the query aren't in Badger until it commits,
+	// they are only in its cache. They are read as well, so that the counts agree with the edges
+	// read in the same transaction. The queries which don't use the cache of a transaction, like
+	// the best effort ones, only see the committed counts.
+	inRange := func(count int64) bool {
+		switch cp.fn {
+		case "le", "lt":
+			return count <= countl
+		case between:
+			return count >= countl && count <= counth
+		default:
+			return count >= countl
+		}
+	}
+	for _, key := range qs.cache.KeysWithPrefix(itOpt.Prefix) {
+		if _, ok := seen[string(key)]; ok {
+			continue
+		}
+		k, err := x.Parse(key)
+		if err != nil {
+			return err
+		}
+		if inRange(int64(k.Count)) {
+			keys = append(keys, key)
+		}
+	}
 
-		pl, err := qs.cache.Get(item.KeyCopy(key))
+	for _, key := range keys {
+		pl, err := qs.cache.Get(key)
 		if err != nil {
 			return err
 		}
@@ -2349,12 +2568,30 @@ func (qs *queryState) handleHasFunction(ctx context.Context, q *pb.Query, out *p
 		return err
 	}
 
-	cnt := int32(0)
+	// With a sample, only the sampled uids are kept while iterating over the predicate.
+	var sampler *algo.UidSampler
+	if q.Sample > 0 {
+		sampler = algo.NewUidSampler(int(q.Sample), q.SampleSeed)
+	}
+	addUid := func(uid uint64) {
+		if sampler != nil {
+			sampler.Add(uid)
+			return
+		}
+		result.Uids = append(result.Uids, uid)
+	}
+
+	// seen counts the uids matching the function, including the ones skipped by the offset, so
+	// that the iteration stops once FuncLimit of them are found.
+	cnt, seen := int32(0), int32(0)
 loop:
 	// This function could be switched to the stream.Lists framework, but after the change to use
 	// BitCompletePosting, the speed here is already pretty fast. The slowdown for @lang predicates
 	// occurs in filterStringFunction (like has(name) queries).
 	for it.Seek(startKey); it.Valid(); {
+		if q.FuncLimit > 0 && seen >= q.FuncLimit {
+			break
+		}
 		item := it.Item()
 		if bytes.Equal(item.Key(), prevKey) {
 			it.Next()
@@ -2391,12 +2628,13 @@ loop:
 			case err != nil:
 				return err
 			}
+			seen++
 			// skip entries upto Offset and do not store in the result.
 			if cnt < q.Offset {
 				cnt++
 				continue
 			}
-			result.Uids = append(result.Uids, pk.Uid)
+			addUid(pk.Uid)
 
 			// We'll stop fetching if we fetch the required count.
 			if len(result.Uids) >= int(q.First) {
@@ -2422,12 +2660,13 @@ loop:
 			case err != nil:
 				return err
 			}
+			seen++
 			// skip entries upto Offset and do not store in the result.
 			if cnt < q.Offset {
 				cnt++
 				continue
 			}
-			result.Uids = append(result.Uids, pk.Uid)
+			addUid(pk.Uid)
 
 			// We'll stop fetching if we fetch the required count.
 			if len(result.Uids) >= int(q.First) {
@@ -2443,6 +2682,9 @@ loop:
 			}
 		}
 	}
+	if sampler != nil {
+		result.Uids = sampler.Uids()
+	}
 	if span != nil {
 		span.Annotatef(nil, "handleHasFunction found %d uids", len(result.Uids))
 	}
@@ -65,6 +65,47 @@ func verifyCustomIndex(ctx context.Context, attr string, tokenizerName string) b
 	return false
 }
 
+// setMembershipTokens returns the index tokens of the values of anyof and allof used with a list of
+// values, e.g. allof(tags, ["a", "b"]), along with the name of the tokenizer. The exact index is
+// used if the predicate has one, so the values match as they are. With only a term index, the
+// values are matched case-insensitively, and a value of several words matches the nodes having
+// any (anyof) or all (allof) of its words.
+func setMembershipTokens(ctx context.Context, attr string, vals []string) ([]string, string,
+	error) {
+
+	typ, err := schema.State().TypeOf(attr)
+	if err != nil || typ != types.StringID {
+		return nil, "", errors.Errorf("Attribute %s must be of type string for anyof and allof "+
+			"with a list of values", x.ParseAttr(attr))
+	}
+	var tokenizer tok.Tokenizer
+	switch {
+	case schema.State().HasTokenizer(ctx, tok.IdentExact, attr):
+		tokenizer = tok.ExactTokenizer{}
+	case schema.State().HasTokenizer(ctx, tok.IdentTerm, attr):
+		tokenizer = tok.TermTokenizer{}
+	default:
+		return nil, "", errors.Errorf("Attribute %s needs an exact or a term index for anyof "+
+			"and allof with a list of values", x.ParseAttr(attr))
+	}
+
+	var tokens []string
+	seen := make(map[string]struct{})
+	for _, val := range vals {
+		toks, err := tok.BuildTokens(val, tokenizer)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, t := range toks {
+			if _, ok := seen[t]; !ok {
+				seen[t] = struct{}{}
+				tokens = append(tokens, t)
+			}
+		}
+	}
+	return tokens, tokenizer.Name(), nil
+}
+
 // Return string tokens from function arguments. It maps function type to correct tokenizer.
 // Note: regexp functions require regexp compilation of argument, not tokenization.
 func getStringTokens(funcArgs []string, lang string, funcType FuncType) ([]string, error) {
@@ -108,18 +149,51 @@ func pickTokenizer(ctx context.Context, attr string, f string) (tok.Tokenizer, e
 		return nil, errors.Errorf("Attribute:%s does not have proper index for comparison", attr)
 	}
 
+	// The expression indexes are never used by eq, as their tokens are the ones of the results
+	// of the expression rather than of the values. They're used by anyof and allof, see
+	// exprIndexTokenizer.
+	var candidates []tok.Tokenizer
+	for _, t := range tokenizers {
+		if _, ok := t.(tok.ExprTokenizer); !ok {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.Errorf("Attribute %s only has expression indexes, which are used "+
+			"with anyof and allof, not eq", x.ParseAttr(attr))
+	}
+
 	// If we didn't find a !isLossy() tokenizer for eq function on string type predicates,
 	// then let's see if we can find a non-trigram tokenizer
 	if typ, err := schema.State().TypeOf(attr); err == nil && typ == types.StringID {
-		for _, t := range tokenizers {
-			if t.Identifier() != tok.IdentTrigram {
+		for _, t := range candidates {
+			// The tokens of the bm25 tokenizer hold the frequencies of the terms.
+			if t.Identifier() != tok.IdentTrigram && t.Identifier() != tok.IdentBM25 {
 				return t, nil
 			}
 		}
 	}
 
 	// otherwise, lets return the first one.
-	return tokenizers[0], nil
+	return candidates[0], nil
+}
+
+// exprIndexTokenizer returns the expression index of the predicate with the expression given to
+// anyof or allof instead of the name of a tokenizer, e.g. anyof(email, "lower(email)", "A@b.com").
+// The expression is compared once normalized, so "LOWER( email )" finds the same index. If the
+// predicate has an exact and a hash index of the expression, the first one in the schema is used.
+func exprIndexTokenizer(ctx context.Context, attr, expr string) (tok.ExprTokenizer, bool) {
+	for _, t := range schema.State().Tokenizer(ctx, attr) {
+		et, ok := t.(tok.ExprTokenizer)
+		if !ok {
+			continue
+		}
+		want, err := tok.NewExprTokenizer(expr, x.ParseAttr(attr), et.Base().Name())
+		if err == nil && want.Name() == et.Name() {
+			return et, true
+		}
+	}
+	return tok.ExprTokenizer{}, false
 }
 
 // getInequalityTokens gets tokens ge/le/between compared to given tokens using the first sortable
@@ -0,0 +1,52 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// checkStoredType checks a value read from a posting list of attr against typ, the type of attr
+// in the schema, following --limit "type-mismatch". A value is only stored with another type if
+// the schema has changed since it was written. It returns false if the value has to be left out.
+// The values which are kept are converted to typ by the caller, as for any other value.
+func checkStoredType(attr string, val types.Val, typ types.TypeID) (bool, error) {
+	if sameStoredType(val.Tid, typ) {
+		return true, nil
+	}
+	switch Config.TypeMismatchMode {
+	case ErrorTypeMismatch:
+		return false, errors.Errorf("Value of predicate %s is stored as %s but its type in the "+
+			"schema is %s", x.ParseAttr(attr), val.Tid.Name(), typ.Name())
+	case SkipTypeMismatch:
+		_, err := types.Convert(val, typ)
+		return err == nil, nil
+	default:
+		return true, nil
+	}
+}
+
+// sameStoredType returns true if a value stored as stored doesn't mismatch the schema type typ.
+// The untyped values of the default type are strings, and the default type is also used for the
+// predicates which aren't in the schema.
+func sameStoredType(stored, typ types.TypeID) bool {
+	return stored == typ || typ == types.DefaultID ||
+		(stored == types.DefaultID && typ == types.StringID)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestCheckStoredType(t *testing.T) {
+	defer func(mode int) { Config.TypeMismatchMode = mode }(Config.TypeMismatchMode)
+
+	attr := x.GalaxyAttr("released")
+	date := types.Val{Tid: types.StringID, Value: []byte("2021-06-01")}
+	word := types.Val{Tid: types.StringID, Value: []byte("unknown")}
+	untyped := types.Val{Tid: types.DefaultID, Value: []byte("x")}
+
+	// The values stored with the type of the schema are always kept.
+	for _, mode := range []int{CoerceTypeMismatch, SkipTypeMismatch, ErrorTypeMismatch} {
+		Config.TypeMismatchMode = mode
+		keep, err := checkStoredType(attr, date, types.StringID)
+		require.NoError(t, err)
+		require.True(t, keep)
+		keep, err = checkStoredType(attr, untyped, types.StringID)
+		require.NoError(t, err)
+		require.True(t, keep)
+	}
+
+	Config.TypeMismatchMode = CoerceTypeMismatch
+	keep, err := checkStoredType(attr, word, types.DateTimeID)
+	require.NoError(t, err)
+	require.True(t, keep)
+
+	Config.TypeMismatchMode = SkipTypeMismatch
+	keep, err = checkStoredType(attr, date, types.DateTimeID)
+	require.NoError(t, err)
+	require.True(t, keep)
+	keep, err = checkStoredType(attr, word, types.DateTimeID)
+	require.NoError(t, err)
+	require.False(t, keep)
+
+	Config.TypeMismatchMode = ErrorTypeMismatch
+	_, err = checkStoredType(attr, date, types.DateTimeID)
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		"Value of predicate released is stored as string but its type in the schema is datetime")
+}
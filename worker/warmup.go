@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/posting"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// WarmupResult is the outcome of a warmup request.
+type WarmupResult struct {
+	// Warmed contains the predicates whose posting lists were all read. If the warmup is
+	// truncated, the predicates it didn't get to the end of are left out.
+	Warmed []string
+	// Skipped contains the predicates that are not served by this Alpha.
+	Skipped []string
+	// Bytes is the estimated amount of data read.
+	Bytes int64
+	// Truncated is set if the warmup stopped early because the block cache would be full.
+	Truncated bool
+}
+
+// WarmupPredicates reads the posting lists of the given predicates served by this Alpha, so that
+// the blocks holding them are loaded into the Badger block cache. The index keys of every
+// predicate are read first, followed by the data keys. Reading more than the block cache can hold
+// would only evict the blocks loaded earlier, so the warmup stops once the size of the block cache
+// has been read.
+func WarmupPredicates(ctx context.Context, preds []string) (*WarmupResult, error) {
+	if pstore == nil {
+		return nil, errors.New("posting store is not initialized")
+	}
+	budget := pstore.Opts().BlockCacheSize
+	if budget <= 0 {
+		return nil, errors.New("block cache is disabled, nothing to warm up")
+	}
+
+	res := &WarmupResult{}
+	var served []string
+	for _, attr := range preds {
+		ok, err := groups().ServesTablet(attr)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			res.Skipped = append(res.Skipped, attr)
+			continue
+		}
+		served = append(served, attr)
+	}
+
+	txn := pstore.NewTransactionAt(posting.Oracle().MaxAssigned(), false)
+	defer txn.Discard()
+	if err := warmupKeys(ctx, txn, served, budget, res); err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Warmed up predicates: %v. Read %d bytes. Truncated: %v. Skipped: %v",
+		res.Warmed, res.Bytes, res.Truncated, res.Skipped)
+	return res, nil
+}
+
+// warmupKeys reads the index keys and then the data keys of the predicates, until budget bytes
+// have been read. A predicate is added to res.Warmed once its data keys have all been read, as
+// its index keys were read before.
+func warmupKeys(ctx context.Context, txn *badger.Txn, attrs []string, budget int64,
+	res *WarmupResult) error {
+	readPrefix := func(prefix []byte) error {
+		itOpt := badger.DefaultIteratorOptions
+		itOpt.PrefetchValues = false
+		itOpt.Prefix = prefix
+		it := txn.NewIterator(itOpt)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			res.Bytes += it.Item().EstimatedSize()
+			if res.Bytes > budget {
+				res.Truncated = true
+				return nil
+			}
+		}
+		return nil
+	}
+
+	// Index keys are read for all the predicates before the data keys, so that the index
+	// lookups which dominate the first queries after a restart are served from the cache.
+	for _, dataKeys := range []bool{false, true} {
+		for _, attr := range attrs {
+			pk := x.ParsedKey{Attr: attr}
+			prefix := pk.IndexPrefix()
+			if dataKeys {
+				prefix = pk.DataPrefix()
+			}
+			if err := readPrefix(prefix); err != nil {
+				return err
+			}
+			if res.Truncated {
+				return nil
+			}
+			if dataKeys {
+				res.Warmed = append(res.Warmed, attr)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestWarmupKeys(t *testing.T) {
+	a, b := x.GalaxyAttr("warmup_a"), x.GalaxyAttr("warmup_b")
+	for _, attr := range []string{a, b} {
+		for uid := uint64(1); uid <= 3; uid++ {
+			addEdge(t, &pb.DirectedEdge{Entity: uid, Attr: attr, ValueId: 10 + uid},
+				getOrCreate(x.DataKey(attr, uid)))
+		}
+	}
+	addEdge(t, &pb.DirectedEdge{Entity: 1, Attr: b, ValueId: 1},
+		getOrCreate(x.IndexKey(b, "tok")))
+
+	warmup := func(attrs []string, budget int64) *WarmupResult {
+		txn := pstore.NewTransactionAt(math.MaxUint64, false)
+		defer txn.Discard()
+		res := &WarmupResult{}
+		require.NoError(t, warmupKeys(context.Background(), txn, attrs, budget, res))
+		return res
+	}
+
+	all := warmup([]string{a, b}, math.MaxInt64)
+	require.Equal(t, []string{a, b}, all.Warmed)
+	require.False(t, all.Truncated)
+	require.Greater(t, all.Bytes, int64(0))
+
+	// Reaching the budget exactly with the last key doesn't truncate the warmup.
+	res := warmup([]string{a, b}, all.Bytes)
+	require.Equal(t, []string{a, b}, res.Warmed)
+	require.False(t, res.Truncated)
+
+	// The last data key of warmup_b is out of the budget, so only warmup_a is warmed.
+	res = warmup([]string{a, b}, all.Bytes-1)
+	require.Equal(t, []string{a}, res.Warmed)
+	require.True(t, res.Truncated)
+
+	// The index key of warmup_b is read before any data key, so nothing is warmed.
+	res = warmup([]string{a, b}, 1)
+	require.Empty(t, res.Warmed)
+	require.True(t, res.Truncated)
+}
+
+func TestWarmupPredicates(t *testing.T) {
+	name, notServed := x.GalaxyAttr("name"), x.GalaxyAttr("friend_not_served")
+	res, err := WarmupPredicates(context.Background(), []string{name, notServed})
+	require.NoError(t, err)
+	require.Equal(t, []string{name}, res.Warmed)
+	require.Equal(t, []string{notServed}, res.Skipped)
+	require.False(t, res.Truncated)
+}
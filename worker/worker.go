@@ -59,7 +59,11 @@ func workerPort() int {
 func Init(ps *badger.DB) {
 	pstore = ps
 	// needs to be initialized after group config
-	limiter = rateLimiter{c: sync.NewCond(&sync.Mutex{}), max: int(x.WorkerConfig.Raft.GetInt64("pending-proposals"))}
+	limiter = rateLimiter{
+		c:    sync.NewCond(&sync.Mutex{}),
+		max:  int(x.WorkerConfig.Raft.GetInt64("pending-proposals")),
+		wait: x.WorkerConfig.Raft.GetDuration("pending-proposals-wait"),
+	}
 	go limiter.bleed()
 
 	grpcOpts := []grpc.ServerOption{
@@ -186,3 +190,24 @@ func UpdateLogDQLRequest(val bool) {
 func LogDQLRequestEnabled() bool {
 	return atomic.LoadInt32(&x.WorkerConfig.LogDQLRequest) > 0
 }
+
+// UpdateGrpcMaxMessageMb updates the maximum size of the messages of the gRPC API, so that it can
+// be raised e.g. while data is being imported, without restarting the alpha.
+func UpdateGrpcMaxMessageMb(mb int64) error {
+	glog.Infof("Updating grpcMaxMessageMb to %d", mb)
+	if mb < 0 {
+		return errors.Errorf("grpc-max-message-mb must be non-negative")
+	}
+	// Compare before shifting, so that a large value can't overflow past the check.
+	if mb > x.GrpcMaxSize>>20 {
+		return errors.Errorf("grpc-max-message-mb can't be more than %d", x.GrpcMaxSize>>20)
+	}
+	atomic.StoreInt64(&x.Config.GrpcMaxMessageSize, mb<<20)
+	return nil
+}
+
+// GrpcMaxMessageSize returns the maximum size in bytes of the messages of the gRPC API, or 0 if
+// they are only limited by x.GrpcMaxSize.
+func GrpcMaxMessageSize() int64 {
+	return atomic.LoadInt64(&x.Config.GrpcMaxMessageSize)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/dgraph-io/dgo/v210"
 	"github.com/dgraph-io/dgo/v210/protos/api"
 
+	"github.com/dgraph-io/dgraph/algo"
 	"github.com/dgraph-io/dgraph/posting"
 	"github.com/dgraph-io/dgraph/protos/pb"
 	"github.com/dgraph-io/dgraph/schema"
@@ -361,6 +362,50 @@ func TestProcessTaskIndex(t *testing.T) {
 	)
 }
 
+func TestCountRangeInTxn(t *testing.T) {
+	require.NoError(t, schema.ParseBytes([]byte("count_follower: [uid] @count ."), 1))
+	attr := x.GalaxyAttr("count_follower")
+	edge := func(uid, follower uint64) *pb.DirectedEdge {
+		return &pb.DirectedEdge{Entity: uid, ValueId: follower, ValueType: pb.Posting_UID,
+			Attr: attr, Op: pb.DirectedEdge_SET}
+	}
+	// 0x1 has one follower and 0x5 has two.
+	addEdge(t, edge(1, 2), getOrCreate(x.DataKey(attr, 1)))
+	addEdge(t, edge(5, 6), getOrCreate(x.DataKey(attr, 5)))
+	addEdge(t, edge(5, 7), getOrCreate(x.DataKey(attr, 5)))
+
+	// The transaction gives 0x1 two more followers, without committing them.
+	startTs := timestamp()
+	txn := posting.Oracle().RegisterStartTs(startTs)
+	defer posting.Oracle().ProcessDelta(&pb.OracleDelta{
+		Txns: []*pb.TxnStatus{{StartTs: startTs}}})
+	l, err := txn.Get(x.DataKey(attr, 1))
+	require.NoError(t, err)
+	for _, follower := range []uint64{3, 4} {
+		require.NoError(t, l.AddMutationWithIndex(context.Background(), edge(1, follower), txn))
+	}
+	txn.Update()
+
+	count := func(qs queryState, fn string, counts ...int64) []uint64 {
+		out := &pb.Result{}
+		require.NoError(t, qs.evaluate(countParams{readTs: startTs, counts: counts, attr: attr,
+			fn: fn}, out))
+		return algo.MergeSorted(out.UidMatrix).Uids
+	}
+	// The counts read by the transaction agree with its edges, 0x1 has three followers.
+	inTxn := queryState{cache: posting.Oracle().CacheAt(startTs)}
+	require.Equal(t, []uint64{1, 5}, count(inTxn, "ge", 2))
+	require.Equal(t, []uint64{5}, count(inTxn, "le", 2))
+	require.Equal(t, []uint64{1}, count(inTxn, "between", 3, 4))
+	require.Equal(t, []uint64{1}, count(inTxn, "gt", 2))
+
+	// The queries reading the committed data only see the committed counts.
+	committed := queryState{cache: posting.NoCache(startTs), committed: true}
+	require.Equal(t, []uint64{5}, count(committed, "ge", 2))
+	require.Equal(t, []uint64{1, 5}, count(committed, "le", 2))
+	require.Empty(t, count(committed, "between", 3, 4))
+}
+
 func TestMain(m *testing.M) {
 	x.Init()
 	posting.Config.CommitFraction = 0.10
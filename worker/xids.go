@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/types"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+// LookupXids returns the xids stored in dgraph.xid for the given uids in the namespace, using a
+// single task for all the uids. Duplicate uids are looked up once and uids which don't have an
+// xid are left out of the result.
+func LookupXids(ctx context.Context, ns uint64, uids []uint64) (map[uint64]string, error) {
+	res := make(map[uint64]string)
+	if len(uids) == 0 {
+		return res, nil
+	}
+
+	unique := uniqueUids(uids)
+	q := &pb.Query{
+		Attr:    x.NamespaceAttr(ns, "dgraph.xid"),
+		UidList: &pb.List{Uids: unique},
+		ReadTs:  State.GetTimestamp(true),
+	}
+	result, err := ProcessTaskOverNetwork(ctx, q)
+	switch {
+	case err == errNonExistentTablet:
+		// No node has an xid yet.
+		return res, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "while looking up xids")
+	}
+	return xidsOf(unique, result)
+}
+
+// uniqueUids returns the sorted list of unique uids the task expects.
+func uniqueUids(uids []uint64) []uint64 {
+	sorted := make([]uint64, len(uids))
+	copy(sorted, uids)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	unique := sorted[:1]
+	for _, uid := range sorted[1:] {
+		if uid != unique[len(unique)-1] {
+			unique = append(unique, uid)
+		}
+	}
+	return unique
+}
+
+// xidsOf returns the xids of the uids in the result of the task looking them up.
+func xidsOf(unique []uint64, result *pb.Result) (map[uint64]string, error) {
+	res := make(map[uint64]string)
+	for i, vl := range result.ValueMatrix {
+		if i >= len(unique) || len(vl.Values) == 0 {
+			continue
+		}
+		tv := vl.Values[0]
+		if bytes.Equal(tv.Val, x.Nilbyte) {
+			continue
+		}
+		val, err := types.Convert(types.Val{Tid: types.TypeID(tv.ValType), Value: tv.Val},
+			types.StringID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "while converting xid of %#x", unique[i])
+		}
+		res[unique[i]] = val.Value.(string)
+	}
+	return res, nil
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dgraph-io/dgraph/protos/pb"
+	"github.com/dgraph-io/dgraph/x"
+)
+
+func TestUniqueUids(t *testing.T) {
+	uids := []uint64{3, 1, 3, 2, 1}
+	require.Equal(t, []uint64{1, 2, 3}, uniqueUids(uids))
+	// The uids of the caller are left as they are.
+	require.Equal(t, []uint64{3, 1, 3, 2, 1}, uids)
+	require.Equal(t, []uint64{5}, uniqueUids([]uint64{5}))
+}
+
+func TestXidsOf(t *testing.T) {
+	value := func(typ pb.Posting_ValType, val []byte) *pb.ValueList {
+		return &pb.ValueList{Values: []*pb.TaskValue{{ValType: typ, Val: val}}}
+	}
+	unique := []uint64{1, 2, 3, 4}
+	xids, err := xidsOf(unique, &pb.Result{ValueMatrix: []*pb.ValueList{
+		value(pb.Posting_STRING, []byte("a")),
+		// The uids without an xid are left out.
+		{},
+		value(pb.Posting_DEFAULT, x.Nilbyte),
+		value(pb.Posting_DEFAULT, []byte("d")),
+	}})
+	require.NoError(t, err)
+	require.Equal(t, map[uint64]string{1: "a", 4: "d"}, xids)
+
+	_, err = xidsOf(unique[:1], &pb.Result{ValueMatrix: []*pb.ValueList{
+		value(pb.Posting_GEO, []byte("not a geo")),
+	}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "while converting xid of 0x1")
+}
+
+func TestLookupXidsWithoutUids(t *testing.T) {
+	xids, err := LookupXids(context.Background(), x.GalaxyNamespace, nil)
+	require.NoError(t, err)
+	require.Empty(t, xids)
+}
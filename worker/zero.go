@@ -58,3 +58,16 @@ func ApplyLicenseOverNetwork(ctx context.Context, req *pb.ApplyLicenseRequest) (
 	c := pb.NewZeroClient(pl.Get())
 	return c.ApplyLicense(ctx, req)
 }
+
+// UpdateMaintenanceOverNetwork sends a request to store the given maintenance policy to a zero
+// server. This operation doesn't necessarily require a zero leader.
+func UpdateMaintenanceOverNetwork(ctx context.Context, req *pb.MaintenancePolicy) (*pb.Status,
+	error) {
+	pl := groups().AnyServer(0)
+	if pl == nil {
+		return nil, conn.ErrNoConnection
+	}
+
+	c := pb.NewZeroClient(pl.Get())
+	return c.UpdateMaintenance(ctx, req)
+}
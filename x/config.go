@@ -38,13 +38,50 @@ type Options struct {
 	// mutations-nquad int - maximum number of nquads that can be inserted in a mutation request
 	// BlockDropAll bool - if set to true, the drop all operation will be rejected by the server.
 	// query-timeout duration - Maximum time after which a query execution will fail.
+	// query-memory-mb int64 - soft limit of the memory used by the queries being processed
+	// grpc-max-message-mb int64 - maximum size of the messages of the gRPC API
+	// default-first uint64 - maximum number of results of a root block without pagination
+	// predicates-per-mutation int - maximum number of new predicates created by a mutation
+	// upsert-match int - maximum number of uids matched by a variable of an upsert query
+	// namespace-query-rate int64 - maximum number of queries per second of a namespace
+	// namespace-mutation-rate int64 - maximum number of mutations per second of a namespace
+	// namespace-storage-mb int64 - on-disk size above which the writes of a namespace are blocked
+	// binary-value-kb int64 - maximum size of a value of a binary predicate
+	// binary-base64 bool - whether the values of binary predicates are in base64 in the text formats
 	Limit                *z.SuperFlag
 	LimitMutationsNquad  int
 	LimitQueryEdge       uint64
 	BlockClusterWideDrop bool
-	LimitNormalizeNode   int
-	QueryTimeout         time.Duration
-	MaxRetries           int64
+	// RequireExistingTargets rejects the uid edges pointing to nodes which don't have any data.
+	RequireExistingTargets bool
+	LimitNormalizeNode     int
+	QueryTimeout           time.Duration
+	MaxRetries             int64
+	LimitQueryMemory       int64
+	LimitDefaultFirst      uint64
+	// LimitPredicatesPerMutation is the maximum number of new predicates a mutation can create,
+	// 0 for no limit.
+	LimitPredicatesPerMutation int
+	// LimitUpsertMatch is the maximum number of uids a variable of an upsert query can match,
+	// 0 for no limit.
+	LimitUpsertMatch int
+	// LimitNamespaceQueryRate and LimitNamespaceMutationRate are the maximum number of requests
+	// per second of a namespace other than the galaxy one, 0 for no limit.
+	LimitNamespaceQueryRate    int64
+	LimitNamespaceMutationRate int64
+	// LimitNamespaceStorage is the on-disk size in bytes above which a namespace other than the
+	// galaxy one can't write any more data, 0 for no limit.
+	LimitNamespaceStorage int64
+	// LimitBinaryValue is the maximum size in bytes of a value of a binary predicate, 0 for no
+	// limit.
+	LimitBinaryValue int64
+	// BinaryBase64 is true if the values of binary predicates are given and returned in base64
+	// in the text formats, and stored decoded.
+	BinaryBase64 bool
+	// GrpcMaxMessageSize is the maximum size in bytes of the requests and the responses of the
+	// gRPC API, 0 for no limit other than GrpcMaxSize. It is accessed atomically, as it can be
+	// updated through the admin API.
+	GrpcMaxMessageSize int64
 
 	// GraphQL options:
 	//
@@ -110,6 +147,11 @@ type WorkerOptions struct {
 	HmacSecret SensitiveByteSlice
 	// AbortOlderThan tells Dgraph to discard transactions that are older than this duration.
 	AbortOlderThan time.Duration
+	// MvccRetention is the duration for which the old committed versions are kept before they
+	// can be garbage collected by Badger.
+	MvccRetention time.Duration
+	// Region is the region or zone this alpha runs in, set with --raft "region".
+	Region string
 	// ProposedGroupId will be used if there's a file in the p directory called group_id with the
 	// proposed group ID for this server.
 	ProposedGroupId uint32
@@ -27,12 +27,19 @@ var (
 	// functions. The value 0 means the draining-mode is disabled, and the value 1 means the
 	// mode is enabled
 	drainingMode uint32
+	// restoreMode is set while a restore proposal is applied. The data of the group is dropped
+	// before the backup is written back, and the indexes missing from the backup are rebuilt
+	// afterwards, so the requests are rejected until it's done rather than returning incomplete
+	// results.
+	restoreMode uint32
 
 	healthCheck     uint32
 	errHealth       = errors.New("Please retry again, server is not ready to accept requests")
 	errDrainingMode = errors.New("the server is in draining mode " +
 		"and client requests will only be allowed after exiting the mode " +
 		" by sending a GraphQL draining(enable: false) mutation to /admin")
+	errRestoreMode = errors.New("a restore is in progress on the server and client requests " +
+		"will be allowed once it's done, to avoid returning incomplete data. Please retry later")
 )
 
 // UpdateHealthStatus updates the server's health status so it can start accepting requests.
@@ -45,6 +52,11 @@ func UpdateDrainingMode(enable bool) {
 	setStatus(&drainingMode, enable)
 }
 
+// UpdateRestoreMode updates whether a restore is being applied by the server.
+func UpdateRestoreMode(enable bool) {
+	setStatus(&restoreMode, enable)
+}
+
 // HealthCheck returns whether the server is ready to accept requests or not
 // Load balancer would add the node to the endpoint once health check starts
 // returning true
@@ -52,6 +64,9 @@ func HealthCheck() error {
 	if atomic.LoadUint32(&healthCheck) == 0 {
 		return errHealth
 	}
+	if atomic.LoadUint32(&restoreMode) == 1 {
+		return errRestoreMode
+	}
 	if atomic.LoadUint32(&drainingMode) == 1 {
 		return errDrainingMode
 	}
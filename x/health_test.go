@@ -0,0 +1,39 @@
+/*
+ * Copyright 2022 Dgraph Labs, Inc. and Contributors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package x
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckRestoreMode(t *testing.T) {
+	UpdateHealthStatus(true)
+	defer UpdateHealthStatus(false)
+	require.NoError(t, HealthCheck())
+
+	UpdateDrainingMode(true)
+	UpdateRestoreMode(true)
+	require.Equal(t, errRestoreMode, HealthCheck())
+
+	UpdateRestoreMode(false)
+	require.Equal(t, errDrainingMode, HealthCheck())
+
+	UpdateDrainingMode(false)
+	require.NoError(t, HealthCheck())
+}
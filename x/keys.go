@@ -518,6 +518,13 @@ func PredicatePrefix(predicate string) []byte {
 	return buf
 }
 
+// PredicateSplitPrefix returns the prefix for the parts of the split lists of the predicate.
+func PredicateSplitPrefix(predicate string) []byte {
+	buf := PredicatePrefix(predicate)
+	buf[0] = ByteSplit
+	return buf
+}
+
 // DataPrefix returns the prefix for all data keys belonging to this namespace.
 func DataPrefix(ns uint64) []byte {
 	buf := make([]byte, 1+8)
@@ -671,6 +678,9 @@ var graphqlReservedPredicate = map[string]struct{}{
 	"dgraph.graphql.schema":  {},
 	"dgraph.drop.op":         {},
 	"dgraph.graphql.p_query": {},
+	"dgraph.uidset.name":     {},
+	"dgraph.uidset.members":  {},
+	"dgraph.uidset.expiry":   {},
 }
 
 // internalPredicateMap stores a set of Dgraph's internal predicate. An internal
@@ -687,6 +697,7 @@ var preDefinedTypeMap = map[string]struct{}{
 	"dgraph.type.Group":              {},
 	"dgraph.type.Rule":               {},
 	"dgraph.graphql.persisted_query": {},
+	"dgraph.uidset":                  {},
 }
 
 // IsGraphqlReservedPredicate returns true if it is the predicate is reserved by graphql.
@@ -798,3 +809,29 @@ func IsPreDefinedType(typ string) bool {
 func isReservedName(name string) bool {
 	return strings.HasPrefix(strings.ToLower(name), "dgraph.")
 }
+
+// ConflictKey returns the conflict key sent to Zero for the fingerprint of a key modified by a
+// transaction, in base 36. If readTs is set, the key is checked for conflicts from readTs
+// instead of the start ts of the transaction, which is only the case for the transactions in
+// read-committed mode, as their mutations can read the data later than their start ts. The key is
+// then fp-readTs, which only the Zeros of this release and the later ones can parse, so the Zeros
+// must be upgraded before the Alphas send any, see worker/isolation.go.
+func ConflictKey(fp, readTs uint64) string {
+	k := strconv.FormatUint(fp, 36)
+	if readTs == 0 {
+		return k
+	}
+	return k + "-" + strconv.FormatUint(readTs, 36)
+}
+
+// ParseConflictKey parses a key returned by ConflictKey.
+func ParseConflictKey(k string) (fp, readTs uint64, err error) {
+	if i := strings.IndexByte(k, '-'); i >= 0 {
+		if readTs, err = strconv.ParseUint(k[i+1:], 36, 64); err != nil {
+			return 0, 0, err
+		}
+		k = k[:i]
+	}
+	fp, err = strconv.ParseUint(k, 36, 64)
+	return fp, readTs, err
+}
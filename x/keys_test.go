@@ -275,3 +275,20 @@ func TestBadKeys(t *testing.T) {
 	_, err = Parse(key)
 	require.Error(t, err)
 }
+
+func TestConflictKey(t *testing.T) {
+	fp, readTs, err := ParseConflictKey(ConflictKey(12345, 0))
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), fp)
+	require.Zero(t, readTs)
+
+	k := ConflictKey(12345, 67)
+	require.Equal(t, "9ix-1v", k)
+	fp, readTs, err = ParseConflictKey(k)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12345), fp)
+	require.Equal(t, uint64(67), readTs)
+
+	_, _, err = ParseConflictKey("9ix-")
+	require.Error(t, err)
+}
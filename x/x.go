@@ -139,6 +139,18 @@ const (
 		"Content-Type, Content-Length, Accept-Encoding, Cache-Control, " +
 		"X-CSRF-Token, X-Auth-Token, X-Requested-With"
 	DgraphCostHeader = "Dgraph-TouchedUids"
+	// DgraphMissingUidsHeader holds the uids of the uid function which don't have any data, for
+	// the gRPC queries with strict-uids.
+	DgraphMissingUidsHeader = "Dgraph-MissingUids"
+	// DgraphMissingUidsTotalHeader holds the number of missing uids, only the first ones are
+	// listed in DgraphMissingUidsHeader.
+	DgraphMissingUidsTotalHeader = "Dgraph-MissingUids-Total"
+	// DgraphTruncatedHeader holds the root blocks whose results were limited by
+	// --limit default-first, for the gRPC queries.
+	DgraphTruncatedHeader = "Dgraph-Truncated"
+	// DgraphIncompleteGroupsHeader holds the groups which didn't reply within the group timeout
+	// of the gRPC queries, whose results are then incomplete.
+	DgraphIncompleteGroupsHeader = "Dgraph-Incomplete-Groups"
 
 	DgraphVersion = 2103
 )